@@ -0,0 +1,67 @@
+// encrypt-user-fields は平文で保存されているユーザーの健康データ（体重・身長・生年月日・性別）と
+// プロフィールの変更履歴（性別・生年月日の変更前後の値）を暗号化するコマンド
+//
+// 暗号化を有効にした後に一度実行し、既存の行を暗号化する
+// 鍵のローテーションでは FIELD_ENCRYPTION_CURRENT_KEY_ID を新しい鍵に切り替えた後（古い鍵も残したまま）に実行し、
+// 古い鍵で暗号化されたデータ鍵を新しい鍵で暗号化し直す。完了後は古い鍵を削除してよい
+//
+//	go run ./cmd/encrypt-user-fields
+package main
+
+import (
+	"context"
+	"os"
+
+	"caltrack/config"
+	"caltrack/infrastructure/fieldcrypto"
+	gormPersistence "caltrack/infrastructure/persistence/gorm"
+	"caltrack/pkg/logger"
+)
+
+// batchSize は1回に読み込む行の件数
+const batchSize = 500
+
+func main() {
+	// ロガー初期化
+	logger.Init()
+
+	// マイグレーションを実行（暗号化用の列を追加するため）
+	if err := config.RunMigrations(); err != nil {
+		logger.Error("マイグレーション失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// DB接続
+	database, err := config.NewDatabase()
+	if err != nil {
+		logger.Error("DB接続失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	encryptionConfig := config.NewFieldEncryptionConfig()
+	fieldEncryptor, err := fieldcrypto.NewEncryptorFromSettings(encryptionConfig.KeyFile, encryptionConfig.Keys, encryptionConfig.CurrentKeyID)
+	if err != nil {
+		logger.Error("暗号化鍵の読み込み失敗", "error", err.Error())
+		os.Exit(1)
+	}
+	if fieldEncryptor == nil {
+		logger.Error("暗号化鍵が設定されていません（FIELD_ENCRYPTION_KEY_FILE または FIELD_ENCRYPTION_KEYS）")
+		os.Exit(1)
+	}
+
+	userRepo := gormPersistence.NewGormUserRepository(database.DB, fieldEncryptor)
+	encrypted, rewrapped, err := userRepo.EncryptExistingRows(context.Background(), batchSize)
+	if err != nil {
+		logger.Error("ユーザー情報の暗号化に一部失敗しました", "encrypted", encrypted, "rewrapped", rewrapped, "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info("ユーザー情報を暗号化しました", "encrypted", encrypted, "rewrapped", rewrapped)
+
+	profileChangeRepo := gormPersistence.NewGormProfileChangeRepository(database.DB, fieldEncryptor)
+	encrypted, rewrapped, err = profileChangeRepo.EncryptExistingRows(context.Background(), batchSize)
+	if err != nil {
+		logger.Error("プロフィールの変更履歴の暗号化に一部失敗しました", "encrypted", encrypted, "rewrapped", rewrapped, "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info("プロフィールの変更履歴を暗号化しました", "encrypted", encrypted, "rewrapped", rewrapped)
+}
@@ -0,0 +1,62 @@
+// purge-deleted-accounts は退会の猶予期間が終わったユーザーと全データを削除するコマンド
+//
+// ACCOUNT_DELETION_GRACE_DAYS で猶予期間を設定している場合に、cronなどで定期的に実行する
+//
+//	go run ./cmd/purge-deleted-accounts
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"caltrack/config"
+	"caltrack/infrastructure/fieldcrypto"
+	gormPersistence "caltrack/infrastructure/persistence/gorm"
+	"caltrack/pkg/logger"
+	"caltrack/usecase"
+)
+
+func main() {
+	// ロガー初期化
+	logger.Init()
+
+	// マイグレーションを実行（account_deletionsテーブルを作成するため）
+	if err := config.RunMigrations(); err != nil {
+		logger.Error("マイグレーション失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// DB接続
+	database, err := config.NewDatabase()
+	if err != nil {
+		logger.Error("DB接続失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// 暗号化済みのユーザーを読み込めるよう、APIサーバーと同じ鍵を使う
+	encryptionConfig := config.NewFieldEncryptionConfig()
+	fieldEncryptor, err := fieldcrypto.NewEncryptorFromSettings(encryptionConfig.KeyFile, encryptionConfig.Keys, encryptionConfig.CurrentKeyID)
+	if err != nil {
+		logger.Error("暗号化鍵の読み込み失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	accountDeletionUsecase := usecase.NewAccountDeletionUsecase(
+		gormPersistence.NewGormUserRepository(database.DB, fieldEncryptor),
+		gormPersistence.NewGormSessionRepository(database.DB),
+		gormPersistence.NewGormPersonalAccessTokenRepository(database.DB),
+		gormPersistence.NewGormAccountDeletionRepository(database.DB),
+		gormPersistence.NewGormAccountPurgeRepository(database.DB),
+		gormPersistence.NewGormTransactionManager(database.DB),
+		usecase.NewAuditUsecase(gormPersistence.NewGormAuditEventRepository(database.DB)),
+		config.GetAccountDeletionGracePeriod(),
+	)
+
+	purged, err := accountDeletionUsecase.PurgeDue(context.Background(), time.Now())
+	if err != nil {
+		logger.Error("退会ユーザーの削除に一部失敗しました", "purged", purged, "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info("退会ユーザーを削除しました", "purged", purged)
+}
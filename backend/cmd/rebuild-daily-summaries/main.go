@@ -0,0 +1,67 @@
+// rebuild-daily-summaries は記録から日別サマリー（daily_summaries）を作り直すコマンド
+//
+// サマリー導入前の記録のバックフィルや、集計のずれを修正する際に実行する
+// 記録がある最初の日より前のサマリー（保存期間を過ぎて記録を削除した期間の集計）は削除せずに残す
+//
+//	go run ./cmd/rebuild-daily-summaries            # 全ユーザー
+//	go run ./cmd/rebuild-daily-summaries -user=<ID> # 指定ユーザーのみ
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"caltrack/config"
+	"caltrack/domain/vo"
+	gormPersistence "caltrack/infrastructure/persistence/gorm"
+	"caltrack/pkg/logger"
+	"caltrack/usecase"
+)
+
+func main() {
+	userIDStr := flag.String("user", "", "対象ユーザーID（省略時は全ユーザー）")
+	flag.Parse()
+
+	// ロガー初期化
+	logger.Init()
+
+	// マイグレーションを実行（daily_summariesテーブルを作成するため）
+	if err := config.RunMigrations(); err != nil {
+		logger.Error("マイグレーション失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// DB接続
+	database, err := config.NewDatabase()
+	if err != nil {
+		logger.Error("DB接続失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	dailySummaryRepo := gormPersistence.NewGormDailySummaryRepository(database.DB)
+	txManager := gormPersistence.NewGormTransactionManager(database.DB)
+	dailySummaryUsecase := usecase.NewDailySummaryUsecase(dailySummaryRepo, txManager)
+
+	ctx := context.Background()
+
+	if *userIDStr == "" {
+		if err := dailySummaryUsecase.RebuildAll(ctx); err != nil {
+			logger.Error("日別サマリーの再構築失敗", "error", err.Error())
+			os.Exit(1)
+		}
+		logger.Info("全ユーザーの日別サマリーを再構築しました")
+		return
+	}
+
+	userID, err := vo.ParseUserID(*userIDStr)
+	if err != nil {
+		logger.Error("ユーザーIDが不正です", "user_id", *userIDStr, "error", err.Error())
+		os.Exit(1)
+	}
+	if err := dailySummaryUsecase.RebuildByUserID(ctx, userID); err != nil {
+		logger.Error("日別サマリーの再構築失敗", "user_id", userID.String(), "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info("日別サマリーを再構築しました", "user_id", userID.String())
+}
@@ -0,0 +1,78 @@
+// set-user-role はユーザーの権限（user / admin）を変更するコマンド
+//
+// 管理者用APIを使える最初の管理者を登録する際などに、サーバー上で実行する
+// 変更は監査ログに記録される（操作者は記録されない）
+//
+//	go run ./cmd/set-user-role -email=<メールアドレス> -role=admin
+//	go run ./cmd/set-user-role -email=<メールアドレス> -role=user
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"caltrack/config"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/fieldcrypto"
+	gormPersistence "caltrack/infrastructure/persistence/gorm"
+	"caltrack/pkg/logger"
+	"caltrack/usecase"
+)
+
+func main() {
+	emailStr := flag.String("email", "", "対象ユーザーのメールアドレス")
+	roleStr := flag.String("role", "", "変更後の権限（user / admin）")
+	flag.Parse()
+
+	// ロガー初期化
+	logger.Init()
+
+	email, err := vo.NewEmail(*emailStr)
+	if err != nil {
+		logger.Error("メールアドレスが不正です", "error", err.Error())
+		os.Exit(1)
+	}
+	role, err := vo.NewUserRole(*roleStr)
+	if err != nil {
+		logger.Error("権限が不正です", "role", *roleStr, "error", err.Error())
+		os.Exit(1)
+	}
+
+	// マイグレーションを実行（usersテーブルにroleカラムを追加するため）
+	if err := config.RunMigrations(); err != nil {
+		logger.Error("マイグレーション失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// DB接続
+	database, err := config.NewDatabase()
+	if err != nil {
+		logger.Error("DB接続失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// 暗号化済みのユーザーを読み込めるよう、APIサーバーと同じ鍵を使う
+	encryptionConfig := config.NewFieldEncryptionConfig()
+	fieldEncryptor, err := fieldcrypto.NewEncryptorFromSettings(encryptionConfig.KeyFile, encryptionConfig.Keys, encryptionConfig.CurrentKeyID)
+	if err != nil {
+		logger.Error("暗号化鍵の読み込み失敗", "error", err.Error())
+		os.Exit(1)
+	}
+
+	adminUsecase := usecase.NewAdminUsecase(
+		gormPersistence.NewGormUserRepository(database.DB, fieldEncryptor),
+		gormPersistence.NewGormSessionRepository(database.DB),
+		gormPersistence.NewGormRecordRepository(database.DB),
+		gormPersistence.NewGormAIUsageRepository(database.DB),
+		gormPersistence.NewGormTransactionManager(database.DB),
+		usecase.NewAuditUsecase(gormPersistence.NewGormAuditEventRepository(database.DB)),
+	)
+
+	user, err := adminUsecase.ChangeRole(context.Background(), email, role)
+	if err != nil {
+		logger.Error("権限の変更失敗", "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info("権限を変更しました", "user_id", user.ID().String(), "role", user.Role().String())
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetAccountDeletionGracePeriod はアカウント削除の猶予期間を取得する
+// 環境変数 ACCOUNT_DELETION_GRACE_DAYS（日数）から取得し、未設定・不正な値の場合は0（即時削除）
+// 猶予期間中にログインすると削除は取り消される
+func GetAccountDeletionGracePeriod() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ACCOUNT_DELETION_GRACE_DAYS"))
+	if err != nil || days < 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,23 @@
+package config
+
+import "os"
+
+// FieldEncryptionConfig はユーザーの健康データ（生年月日・体重・身長・性別）の暗号化設定
+type FieldEncryptionConfig struct {
+	KeyFile      string
+	Keys         string
+	CurrentKeyID string
+}
+
+// NewFieldEncryptionConfig は環境変数から暗号化の鍵設定を読み込む
+// FIELD_ENCRYPTION_KEY_FILE: 鍵ファイル（JSON）のパス。設定されていれば FIELD_ENCRYPTION_KEYS より優先する
+// FIELD_ENCRYPTION_KEYS: "鍵ID:base64鍵" のカンマ区切り（鍵は32バイト）
+// FIELD_ENCRYPTION_CURRENT_KEY_ID: 新しく暗号化する際に使う鍵ID（未設定時は FIELD_ENCRYPTION_KEYS の先頭）
+// いずれも未設定の場合は暗号化せず平文で保存する
+func NewFieldEncryptionConfig() FieldEncryptionConfig {
+	return FieldEncryptionConfig{
+		KeyFile:      os.Getenv("FIELD_ENCRYPTION_KEY_FILE"),
+		Keys:         os.Getenv("FIELD_ENCRYPTION_KEYS"),
+		CurrentKeyID: os.Getenv("FIELD_ENCRYPTION_CURRENT_KEY_ID"),
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetentionJobInterval      = 24 * time.Hour
+	defaultSessionCleanupJobInterval = time.Hour
+	defaultAdviceCacheRetentionDays  = 30
+)
+
+// IsJobRunnerEnabled はAPIサーバーのプロセス内で定期実行ジョブを動かすかを取得する
+// 環境変数 JOB_RUNNER_ENABLED が "false" の場合は無効、未設定の場合は有効
+// 複数台構成でもロックにより同じジョブが同時に実行されることはない
+func IsJobRunnerEnabled() bool {
+	return os.Getenv("JOB_RUNNER_ENABLED") != "false"
+}
+
+// GetRetentionJobInterval はデータ保存期間ジョブの実行間隔を取得する
+// 環境変数 RETENTION_JOB_INTERVAL（例: "24h"）から取得し、未設定・不正な値の場合は24時間
+func GetRetentionJobInterval() time.Duration {
+	return getDurationEnv("RETENTION_JOB_INTERVAL", defaultRetentionJobInterval)
+}
+
+// GetSessionCleanupJobInterval は期限切れセッション削除ジョブの実行間隔を取得する
+// 環境変数 SESSION_CLEANUP_JOB_INTERVAL（例: "1h"）から取得し、未設定・不正な値の場合は1時間
+func GetSessionCleanupJobInterval() time.Duration {
+	return getDurationEnv("SESSION_CLEANUP_JOB_INTERVAL", defaultSessionCleanupJobInterval)
+}
+
+// GetRecordRetentionYears はデプロイ全体の記録の保存年数を取得する
+// 環境変数 RECORD_RETENTION_YEARS から取得し、未設定・不正な値の場合は0（無期限）
+// ユーザーがプライバシー設定でより短い保存期間を設定している場合はそちらが優先される
+func GetRecordRetentionYears() int {
+	years, err := strconv.Atoi(os.Getenv("RECORD_RETENTION_YEARS"))
+	if err != nil || years < 0 {
+		return 0
+	}
+	return years
+}
+
+// GetAdviceCacheRetentionDays はアドバイスキャッシュの保存日数を取得する
+// 環境変数 ADVICE_CACHE_RETENTION_DAYS から取得し、未設定・不正な値の場合は30日
+// 0の場合は削除しない
+func GetAdviceCacheRetentionDays() int {
+	value := os.Getenv("ADVICE_CACHE_RETENTION_DAYS")
+	if value == "" {
+		return defaultAdviceCacheRetentionDays
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return defaultAdviceCacheRetentionDays
+	}
+	return days
+}
+
+// getDurationEnv は環境変数から正の時間を取得する
+// 未設定・不正な値の場合はdefaultValueを返す
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
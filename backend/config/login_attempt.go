@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+// ログイン失敗回数のストア種別
+const (
+	LoginAttemptStoreDatabase = "database"
+	LoginAttemptStoreMemory   = "memory"
+)
+
+// GetLoginAttemptStoreType はログイン失敗回数を保持するストアの種別を取得する
+// 環境変数 LOGIN_ATTEMPT_STORE から取得し、未設定・不明な値の場合はDBを使用
+// 複数台構成ではプロセス間で回数を共有するためDBを使うこと
+func GetLoginAttemptStoreType() string {
+	if os.Getenv("LOGIN_ATTEMPT_STORE") == LoginAttemptStoreMemory {
+		return LoginAttemptStoreMemory
+	}
+	return LoginAttemptStoreDatabase
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	defaultSMTPPort   = 587
+	defaultMailFrom   = "CalTrack <no-reply@caltrack.local>"
+	defaultAppBaseURL = "http://localhost:5173"
+)
+
+// MailConfig はメール送信の設定を保持する構造体
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	// LogFile はSMTPを使わない場合にメールを書き出すファイル（空の場合はログに出力）
+	LogFile string
+}
+
+// NewMailConfig は環境変数からメール送信の設定を読み込む
+// SMTP_HOST が未設定の場合はSMTPを使わず、ファイルまたはログにメールを出力する
+func NewMailConfig() MailConfig {
+	port := defaultSMTPPort
+	if portStr := os.Getenv("SMTP_PORT"); portStr != "" {
+		if parsed, err := strconv.Atoi(portStr); err == nil {
+			port = parsed
+		}
+	}
+
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = defaultMailFrom
+	}
+
+	return MailConfig{
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     port,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		From:         from,
+		LogFile:      os.Getenv("MAIL_LOG_FILE"),
+	}
+}
+
+// SMTPEnabled はSMTPでメールを送信するかを返す
+func (c MailConfig) SMTPEnabled() bool {
+	return c.SMTPHost != ""
+}
+
+// GetAppBaseURL はメール本文のリンクに使うフロントエンドのURLを取得する
+// 環境変数 APP_BASE_URL から取得し、未設定の場合はデフォルト値を使用
+func GetAppBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultAppBaseURL
+	}
+	return baseURL
+}
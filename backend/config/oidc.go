@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+const (
+	googleIssuer             = "https://accounts.google.com"
+	defaultOIDCProviderName  = "oidc"
+	defaultOIDCRedirectBase  = "http://localhost:8080"
+	oidcCallbackPathTemplate = "/api/v1/auth/oidc/%s/callback"
+)
+
+// OIDCProviderConfig はOpenID Connectプロバイダー1件分の設定
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// NewOIDCProviderConfigs は環境変数から有効なOpenID Connectプロバイダーの設定を読み込む
+// OIDC_GOOGLE_CLIENT_ID / OIDC_GOOGLE_CLIENT_SECRET が設定されていればGoogleを有効にする
+// OIDC_ISSUER / OIDC_CLIENT_ID / OIDC_CLIENT_SECRET が設定されていれば、
+// OIDC_PROVIDER_NAME（未設定時は "oidc"）の名前で任意のプロバイダーをディスカバリーで有効にする
+// コールバックURLは OIDC_REDIRECT_BASE_URL（APIサーバーの公開URL）から組み立てる
+func NewOIDCProviderConfigs() []OIDCProviderConfig {
+	redirectBase := strings.TrimRight(getEnv("OIDC_REDIRECT_BASE_URL", defaultOIDCRedirectBase), "/")
+
+	var configs []OIDCProviderConfig
+	if clientID, secret := os.Getenv("OIDC_GOOGLE_CLIENT_ID"), os.Getenv("OIDC_GOOGLE_CLIENT_SECRET"); clientID != "" && secret != "" {
+		configs = append(configs, newOIDCProviderConfig("google", googleIssuer, clientID, secret, redirectBase))
+	}
+
+	issuer := os.Getenv("OIDC_ISSUER")
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	secret := os.Getenv("OIDC_CLIENT_SECRET")
+	if issuer != "" && clientID != "" {
+		name := getEnv("OIDC_PROVIDER_NAME", defaultOIDCProviderName)
+		configs = append(configs, newOIDCProviderConfig(name, issuer, clientID, secret, redirectBase))
+	}
+
+	for _, c := range configs {
+		log.Printf("OIDC provider enabled: %s (%s)", c.Name, c.Issuer)
+	}
+	return configs
+}
+
+func newOIDCProviderConfig(name, issuer, clientID, clientSecret, redirectBase string) OIDCProviderConfig {
+	return OIDCProviderConfig{
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectBase + fmt.Sprintf(oidcCallbackPathTemplate, name),
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
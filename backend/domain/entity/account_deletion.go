@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// AccountDeletion はアカウント削除の申請を表すEntity
+// 猶予期間中（scheduledAt より前）はログインで取り消せる
+// 削除の記録として、ユーザーのデータを削除した後も保持する（個人情報は持たない）
+type AccountDeletion struct {
+	id          vo.AccountDeletionID
+	userID      vo.UserID
+	requestedAt time.Time
+	scheduledAt time.Time
+	cancelledAt *time.Time
+	purgedAt    *time.Time
+	purgedRows  map[string]int64
+}
+
+// NewAccountDeletion は新しいアカウント削除の申請を生成する
+// gracePeriodが0の場合は即時に削除する申請になる
+func NewAccountDeletion(userID vo.UserID, gracePeriod time.Duration) *AccountDeletion {
+	now := time.Now()
+	return &AccountDeletion{
+		id:          vo.NewAccountDeletionID(),
+		userID:      userID,
+		requestedAt: now,
+		scheduledAt: now.Add(gracePeriod),
+	}
+}
+
+// ReconstructAccountDeletion はDBからAccountDeletionを復元する
+func ReconstructAccountDeletion(
+	idStr string,
+	userIDStr string,
+	requestedAt time.Time,
+	scheduledAt time.Time,
+	cancelledAt *time.Time,
+	purgedAt *time.Time,
+	purgedRows map[string]int64,
+) *AccountDeletion {
+	return &AccountDeletion{
+		id:          vo.ReconstructAccountDeletionID(idStr),
+		userID:      vo.ReconstructUserID(userIDStr),
+		requestedAt: requestedAt,
+		scheduledAt: scheduledAt,
+		cancelledAt: cancelledAt,
+		purgedAt:    purgedAt,
+		purgedRows:  purgedRows,
+	}
+}
+
+func (d *AccountDeletion) ID() vo.AccountDeletionID     { return d.id }
+func (d *AccountDeletion) UserID() vo.UserID            { return d.userID }
+func (d *AccountDeletion) RequestedAt() time.Time       { return d.requestedAt }
+func (d *AccountDeletion) ScheduledAt() time.Time       { return d.scheduledAt }
+func (d *AccountDeletion) CancelledAt() *time.Time      { return d.cancelledAt }
+func (d *AccountDeletion) PurgedAt() *time.Time         { return d.purgedAt }
+func (d *AccountDeletion) PurgedRows() map[string]int64 { return d.purgedRows }
+
+// IsPending は削除待ち（取り消し・削除のどちらも済んでいない）かを判定する
+func (d *AccountDeletion) IsPending() bool {
+	return d.cancelledAt == nil && d.purgedAt == nil
+}
+
+// IsDue は指定時刻の時点で削除を実行すべきかを判定する
+func (d *AccountDeletion) IsDue(now time.Time) bool {
+	return d.IsPending() && !now.Before(d.scheduledAt)
+}
+
+// Cancel は削除の申請を取り消す
+// 削除待ちでない場合は ErrAccountDeletionNotPending を返す
+func (d *AccountDeletion) Cancel() error {
+	if !d.IsPending() {
+		return domainErrors.ErrAccountDeletionNotPending
+	}
+	now := time.Now()
+	d.cancelledAt = &now
+	return nil
+}
+
+// MarkPurged はデータの削除が完了したことを記録する
+// purgedRowsにはテーブルごとの削除件数を渡す
+// 削除待ちでない場合は ErrAccountDeletionNotPending を返す
+func (d *AccountDeletion) MarkPurged(purgedRows map[string]int64) error {
+	if !d.IsPending() {
+		return domainErrors.ErrAccountDeletionNotPending
+	}
+	now := time.Now()
+	d.purgedAt = &now
+	d.purgedRows = purgedRows
+	return nil
+}
@@ -0,0 +1,115 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewAccountDeletion(t *testing.T) {
+	userID := vo.NewUserID()
+
+	deletion := entity.NewAccountDeletion(userID, 14*24*time.Hour)
+
+	if !deletion.UserID().Equals(userID) {
+		t.Errorf("UserID = %v, want %v", deletion.UserID(), userID)
+	}
+	if got := deletion.ScheduledAt().Sub(deletion.RequestedAt()); got != 14*24*time.Hour {
+		t.Errorf("ScheduledAt - RequestedAt = %v, want %v", got, 14*24*time.Hour)
+	}
+	if !deletion.IsPending() {
+		t.Error("new deletion should be pending")
+	}
+	if deletion.IsDue(time.Now()) {
+		t.Error("deletion within grace period should not be due")
+	}
+}
+
+func TestAccountDeletion_IsDue(t *testing.T) {
+	cancelledAt := time.Now().Add(-1 * time.Hour)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		scheduledAt time.Time
+		cancelledAt *time.Time
+		want        bool
+	}{
+		{"猶予期間を過ぎていれば削除対象", now.Add(-1 * time.Minute), nil, true},
+		{"猶予期間中は削除対象外", now.Add(time.Minute), nil, false},
+		{"取り消し済みは削除対象外", now.Add(-1 * time.Minute), &cancelledAt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletion := entity.ReconstructAccountDeletion(
+				vo.NewAccountDeletionID().String(),
+				vo.NewUserID().String(),
+				now.Add(-24*time.Hour),
+				tt.scheduledAt,
+				tt.cancelledAt,
+				nil,
+				nil,
+			)
+
+			if got := deletion.IsDue(now); got != tt.want {
+				t.Errorf("IsDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountDeletion_Cancel(t *testing.T) {
+	t.Run("削除待ちなら取り消せる", func(t *testing.T) {
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), time.Hour)
+
+		if err := deletion.Cancel(); err != nil {
+			t.Fatalf("Cancel() unexpected error: %v", err)
+		}
+		if deletion.CancelledAt() == nil {
+			t.Error("CancelledAt should be set")
+		}
+		if deletion.IsPending() {
+			t.Error("cancelled deletion should not be pending")
+		}
+	})
+
+	t.Run("取り消し済みはエラー", func(t *testing.T) {
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), time.Hour)
+		_ = deletion.Cancel()
+
+		if err := deletion.Cancel(); !errors.Is(err, domainErrors.ErrAccountDeletionNotPending) {
+			t.Errorf("Cancel() error = %v, want %v", err, domainErrors.ErrAccountDeletionNotPending)
+		}
+	})
+}
+
+func TestAccountDeletion_MarkPurged(t *testing.T) {
+	t.Run("削除件数を記録する", func(t *testing.T) {
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), 0)
+		rows := map[string]int64{"users": 1, "records": 3}
+
+		if err := deletion.MarkPurged(rows); err != nil {
+			t.Fatalf("MarkPurged() unexpected error: %v", err)
+		}
+		if deletion.PurgedAt() == nil {
+			t.Error("PurgedAt should be set")
+		}
+		if deletion.PurgedRows()["records"] != 3 {
+			t.Errorf("PurgedRows[records] = %v, want 3", deletion.PurgedRows()["records"])
+		}
+	})
+
+	t.Run("取り消し済みは削除できない", func(t *testing.T) {
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), time.Hour)
+		_ = deletion.Cancel()
+
+		if err := deletion.MarkPurged(map[string]int64{}); !errors.Is(err, domainErrors.ErrAccountDeletionNotPending) {
+			t.Errorf("MarkPurged() error = %v, want %v", err, domainErrors.ErrAccountDeletionNotPending)
+		}
+	})
+}
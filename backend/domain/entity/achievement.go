@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// 累計記録日数で獲得できるバッジ
+var loggedDaysMilestones = []struct {
+	days      int
+	badgeType string
+}{
+	{1, vo.BadgeTypeFirstRecord},
+	{7, vo.BadgeTypeLogged7Days},
+	{30, vo.BadgeTypeLogged30Days},
+	{100, vo.BadgeTypeLogged100Days},
+}
+
+// 連続達成日数で獲得できるバッジ
+var achievementStreakMilestones = []struct {
+	days      int
+	badgeType string
+}{
+	{7, vo.BadgeTypeAchievementStreak7},
+	{30, vo.BadgeTypeAchievementStreak30},
+}
+
+// DailyAchievement は1日分の記録・達成状況
+// 記録がある日のみを対象とする
+type DailyAchievement struct {
+	Date       time.Time // 対象日付
+	IsAchieved bool      // 目標カロリーを達成したか（vo.Calories.IsAchievedの判定結果）
+}
+
+// Streaks は連続記録・連続達成の集計結果
+type Streaks struct {
+	LoggedDays               int // 累計記録日数
+	CurrentLoggingStreak     int // 現在の連続記録日数
+	LongestLoggingStreak     int // 最長の連続記録日数
+	CurrentAchievementStreak int // 現在の連続達成日数
+	LongestAchievementStreak int // 最長の連続達成日数
+}
+
+// BadgeUnlock は獲得条件を満たしたバッジと、条件を満たした日付
+type BadgeUnlock struct {
+	BadgeType  vo.BadgeType
+	UnlockedAt time.Time
+}
+
+// CalculateStreaks は日別の達成状況から連続記録・連続達成日数を計算する
+// daysは日付の昇順であること
+// 当日はまだ記録・達成の途中のため、条件を満たしていなくても前日までのストリークを継続中とみなす
+func CalculateStreaks(days []DailyAchievement, today time.Time) Streaks {
+	logged := func(DailyAchievement) bool { return true }
+	achieved := func(day DailyAchievement) bool { return day.IsAchieved }
+
+	return Streaks{
+		LoggedDays:               len(days),
+		CurrentLoggingStreak:     currentStreak(days, today, logged),
+		LongestLoggingStreak:     longestStreak(days, logged),
+		CurrentAchievementStreak: currentStreak(days, today, achieved),
+		LongestAchievementStreak: longestStreak(days, achieved),
+	}
+}
+
+// EvaluateMilestoneBadges は日別の達成状況から獲得条件を満たしたマイルストーンバッジを返す
+// daysは日付の昇順であること。UnlockedAtには条件を初めて満たした日付が入る
+func EvaluateMilestoneBadges(days []DailyAchievement) []BadgeUnlock {
+	var unlocks []BadgeUnlock
+
+	// 累計記録日数
+	for _, milestone := range loggedDaysMilestones {
+		if len(days) >= milestone.days {
+			unlocks = append(unlocks, BadgeUnlock{
+				BadgeType:  vo.ReconstructBadgeType(milestone.badgeType),
+				UnlockedAt: days[milestone.days-1].Date,
+			})
+		}
+	}
+
+	// 連続達成日数（各マイルストーンに初めて到達した日付を記録）
+	reached := make(map[string]bool, len(achievementStreakMilestones))
+	run := 0
+	var last time.Time
+	for _, day := range days {
+		if !day.IsAchieved {
+			run = 0
+			continue
+		}
+		if run > 0 && isNextDate(last, day.Date) {
+			run++
+		} else {
+			run = 1
+		}
+		last = day.Date
+
+		for _, milestone := range achievementStreakMilestones {
+			if run >= milestone.days && !reached[milestone.badgeType] {
+				reached[milestone.badgeType] = true
+				unlocks = append(unlocks, BadgeUnlock{
+					BadgeType:  vo.ReconstructBadgeType(milestone.badgeType),
+					UnlockedAt: day.Date,
+				})
+			}
+		}
+	}
+
+	return unlocks
+}
+
+// currentStreak は当日（当日が未達なら前日）から遡って条件を満たす連続日数を返す
+func currentStreak(days []DailyAchievement, today time.Time, match func(DailyAchievement) bool) int {
+	byDate := make(map[string]DailyAchievement, len(days))
+	for _, day := range days {
+		byDate[day.Date.Format(dateLayout)] = day
+	}
+
+	date := today
+	if day, ok := byDate[date.Format(dateLayout)]; !ok || !match(day) {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	count := 0
+	for {
+		day, ok := byDate[date.Format(dateLayout)]
+		if !ok || !match(day) {
+			return count
+		}
+		count++
+		date = date.AddDate(0, 0, -1)
+	}
+}
+
+// longestStreak は条件を満たす最長の連続日数を返す
+func longestStreak(days []DailyAchievement, match func(DailyAchievement) bool) int {
+	longest := 0
+	run := 0
+	var last time.Time
+	for _, day := range days {
+		if !match(day) {
+			run = 0
+			continue
+		}
+		if run > 0 && isNextDate(last, day.Date) {
+			run++
+		} else {
+			run = 1
+		}
+		last = day.Date
+
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// isNextDate はnextがprevの翌日かどうかを返す
+func isNextDate(prev, next time.Time) bool {
+	return prev.AddDate(0, 0, 1).Format(dateLayout) == next.Format(dateLayout)
+}
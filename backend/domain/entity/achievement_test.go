@@ -0,0 +1,143 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// achievementDays は基準日からのオフセットと達成フラグから日別達成状況を生成する
+func achievementDays(base time.Time, offsets []int, achieved []bool) []entity.DailyAchievement {
+	days := make([]entity.DailyAchievement, len(offsets))
+	for i, offset := range offsets {
+		days[i] = entity.DailyAchievement{
+			Date:       base.AddDate(0, 0, offset),
+			IsAchieved: achieved[i],
+		}
+	}
+	return days
+}
+
+func TestCalculateStreaks(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		offsets  []int
+		achieved []bool
+		want     entity.Streaks
+	}{
+		{
+			name: "記録なしは全て0",
+			want: entity.Streaks{},
+		},
+		{
+			name:     "今日まで連続している場合は今日を含めて数える",
+			offsets:  []int{-2, -1, 0},
+			achieved: []bool{true, true, true},
+			want:     entity.Streaks{LoggedDays: 3, CurrentLoggingStreak: 3, LongestLoggingStreak: 3, CurrentAchievementStreak: 3, LongestAchievementStreak: 3},
+		},
+		{
+			name:     "今日が未記録でも昨日までの連続は継続中",
+			offsets:  []int{-2, -1},
+			achieved: []bool{true, true},
+			want:     entity.Streaks{LoggedDays: 2, CurrentLoggingStreak: 2, LongestLoggingStreak: 2, CurrentAchievementStreak: 2, LongestAchievementStreak: 2},
+		},
+		{
+			name:     "今日が未達成でも昨日までの連続達成は継続中",
+			offsets:  []int{-2, -1, 0},
+			achieved: []bool{true, true, false},
+			want:     entity.Streaks{LoggedDays: 3, CurrentLoggingStreak: 3, LongestLoggingStreak: 3, CurrentAchievementStreak: 2, LongestAchievementStreak: 2},
+		},
+		{
+			name:     "一昨日で途切れている場合は現在のストリークは0",
+			offsets:  []int{-4, -3, -2},
+			achieved: []bool{true, true, true},
+			want:     entity.Streaks{LoggedDays: 3, CurrentLoggingStreak: 0, LongestLoggingStreak: 3, CurrentAchievementStreak: 0, LongestAchievementStreak: 3},
+		},
+		{
+			name:     "未達成の日で連続達成が途切れる",
+			offsets:  []int{-5, -4, -3, -2, -1, 0},
+			achieved: []bool{true, true, true, false, true, true},
+			want:     entity.Streaks{LoggedDays: 6, CurrentLoggingStreak: 6, LongestLoggingStreak: 6, CurrentAchievementStreak: 2, LongestAchievementStreak: 3},
+		},
+		{
+			name:     "記録の空白で連続記録が途切れる",
+			offsets:  []int{-6, -5, -4, -1, 0},
+			achieved: []bool{false, false, false, false, false},
+			want:     entity.Streaks{LoggedDays: 5, CurrentLoggingStreak: 2, LongestLoggingStreak: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := achievementDays(today, tt.offsets, tt.achieved)
+			got := entity.CalculateStreaks(days, today)
+			if got != tt.want {
+				t.Errorf("CalculateStreaks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateMilestoneBadges(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系_記録がない場合はバッジなし", func(t *testing.T) {
+		unlocks := entity.EvaluateMilestoneBadges(nil)
+		if len(unlocks) != 0 {
+			t.Errorf("len(unlocks) = %d, want 0", len(unlocks))
+		}
+	})
+
+	t.Run("正常系_初回記録日に初めての記録バッジを獲得する", func(t *testing.T) {
+		days := achievementDays(base, []int{0, 3}, []bool{false, false})
+
+		unlocks := entity.EvaluateMilestoneBadges(days)
+		if len(unlocks) != 1 {
+			t.Fatalf("len(unlocks) = %d, want 1", len(unlocks))
+		}
+		if unlocks[0].BadgeType.String() != vo.BadgeTypeFirstRecord {
+			t.Errorf("BadgeType = %s, want %s", unlocks[0].BadgeType.String(), vo.BadgeTypeFirstRecord)
+		}
+		if !unlocks[0].UnlockedAt.Equal(base) {
+			t.Errorf("UnlockedAt = %v, want %v", unlocks[0].UnlockedAt, base)
+		}
+	})
+
+	t.Run("正常系_7日連続達成で連続達成バッジを獲得する", func(t *testing.T) {
+		offsets := []int{0, 1, 2, 3, 4, 5, 6}
+		achieved := []bool{true, true, true, true, true, true, true}
+		days := achievementDays(base, offsets, achieved)
+
+		unlocks := entity.EvaluateMilestoneBadges(days)
+
+		got := make(map[string]time.Time)
+		for _, unlock := range unlocks {
+			got[unlock.BadgeType.String()] = unlock.UnlockedAt
+		}
+		if len(got) != 3 {
+			t.Errorf("len(unlocks) = %d, want 3", len(got))
+		}
+		if at, ok := got[vo.BadgeTypeLogged7Days]; !ok || !at.Equal(base.AddDate(0, 0, 6)) {
+			t.Errorf("logged7Days UnlockedAt = %v, want %v", at, base.AddDate(0, 0, 6))
+		}
+		if at, ok := got[vo.BadgeTypeAchievementStreak7]; !ok || !at.Equal(base.AddDate(0, 0, 6)) {
+			t.Errorf("achievementStreak7 UnlockedAt = %v, want %v", at, base.AddDate(0, 0, 6))
+		}
+	})
+
+	t.Run("正常系_途切れた連続達成はバッジ対象外", func(t *testing.T) {
+		offsets := []int{0, 1, 2, 3, 4, 5, 6}
+		achieved := []bool{true, true, true, false, true, true, true}
+		days := achievementDays(base, offsets, achieved)
+
+		for _, unlock := range entity.EvaluateMilestoneBadges(days) {
+			if unlock.BadgeType.String() == vo.BadgeTypeAchievementStreak7 {
+				t.Error("achievementStreak7 should not be unlocked")
+			}
+		}
+	})
+}
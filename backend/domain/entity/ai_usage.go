@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// AIFeature はトークンを消費したAI機能の種別
+type AIFeature string
+
+const (
+	AIFeatureImageAnalysis   AIFeature = "image_analysis"
+	AIFeatureNutritionAdvice AIFeature = "nutrition_advice"
+	AIFeaturePfcEstimation   AIFeature = "pfc_estimation"
+)
+
+// AIUsage はAI機能1回の呼び出しで消費したトークン数を表すEntity
+// 追記のみで、ユーザーごとの集計にだけ使う
+type AIUsage struct {
+	id           vo.AIUsageID
+	userID       vo.UserID
+	feature      AIFeature
+	model        string
+	promptTokens int
+	outputTokens int
+	totalTokens  int
+	occurredAt   time.Time
+}
+
+// NewAIUsage は新しいAI使用履歴を生成する
+func NewAIUsage(userID vo.UserID, feature AIFeature, model string, promptTokens, outputTokens, totalTokens int, occurredAt time.Time) *AIUsage {
+	return &AIUsage{
+		id:           vo.NewAIUsageID(),
+		userID:       userID,
+		feature:      feature,
+		model:        model,
+		promptTokens: promptTokens,
+		outputTokens: outputTokens,
+		totalTokens:  totalTokens,
+		occurredAt:   occurredAt,
+	}
+}
+
+func (u *AIUsage) ID() vo.AIUsageID      { return u.id }
+func (u *AIUsage) UserID() vo.UserID     { return u.userID }
+func (u *AIUsage) Feature() AIFeature    { return u.feature }
+func (u *AIUsage) Model() string         { return u.model }
+func (u *AIUsage) PromptTokens() int     { return u.promptTokens }
+func (u *AIUsage) OutputTokens() int     { return u.outputTokens }
+func (u *AIUsage) TotalTokens() int      { return u.totalTokens }
+func (u *AIUsage) OccurredAt() time.Time { return u.occurredAt }
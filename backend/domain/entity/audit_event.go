@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// AuditAction は監査ログに記録する操作の種類
+type AuditAction string
+
+const (
+	AuditActionLogin                   AuditAction = "auth.login"
+	AuditActionLoginFailed             AuditAction = "auth.login_failed"
+	AuditActionLogout                  AuditAction = "auth.logout"
+	AuditActionProfileUpdate           AuditAction = "profile.update"
+	AuditActionPrivacyUpdate           AuditAction = "privacy.update"
+	AuditActionPasswordChange          AuditAction = "password.change"
+	AuditActionPasswordSet             AuditAction = "password.set"
+	AuditActionPasswordReset           AuditAction = "password.reset"
+	AuditActionEmailChangeRequest      AuditAction = "email.change_requested"
+	AuditActionEmailVerify             AuditAction = "email.verified"
+	AuditActionTwoFactorEnable         AuditAction = "two_factor.enabled"
+	AuditActionTwoFactorDisable        AuditAction = "two_factor.disabled"
+	AuditActionRecordCreate            AuditAction = "record.create"
+	AuditActionRecordImport            AuditAction = "record.import"
+	AuditActionDataExport              AuditAction = "data.export"
+	AuditActionAccountDeletionRequest  AuditAction = "account.deletion_requested"
+	AuditActionAccountDeletionComplete AuditAction = "account.deleted"
+	AuditActionAdminUserSearch         AuditAction = "admin.users_searched"
+	AuditActionAdminUsageView          AuditAction = "admin.usage_viewed"
+	AuditActionAdminUserDisable        AuditAction = "admin.user_disabled"
+	AuditActionAdminUserEnable         AuditAction = "admin.user_enabled"
+	AuditActionAdminSessionsRevoke     AuditAction = "admin.sessions_revoked"
+	AuditActionAdminRoleChange         AuditAction = "admin.role_changed"
+)
+
+// 監査イベントの対象の種類
+const (
+	AuditTargetUser    = "user"
+	AuditTargetSession = "session"
+	AuditTargetRecord  = "record"
+)
+
+// AuditEvent はセキュリティやデータに関わる操作の監査ログを表すEntity
+// 追記のみで、記録した内容は更新しない
+// - userID: イベントが属するアカウント（ユーザーが自分の履歴として参照する）。存在しないアカウントへのログイン失敗などではnil
+// - actorID: 操作したユーザー。システムによる操作ではnil
+// - diff: 変更内容（変更前後の値など）。健康データの値そのものは含めない
+type AuditEvent struct {
+	id         vo.AuditEventID
+	action     AuditAction
+	userID     *vo.UserID
+	actorID    *vo.UserID
+	targetType string
+	targetID   string
+	ipAddress  string
+	userAgent  string
+	diff       map[string]any
+	occurredAt time.Time
+}
+
+// NewAuditEvent は新しい監査イベントを生成する
+func NewAuditEvent(action AuditAction, userID, actorID *vo.UserID, targetType, targetID string, diff map[string]any) *AuditEvent {
+	return &AuditEvent{
+		id:         vo.NewAuditEventID(),
+		action:     action,
+		userID:     userID,
+		actorID:    actorID,
+		targetType: targetType,
+		targetID:   targetID,
+		diff:       diff,
+		occurredAt: time.Now(),
+	}
+}
+
+// NewUserAuditEvent はユーザー自身が自分のアカウントに対して行った操作の監査イベントを生成する
+func NewUserAuditEvent(action AuditAction, userID vo.UserID, targetType, targetID string, diff map[string]any) *AuditEvent {
+	return NewAuditEvent(action, &userID, &userID, targetType, targetID, diff)
+}
+
+// ReconstructAuditEvent はDBからAuditEventを復元する
+func ReconstructAuditEvent(
+	idStr string,
+	action string,
+	userIDStr *string,
+	actorIDStr *string,
+	targetType string,
+	targetID string,
+	ipAddress string,
+	userAgent string,
+	diff map[string]any,
+	occurredAt time.Time,
+) *AuditEvent {
+	return &AuditEvent{
+		id:         vo.ReconstructAuditEventID(idStr),
+		action:     AuditAction(action),
+		userID:     reconstructOptionalUserID(userIDStr),
+		actorID:    reconstructOptionalUserID(actorIDStr),
+		targetType: targetType,
+		targetID:   targetID,
+		ipAddress:  ipAddress,
+		userAgent:  userAgent,
+		diff:       diff,
+		occurredAt: occurredAt,
+	}
+}
+
+func reconstructOptionalUserID(s *string) *vo.UserID {
+	if s == nil {
+		return nil
+	}
+	id := vo.ReconstructUserID(*s)
+	return &id
+}
+
+func (e *AuditEvent) ID() vo.AuditEventID   { return e.id }
+func (e *AuditEvent) Action() AuditAction   { return e.action }
+func (e *AuditEvent) UserID() *vo.UserID    { return e.userID }
+func (e *AuditEvent) ActorID() *vo.UserID   { return e.actorID }
+func (e *AuditEvent) TargetType() string    { return e.targetType }
+func (e *AuditEvent) TargetID() string      { return e.targetID }
+func (e *AuditEvent) IPAddress() string     { return e.ipAddress }
+func (e *AuditEvent) UserAgent() string     { return e.userAgent }
+func (e *AuditEvent) Diff() map[string]any  { return e.diff }
+func (e *AuditEvent) OccurredAt() time.Time { return e.occurredAt }
+
+// AttachClient は操作を行ったクライアントのIPアドレスとUser-Agentを設定する
+func (e *AuditEvent) AttachClient(client SessionClient) {
+	e.ipAddress = client.IPAddress
+	e.userAgent = client.UserAgent
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// DailySummary はユーザーの1日分の記録集計（カロリー・PFC・記録件数）を表すEntity
+// 記録の作成・編集時に再集計されるため、統計系の参照はこの値を使用する
+type DailySummary struct {
+	userID      vo.UserID
+	date        time.Time
+	calories    vo.Calories
+	pfc         vo.Pfc
+	recordCount int
+	updatedAt   time.Time
+}
+
+// ReconstructDailySummary はDBからDailySummaryを復元する
+func ReconstructDailySummary(
+	userIDStr string,
+	date time.Time,
+	calories int,
+	protein, fat, carbs float64,
+	recordCount int,
+	updatedAt time.Time,
+) *DailySummary {
+	return &DailySummary{
+		userID:      vo.ReconstructUserID(userIDStr),
+		date:        date,
+		calories:    vo.ReconstructCalories(calories),
+		pfc:         vo.NewPfc(protein, fat, carbs),
+		recordCount: recordCount,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (s *DailySummary) UserID() vo.UserID     { return s.userID }
+func (s *DailySummary) Date() time.Time       { return s.date }
+func (s *DailySummary) Calories() vo.Calories { return s.calories }
+func (s *DailySummary) Pfc() vo.Pfc           { return s.pfc }
+func (s *DailySummary) RecordCount() int      { return s.recordCount }
+func (s *DailySummary) UpdatedAt() time.Time  { return s.updatedAt }
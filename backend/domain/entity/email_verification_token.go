@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// メールアドレス確認トークンの有効期間
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+// EmailVerificationToken はメールアドレスの所有確認用の使い捨てトークンを表すEntity
+// 登録時は現在のアドレス、メールアドレス変更時は変更先のアドレスを確認対象として保持する
+type EmailVerificationToken struct {
+	id        vo.EmailVerificationTokenID
+	userID    vo.UserID
+	email     vo.Email
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewEmailVerificationToken は確認対象のメールアドレスに対するトークンを生成する
+// 戻り値のOneTimeTokenはメール送信用の平文で、保存されない
+func NewEmailVerificationToken(userID vo.UserID, email vo.Email) (*EmailVerificationToken, vo.OneTimeToken, error) {
+	token, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+
+	now := time.Now()
+	return &EmailVerificationToken{
+		id:        vo.NewEmailVerificationTokenID(),
+		userID:    userID,
+		email:     email,
+		tokenHash: token.Hash(),
+		expiresAt: now.Add(EmailVerificationTokenTTL),
+		createdAt: now,
+	}, token, nil
+}
+
+// ReconstructEmailVerificationToken はDBからEmailVerificationTokenを復元する
+func ReconstructEmailVerificationToken(
+	idStr string,
+	userIDStr string,
+	emailStr string,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) (*EmailVerificationToken, error) {
+	email, err := vo.NewEmail(emailStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailVerificationToken{
+		id:        vo.ReconstructEmailVerificationTokenID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		email:     email,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}, nil
+}
+
+func (t *EmailVerificationToken) ID() vo.EmailVerificationTokenID { return t.id }
+func (t *EmailVerificationToken) UserID() vo.UserID               { return t.userID }
+func (t *EmailVerificationToken) Email() vo.Email                 { return t.email }
+func (t *EmailVerificationToken) TokenHash() string               { return t.tokenHash }
+func (t *EmailVerificationToken) ExpiresAt() time.Time            { return t.expiresAt }
+func (t *EmailVerificationToken) UsedAt() *time.Time              { return t.usedAt }
+func (t *EmailVerificationToken) CreatedAt() time.Time            { return t.createdAt }
+
+// Use はトークンを使用済みにする
+// 有効期限切れ・使用済みの場合は ErrEmailVerificationTokenInvalid を返す
+func (t *EmailVerificationToken) Use() error {
+	now := time.Now()
+	if t.usedAt != nil || now.After(t.expiresAt) {
+		return domainErrors.ErrEmailVerificationTokenInvalid
+	}
+	t.usedAt = &now
+	return nil
+}
@@ -0,0 +1,90 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewEmailVerificationToken(t *testing.T) {
+	userID := vo.NewUserID()
+	email, _ := vo.NewEmail("new@example.com")
+
+	token, raw, err := entity.NewEmailVerificationToken(userID, email)
+
+	if err != nil {
+		t.Fatalf("NewEmailVerificationToken() unexpected error: %v", err)
+	}
+	if !token.UserID().Equals(userID) {
+		t.Errorf("UserID = %v, want %v", token.UserID(), userID)
+	}
+	if !token.Email().Equals(email) {
+		t.Errorf("Email = %v, want %v", token.Email(), email)
+	}
+	if token.TokenHash() != raw.Hash() {
+		t.Error("TokenHash should be the hash of the raw token")
+	}
+	if got := token.ExpiresAt().Sub(token.CreatedAt()); got != entity.EmailVerificationTokenTTL {
+		t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, entity.EmailVerificationTokenTTL)
+	}
+}
+
+func TestReconstructEmailVerificationToken_InvalidEmail(t *testing.T) {
+	_, err := entity.ReconstructEmailVerificationToken(
+		vo.NewEmailVerificationTokenID().String(),
+		vo.NewUserID().String(),
+		"invalid",
+		"hash",
+		time.Now().Add(time.Hour),
+		nil,
+		time.Now(),
+	)
+
+	if err != domainErrors.ErrInvalidEmailFormat {
+		t.Errorf("ReconstructEmailVerificationToken() error = %v, want ErrInvalidEmailFormat", err)
+	}
+}
+
+func TestEmailVerificationToken_Use(t *testing.T) {
+	usedAt := time.Now().Add(-1 * time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		usedAt    *time.Time
+		wantErr   error
+	}{
+		{"有効期限内かつ未使用なら使用できる", time.Now().Add(time.Hour), nil, nil},
+		{"有効期限切れはエラー", time.Now().Add(-1 * time.Minute), nil, domainErrors.ErrEmailVerificationTokenInvalid},
+		{"使用済みはエラー", time.Now().Add(time.Hour), &usedAt, domainErrors.ErrEmailVerificationTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := entity.ReconstructEmailVerificationToken(
+				vo.NewEmailVerificationTokenID().String(),
+				vo.NewUserID().String(),
+				"test@example.com",
+				"hash",
+				tt.expiresAt,
+				tt.usedAt,
+				time.Now().Add(-time.Hour),
+			)
+			if err != nil {
+				t.Fatalf("ReconstructEmailVerificationToken() unexpected error: %v", err)
+			}
+
+			err = token.Use()
+
+			if err != tt.wantErr {
+				t.Errorf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && token.UsedAt() == nil {
+				t.Error("UsedAt should be set after Use()")
+			}
+		})
+	}
+}
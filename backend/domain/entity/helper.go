@@ -7,3 +7,6 @@ func appendIfErr(errs []error, err error) []error {
 	}
 	return errs
 }
+
+// dateLayout は日付単位の比較に使用するレイアウト
+const dateLayout = "2006-01-02"
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// JobRunStatus はジョブの実行状態
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+// JobRun は定期実行ジョブの1回分の実行履歴を表すEntity
+// - owner: ジョブを実行したプロセス（複数台構成でどのプロセスが実行したかを追うため）
+// - result: 処理件数（テーブル名ごとの削除件数など）
+type JobRun struct {
+	id           vo.JobRunID
+	jobName      string
+	owner        string
+	status       JobRunStatus
+	startedAt    time.Time
+	finishedAt   *time.Time
+	result       map[string]int64
+	errorMessage string
+}
+
+// NewJobRun は実行中のジョブの実行履歴を生成する
+func NewJobRun(jobName, owner string, startedAt time.Time) *JobRun {
+	return &JobRun{
+		id:        vo.NewJobRunID(),
+		jobName:   jobName,
+		owner:     owner,
+		status:    JobRunStatusRunning,
+		startedAt: startedAt,
+	}
+}
+
+// ReconstructJobRun はDBからJobRunを復元する
+func ReconstructJobRun(
+	idStr string,
+	jobName string,
+	owner string,
+	status string,
+	startedAt time.Time,
+	finishedAt *time.Time,
+	result map[string]int64,
+	errorMessage string,
+) *JobRun {
+	return &JobRun{
+		id:           vo.ReconstructJobRunID(idStr),
+		jobName:      jobName,
+		owner:        owner,
+		status:       JobRunStatus(status),
+		startedAt:    startedAt,
+		finishedAt:   finishedAt,
+		result:       result,
+		errorMessage: errorMessage,
+	}
+}
+
+func (r *JobRun) ID() vo.JobRunID          { return r.id }
+func (r *JobRun) JobName() string          { return r.jobName }
+func (r *JobRun) Owner() string            { return r.owner }
+func (r *JobRun) Status() JobRunStatus     { return r.status }
+func (r *JobRun) StartedAt() time.Time     { return r.startedAt }
+func (r *JobRun) FinishedAt() *time.Time   { return r.finishedAt }
+func (r *JobRun) Result() map[string]int64 { return r.result }
+func (r *JobRun) ErrorMessage() string     { return r.errorMessage }
+
+// Succeed はジョブが成功したことを記録する
+func (r *JobRun) Succeed(result map[string]int64) {
+	r.finish(JobRunStatusSucceeded, result, "")
+}
+
+// Fail はジョブが失敗したことを記録する
+// 途中まで処理した件数がある場合はresultに渡す
+func (r *JobRun) Fail(result map[string]int64, err error) {
+	r.finish(JobRunStatusFailed, result, err.Error())
+}
+
+func (r *JobRun) finish(status JobRunStatus, result map[string]int64, errorMessage string) {
+	now := time.Now()
+	r.status = status
+	r.finishedAt = &now
+	r.result = result
+	r.errorMessage = errorMessage
+}
@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+func TestNewJobRun(t *testing.T) {
+	startedAt := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	run := entity.NewJobRun("retention", "host:1", startedAt)
+
+	if run.Status() != entity.JobRunStatusRunning {
+		t.Errorf("Status = %v, want %v", run.Status(), entity.JobRunStatusRunning)
+	}
+	if !run.StartedAt().Equal(startedAt) {
+		t.Errorf("StartedAt = %v, want %v", run.StartedAt(), startedAt)
+	}
+	if run.FinishedAt() != nil {
+		t.Error("FinishedAt should be nil while running")
+	}
+}
+
+func TestJobRun_Succeed(t *testing.T) {
+	run := entity.NewJobRun("retention", "host:1", time.Now())
+
+	run.Succeed(map[string]int64{"records": 3})
+
+	if run.Status() != entity.JobRunStatusSucceeded {
+		t.Errorf("Status = %v, want %v", run.Status(), entity.JobRunStatusSucceeded)
+	}
+	if run.FinishedAt() == nil {
+		t.Error("FinishedAt should be set")
+	}
+	if run.Result()["records"] != 3 {
+		t.Errorf("Result[records] = %d, want 3", run.Result()["records"])
+	}
+	if run.ErrorMessage() != "" {
+		t.Errorf("ErrorMessage = %q, want empty", run.ErrorMessage())
+	}
+}
+
+func TestJobRun_Fail(t *testing.T) {
+	run := entity.NewJobRun("retention", "host:1", time.Now())
+
+	run.Fail(map[string]int64{"records": 1}, errors.New("db error"))
+
+	if run.Status() != entity.JobRunStatusFailed {
+		t.Errorf("Status = %v, want %v", run.Status(), entity.JobRunStatusFailed)
+	}
+	if run.FinishedAt() == nil {
+		t.Error("FinishedAt should be set")
+	}
+	if run.Result()["records"] != 1 {
+		t.Errorf("Result[records] = %d, want 1", run.Result()["records"])
+	}
+	if run.ErrorMessage() != "db error" {
+		t.Errorf("ErrorMessage = %q, want %q", run.ErrorMessage(), "db error")
+	}
+}
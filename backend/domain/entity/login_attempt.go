@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// ログイン試行キーの種別を表すプレフィックス
+const (
+	loginAttemptKeyPrefixEmail = "email:"
+	loginAttemptKeyPrefixIP    = "ip:"
+)
+
+// LoginAttempt はあるキー（メールアドレスまたはIPアドレス）に対するログイン失敗の状況を表す
+// 待ち時間はLoginThrottlePolicyで失敗回数と最終失敗日時から算出するため、ここでは保持しない
+type LoginAttempt struct {
+	key          string
+	failures     int
+	lastFailedAt time.Time
+}
+
+// ReconstructLoginAttempt はストアからLoginAttemptを復元する
+func ReconstructLoginAttempt(key string, failures int, lastFailedAt time.Time) *LoginAttempt {
+	return &LoginAttempt{
+		key:          key,
+		failures:     failures,
+		lastFailedAt: lastFailedAt,
+	}
+}
+
+func (a *LoginAttempt) Key() string             { return a.key }
+func (a *LoginAttempt) Failures() int           { return a.failures }
+func (a *LoginAttempt) LastFailedAt() time.Time { return a.lastFailedAt }
+
+// EmailLoginAttemptKey はメールアドレス単位の試行キーを返す
+func EmailLoginAttemptKey(email vo.Email) string {
+	return loginAttemptKeyPrefixEmail + email.String()
+}
+
+// IPLoginAttemptKey はIPアドレス単位の試行キーを返す
+func IPLoginAttemptKey(ipAddress string) string {
+	return loginAttemptKeyPrefixIP + ipAddress
+}
+
+// LoginThrottlePolicy はログイン失敗時の待ち時間とロックアウトの条件を表す
+//
+// 失敗回数に応じた待ち時間:
+//
+//	FreeAttempts 回まで: 待ち時間なし
+//	LockoutThreshold 回未満: BaseDelay × 2^(失敗回数 - FreeAttempts - 1)（LockoutDuration が上限）
+//	LockoutThreshold 回以上: LockoutDuration（一時ロックアウト）
+//
+// 最後の失敗から ResetAfter が経過すると失敗回数はリセットされる
+type LoginThrottlePolicy struct {
+	FreeAttempts     int
+	LockoutThreshold int
+	BaseDelay        time.Duration
+	LockoutDuration  time.Duration
+	ResetAfter       time.Duration
+}
+
+// メールアドレス単位の制限（特定アカウントへの総当たり対策）
+var DefaultEmailLoginThrottlePolicy = LoginThrottlePolicy{
+	FreeAttempts:     3,
+	LockoutThreshold: 10,
+	BaseDelay:        time.Second,
+	LockoutDuration:  15 * time.Minute,
+	ResetAfter:       time.Hour,
+}
+
+// IPアドレス単位の制限（多数のアカウントへのリスト型攻撃対策）
+// NAT配下の複数ユーザーを考慮してメールアドレス単位より緩くする
+var DefaultIPLoginThrottlePolicy = LoginThrottlePolicy{
+	FreeAttempts:     10,
+	LockoutThreshold: 50,
+	BaseDelay:        time.Second,
+	LockoutDuration:  15 * time.Minute,
+	ResetAfter:       time.Hour,
+}
+
+// Delay は失敗回数に対する待ち時間を返す
+func (p LoginThrottlePolicy) Delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	if failures >= p.LockoutThreshold {
+		return p.LockoutDuration
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= p.LockoutDuration {
+			return p.LockoutDuration
+		}
+	}
+	return delay
+}
+
+// IsExpired は最後の失敗から ResetAfter が経過し、失敗回数がリセット対象かを返す
+func (p LoginThrottlePolicy) IsExpired(attempt *LoginAttempt, now time.Time) bool {
+	return now.Sub(attempt.lastFailedAt) >= p.ResetAfter
+}
+
+// RetryAfter は次にログインを試行できるまでの残り時間を返す（試行可能な場合は0）
+func (p LoginThrottlePolicy) RetryAfter(attempt *LoginAttempt, now time.Time) time.Duration {
+	if attempt == nil || p.IsExpired(attempt, now) {
+		return 0
+	}
+
+	remaining := attempt.lastFailedAt.Add(p.Delay(attempt.failures)).Sub(now)
+	if remaining <= 0 {
+		return 0
+	}
+	return remaining
+}
@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+func TestLoginThrottlePolicy_Delay(t *testing.T) {
+	policy := entity.LoginThrottlePolicy{
+		FreeAttempts:     3,
+		LockoutThreshold: 10,
+		BaseDelay:        time.Second,
+		LockoutDuration:  15 * time.Minute,
+		ResetAfter:       time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{"失敗なし", 0, 0},
+		{"無料回数ちょうど", 3, 0},
+		{"無料回数を1回超過", 4, time.Second},
+		{"無料回数を2回超過", 5, 2 * time.Second},
+		{"しきい値の1回手前", 9, 32 * time.Second},
+		{"しきい値でロックアウト", 10, 15 * time.Minute},
+		{"しきい値超過でもロックアウト", 20, 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Delay(tt.failures); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("待ち時間はLockoutDurationを上限とする", func(t *testing.T) {
+		p := policy
+		p.LockoutThreshold = 100
+		if got := p.Delay(50); got != p.LockoutDuration {
+			t.Errorf("Delay(50) = %v, want %v", got, p.LockoutDuration)
+		}
+	})
+}
+
+func TestLoginThrottlePolicy_RetryAfter(t *testing.T) {
+	policy := entity.DefaultEmailLoginThrottlePolicy
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("記録なしは0", func(t *testing.T) {
+		if got := policy.RetryAfter(nil, now); got != 0 {
+			t.Errorf("RetryAfter() = %v, want 0", got)
+		}
+	})
+
+	t.Run("待ち時間の残りを返す", func(t *testing.T) {
+		attempt := entity.ReconstructLoginAttempt("email:test@example.com", 5, now.Add(-500*time.Millisecond))
+		if got := policy.RetryAfter(attempt, now); got != 1500*time.Millisecond {
+			t.Errorf("RetryAfter() = %v, want %v", got, 1500*time.Millisecond)
+		}
+	})
+
+	t.Run("待ち時間経過後は0", func(t *testing.T) {
+		attempt := entity.ReconstructLoginAttempt("email:test@example.com", 5, now.Add(-3*time.Second))
+		if got := policy.RetryAfter(attempt, now); got != 0 {
+			t.Errorf("RetryAfter() = %v, want 0", got)
+		}
+	})
+
+	t.Run("リセット期間経過後はロックアウト中でも0", func(t *testing.T) {
+		attempt := entity.ReconstructLoginAttempt("email:test@example.com", 10, now.Add(-policy.ResetAfter))
+		if !policy.IsExpired(attempt, now) {
+			t.Error("IsExpired() should be true")
+		}
+		if got := policy.RetryAfter(attempt, now); got != 0 {
+			t.Errorf("RetryAfter() = %v, want 0", got)
+		}
+	})
+}
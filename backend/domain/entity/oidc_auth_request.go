@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// OpenID Connectの認可リクエストの有効期間
+const OIDCAuthRequestTTL = 10 * time.Minute
+
+// OIDCAuthRequest はOpenID Connectの認可コードフローの開始から完了までの状態を表すEntity
+// state はブラウザにのみ渡してハッシュを保持し、nonce とPKCEの code_verifier はトークン検証のために保持する
+type OIDCAuthRequest struct {
+	id           vo.OIDCAuthRequestID
+	provider     string
+	stateHash    string
+	nonce        string
+	codeVerifier string
+	expiresAt    time.Time
+	usedAt       *time.Time
+	createdAt    time.Time
+}
+
+// NewOIDCAuthRequest は新しい認可リクエストを生成する
+// 戻り値のOneTimeTokenは認可エンドポイントに渡す state の平文で、保存されない
+func NewOIDCAuthRequest(provider string) (*OIDCAuthRequest, vo.OneTimeToken, error) {
+	state, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+	nonce, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+	// RFC 7636 の code_verifier（43〜128文字の英数字と -._~）を満たす
+	codeVerifier, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+
+	now := time.Now()
+	return &OIDCAuthRequest{
+		id:           vo.NewOIDCAuthRequestID(),
+		provider:     provider,
+		stateHash:    state.Hash(),
+		nonce:        nonce.String(),
+		codeVerifier: codeVerifier.String(),
+		expiresAt:    now.Add(OIDCAuthRequestTTL),
+		createdAt:    now,
+	}, state, nil
+}
+
+// ReconstructOIDCAuthRequest はDBからOIDCAuthRequestを復元する
+func ReconstructOIDCAuthRequest(
+	idStr string,
+	provider string,
+	stateHash string,
+	nonce string,
+	codeVerifier string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *OIDCAuthRequest {
+	return &OIDCAuthRequest{
+		id:           vo.ReconstructOIDCAuthRequestID(idStr),
+		provider:     provider,
+		stateHash:    stateHash,
+		nonce:        nonce,
+		codeVerifier: codeVerifier,
+		expiresAt:    expiresAt,
+		usedAt:       usedAt,
+		createdAt:    createdAt,
+	}
+}
+
+func (r *OIDCAuthRequest) ID() vo.OIDCAuthRequestID { return r.id }
+func (r *OIDCAuthRequest) Provider() string         { return r.provider }
+func (r *OIDCAuthRequest) StateHash() string        { return r.stateHash }
+func (r *OIDCAuthRequest) Nonce() string            { return r.nonce }
+func (r *OIDCAuthRequest) CodeVerifier() string     { return r.codeVerifier }
+func (r *OIDCAuthRequest) ExpiresAt() time.Time     { return r.expiresAt }
+func (r *OIDCAuthRequest) UsedAt() *time.Time       { return r.usedAt }
+func (r *OIDCAuthRequest) CreatedAt() time.Time     { return r.createdAt }
+
+// CodeChallenge はPKCEの code_challenge（S256）を返す
+func (r *OIDCAuthRequest) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(r.codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Use は認可リクエストを使用済みにする
+// プロバイダーが異なる・有効期限切れ・使用済みの場合は ErrOIDCStateInvalid を返す
+func (r *OIDCAuthRequest) Use(provider string) error {
+	now := time.Now()
+	if r.provider != provider || r.usedAt != nil || now.After(r.expiresAt) {
+		return domainErrors.ErrOIDCStateInvalid
+	}
+	r.usedAt = &now
+	return nil
+}
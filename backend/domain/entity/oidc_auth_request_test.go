@@ -0,0 +1,97 @@
+package entity_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewOIDCAuthRequest(t *testing.T) {
+	req, state, err := entity.NewOIDCAuthRequest("google")
+
+	if err != nil {
+		t.Fatalf("NewOIDCAuthRequest() unexpected error: %v", err)
+	}
+	if req.Provider() != "google" {
+		t.Errorf("Provider = %v, want google", req.Provider())
+	}
+	if req.StateHash() != state.Hash() {
+		t.Error("StateHash should be the hash of the raw state")
+	}
+	if req.Nonce() == "" || req.CodeVerifier() == "" {
+		t.Error("Nonce and CodeVerifier should be generated")
+	}
+	if req.Nonce() == req.CodeVerifier() || req.Nonce() == state.String() {
+		t.Error("state, nonce and code verifier should be independent values")
+	}
+	if got := req.ExpiresAt().Sub(req.CreatedAt()); got != entity.OIDCAuthRequestTTL {
+		t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, entity.OIDCAuthRequestTTL)
+	}
+}
+
+func TestOIDCAuthRequest_CodeChallenge(t *testing.T) {
+	// RFC 7636 Appendix B のテストベクタ
+	req := entity.ReconstructOIDCAuthRequest(
+		vo.NewOIDCAuthRequestID().String(),
+		"google",
+		"hash",
+		"nonce",
+		"dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+		time.Now().Add(time.Minute),
+		nil,
+		time.Now(),
+	)
+
+	if got, want := req.CodeChallenge(), "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge() = %v, want %v", got, want)
+	}
+
+	sum := sha256.Sum256([]byte(req.CodeVerifier()))
+	if req.CodeChallenge() != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		t.Error("CodeChallenge should be base64url(SHA256(code_verifier))")
+	}
+}
+
+func TestOIDCAuthRequest_Use(t *testing.T) {
+	usedAt := time.Now().Add(-1 * time.Minute)
+
+	tests := []struct {
+		name      string
+		provider  string
+		expiresAt time.Time
+		usedAt    *time.Time
+		wantErr   error
+	}{
+		{"有効期限内かつ未使用なら使用できる", "google", time.Now().Add(time.Minute), nil, nil},
+		{"プロバイダーが異なる場合はエラー", "other", time.Now().Add(time.Minute), nil, domainErrors.ErrOIDCStateInvalid},
+		{"有効期限切れはエラー", "google", time.Now().Add(-1 * time.Second), nil, domainErrors.ErrOIDCStateInvalid},
+		{"使用済みはエラー", "google", time.Now().Add(time.Minute), &usedAt, domainErrors.ErrOIDCStateInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := entity.ReconstructOIDCAuthRequest(
+				vo.NewOIDCAuthRequestID().String(),
+				"google",
+				"hash",
+				"nonce",
+				"verifier",
+				tt.expiresAt,
+				tt.usedAt,
+				time.Now().Add(-5*time.Minute),
+			)
+
+			if err := req.Use(tt.provider); err != tt.wantErr {
+				t.Errorf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && req.UsedAt() == nil {
+				t.Error("UsedAt should be set after Use()")
+			}
+		})
+	}
+}
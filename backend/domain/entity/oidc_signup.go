@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// OpenID Connectでの新規登録トークンの有効期間
+const OIDCSignupTTL = 30 * time.Minute
+
+// OIDCSignup はOpenID Connectで本人確認できたが、まだユーザーが存在しない状態を表すEntity
+// プロフィールを入力して登録を完了するまで、プロバイダーのアカウント情報を保持する
+// トークンの平文はリダイレクト先にのみ渡し、ここではハッシュのみを保持する
+type OIDCSignup struct {
+	id        vo.OIDCSignupID
+	provider  string
+	subject   string
+	email     string
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewOIDCSignup は新しい登録待ちを生成する
+// 戻り値のOneTimeTokenは登録画面に渡す平文で、保存されない
+func NewOIDCSignup(provider, subject string, email vo.Email) (*OIDCSignup, vo.OneTimeToken, error) {
+	token, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+
+	now := time.Now()
+	return &OIDCSignup{
+		id:        vo.NewOIDCSignupID(),
+		provider:  provider,
+		subject:   subject,
+		email:     email.String(),
+		tokenHash: token.Hash(),
+		expiresAt: now.Add(OIDCSignupTTL),
+		createdAt: now,
+	}, token, nil
+}
+
+// ReconstructOIDCSignup はDBからOIDCSignupを復元する
+func ReconstructOIDCSignup(
+	idStr string,
+	provider string,
+	subject string,
+	email string,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *OIDCSignup {
+	return &OIDCSignup{
+		id:        vo.ReconstructOIDCSignupID(idStr),
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+func (s *OIDCSignup) ID() vo.OIDCSignupID  { return s.id }
+func (s *OIDCSignup) Provider() string     { return s.provider }
+func (s *OIDCSignup) Subject() string      { return s.subject }
+func (s *OIDCSignup) Email() string        { return s.email }
+func (s *OIDCSignup) TokenHash() string    { return s.tokenHash }
+func (s *OIDCSignup) ExpiresAt() time.Time { return s.expiresAt }
+func (s *OIDCSignup) UsedAt() *time.Time   { return s.usedAt }
+func (s *OIDCSignup) CreatedAt() time.Time { return s.createdAt }
+
+// Use は登録待ちを使用済みにする
+// 有効期限切れ・使用済みの場合は ErrOIDCSignupTokenInvalid を返す
+func (s *OIDCSignup) Use() error {
+	now := time.Now()
+	if s.usedAt != nil || now.After(s.expiresAt) {
+		return domainErrors.ErrOIDCSignupTokenInvalid
+	}
+	s.usedAt = &now
+	return nil
+}
@@ -0,0 +1,69 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewOIDCSignup(t *testing.T) {
+	email, _ := vo.NewEmail("oidc@example.com")
+
+	signup, token, err := entity.NewOIDCSignup("google", "sub-123", email)
+
+	if err != nil {
+		t.Fatalf("NewOIDCSignup() unexpected error: %v", err)
+	}
+	if signup.Provider() != "google" || signup.Subject() != "sub-123" {
+		t.Errorf("Provider/Subject = %v/%v, want google/sub-123", signup.Provider(), signup.Subject())
+	}
+	if signup.Email() != "oidc@example.com" {
+		t.Errorf("Email = %v, want oidc@example.com", signup.Email())
+	}
+	if signup.TokenHash() != token.Hash() {
+		t.Error("TokenHash should be the hash of the raw token")
+	}
+	if got := signup.ExpiresAt().Sub(signup.CreatedAt()); got != entity.OIDCSignupTTL {
+		t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, entity.OIDCSignupTTL)
+	}
+}
+
+func TestOIDCSignup_Use(t *testing.T) {
+	usedAt := time.Now().Add(-1 * time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		usedAt    *time.Time
+		wantErr   error
+	}{
+		{"有効期限内かつ未使用なら使用できる", time.Now().Add(time.Minute), nil, nil},
+		{"有効期限切れはエラー", time.Now().Add(-1 * time.Second), nil, domainErrors.ErrOIDCSignupTokenInvalid},
+		{"使用済みはエラー", time.Now().Add(time.Minute), &usedAt, domainErrors.ErrOIDCSignupTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signup := entity.ReconstructOIDCSignup(
+				vo.NewOIDCSignupID().String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				"hash",
+				tt.expiresAt,
+				tt.usedAt,
+				time.Now().Add(-10*time.Minute),
+			)
+
+			if err := signup.Use(); err != tt.wantErr {
+				t.Errorf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && signup.UsedAt() == nil {
+				t.Error("UsedAt should be set after Use()")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// パスワードリセットトークンの有効期間
+const PasswordResetTokenTTL = 30 * time.Minute
+
+// PasswordResetToken はパスワードリセット用の使い捨てトークンを表すEntity
+// トークンの平文はメールでのみ送り、ここではハッシュのみを保持する
+type PasswordResetToken struct {
+	id        vo.PasswordResetTokenID
+	userID    vo.UserID
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewPasswordResetToken は新しいパスワードリセットトークンを生成する
+// 戻り値のOneTimeTokenはメール送信用の平文で、保存されない
+func NewPasswordResetToken(userID vo.UserID) (*PasswordResetToken, vo.OneTimeToken, error) {
+	token, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+
+	now := time.Now()
+	return &PasswordResetToken{
+		id:        vo.NewPasswordResetTokenID(),
+		userID:    userID,
+		tokenHash: token.Hash(),
+		expiresAt: now.Add(PasswordResetTokenTTL),
+		createdAt: now,
+	}, token, nil
+}
+
+// ReconstructPasswordResetToken はDBからPasswordResetTokenを復元する
+func ReconstructPasswordResetToken(
+	idStr string,
+	userIDStr string,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *PasswordResetToken {
+	return &PasswordResetToken{
+		id:        vo.ReconstructPasswordResetTokenID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+func (t *PasswordResetToken) ID() vo.PasswordResetTokenID { return t.id }
+func (t *PasswordResetToken) UserID() vo.UserID           { return t.userID }
+func (t *PasswordResetToken) TokenHash() string           { return t.tokenHash }
+func (t *PasswordResetToken) ExpiresAt() time.Time        { return t.expiresAt }
+func (t *PasswordResetToken) UsedAt() *time.Time          { return t.usedAt }
+func (t *PasswordResetToken) CreatedAt() time.Time        { return t.createdAt }
+
+// Use はトークンを使用済みにする
+// 有効期限切れ・使用済みの場合は ErrPasswordResetTokenInvalid を返す
+func (t *PasswordResetToken) Use() error {
+	now := time.Now()
+	if t.usedAt != nil || now.After(t.expiresAt) {
+		return domainErrors.ErrPasswordResetTokenInvalid
+	}
+	t.usedAt = &now
+	return nil
+}
@@ -0,0 +1,72 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	userID := vo.NewUserID()
+
+	token, raw, err := entity.NewPasswordResetToken(userID)
+
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() unexpected error: %v", err)
+	}
+	if !token.UserID().Equals(userID) {
+		t.Errorf("UserID = %v, want %v", token.UserID(), userID)
+	}
+	if token.TokenHash() != raw.Hash() {
+		t.Error("TokenHash should be the hash of the raw token")
+	}
+	if token.TokenHash() == raw.String() {
+		t.Error("TokenHash should not store the raw token")
+	}
+	if got := token.ExpiresAt().Sub(token.CreatedAt()); got != entity.PasswordResetTokenTTL {
+		t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, entity.PasswordResetTokenTTL)
+	}
+	if token.UsedAt() != nil {
+		t.Error("UsedAt should be nil")
+	}
+}
+
+func TestPasswordResetToken_Use(t *testing.T) {
+	usedAt := time.Now().Add(-1 * time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		usedAt    *time.Time
+		wantErr   error
+	}{
+		{"有効期限内かつ未使用なら使用できる", time.Now().Add(10 * time.Minute), nil, nil},
+		{"有効期限切れはエラー", time.Now().Add(-1 * time.Minute), nil, domainErrors.ErrPasswordResetTokenInvalid},
+		{"使用済みはエラー", time.Now().Add(10 * time.Minute), &usedAt, domainErrors.ErrPasswordResetTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.ReconstructPasswordResetToken(
+				vo.NewPasswordResetTokenID().String(),
+				vo.NewUserID().String(),
+				"hash",
+				tt.expiresAt,
+				tt.usedAt,
+				time.Now().Add(-20*time.Minute),
+			)
+
+			err := token.Use()
+
+			if err != tt.wantErr {
+				t.Errorf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && token.UsedAt() == nil {
+				t.Error("UsedAt should be set after Use()")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"time"
+	"unicode/utf8"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// トークン名の最大文字数
+const maxAccessTokenNameLength = 100
+
+// PersonalAccessToken はスクリプト等からAPIを利用するための長期間有効なトークンを表すEntity
+// ブラウザのセッションとは独立して発行・失効でき、許可したスコープの操作のみ行える
+// トークンの平文は発行時にのみ返し、ここではハッシュのみを保持する
+type PersonalAccessToken struct {
+	id         vo.PersonalAccessTokenID
+	userID     vo.UserID
+	name       string
+	tokenHash  string
+	scopes     []vo.TokenScope
+	expiresAt  *time.Time
+	lastUsedAt *time.Time
+	createdAt  time.Time
+}
+
+// NewPersonalAccessToken は新しいパーソナルアクセストークンを生成する
+// expiresAt がnilの場合は無期限とする
+// 戻り値のAccessTokenはユーザーに一度だけ表示する平文で、保存されない
+func NewPersonalAccessToken(
+	userID vo.UserID,
+	name string,
+	scopes []vo.TokenScope,
+	expiresAt *time.Time,
+) (*PersonalAccessToken, vo.AccessToken, error) {
+	if name == "" {
+		return nil, vo.AccessToken{}, domainErrors.ErrAccessTokenNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxAccessTokenNameLength {
+		return nil, vo.AccessToken{}, domainErrors.ErrAccessTokenNameTooLong
+	}
+	if len(scopes) == 0 {
+		return nil, vo.AccessToken{}, domainErrors.ErrAccessTokenScopeRequired
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, vo.AccessToken{}, domainErrors.ErrAccessTokenExpiryMustBeFuture
+	}
+
+	token, err := vo.NewAccessToken()
+	if err != nil {
+		return nil, vo.AccessToken{}, err
+	}
+
+	return &PersonalAccessToken{
+		id:        vo.NewPersonalAccessTokenID(),
+		userID:    userID,
+		name:      name,
+		tokenHash: token.Hash(),
+		scopes:    scopes,
+		expiresAt: expiresAt,
+		createdAt: now,
+	}, token, nil
+}
+
+// ReconstructPersonalAccessToken はDBからPersonalAccessTokenを復元する
+func ReconstructPersonalAccessToken(
+	idStr string,
+	userIDStr string,
+	name string,
+	tokenHash string,
+	scopeStrs []string,
+	expiresAt *time.Time,
+	lastUsedAt *time.Time,
+	createdAt time.Time,
+) *PersonalAccessToken {
+	scopes := make([]vo.TokenScope, len(scopeStrs))
+	for i, s := range scopeStrs {
+		scopes[i] = vo.ReconstructTokenScope(s)
+	}
+
+	return &PersonalAccessToken{
+		id:         vo.ReconstructPersonalAccessTokenID(idStr),
+		userID:     vo.ReconstructUserID(userIDStr),
+		name:       name,
+		tokenHash:  tokenHash,
+		scopes:     scopes,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		createdAt:  createdAt,
+	}
+}
+
+func (t *PersonalAccessToken) ID() vo.PersonalAccessTokenID { return t.id }
+func (t *PersonalAccessToken) UserID() vo.UserID            { return t.userID }
+func (t *PersonalAccessToken) Name() string                 { return t.name }
+func (t *PersonalAccessToken) TokenHash() string            { return t.tokenHash }
+func (t *PersonalAccessToken) Scopes() []vo.TokenScope      { return t.scopes }
+func (t *PersonalAccessToken) ExpiresAt() *time.Time        { return t.expiresAt }
+func (t *PersonalAccessToken) LastUsedAt() *time.Time       { return t.lastUsedAt }
+func (t *PersonalAccessToken) CreatedAt() time.Time         { return t.createdAt }
+
+// IsExpired はトークンが有効期限切れかを判定する（無期限の場合は常にfalse）
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.expiresAt != nil && !time.Now().Before(*t.expiresAt)
+}
+
+// HasScope はトークンが指定したスコープを持つかを判定する
+func (t *PersonalAccessToken) HasScope(scope vo.TokenScope) bool {
+	for _, s := range t.scopes {
+		if s.Equals(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// Use はトークンでの認証を記録し、最終利用日時を更新する
+// 有効期限切れの場合は ErrInvalidAccessToken を返す
+func (t *PersonalAccessToken) Use() error {
+	if t.IsExpired() {
+		return domainErrors.ErrInvalidAccessToken
+	}
+	now := time.Now()
+	t.lastUsedAt = &now
+	return nil
+}
@@ -0,0 +1,127 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	userID := vo.NewUserID()
+	scopes, _ := vo.NewTokenScopes([]string{vo.TokenScopeRecordsRead})
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-1 * time.Minute)
+
+	t.Run("正常系_トークンを発行する", func(t *testing.T) {
+		token, raw, err := entity.NewPersonalAccessToken(userID, "spreadsheet", scopes, &future)
+		if err != nil {
+			t.Fatalf("NewPersonalAccessToken() unexpected error: %v", err)
+		}
+		if !token.UserID().Equals(userID) {
+			t.Errorf("UserID = %v, want %v", token.UserID(), userID)
+		}
+		if token.TokenHash() != raw.Hash() {
+			t.Error("TokenHash should be the hash of the raw token")
+		}
+		if token.LastUsedAt() != nil {
+			t.Error("LastUsedAt should be nil for a new token")
+		}
+	})
+
+	t.Run("正常系_有効期限なし", func(t *testing.T) {
+		token, _, err := entity.NewPersonalAccessToken(userID, "spreadsheet", scopes, nil)
+		if err != nil {
+			t.Fatalf("NewPersonalAccessToken() unexpected error: %v", err)
+		}
+		if token.ExpiresAt() != nil || token.IsExpired() {
+			t.Error("token without expiry should never expire")
+		}
+	})
+
+	tests := []struct {
+		name      string
+		tokenName string
+		scopes    []vo.TokenScope
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"名前が空", "", scopes, nil, domainErrors.ErrAccessTokenNameRequired},
+		{"名前が長すぎる", strings.Repeat("a", 101), scopes, nil, domainErrors.ErrAccessTokenNameTooLong},
+		{"スコープなし", "spreadsheet", nil, nil, domainErrors.ErrAccessTokenScopeRequired},
+		{"有効期限が過去", "spreadsheet", scopes, &past, domainErrors.ErrAccessTokenExpiryMustBeFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run("異常系_"+tt.name, func(t *testing.T) {
+			_, _, err := entity.NewPersonalAccessToken(userID, tt.tokenName, tt.scopes, tt.expiresAt)
+			if err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessToken_HasScope(t *testing.T) {
+	token := entity.ReconstructPersonalAccessToken(
+		vo.NewPersonalAccessTokenID().String(),
+		vo.NewUserID().String(),
+		"spreadsheet",
+		"hash",
+		[]string{vo.TokenScopeRecordsRead, vo.TokenScopeProfileRead},
+		nil,
+		nil,
+		time.Now(),
+	)
+
+	read, _ := vo.NewTokenScope(vo.TokenScopeRecordsRead)
+	write, _ := vo.NewTokenScope(vo.TokenScopeRecordsWrite)
+
+	if !token.HasScope(read) {
+		t.Error("HasScope(records:read) should be true")
+	}
+	if token.HasScope(write) {
+		t.Error("HasScope(records:write) should be false")
+	}
+}
+
+func TestPersonalAccessToken_Use(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-1 * time.Second)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"無期限なら使用できる", nil, nil},
+		{"有効期限内なら使用できる", &future, nil},
+		{"有効期限切れはエラー", &past, domainErrors.ErrInvalidAccessToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.ReconstructPersonalAccessToken(
+				vo.NewPersonalAccessTokenID().String(),
+				vo.NewUserID().String(),
+				"spreadsheet",
+				"hash",
+				[]string{vo.TokenScopeRecordsRead},
+				tt.expiresAt,
+				nil,
+				time.Now().Add(-1*time.Hour),
+			)
+
+			err := token.Use()
+			if err != tt.wantErr {
+				t.Fatalf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && token.LastUsedAt() == nil {
+				t.Error("LastUsedAt should be set after Use()")
+			}
+		})
+	}
+}
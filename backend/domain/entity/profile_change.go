@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// 変更履歴を記録するプロフィールの項目名
+const (
+	ProfileFieldGender    = "gender"
+	ProfileFieldBirthDate = "birth_date"
+)
+
+// ProfileChange はプロフィールの変更履歴（監査用）を表すEntity
+// 目標カロリーの計算に影響する登録時の入力の訂正を追跡するために記録する
+type ProfileChange struct {
+	id        vo.ProfileChangeID
+	userID    vo.UserID
+	field     string
+	oldValue  string
+	newValue  string
+	changedAt time.Time
+}
+
+// NewProfileChange は新しいプロフィールの変更履歴を生成する
+func NewProfileChange(userID vo.UserID, field, oldValue, newValue string) *ProfileChange {
+	return &ProfileChange{
+		id:        vo.NewProfileChangeID(),
+		userID:    userID,
+		field:     field,
+		oldValue:  oldValue,
+		newValue:  newValue,
+		changedAt: time.Now(),
+	}
+}
+
+// ReconstructProfileChange はDBからProfileChangeを復元する
+func ReconstructProfileChange(
+	idStr string,
+	userIDStr string,
+	field string,
+	oldValue string,
+	newValue string,
+	changedAt time.Time,
+) *ProfileChange {
+	return &ProfileChange{
+		id:        vo.ReconstructProfileChangeID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		field:     field,
+		oldValue:  oldValue,
+		newValue:  newValue,
+		changedAt: changedAt,
+	}
+}
+
+func (p *ProfileChange) ID() vo.ProfileChangeID { return p.id }
+func (p *ProfileChange) UserID() vo.UserID      { return p.userID }
+func (p *ProfileChange) Field() string          { return p.field }
+func (p *ProfileChange) OldValue() string       { return p.oldValue }
+func (p *ProfileChange) NewValue() string       { return p.newValue }
+func (p *ProfileChange) ChangedAt() time.Time   { return p.changedAt }
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// 2段階認証の登録時に発行するリカバリーコードの件数
+const RecoveryCodeCount = 10
+
+// RecoveryCode は2段階認証のリカバリーコード1件を表すEntity
+// コードの平文は発行時に一度だけ表示し、ここではハッシュのみを保持する
+type RecoveryCode struct {
+	id        vo.RecoveryCodeID
+	userID    vo.UserID
+	codeHash  string
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewRecoveryCodes はユーザーのリカバリーコードを RecoveryCodeCount 件生成する
+// 戻り値の []vo.RecoveryCode はユーザーへの表示用の平文で、保存されない
+func NewRecoveryCodes(userID vo.UserID) ([]*RecoveryCode, []vo.RecoveryCode, error) {
+	now := time.Now()
+	codes := make([]*RecoveryCode, 0, RecoveryCodeCount)
+	raws := make([]vo.RecoveryCode, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		raw, err := vo.NewRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, &RecoveryCode{
+			id:        vo.NewRecoveryCodeID(),
+			userID:    userID,
+			codeHash:  raw.Hash(),
+			createdAt: now,
+		})
+		raws = append(raws, raw)
+	}
+	return codes, raws, nil
+}
+
+// ReconstructRecoveryCode はDBからRecoveryCodeを復元する
+func ReconstructRecoveryCode(
+	idStr string,
+	userIDStr string,
+	codeHash string,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *RecoveryCode {
+	return &RecoveryCode{
+		id:        vo.ReconstructRecoveryCodeID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		codeHash:  codeHash,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+func (c *RecoveryCode) ID() vo.RecoveryCodeID { return c.id }
+func (c *RecoveryCode) UserID() vo.UserID     { return c.userID }
+func (c *RecoveryCode) CodeHash() string      { return c.codeHash }
+func (c *RecoveryCode) UsedAt() *time.Time    { return c.usedAt }
+func (c *RecoveryCode) CreatedAt() time.Time  { return c.createdAt }
+
+// Use はコードを使用済みにする
+// 使用済みの場合は ErrInvalidRecoveryCode を返す
+func (c *RecoveryCode) Use() error {
+	if c.usedAt != nil {
+		return domainErrors.ErrInvalidRecoveryCode
+	}
+	now := time.Now()
+	c.usedAt = &now
+	return nil
+}
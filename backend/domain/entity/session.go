@@ -6,12 +6,28 @@ import (
 	"caltrack/domain/vo"
 )
 
+// セッションの最終利用日時を更新する最小間隔
+// 認証のたびにDBへ書き込まないよう、この間隔より短い利用では更新しない
+const SessionLastSeenInterval = 5 * time.Minute
+
+// ユーザーエージェントとして保持する最大文字数
+const maxUserAgentLength = 512
+
+// SessionClient はセッションを開始したクライアントの情報を表す
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
 // Session はユーザーセッションを表すエンティティ
 type Session struct {
-	id        vo.SessionID
-	userID    vo.UserID
-	expiresAt vo.ExpiresAt
-	createdAt time.Time
+	id         vo.SessionID
+	userID     vo.UserID
+	expiresAt  vo.ExpiresAt
+	userAgent  string
+	ipAddress  string
+	lastSeenAt time.Time
+	createdAt  time.Time
 }
 
 // NewSession は新しいセッションを生成する
@@ -25,11 +41,13 @@ func NewSession(userIDStr string) (*Session, error) {
 		return nil, err
 	}
 
+	now := time.Now()
 	return &Session{
-		id:        sessionID,
-		userID:    userID,
-		expiresAt: vo.NewExpiresAt(),
-		createdAt: time.Now(),
+		id:         sessionID,
+		userID:     userID,
+		expiresAt:  vo.NewExpiresAt(),
+		lastSeenAt: now,
+		createdAt:  now,
 	}, nil
 }
 
@@ -41,11 +59,13 @@ func NewSessionWithUserID(userID vo.UserID) (*Session, error) {
 		return nil, err
 	}
 
+	now := time.Now()
 	return &Session{
-		id:        sessionID,
-		userID:    userID,
-		expiresAt: vo.NewExpiresAt(),
-		createdAt: time.Now(),
+		id:         sessionID,
+		userID:     userID,
+		expiresAt:  vo.NewExpiresAt(),
+		lastSeenAt: now,
+		createdAt:  now,
 	}, nil
 }
 
@@ -56,6 +76,9 @@ func ReconstructSession(
 	sessionIDStr string,
 	userIDStr string,
 	expiresAt time.Time,
+	userAgent string,
+	ipAddress string,
+	lastSeenAt time.Time,
 	createdAt time.Time,
 ) (*Session, error) {
 	sessionID, err := vo.ParseSessionID(sessionIDStr)
@@ -66,10 +89,13 @@ func ReconstructSession(
 	userID := vo.ReconstructUserID(userIDStr)
 
 	return &Session{
-		id:        sessionID,
-		userID:    userID,
-		expiresAt: vo.ParseExpiresAt(expiresAt),
-		createdAt: createdAt,
+		id:         sessionID,
+		userID:     userID,
+		expiresAt:  vo.ParseExpiresAt(expiresAt),
+		userAgent:  userAgent,
+		ipAddress:  ipAddress,
+		lastSeenAt: lastSeenAt,
+		createdAt:  createdAt,
 	}, nil
 }
 
@@ -98,6 +124,21 @@ func (s *Session) ExpiresAt() vo.ExpiresAt {
 	return s.expiresAt
 }
 
+// UserAgent はセッションを開始したクライアントのユーザーエージェントを返す
+func (s *Session) UserAgent() string {
+	return s.userAgent
+}
+
+// IPAddress はセッションを開始したクライアントのIPアドレスを返す
+func (s *Session) IPAddress() string {
+	return s.ipAddress
+}
+
+// LastSeenAt は最終利用日時を返す
+func (s *Session) LastSeenAt() time.Time {
+	return s.lastSeenAt
+}
+
 // CreatedAt は作成日時を返す
 func (s *Session) CreatedAt() time.Time {
 	return s.createdAt
@@ -108,6 +149,41 @@ func (s *Session) IsExpired() bool {
 	return s.expiresAt.IsExpired()
 }
 
+// Refresh は有効期間の半分を過ぎたセッションの有効期限を延長する（スライド有効期限）
+// 延長した場合はtrueを返す。呼び出し側はtrueの場合のみ永続化すればよい
+func (s *Session) Refresh() bool {
+	extended, ok := s.expiresAt.Extend(s.createdAt)
+	if !ok {
+		return false
+	}
+	s.expiresAt = extended
+	return true
+}
+
+// AttachClient はセッションを開始したクライアントの情報を設定する
+// ユーザーエージェントは maxUserAgentLength 文字で切り詰める
+func (s *Session) AttachClient(client SessionClient) {
+	userAgent := []rune(client.UserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	s.userAgent = string(userAgent)
+	s.ipAddress = client.IPAddress
+}
+
+// Touch はセッションの利用を記録する
+// 有効期限の延長（Refresh）と、SessionLastSeenInterval 以上経過していれば最終利用日時の更新を行う
+// いずれかを更新した場合はtrueを返す。呼び出し側はtrueの場合のみ永続化すればよい
+func (s *Session) Touch() bool {
+	now := time.Now()
+	refreshed := s.Refresh()
+	if !refreshed && now.Sub(s.lastSeenAt) < SessionLastSeenInterval {
+		return false
+	}
+	s.lastSeenAt = now
+	return true
+}
+
 // ValidateNotExpired はセッションが有効期限内かどうかを検証する
 // 有効期限切れの場合は ErrSessionExpired を返す
 func (s *Session) ValidateNotExpired() error {
@@ -1,6 +1,7 @@
 package entity_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -83,10 +84,15 @@ func TestReconstructSession_Success(t *testing.T) {
 	expiresAt := time.Date(2024, 6, 22, 12, 0, 0, 0, time.UTC)
 	createdAt := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
 
+	lastSeenAt := time.Date(2024, 6, 16, 9, 0, 0, 0, time.UTC)
+
 	session, err := entity.ReconstructSession(
 		sessionIDStr,
 		userIDStr,
 		expiresAt,
+		"Mozilla/5.0",
+		"203.0.113.1",
+		lastSeenAt,
 		createdAt,
 	)
 
@@ -102,6 +108,15 @@ func TestReconstructSession_Success(t *testing.T) {
 	if !session.ExpiresAt().Time().Equal(expiresAt) {
 		t.Errorf("ExpiresAt = %v, want %v", session.ExpiresAt().Time(), expiresAt)
 	}
+	if session.UserAgent() != "Mozilla/5.0" {
+		t.Errorf("UserAgent = %v, want %v", session.UserAgent(), "Mozilla/5.0")
+	}
+	if session.IPAddress() != "203.0.113.1" {
+		t.Errorf("IPAddress = %v, want %v", session.IPAddress(), "203.0.113.1")
+	}
+	if !session.LastSeenAt().Equal(lastSeenAt) {
+		t.Errorf("LastSeenAt = %v, want %v", session.LastSeenAt(), lastSeenAt)
+	}
 	if !session.CreatedAt().Equal(createdAt) {
 		t.Errorf("CreatedAt = %v, want %v", session.CreatedAt(), createdAt)
 	}
@@ -127,6 +142,9 @@ func TestReconstructSession_InvalidSessionID(t *testing.T) {
 				tt.sessionID,
 				userIDStr,
 				expiresAt,
+				"",
+				"",
+				createdAt,
 				createdAt,
 			)
 
@@ -160,6 +178,9 @@ func TestSession_IsExpired(t *testing.T) {
 				sessionIDStr,
 				userIDStr,
 				tt.expiresAt,
+				"",
+				"",
+				createdAt,
 				createdAt,
 			)
 
@@ -193,6 +214,9 @@ func TestSession_ValidateNotExpired(t *testing.T) {
 				sessionIDStr,
 				userIDStr,
 				tt.expiresAt,
+				"",
+				"",
+				createdAt,
 				createdAt,
 			)
 
@@ -202,3 +226,103 @@ func TestSession_ValidateNotExpired(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_Refresh(t *testing.T) {
+	validSessionID, _ := vo.NewSessionID()
+	sessionIDStr := validSessionID.String()
+	userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+	t.Run("有効期間の半分を過ぎていれば延長される", func(t *testing.T) {
+		session, _ := entity.ReconstructSession(
+			sessionIDStr,
+			userIDStr,
+			time.Now().Add(24*time.Hour),
+			"",
+			"",
+			time.Now().AddDate(0, 0, -6),
+			time.Now().AddDate(0, 0, -6),
+		)
+		before := session.ExpiresAt().Time()
+
+		if !session.Refresh() {
+			t.Fatal("Refresh() = false, want true")
+		}
+		if !session.ExpiresAt().Time().After(before) {
+			t.Errorf("ExpiresAt = %v, want after %v", session.ExpiresAt().Time(), before)
+		}
+	})
+
+	t.Run("有効期間の半分を過ぎていなければ延長されない", func(t *testing.T) {
+		expiresAt := time.Now().AddDate(0, 0, 6)
+		session, _ := entity.ReconstructSession(
+			sessionIDStr,
+			userIDStr,
+			expiresAt,
+			"",
+			"",
+			time.Now().AddDate(0, 0, -1),
+			time.Now().AddDate(0, 0, -1),
+		)
+
+		if session.Refresh() {
+			t.Error("Refresh() = true, want false")
+		}
+		if !session.ExpiresAt().Time().Equal(expiresAt) {
+			t.Errorf("ExpiresAt = %v, want %v", session.ExpiresAt().Time(), expiresAt)
+		}
+	})
+}
+
+func TestSession_AttachClient(t *testing.T) {
+	session, _ := entity.NewSessionWithUserID(vo.NewUserID())
+
+	longUserAgent := strings.Repeat("a", 600)
+	session.AttachClient(entity.SessionClient{UserAgent: longUserAgent, IPAddress: "203.0.113.1"})
+
+	if len(session.UserAgent()) != 512 {
+		t.Errorf("len(UserAgent) = %d, want 512", len(session.UserAgent()))
+	}
+	if session.IPAddress() != "203.0.113.1" {
+		t.Errorf("IPAddress = %v, want %v", session.IPAddress(), "203.0.113.1")
+	}
+}
+
+func TestSession_Touch(t *testing.T) {
+	validSessionID, _ := vo.NewSessionID()
+	sessionIDStr := validSessionID.String()
+	userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+	expiresAt := time.Now().AddDate(0, 0, 6)
+	createdAt := time.Now().AddDate(0, 0, -1)
+
+	tests := []struct {
+		name       string
+		expiresAt  time.Time
+		lastSeenAt time.Time
+		want       bool
+	}{
+		{"最終利用から間隔が空いていなければ更新しない", expiresAt, time.Now().Add(-1 * time.Minute), false},
+		{"最終利用から間隔が空いていれば更新する", expiresAt, time.Now().Add(-10 * time.Minute), true},
+		{"有効期限を延長した場合は更新する", time.Now().Add(24 * time.Hour), time.Now().Add(-1 * time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, _ := entity.ReconstructSession(
+				sessionIDStr,
+				userIDStr,
+				tt.expiresAt,
+				"",
+				"",
+				tt.lastSeenAt,
+				createdAt,
+			)
+
+			if got := session.Touch(); got != tt.want {
+				t.Errorf("Touch() = %v, want %v", got, tt.want)
+			}
+			if tt.want && !session.LastSeenAt().After(tt.lastSeenAt) {
+				t.Errorf("LastSeenAt = %v, want after %v", session.LastSeenAt(), tt.lastSeenAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// TargetSnapshot はある日付以降に適用される目標値（カロリー・PFC）を表すEntity
+// プロフィール変更で目標値が変わっても、過去日の達成判定が変わらないように記録する
+type TargetSnapshot struct {
+	id             vo.TargetSnapshotID
+	userID         vo.UserID
+	effectiveDate  time.Time
+	targetCalories vo.Calories
+	targetPfc      vo.Pfc
+	createdAt      time.Time
+}
+
+// NewTargetSnapshot はユーザーの現在の目標値から新しいTargetSnapshotを生成する
+// effectiveDateは日付単位に正規化される
+func NewTargetSnapshot(user *User, effectiveDate time.Time) *TargetSnapshot {
+	normalizedDate := time.Date(effectiveDate.Year(), effectiveDate.Month(), effectiveDate.Day(), 0, 0, 0, 0, effectiveDate.Location())
+
+	return &TargetSnapshot{
+		id:             vo.NewTargetSnapshotID(),
+		userID:         user.ID(),
+		effectiveDate:  normalizedDate,
+		targetCalories: vo.ReconstructCalories(user.CalculateTargetCalories()),
+		targetPfc:      user.CalculateTargetPfc(),
+		createdAt:      time.Now(),
+	}
+}
+
+// ReconstructTargetSnapshot はDBからTargetSnapshotを復元する
+func ReconstructTargetSnapshot(
+	idStr string,
+	userIDStr string,
+	effectiveDate time.Time,
+	targetCalories int,
+	protein, fat, carbs float64,
+	createdAt time.Time,
+) *TargetSnapshot {
+	return &TargetSnapshot{
+		id:             vo.ReconstructTargetSnapshotID(idStr),
+		userID:         vo.ReconstructUserID(userIDStr),
+		effectiveDate:  effectiveDate,
+		targetCalories: vo.ReconstructCalories(targetCalories),
+		targetPfc:      vo.NewPfc(protein, fat, carbs),
+		createdAt:      createdAt,
+	}
+}
+
+func (s *TargetSnapshot) ID() vo.TargetSnapshotID     { return s.id }
+func (s *TargetSnapshot) UserID() vo.UserID           { return s.userID }
+func (s *TargetSnapshot) EffectiveDate() time.Time    { return s.effectiveDate }
+func (s *TargetSnapshot) TargetCalories() vo.Calories { return s.targetCalories }
+func (s *TargetSnapshot) TargetPfc() vo.Pfc           { return s.targetPfc }
+func (s *TargetSnapshot) CreatedAt() time.Time        { return s.createdAt }
+
+// IsEffectiveOn は指定日にこのスナップショットが適用済みかどうかを返す
+// 日付はそれぞれのロケーションでの年月日で比較する
+func (s *TargetSnapshot) IsEffectiveOn(date time.Time) bool {
+	return s.effectiveDate.Format(dateLayout) <= date.Format(dateLayout)
+}
+
+// FindEffectiveTargetSnapshot は指定日に有効なスナップショットを返す
+// snapshotsは適用日の昇順であること。該当がない場合はnilを返す
+func FindEffectiveTargetSnapshot(snapshots []*TargetSnapshot, date time.Time) *TargetSnapshot {
+	var effective *TargetSnapshot
+	for _, snapshot := range snapshots {
+		if !snapshot.IsEffectiveOn(date) {
+			break
+		}
+		effective = snapshot
+	}
+	return effective
+}
@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// snapshotTestUser はスナップショットテスト用のユーザーを生成する
+func snapshotTestUser(t *testing.T) *entity.User {
+	t.Helper()
+	user, errs := entity.NewUser(
+		"test@example.com",
+		"password123",
+		"testuser",
+		70.0,
+		175.0,
+		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		"male",
+		"moderate",
+	)
+	if errs != nil {
+		t.Fatalf("failed to create test user: %v", errs)
+	}
+	return user
+}
+
+func TestNewTargetSnapshot(t *testing.T) {
+	t.Run("正常系_ユーザーの現在の目標値で作成される", func(t *testing.T) {
+		user := snapshotTestUser(t)
+		effectiveDate := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+
+		snapshot := entity.NewTargetSnapshot(user, effectiveDate)
+
+		if snapshot.ID().String() == "" {
+			t.Error("ID should not be empty")
+		}
+		if !snapshot.UserID().Equals(user.ID()) {
+			t.Errorf("UserID = %v, want %v", snapshot.UserID(), user.ID())
+		}
+		if snapshot.TargetCalories().Value() != user.CalculateTargetCalories() {
+			t.Errorf("TargetCalories = %d, want %d", snapshot.TargetCalories().Value(), user.CalculateTargetCalories())
+		}
+		if snapshot.TargetPfc() != user.CalculateTargetPfc() {
+			t.Errorf("TargetPfc = %v, want %v", snapshot.TargetPfc(), user.CalculateTargetPfc())
+		}
+	})
+
+	t.Run("正常系_適用日は日付単位に正規化される", func(t *testing.T) {
+		user := snapshotTestUser(t)
+		effectiveDate := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+
+		snapshot := entity.NewTargetSnapshot(user, effectiveDate)
+
+		want := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+		if !snapshot.EffectiveDate().Equal(want) {
+			t.Errorf("EffectiveDate = %v, want %v", snapshot.EffectiveDate(), want)
+		}
+	})
+}
+
+func TestTargetSnapshot_IsEffectiveOn(t *testing.T) {
+	snapshot := entity.ReconstructTargetSnapshot(
+		vo.NewTargetSnapshotID().String(),
+		vo.NewUserID().String(),
+		time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		2000, 75, 55.6, 300,
+		time.Now(),
+	)
+
+	tests := []struct {
+		name string
+		date time.Time
+		want bool
+	}{
+		{"前日は適用前", time.Date(2025, 3, 9, 23, 59, 0, 0, time.UTC), false},
+		{"適用日当日は適用済み", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), true},
+		{"翌日以降は適用済み", time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snapshot.IsEffectiveOn(tt.date); got != tt.want {
+				t.Errorf("IsEffectiveOn(%v) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindEffectiveTargetSnapshot(t *testing.T) {
+	userID := vo.NewUserID().String()
+	first := entity.ReconstructTargetSnapshot(vo.NewTargetSnapshotID().String(), userID, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 1800, 67.5, 50, 270, time.Now())
+	second := entity.ReconstructTargetSnapshot(vo.NewTargetSnapshotID().String(), userID, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), 2200, 82.5, 61.1, 330, time.Now())
+	snapshots := []*entity.TargetSnapshot{first, second}
+
+	t.Run("正常系_適用開始前の日はnil", func(t *testing.T) {
+		got := entity.FindEffectiveTargetSnapshot(snapshots, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+		if got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+	})
+
+	t.Run("正常系_期間中は直近に適用されたスナップショット", func(t *testing.T) {
+		got := entity.FindEffectiveTargetSnapshot(snapshots, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC))
+		if got != first {
+			t.Errorf("got %v, want first snapshot", got)
+		}
+	})
+
+	t.Run("正常系_変更後は新しいスナップショット", func(t *testing.T) {
+		got := entity.FindEffectiveTargetSnapshot(snapshots, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+		if got != second {
+			t.Errorf("got %v, want second snapshot", got)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// 2段階認証チャレンジの有効期間
+// パスワード確認後、認証アプリのコードを入力するまでの猶予
+const TwoFactorChallengeTTL = 5 * time.Minute
+
+// TwoFactorChallenge はパスワード確認済みで2段階認証のコード入力待ちであることを表すEntity
+// クライアントにはトークンの平文を返し、ここではハッシュのみを保持する
+type TwoFactorChallenge struct {
+	id        vo.TwoFactorChallengeID
+	userID    vo.UserID
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewTwoFactorChallenge は新しい2段階認証チャレンジを生成する
+// 戻り値のOneTimeTokenはクライアントに返す平文で、保存されない
+func NewTwoFactorChallenge(userID vo.UserID) (*TwoFactorChallenge, vo.OneTimeToken, error) {
+	token, err := vo.NewOneTimeToken()
+	if err != nil {
+		return nil, vo.OneTimeToken{}, err
+	}
+
+	now := time.Now()
+	return &TwoFactorChallenge{
+		id:        vo.NewTwoFactorChallengeID(),
+		userID:    userID,
+		tokenHash: token.Hash(),
+		expiresAt: now.Add(TwoFactorChallengeTTL),
+		createdAt: now,
+	}, token, nil
+}
+
+// ReconstructTwoFactorChallenge はDBからTwoFactorChallengeを復元する
+func ReconstructTwoFactorChallenge(
+	idStr string,
+	userIDStr string,
+	tokenHash string,
+	expiresAt time.Time,
+	usedAt *time.Time,
+	createdAt time.Time,
+) *TwoFactorChallenge {
+	return &TwoFactorChallenge{
+		id:        vo.ReconstructTwoFactorChallengeID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+func (c *TwoFactorChallenge) ID() vo.TwoFactorChallengeID { return c.id }
+func (c *TwoFactorChallenge) UserID() vo.UserID           { return c.userID }
+func (c *TwoFactorChallenge) TokenHash() string           { return c.tokenHash }
+func (c *TwoFactorChallenge) ExpiresAt() time.Time        { return c.expiresAt }
+func (c *TwoFactorChallenge) UsedAt() *time.Time          { return c.usedAt }
+func (c *TwoFactorChallenge) CreatedAt() time.Time        { return c.createdAt }
+
+// Validate はチャレンジが未使用かつ有効期限内かを検証する
+// 有効期限切れ・使用済みの場合は ErrTwoFactorTokenInvalid を返す
+func (c *TwoFactorChallenge) Validate() error {
+	if c.usedAt != nil || time.Now().After(c.expiresAt) {
+		return domainErrors.ErrTwoFactorTokenInvalid
+	}
+	return nil
+}
+
+// Use はチャレンジを使用済みにする
+func (c *TwoFactorChallenge) Use() error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	now := time.Now()
+	c.usedAt = &now
+	return nil
+}
@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewTwoFactorChallenge(t *testing.T) {
+	userID := vo.NewUserID()
+
+	challenge, raw, err := entity.NewTwoFactorChallenge(userID)
+
+	if err != nil {
+		t.Fatalf("NewTwoFactorChallenge() unexpected error: %v", err)
+	}
+	if !challenge.UserID().Equals(userID) {
+		t.Errorf("UserID = %v, want %v", challenge.UserID(), userID)
+	}
+	if challenge.TokenHash() != raw.Hash() {
+		t.Error("TokenHash should be the hash of the raw token")
+	}
+	if got := challenge.ExpiresAt().Sub(challenge.CreatedAt()); got != entity.TwoFactorChallengeTTL {
+		t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, entity.TwoFactorChallengeTTL)
+	}
+}
+
+func TestTwoFactorChallenge_Use(t *testing.T) {
+	usedAt := time.Now().Add(-1 * time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		usedAt    *time.Time
+		wantErr   error
+	}{
+		{"有効期限内かつ未使用なら使用できる", time.Now().Add(time.Minute), nil, nil},
+		{"有効期限切れはエラー", time.Now().Add(-1 * time.Second), nil, domainErrors.ErrTwoFactorTokenInvalid},
+		{"使用済みはエラー", time.Now().Add(time.Minute), &usedAt, domainErrors.ErrTwoFactorTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := entity.ReconstructTwoFactorChallenge(
+				vo.NewTwoFactorChallengeID().String(),
+				vo.NewUserID().String(),
+				"hash",
+				tt.expiresAt,
+				tt.usedAt,
+				time.Now().Add(-4*time.Minute),
+			)
+
+			if err := challenge.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := challenge.Use(); err != tt.wantErr {
+				t.Errorf("Use() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && challenge.UsedAt() == nil {
+				t.Error("UsedAt should be set after Use()")
+			}
+		})
+	}
+}
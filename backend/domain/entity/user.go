@@ -3,6 +3,7 @@ package entity
 import (
 	"time"
 
+	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/vo"
 )
 
@@ -16,8 +17,16 @@ type User struct {
 	birthDate      vo.BirthDate
 	gender         vo.Gender
 	activityLevel  vo.ActivityLevel
-	createdAt      time.Time
-	updatedAt      time.Time
+	budgetMode     vo.BudgetMode
+	// privacySettings はAI機能・分析への利用可否と記録の保存期間、その同意状況
+	privacySettings vo.PrivacySettings
+	role            vo.UserRole
+	// disabledAt は管理者がアカウントを停止した日時（停止中でない場合はnil）
+	disabledAt *time.Time
+	// emailVerifiedAt はメールアドレスの所有確認が完了した日時（未確認の場合はnil）
+	emailVerifiedAt *time.Time
+	createdAt       time.Time
+	updatedAt       time.Time
 }
 
 func NewUser(
@@ -70,20 +79,55 @@ func NewUser(
 
 	now := time.Now()
 	return &User{
-		id:             vo.NewUserID(),
-		email:          email,
-		hashedPassword: hashedPassword,
-		nickname:       nickname,
-		weight:         weight,
-		height:         height,
-		birthDate:      birthDate,
-		gender:         gender,
-		activityLevel:  activityLevel,
-		createdAt:      now,
-		updatedAt:      now,
+		id:              vo.NewUserID(),
+		email:           email,
+		hashedPassword:  hashedPassword,
+		nickname:        nickname,
+		weight:          weight,
+		height:          height,
+		birthDate:       birthDate,
+		gender:          gender,
+		activityLevel:   activityLevel,
+		budgetMode:      vo.DefaultBudgetMode(),
+		privacySettings: vo.DefaultPrivacySettings(),
+		role:            vo.DefaultUserRole(),
+		createdAt:       now,
+		updatedAt:       now,
 	}, nil
 }
 
+// NewUserWithoutPassword は外部IDプロバイダーで本人確認したユーザーをパスワードなしで生成する
+// プロフィールはVO変換済みの値を受け取る
+// メールアドレスはプロバイダーで確認済みのため、確認済みとして作成する
+// パスワードは SetPassword で後から設定できる
+func NewUserWithoutPassword(
+	email vo.Email,
+	nickname vo.Nickname,
+	weight vo.Weight,
+	height vo.Height,
+	birthDate vo.BirthDate,
+	gender vo.Gender,
+	activityLevel vo.ActivityLevel,
+) *User {
+	now := time.Now()
+	return &User{
+		id:              vo.NewUserID(),
+		email:           email,
+		nickname:        nickname,
+		weight:          weight,
+		height:          height,
+		birthDate:       birthDate,
+		gender:          gender,
+		activityLevel:   activityLevel,
+		budgetMode:      vo.DefaultBudgetMode(),
+		privacySettings: vo.DefaultPrivacySettings(),
+		role:            vo.DefaultUserRole(),
+		emailVerifiedAt: &now,
+		createdAt:       now,
+		updatedAt:       now,
+	}
+}
+
 // ReconstructUser はDBからの復元用。
 // NOTE: DBデータは保存時にバリデーション済みなのでVO変換は本来不要だが、
 // データ破損検知のため一旦バリデーションありで実装。
@@ -98,6 +142,11 @@ func ReconstructUser(
 	birthDateVal time.Time,
 	genderStr string,
 	activityLevelStr string,
+	budgetModeStr string,
+	privacySettings vo.PrivacySettings,
+	roleStr string,
+	disabledAt *time.Time,
+	emailVerifiedAt *time.Time,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) (*User, error) {
@@ -140,18 +189,33 @@ func ReconstructUser(
 		return nil, err
 	}
 
+	budgetMode, err := vo.NewBudgetMode(budgetModeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := vo.NewUserRole(roleStr)
+	if err != nil {
+		return nil, err
+	}
+
 	return &User{
-		id:             id,
-		email:          email,
-		hashedPassword: hashedPassword,
-		nickname:       nickname,
-		weight:         weight,
-		height:         height,
-		birthDate:      birthDate,
-		gender:         gender,
-		activityLevel:  activityLevel,
-		createdAt:      createdAt,
-		updatedAt:      updatedAt,
+		id:              id,
+		email:           email,
+		hashedPassword:  hashedPassword,
+		nickname:        nickname,
+		weight:          weight,
+		height:          height,
+		birthDate:       birthDate,
+		gender:          gender,
+		activityLevel:   activityLevel,
+		budgetMode:      budgetMode,
+		privacySettings: privacySettings,
+		role:            role,
+		disabledAt:      disabledAt,
+		emailVerifiedAt: emailVerifiedAt,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
 	}, nil
 }
 
@@ -167,6 +231,12 @@ func (u *User) HashedPassword() vo.HashedPassword {
 	return u.hashedPassword
 }
 
+// HasPassword はパスワードが設定されているかを返す
+// 外部IDプロバイダーで登録したユーザーはパスワードを設定するまでfalseになる
+func (u *User) HasPassword() bool {
+	return u.hashedPassword.String() != ""
+}
+
 func (u *User) Nickname() vo.Nickname {
 	return u.nickname
 }
@@ -191,6 +261,48 @@ func (u *User) ActivityLevel() vo.ActivityLevel {
 	return u.activityLevel
 }
 
+func (u *User) BudgetMode() vo.BudgetMode {
+	return u.budgetMode
+}
+
+func (u *User) PrivacySettings() vo.PrivacySettings {
+	return u.privacySettings
+}
+
+// AllowsAIProcessing は食事内容・写真を外部のAIプロバイダーに送信してよいかを返す
+func (u *User) AllowsAIProcessing() bool {
+	return u.privacySettings.AIProcessingEnabled()
+}
+
+// Role はユーザーの権限を返す
+func (u *User) Role() vo.UserRole {
+	return u.role
+}
+
+// IsAdmin は管理者かどうかを返す
+func (u *User) IsAdmin() bool {
+	return u.role.IsAdmin()
+}
+
+// DisabledAt はアカウントを停止した日時を返す（停止中でない場合はnil）
+func (u *User) DisabledAt() *time.Time {
+	return u.disabledAt
+}
+
+// IsDisabled はアカウントが停止中かどうかを返す
+func (u *User) IsDisabled() bool {
+	return u.disabledAt != nil
+}
+
+func (u *User) EmailVerifiedAt() *time.Time {
+	return u.emailVerifiedAt
+}
+
+// IsEmailVerified はメールアドレスの所有確認が完了しているかを返す
+func (u *User) IsEmailVerified() bool {
+	return u.emailVerifiedAt != nil
+}
+
 func (u *User) CreatedAt() time.Time {
 	return u.createdAt
 }
@@ -292,3 +404,125 @@ func (u *User) ApplyProfile(
 	u.activityLevel = activityLevel
 	u.updatedAt = time.Now()
 }
+
+// CorrectDemographics は登録時に入力した性別・生年月日を訂正する（nilの項目は変更しない）
+// 実際に値が変わった項目の変更履歴を返す
+func (u *User) CorrectDemographics(gender *vo.Gender, birthDate *vo.BirthDate) []*ProfileChange {
+	var changes []*ProfileChange
+
+	if gender != nil && gender.String() != u.gender.String() {
+		changes = append(changes, NewProfileChange(u.id, ProfileFieldGender, u.gender.String(), gender.String()))
+		u.gender = *gender
+	}
+
+	if birthDate != nil {
+		oldValue := u.birthDate.Time().Format(dateLayout)
+		newValue := birthDate.Time().Format(dateLayout)
+		if oldValue != newValue {
+			changes = append(changes, NewProfileChange(u.id, ProfileFieldBirthDate, oldValue, newValue))
+			u.birthDate = *birthDate
+		}
+	}
+
+	if len(changes) > 0 {
+		u.updatedAt = time.Now()
+	}
+	return changes
+}
+
+// ChangePassword は現在のパスワードを照合した上で、新しいパスワードのハッシュに置き換える
+// 現在のパスワードが一致しない場合は ErrCurrentPasswordIncorrect を返す
+func (u *User) ChangePassword(currentPassword vo.Password, newPassword vo.Password) error {
+	if !u.hashedPassword.Compare(currentPassword) {
+		return domainErrors.ErrCurrentPasswordIncorrect
+	}
+
+	hashedPassword, err := newPassword.Hash()
+	if err != nil {
+		return err
+	}
+
+	u.hashedPassword = hashedPassword
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// ResetPassword は現在のパスワードを照合せずに新しいパスワードへ置き換える
+// パスワードリセットトークンで本人確認が済んでいる場合にのみ使用する
+func (u *User) ResetPassword(newPassword vo.Password) error {
+	hashedPassword, err := newPassword.Hash()
+	if err != nil {
+		return err
+	}
+
+	u.hashedPassword = hashedPassword
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// SetPassword はパスワード未設定のユーザーに初めてパスワードを設定する
+// 既に設定済みの場合は ErrPasswordAlreadySet を返す（変更は ChangePassword を使う）
+func (u *User) SetPassword(newPassword vo.Password) error {
+	if u.HasPassword() {
+		return domainErrors.ErrPasswordAlreadySet
+	}
+
+	hashedPassword, err := newPassword.Hash()
+	if err != nil {
+		return err
+	}
+
+	u.hashedPassword = hashedPassword
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeBudgetMode はカロリー予算の管理単位（日次/週間）を変更する
+func (u *User) ChangeBudgetMode(budgetMode vo.BudgetMode) {
+	u.budgetMode = budgetMode
+	u.updatedAt = time.Now()
+}
+
+// ChangePrivacySettings はユーザーが同意したプライバシー設定に変更する
+func (u *User) ChangePrivacySettings(settings vo.PrivacySettings) {
+	u.privacySettings = settings
+	u.updatedAt = time.Now()
+}
+
+// ChangeRole はユーザーの権限を変更する
+func (u *User) ChangeRole(role vo.UserRole) {
+	u.role = role
+	u.updatedAt = time.Now()
+}
+
+// Disable はアカウントを停止する（停止中はログインできない）
+// 既に停止中の場合は ErrAccountAlreadyDisabled を返す
+func (u *User) Disable() error {
+	if u.IsDisabled() {
+		return domainErrors.ErrAccountAlreadyDisabled
+	}
+	now := time.Now()
+	u.disabledAt = &now
+	u.updatedAt = now
+	return nil
+}
+
+// Enable は停止したアカウントを再開する
+// 停止中でない場合は ErrAccountNotDisabled を返す
+func (u *User) Enable() error {
+	if !u.IsDisabled() {
+		return domainErrors.ErrAccountNotDisabled
+	}
+	u.disabledAt = nil
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// ConfirmEmail は所有確認が完了したメールアドレスを設定し、確認済みにする
+// 登録時の確認では現在のアドレス、メールアドレス変更では新しいアドレスを渡す
+func (u *User) ConfirmEmail(email vo.Email) {
+	now := time.Now()
+	u.email = email
+	u.emailVerifiedAt = &now
+	u.updatedAt = now
+}
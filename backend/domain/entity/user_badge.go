@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// UserBadge はユーザーが獲得したバッジを表すEntity
+type UserBadge struct {
+	id         vo.UserBadgeID
+	userID     vo.UserID
+	badgeType  vo.BadgeType
+	unlockedAt time.Time
+}
+
+// NewUserBadge は新しいUserBadgeを生成する
+// unlockedAtには獲得条件を満たした日時を指定する
+func NewUserBadge(userID vo.UserID, badgeType vo.BadgeType, unlockedAt time.Time) *UserBadge {
+	return &UserBadge{
+		id:         vo.NewUserBadgeID(),
+		userID:     userID,
+		badgeType:  badgeType,
+		unlockedAt: unlockedAt,
+	}
+}
+
+// ReconstructUserBadge はDBからUserBadgeを復元する
+func ReconstructUserBadge(idStr, userIDStr, badgeTypeStr string, unlockedAt time.Time) *UserBadge {
+	return &UserBadge{
+		id:         vo.ReconstructUserBadgeID(idStr),
+		userID:     vo.ReconstructUserID(userIDStr),
+		badgeType:  vo.ReconstructBadgeType(badgeTypeStr),
+		unlockedAt: unlockedAt,
+	}
+}
+
+func (b *UserBadge) ID() vo.UserBadgeID      { return b.id }
+func (b *UserBadge) UserID() vo.UserID       { return b.userID }
+func (b *UserBadge) BadgeType() vo.BadgeType { return b.badgeType }
+func (b *UserBadge) UnlockedAt() time.Time   { return b.unlockedAt }
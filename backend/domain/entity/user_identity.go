@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// UserIdentity は外部IDプロバイダー（OpenID Connect）のアカウントとユーザーの連携を表すEntity
+// プロバイダー名とプロバイダー内のユーザー識別子（sub）の組で一意になる
+type UserIdentity struct {
+	id        vo.UserIdentityID
+	userID    vo.UserID
+	provider  string
+	subject   string
+	email     string
+	createdAt time.Time
+}
+
+// NewUserIdentity は新しい連携を生成する
+// email は連携時点でプロバイダーが返したメールアドレスで、記録用に保持する
+func NewUserIdentity(userID vo.UserID, provider, subject, email string) *UserIdentity {
+	return &UserIdentity{
+		id:        vo.NewUserIdentityID(),
+		userID:    userID,
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		createdAt: time.Now(),
+	}
+}
+
+// ReconstructUserIdentity はDBからUserIdentityを復元する
+func ReconstructUserIdentity(
+	idStr string,
+	userIDStr string,
+	provider string,
+	subject string,
+	email string,
+	createdAt time.Time,
+) *UserIdentity {
+	return &UserIdentity{
+		id:        vo.ReconstructUserIdentityID(idStr),
+		userID:    vo.ReconstructUserID(userIDStr),
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		createdAt: createdAt,
+	}
+}
+
+func (i *UserIdentity) ID() vo.UserIdentityID { return i.id }
+func (i *UserIdentity) UserID() vo.UserID     { return i.userID }
+func (i *UserIdentity) Provider() string      { return i.provider }
+func (i *UserIdentity) Subject() string       { return i.subject }
+func (i *UserIdentity) Email() string         { return i.email }
+func (i *UserIdentity) CreatedAt() time.Time  { return i.createdAt }
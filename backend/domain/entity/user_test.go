@@ -1,6 +1,7 @@
 package entity_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -108,6 +109,11 @@ func TestReconstructUser_Success(t *testing.T) {
 		birthDate,
 		"male",
 		"moderate",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		createdAt,
 		updatedAt,
 	)
@@ -127,6 +133,34 @@ func TestReconstructUser_Success(t *testing.T) {
 	if !user.UpdatedAt().Equal(updatedAt) {
 		t.Errorf("UpdatedAt = %v, want %v", user.UpdatedAt(), updatedAt)
 	}
+	if user.BudgetMode().String() != "daily" {
+		t.Errorf("BudgetMode = %v, want daily", user.BudgetMode().String())
+	}
+}
+
+func TestReconstructUser_InvalidBudgetMode(t *testing.T) {
+	_, err := entity.ReconstructUser(
+		"550e8400-e29b-41d4-a716-446655440000",
+		"test@example.com",
+		"$2a$10$hashedpassword",
+		"testuser",
+		70.5,
+		175.0,
+		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		"male",
+		"moderate",
+		"monthly",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	if !errors.Is(err, domainErrors.ErrInvalidBudgetMode) {
+		t.Errorf("ReconstructUser() error = %v, want ErrInvalidBudgetMode", err)
+	}
 }
 
 func TestUser_CalculateTargetCalories(t *testing.T) {
@@ -203,6 +237,11 @@ func TestUser_CalculateTargetCalories(t *testing.T) {
 				tt.birthDate,
 				tt.gender,
 				tt.activityLevel,
+				"daily",
+				vo.DefaultPrivacySettings(),
+				vo.UserRoleUser,
+				nil,
+				nil,
 				time.Now(),
 				time.Now(),
 			)
@@ -230,6 +269,11 @@ func TestUser_UpdateProfile_Success(t *testing.T) {
 		birthDate,
 		"male",
 		"sedentary",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	)
@@ -273,6 +317,11 @@ func TestUser_UpdateProfile_ValidationErrors(t *testing.T) {
 		birthDate,
 		"male",
 		"sedentary",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	)
@@ -311,6 +360,11 @@ func TestUser_UpdateProfile_PartialErrors(t *testing.T) {
 		birthDate,
 		"male",
 		"sedentary",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	)
@@ -330,3 +384,303 @@ func TestUser_UpdateProfile_PartialErrors(t *testing.T) {
 		t.Errorf("Height should not change on partial error, got %v", user.Height().Cm())
 	}
 }
+
+func TestUser_ChangeBudgetMode(t *testing.T) {
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("NewUser() unexpected errors: %v", errs)
+	}
+	if user.BudgetMode().IsWeekly() {
+		t.Fatal("default BudgetMode should be daily")
+	}
+
+	beforeUpdate := user.UpdatedAt()
+	weekly, _ := vo.NewBudgetMode("weekly")
+	user.ChangeBudgetMode(weekly)
+
+	if !user.BudgetMode().IsWeekly() {
+		t.Errorf("BudgetMode = %v, want weekly", user.BudgetMode().String())
+	}
+	if user.UpdatedAt().Before(beforeUpdate) {
+		t.Error("UpdatedAt should not go backwards")
+	}
+}
+
+func TestUser_ChangePrivacySettings(t *testing.T) {
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("NewUser() unexpected errors: %v", errs)
+	}
+	if !user.AllowsAIProcessing() {
+		t.Fatal("AI processing should be allowed by default")
+	}
+
+	settings, err := vo.NewPrivacySettings(false, false, 3, "2026-10")
+	if err != nil {
+		t.Fatalf("NewPrivacySettings() unexpected error: %v", err)
+	}
+	user.ChangePrivacySettings(settings)
+
+	if user.AllowsAIProcessing() {
+		t.Error("AI processing should be disallowed after opting out")
+	}
+	if user.PrivacySettings().RetentionYears() != 3 || user.PrivacySettings().ConsentVersion() != "2026-10" {
+		t.Errorf("PrivacySettings = %+v, want 3 years and version 2026-10", user.PrivacySettings())
+	}
+}
+
+func TestUser_ChangeRole(t *testing.T) {
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("NewUser() unexpected errors: %v", errs)
+	}
+	if user.IsAdmin() {
+		t.Fatal("new user should not be an admin")
+	}
+
+	admin, _ := vo.NewUserRole(vo.UserRoleAdmin)
+	user.ChangeRole(admin)
+
+	if !user.IsAdmin() {
+		t.Errorf("Role = %v, want admin", user.Role().String())
+	}
+}
+
+func TestUser_DisableAndEnable(t *testing.T) {
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("NewUser() unexpected errors: %v", errs)
+	}
+	if user.IsDisabled() {
+		t.Fatal("new user should not be disabled")
+	}
+
+	if err := user.Disable(); err != nil {
+		t.Fatalf("Disable() unexpected error: %v", err)
+	}
+	if !user.IsDisabled() || user.DisabledAt() == nil {
+		t.Error("user should be disabled with DisabledAt set")
+	}
+	if err := user.Disable(); !errors.Is(err, domainErrors.ErrAccountAlreadyDisabled) {
+		t.Errorf("Disable() twice error = %v, want ErrAccountAlreadyDisabled", err)
+	}
+
+	if err := user.Enable(); err != nil {
+		t.Fatalf("Enable() unexpected error: %v", err)
+	}
+	if user.IsDisabled() || user.DisabledAt() != nil {
+		t.Error("user should be enabled with DisabledAt cleared")
+	}
+	if err := user.Enable(); !errors.Is(err, domainErrors.ErrAccountNotDisabled) {
+		t.Errorf("Enable() twice error = %v, want ErrAccountNotDisabled", err)
+	}
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	currentPassword, _ := vo.NewPassword("password123")
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	t.Run("現在のパスワードが一致すれば変更される", func(t *testing.T) {
+		user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+		if errs != nil {
+			t.Fatalf("NewUser() unexpected errors: %v", errs)
+		}
+
+		if err := user.ChangePassword(currentPassword, newPassword); err != nil {
+			t.Fatalf("ChangePassword() unexpected error: %v", err)
+		}
+		if !user.HashedPassword().Compare(newPassword) {
+			t.Error("new password should match")
+		}
+		if user.HashedPassword().Compare(currentPassword) {
+			t.Error("old password should not match")
+		}
+	})
+
+	t.Run("現在のパスワードが一致しなければエラー", func(t *testing.T) {
+		user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+		if errs != nil {
+			t.Fatalf("NewUser() unexpected errors: %v", errs)
+		}
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		err := user.ChangePassword(wrongPassword, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+			t.Errorf("ChangePassword() error = %v, want ErrCurrentPasswordIncorrect", err)
+		}
+		if !user.HashedPassword().Compare(currentPassword) {
+			t.Error("password should not change on error")
+		}
+	})
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("NewUser() unexpected errors: %v", errs)
+	}
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	if err := user.ResetPassword(newPassword); err != nil {
+		t.Fatalf("ResetPassword() unexpected error: %v", err)
+	}
+	if !user.HashedPassword().Compare(newPassword) {
+		t.Error("new password should match")
+	}
+}
+
+// testUserWithoutPassword はテスト用のパスワード未設定ユーザーを生成する
+func testUserWithoutPassword(t *testing.T) *entity.User {
+	t.Helper()
+	email, _ := vo.NewEmail("oidc@example.com")
+	nickname, _ := vo.NewNickname("testuser")
+	weight, _ := vo.NewWeight(70.5)
+	height, _ := vo.NewHeight(175.0)
+	birthDate, _ := vo.NewBirthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+	gender, _ := vo.NewGender("male")
+	activityLevel, _ := vo.NewActivityLevel("moderate")
+	return entity.NewUserWithoutPassword(email, nickname, weight, height, birthDate, gender, activityLevel)
+}
+
+func TestNewUserWithoutPassword(t *testing.T) {
+	user := testUserWithoutPassword(t)
+
+	if user.HasPassword() {
+		t.Error("user should not have a password")
+	}
+	if !user.IsEmailVerified() {
+		t.Error("user should be verified")
+	}
+	anyPassword, _ := vo.NewPassword("password123")
+	if user.HashedPassword().Compare(anyPassword) {
+		t.Error("no password should match an empty hash")
+	}
+}
+
+func TestUser_SetPassword(t *testing.T) {
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	t.Run("パスワード未設定なら設定できる", func(t *testing.T) {
+		user := testUserWithoutPassword(t)
+
+		if err := user.SetPassword(newPassword); err != nil {
+			t.Fatalf("SetPassword() unexpected error: %v", err)
+		}
+		if !user.HasPassword() || !user.HashedPassword().Compare(newPassword) {
+			t.Error("new password should match")
+		}
+	})
+
+	t.Run("設定済みならエラー", func(t *testing.T) {
+		user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+		if errs != nil {
+			t.Fatalf("NewUser() unexpected errors: %v", errs)
+		}
+
+		err := user.SetPassword(newPassword)
+
+		if !errors.Is(err, domainErrors.ErrPasswordAlreadySet) {
+			t.Errorf("SetPassword() error = %v, want ErrPasswordAlreadySet", err)
+		}
+	})
+}
+
+func TestUser_ConfirmEmail(t *testing.T) {
+	t.Run("登録時は未確認", func(t *testing.T) {
+		user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+		if errs != nil {
+			t.Fatalf("NewUser() unexpected errors: %v", errs)
+		}
+		if user.IsEmailVerified() {
+			t.Error("new user should not be verified")
+		}
+	})
+
+	t.Run("確認したアドレスに切り替わり確認済みになる", func(t *testing.T) {
+		user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+		if errs != nil {
+			t.Fatalf("NewUser() unexpected errors: %v", errs)
+		}
+		newEmail, _ := vo.NewEmail("new@example.com")
+
+		user.ConfirmEmail(newEmail)
+
+		if !user.Email().Equals(newEmail) {
+			t.Errorf("Email = %s, want %s", user.Email().String(), newEmail.String())
+		}
+		if !user.IsEmailVerified() || user.EmailVerifiedAt() == nil {
+			t.Error("user should be verified")
+		}
+	})
+}
+
+func TestUser_CorrectDemographics(t *testing.T) {
+	newUser := func(t *testing.T) *entity.User {
+		t.Helper()
+		user, err := entity.ReconstructUser(
+			"550e8400-e29b-41d4-a716-446655440000",
+			"test@example.com",
+			"$2a$10$hashedpassword",
+			"nick",
+			60.0,
+			165.0,
+			time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			"male",
+			"sedentary",
+			"daily",
+			vo.DefaultPrivacySettings(),
+			vo.UserRoleUser,
+			nil,
+			nil,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		)
+		if err != nil {
+			t.Fatalf("ReconstructUser() unexpected error: %v", err)
+		}
+		return user
+	}
+
+	t.Run("変更した項目の履歴を返す", func(t *testing.T) {
+		user := newUser(t)
+		gender, _ := vo.NewGender("female")
+		birthDate, _ := vo.NewBirthDate(time.Date(1991, 2, 3, 0, 0, 0, 0, time.UTC))
+		beforeCalories := user.CalculateTargetCalories()
+
+		changes := user.CorrectDemographics(&gender, &birthDate)
+
+		if len(changes) != 2 {
+			t.Fatalf("got %d changes, want 2", len(changes))
+		}
+		if changes[0].Field() != entity.ProfileFieldGender || changes[0].OldValue() != "male" || changes[0].NewValue() != "female" {
+			t.Errorf("changes[0] = %s: %s -> %s", changes[0].Field(), changes[0].OldValue(), changes[0].NewValue())
+		}
+		if changes[1].Field() != entity.ProfileFieldBirthDate || changes[1].OldValue() != "1990-01-01" || changes[1].NewValue() != "1991-02-03" {
+			t.Errorf("changes[1] = %s: %s -> %s", changes[1].Field(), changes[1].OldValue(), changes[1].NewValue())
+		}
+		if user.Gender().String() != "female" {
+			t.Errorf("Gender = %v, want female", user.Gender().String())
+		}
+		if user.CalculateTargetCalories() == beforeCalories {
+			t.Error("target calories should be recalculated with the corrected values")
+		}
+		if !user.UpdatedAt().After(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Error("UpdatedAt should be updated")
+		}
+	})
+
+	t.Run("nilや同じ値の項目は変更しない", func(t *testing.T) {
+		user := newUser(t)
+		gender, _ := vo.NewGender("male")
+
+		changes := user.CorrectDemographics(&gender, nil)
+
+		if len(changes) != 0 {
+			t.Errorf("got %d changes, want 0", len(changes))
+		}
+		if !user.UpdatedAt().Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Error("UpdatedAt should not change")
+		}
+	})
+}
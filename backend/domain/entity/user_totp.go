@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// TOTPIssuer は認証アプリに表示するサービス名
+const TOTPIssuer = "CalTrack"
+
+// UserTOTP はユーザーのTOTP（認証アプリによる2段階認証）の設定を表すEntity
+// 登録開始時点では無効で、認証アプリが生成したコードを確認した時点で有効になる
+type UserTOTP struct {
+	userID       vo.UserID
+	secret       vo.TOTPSecret
+	enabledAt    *time.Time
+	lastUsedStep int64
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewUserTOTP は新しいシークレットで未確認のTOTP設定を生成する
+func NewUserTOTP(userID vo.UserID) (*UserTOTP, error) {
+	secret, err := vo.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &UserTOTP{
+		userID:    userID,
+		secret:    secret,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstructUserTOTP はDBからUserTOTPを復元する
+func ReconstructUserTOTP(
+	userIDStr string,
+	secret string,
+	enabledAt *time.Time,
+	lastUsedStep int64,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *UserTOTP {
+	return &UserTOTP{
+		userID:       vo.ReconstructUserID(userIDStr),
+		secret:       vo.ReconstructTOTPSecret(secret),
+		enabledAt:    enabledAt,
+		lastUsedStep: lastUsedStep,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (t *UserTOTP) UserID() vo.UserID     { return t.userID }
+func (t *UserTOTP) Secret() vo.TOTPSecret { return t.secret }
+func (t *UserTOTP) EnabledAt() *time.Time { return t.enabledAt }
+func (t *UserTOTP) LastUsedStep() int64   { return t.lastUsedStep }
+func (t *UserTOTP) CreatedAt() time.Time  { return t.createdAt }
+func (t *UserTOTP) UpdatedAt() time.Time  { return t.updatedAt }
+func (t *UserTOTP) IsEnabled() bool       { return t.enabledAt != nil }
+
+// ProvisioningURI は認証アプリ登録用の otpauth:// URI を返す
+func (t *UserTOTP) ProvisioningURI(email vo.Email) string {
+	return t.secret.ProvisioningURI(TOTPIssuer, email.String())
+}
+
+// Enable は認証アプリが生成したコードを確認してTOTPを有効にする
+// 有効化済みの場合は ErrTOTPAlreadyEnabled、コードが一致しない場合は ErrInvalidTOTPCode を返す
+func (t *UserTOTP) Enable(code string) error {
+	if t.IsEnabled() {
+		return domainErrors.ErrTOTPAlreadyEnabled
+	}
+
+	now := time.Now()
+	step, ok := t.secret.Verify(code, now)
+	if !ok {
+		return domainErrors.ErrInvalidTOTPCode
+	}
+
+	t.enabledAt = &now
+	t.lastUsedStep = step
+	t.updatedAt = now
+	return nil
+}
+
+// VerifyCode はログイン時のコードを検証する
+// 一度使ったタイムステップ以前のコードは、盗み見たコードの再利用を防ぐため受け付けない
+func (t *UserTOTP) VerifyCode(code string) error {
+	if !t.IsEnabled() {
+		return domainErrors.ErrTOTPNotEnabled
+	}
+
+	now := time.Now()
+	step, ok := t.secret.Verify(code, now)
+	if !ok || step <= t.lastUsedStep {
+		return domainErrors.ErrInvalidTOTPCode
+	}
+
+	t.lastUsedStep = step
+	t.updatedAt = now
+	return nil
+}
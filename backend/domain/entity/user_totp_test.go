@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewUserTOTP(t *testing.T) {
+	userID := vo.NewUserID()
+
+	totp, err := entity.NewUserTOTP(userID)
+
+	if err != nil {
+		t.Fatalf("NewUserTOTP() unexpected error: %v", err)
+	}
+	if !totp.UserID().Equals(userID) {
+		t.Errorf("UserID = %v, want %v", totp.UserID(), userID)
+	}
+	if totp.Secret().String() == "" {
+		t.Error("Secret should not be empty")
+	}
+	if totp.IsEnabled() {
+		t.Error("TOTP should not be enabled before confirmation")
+	}
+}
+
+func TestUserTOTP_Enable(t *testing.T) {
+	t.Run("正しいコードで有効になる", func(t *testing.T) {
+		totp, _ := entity.NewUserTOTP(vo.NewUserID())
+
+		if err := totp.Enable(totp.Secret().Code(time.Now())); err != nil {
+			t.Fatalf("Enable() unexpected error: %v", err)
+		}
+		if !totp.IsEnabled() {
+			t.Error("TOTP should be enabled")
+		}
+		if totp.LastUsedStep() != vo.TOTPStep(time.Now()) {
+			t.Errorf("LastUsedStep = %d, want %d", totp.LastUsedStep(), vo.TOTPStep(time.Now()))
+		}
+	})
+
+	t.Run("誤ったコードはエラー", func(t *testing.T) {
+		totp, _ := entity.NewUserTOTP(vo.NewUserID())
+
+		if err := totp.Enable("abcdef"); err != domainErrors.ErrInvalidTOTPCode {
+			t.Errorf("Enable() error = %v, want %v", err, domainErrors.ErrInvalidTOTPCode)
+		}
+		if totp.IsEnabled() {
+			t.Error("TOTP should not be enabled")
+		}
+	})
+
+	t.Run("有効化済みはエラー", func(t *testing.T) {
+		totp := enabledUserTOTP(t, 0)
+
+		if err := totp.Enable(totp.Secret().Code(time.Now())); err != domainErrors.ErrTOTPAlreadyEnabled {
+			t.Errorf("Enable() error = %v, want %v", err, domainErrors.ErrTOTPAlreadyEnabled)
+		}
+	})
+}
+
+func TestUserTOTP_VerifyCode(t *testing.T) {
+	t.Run("正しいコードを受け付け、使用したステップを記録する", func(t *testing.T) {
+		totp := enabledUserTOTP(t, 0)
+
+		if err := totp.VerifyCode(totp.Secret().Code(time.Now())); err != nil {
+			t.Fatalf("VerifyCode() unexpected error: %v", err)
+		}
+		if totp.LastUsedStep() != vo.TOTPStep(time.Now()) {
+			t.Errorf("LastUsedStep = %d, want %d", totp.LastUsedStep(), vo.TOTPStep(time.Now()))
+		}
+	})
+
+	t.Run("使用済みのコードは再利用できない", func(t *testing.T) {
+		totp := enabledUserTOTP(t, 0)
+		code := totp.Secret().Code(time.Now())
+
+		if err := totp.VerifyCode(code); err != nil {
+			t.Fatalf("VerifyCode() unexpected error: %v", err)
+		}
+		if err := totp.VerifyCode(code); err != domainErrors.ErrInvalidTOTPCode {
+			t.Errorf("VerifyCode() error = %v, want %v", err, domainErrors.ErrInvalidTOTPCode)
+		}
+	})
+
+	t.Run("誤ったコードはエラー", func(t *testing.T) {
+		totp := enabledUserTOTP(t, 0)
+
+		if err := totp.VerifyCode("abcdef"); err != domainErrors.ErrInvalidTOTPCode {
+			t.Errorf("VerifyCode() error = %v, want %v", err, domainErrors.ErrInvalidTOTPCode)
+		}
+	})
+
+	t.Run("未有効化はエラー", func(t *testing.T) {
+		totp, _ := entity.NewUserTOTP(vo.NewUserID())
+
+		if err := totp.VerifyCode(totp.Secret().Code(time.Now())); err != domainErrors.ErrTOTPNotEnabled {
+			t.Errorf("VerifyCode() error = %v, want %v", err, domainErrors.ErrTOTPNotEnabled)
+		}
+	})
+}
+
+func TestUserTOTP_ProvisioningURI(t *testing.T) {
+	totp, _ := entity.NewUserTOTP(vo.NewUserID())
+	email, _ := vo.NewEmail("user@example.com")
+
+	want := totp.Secret().ProvisioningURI(entity.TOTPIssuer, "user@example.com")
+	if got := totp.ProvisioningURI(email); got != want {
+		t.Errorf("ProvisioningURI() = %s, want %s", got, want)
+	}
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	userID := vo.NewUserID()
+
+	codes, raws, err := entity.NewRecoveryCodes(userID)
+
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() unexpected error: %v", err)
+	}
+	if len(codes) != entity.RecoveryCodeCount || len(raws) != entity.RecoveryCodeCount {
+		t.Fatalf("len = %d/%d, want %d", len(codes), len(raws), entity.RecoveryCodeCount)
+	}
+	for i, code := range codes {
+		if !code.UserID().Equals(userID) {
+			t.Errorf("UserID = %v, want %v", code.UserID(), userID)
+		}
+		if code.CodeHash() != raws[i].Hash() {
+			t.Error("CodeHash should be the hash of the raw code")
+		}
+		if code.UsedAt() != nil {
+			t.Error("UsedAt should be nil")
+		}
+	}
+}
+
+func TestRecoveryCode_Use(t *testing.T) {
+	code := entity.ReconstructRecoveryCode(vo.NewRecoveryCodeID().String(), vo.NewUserID().String(), "hash", nil, time.Now())
+
+	if err := code.Use(); err != nil {
+		t.Fatalf("Use() unexpected error: %v", err)
+	}
+	if code.UsedAt() == nil {
+		t.Error("UsedAt should be set after Use()")
+	}
+	if err := code.Use(); err != domainErrors.ErrInvalidRecoveryCode {
+		t.Errorf("Use() error = %v, want %v", err, domainErrors.ErrInvalidRecoveryCode)
+	}
+}
+
+// enabledUserTOTP は有効化済みのTOTP設定を生成する
+func enabledUserTOTP(t *testing.T, lastUsedStep int64) *entity.UserTOTP {
+	t.Helper()
+	secret, err := vo.NewTOTPSecret()
+	if err != nil {
+		t.Fatalf("failed to create totp secret: %v", err)
+	}
+	enabledAt := time.Now().Add(-time.Hour)
+	return entity.ReconstructUserTOTP(vo.NewUserID().String(), secret.String(), &enabledAt, lastUsedStep, enabledAt, enabledAt)
+}
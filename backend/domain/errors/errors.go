@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrEmailRequired          = errors.New("email is required")
@@ -16,18 +19,80 @@ var (
 	ErrHeightTooTall          = errors.New("height must be 300cm or less")
 	ErrBirthDateMustBePast    = errors.New("birth date must be in the past")
 	ErrBirthDateTooOld        = errors.New("birth date must be within 150 years")
+	ErrInvalidBirthDateFormat = errors.New("birth date must be in YYYY-MM-DD format")
 	ErrEatenAtMustNotBeFuture = errors.New("eaten at must not be in the future")
 	ErrCaloriesMustBePositive = errors.New("calories must be positive")
 	ErrInvalidGender          = errors.New("gender must be male, female, or other")
 	ErrInvalidActivityLevel   = errors.New("activity level must be sedentary, light, moderate, active, or veryActive")
+	ErrInvalidBudgetMode      = errors.New("budget mode must be daily or weekly")
 
 	// Usecase errors
 	ErrEmailAlreadyExists = errors.New("email already exists")
 
 	// Auth errors
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrSessionNotFound    = errors.New("session not found")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrSessionNotFound      = errors.New("session not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+
+	// Password errors
+	ErrCurrentPasswordIncorrect  = errors.New("current password is incorrect")
+	ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+	ErrPasswordAlreadySet        = errors.New("password is already set")
+
+	// Email verification errors
+	ErrEmailNotVerified              = errors.New("email address is not verified")
+	ErrEmailAlreadyVerified          = errors.New("email address is already verified")
+	ErrEmailUnchanged                = errors.New("new email must be different from the current email")
+	ErrEmailVerificationTokenInvalid = errors.New("email verification token is invalid or expired")
+
+	// Two-factor authentication errors
+	ErrTOTPSecretGenerationFailed   = errors.New("failed to generate totp secret")
+	ErrTOTPAlreadyEnabled           = errors.New("totp is already enabled")
+	ErrTOTPNotEnabled               = errors.New("totp is not enabled")
+	ErrTOTPEnrollmentNotFound       = errors.New("totp enrollment not found")
+	ErrInvalidTOTPCode              = errors.New("invalid totp code")
+	ErrRecoveryCodeGenerationFailed = errors.New("failed to generate recovery code")
+	ErrInvalidRecoveryCode          = errors.New("invalid recovery code")
+	ErrTwoFactorTokenInvalid        = errors.New("two-factor token is invalid or expired")
+
+	// OpenID Connect errors
+	ErrOIDCProviderNotFound   = errors.New("oidc provider not found")
+	ErrOIDCStateInvalid       = errors.New("oidc state is invalid or expired")
+	ErrOIDCTokenInvalid       = errors.New("oidc id token is invalid")
+	ErrOIDCExchangeFailed     = errors.New("failed to exchange oidc authorization code")
+	ErrOIDCEmailNotVerified   = errors.New("oidc provider did not return a verified email")
+	ErrOIDCSignupTokenInvalid = errors.New("oidc signup token is invalid or expired")
+
+	// Privacy settings errors
+	ErrInvalidDataRetention   = errors.New("data retention must be between 0 and 10 years")
+	ErrConsentVersionRequired = errors.New("consent version is required")
+	ErrConsentVersionTooLong  = errors.New("consent version must be 20 characters or less")
+	ErrAIProcessingDisabled   = errors.New("ai processing is disabled in privacy settings")
+	ErrEatenAtBeforeRetention = errors.New("eaten at must be within the data retention period")
+
+	// Account deletion errors
+	ErrAccountDeletionAlreadyScheduled = errors.New("account deletion is already scheduled")
+	ErrAccountDeletionNotPending       = errors.New("account deletion is not pending")
+
+	// Admin errors
+	ErrInvalidUserRole        = errors.New("role must be user or admin")
+	ErrAdminRequired          = errors.New("admin role is required")
+	ErrAccountDisabled        = errors.New("account is disabled")
+	ErrAccountAlreadyDisabled = errors.New("account is already disabled")
+	ErrAccountNotDisabled     = errors.New("account is not disabled")
+	ErrCannotDisableSelf      = errors.New("admins cannot disable their own account")
+
+	// Personal access token errors
+	ErrAccessTokenGenerationFailed   = errors.New("failed to generate access token")
+	ErrInvalidAccessToken            = errors.New("access token is invalid or expired")
+	ErrAccessTokenNameRequired       = errors.New("token name is required")
+	ErrAccessTokenNameTooLong        = errors.New("token name must be 100 characters or less")
+	ErrAccessTokenScopeRequired      = errors.New("at least one scope is required")
+	ErrInvalidTokenScope             = errors.New("scope must be records:read, records:write, or profile:read")
+	ErrAccessTokenExpiryMustBeFuture = errors.New("token expiry must be in the future")
+	ErrPersonalAccessTokenNotFound   = errors.New("personal access token not found")
+	ErrInvalidPersonalAccessTokenID  = errors.New("invalid personal access token id")
 
 	// Session errors
 	ErrSessionIDGenerationFailed = errors.New("failed to generate session id")
@@ -39,21 +104,55 @@ var (
 	ErrInvalidUUIDFormat = errors.New("invalid uuid format")
 
 	// ID errors
-	ErrInvalidUserID        = errors.New("invalid user id")
-	ErrInvalidRecordID      = errors.New("invalid record id")
-	ErrInvalidRecordItemID  = errors.New("invalid record item id")
-	ErrInvalidRecordPfcID   = errors.New("invalid record pfc id")
-	ErrInvalidAdviceCacheID = errors.New("invalid advice cache id")
+	ErrInvalidUserID           = errors.New("invalid user id")
+	ErrInvalidRecordID         = errors.New("invalid record id")
+	ErrInvalidRecordItemID     = errors.New("invalid record item id")
+	ErrInvalidRecordPfcID      = errors.New("invalid record pfc id")
+	ErrInvalidAdviceCacheID    = errors.New("invalid advice cache id")
+	ErrInvalidTargetSnapshotID = errors.New("invalid target snapshot id")
+	ErrInvalidUserBadgeID      = errors.New("invalid user badge id")
+
+	// One-time token errors
+	ErrOneTimeTokenGenerationFailed = errors.New("failed to generate one-time token")
+	ErrInvalidOneTimeToken          = errors.New("invalid one-time token")
 
 	// Record Item errors
 	ErrItemNameRequired = errors.New("item name is required")
 
+	// Record import errors
+	ErrImportPresetNotFound        = errors.New("import preset must be caltrack, myfitnesspal, cronometer, or loseit")
+	ErrImportColumnMappingRequired = errors.New("date, name, and calories columns are required")
+	ErrImportColumnNotFound        = errors.New("mapped column not found in csv header")
+	ErrImportFileEmpty             = errors.New("csv file has no data rows")
+	ErrImportTooManyRows           = errors.New("csv file must have 5000 rows or less")
+	ErrInvalidImportCSV            = errors.New("csv file is malformed")
+
 	// Statistics errors
 	ErrInvalidStatisticsPeriod = errors.New("statistics period must be week or month")
 
+	// Calendar errors
+	ErrInvalidCalendarMonth = errors.New("month must be in YYYY-MM format")
+
+	// Achievement errors
+	ErrInvalidBadgeType = errors.New("invalid badge type")
+
 	// 画像解析関連エラー
 	ErrImageDataRequired   = errors.New("画像データは必須です")
 	ErrMimeTypeRequired    = errors.New("MIMEタイプは必須です")
 	ErrNoFoodDetected      = errors.New("食品を検出できませんでした")
 	ErrImageAnalysisFailed = errors.New("画像解析に失敗しました")
 )
+
+// LoginThrottledError はログイン試行が制限中であることを表すエラー
+// errors.Is で ErrTooManyLoginAttempts と判定でき、再試行までの時間を保持する
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
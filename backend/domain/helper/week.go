@@ -0,0 +1,23 @@
+package helper
+
+import "time"
+
+// DaysPerWeek は1週間の日数
+const DaysPerWeek = 7
+
+// weekdayOffset は月曜始まりでの曜日のオフセット（月曜=0、日曜=6）を返す
+func weekdayOffset(t time.Time) int {
+	return (int(t.Weekday()) + 6) % DaysPerWeek
+}
+
+// StartOfWeek は指定日時を含む週（月曜始まり）の初日0時をJSTで返す
+func StartOfWeek(t time.Time) time.Time {
+	jstTime := t.In(jst)
+	return time.Date(jstTime.Year(), jstTime.Month(), jstTime.Day()-weekdayOffset(jstTime), 0, 0, 0, 0, jst)
+}
+
+// DaysLeftInWeek は指定日を含む週の残り日数（当日を含む）を返す
+// 月曜は7、日曜は1となる
+func DaysLeftInWeek(t time.Time) int {
+	return DaysPerWeek - weekdayOffset(t.In(jst))
+}
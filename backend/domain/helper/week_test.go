@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	wantMonday := time.Date(2025, 3, 10, 0, 0, 0, 0, JST())
+
+	tests := []struct {
+		name  string
+		input time.Time
+		want  time.Time
+	}{
+		{"月曜はその日", time.Date(2025, 3, 10, 8, 0, 0, 0, JST()), wantMonday},
+		{"水曜は同じ週の月曜", time.Date(2025, 3, 12, 23, 59, 0, 0, JST()), wantMonday},
+		{"日曜は前日までの週の月曜", time.Date(2025, 3, 16, 12, 0, 0, 0, JST()), wantMonday},
+		{"UTCで日曜でもJSTで月曜なら翌週", time.Date(2025, 3, 16, 15, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 0, 0, 0, 0, JST())},
+		{"月をまたぐ週", time.Date(2025, 4, 2, 12, 0, 0, 0, JST()), time.Date(2025, 3, 31, 0, 0, 0, 0, JST())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StartOfWeek(tt.input)
+			if !got.Equal(tt.want) {
+				t.Errorf("StartOfWeek(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysLeftInWeek(t *testing.T) {
+	tests := []struct {
+		name  string
+		input time.Time
+		want  int
+	}{
+		{"月曜は7日", time.Date(2025, 3, 10, 0, 0, 0, 0, JST()), 7},
+		{"木曜は4日", time.Date(2025, 3, 13, 12, 0, 0, 0, JST()), 4},
+		{"日曜は1日", time.Date(2025, 3, 16, 23, 0, 0, 0, JST()), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysLeftInWeek(tt.input); got != tt.want {
+				t.Errorf("DaysLeftInWeek(%v) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// AccountDeletionRepository はアカウント削除の申請の永続化を担当するリポジトリインターフェース
+type AccountDeletionRepository interface {
+	// Save は新しい削除の申請を保存する
+	Save(ctx context.Context, deletion *entity.AccountDeletion) error
+	// Update は削除の申請の取り消し・削除完了を保存する
+	Update(ctx context.Context, deletion *entity.AccountDeletion) error
+	// FindPendingByUserID はユーザーの削除待ちの申請を取得する（存在しない場合はnil）
+	FindPendingByUserID(ctx context.Context, userID vo.UserID) (*entity.AccountDeletion, error)
+	// FindDue は指定時刻までに猶予期間が終わった削除待ちの申請を予定日時の古い順に最大limit件取得する
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.AccountDeletion, error)
+}
+
+// AccountPurgeRepository はユーザーに紐づく全データの削除を担当するリポジトリインターフェース
+type AccountPurgeRepository interface {
+	// PurgeByUserID はユーザーと、記録・PFC・アドバイスキャッシュ・セッションなどの関連データを全て削除する
+	// 戻り値はテーブル名ごとの削除件数（監査のため記録する）
+	PurgeByUserID(ctx context.Context, userID vo.UserID) (map[string]int64, error)
+}
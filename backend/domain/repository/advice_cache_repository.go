@@ -13,4 +13,10 @@ type AdviceCacheRepository interface {
 	Save(ctx context.Context, cache *entity.AdviceCache) error
 	FindByUserIDAndDate(ctx context.Context, userID vo.UserID, date time.Time) (*entity.AdviceCache, error)
 	DeleteByUserIDAndDate(ctx context.Context, userID vo.UserID, date time.Time) error
+	FindCacheDatesByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]time.Time, error)
+	// ForEachBatchByUserID は指定ユーザーの全アドバイスを日付の昇順でbatchSize件ずつ取得し、fnに渡す
+	// fnがエラーを返した場合は中断してそのエラーを返す
+	ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func(caches []*entity.AdviceCache) error) error
+	// DeleteCreatedBefore は作成日時がbeforeより前の全ユーザーのアドバイスを削除し、削除件数を返す
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// AIUsageSummary はAI機能ごとのトークン使用量の集計
+type AIUsageSummary struct {
+	Feature      entity.AIFeature
+	Requests     int64
+	PromptTokens int64
+	OutputTokens int64
+	TotalTokens  int64
+}
+
+// AIUsageRepository はAI機能のトークン使用量の永続化を担当するリポジトリインターフェース
+type AIUsageRepository interface {
+	// Save はAI使用履歴を保存する
+	Save(ctx context.Context, usage *entity.AIUsage) error
+	// SummarizeByUserID は指定ユーザーのsince以降の使用量をAI機能ごとに集計する
+	// 使用履歴がないAI機能は結果に含まない
+	SummarizeByUserID(ctx context.Context, userID vo.UserID, since time.Time) ([]AIUsageSummary, error)
+}
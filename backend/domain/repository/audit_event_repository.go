@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// AuditEventRepository は監査イベントの永続化を担当するリポジトリインターフェース
+// 監査ログは追記のみで、更新・個別の削除は行わない（アカウントの完全削除時のみまとめて削除する）
+type AuditEventRepository interface {
+	// Save は監査イベントを保存する
+	Save(ctx context.Context, event *entity.AuditEvent) error
+	// FindByUserID は指定ユーザーのアカウントの監査イベントを発生日時の新しい順にlimit件取得する
+	// beforeを指定した場合はそれより前のイベントのみを返す（ページング用）
+	FindByUserID(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error)
+}
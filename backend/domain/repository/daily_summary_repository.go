@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// DailySummaryRepository は日別サマリーの永続化を担当するリポジトリインターフェース
+type DailySummaryRepository interface {
+	// Refresh は指定ユーザーの指定日の記録を再集計してサマリーを更新する
+	// dateにはその日の0時を指定する。記録が1件もない日はサマリーを削除する
+	// 記録の作成・編集と同じトランザクション内で呼び出すこと
+	Refresh(ctx context.Context, userID vo.UserID, date time.Time) error
+	// RebuildByUserID は指定ユーザーのサマリーを記録から作り直す
+	// 記録がある最初の日より前のサマリー（保存期間を過ぎて記録を削除した期間の集計）は残す
+	RebuildByUserID(ctx context.Context, userID vo.UserID) error
+	// RebuildAll は全ユーザーのサマリーを記録から作り直す（バックフィル用）
+	// RebuildByUserID と同じく、記録を削除した期間のサマリーは残す
+	RebuildAll(ctx context.Context) error
+	// FindByUserID は指定ユーザーの全期間のサマリーを日付の昇順で取得する（実績集計用）
+	FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.DailySummary, error)
+	// FindByUserIDAndDateRange は指定期間（startDate以上、endDate未満）のサマリーを日付の昇順で取得する
+	// 記録がない日のサマリーは含まれない
+	FindByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.DailySummary, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+// JobRunRepository はジョブの実行履歴の永続化を担当するリポジトリインターフェース
+type JobRunRepository interface {
+	// Save は実行を開始したジョブの実行履歴を保存する
+	Save(ctx context.Context, run *entity.JobRun) error
+	// Update はジョブの実行結果（状態・終了日時・処理件数・エラー）を保存する
+	Update(ctx context.Context, run *entity.JobRun) error
+	// FindLatestByJobName は指定ジョブの最後に開始した実行履歴を取得する（存在しない場合はnil）
+	FindLatestByJobName(ctx context.Context, jobName string) (*entity.JobRun, error)
+}
+
+// JobLockRepository は複数のプロセスで同じジョブを同時に実行しないためのロックを担当するリポジトリインターフェース
+type JobLockRepository interface {
+	// TryAcquire は指定ジョブのロックの取得を試みる
+	// 他のプロセスが有効期限内のロックを持っている場合はfalseを返す
+	// プロセスが異常終了してもロックが残り続けないよう、ttl経過後は他のプロセスが取得できる
+	TryAcquire(ctx context.Context, jobName, owner string, now time.Time, ttl time.Duration) (bool, error)
+	// Release は自分が持っている指定ジョブのロックを解放する
+	Release(ctx context.Context, jobName, owner string) error
+}
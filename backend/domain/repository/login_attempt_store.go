@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+// LoginAttemptStore はログイン失敗の状況を保持するストアのインターフェース
+// 複数台構成ではDB実装、テストや単一ノード構成ではインメモリ実装を使う
+type LoginAttemptStore interface {
+	// Find は指定キーの失敗状況を取得する（記録がない場合はnil）
+	Find(ctx context.Context, key string) (*entity.LoginAttempt, error)
+	// RecordFailure は失敗を1回加算し、加算後の状況を返す
+	// 最後の失敗から resetAfter 以上経過している場合は1回目として記録し直す
+	RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (*entity.LoginAttempt, error)
+	// Reset は指定キーの失敗状況を削除する
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+)
+
+// OIDCAuthRequestRepository はOpenID Connectの認可リクエストの永続化を担当するリポジトリインターフェース
+type OIDCAuthRequestRepository interface {
+	// Save は認可リクエストを保存する
+	Save(ctx context.Context, req *entity.OIDCAuthRequest) error
+	// FindByStateHash はstateのハッシュで認可リクエストを取得する（存在しない場合はnil）
+	FindByStateHash(ctx context.Context, stateHash string) (*entity.OIDCAuthRequest, error)
+	// MarkUsed は認可リクエストの使用日時を保存する
+	MarkUsed(ctx context.Context, req *entity.OIDCAuthRequest) error
+}
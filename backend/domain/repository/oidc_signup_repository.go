@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+)
+
+// OIDCSignupRepository はOpenID Connectでの登録待ちの永続化を担当するリポジトリインターフェース
+type OIDCSignupRepository interface {
+	// Save は登録待ちを保存する
+	Save(ctx context.Context, signup *entity.OIDCSignup) error
+	// FindByTokenHash はトークンハッシュで登録待ちを取得する（存在しない場合はnil）
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.OIDCSignup, error)
+	// MarkUsed は登録待ちの使用日時を保存する
+	MarkUsed(ctx context.Context, signup *entity.OIDCSignup) error
+}
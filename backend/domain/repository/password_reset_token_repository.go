@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// PasswordResetTokenRepository はパスワードリセットトークンの永続化を担当するリポジトリインターフェース
+type PasswordResetTokenRepository interface {
+	// Save はトークンを保存する
+	Save(ctx context.Context, token *entity.PasswordResetToken) error
+	// FindByTokenHash はトークンハッシュでトークンを取得する（存在しない場合はnil）
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	// MarkUsed はトークンの使用日時を保存する
+	MarkUsed(ctx context.Context, token *entity.PasswordResetToken) error
+	// DeleteByUserID はユーザーの全トークンを削除する（再発行時に古いトークンを無効化する）
+	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+}
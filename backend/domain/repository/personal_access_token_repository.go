@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// PersonalAccessTokenRepository はパーソナルアクセストークンの永続化を担当するリポジトリインターフェース
+type PersonalAccessTokenRepository interface {
+	// Save はトークンを保存する
+	Save(ctx context.Context, token *entity.PersonalAccessToken) error
+	// FindByTokenHash はトークンハッシュでトークンを取得する（存在しない場合はnil）
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+	// FindByUserID はユーザーの全トークンを作成日時の降順で取得する
+	FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error)
+	// UpdateLastUsedAt はトークンの最終利用日時を保存する
+	UpdateLastUsedAt(ctx context.Context, token *entity.PersonalAccessToken) error
+	// DeleteByID はトークンを削除する
+	DeleteByID(ctx context.Context, id vo.PersonalAccessTokenID) error
+	// DeleteByUserID はユーザーの全トークンを削除する
+	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// ProfileChangeRepository はプロフィールの変更履歴の永続化を担当するリポジトリインターフェース
+// 変更履歴は追記のみで、更新・個別の削除は行わない
+type ProfileChangeRepository interface {
+	// Save は変更履歴を保存する
+	Save(ctx context.Context, change *entity.ProfileChange) error
+	// FindByUserID は指定ユーザーの変更履歴を変更日時の新しい順に取得する
+	FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.ProfileChange, error)
+}
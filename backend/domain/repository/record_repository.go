@@ -8,12 +8,6 @@ import (
 	"caltrack/domain/vo"
 )
 
-// DailyCalories は日別カロリー集計結果
-type DailyCalories struct {
-	Date     vo.EatenAt
-	Calories vo.Calories
-}
-
 // RecordRepository はカロリー記録の永続化を担当するリポジトリインターフェース
 type RecordRepository interface {
 	// Save はRecordを保存する
@@ -22,6 +16,10 @@ type RecordRepository interface {
 	// startTime以上、endTime未満のeatenAtを持つRecordを返す
 	// Recordには関連するRecordItemsも含まれる
 	FindByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startTime, endTime time.Time) ([]*entity.Record, error)
-	// GetDailyCalories は日別カロリーを取得（グラフ用）
-	GetDailyCalories(ctx context.Context, userID vo.UserID, period vo.StatisticsPeriod) ([]DailyCalories, error)
+	// ForEachBatchByUserID は指定ユーザーの全Recordを食事日時の昇順でbatchSize件ずつ取得し、fnに渡す
+	// 全件をメモリに載せずに処理するためのもので、Recordには関連するRecordItemsも含まれる
+	// fnがエラーを返した場合は中断してそのエラーを返す
+	ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func(records []*entity.Record) error) error
+	// CountByUserID は指定ユーザーのRecordの件数を返す
+	CountByUserID(ctx context.Context, userID vo.UserID) (int64, error)
 }
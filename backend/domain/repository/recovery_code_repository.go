@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// RecoveryCodeRepository は2段階認証のリカバリーコードの永続化を担当するリポジトリインターフェース
+type RecoveryCodeRepository interface {
+	// SaveAll はリカバリーコードをまとめて保存する
+	SaveAll(ctx context.Context, codes []*entity.RecoveryCode) error
+	// FindByUserIDAndCodeHash はユーザーのリカバリーコードをハッシュで取得する（存在しない場合はnil）
+	FindByUserIDAndCodeHash(ctx context.Context, userID vo.UserID, codeHash string) (*entity.RecoveryCode, error)
+	// MarkUsed はコードの使用日時を保存する
+	MarkUsed(ctx context.Context, code *entity.RecoveryCode) error
+	// DeleteByUserID はユーザーの全リカバリーコードを削除する（再発行時に古いコードを無効化する）
+	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// UserRetention はユーザーが個別に設定したデータの保存期間
+type UserRetention struct {
+	UserID vo.UserID
+	Years  int
+}
+
+// RetentionRepository は保存期間を過ぎたデータの削除を担当するリポジトリインターフェース
+type RetentionRepository interface {
+	// FindUserRetentions は保存期間（年数）を設定しているユーザーを取得する
+	FindUserRetentions(ctx context.Context) ([]UserRetention, error)
+	// PurgeRecordsBefore は食事日時がbeforeより前の記録と、その明細・PFCを削除する
+	// 日別集計（daily_summaries）は削除せずに残す
+	// userIDがnilの場合は全ユーザーが対象
+	// 戻り値はテーブル名ごとの削除件数
+	PurgeRecordsBefore(ctx context.Context, userID *vo.UserID, before time.Time) (map[string]int64, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"caltrack/domain/entity"
 	"caltrack/domain/vo"
@@ -15,9 +16,22 @@ type SessionRepository interface {
 	// FindByID はセッションIDでセッションを取得する
 	FindByID(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error)
 
+	// FindActiveByUserID はユーザーの有効期限内のセッションを最終利用日時の降順で取得する
+	FindActiveByUserID(ctx context.Context, userID vo.UserID) ([]*entity.Session, error)
+
+	// UpdateActivity はセッションの有効期限と最終利用日時を更新する
+	// 保存済みの最終利用日時より後になる場合のみ更新する（同時リクエストでの重複書き込みを抑制）
+	UpdateActivity(ctx context.Context, session *entity.Session) error
+
 	// DeleteByID はセッションを削除する
 	DeleteByID(ctx context.Context, sessionID vo.SessionID) error
 
 	// DeleteByUserID はユーザーの全セッションを削除する（ログアウト時等）
 	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+
+	// DeleteByUserIDExcept はユーザーの指定セッション以外の全セッションを削除する（他の端末からのログアウト）
+	DeleteByUserIDExcept(ctx context.Context, userID vo.UserID, keepSessionID vo.SessionID) error
+
+	// DeleteExpired はnowの時点で有効期限が切れている全ユーザーのセッションを削除し、削除件数を返す
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
 }
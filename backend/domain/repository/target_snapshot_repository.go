@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// TargetSnapshotRepository は目標値スナップショットの永続化を担当するリポジトリインターフェース
+type TargetSnapshotRepository interface {
+	// Save はスナップショットを保存する
+	// 同一ユーザー・同一適用日のスナップショットが存在する場合は上書きする
+	Save(ctx context.Context, snapshot *entity.TargetSnapshot) error
+	// ExistsByUserID は指定ユーザーのスナップショットが1件以上存在するかを返す
+	ExistsByUserID(ctx context.Context, userID vo.UserID) (bool, error)
+	// FindEffectiveByUserIDAndDateRange は指定期間の判定に必要なスナップショットを適用日の昇順で取得する
+	// startDate時点で有効な1件と、startDateより後・endDate未満に適用開始されたものを返す
+	FindEffectiveByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.TargetSnapshot, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// TwoFactorChallengeRepository は2段階認証チャレンジの永続化を担当するリポジトリインターフェース
+type TwoFactorChallengeRepository interface {
+	// Save はチャレンジを保存する
+	Save(ctx context.Context, challenge *entity.TwoFactorChallenge) error
+	// FindByTokenHash はトークンハッシュでチャレンジを取得する（存在しない場合はnil）
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.TwoFactorChallenge, error)
+	// MarkUsed はチャレンジの使用日時を保存する
+	MarkUsed(ctx context.Context, challenge *entity.TwoFactorChallenge) error
+	// DeleteByUserID はユーザーの全チャレンジを削除する
+	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// UserBadgeRepository は獲得バッジの永続化を担当するリポジトリインターフェース
+type UserBadgeRepository interface {
+	// Save はバッジを保存する
+	// 同一ユーザー・同一種別のバッジが既に存在する場合は何もしない（最初の獲得日時を保持する）
+	Save(ctx context.Context, badge *entity.UserBadge) error
+	// FindByUserID は指定ユーザーの獲得バッジを獲得日時の昇順で取得する
+	FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.UserBadge, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// UserIdentityRepository は外部IDプロバイダーとの連携の永続化を担当するリポジトリインターフェース
+type UserIdentityRepository interface {
+	// Save は連携を保存する
+	Save(ctx context.Context, identity *entity.UserIdentity) error
+	// FindByProviderSubject はプロバイダー名とsubjectで連携を取得する（存在しない場合はnil）
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	// FindByUserID はユーザーの全連携を取得する
+	FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.UserIdentity, error)
+}
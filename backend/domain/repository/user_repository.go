@@ -16,4 +16,7 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id vo.UserID) (*entity.User, error)
 	// Update は既存ユーザーを更新する
 	Update(ctx context.Context, user *entity.User) error
+	// Search はメールアドレスかニックネームにqueryを含むユーザーを登録日時の新しい順にlimit件取得する（管理者向け）
+	// queryが空の場合は全ユーザーを対象とし、ページング用に条件に一致する総件数も返す
+	Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, int64, error)
 }
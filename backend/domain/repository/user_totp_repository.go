@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+)
+
+// UserTOTPRepository はユーザーのTOTP設定の永続化を担当するリポジトリインターフェース
+type UserTOTPRepository interface {
+	// Save はTOTP設定を保存する（既に存在する場合は上書きする）
+	Save(ctx context.Context, totp *entity.UserTOTP) error
+	// FindByUserID はユーザーのTOTP設定を取得する（存在しない場合はnil）
+	FindByUserID(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error)
+	// DeleteByUserID はユーザーのTOTP設定を削除する
+	DeleteByUserID(ctx context.Context, userID vo.UserID) error
+}
@@ -0,0 +1,62 @@
+package vo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	domainErrors "caltrack/domain/errors"
+)
+
+// AccessTokenPrefix はパーソナルアクセストークンの接頭辞
+// Bearerトークンとして送られたときにセッションIDと区別するために付ける
+const AccessTokenPrefix = "ctpat_"
+
+// アクセストークンのランダム部分の長さ（バイト数）
+const accessTokenBytes = 32
+
+// AccessToken はスクリプト等から使うパーソナルアクセストークンの平文を表す
+// DBには平文を保存せず、Hash() の値のみを保存する
+type AccessToken struct {
+	value string
+}
+
+// NewAccessToken は新しいアクセストークンを生成する
+func NewAccessToken() (AccessToken, error) {
+	bytes := make([]byte, accessTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return AccessToken{}, domainErrors.ErrAccessTokenGenerationFailed
+	}
+	return AccessToken{value: AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(bytes)}, nil
+}
+
+// ParseAccessToken は文字列からアクセストークンを復元する
+func ParseAccessToken(value string) (AccessToken, error) {
+	if !IsAccessToken(value) {
+		return AccessToken{}, domainErrors.ErrInvalidAccessToken
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, AccessTokenPrefix))
+	if err != nil || len(decoded) != accessTokenBytes {
+		return AccessToken{}, domainErrors.ErrInvalidAccessToken
+	}
+	return AccessToken{value: value}, nil
+}
+
+// IsAccessToken は文字列がアクセストークンの接頭辞を持つかを判定する
+func IsAccessToken(value string) bool {
+	return strings.HasPrefix(value, AccessTokenPrefix)
+}
+
+// String はトークンの文字列表現を返す
+func (t AccessToken) String() string {
+	return t.value
+}
+
+// Hash はDB保存用のトークンハッシュ（SHA-256の16進表現）を返す
+// トークン自体が十分なエントロピーを持つため、ソルトなしのハッシュで照合する
+func (t AccessToken) Hash() string {
+	sum := sha256.Sum256([]byte(t.value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,69 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewAccessToken(t *testing.T) {
+	token1, err := vo.NewAccessToken()
+	if err != nil {
+		t.Fatalf("NewAccessToken() unexpected error: %v", err)
+	}
+	token2, _ := vo.NewAccessToken()
+
+	if token1.String() == token2.String() {
+		t.Error("NewAccessToken() should generate unique tokens")
+	}
+	if !strings.HasPrefix(token1.String(), vo.AccessTokenPrefix) {
+		t.Errorf("token = %s, want prefix %s", token1.String(), vo.AccessTokenPrefix)
+	}
+	if _, err := vo.ParseAccessToken(token1.String()); err != nil {
+		t.Errorf("ParseAccessToken(generated) unexpected error: %v", err)
+	}
+}
+
+func TestParseAccessToken(t *testing.T) {
+	valid, _ := vo.NewAccessToken()
+	oneTime, _ := vo.NewOneTimeToken()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"生成したトークンは有効", valid.String(), nil},
+		{"空文字はエラー", "", domainErrors.ErrInvalidAccessToken},
+		{"接頭辞がないとエラー", oneTime.String(), domainErrors.ErrInvalidAccessToken},
+		{"不正なbase64はエラー", vo.AccessTokenPrefix + "not-valid-base64!!!", domainErrors.ErrInvalidAccessToken},
+		{"長さが足りないとエラー", vo.AccessTokenPrefix + "YWJjZA", domainErrors.ErrInvalidAccessToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vo.ParseAccessToken(tt.input)
+			if err != tt.wantErr {
+				t.Errorf("ParseAccessToken(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccessToken_Hash(t *testing.T) {
+	token, _ := vo.NewAccessToken()
+	parsed, _ := vo.ParseAccessToken(token.String())
+	other, _ := vo.NewAccessToken()
+
+	if len(token.Hash()) != 64 {
+		t.Errorf("len(Hash()) = %d, want 64", len(token.Hash()))
+	}
+	if token.Hash() != parsed.Hash() {
+		t.Error("Hash() should be stable for the same token")
+	}
+	if token.Hash() == other.Hash() {
+		t.Error("Hash() should differ for different tokens")
+	}
+}
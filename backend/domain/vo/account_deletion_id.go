@@ -0,0 +1,26 @@
+package vo
+
+// AccountDeletionID はアカウント削除の申請の識別子を表す値オブジェクト
+type AccountDeletionID struct {
+	value UUID
+}
+
+// NewAccountDeletionID は新しいAccountDeletionIDを生成する
+func NewAccountDeletionID() AccountDeletionID {
+	return AccountDeletionID{value: NewUUID()}
+}
+
+// ReconstructAccountDeletionID はDBからAccountDeletionIDを復元する
+func ReconstructAccountDeletionID(value string) AccountDeletionID {
+	return AccountDeletionID{value: ReconstructUUID(value)}
+}
+
+// String はAccountDeletionIDの文字列表現を返す
+func (a AccountDeletionID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのAccountDeletionIDが等しいかを比較する
+func (a AccountDeletionID) Equals(other AccountDeletionID) bool {
+	return a.value.Equals(other.value)
+}
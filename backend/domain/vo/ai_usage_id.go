@@ -0,0 +1,26 @@
+package vo
+
+// AIUsageID はAI機能の使用履歴の識別子を表す値オブジェクト
+type AIUsageID struct {
+	value UUID
+}
+
+// NewAIUsageID は新しいAIUsageIDを生成する
+func NewAIUsageID() AIUsageID {
+	return AIUsageID{value: NewUUID()}
+}
+
+// ReconstructAIUsageID はDBからAIUsageIDを復元する
+func ReconstructAIUsageID(value string) AIUsageID {
+	return AIUsageID{value: ReconstructUUID(value)}
+}
+
+// String はAIUsageIDの文字列表現を返す
+func (p AIUsageID) String() string {
+	return p.value.String()
+}
+
+// Equals は2つのAIUsageIDが等しいかを比較する
+func (p AIUsageID) Equals(other AIUsageID) bool {
+	return p.value.Equals(other.value)
+}
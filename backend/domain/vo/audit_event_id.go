@@ -0,0 +1,26 @@
+package vo
+
+// AuditEventID は監査イベントの識別子を表す値オブジェクト
+type AuditEventID struct {
+	value UUID
+}
+
+// NewAuditEventID は新しいAuditEventIDを生成する
+func NewAuditEventID() AuditEventID {
+	return AuditEventID{value: NewUUID()}
+}
+
+// ReconstructAuditEventID はDBからAuditEventIDを復元する
+func ReconstructAuditEventID(value string) AuditEventID {
+	return AuditEventID{value: ReconstructUUID(value)}
+}
+
+// String はAuditEventIDの文字列表現を返す
+func (p AuditEventID) String() string {
+	return p.value.String()
+}
+
+// Equals は2つのAuditEventIDが等しいかを比較する
+func (p AuditEventID) Equals(other AuditEventID) bool {
+	return p.value.Equals(other.value)
+}
@@ -0,0 +1,55 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+const (
+	BadgeTypeFirstRecord         = "firstRecord"         // 初めての記録
+	BadgeTypeFirstPhotoAnalysis  = "firstPhotoAnalysis"  // 初めての写真解析
+	BadgeTypeLogged7Days         = "logged7Days"         // 累計7日記録
+	BadgeTypeLogged30Days        = "logged30Days"        // 累計30日記録
+	BadgeTypeLogged100Days       = "logged100Days"       // 累計100日記録
+	BadgeTypeAchievementStreak7  = "achievementStreak7"  // 7日連続達成
+	BadgeTypeAchievementStreak30 = "achievementStreak30" // 30日連続達成
+)
+
+var validBadgeTypes = map[string]bool{
+	BadgeTypeFirstRecord:         true,
+	BadgeTypeFirstPhotoAnalysis:  true,
+	BadgeTypeLogged7Days:         true,
+	BadgeTypeLogged30Days:        true,
+	BadgeTypeLogged100Days:       true,
+	BadgeTypeAchievementStreak7:  true,
+	BadgeTypeAchievementStreak30: true,
+}
+
+// BadgeType はバッジの種類を表すValue Object
+type BadgeType struct {
+	value string
+}
+
+// NewBadgeType は新しいBadgeTypeを生成する
+// 定義済みのバッジ種別のみ許可する
+func NewBadgeType(value string) (BadgeType, error) {
+	if !validBadgeTypes[value] {
+		return BadgeType{}, domainErrors.ErrInvalidBadgeType
+	}
+	return BadgeType{value: value}, nil
+}
+
+// ReconstructBadgeType はDBから復元する際に使用する
+// バリデーションをスキップする
+func ReconstructBadgeType(value string) BadgeType {
+	return BadgeType{value: value}
+}
+
+// String はバッジ種別の文字列表現を返す
+func (b BadgeType) String() string {
+	return b.value
+}
+
+// Equals は2つのBadgeTypeが等しいかを比較する
+func (b BadgeType) Equals(other BadgeType) bool {
+	return b.value == other.value
+}
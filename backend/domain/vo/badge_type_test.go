@@ -0,0 +1,41 @@
+package vo_test
+
+import (
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewBadgeType(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValue string
+		wantErr   error
+	}{
+		// 正常系
+		{"firstRecordは有効", "firstRecord", "firstRecord", nil},
+		{"firstPhotoAnalysisは有効", "firstPhotoAnalysis", "firstPhotoAnalysis", nil},
+		{"logged30Daysは有効", "logged30Days", "logged30Days", nil},
+		{"achievementStreak7は有効", "achievementStreak7", "achievementStreak7", nil},
+		// 異常系
+		{"空文字はエラー", "", "", domainErrors.ErrInvalidBadgeType},
+		{"無効な値はエラー", "unknown", "", domainErrors.ErrInvalidBadgeType},
+		{"大文字始まりはエラー", "FirstRecord", "", domainErrors.ErrInvalidBadgeType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vo.NewBadgeType(tt.input)
+
+			if err != tt.wantErr {
+				t.Errorf("NewBadgeType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.wantValue {
+				t.Errorf("NewBadgeType(%q).String() = %v, want %v", tt.input, got.String(), tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+const (
+	BudgetModeDaily  = "daily"  // 1日単位で目標カロリーを管理する
+	BudgetModeWeekly = "weekly" // 1週間単位で目標カロリーを管理する（カロリー貯金）
+)
+
+var validBudgetModes = map[string]bool{
+	BudgetModeDaily:  true,
+	BudgetModeWeekly: true,
+}
+
+// BudgetMode はカロリー予算の管理単位を表すValue Object
+type BudgetMode struct {
+	value string
+}
+
+// NewBudgetMode は新しいBudgetModeを生成する
+// daily または weekly のみ許可する
+func NewBudgetMode(value string) (BudgetMode, error) {
+	if !validBudgetModes[value] {
+		return BudgetMode{}, domainErrors.ErrInvalidBudgetMode
+	}
+	return BudgetMode{value: value}, nil
+}
+
+// DefaultBudgetMode はデフォルトの予算モード（daily）を返す
+func DefaultBudgetMode() BudgetMode {
+	return BudgetMode{value: BudgetModeDaily}
+}
+
+// String は予算モードの文字列表現を返す
+func (b BudgetMode) String() string {
+	return b.value
+}
+
+// IsWeekly は週間予算モードかどうかを返す
+func (b BudgetMode) IsWeekly() bool {
+	return b.value == BudgetModeWeekly
+}
@@ -0,0 +1,52 @@
+package vo_test
+
+import (
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewBudgetMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMode   string
+		wantWeekly bool
+		wantErr    error
+	}{
+		// 正常系
+		{"dailyは有効", "daily", "daily", false, nil},
+		{"weeklyは有効", "weekly", "weekly", true, nil},
+		// 異常系
+		{"空文字はエラー", "", "", false, domainErrors.ErrInvalidBudgetMode},
+		{"無効な値はエラー", "monthly", "", false, domainErrors.ErrInvalidBudgetMode},
+		{"大文字はエラー", "Weekly", "", false, domainErrors.ErrInvalidBudgetMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vo.NewBudgetMode(tt.input)
+
+			if err != tt.wantErr {
+				t.Errorf("NewBudgetMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.wantMode {
+				t.Errorf("NewBudgetMode(%q).String() = %v, want %v", tt.input, got.String(), tt.wantMode)
+			}
+			if got.IsWeekly() != tt.wantWeekly {
+				t.Errorf("NewBudgetMode(%q).IsWeekly() = %v, want %v", tt.input, got.IsWeekly(), tt.wantWeekly)
+			}
+		})
+	}
+}
+
+func TestDefaultBudgetMode(t *testing.T) {
+	if got := vo.DefaultBudgetMode(); got.String() != vo.BudgetModeDaily {
+		t.Errorf("DefaultBudgetMode() = %v, want %v", got.String(), vo.BudgetModeDaily)
+	}
+}
@@ -0,0 +1,51 @@
+package vo
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+)
+
+// calendarMonthLayout はカレンダー月の文字列表現（YYYY-MM）
+const calendarMonthLayout = "2006-01"
+
+// CalendarMonth はカレンダー表示の対象月を表すValue Object
+type CalendarMonth struct {
+	value time.Time // JSTでの月初0時
+}
+
+// NewCalendarMonth は新しいCalendarMonthを生成する
+// 空文字の場合はデフォルトで今月を設定する
+// YYYY-MM 形式のみ許可する
+func NewCalendarMonth(value string) (CalendarMonth, error) {
+	if value == "" {
+		now := nowFunc().In(helper.JST())
+		return CalendarMonth{value: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, helper.JST())}, nil
+	}
+	parsed, err := time.ParseInLocation(calendarMonthLayout, value, helper.JST())
+	if err != nil {
+		return CalendarMonth{}, domainErrors.ErrInvalidCalendarMonth
+	}
+	return CalendarMonth{value: parsed}, nil
+}
+
+// String はカレンダー月の文字列表現（YYYY-MM）を返す
+func (m CalendarMonth) String() string {
+	return m.value.Format(calendarMonthLayout)
+}
+
+// Start は月初（JSTの1日0時）を返す
+func (m CalendarMonth) Start() time.Time {
+	return m.value
+}
+
+// End は翌月初（JSTの1日0時）を返す
+func (m CalendarMonth) End() time.Time {
+	return m.value.AddDate(0, 1, 0)
+}
+
+// Days は月の日数を返す
+func (m CalendarMonth) Days() int {
+	return m.End().AddDate(0, 0, -1).Day()
+}
@@ -0,0 +1,69 @@
+package vo
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+)
+
+func TestNewCalendarMonth(t *testing.T) {
+	fixedNow := time.Date(2025, 3, 15, 12, 0, 0, 0, helper.JST())
+	nowFunc = func() time.Time { return fixedNow }
+	defer func() { nowFunc = time.Now }()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantStr  string
+		wantDays int
+		wantErr  error
+	}{
+		// 正常系
+		{"空文字は今月", "", "2025-03", 31, nil},
+		{"2025-02は28日", "2025-02", "2025-02", 28, nil},
+		{"うるう年の2024-02は29日", "2024-02", "2024-02", 29, nil},
+		{"2025-04は30日", "2025-04", "2025-04", 30, nil},
+		// 異常系
+		{"日付付きはエラー", "2025-03-01", "", 0, domainErrors.ErrInvalidCalendarMonth},
+		{"存在しない月はエラー", "2025-13", "", 0, domainErrors.ErrInvalidCalendarMonth},
+		{"スラッシュ区切りはエラー", "2025/03", "", 0, domainErrors.ErrInvalidCalendarMonth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCalendarMonth(tt.input)
+
+			if err != tt.wantErr {
+				t.Errorf("NewCalendarMonth(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.wantStr {
+				t.Errorf("String() = %v, want %v", got.String(), tt.wantStr)
+			}
+			if got.Days() != tt.wantDays {
+				t.Errorf("Days() = %v, want %v", got.Days(), tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestCalendarMonth_Range(t *testing.T) {
+	month, err := NewCalendarMonth("2025-12")
+	if err != nil {
+		t.Fatalf("NewCalendarMonth() error = %v", err)
+	}
+
+	wantStart := time.Date(2025, 12, 1, 0, 0, 0, 0, helper.JST())
+	wantEnd := time.Date(2026, 1, 1, 0, 0, 0, 0, helper.JST())
+	if !month.Start().Equal(wantStart) {
+		t.Errorf("Start() = %v, want %v", month.Start(), wantStart)
+	}
+	if !month.End().Equal(wantEnd) {
+		t.Errorf("End() = %v, want %v", month.End(), wantEnd)
+	}
+}
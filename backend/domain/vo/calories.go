@@ -2,6 +2,7 @@ package vo
 
 import (
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
 )
 
 // カロリー達成率の閾値定数
@@ -61,3 +62,17 @@ func (c Calories) IsOver(target Calories) bool {
 func (c Calories) Add(other Calories) Calories {
 	return Calories{value: c.value + other.value}
 }
+
+// WeeklyAllowance は1日の目標カロリーを週間予算（×7）として扱ったときの当日の許容量を返す
+// 週内で当日より前に摂取したカロリーを差し引いた残りを、当日を含む残り日数で割る
+// 予算を使い切っている場合は0を返す
+func (c Calories) WeeklyAllowance(consumedBefore Calories, daysLeft int) Calories {
+	if daysLeft < 1 {
+		daysLeft = 1
+	}
+	remaining := c.value*helper.DaysPerWeek - consumedBefore.value
+	if remaining <= 0 {
+		return ZeroCalories()
+	}
+	return Calories{value: remaining / daysLeft}
+}
@@ -160,3 +160,31 @@ func TestCalories_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestCalories_WeeklyAllowance(t *testing.T) {
+	tests := []struct {
+		name           string
+		dailyTarget    int
+		consumedBefore int
+		daysLeft       int
+		expected       int
+	}{
+		{"週初めは1日の目標と同じ", 2000, 0, 7, 2000},
+		{"平日に控えた分を残り日数で配分", 2000, 4000, 4, 2500},
+		{"食べ過ぎた分は残り日数で減る", 2000, 9000, 2, 2500},
+		{"最終日は残り全て", 2000, 12500, 1, 1500},
+		{"予算超過は0", 2000, 15000, 1, 0},
+		{"残り日数0以下は1日として扱う", 2000, 13000, 0, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := vo.ReconstructCalories(tt.dailyTarget)
+			consumed := vo.ReconstructCalories(tt.consumedBefore)
+			got := target.WeeklyAllowance(consumed, tt.daysLeft)
+			if got.Value() != tt.expected {
+				t.Errorf("Calories(%d).WeeklyAllowance(%d, %d) = %v, want %v", tt.dailyTarget, tt.consumedBefore, tt.daysLeft, got.Value(), tt.expected)
+			}
+		})
+	}
+}
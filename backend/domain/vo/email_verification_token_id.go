@@ -0,0 +1,26 @@
+package vo
+
+// EmailVerificationTokenID はメールアドレス確認トークンの識別子を表す値オブジェクト
+type EmailVerificationTokenID struct {
+	value UUID
+}
+
+// NewEmailVerificationTokenID は新しいEmailVerificationTokenIDを生成する
+func NewEmailVerificationTokenID() EmailVerificationTokenID {
+	return EmailVerificationTokenID{value: NewUUID()}
+}
+
+// ReconstructEmailVerificationTokenID はDBからEmailVerificationTokenIDを復元する
+func ReconstructEmailVerificationTokenID(value string) EmailVerificationTokenID {
+	return EmailVerificationTokenID{value: ReconstructUUID(value)}
+}
+
+// String はEmailVerificationTokenIDの文字列表現を返す
+func (a EmailVerificationTokenID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのEmailVerificationTokenIDが等しいかを比較する
+func (a EmailVerificationTokenID) Equals(other EmailVerificationTokenID) bool {
+	return a.value.Equals(other.value)
+}
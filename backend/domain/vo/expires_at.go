@@ -9,6 +9,10 @@ import (
 // セッションの有効期間（7日間）
 const SessionDurationDays = 7
 
+// セッションの絶対的な最大有効期間（30日間）
+// スライド延長してもセッション作成からこの期間を超えて有効にはならない
+const SessionMaxLifetimeDays = 30
+
 // ExpiresAt はセッションの有効期限を表す
 type ExpiresAt struct {
 	value time.Time
@@ -35,6 +39,29 @@ func (e ExpiresAt) IsExpired() bool {
 	return nowFunc().After(e.value)
 }
 
+// Extend は有効期間の半分を過ぎていれば、現在時刻から7日後まで延長した有効期限を返す
+// 延長後の有効期限は createdAt から SessionMaxLifetimeDays 日後を超えない
+// 延長が不要な場合（半分を過ぎていない・上限に達している）は第2戻り値にfalseを返す
+func (e ExpiresAt) Extend(createdAt time.Time) (ExpiresAt, bool) {
+	now := nowFunc()
+	extended := now.AddDate(0, 0, SessionDurationDays)
+
+	// 残り期間が有効期間の半分以上ある場合は延長しない（DB書き込みの抑制）
+	halfLife := extended.Sub(now) / 2
+	if e.value.Sub(now) > halfLife {
+		return e, false
+	}
+
+	maxExpiresAt := createdAt.AddDate(0, 0, SessionMaxLifetimeDays)
+	if extended.After(maxExpiresAt) {
+		extended = maxExpiresAt
+	}
+	if !extended.After(e.value) {
+		return e, false
+	}
+	return ExpiresAt{value: extended}, true
+}
+
 // ValidateNotExpired は有効期限内かどうかを検証する
 func (e ExpiresAt) ValidateNotExpired() error {
 	if e.IsExpired() {
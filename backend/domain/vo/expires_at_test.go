@@ -86,3 +86,37 @@ func TestExpiresAt_ValidateNotExpired(t *testing.T) {
 		})
 	}
 }
+
+func TestExpiresAt_Extend(t *testing.T) {
+	// 現在時刻を固定
+	fixedNow := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return fixedNow }
+	defer func() { nowFunc = time.Now }()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		createdAt time.Time
+		want      time.Time
+		wantOk    bool
+	}{
+		// 延長なし
+		{"残り期間が半分以上なら延長しない", fixedNow.AddDate(0, 0, 5), fixedNow.AddDate(0, 0, -2), fixedNow.AddDate(0, 0, 5), false},
+		{"最大有効期間に達していれば延長しない", fixedNow.AddDate(0, 0, 1), fixedNow.AddDate(0, 0, -29), fixedNow.AddDate(0, 0, 1), false},
+		// 延長あり
+		{"残り期間が半分未満なら7日後まで延長する", fixedNow.AddDate(0, 0, 3), fixedNow.AddDate(0, 0, -4), fixedNow.AddDate(0, 0, 7), true},
+		{"最大有効期間を超えない範囲で延長する", fixedNow.AddDate(0, 0, 1), fixedNow.AddDate(0, 0, -27), fixedNow.AddDate(0, 0, 3), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseExpiresAt(tt.expiresAt).Extend(tt.createdAt)
+			if ok != tt.wantOk {
+				t.Errorf("Extend() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !got.Time().Equal(tt.want) {
+				t.Errorf("Extend().Time() = %v, want %v", got.Time(), tt.want)
+			}
+		})
+	}
+}
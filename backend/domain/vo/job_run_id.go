@@ -0,0 +1,26 @@
+package vo
+
+// JobRunID はジョブの実行履歴の識別子を表す値オブジェクト
+type JobRunID struct {
+	value UUID
+}
+
+// NewJobRunID は新しいJobRunIDを生成する
+func NewJobRunID() JobRunID {
+	return JobRunID{value: NewUUID()}
+}
+
+// ReconstructJobRunID はDBからJobRunIDを復元する
+func ReconstructJobRunID(value string) JobRunID {
+	return JobRunID{value: ReconstructUUID(value)}
+}
+
+// String はJobRunIDの文字列表現を返す
+func (p JobRunID) String() string {
+	return p.value.String()
+}
+
+// Equals は2つのJobRunIDが等しいかを比較する
+func (p JobRunID) Equals(other JobRunID) bool {
+	return p.value.Equals(other.value)
+}
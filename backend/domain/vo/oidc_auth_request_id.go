@@ -0,0 +1,26 @@
+package vo
+
+// OIDCAuthRequestID はOpenID Connectの認可リクエストの識別子を表す値オブジェクト
+type OIDCAuthRequestID struct {
+	value UUID
+}
+
+// NewOIDCAuthRequestID は新しいOIDCAuthRequestIDを生成する
+func NewOIDCAuthRequestID() OIDCAuthRequestID {
+	return OIDCAuthRequestID{value: NewUUID()}
+}
+
+// ReconstructOIDCAuthRequestID はDBからOIDCAuthRequestIDを復元する
+func ReconstructOIDCAuthRequestID(value string) OIDCAuthRequestID {
+	return OIDCAuthRequestID{value: ReconstructUUID(value)}
+}
+
+// String はOIDCAuthRequestIDの文字列表現を返す
+func (a OIDCAuthRequestID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのOIDCAuthRequestIDが等しいかを比較する
+func (a OIDCAuthRequestID) Equals(other OIDCAuthRequestID) bool {
+	return a.value.Equals(other.value)
+}
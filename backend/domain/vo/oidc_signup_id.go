@@ -0,0 +1,26 @@
+package vo
+
+// OIDCSignupID はOpenID Connectでの新規登録待ちの識別子を表す値オブジェクト
+type OIDCSignupID struct {
+	value UUID
+}
+
+// NewOIDCSignupID は新しいOIDCSignupIDを生成する
+func NewOIDCSignupID() OIDCSignupID {
+	return OIDCSignupID{value: NewUUID()}
+}
+
+// ReconstructOIDCSignupID はDBからOIDCSignupIDを復元する
+func ReconstructOIDCSignupID(value string) OIDCSignupID {
+	return OIDCSignupID{value: ReconstructUUID(value)}
+}
+
+// String はOIDCSignupIDの文字列表現を返す
+func (a OIDCSignupID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのOIDCSignupIDが等しいかを比較する
+func (a OIDCSignupID) Equals(other OIDCSignupID) bool {
+	return a.value.Equals(other.value)
+}
@@ -0,0 +1,50 @@
+package vo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	domainErrors "caltrack/domain/errors"
+)
+
+// ワンタイムトークンの長さ（バイト数）
+const oneTimeTokenBytes = 32
+
+// OneTimeToken はメールで送るパスワードリセット等の使い捨てトークンを表す
+// DBには平文を保存せず、Hash() の値のみを保存する
+type OneTimeToken struct {
+	value string
+}
+
+// NewOneTimeToken は新しいワンタイムトークンを生成する
+func NewOneTimeToken() (OneTimeToken, error) {
+	bytes := make([]byte, oneTimeTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return OneTimeToken{}, domainErrors.ErrOneTimeTokenGenerationFailed
+	}
+	// URLのクエリに含められるよう、パディングなしのURL-safe Base64でエンコード
+	return OneTimeToken{value: base64.RawURLEncoding.EncodeToString(bytes)}, nil
+}
+
+// ParseOneTimeToken は文字列からワンタイムトークンを復元する
+func ParseOneTimeToken(value string) (OneTimeToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(decoded) != oneTimeTokenBytes {
+		return OneTimeToken{}, domainErrors.ErrInvalidOneTimeToken
+	}
+	return OneTimeToken{value: value}, nil
+}
+
+// String はトークンの文字列表現を返す
+func (t OneTimeToken) String() string {
+	return t.value
+}
+
+// Hash はDB保存用のトークンハッシュ（SHA-256の16進表現）を返す
+// トークン自体が十分なエントロピーを持つため、ソルトなしのハッシュで照合する
+func (t OneTimeToken) Hash() string {
+	sum := sha256.Sum256([]byte(t.value))
+	return hex.EncodeToString(sum[:])
+}
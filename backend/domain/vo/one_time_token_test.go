@@ -0,0 +1,66 @@
+package vo_test
+
+import (
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewOneTimeToken(t *testing.T) {
+	token1, err := vo.NewOneTimeToken()
+	if err != nil {
+		t.Fatalf("NewOneTimeToken() unexpected error: %v", err)
+	}
+	token2, _ := vo.NewOneTimeToken()
+
+	if token1.String() == token2.String() {
+		t.Error("NewOneTimeToken() should generate unique tokens")
+	}
+	if _, err := vo.ParseOneTimeToken(token1.String()); err != nil {
+		t.Errorf("ParseOneTimeToken(generated) unexpected error: %v", err)
+	}
+}
+
+func TestParseOneTimeToken(t *testing.T) {
+	valid, _ := vo.NewOneTimeToken()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"生成したトークンは有効", valid.String(), nil},
+		{"空文字はエラー", "", domainErrors.ErrInvalidOneTimeToken},
+		{"不正なbase64はエラー", "not-valid-base64!!!", domainErrors.ErrInvalidOneTimeToken},
+		{"長さが足りないとエラー", "YWJjZA", domainErrors.ErrInvalidOneTimeToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vo.ParseOneTimeToken(tt.input)
+			if err != tt.wantErr {
+				t.Errorf("ParseOneTimeToken(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOneTimeToken_Hash(t *testing.T) {
+	token, _ := vo.NewOneTimeToken()
+	parsed, _ := vo.ParseOneTimeToken(token.String())
+	other, _ := vo.NewOneTimeToken()
+
+	if len(token.Hash()) != 64 {
+		t.Errorf("len(Hash()) = %d, want 64", len(token.Hash()))
+	}
+	if token.Hash() != parsed.Hash() {
+		t.Error("Hash() should be stable for the same token")
+	}
+	if token.Hash() == other.Hash() {
+		t.Error("Hash() should differ for different tokens")
+	}
+	if token.Hash() == token.String() {
+		t.Error("Hash() should not equal the raw token")
+	}
+}
@@ -0,0 +1,26 @@
+package vo
+
+// PasswordResetTokenID はパスワードリセットトークンの識別子を表す値オブジェクト
+type PasswordResetTokenID struct {
+	value UUID
+}
+
+// NewPasswordResetTokenID は新しいPasswordResetTokenIDを生成する
+func NewPasswordResetTokenID() PasswordResetTokenID {
+	return PasswordResetTokenID{value: NewUUID()}
+}
+
+// ReconstructPasswordResetTokenID はDBからPasswordResetTokenIDを復元する
+func ReconstructPasswordResetTokenID(value string) PasswordResetTokenID {
+	return PasswordResetTokenID{value: ReconstructUUID(value)}
+}
+
+// String はPasswordResetTokenIDの文字列表現を返す
+func (a PasswordResetTokenID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのPasswordResetTokenIDが等しいかを比較する
+func (a PasswordResetTokenID) Equals(other PasswordResetTokenID) bool {
+	return a.value.Equals(other.value)
+}
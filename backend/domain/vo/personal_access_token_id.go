@@ -0,0 +1,39 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+// PersonalAccessTokenID はパーソナルアクセストークンの識別子を表す値オブジェクト
+type PersonalAccessTokenID struct {
+	value UUID
+}
+
+// NewPersonalAccessTokenID は新しいPersonalAccessTokenIDを生成する
+func NewPersonalAccessTokenID() PersonalAccessTokenID {
+	return PersonalAccessTokenID{value: NewUUID()}
+}
+
+// ParsePersonalAccessTokenID は文字列からPersonalAccessTokenIDを生成する
+func ParsePersonalAccessTokenID(value string) (PersonalAccessTokenID, error) {
+	parsed, err := ParseUUID(value)
+	if err != nil {
+		return PersonalAccessTokenID{}, domainErrors.ErrInvalidPersonalAccessTokenID
+	}
+	return PersonalAccessTokenID{value: parsed}, nil
+}
+
+// ReconstructPersonalAccessTokenID はDBからPersonalAccessTokenIDを復元する
+func ReconstructPersonalAccessTokenID(value string) PersonalAccessTokenID {
+	return PersonalAccessTokenID{value: ReconstructUUID(value)}
+}
+
+// String はPersonalAccessTokenIDの文字列表現を返す
+func (p PersonalAccessTokenID) String() string {
+	return p.value.String()
+}
+
+// Equals は2つのPersonalAccessTokenIDが等しいかを比較する
+func (p PersonalAccessTokenID) Equals(other PersonalAccessTokenID) bool {
+	return p.value.Equals(other.value)
+}
@@ -0,0 +1,92 @@
+package vo
+
+import (
+	"time"
+	"unicode/utf8"
+
+	domainErrors "caltrack/domain/errors"
+)
+
+const (
+	// MaxDataRetentionYears は指定できる記録の保存期間の上限（年）
+	MaxDataRetentionYears = 10
+	// maxConsentVersionLength は同意したプライバシーポリシーのバージョンの最大文字数
+	maxConsentVersionLength = 20
+)
+
+// PrivacySettings はユーザーのプライバシー設定と、その設定に同意した日時・ポリシーのバージョンを表すValue Object
+type PrivacySettings struct {
+	aiProcessing   bool       // 食事内容・写真を外部のAIプロバイダーに送信してよいか
+	analytics      bool       // 利用状況を分析に使ってよいか
+	retentionYears int        // 記録の保存期間（年）。0の場合は無期限
+	consentVersion string     // 同意したプライバシーポリシーのバージョン（未同意の場合は空）
+	consentedAt    *time.Time // 設定に同意した日時（未同意の場合はnil）
+}
+
+// NewPrivacySettings はユーザーが同意したプライバシー設定を生成する
+// 同意日時は現在時刻になる
+func NewPrivacySettings(aiProcessing, analytics bool, retentionYears int, consentVersion string) (PrivacySettings, error) {
+	if retentionYears < 0 || retentionYears > MaxDataRetentionYears {
+		return PrivacySettings{}, domainErrors.ErrInvalidDataRetention
+	}
+	if consentVersion == "" {
+		return PrivacySettings{}, domainErrors.ErrConsentVersionRequired
+	}
+	if utf8.RuneCountInString(consentVersion) > maxConsentVersionLength {
+		return PrivacySettings{}, domainErrors.ErrConsentVersionTooLong
+	}
+
+	now := time.Now()
+	return PrivacySettings{
+		aiProcessing:   aiProcessing,
+		analytics:      analytics,
+		retentionYears: retentionYears,
+		consentVersion: consentVersion,
+		consentedAt:    &now,
+	}, nil
+}
+
+// DefaultPrivacySettings は設定を変更していないユーザーのプライバシー設定を返す
+// AI機能は利用可能（オプトアウト方式）、分析への利用は無効、記録は無期限に保存する
+func DefaultPrivacySettings() PrivacySettings {
+	return PrivacySettings{aiProcessing: true}
+}
+
+// ReconstructPrivacySettings はDBからの復元用
+func ReconstructPrivacySettings(aiProcessing, analytics bool, retentionYears int, consentVersion *string, consentedAt *time.Time) PrivacySettings {
+	settings := PrivacySettings{
+		aiProcessing:   aiProcessing,
+		analytics:      analytics,
+		retentionYears: retentionYears,
+		consentedAt:    consentedAt,
+	}
+	if consentVersion != nil {
+		settings.consentVersion = *consentVersion
+	}
+	return settings
+}
+
+// AIProcessingEnabled は外部のAIプロバイダーへの送信が許可されているかを返す
+func (p PrivacySettings) AIProcessingEnabled() bool {
+	return p.aiProcessing
+}
+
+// AnalyticsEnabled は利用状況の分析への利用が許可されているかを返す
+func (p PrivacySettings) AnalyticsEnabled() bool {
+	return p.analytics
+}
+
+// RetentionYears は記録の保存期間（年）を返す。0の場合は無期限
+func (p PrivacySettings) RetentionYears() int {
+	return p.retentionYears
+}
+
+// ConsentVersion は同意したプライバシーポリシーのバージョンを返す
+func (p PrivacySettings) ConsentVersion() string {
+	return p.consentVersion
+}
+
+// ConsentedAt は設定に同意した日時を返す
+func (p PrivacySettings) ConsentedAt() *time.Time {
+	return p.consentedAt
+}
@@ -0,0 +1,61 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewPrivacySettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		retentionYears int
+		consentVersion string
+		wantErr        error
+	}{
+		// 正常系
+		{"無期限は有効", 0, "2026-10", nil},
+		{"上限の年数は有効", vo.MaxDataRetentionYears, "2026-10", nil},
+		{"20文字のバージョンは有効", 3, strings.Repeat("v", 20), nil},
+		// 異常系
+		{"負の年数はエラー", -1, "2026-10", domainErrors.ErrInvalidDataRetention},
+		{"上限を超える年数はエラー", vo.MaxDataRetentionYears + 1, "2026-10", domainErrors.ErrInvalidDataRetention},
+		{"バージョンが空はエラー", 1, "", domainErrors.ErrConsentVersionRequired},
+		{"21文字のバージョンはエラー", 1, strings.Repeat("v", 21), domainErrors.ErrConsentVersionTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vo.NewPrivacySettings(false, true, tt.retentionYears, tt.consentVersion)
+
+			if err != tt.wantErr {
+				t.Errorf("NewPrivacySettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.AIProcessingEnabled() || !got.AnalyticsEnabled() {
+				t.Errorf("AIProcessingEnabled() = %v, AnalyticsEnabled() = %v, want false, true", got.AIProcessingEnabled(), got.AnalyticsEnabled())
+			}
+			if got.RetentionYears() != tt.retentionYears || got.ConsentVersion() != tt.consentVersion {
+				t.Errorf("RetentionYears() = %v, ConsentVersion() = %v", got.RetentionYears(), got.ConsentVersion())
+			}
+			if got.ConsentedAt() == nil {
+				t.Error("ConsentedAt() should be set")
+			}
+		})
+	}
+}
+
+func TestDefaultPrivacySettings(t *testing.T) {
+	got := vo.DefaultPrivacySettings()
+	if !got.AIProcessingEnabled() || got.AnalyticsEnabled() || got.RetentionYears() != 0 {
+		t.Errorf("DefaultPrivacySettings() = %+v, want AI enabled, analytics disabled, no retention limit", got)
+	}
+	if got.ConsentVersion() != "" || got.ConsentedAt() != nil {
+		t.Errorf("DefaultPrivacySettings() should not have consent, got %q %v", got.ConsentVersion(), got.ConsentedAt())
+	}
+}
@@ -0,0 +1,26 @@
+package vo
+
+// ProfileChangeID はプロフィール変更履歴の識別子を表す値オブジェクト
+type ProfileChangeID struct {
+	value UUID
+}
+
+// NewProfileChangeID は新しいProfileChangeIDを生成する
+func NewProfileChangeID() ProfileChangeID {
+	return ProfileChangeID{value: NewUUID()}
+}
+
+// ReconstructProfileChangeID はDBからProfileChangeIDを復元する
+func ReconstructProfileChangeID(value string) ProfileChangeID {
+	return ProfileChangeID{value: ReconstructUUID(value)}
+}
+
+// String はProfileChangeIDの文字列表現を返す
+func (p ProfileChangeID) String() string {
+	return p.value.String()
+}
+
+// Equals は2つのProfileChangeIDが等しいかを比較する
+func (p ProfileChangeID) Equals(other ProfileChangeID) bool {
+	return p.value.Equals(other.value)
+}
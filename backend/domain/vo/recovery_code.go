@@ -0,0 +1,65 @@
+package vo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+
+	domainErrors "caltrack/domain/errors"
+)
+
+// リカバリーコードの長さ（10バイト = Base32で16文字、80bit）
+const (
+	recoveryCodeBytes     = 10
+	recoveryCodeLength    = 16
+	recoveryCodeGroupSize = 4
+)
+
+// recoveryCodeEncoding は読み間違いの少ない小文字Base32（パディングなし）
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// RecoveryCode は認証アプリを使えない場合に2段階認証の代わりに使う使い捨てコードを表す
+// DBには平文を保存せず、Hash() の値のみを保存する
+type RecoveryCode struct {
+	value string
+}
+
+// NewRecoveryCode は新しいリカバリーコードを生成する
+func NewRecoveryCode() (RecoveryCode, error) {
+	bytes := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return RecoveryCode{}, domainErrors.ErrRecoveryCodeGenerationFailed
+	}
+	return RecoveryCode{value: recoveryCodeEncoding.EncodeToString(bytes)}, nil
+}
+
+// ParseRecoveryCode は入力された文字列からリカバリーコードを復元する
+// 区切りのハイフン・空白と大文字小文字の違いは無視する
+func ParseRecoveryCode(input string) (RecoveryCode, error) {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(input))
+	if len(normalized) != recoveryCodeLength {
+		return RecoveryCode{}, domainErrors.ErrInvalidRecoveryCode
+	}
+	if _, err := recoveryCodeEncoding.DecodeString(normalized); err != nil {
+		return RecoveryCode{}, domainErrors.ErrInvalidRecoveryCode
+	}
+	return RecoveryCode{value: normalized}, nil
+}
+
+// String はユーザーに表示する形式（4文字ごとにハイフン区切り）を返す
+func (c RecoveryCode) String() string {
+	groups := make([]string, 0, recoveryCodeLength/recoveryCodeGroupSize)
+	for i := 0; i < len(c.value); i += recoveryCodeGroupSize {
+		groups = append(groups, c.value[i:i+recoveryCodeGroupSize])
+	}
+	return strings.Join(groups, "-")
+}
+
+// Hash はDB保存用のハッシュ（SHA-256の16進表現）を返す
+// コード自体が十分なエントロピーを持つため、ソルトなしのハッシュで照合する
+func (c RecoveryCode) Hash() string {
+	sum := sha256.Sum256([]byte(c.value))
+	return hex.EncodeToString(sum[:])
+}
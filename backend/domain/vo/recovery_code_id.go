@@ -0,0 +1,26 @@
+package vo
+
+// RecoveryCodeID はリカバリーコードの識別子を表す値オブジェクト
+type RecoveryCodeID struct {
+	value UUID
+}
+
+// NewRecoveryCodeID は新しいRecoveryCodeIDを生成する
+func NewRecoveryCodeID() RecoveryCodeID {
+	return RecoveryCodeID{value: NewUUID()}
+}
+
+// ReconstructRecoveryCodeID はDBからRecoveryCodeIDを復元する
+func ReconstructRecoveryCodeID(value string) RecoveryCodeID {
+	return RecoveryCodeID{value: ReconstructUUID(value)}
+}
+
+// String はRecoveryCodeIDの文字列表現を返す
+func (a RecoveryCodeID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのRecoveryCodeIDが等しいかを比較する
+func (a RecoveryCodeID) Equals(other RecoveryCodeID) bool {
+	return a.value.Equals(other.value)
+}
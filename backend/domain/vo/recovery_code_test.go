@@ -0,0 +1,58 @@
+package vo_test
+
+import (
+	"strings"
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewRecoveryCode(t *testing.T) {
+	code1, err := vo.NewRecoveryCode()
+	if err != nil {
+		t.Fatalf("NewRecoveryCode() unexpected error: %v", err)
+	}
+	code2, _ := vo.NewRecoveryCode()
+
+	if code1.String() == code2.String() {
+		t.Error("NewRecoveryCode() should generate unique codes")
+	}
+	// 4文字×4グループのハイフン区切り
+	if len(code1.String()) != 19 || strings.Count(code1.String(), "-") != 3 {
+		t.Errorf("String() = %s, want xxxx-xxxx-xxxx-xxxx format", code1.String())
+	}
+}
+
+func TestParseRecoveryCode(t *testing.T) {
+	code, _ := vo.NewRecoveryCode()
+
+	t.Run("表示形式をそのまま復元できる", func(t *testing.T) {
+		parsed, err := vo.ParseRecoveryCode(code.String())
+		if err != nil {
+			t.Fatalf("ParseRecoveryCode() unexpected error: %v", err)
+		}
+		if parsed.Hash() != code.Hash() {
+			t.Error("parsed code should have the same hash")
+		}
+	})
+
+	t.Run("区切りと大文字小文字の違いは無視する", func(t *testing.T) {
+		input := " " + strings.ToUpper(strings.ReplaceAll(code.String(), "-", "")) + " "
+		parsed, err := vo.ParseRecoveryCode(input)
+		if err != nil {
+			t.Fatalf("ParseRecoveryCode() unexpected error: %v", err)
+		}
+		if parsed.Hash() != code.Hash() {
+			t.Error("parsed code should have the same hash")
+		}
+	})
+
+	t.Run("不正な形式はエラー", func(t *testing.T) {
+		for _, input := range []string{"", "123456", "abcd-efgh-ijkl", "abcd-efgh-ijkl-mno1"} {
+			if _, err := vo.ParseRecoveryCode(input); err != domainErrors.ErrInvalidRecoveryCode {
+				t.Errorf("ParseRecoveryCode(%q) error = %v, want %v", input, err, domainErrors.ErrInvalidRecoveryCode)
+			}
+		}
+	})
+}
@@ -2,7 +2,9 @@ package vo
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 
 	domainErrors "caltrack/domain/errors"
 )
@@ -53,3 +55,10 @@ func (s SessionID) String() string {
 func (s SessionID) Equals(other SessionID) bool {
 	return s.value == other.value
 }
+
+// PublicID はセッションを外部に示すための識別子を返す
+// セッション一覧などのレスポンスにセッションID自体を含めないよう、SHA-256ハッシュの先頭32文字を使う
+func (s SessionID) PublicID() string {
+	sum := sha256.Sum256([]byte(s.value))
+	return hex.EncodeToString(sum[:16])
+}
@@ -88,3 +88,22 @@ func TestSessionID_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionID_PublicID(t *testing.T) {
+	id1, _ := vo.NewSessionID()
+	id2, _ := vo.ParseSessionID(id1.String())
+	id3, _ := vo.NewSessionID()
+
+	if len(id1.PublicID()) != 32 {
+		t.Errorf("len(PublicID()) = %d, want 32", len(id1.PublicID()))
+	}
+	if id1.PublicID() == id1.String() {
+		t.Error("PublicID() should not expose the session id")
+	}
+	if id1.PublicID() != id2.PublicID() {
+		t.Error("PublicID() should be stable for the same session id")
+	}
+	if id1.PublicID() == id3.PublicID() {
+		t.Error("PublicID() should differ for different session ids")
+	}
+}
@@ -0,0 +1,44 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+// TargetSnapshotID は目標値スナップショットの識別子を表す値オブジェクト
+type TargetSnapshotID struct {
+	value UUID
+}
+
+// NewTargetSnapshotID は新しいTargetSnapshotIDを生成する
+func NewTargetSnapshotID() TargetSnapshotID {
+	return TargetSnapshotID{value: NewUUID()}
+}
+
+// ParseTargetSnapshotID は文字列からTargetSnapshotIDを生成する
+func ParseTargetSnapshotID(value string) (TargetSnapshotID, error) {
+	parsed, err := ParseUUID(value)
+	if err != nil {
+		return TargetSnapshotID{}, domainErrors.ErrInvalidTargetSnapshotID
+	}
+	return TargetSnapshotID{value: parsed}, nil
+}
+
+// ReconstructTargetSnapshotID はDBからTargetSnapshotIDを復元する
+func ReconstructTargetSnapshotID(value string) TargetSnapshotID {
+	return TargetSnapshotID{value: ReconstructUUID(value)}
+}
+
+// String はTargetSnapshotIDの文字列表現を返す
+func (a TargetSnapshotID) String() string {
+	return a.value.String()
+}
+
+// IsZero はTargetSnapshotIDがゼロ値かを判定する
+func (a TargetSnapshotID) IsZero() bool {
+	return a.value.IsZero()
+}
+
+// Equals は2つのTargetSnapshotIDが等しいかを比較する
+func (a TargetSnapshotID) Equals(other TargetSnapshotID) bool {
+	return a.value.Equals(other.value)
+}
@@ -0,0 +1,67 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+const (
+	TokenScopeRecordsRead  = "records:read"
+	TokenScopeRecordsWrite = "records:write"
+	TokenScopeProfileRead  = "profile:read"
+)
+
+var validTokenScopes = map[string]bool{
+	TokenScopeRecordsRead:  true,
+	TokenScopeRecordsWrite: true,
+	TokenScopeProfileRead:  true,
+}
+
+// TokenScope はパーソナルアクセストークンで許可する操作の範囲を表す
+type TokenScope struct {
+	value string
+}
+
+// NewTokenScope は文字列からTokenScopeを生成する
+func NewTokenScope(value string) (TokenScope, error) {
+	if !validTokenScopes[value] {
+		return TokenScope{}, domainErrors.ErrInvalidTokenScope
+	}
+	return TokenScope{value: value}, nil
+}
+
+// NewTokenScopes は文字列の一覧からTokenScopeの一覧を生成する
+// 重複は取り除き、空の場合は ErrAccessTokenScopeRequired を返す
+func NewTokenScopes(values []string) ([]TokenScope, error) {
+	if len(values) == 0 {
+		return nil, domainErrors.ErrAccessTokenScopeRequired
+	}
+
+	seen := make(map[string]bool, len(values))
+	scopes := make([]TokenScope, 0, len(values))
+	for _, value := range values {
+		scope, err := NewTokenScope(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// ReconstructTokenScope はDBからTokenScopeを復元する
+func ReconstructTokenScope(value string) TokenScope {
+	return TokenScope{value: value}
+}
+
+func (s TokenScope) String() string {
+	return s.value
+}
+
+// Equals は2つのTokenScopeが等しいかを比較する
+func (s TokenScope) Equals(other TokenScope) bool {
+	return s.value == other.value
+}
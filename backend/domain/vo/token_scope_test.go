@@ -0,0 +1,58 @@
+package vo_test
+
+import (
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewTokenScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"records:read", vo.TokenScopeRecordsRead, nil},
+		{"records:write", vo.TokenScopeRecordsWrite, nil},
+		{"profile:read", vo.TokenScopeProfileRead, nil},
+		{"未定義のスコープはエラー", "profile:write", domainErrors.ErrInvalidTokenScope},
+		{"空文字はエラー", "", domainErrors.ErrInvalidTokenScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := vo.NewTokenScope(tt.input)
+			if err != tt.wantErr {
+				t.Fatalf("NewTokenScope(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && scope.String() != tt.input {
+				t.Errorf("String() = %s, want %s", scope.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestNewTokenScopes(t *testing.T) {
+	t.Run("正常系_重複を取り除く", func(t *testing.T) {
+		scopes, err := vo.NewTokenScopes([]string{vo.TokenScopeRecordsRead, vo.TokenScopeProfileRead, vo.TokenScopeRecordsRead})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(scopes) != 2 {
+			t.Errorf("len(scopes) = %d, want 2", len(scopes))
+		}
+	})
+
+	t.Run("異常系_空", func(t *testing.T) {
+		if _, err := vo.NewTokenScopes(nil); err != domainErrors.ErrAccessTokenScopeRequired {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrAccessTokenScopeRequired)
+		}
+	})
+
+	t.Run("異常系_不正なスコープを含む", func(t *testing.T) {
+		if _, err := vo.NewTokenScopes([]string{vo.TokenScopeRecordsRead, "admin"}); err != domainErrors.ErrInvalidTokenScope {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrInvalidTokenScope)
+		}
+	})
+}
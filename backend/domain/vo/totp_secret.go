@@ -0,0 +1,120 @@
+package vo
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+)
+
+// TOTPのパラメータ（RFC 6238）
+// 認証アプリの多くは既定値（SHA-1・6桁・30秒）以外に対応しないため、既定値に固定する
+const (
+	totpSecretBytes = 20 // RFC 4226 が推奨する160bit
+	TOTPDigits      = 6
+	TOTPPeriod      = 30 * time.Second
+	// 端末の時刻ずれを考慮して前後1ステップまで許容する
+	totpSkewSteps = 1
+)
+
+// totpEncoding は認証アプリに渡すシークレットのエンコーディング（パディングなしのBase32）
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPSecret はTOTP（時間ベースのワンタイムパスワード）の共有シークレットを表す値オブジェクト
+// コードの検証に平文が必要なため、ハッシュ化せずに保持する
+type TOTPSecret struct {
+	key []byte
+}
+
+// NewTOTPSecret は新しいシークレットをランダムに生成する
+func NewTOTPSecret() (TOTPSecret, error) {
+	key := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return TOTPSecret{}, domainErrors.ErrTOTPSecretGenerationFailed
+	}
+	return TOTPSecret{key: key}, nil
+}
+
+// ReconstructTOTPSecret はDBに保存したBase32文字列からシークレットを復元する
+// 復号できない値の場合は空のシークレットとなり、どのコードも一致しない
+func ReconstructTOTPSecret(encoded string) TOTPSecret {
+	key, err := totpEncoding.DecodeString(encoded)
+	if err != nil {
+		return TOTPSecret{}
+	}
+	return TOTPSecret{key: key}
+}
+
+// String はシークレットのBase32表現を返す（認証アプリへの手入力用）
+func (s TOTPSecret) String() string {
+	return totpEncoding.EncodeToString(s.key)
+}
+
+// ProvisioningURI は認証アプリに登録するための otpauth:// URI を返す
+// QRコードにはこのURIをそのまま埋め込む
+func (s TOTPSecret) ProvisioningURI(issuer, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", s.String())
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Code は指定時刻のTOTPコードを返す
+func (s TOTPSecret) Code(t time.Time) string {
+	return s.codeAt(TOTPStep(t))
+}
+
+// Verify はコードが現在時刻の前後の許容範囲内で一致するかを検証する
+// 一致した場合はそのタイムステップを返す（同じコードの再利用を防ぐために使う）
+func (s TOTPSecret) Verify(code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(s.key) == 0 || len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if subtle.ConstantTimeCompare([]byte(s.codeAt(step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// codeAt はタイムステップに対するコードを計算する（RFC 4226 の HOTP）
+func (s TOTPSecret) codeAt(step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, s.key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// 動的切り捨て
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
+
+// TOTPStep は時刻をTOTPのタイムステップ（UNIX時間 / 30秒）に変換する
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
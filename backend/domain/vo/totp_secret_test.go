@@ -0,0 +1,129 @@
+package vo_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// rfc6238Secret は RFC 6238 付録Bのテストベクトルで使われるシークレット（"12345678901234567890"）
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPSecret_Code(t *testing.T) {
+	secret := vo.ReconstructTOTPSecret(rfc6238Secret)
+
+	// RFC 6238 付録B（SHA-1）の8桁の値の下6桁
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		if got := secret.Code(time.Unix(tt.unix, 0)); got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPSecret_Verify(t *testing.T) {
+	secret, err := vo.NewTOTPSecret()
+	if err != nil {
+		t.Fatalf("NewTOTPSecret() unexpected error: %v", err)
+	}
+	now := time.Now()
+
+	t.Run("現在のコードは一致する", func(t *testing.T) {
+		step, ok := secret.Verify(secret.Code(now), now)
+		if !ok {
+			t.Fatal("Verify() should accept the current code")
+		}
+		if step != vo.TOTPStep(now) {
+			t.Errorf("step = %d, want %d", step, vo.TOTPStep(now))
+		}
+	})
+
+	t.Run("前後1ステップのずれは許容する", func(t *testing.T) {
+		if _, ok := secret.Verify(secret.Code(now.Add(-vo.TOTPPeriod)), now); !ok {
+			t.Error("Verify() should accept the previous code")
+		}
+		if _, ok := secret.Verify(secret.Code(now.Add(vo.TOTPPeriod)), now); !ok {
+			t.Error("Verify() should accept the next code")
+		}
+	})
+
+	t.Run("2ステップ以上前のコードは一致しない", func(t *testing.T) {
+		old := secret.Code(now.Add(-3 * vo.TOTPPeriod))
+		if old == secret.Code(now) || old == secret.Code(now.Add(-vo.TOTPPeriod)) || old == secret.Code(now.Add(vo.TOTPPeriod)) {
+			t.Skip("coincidental code collision")
+		}
+		if _, ok := secret.Verify(old, now); ok {
+			t.Error("Verify() should reject an expired code")
+		}
+	})
+
+	t.Run("桁数が異なるコードは一致しない", func(t *testing.T) {
+		for _, code := range []string{"", "12345", "1234567", "abcdef"} {
+			if _, ok := secret.Verify(code, now); ok {
+				t.Errorf("Verify(%q) should be false", code)
+			}
+		}
+	})
+
+	t.Run("復号できないシークレットはどのコードとも一致しない", func(t *testing.T) {
+		broken := vo.ReconstructTOTPSecret("!!invalid!!")
+		if _, ok := broken.Verify(broken.Code(now), now); ok {
+			t.Error("Verify() should be false for an invalid secret")
+		}
+	})
+}
+
+func TestTOTPSecret_String(t *testing.T) {
+	secret, _ := vo.NewTOTPSecret()
+
+	restored := vo.ReconstructTOTPSecret(secret.String())
+	if restored.String() != secret.String() {
+		t.Errorf("restored secret = %s, want %s", restored.String(), secret.String())
+	}
+	if strings.Contains(secret.String(), "=") {
+		t.Error("String() should not contain padding")
+	}
+}
+
+func TestTOTPSecret_ProvisioningURI(t *testing.T) {
+	secret := vo.ReconstructTOTPSecret(rfc6238Secret)
+
+	uri, err := url.Parse(secret.ProvisioningURI("CalTrack", "user@example.com"))
+	if err != nil {
+		t.Fatalf("ProvisioningURI() is not a valid URL: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Errorf("scheme/host = %s/%s, want otpauth/totp", uri.Scheme, uri.Host)
+	}
+	if uri.Path != "/CalTrack:user@example.com" {
+		t.Errorf("path = %s, want /CalTrack:user@example.com", uri.Path)
+	}
+
+	query := uri.Query()
+	wants := map[string]string{
+		"secret":    rfc6238Secret,
+		"issuer":    "CalTrack",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	for key, want := range wants {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %s, want %s", key, got, want)
+		}
+	}
+}
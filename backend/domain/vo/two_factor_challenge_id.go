@@ -0,0 +1,26 @@
+package vo
+
+// TwoFactorChallengeID は2段階認証チャレンジの識別子を表す値オブジェクト
+type TwoFactorChallengeID struct {
+	value UUID
+}
+
+// NewTwoFactorChallengeID は新しいTwoFactorChallengeIDを生成する
+func NewTwoFactorChallengeID() TwoFactorChallengeID {
+	return TwoFactorChallengeID{value: NewUUID()}
+}
+
+// ReconstructTwoFactorChallengeID はDBからTwoFactorChallengeIDを復元する
+func ReconstructTwoFactorChallengeID(value string) TwoFactorChallengeID {
+	return TwoFactorChallengeID{value: ReconstructUUID(value)}
+}
+
+// String はTwoFactorChallengeIDの文字列表現を返す
+func (a TwoFactorChallengeID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのTwoFactorChallengeIDが等しいかを比較する
+func (a TwoFactorChallengeID) Equals(other TwoFactorChallengeID) bool {
+	return a.value.Equals(other.value)
+}
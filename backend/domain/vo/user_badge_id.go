@@ -0,0 +1,44 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+// UserBadgeID は獲得バッジの識別子を表す値オブジェクト
+type UserBadgeID struct {
+	value UUID
+}
+
+// NewUserBadgeID は新しいUserBadgeIDを生成する
+func NewUserBadgeID() UserBadgeID {
+	return UserBadgeID{value: NewUUID()}
+}
+
+// ParseUserBadgeID は文字列からUserBadgeIDを生成する
+func ParseUserBadgeID(value string) (UserBadgeID, error) {
+	parsed, err := ParseUUID(value)
+	if err != nil {
+		return UserBadgeID{}, domainErrors.ErrInvalidUserBadgeID
+	}
+	return UserBadgeID{value: parsed}, nil
+}
+
+// ReconstructUserBadgeID はDBからUserBadgeIDを復元する
+func ReconstructUserBadgeID(value string) UserBadgeID {
+	return UserBadgeID{value: ReconstructUUID(value)}
+}
+
+// String はUserBadgeIDの文字列表現を返す
+func (a UserBadgeID) String() string {
+	return a.value.String()
+}
+
+// IsZero はUserBadgeIDがゼロ値かを判定する
+func (a UserBadgeID) IsZero() bool {
+	return a.value.IsZero()
+}
+
+// Equals は2つのUserBadgeIDが等しいかを比較する
+func (a UserBadgeID) Equals(other UserBadgeID) bool {
+	return a.value.Equals(other.value)
+}
@@ -0,0 +1,26 @@
+package vo
+
+// UserIdentityID は外部IDプロバイダーとの連携の識別子を表す値オブジェクト
+type UserIdentityID struct {
+	value UUID
+}
+
+// NewUserIdentityID は新しいUserIdentityIDを生成する
+func NewUserIdentityID() UserIdentityID {
+	return UserIdentityID{value: NewUUID()}
+}
+
+// ReconstructUserIdentityID はDBからUserIdentityIDを復元する
+func ReconstructUserIdentityID(value string) UserIdentityID {
+	return UserIdentityID{value: ReconstructUUID(value)}
+}
+
+// String はUserIdentityIDの文字列表現を返す
+func (a UserIdentityID) String() string {
+	return a.value.String()
+}
+
+// Equals は2つのUserIdentityIDが等しいかを比較する
+func (a UserIdentityID) Equals(other UserIdentityID) bool {
+	return a.value.Equals(other.value)
+}
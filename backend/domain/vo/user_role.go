@@ -0,0 +1,44 @@
+package vo
+
+import (
+	domainErrors "caltrack/domain/errors"
+)
+
+const (
+	UserRoleUser  = "user"  // 一般ユーザー
+	UserRoleAdmin = "admin" // 管理者（ユーザーの検索・利用状況の参照・アカウントの停止ができる）
+)
+
+var validUserRoles = map[string]bool{
+	UserRoleUser:  true,
+	UserRoleAdmin: true,
+}
+
+// UserRole はユーザーの権限を表すValue Object
+type UserRole struct {
+	value string
+}
+
+// NewUserRole は新しいUserRoleを生成する
+// user または admin のみ許可する
+func NewUserRole(value string) (UserRole, error) {
+	if !validUserRoles[value] {
+		return UserRole{}, domainErrors.ErrInvalidUserRole
+	}
+	return UserRole{value: value}, nil
+}
+
+// DefaultUserRole はデフォルトの権限（user）を返す
+func DefaultUserRole() UserRole {
+	return UserRole{value: UserRoleUser}
+}
+
+// String は権限の文字列表現を返す
+func (r UserRole) String() string {
+	return r.value
+}
+
+// IsAdmin は管理者かどうかを返す
+func (r UserRole) IsAdmin() bool {
+	return r.value == UserRoleAdmin
+}
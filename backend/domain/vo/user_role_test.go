@@ -0,0 +1,52 @@
+package vo_test
+
+import (
+	"testing"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+func TestNewUserRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantRole  string
+		wantAdmin bool
+		wantErr   error
+	}{
+		// 正常系
+		{"userは有効", "user", "user", false, nil},
+		{"adminは有効", "admin", "admin", true, nil},
+		// 異常系
+		{"空文字はエラー", "", "", false, domainErrors.ErrInvalidUserRole},
+		{"無効な値はエラー", "owner", "", false, domainErrors.ErrInvalidUserRole},
+		{"大文字はエラー", "Admin", "", false, domainErrors.ErrInvalidUserRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vo.NewUserRole(tt.input)
+
+			if err != tt.wantErr {
+				t.Errorf("NewUserRole(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.wantRole {
+				t.Errorf("NewUserRole(%q).String() = %v, want %v", tt.input, got.String(), tt.wantRole)
+			}
+			if got.IsAdmin() != tt.wantAdmin {
+				t.Errorf("NewUserRole(%q).IsAdmin() = %v, want %v", tt.input, got.IsAdmin(), tt.wantAdmin)
+			}
+		})
+	}
+}
+
+func TestDefaultUserRole(t *testing.T) {
+	if got := vo.DefaultUserRole(); got.String() != vo.UserRoleUser || got.IsAdmin() {
+		t.Errorf("DefaultUserRole() = %v, want user", got)
+	}
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/vo"
+)
+
+// CreateTokenRequest はパーソナルアクセストークン発行のリクエストDTO
+type CreateTokenRequest struct {
+	Name      string     `json:"name" example:"Spreadsheet sync"`                    // トークンの用途を識別する名前
+	Scopes    []string   `json:"scopes" example:"records:read,profile:read"`         // records:read / records:write / profile:read
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" example:"2026-12-31T00:00:00Z"` // 省略した場合は無期限
+}
+
+// ToDomain はリクエストのスコープをドメインのVOに変換する
+func (r CreateTokenRequest) ToDomain() ([]vo.TokenScope, error) {
+	return vo.NewTokenScopes(r.Scopes)
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/usecase"
+)
+
+// TokenResponse はパーソナルアクセストークン1件のレスポンスDTO
+// トークンの平文は含まない
+type TokenResponse struct {
+	ID         string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string     `json:"name" example:"Spreadsheet sync"`
+	Scopes     []string   `json:"scopes" example:"records:read,profile:read"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// NewTokenResponse はトークンからレスポンスDTOを生成する
+func NewTokenResponse(token *entity.PersonalAccessToken) TokenResponse {
+	scopes := make([]string, len(token.Scopes()))
+	for i, scope := range token.Scopes() {
+		scopes[i] = scope.String()
+	}
+	return TokenResponse{
+		ID:         token.ID().String(),
+		Name:       token.Name(),
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt(),
+		LastUsedAt: token.LastUsedAt(),
+		CreatedAt:  token.CreatedAt(),
+	}
+}
+
+// TokensResponse はパーソナルアクセストークン一覧のレスポンスDTO
+type TokensResponse struct {
+	Tokens []TokenResponse `json:"tokens"`
+}
+
+// NewTokensResponse はトークン一覧からレスポンスDTOを生成する
+func NewTokensResponse(tokens []*entity.PersonalAccessToken) TokensResponse {
+	items := make([]TokenResponse, len(tokens))
+	for i, token := range tokens {
+		items[i] = NewTokenResponse(token)
+	}
+	return TokensResponse{Tokens: items}
+}
+
+// CreateTokenResponse はパーソナルアクセストークン発行のレスポンスDTO
+type CreateTokenResponse struct {
+	TokenResponse
+	Token string `json:"token" example:"ctpat_q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // 一度だけ表示するトークンの平文
+}
+
+// NewCreateTokenResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewCreateTokenResponse(output *usecase.CreatePersonalAccessTokenOutput) CreateTokenResponse {
+	return CreateTokenResponse{
+		TokenResponse: NewTokenResponse(output.Token),
+		Token:         output.AccessToken.String(),
+	}
+}
@@ -0,0 +1,159 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/accesstoken/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+// PersonalAccessTokenUsecaseInterface はPersonalAccessTokenUsecaseのインターフェース
+type PersonalAccessTokenUsecaseInterface interface {
+	CreateToken(ctx context.Context, userID vo.UserID, name string, scopes []vo.TokenScope, expiresAt *time.Time) (*usecase.CreatePersonalAccessTokenOutput, error)
+	ListTokens(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID vo.UserID, tokenID vo.PersonalAccessTokenID) error
+}
+
+// AccessTokenHandler はパーソナルアクセストークンの管理に関するHTTPハンドラ
+type AccessTokenHandler struct {
+	usecase PersonalAccessTokenUsecaseInterface
+}
+
+// NewAccessTokenHandler は AccessTokenHandler のインスタンスを生成する
+func NewAccessTokenHandler(uc PersonalAccessTokenUsecaseInterface) *AccessTokenHandler {
+	return &AccessTokenHandler{usecase: uc}
+}
+
+// List はパーソナルアクセストークンの一覧を取得する
+// @Summary パーソナルアクセストークン一覧取得
+// @Description 認証ユーザーが発行したパーソナルアクセストークンを作成日時の降順で取得する。トークンの平文は含まない
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.TokensResponse "取得成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/tokens [get]
+func (h *AccessTokenHandler) List(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.usecase.ListTokens(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTokensResponse(tokens))
+}
+
+// Create はパーソナルアクセストークンを発行する
+// @Summary パーソナルアクセストークン発行
+// @Description スクリプト等から Authorization: Bearer ヘッダーで利用するトークンを発行する。トークンの平文はこのレスポンスでのみ返す
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateTokenRequest true "トークン発行リクエスト"
+// @Success 201 {object} dto.CreateTokenResponse "発行成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正・バリデーションエラー"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/tokens [post]
+func (h *AccessTokenHandler) Create(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	scopes, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	output, err := h.usecase.CreateToken(c.Request.Context(), userID, req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewCreateTokenResponse(output))
+}
+
+// Revoke はパーソナルアクセストークンを失効させる
+// @Summary パーソナルアクセストークン失効
+// @Description 認証ユーザーのパーソナルアクセストークンを削除し、以降のリクエストで使えなくする
+// @Tags auth
+// @Produce json
+// @Param id path string true "トークンID"
+// @Success 200 {object} map[string]string "失効成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "トークンが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/tokens/{id} [delete]
+func (h *AccessTokenHandler) Revoke(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	// 形式が不正なIDは存在しないトークンとして扱う
+	tokenID, err := vo.ParsePersonalAccessTokenID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, domainErrors.ErrPersonalAccessTokenNotFound)
+		return
+	}
+
+	if err := h.usecase.RevokeToken(c.Request.Context(), userID, tokenID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Access token revoked successfully"})
+}
+
+// currentUserID はAuthMiddlewareが設定したユーザーIDを取得する
+// 取得できない場合は401を返してfalseを返す
+func currentUserID(c *gin.Context) (vo.UserID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return vo.UserID{}, false
+	}
+	return vo.ReconstructUserID(userIDStr.(string)), true
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *AccessTokenHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrAccessTokenNameRequired) ||
+		errors.Is(err, domainErrors.ErrAccessTokenNameTooLong) ||
+		errors.Is(err, domainErrors.ErrAccessTokenScopeRequired) ||
+		errors.Is(err, domainErrors.ErrInvalidTokenScope) ||
+		errors.Is(err, domainErrors.ErrAccessTokenExpiryMustBeFuture) {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrPersonalAccessTokenNotFound) {
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "Access token not found", nil)
+		return
+	}
+
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+}
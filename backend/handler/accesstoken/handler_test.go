@@ -0,0 +1,249 @@
+package accesstoken_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/accesstoken"
+	"caltrack/handler/accesstoken/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockPersonalAccessTokenUsecase はPersonalAccessTokenUsecaseのモック実装
+type MockPersonalAccessTokenUsecase struct {
+	CreateTokenFunc func(ctx context.Context, userID vo.UserID, name string, scopes []vo.TokenScope, expiresAt *time.Time) (*usecase.CreatePersonalAccessTokenOutput, error)
+	ListTokensFunc  func(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error)
+	RevokeTokenFunc func(ctx context.Context, userID vo.UserID, tokenID vo.PersonalAccessTokenID) error
+}
+
+func (m *MockPersonalAccessTokenUsecase) CreateToken(ctx context.Context, userID vo.UserID, name string, scopes []vo.TokenScope, expiresAt *time.Time) (*usecase.CreatePersonalAccessTokenOutput, error) {
+	if m.CreateTokenFunc != nil {
+		return m.CreateTokenFunc(ctx, userID, name, scopes, expiresAt)
+	}
+	return nil, nil
+}
+
+func (m *MockPersonalAccessTokenUsecase) ListTokens(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error) {
+	if m.ListTokensFunc != nil {
+		return m.ListTokensFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockPersonalAccessTokenUsecase) RevokeToken(ctx context.Context, userID vo.UserID, tokenID vo.PersonalAccessTokenID) error {
+	if m.RevokeTokenFunc != nil {
+		return m.RevokeTokenFunc(ctx, userID, tokenID)
+	}
+	return nil
+}
+
+// performRequest は認証済みユーザーとしてハンドラにリクエストを渡して結果を返す
+func performRequest(handlerFunc gin.HandlerFunc, method, path, body string, params ...gin.Param) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	c.Set("userID", testUserID)
+	handlerFunc(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+func TestAccessTokenHandler_Create(t *testing.T) {
+	t.Run("正常系_トークンを発行して平文を返す", func(t *testing.T) {
+		var gotName string
+		var gotScopes []vo.TokenScope
+		var gotExpiresAt *time.Time
+		mockUC := &MockPersonalAccessTokenUsecase{
+			CreateTokenFunc: func(ctx context.Context, userID vo.UserID, name string, scopes []vo.TokenScope, expiresAt *time.Time) (*usecase.CreatePersonalAccessTokenOutput, error) {
+				gotName, gotScopes, gotExpiresAt = name, scopes, expiresAt
+				token, raw, err := entity.NewPersonalAccessToken(userID, name, scopes, expiresAt)
+				if err != nil {
+					return nil, err
+				}
+				return &usecase.CreatePersonalAccessTokenOutput{Token: token, AccessToken: raw}, nil
+			},
+		}
+		handler := accesstoken.NewAccessTokenHandler(mockUC)
+
+		body := `{"name": "Spreadsheet sync", "scopes": ["records:read", "profile:read"], "expiresAt": "2099-01-01T00:00:00Z"}`
+		w := performRequest(handler.Create, http.MethodPost, "/api/v1/auth/tokens", body)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusCreated, w.Body.String())
+		}
+		if gotName != "Spreadsheet sync" || len(gotScopes) != 2 {
+			t.Errorf("name = %s, scopes = %v", gotName, gotScopes)
+		}
+		if gotExpiresAt == nil || !gotExpiresAt.Equal(time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expiresAt = %v", gotExpiresAt)
+		}
+
+		var resp dto.CreateTokenResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !strings.HasPrefix(resp.Token, vo.AccessTokenPrefix) {
+			t.Errorf("token = %s, want prefix %s", resp.Token, vo.AccessTokenPrefix)
+		}
+		if resp.ID == "" || resp.Name != "Spreadsheet sync" {
+			t.Errorf("resp = %+v", resp)
+		}
+	})
+
+	t.Run("異常系_不正なスコープ", func(t *testing.T) {
+		handler := accesstoken.NewAccessTokenHandler(&MockPersonalAccessTokenUsecase{})
+
+		w := performRequest(handler.Create, http.MethodPost, "/api/v1/auth/tokens", `{"name": "script", "scopes": ["admin"]}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_スコープなし", func(t *testing.T) {
+		handler := accesstoken.NewAccessTokenHandler(&MockPersonalAccessTokenUsecase{})
+
+		w := performRequest(handler.Create, http.MethodPost, "/api/v1/auth/tokens", `{"name": "script", "scopes": []}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_名前が空", func(t *testing.T) {
+		mockUC := &MockPersonalAccessTokenUsecase{
+			CreateTokenFunc: func(ctx context.Context, userID vo.UserID, name string, scopes []vo.TokenScope, expiresAt *time.Time) (*usecase.CreatePersonalAccessTokenOutput, error) {
+				return nil, domainErrors.ErrAccessTokenNameRequired
+			},
+		}
+		handler := accesstoken.NewAccessTokenHandler(mockUC)
+
+		w := performRequest(handler.Create, http.MethodPost, "/api/v1/auth/tokens", `{"name": "", "scopes": ["records:read"]}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_リクエストボディ不正", func(t *testing.T) {
+		handler := accesstoken.NewAccessTokenHandler(&MockPersonalAccessTokenUsecase{})
+
+		w := performRequest(handler.Create, http.MethodPost, "/api/v1/auth/tokens", `{invalid`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidRequest)
+	})
+}
+
+func TestAccessTokenHandler_List(t *testing.T) {
+	t.Run("正常系_平文を含まない一覧を返す", func(t *testing.T) {
+		lastUsedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		token := entity.ReconstructPersonalAccessToken(
+			vo.NewPersonalAccessTokenID().String(),
+			testUserID,
+			"Spreadsheet sync",
+			"hash",
+			[]string{vo.TokenScopeRecordsRead},
+			nil,
+			&lastUsedAt,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		)
+		mockUC := &MockPersonalAccessTokenUsecase{
+			ListTokensFunc: func(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error) {
+				return []*entity.PersonalAccessToken{token}, nil
+			},
+		}
+		handler := accesstoken.NewAccessTokenHandler(mockUC)
+
+		w := performRequest(handler.List, http.MethodGet, "/api/v1/auth/tokens", "")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if strings.Contains(w.Body.String(), `"token"`) {
+			t.Error("response should not contain the token value")
+		}
+
+		var resp dto.TokensResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Tokens) != 1 {
+			t.Fatalf("len(tokens) = %d, want 1", len(resp.Tokens))
+		}
+		if resp.Tokens[0].LastUsedAt == nil || !resp.Tokens[0].LastUsedAt.Equal(lastUsedAt) {
+			t.Errorf("lastUsedAt = %v, want %v", resp.Tokens[0].LastUsedAt, lastUsedAt)
+		}
+	})
+}
+
+func TestAccessTokenHandler_Revoke(t *testing.T) {
+	t.Run("正常系_トークンを失効させる", func(t *testing.T) {
+		tokenID := vo.NewPersonalAccessTokenID()
+		var gotID vo.PersonalAccessTokenID
+		mockUC := &MockPersonalAccessTokenUsecase{
+			RevokeTokenFunc: func(ctx context.Context, userID vo.UserID, id vo.PersonalAccessTokenID) error {
+				gotID = id
+				return nil
+			},
+		}
+		handler := accesstoken.NewAccessTokenHandler(mockUC)
+
+		w := performRequest(handler.Revoke, http.MethodDelete, "/api/v1/auth/tokens/"+tokenID.String(), "", gin.Param{Key: "id", Value: tokenID.String()})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if !gotID.Equals(tokenID) {
+			t.Errorf("id = %v, want %v", gotID, tokenID)
+		}
+	})
+
+	t.Run("異常系_存在しないトークン", func(t *testing.T) {
+		mockUC := &MockPersonalAccessTokenUsecase{
+			RevokeTokenFunc: func(ctx context.Context, userID vo.UserID, id vo.PersonalAccessTokenID) error {
+				return domainErrors.ErrPersonalAccessTokenNotFound
+			},
+		}
+		handler := accesstoken.NewAccessTokenHandler(mockUC)
+
+		id := vo.NewPersonalAccessTokenID().String()
+		w := performRequest(handler.Revoke, http.MethodDelete, "/api/v1/auth/tokens/"+id, "", gin.Param{Key: "id", Value: id})
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+
+	t.Run("異常系_ID形式不正", func(t *testing.T) {
+		handler := accesstoken.NewAccessTokenHandler(&MockPersonalAccessTokenUsecase{})
+
+		w := performRequest(handler.Revoke, http.MethodDelete, "/api/v1/auth/tokens/invalid", "", gin.Param{Key: "id", Value: "invalid"})
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+}
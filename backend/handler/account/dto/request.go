@@ -0,0 +1,21 @@
+package dto
+
+import (
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// DeleteAccountRequest は退会リクエストDTO
+type DeleteAccountRequest struct {
+	Password string `json:"password" example:"password123"` // 本人確認のための現在のパスワード
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// パスワードが形式を満たさない場合は、照合失敗として ErrCurrentPasswordIncorrect を返す
+func (r DeleteAccountRequest) ToDomain() (vo.Password, error) {
+	password, err := vo.NewPassword(r.Password)
+	if err != nil {
+		return vo.Password{}, domainErrors.ErrCurrentPasswordIncorrect
+	}
+	return password, nil
+}
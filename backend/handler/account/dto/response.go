@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+// 退会の状態
+const (
+	AccountDeletionStatusDeleted   = "deleted"
+	AccountDeletionStatusScheduled = "scheduled"
+)
+
+// AccountDeletionResponse は退会のレスポンスDTO
+type AccountDeletionResponse struct {
+	Status      string     `json:"status" example:"scheduled"` // deleted: 削除済み, scheduled: 猶予期間後に削除
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`      // 削除予定日時（猶予期間中にログインすると取り消される）
+}
+
+// NewAccountDeletionResponse は削除の申請からレスポンスDTOを生成する
+func NewAccountDeletionResponse(deletion *entity.AccountDeletion) AccountDeletionResponse {
+	if deletion.PurgedAt() != nil {
+		return AccountDeletionResponse{Status: AccountDeletionStatusDeleted}
+	}
+	scheduledAt := deletion.ScheduledAt()
+	return AccountDeletionResponse{
+		Status:      AccountDeletionStatusScheduled,
+		ScheduledAt: &scheduledAt,
+	}
+}
@@ -0,0 +1,108 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/account/dto"
+	"caltrack/handler/common"
+)
+
+// AccountDeletionUsecaseInterface はAccountDeletionUsecaseのインターフェース
+type AccountDeletionUsecaseInterface interface {
+	RequestDeletion(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error)
+}
+
+// AccountHandler はアカウント（退会）に関するHTTPハンドラ
+type AccountHandler struct {
+	usecase AccountDeletionUsecaseInterface
+}
+
+// NewAccountHandler は AccountHandler のインスタンスを生成する
+func NewAccountHandler(uc AccountDeletionUsecaseInterface) *AccountHandler {
+	return &AccountHandler{usecase: uc}
+}
+
+// Delete は退会処理を行う
+// @Summary 退会
+// @Description 現在のパスワードを確認し、ユーザーと記録・PFC・アドバイスなどの全データを削除する。猶予期間が設定されている場合は削除を予約して202を返し、猶予期間中にログインすると取り消される。いずれの場合も全端末からログアウトする
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.DeleteAccountRequest true "退会リクエスト"
+// @Success 200 {object} dto.AccountDeletionResponse "削除完了"
+// @Success 202 {object} dto.AccountDeletionResponse "削除を予約"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正・パスワード誤り"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 409 {object} common.ErrorResponse "削除を予約済み"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/me [delete]
+func (h *AccountHandler) Delete(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	password, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	deletion, err := h.usecase.RequestDeletion(c.Request.Context(), userID, password)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// セッションは削除済みのため、Cookieも削除する
+	h.clearSessionCookie(c)
+
+	status := http.StatusOK
+	if deletion.PurgedAt() == nil {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, dto.NewAccountDeletionResponse(deletion))
+}
+
+// clearSessionCookie はセッションCookieを削除する
+func (h *AccountHandler) clearSessionCookie(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(common.SessionCookieName, "", -1, "/", "", true, true)
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *AccountHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidCredentials, "Current password is incorrect", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrAccountDeletionAlreadyScheduled) {
+		common.RespondError(c, http.StatusConflict, common.CodeAccountDeletionAlreadyScheduled, "Account deletion is already scheduled", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrUserNotFound) {
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+		return
+	}
+
+	// 500エラー
+	common.LogError("handleError", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", nil)
+}
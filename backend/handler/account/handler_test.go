@@ -0,0 +1,175 @@
+package account_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/account"
+	"caltrack/handler/account/dto"
+	"caltrack/handler/common"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockAccountDeletionUsecase はAccountDeletionUsecaseのモック実装
+type MockAccountDeletionUsecase struct {
+	RequestDeletionFunc func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error)
+}
+
+func (m *MockAccountDeletionUsecase) RequestDeletion(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+	if m.RequestDeletionFunc != nil {
+		return m.RequestDeletionFunc(ctx, userID, password)
+	}
+	return nil, nil
+}
+
+// performDelete は認証済みユーザーとして退会リクエストを実行する
+func performDelete(uc account.AccountDeletionUsecaseInterface, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", testUserID)
+	account.NewAccountHandler(uc).Delete(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+// assertSessionCookieCleared はセッションCookieが削除されたことを検証する
+func assertSessionCookieCleared(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == common.SessionCookieName && cookie.MaxAge < 0 {
+			return
+		}
+	}
+	t.Error("session cookie should be cleared")
+}
+
+func TestAccountHandler_Delete(t *testing.T) {
+	t.Run("正常系_即時削除の場合は200を返す", func(t *testing.T) {
+		var gotUserID vo.UserID
+		mockUC := &MockAccountDeletionUsecase{
+			RequestDeletionFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+				gotUserID = userID
+				deletion := entity.NewAccountDeletion(userID, 0)
+				_ = deletion.MarkPurged(map[string]int64{"users": 1})
+				return deletion, nil
+			},
+		}
+
+		w := performDelete(mockUC, `{"password": "password123"}`)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotUserID.String() != testUserID {
+			t.Errorf("userID = %v, want %v", gotUserID, testUserID)
+		}
+		var resp dto.AccountDeletionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Status != dto.AccountDeletionStatusDeleted {
+			t.Errorf("status = %v, want %v", resp.Status, dto.AccountDeletionStatusDeleted)
+		}
+		assertSessionCookieCleared(t, w)
+	})
+
+	t.Run("正常系_猶予期間ありの場合は202と削除予定日時を返す", func(t *testing.T) {
+		mockUC := &MockAccountDeletionUsecase{
+			RequestDeletionFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+				return entity.NewAccountDeletion(userID, 14*24*time.Hour), nil
+			},
+		}
+
+		w := performDelete(mockUC, `{"password": "password123"}`)
+
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusAccepted, w.Body.String())
+		}
+		var resp dto.AccountDeletionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Status != dto.AccountDeletionStatusScheduled || resp.ScheduledAt == nil {
+			t.Errorf("response = %+v, want scheduled with scheduledAt", resp)
+		}
+		assertSessionCookieCleared(t, w)
+	})
+
+	t.Run("異常系_パスワードが誤っている", func(t *testing.T) {
+		mockUC := &MockAccountDeletionUsecase{
+			RequestDeletionFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+				return nil, domainErrors.ErrCurrentPasswordIncorrect
+			},
+		}
+
+		w := performDelete(mockUC, `{"password": "wrongpassword"}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidCredentials)
+	})
+
+	t.Run("異常系_パスワードが形式を満たさない場合はUsecaseを呼ばない", func(t *testing.T) {
+		called := false
+		mockUC := &MockAccountDeletionUsecase{
+			RequestDeletionFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+				called = true
+				return nil, nil
+			},
+		}
+
+		w := performDelete(mockUC, `{"password": ""}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidCredentials)
+		if called {
+			t.Error("usecase should not be called")
+		}
+	})
+
+	t.Run("異常系_削除を予約済み", func(t *testing.T) {
+		mockUC := &MockAccountDeletionUsecase{
+			RequestDeletionFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+				return nil, domainErrors.ErrAccountDeletionAlreadyScheduled
+			},
+		}
+
+		w := performDelete(mockUC, `{"password": "password123"}`)
+
+		assertErrorCode(t, w, http.StatusConflict, common.CodeAccountDeletionAlreadyScheduled)
+	})
+
+	t.Run("異常系_リクエストボディが不正", func(t *testing.T) {
+		w := performDelete(&MockAccountDeletionUsecase{}, `{invalid`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidRequest)
+	})
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/usecase"
+)
+
+// StreaksResponse はストリークのレスポンスDTO
+type StreaksResponse struct {
+	LoggedDays               int `json:"loggedDays"`               // 累計記録日数
+	CurrentLoggingStreak     int `json:"currentLoggingStreak"`     // 現在の連続記録日数
+	LongestLoggingStreak     int `json:"longestLoggingStreak"`     // 最長の連続記録日数
+	CurrentAchievementStreak int `json:"currentAchievementStreak"` // 現在の連続達成日数
+	LongestAchievementStreak int `json:"longestAchievementStreak"` // 最長の連続達成日数
+}
+
+// BadgeResponse は獲得バッジのレスポンスDTO
+type BadgeResponse struct {
+	Type       string    `json:"type"`       // バッジ種別
+	UnlockedAt time.Time `json:"unlockedAt"` // 獲得日時
+}
+
+// AchievementsResponse は実績のレスポンスDTO
+type AchievementsResponse struct {
+	Streaks StreaksResponse `json:"streaks"`
+	Badges  []BadgeResponse `json:"badges"`
+}
+
+// NewAchievementsResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewAchievementsResponse(output *usecase.AchievementsOutput) AchievementsResponse {
+	badges := make([]BadgeResponse, len(output.Badges))
+	for i, badge := range output.Badges {
+		badges[i] = BadgeResponse{
+			Type:       badge.BadgeType().String(),
+			UnlockedAt: badge.UnlockedAt(),
+		}
+	}
+
+	return AchievementsResponse{
+		Streaks: StreaksResponse{
+			LoggedDays:               output.Streaks.LoggedDays,
+			CurrentLoggingStreak:     output.Streaks.CurrentLoggingStreak,
+			LongestLoggingStreak:     output.Streaks.LongestLoggingStreak,
+			CurrentAchievementStreak: output.Streaks.CurrentAchievementStreak,
+			LongestAchievementStreak: output.Streaks.LongestAchievementStreak,
+		},
+		Badges: badges,
+	}
+}
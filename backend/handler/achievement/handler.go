@@ -0,0 +1,66 @@
+package achievement
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/achievement/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+// AchievementUsecaseInterface はAchievementUsecaseのインターフェース
+type AchievementUsecaseInterface interface {
+	GetAchievements(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error)
+}
+
+// AchievementHandler は実績関連のHTTPハンドラ
+type AchievementHandler struct {
+	usecase AchievementUsecaseInterface
+}
+
+// NewAchievementHandler は AchievementHandler のインスタンスを生成する
+func NewAchievementHandler(uc AchievementUsecaseInterface) *AchievementHandler {
+	return &AchievementHandler{usecase: uc}
+}
+
+// GetAchievements はストリークと獲得バッジを取得する
+// @Summary 実績取得
+// @Description 認証ユーザーの連続記録・連続達成日数と獲得バッジを取得する
+// @Tags achievements
+// @Produce json
+// @Success 200 {object} dto.AchievementsResponse "取得成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /achievements [get]
+func (h *AchievementHandler) GetAchievements(c *gin.Context) {
+	// コンテキストからユーザーIDを取得
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	// UserID VOに変換
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	// Usecase実行
+	output, err := h.usecase.GetAchievements(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+			return
+		}
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	// 成功レスポンス
+	c.JSON(http.StatusOK, dto.NewAchievementsResponse(output))
+}
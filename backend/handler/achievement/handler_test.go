@@ -0,0 +1,161 @@
+package achievement_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/achievement"
+	"caltrack/handler/achievement/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// MockAchievementUsecase はAchievementUsecaseのモック実装
+type MockAchievementUsecase struct {
+	GetAchievementsFunc func(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error)
+}
+
+func (m *MockAchievementUsecase) GetAchievements(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error) {
+	if m.GetAchievementsFunc != nil {
+		return m.GetAchievementsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func TestAchievementHandler_GetAchievements(t *testing.T) {
+	t.Run("正常系_実績取得成功", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+		unlockedAt := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+		mockUsecase := &MockAchievementUsecase{
+			GetAchievementsFunc: func(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error) {
+				return &usecase.AchievementsOutput{
+					Streaks: entity.Streaks{
+						LoggedDays:               10,
+						CurrentLoggingStreak:     3,
+						LongestLoggingStreak:     5,
+						CurrentAchievementStreak: 2,
+						LongestAchievementStreak: 4,
+					},
+					Badges: []*entity.UserBadge{
+						entity.NewUserBadge(userID, vo.ReconstructBadgeType(vo.BadgeTypeFirstRecord), unlockedAt),
+					},
+				}, nil
+			},
+		}
+
+		handler := achievement.NewAchievementHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/achievements", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetAchievements(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var resp dto.AchievementsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Streaks.LoggedDays != 10 {
+			t.Errorf("loggedDays = %d, want 10", resp.Streaks.LoggedDays)
+		}
+		if resp.Streaks.LongestAchievementStreak != 4 {
+			t.Errorf("longestAchievementStreak = %d, want 4", resp.Streaks.LongestAchievementStreak)
+		}
+		if len(resp.Badges) != 1 {
+			t.Fatalf("len(badges) = %d, want 1", len(resp.Badges))
+		}
+		if resp.Badges[0].Type != vo.BadgeTypeFirstRecord {
+			t.Errorf("badge type = %s, want %s", resp.Badges[0].Type, vo.BadgeTypeFirstRecord)
+		}
+		if !resp.Badges[0].UnlockedAt.Equal(unlockedAt) {
+			t.Errorf("unlockedAt = %v, want %v", resp.Badges[0].UnlockedAt, unlockedAt)
+		}
+	})
+
+	t.Run("異常系_未認証（userIDがない）", func(t *testing.T) {
+		mockUsecase := &MockAchievementUsecase{}
+		handler := achievement.NewAchievementHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/achievements", nil)
+
+		handler.GetAchievements(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Code != common.CodeUnauthorized {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeUnauthorized)
+		}
+	})
+
+	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
+		mockUsecase := &MockAchievementUsecase{
+			GetAchievementsFunc: func(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error) {
+				return nil, domainErrors.ErrUserNotFound
+			},
+		}
+
+		handler := achievement.NewAchievementHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/achievements", nil)
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+
+		handler.GetAchievements(c)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("異常系_Usecaseエラー", func(t *testing.T) {
+		mockUsecase := &MockAchievementUsecase{
+			GetAchievementsFunc: func(ctx context.Context, userID vo.UserID) (*usecase.AchievementsOutput, error) {
+				return nil, errors.New("database connection error")
+			},
+		}
+
+		handler := achievement.NewAchievementHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/achievements", nil)
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+
+		handler.GetAchievements(c)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
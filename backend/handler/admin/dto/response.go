@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/repository"
+	"caltrack/usecase"
+)
+
+// AdminUserResponse は管理者向けのユーザー情報のレスポンスDTO
+type AdminUserResponse struct {
+	ID            string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email         string     `json:"email" example:"user@example.com"`
+	Nickname      string     `json:"nickname" example:"たろう"`
+	Role          string     `json:"role" example:"user"`
+	EmailVerified bool       `json:"emailVerified" example:"true"`
+	DisabledAt    *time.Time `json:"disabledAt,omitempty"` // 停止中のアカウントのみ
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// NewAdminUserResponse はユーザーからレスポンスDTOを生成する
+func NewAdminUserResponse(user *entity.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:            user.ID().String(),
+		Email:         user.Email().String(),
+		Nickname:      user.Nickname().String(),
+		Role:          user.Role().String(),
+		EmailVerified: user.IsEmailVerified(),
+		DisabledAt:    user.DisabledAt(),
+		CreatedAt:     user.CreatedAt(),
+	}
+}
+
+// AdminUsersResponse はユーザー検索結果のレスポンスDTO
+type AdminUsersResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	Total int64               `json:"total" example:"42"` // 条件に一致するユーザーの総数
+}
+
+// NewAdminUsersResponse はユーザー検索の出力からレスポンスDTOを生成する
+func NewAdminUsersResponse(output *usecase.AdminUserSearchOutput) AdminUsersResponse {
+	users := make([]AdminUserResponse, len(output.Users))
+	for i, user := range output.Users {
+		users[i] = NewAdminUserResponse(user)
+	}
+	return AdminUsersResponse{Users: users, Total: output.Total}
+}
+
+// AIUsageResponse はAI機能ごとのトークン使用量のレスポンスDTO
+type AIUsageResponse struct {
+	Feature      string `json:"feature" example:"image_analysis"`
+	Requests     int64  `json:"requests" example:"12"`
+	PromptTokens int64  `json:"promptTokens" example:"15000"`
+	OutputTokens int64  `json:"outputTokens" example:"3000"`
+	TotalTokens  int64  `json:"totalTokens" example:"18000"`
+}
+
+// AdminUserUsageResponse はユーザーの利用状況のレスポンスDTO
+type AdminUserUsageResponse struct {
+	User           AdminUserResponse `json:"user"`
+	RecordCount    int64             `json:"recordCount" example:"128"`
+	ActiveSessions int               `json:"activeSessions" example:"2"`
+	LastSeenAt     *time.Time        `json:"lastSeenAt,omitempty"` // 有効なセッションがない場合は省略
+	AIUsageSince   time.Time         `json:"aiUsageSince"`
+	AIUsage        []AIUsageResponse `json:"aiUsage"`
+}
+
+// NewAdminUserUsageResponse は利用状況の出力からレスポンスDTOを生成する
+func NewAdminUserUsageResponse(output *usecase.AdminUserUsageOutput) AdminUserUsageResponse {
+	return AdminUserUsageResponse{
+		User:           NewAdminUserResponse(output.User),
+		RecordCount:    output.RecordCount,
+		ActiveSessions: output.ActiveSessions,
+		LastSeenAt:     output.LastSeenAt,
+		AIUsageSince:   output.AIUsageSince,
+		AIUsage:        newAIUsageResponses(output.AIUsage),
+	}
+}
+
+func newAIUsageResponses(summaries []repository.AIUsageSummary) []AIUsageResponse {
+	items := make([]AIUsageResponse, len(summaries))
+	for i, s := range summaries {
+		items[i] = AIUsageResponse{
+			Feature:      string(s.Feature),
+			Requests:     s.Requests,
+			PromptTokens: s.PromptTokens,
+			OutputTokens: s.OutputTokens,
+			TotalTokens:  s.TotalTokens,
+		}
+	}
+	return items
+}
@@ -0,0 +1,260 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/admin/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+// AdminUsecaseInterface はAdminUsecaseのインターフェース
+type AdminUsecaseInterface interface {
+	SearchUsers(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*usecase.AdminUserSearchOutput, error)
+	GetUserUsage(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error)
+	DisableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error)
+	EnableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error)
+	ForceLogout(ctx context.Context, actorID, userID vo.UserID) error
+}
+
+// AdminHandler は管理者によるユーザー管理に関するHTTPハンドラ
+// ルートには認証ミドルウェアと RequireAdmin を設定する前提
+type AdminHandler struct {
+	usecase AdminUsecaseInterface
+}
+
+// NewAdminHandler は AdminHandler のインスタンスを生成する
+func NewAdminHandler(uc AdminUsecaseInterface) *AdminHandler {
+	return &AdminHandler{usecase: uc}
+}
+
+// SearchUsers はユーザーを検索する
+// @Summary ユーザー検索（管理者）
+// @Description メールアドレスかニックネームに q を含むユーザーを登録日時の新しい順に取得する。q を省略した場合は全ユーザーが対象
+// @Tags admin
+// @Produce json
+// @Param q query string false "検索文字列"
+// @Param limit query int false "取得件数（既定20、最大100）"
+// @Param offset query int false "取得開始位置"
+// @Success 200 {object} dto.AdminUsersResponse "検索成功"
+// @Failure 400 {object} common.ErrorResponse "クエリパラメータ不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "管理者権限がない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /admin/users [get]
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := queryInt(c, "limit", 1)
+	if !ok {
+		return
+	}
+	offset, ok := queryInt(c, "offset", 0)
+	if !ok {
+		return
+	}
+
+	output, err := h.usecase.SearchUsers(c.Request.Context(), actorID, c.Query("q"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAdminUsersResponse(output))
+}
+
+// GetUserUsage はユーザーの利用状況を取得する
+// @Summary ユーザーの利用状況取得（管理者）
+// @Description 記録の件数、有効なセッション数と最終利用日時、直近 days 日間のAI機能ごとのトークン使用量を取得する
+// @Tags admin
+// @Produce json
+// @Param id path string true "ユーザーID"
+// @Param days query int false "AI使用量の集計日数（既定30、最大365）"
+// @Success 200 {object} dto.AdminUserUsageResponse "取得成功"
+// @Failure 400 {object} common.ErrorResponse "クエリパラメータ不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "管理者権限がない"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /admin/users/{id}/usage [get]
+func (h *AdminHandler) GetUserUsage(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.targetUserID(c)
+	if !ok {
+		return
+	}
+
+	days, ok := queryInt(c, "days", 1)
+	if !ok {
+		return
+	}
+
+	output, err := h.usecase.GetUserUsage(c.Request.Context(), actorID, userID, days)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAdminUserUsageResponse(output))
+}
+
+// DisableUser はユーザーのアカウントを停止する
+// @Summary アカウント停止（管理者）
+// @Description アカウントを停止して全端末からログアウトさせる。停止中はログインとパーソナルアクセストークンの利用ができない
+// @Tags admin
+// @Produce json
+// @Param id path string true "ユーザーID"
+// @Success 200 {object} dto.AdminUserResponse "停止成功"
+// @Failure 400 {object} common.ErrorResponse "自分自身は停止できない"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "管理者権限がない"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 409 {object} common.ErrorResponse "既に停止中"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /admin/users/{id}/disable [post]
+func (h *AdminHandler) DisableUser(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.targetUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.usecase.DisableUser(c.Request.Context(), actorID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAdminUserResponse(user))
+}
+
+// EnableUser は停止したユーザーのアカウントを再開する
+// @Summary アカウント再開（管理者）
+// @Description 停止したアカウントを再開し、再びログインできるようにする
+// @Tags admin
+// @Produce json
+// @Param id path string true "ユーザーID"
+// @Success 200 {object} dto.AdminUserResponse "再開成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "管理者権限がない"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 409 {object} common.ErrorResponse "停止中ではない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /admin/users/{id}/enable [post]
+func (h *AdminHandler) EnableUser(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.targetUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.usecase.EnableUser(c.Request.Context(), actorID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAdminUserResponse(user))
+}
+
+// ForceLogout はユーザーを全端末から強制的にログアウトさせる
+// @Summary 強制ログアウト（管理者）
+// @Description ユーザーの全てのセッションを削除する。パーソナルアクセストークンは削除しない
+// @Tags admin
+// @Param id path string true "ユーザーID"
+// @Success 204 "ログアウト成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "管理者権限がない"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /admin/users/{id}/sessions [delete]
+func (h *AdminHandler) ForceLogout(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.targetUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ForceLogout(c.Request.Context(), actorID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// currentUserID はAuthMiddlewareが設定したユーザーIDを取得する
+// 取得できない場合は401を返してfalseを返す
+func currentUserID(c *gin.Context) (vo.UserID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return vo.UserID{}, false
+	}
+	return vo.ReconstructUserID(userIDStr.(string)), true
+}
+
+// targetUserID はパスの操作対象ユーザーIDを取得する
+// 形式が不正なIDは存在しないユーザーとして扱う
+func (h *AdminHandler) targetUserID(c *gin.Context) (vo.UserID, bool) {
+	userID, err := vo.ParseUserID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, domainErrors.ErrUserNotFound)
+		return vo.UserID{}, false
+	}
+	return userID, true
+}
+
+// queryInt は整数のクエリパラメータを取得する
+// 省略された場合は0を返す。min未満や整数でない場合は400を返してfalseを返す
+func queryInt(c *gin.Context, name string, min int) (int, bool) {
+	s := c.Query(name)
+	if s == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, name+" must be an integer greater than or equal to "+strconv.Itoa(min), nil)
+		return 0, false
+	}
+	return n, true
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *AdminHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrUserNotFound):
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+	case errors.Is(err, domainErrors.ErrCannotDisableSelf):
+		common.RespondError(c, http.StatusBadRequest, common.CodeCannotDisableSelf, "Cannot disable your own account", nil)
+	case errors.Is(err, domainErrors.ErrAccountAlreadyDisabled):
+		common.RespondError(c, http.StatusConflict, common.CodeAccountAlreadyDisabled, "Account is already disabled", nil)
+	case errors.Is(err, domainErrors.ErrAccountNotDisabled):
+		common.RespondError(c, http.StatusConflict, common.CodeAccountNotDisabled, "Account is not disabled", nil)
+	default:
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+	}
+}
@@ -0,0 +1,371 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/handler/admin"
+	"caltrack/handler/admin/dto"
+	"caltrack/handler/common"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const (
+	testAdminID  = "550e8400-e29b-41d4-a716-446655440000"
+	testTargetID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+)
+
+// MockAdminUsecase はAdminUsecaseのモック実装
+type MockAdminUsecase struct {
+	SearchUsersFunc  func(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*usecase.AdminUserSearchOutput, error)
+	GetUserUsageFunc func(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error)
+	DisableUserFunc  func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error)
+	EnableUserFunc   func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error)
+	ForceLogoutFunc  func(ctx context.Context, actorID, userID vo.UserID) error
+}
+
+func (m *MockAdminUsecase) SearchUsers(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*usecase.AdminUserSearchOutput, error) {
+	if m.SearchUsersFunc != nil {
+		return m.SearchUsersFunc(ctx, actorID, query, limit, offset)
+	}
+	return &usecase.AdminUserSearchOutput{}, nil
+}
+
+func (m *MockAdminUsecase) GetUserUsage(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error) {
+	if m.GetUserUsageFunc != nil {
+		return m.GetUserUsageFunc(ctx, actorID, userID, days)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminUsecase) DisableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+	if m.DisableUserFunc != nil {
+		return m.DisableUserFunc(ctx, actorID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminUsecase) EnableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+	if m.EnableUserFunc != nil {
+		return m.EnableUserFunc(ctx, actorID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminUsecase) ForceLogout(ctx context.Context, actorID, userID vo.UserID) error {
+	if m.ForceLogoutFunc != nil {
+		return m.ForceLogoutFunc(ctx, actorID, userID)
+	}
+	return nil
+}
+
+// testUser はテスト用の操作対象ユーザーを生成する
+func testUser(t *testing.T, disabledAt *time.Time) *entity.User {
+	t.Helper()
+	now := time.Now()
+	user, err := entity.ReconstructUser(
+		testTargetID, "target@example.com", "hashed", "target",
+		70.0, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate", "daily",
+		vo.DefaultPrivacySettings(), vo.UserRoleUser, disabledAt, &now, now, now,
+	)
+	if err != nil {
+		t.Fatalf("failed to reconstruct user: %v", err)
+	}
+	return user
+}
+
+// perform は管理者としてリクエストを処理して結果を返す
+func perform(handle gin.HandlerFunc, method, path, id string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, nil)
+	if id != "" {
+		c.Params = gin.Params{{Key: "id", Value: id}}
+	}
+	c.Set("userID", testAdminID)
+	handle(c)
+	// c.Status のみの場合はボディを書き込むまでレコーダーに反映されない
+	c.Writer.WriteHeaderNow()
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+func TestAdminHandler_SearchUsers(t *testing.T) {
+	t.Run("正常系_検索条件を渡して結果を返す", func(t *testing.T) {
+		var gotQuery string
+		var gotLimit, gotOffset int
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			SearchUsersFunc: func(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*usecase.AdminUserSearchOutput, error) {
+				if actorID.String() != testAdminID {
+					t.Errorf("actorID = %s, want %s", actorID, testAdminID)
+				}
+				gotQuery, gotLimit, gotOffset = query, limit, offset
+				return &usecase.AdminUserSearchOutput{Users: []*entity.User{testUser(t, nil)}, Total: 21}, nil
+			},
+		})
+
+		w := perform(h.SearchUsers, http.MethodGet, "/admin/users?q=target&limit=1&offset=20", "")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotQuery != "target" || gotLimit != 1 || gotOffset != 20 {
+			t.Errorf("query, limit, offset = %q, %d, %d, want %q, 1, 20", gotQuery, gotLimit, gotOffset, "target")
+		}
+		var resp dto.AdminUsersResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Total != 21 || len(resp.Users) != 1 {
+			t.Fatalf("total, users = %d, %d, want 21, 1", resp.Total, len(resp.Users))
+		}
+		if resp.Users[0].ID != testTargetID || resp.Users[0].Role != vo.UserRoleUser || resp.Users[0].DisabledAt != nil {
+			t.Errorf("user = %+v", resp.Users[0])
+		}
+	})
+
+	t.Run("異常系_limitが整数でない場合は400", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			SearchUsersFunc: func(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*usecase.AdminUserSearchOutput, error) {
+				t.Error("SearchUsers should not be called")
+				return nil, nil
+			},
+		})
+
+		w := perform(h.SearchUsers, http.MethodGet, "/admin/users?limit=abc", "")
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidRequest)
+	})
+
+	t.Run("異常系_offsetが負の場合は400", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{})
+
+		w := perform(h.SearchUsers, http.MethodGet, "/admin/users?offset=-1", "")
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidRequest)
+	})
+}
+
+func TestAdminHandler_GetUserUsage(t *testing.T) {
+	t.Run("正常系_利用状況とAI使用量を返す", func(t *testing.T) {
+		lastSeenAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		var gotDays int
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			GetUserUsageFunc: func(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error) {
+				if userID.String() != testTargetID {
+					t.Errorf("userID = %s, want %s", userID, testTargetID)
+				}
+				gotDays = days
+				return &usecase.AdminUserUsageOutput{
+					User:           testUser(t, nil),
+					RecordCount:    128,
+					ActiveSessions: 2,
+					LastSeenAt:     &lastSeenAt,
+					AIUsageSince:   time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC),
+					AIUsage: []repository.AIUsageSummary{
+						{Feature: entity.AIFeatureImageAnalysis, Requests: 3, PromptTokens: 300, OutputTokens: 60, TotalTokens: 360},
+					},
+				}, nil
+			},
+		})
+
+		w := perform(h.GetUserUsage, http.MethodGet, "/admin/users/"+testTargetID+"/usage?days=7", testTargetID)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotDays != 7 {
+			t.Errorf("days = %d, want 7", gotDays)
+		}
+		var resp dto.AdminUserUsageResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.RecordCount != 128 || resp.ActiveSessions != 2 {
+			t.Errorf("recordCount, activeSessions = %d, %d, want 128, 2", resp.RecordCount, resp.ActiveSessions)
+		}
+		if resp.LastSeenAt == nil || !resp.LastSeenAt.Equal(lastSeenAt) {
+			t.Errorf("lastSeenAt = %v, want %v", resp.LastSeenAt, lastSeenAt)
+		}
+		if len(resp.AIUsage) != 1 || resp.AIUsage[0].Feature != string(entity.AIFeatureImageAnalysis) || resp.AIUsage[0].TotalTokens != 360 {
+			t.Errorf("aiUsage = %+v", resp.AIUsage)
+		}
+	})
+
+	t.Run("異常系_IDの形式が不正な場合は404", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			GetUserUsageFunc: func(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error) {
+				t.Error("GetUserUsage should not be called")
+				return nil, nil
+			},
+		})
+
+		w := perform(h.GetUserUsage, http.MethodGet, "/admin/users/invalid/usage", "invalid")
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+
+	t.Run("異常系_ユーザーが存在しない場合は404", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			GetUserUsageFunc: func(ctx context.Context, actorID, userID vo.UserID, days int) (*usecase.AdminUserUsageOutput, error) {
+				return nil, domainErrors.ErrUserNotFound
+			},
+		})
+
+		w := perform(h.GetUserUsage, http.MethodGet, "/admin/users/"+testTargetID+"/usage", testTargetID)
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+}
+
+func TestAdminHandler_DisableUser(t *testing.T) {
+	t.Run("正常系_停止したユーザーを返す", func(t *testing.T) {
+		disabledAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			DisableUserFunc: func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+				if actorID.String() != testAdminID || userID.String() != testTargetID {
+					t.Errorf("actorID, userID = %s, %s", actorID, userID)
+				}
+				return testUser(t, &disabledAt), nil
+			},
+		})
+
+		w := perform(h.DisableUser, http.MethodPost, "/admin/users/"+testTargetID+"/disable", testTargetID)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		var resp dto.AdminUserResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.DisabledAt == nil || !resp.DisabledAt.Equal(disabledAt) {
+			t.Errorf("disabledAt = %v, want %v", resp.DisabledAt, disabledAt)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"異常系_自分自身は停止できない", domainErrors.ErrCannotDisableSelf, http.StatusBadRequest, common.CodeCannotDisableSelf},
+		{"異常系_既に停止中", domainErrors.ErrAccountAlreadyDisabled, http.StatusConflict, common.CodeAccountAlreadyDisabled},
+		{"異常系_ユーザーが存在しない", domainErrors.ErrUserNotFound, http.StatusNotFound, common.CodeNotFound},
+		{"異常系_予期しないエラー", errors.New("db error"), http.StatusInternalServerError, common.CodeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := admin.NewAdminHandler(&MockAdminUsecase{
+				DisableUserFunc: func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+					return nil, tt.err
+				},
+			})
+
+			w := perform(h.DisableUser, http.MethodPost, "/admin/users/"+testTargetID+"/disable", testTargetID)
+
+			assertErrorCode(t, w, tt.wantStatus, tt.wantCode)
+		})
+	}
+}
+
+func TestAdminHandler_EnableUser(t *testing.T) {
+	t.Run("正常系_再開したユーザーを返す", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			EnableUserFunc: func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+				return testUser(t, nil), nil
+			},
+		})
+
+		w := perform(h.EnableUser, http.MethodPost, "/admin/users/"+testTargetID+"/enable", testTargetID)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		var resp dto.AdminUserResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.DisabledAt != nil {
+			t.Errorf("disabledAt = %v, want nil", resp.DisabledAt)
+		}
+	})
+
+	t.Run("異常系_停止中ではない場合は409", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			EnableUserFunc: func(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+				return nil, domainErrors.ErrAccountNotDisabled
+			},
+		})
+
+		w := perform(h.EnableUser, http.MethodPost, "/admin/users/"+testTargetID+"/enable", testTargetID)
+
+		assertErrorCode(t, w, http.StatusConflict, common.CodeAccountNotDisabled)
+	})
+}
+
+func TestAdminHandler_ForceLogout(t *testing.T) {
+	t.Run("正常系_204を返す", func(t *testing.T) {
+		called := false
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			ForceLogoutFunc: func(ctx context.Context, actorID, userID vo.UserID) error {
+				called = true
+				if userID.String() != testTargetID {
+					t.Errorf("userID = %s, want %s", userID, testTargetID)
+				}
+				return nil
+			},
+		})
+
+		w := perform(h.ForceLogout, http.MethodDelete, "/admin/users/"+testTargetID+"/sessions", testTargetID)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if !called {
+			t.Error("ForceLogout was not called")
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない場合は404", func(t *testing.T) {
+		h := admin.NewAdminHandler(&MockAdminUsecase{
+			ForceLogoutFunc: func(ctx context.Context, actorID, userID vo.UserID) error {
+				return domainErrors.ErrUserNotFound
+			},
+		})
+
+		w := perform(h.ForceLogout, http.MethodDelete, "/admin/users/"+testTargetID+"/sessions", testTargetID)
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+}
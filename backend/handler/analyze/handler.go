@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
 	"caltrack/handler/analyze/dto"
 	"caltrack/handler/common"
 	"caltrack/usecase"
@@ -15,7 +16,7 @@ import (
 
 // AnalyzeUsecaseInterface はAnalyzeUsecaseのインターフェース（テスタビリティ向上のため）
 type AnalyzeUsecaseInterface interface {
-	AnalyzeImage(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error)
+	AnalyzeImage(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error)
 }
 
 // AnalyzeHandler は画像解析関連のHTTPハンドラ
@@ -38,16 +39,20 @@ func NewAnalyzeHandler(uc AnalyzeUsecaseInterface) *AnalyzeHandler {
 // @Success 200 {object} dto.AnalyzeImageResponse "解析成功"
 // @Failure 400 {object} common.ErrorResponse "リクエスト不正"
 // @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "プライバシー設定でAI機能がオフ"
 // @Failure 500 {object} common.ErrorResponse "サーバーエラー"
 // @Router /analyze-image [post]
 func (h *AnalyzeHandler) AnalyzeImage(c *gin.Context) {
 	// コンテキストからユーザーIDを取得（認証済みユーザーのみ使用可能）
-	_, exists := c.Get("userID")
+	userIDStr, exists := c.Get("userID")
 	if !exists {
 		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
 		return
 	}
 
+	// UserID VOに変換
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
 	// リクエストボディのバインド
 	var req dto.AnalyzeImageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -56,7 +61,7 @@ func (h *AnalyzeHandler) AnalyzeImage(c *gin.Context) {
 	}
 
 	// Usecase実行
-	output, err := h.usecase.AnalyzeImage(c.Request.Context(), req.ImageData, req.MimeType)
+	output, err := h.usecase.AnalyzeImage(c.Request.Context(), userID, req.ImageData, req.MimeType)
 	if err != nil {
 		// 入力バリデーションエラー
 		if errors.Is(err, domainErrors.ErrImageDataRequired) {
@@ -67,6 +72,15 @@ func (h *AnalyzeHandler) AnalyzeImage(c *gin.Context) {
 			common.RespondValidationError(c, []string{err.Error()})
 			return
 		}
+		// プライバシー設定でAI機能をオフにしている場合
+		if errors.Is(err, domainErrors.ErrAIProcessingDisabled) {
+			common.RespondError(c, http.StatusForbidden, common.CodeAIProcessingDisabled, "AI processing is disabled in your privacy settings", nil)
+			return
+		}
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+			return
+		}
 		// 食品が検出されなかった場合
 		if errors.Is(err, domainErrors.ErrNoFoodDetected) {
 			common.RespondError(c, http.StatusBadRequest, common.CodeNoFoodDetected, err.Error(), nil)
@@ -21,11 +21,11 @@ import (
 
 // MockAnalyzeUsecase はAnalyzeUsecaseのモック
 type MockAnalyzeUsecase struct {
-	AnalyzeImageFunc func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error)
+	AnalyzeImageFunc func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error)
 }
 
-func (m *MockAnalyzeUsecase) AnalyzeImage(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
-	return m.AnalyzeImageFunc(ctx, imageData, mimeType)
+func (m *MockAnalyzeUsecase) AnalyzeImage(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+	return m.AnalyzeImageFunc(ctx, userID, imageData, mimeType)
 }
 
 func setupTestRouter(handler *analyze.AnalyzeHandler) *gin.Engine {
@@ -34,7 +34,7 @@ func setupTestRouter(handler *analyze.AnalyzeHandler) *gin.Engine {
 
 	// 認証済みユーザーのミドルウェアを設定
 	r.Use(func(c *gin.Context) {
-		c.Set("userID", uint(1))
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
 		c.Next()
 	})
 
@@ -57,7 +57,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					name, _ := vo.NewItemName("唐揚げ")
 					calories, _ := vo.NewCalories(250)
 					return &usecase.AnalyzeOutput{
@@ -84,7 +84,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					name1, _ := vo.NewItemName("ご飯")
 					calories1, _ := vo.NewCalories(250)
 					name2, _ := vo.NewItemName("味噌汁")
@@ -125,7 +125,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					return nil, domainErrors.ErrImageDataRequired
 				}
 			},
@@ -141,7 +141,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					return nil, domainErrors.ErrMimeTypeRequired
 				}
 			},
@@ -157,7 +157,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					return nil, domainErrors.ErrNoFoodDetected
 				}
 			},
@@ -166,6 +166,22 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				assert.Contains(t, rec.Body.String(), domainErrors.ErrNoFoodDetected.Error())
 			},
 		},
+		{
+			name: "異常系_AI機能をオフにしている",
+			requestBody: dto.AnalyzeImageRequest{
+				ImageData: "base64encodedimage",
+				MimeType:  "image/jpeg",
+			},
+			setupMock: func(m *MockAnalyzeUsecase) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+					return nil, domainErrors.ErrAIProcessingDisabled
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Body.String(), "AI_PROCESSING_DISABLED")
+			},
+		},
 		{
 			name: "異常系_画像解析に失敗",
 			requestBody: dto.AnalyzeImageRequest{
@@ -173,7 +189,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					return nil, domainErrors.ErrImageAnalysisFailed
 				}
 			},
@@ -189,7 +205,7 @@ func TestAnalyzeHandler_AnalyzeImage(t *testing.T) {
 				MimeType:  "image/jpeg",
 			},
 			setupMock: func(m *MockAnalyzeUsecase) {
-				m.AnalyzeImageFunc = func(ctx context.Context, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
+				m.AnalyzeImageFunc = func(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*usecase.AnalyzeOutput, error) {
 					return nil, errors.New("unexpected error")
 				}
 			},
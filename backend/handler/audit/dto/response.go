@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+// AuditEventResponse は監査イベント1件のレスポンスDTO
+type AuditEventResponse struct {
+	ID         string         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action     string         `json:"action" example:"auth.login"`
+	ActorID    *string        `json:"actorId,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // 操作したユーザー。バッチ処理の場合は省略
+	TargetType string         `json:"targetType,omitempty" example:"session"`
+	TargetID   string         `json:"targetId,omitempty" example:"a1b2c3d4"`
+	IPAddress  string         `json:"ipAddress,omitempty" example:"192.0.2.1"`
+	UserAgent  string         `json:"userAgent,omitempty" example:"Mozilla/5.0"`
+	Diff       map[string]any `json:"diff,omitempty"`
+	OccurredAt time.Time      `json:"occurredAt"`
+}
+
+// NewAuditEventResponse は監査イベントからレスポンスDTOを生成する
+func NewAuditEventResponse(event *entity.AuditEvent) AuditEventResponse {
+	var actorID *string
+	if event.ActorID() != nil {
+		id := event.ActorID().String()
+		actorID = &id
+	}
+	return AuditEventResponse{
+		ID:         event.ID().String(),
+		Action:     string(event.Action()),
+		ActorID:    actorID,
+		TargetType: event.TargetType(),
+		TargetID:   event.TargetID(),
+		IPAddress:  event.IPAddress(),
+		UserAgent:  event.UserAgent(),
+		Diff:       event.Diff(),
+		OccurredAt: event.OccurredAt(),
+	}
+}
+
+// AuditEventsResponse は監査イベント一覧のレスポンスDTO
+type AuditEventsResponse struct {
+	Events []AuditEventResponse `json:"events"`
+}
+
+// NewAuditEventsResponse は監査イベント一覧からレスポンスDTOを生成する
+func NewAuditEventsResponse(events []*entity.AuditEvent) AuditEventsResponse {
+	items := make([]AuditEventResponse, len(events))
+	for i, event := range events {
+		items[i] = NewAuditEventResponse(event)
+	}
+	return AuditEventsResponse{Events: items}
+}
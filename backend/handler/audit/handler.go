@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/handler/audit/dto"
+	"caltrack/handler/common"
+)
+
+// AuditUsecaseInterface はAuditUsecaseのインターフェース
+type AuditUsecaseInterface interface {
+	ListEvents(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error)
+}
+
+// AuditHandler は監査ログに関するHTTPハンドラ
+type AuditHandler struct {
+	usecase AuditUsecaseInterface
+}
+
+// NewAuditHandler は AuditHandler のインスタンスを生成する
+func NewAuditHandler(uc AuditUsecaseInterface) *AuditHandler {
+	return &AuditHandler{usecase: uc}
+}
+
+// List は認証ユーザーの監査ログを取得する
+// @Summary 監査ログ取得
+// @Description ログイン・プロフィール変更・記録の作成・エクスポートなど、自分のアカウントに対する操作履歴を新しい順に取得する。続きは最後のイベントの occurredAt を before に指定して取得する
+// @Tags users
+// @Produce json
+// @Param limit query int false "取得件数（既定50、最大200）"
+// @Param before query string false "この日時より前のイベントを取得する（RFC3339）"
+// @Success 200 {object} dto.AuditEventsResponse "取得成功"
+// @Failure 400 {object} common.ErrorResponse "クエリパラメータ不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/me/audit [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	limit := 0
+	if s := c.Query("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "limit must be a positive integer", nil)
+			return
+		}
+		limit = n
+	}
+
+	var before *time.Time
+	if s := c.Query("before"); s != "" {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "before must be an RFC3339 timestamp", nil)
+			return
+		}
+		before = &t
+	}
+
+	events, err := h.usecase.ListEvents(c.Request.Context(), vo.ReconstructUserID(userIDStr.(string)), before, limit)
+	if err != nil {
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAuditEventsResponse(events))
+}
@@ -0,0 +1,125 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/handler/audit"
+	"caltrack/handler/audit/dto"
+	"caltrack/handler/common"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockAuditUsecase はAuditUsecaseのモック実装
+type MockAuditUsecase struct {
+	ListEventsFunc func(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error)
+}
+
+func (m *MockAuditUsecase) ListEvents(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+	if m.ListEventsFunc != nil {
+		return m.ListEventsFunc(ctx, userID, before, limit)
+	}
+	return nil, nil
+}
+
+// performList は認証済みユーザーとして監査ログ取得のリクエストを処理して結果を返す
+func performList(h *audit.AuditHandler, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, path, nil)
+	c.Set("userID", testUserID)
+	h.List(c)
+	return w
+}
+
+func TestAuditHandler_List(t *testing.T) {
+	t.Run("正常系_監査イベントの一覧を返す", func(t *testing.T) {
+		userID := vo.ReconstructUserID(testUserID)
+		event := entity.NewUserAuditEvent(entity.AuditActionLogin, userID, entity.AuditTargetSession, "abcd", map[string]any{"method": "password"})
+		event.AttachClient(entity.SessionClient{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"})
+
+		var gotLimit int
+		var gotBefore *time.Time
+		h := audit.NewAuditHandler(&MockAuditUsecase{
+			ListEventsFunc: func(ctx context.Context, uid vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+				if !uid.Equals(userID) {
+					t.Errorf("userID = %s, want %s", uid, userID)
+				}
+				gotLimit = limit
+				gotBefore = before
+				return []*entity.AuditEvent{event}, nil
+			},
+		})
+
+		w := performList(h, "/users/me/audit?limit=10&before=2026-01-02T03:04:05Z")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotLimit != 10 {
+			t.Errorf("limit = %d, want 10", gotLimit)
+		}
+		if gotBefore == nil || !gotBefore.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Errorf("before = %v, want 2026-01-02T03:04:05Z", gotBefore)
+		}
+		var resp dto.AuditEventsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Events) != 1 {
+			t.Fatalf("len(events) = %d, want 1", len(resp.Events))
+		}
+		got := resp.Events[0]
+		if got.Action != "auth.login" || got.TargetType != "session" || got.IPAddress != "192.0.2.1" || got.Diff["method"] != "password" {
+			t.Errorf("event = %+v", got)
+		}
+		if got.ActorID == nil || *got.ActorID != testUserID {
+			t.Errorf("actorId = %v, want %s", got.ActorID, testUserID)
+		}
+	})
+
+	t.Run("異常系_クエリパラメータが不正な場合は400", func(t *testing.T) {
+		for _, path := range []string{"/users/me/audit?limit=0", "/users/me/audit?limit=abc", "/users/me/audit?before=yesterday"} {
+			h := audit.NewAuditHandler(&MockAuditUsecase{})
+			w := performList(h, path)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+			}
+		}
+	})
+
+	t.Run("異常系_取得に失敗した場合は500", func(t *testing.T) {
+		h := audit.NewAuditHandler(&MockAuditUsecase{
+			ListEventsFunc: func(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+				return nil, errors.New("db error")
+			},
+		})
+
+		w := performList(h, "/users/me/audit")
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeInternalError {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeInternalError)
+		}
+	})
+}
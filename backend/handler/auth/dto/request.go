@@ -1,6 +1,9 @@
 package dto
 
-import "caltrack/domain/vo"
+import (
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
 
 // LoginRequest はログインリクエストDTO
 type LoginRequest struct {
@@ -22,3 +25,19 @@ func (r LoginRequest) ToDomain() (vo.Email, vo.Password, error) {
 
 	return email, password, nil
 }
+
+// LoginTOTPRequest は2段階認証ログインのリクエストDTO
+type LoginTOTPRequest struct {
+	Token string `json:"token" example:"q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // ログインで受け取ったトークン
+	Code  string `json:"code" example:"123456"`                                       // 認証アプリのコードまたはリカバリーコード
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// トークンの形式が不正な場合は ErrTwoFactorTokenInvalid を返す
+func (r LoginTOTPRequest) ToDomain() (vo.OneTimeToken, error) {
+	token, err := vo.ParseOneTimeToken(r.Token)
+	if err != nil {
+		return vo.OneTimeToken{}, domainErrors.ErrTwoFactorTokenInvalid
+	}
+	return token, nil
+}
@@ -1,6 +1,12 @@
 package dto
 
-import "caltrack/usecase"
+import (
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/usecase"
+)
 
 // LoginResponse はログインレスポンスDTO
 type LoginResponse struct {
@@ -17,3 +23,74 @@ func NewLoginResponse(output *usecase.LoginOutput) LoginResponse {
 		Nickname: output.User.Nickname().String(),
 	}
 }
+
+// TokenLoginResponse はセッショントークンをボディで返すログインレスポンスDTO
+type TokenLoginResponse struct {
+	UserID    string    `json:"userId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email     string    `json:"email" example:"user@example.com"`
+	Nickname  string    `json:"nickname" example:"John"`
+	Token     string    `json:"token" example:"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="` // Authorization: Bearer ヘッダーで送るセッショントークン
+	TokenType string    `json:"tokenType" example:"Bearer"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewTokenLoginResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewTokenLoginResponse(output *usecase.LoginOutput) TokenLoginResponse {
+	return TokenLoginResponse{
+		UserID:    output.User.ID().String(),
+		Email:     output.User.Email().String(),
+		Nickname:  output.User.Nickname().String(),
+		Token:     output.Session.ID().String(),
+		TokenType: "Bearer",
+		ExpiresAt: output.Session.ExpiresAt().Time(),
+	}
+}
+
+// TwoFactorChallengeResponse は2段階認証のコード入力が必要な場合のログインレスポンスDTO
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"twoFactorRequired" example:"true"`
+	TwoFactorToken    string    `json:"twoFactorToken" example:"q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // /auth/login/totp（トークンログインの場合は /auth/token/totp）に渡すトークン
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+// NewTwoFactorChallengeResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewTwoFactorChallengeResponse(output *usecase.LoginOutput) TwoFactorChallengeResponse {
+	return TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		TwoFactorToken:    output.TwoFactorToken.String(),
+		ExpiresAt:         output.TwoFactorExpiresAt,
+	}
+}
+
+// SessionResponse はセッション1件のレスポンスDTO
+type SessionResponse struct {
+	ID         string    `json:"id" example:"3f2b8c0e9a1d4e7f8b6c5a4d3e2f1a0b"` // セッションの公開ID
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0"`
+	IPAddress  string    `json:"ipAddress" example:"203.0.113.1"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"` // リクエスト元のセッションかどうか
+}
+
+// SessionsResponse はセッション一覧のレスポンスDTO
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// NewSessionsResponse はセッション一覧からレスポンスDTOを生成する
+func NewSessionsResponse(sessions []*entity.Session, currentSessionID vo.SessionID) SessionsResponse {
+	items := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		items[i] = SessionResponse{
+			ID:         session.ID().PublicID(),
+			UserAgent:  session.UserAgent(),
+			IPAddress:  session.IPAddress(),
+			CreatedAt:  session.CreatedAt(),
+			LastSeenAt: session.LastSeenAt(),
+			ExpiresAt:  session.ExpiresAt().Time(),
+			Current:    session.ID().Equals(currentSessionID),
+		}
+	}
+	return SessionsResponse{Sessions: items}
+}
@@ -3,7 +3,9 @@ package auth
 import (
 	"context"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,7 +20,7 @@ import (
 
 // Cookie設定定数
 const (
-	sessionCookieName = "session_id"
+	sessionCookieName = common.SessionCookieName
 	cookiePath        = "/"
 	// 有効期限は7日間（秒単位）
 	cookieMaxAge = int(vo.SessionDurationDays * 24 * time.Hour / time.Second)
@@ -26,9 +28,13 @@ const (
 
 // AuthUsecaseInterface はAuthUsecaseのインターフェース
 type AuthUsecaseInterface interface {
-	Login(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error)
+	Login(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error)
+	LoginWithTOTP(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error)
 	Logout(ctx context.Context, sessionID vo.SessionID) error
 	ValidateSession(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error)
+	ListSessions(ctx context.Context, userID vo.UserID) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, userID vo.UserID, publicID string) error
+	RevokeOtherSessions(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error
 }
 
 // AuthHandler は認証関連のHTTPハンドラ
@@ -43,47 +49,166 @@ func NewAuthHandler(uc AuthUsecaseInterface) *AuthHandler {
 
 // Login はログイン処理を行う
 // @Summary ログイン
-// @Description メールアドレスとパスワードでログインし、セッションを開始する
+// @Description メールアドレスとパスワードでログインし、セッションを開始する。2段階認証が有効なユーザーの場合はセッションを開始せず、/auth/login/totp で使うトークンを返す
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "ログインリクエスト"
 // @Success 200 {object} dto.LoginResponse "ログイン成功"
+// @Success 202 {object} dto.TwoFactorChallengeResponse "2段階認証のコード入力が必要"
 // @Failure 400 {object} common.ErrorResponse "リクエスト不正"
 // @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "アカウント停止中"
+// @Failure 429 {object} common.ErrorResponse "ログイン試行回数超過（Retry-Afterヘッダーに再試行までの秒数）"
 // @Failure 500 {object} common.ErrorResponse "サーバーエラー"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
+	output, ok := h.login(c)
+	if !ok {
+		return
+	}
+
+	// セッションCookieを設定
+	h.setSessionCookie(c, output.Session.ID().String())
+
+	// レスポンス返却
+	c.JSON(http.StatusOK, dto.NewLoginResponse(output))
+}
+
+// LoginToken はログインしてセッショントークンをレスポンスボディで返す
+// @Summary トークンログイン
+// @Description Cookieを扱いにくいモバイルアプリやスクリプト向けのログイン。セッションCookieは設定せず、Authorization: Bearer ヘッダーで使うトークンを返す。2段階認証が有効なユーザーの場合は /auth/token/totp で使うトークンを返す
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "ログインリクエスト"
+// @Success 200 {object} dto.TokenLoginResponse "ログイン成功"
+// @Success 202 {object} dto.TwoFactorChallengeResponse "2段階認証のコード入力が必要"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 403 {object} common.ErrorResponse "アカウント停止中"
+// @Failure 429 {object} common.ErrorResponse "ログイン試行回数超過（Retry-Afterヘッダーに再試行までの秒数）"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/token [post]
+func (h *AuthHandler) LoginToken(c *gin.Context) {
+	output, ok := h.login(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTokenLoginResponse(output))
+}
+
+// LoginTOTP は2段階認証のコードを確認してログインを完了する
+// @Summary 2段階認証ログイン
+// @Description ログインで受け取ったトークンと認証アプリのコード（またはリカバリーコード）を確認し、セッションを開始する
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginTOTPRequest true "2段階認証ログインリクエスト"
+// @Success 200 {object} dto.LoginResponse "ログイン成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正"
+// @Failure 401 {object} common.ErrorResponse "トークン不正・期限切れ、コード不一致"
+// @Failure 403 {object} common.ErrorResponse "アカウント停止中"
+// @Failure 429 {object} common.ErrorResponse "ログイン試行回数超過（Retry-Afterヘッダーに再試行までの秒数）"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/login/totp [post]
+func (h *AuthHandler) LoginTOTP(c *gin.Context) {
+	output, ok := h.loginTOTP(c)
+	if !ok {
+		return
+	}
+
+	// セッションCookieを設定
+	h.setSessionCookie(c, output.Session.ID().String())
+
+	c.JSON(http.StatusOK, dto.NewLoginResponse(output))
+}
+
+// LoginTOTPToken は2段階認証のコードを確認し、セッショントークンをレスポンスボディで返す
+// @Summary 2段階認証トークンログイン
+// @Description /auth/token で受け取ったトークンと認証アプリのコード（またはリカバリーコード）を確認し、Authorization: Bearer ヘッダーで使うトークンを返す。セッションCookieは設定しない
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginTOTPRequest true "2段階認証ログインリクエスト"
+// @Success 200 {object} dto.TokenLoginResponse "ログイン成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正"
+// @Failure 401 {object} common.ErrorResponse "トークン不正・期限切れ、コード不一致"
+// @Failure 403 {object} common.ErrorResponse "アカウント停止中"
+// @Failure 429 {object} common.ErrorResponse "ログイン試行回数超過（Retry-Afterヘッダーに再試行までの秒数）"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/token/totp [post]
+func (h *AuthHandler) LoginTOTPToken(c *gin.Context) {
+	output, ok := h.loginTOTP(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTokenLoginResponse(output))
+}
+
+// login はメールアドレスとパスワードでログインする
+// 2段階認証が必要な場合は202を返し、エラー時はエラーレスポンスを返してfalseを返す
+func (h *AuthHandler) login(c *gin.Context) (*usecase.LoginOutput, bool) {
 	var req dto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
-		return
+		return nil, false
 	}
 
 	// DTOからVOに変換
 	email, password, err := req.ToDomain()
 	if err != nil {
 		common.RespondError(c, http.StatusUnauthorized, common.CodeInvalidCredentials, "Invalid email or password", nil)
-		return
+		return nil, false
 	}
 
 	// Usecase実行
-	output, err := h.usecase.Login(c.Request.Context(), email, password)
+	output, err := h.usecase.Login(c.Request.Context(), email, password, sessionClient(c))
 	if err != nil {
 		h.handleError(c, err)
-		return
+		return nil, false
 	}
 
-	// セッションCookieを設定
-	h.setSessionCookie(c, output.Session.ID().String())
+	// 2段階認証が必要な場合はセッションを開始せずトークンを返す
+	if output.TwoFactorRequired {
+		c.JSON(http.StatusAccepted, dto.NewTwoFactorChallengeResponse(output))
+		return nil, false
+	}
 
-	// レスポンス返却
-	c.JSON(http.StatusOK, dto.NewLoginResponse(output))
+	return output, true
+}
+
+// loginTOTP は2段階認証のコードを確認してログインを完了する
+// エラー時はエラーレスポンスを返してfalseを返す
+func (h *AuthHandler) loginTOTP(c *gin.Context) (*usecase.LoginOutput, bool) {
+	var req dto.LoginTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return nil, false
+	}
+
+	// DTOからVOに変換
+	token, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	// Usecase実行
+	output, err := h.usecase.LoginWithTOTP(c.Request.Context(), token, req.Code, sessionClient(c))
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	return output, true
 }
 
 // Logout はログアウト処理を行う
 // @Summary ログアウト
-// @Description セッションを終了してログアウトする
+// @Description セッションを終了してログアウトする。Authorization: Bearer ヘッダーまたはセッションCookieのセッションを対象とする
 // @Tags auth
 // @Produce json
 // @Success 200 {object} map[string]string "ログアウト成功"
@@ -92,10 +217,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Failure 500 {object} common.ErrorResponse "サーバーエラー"
 // @Router /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// CookieからセッションIDを取得
-	sessionIDStr, err := c.Cookie(sessionCookieName)
+	// AuthorizationヘッダーまたはCookieからセッションIDを取得
+	sessionIDStr, source, err := common.SessionTokenFromRequest(c)
 	if err != nil {
-		// Cookieが無い場合は既にログアウト済みとして成功扱い
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid authorization header", nil)
+		return
+	}
+	if source == common.SessionTokenNone {
+		// セッションが送られていない場合は既にログアウト済みとして成功扱い
 		c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 		return
 	}
@@ -113,12 +242,120 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	// セッションCookieを削除
-	h.clearSessionCookie(c)
+	// Cookieでログインしていた場合はセッションCookieを削除
+	if source == common.SessionTokenCookie {
+		h.clearSessionCookie(c)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
+// ListSessions はログイン中のセッション一覧を取得する
+// @Summary セッション一覧取得
+// @Description 認証ユーザーの有効なセッション（ログイン中の端末）を最終利用日時の降順で取得する
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.SessionsResponse "取得成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, currentSessionID, ok := h.currentSession(c)
+	if !ok {
+		return
+	}
+
+	// Usecase実行
+	sessions, err := h.usecase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSessionsResponse(sessions, currentSessionID))
+}
+
+// RevokeSession は指定したセッションを削除する
+// @Summary セッション削除
+// @Description 認証ユーザーのセッションを1件削除し、その端末をログアウトさせる
+// @Tags auth
+// @Produce json
+// @Param id path string true "セッションの公開ID"
+// @Success 200 {object} map[string]string "削除成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "セッションが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, _, ok := h.currentSession(c)
+	if !ok {
+		return
+	}
+
+	// Usecase実行
+	if err := h.usecase.RevokeSession(c.Request.Context(), userID, c.Param("id")); err != nil {
+		if errors.Is(err, domainErrors.ErrSessionNotFound) {
+			common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "Session not found", nil)
+			return
+		}
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// RevokeOtherSessions は現在のセッション以外の全セッションを削除する
+// @Summary 他の端末からログアウト
+// @Description 現在のセッションを残し、認証ユーザーの他の全セッションを削除する
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string "削除成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/sessions/revoke-others [post]
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, currentSessionID, ok := h.currentSession(c)
+	if !ok {
+		return
+	}
+
+	// Usecase実行
+	if err := h.usecase.RevokeOtherSessions(c.Request.Context(), userID, currentSessionID); err != nil {
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Other sessions revoked successfully"})
+}
+
+// currentSession はAuthMiddlewareが設定したユーザーIDとセッションIDを取得する
+// 取得できない場合は401を返してfalseを返す
+func (h *AuthHandler) currentSession(c *gin.Context) (vo.UserID, vo.SessionID, bool) {
+	userIDStr, userExists := c.Get("userID")
+	sessionIDStr, sessionExists := c.Get("sessionID")
+	if !userExists || !sessionExists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return vo.UserID{}, vo.SessionID{}, false
+	}
+
+	sessionID, err := vo.ParseSessionID(sessionIDStr.(string))
+	if err != nil {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid session", nil)
+		return vo.UserID{}, vo.SessionID{}, false
+	}
+
+	return vo.ReconstructUserID(userIDStr.(string)), sessionID, true
+}
+
+// sessionClient はセッション一覧で端末を識別するためのリクエスト元情報を取得する
+func sessionClient(c *gin.Context) entity.SessionClient {
+	return entity.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 // setSessionCookie はセッションCookieを設定する
 func (h *AuthHandler) setSessionCookie(c *gin.Context, sessionID string) {
 	c.SetSameSite(http.SameSiteLaxMode)
@@ -155,6 +392,32 @@ func (h *AuthHandler) handleError(c *gin.Context, err error) {
 		return
 	}
 
+	// 2段階認証トークンが不正・期限切れ
+	if errors.Is(err, domainErrors.ErrTwoFactorTokenInvalid) {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeInvalidToken, "Two-factor token is invalid or expired", nil)
+		return
+	}
+
+	// 2段階認証のコード不一致
+	if errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeInvalidTOTPCode, "Invalid authentication code", nil)
+		return
+	}
+
+	// 管理者に停止されたアカウント
+	if errors.Is(err, domainErrors.ErrAccountDisabled) {
+		common.RespondError(c, http.StatusForbidden, common.CodeAccountDisabled, "Account has been disabled", nil)
+		return
+	}
+
+	// ログイン試行回数超過
+	var throttledErr *domainErrors.LoginThrottledError
+	if errors.As(err, &throttledErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttledErr.RetryAfter.Seconds()))))
+		common.RespondError(c, http.StatusTooManyRequests, common.CodeTooManyLoginAttempts, "Too many login attempts. Please try again later", nil)
+		return
+	}
+
 	// セッション期限切れ
 	if errors.Is(err, domainErrors.ErrSessionExpired) {
 		common.RespondError(c, http.StatusUnauthorized, common.CodeSessionExpired, "Session has expired", nil)
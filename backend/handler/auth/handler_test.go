@@ -3,6 +3,7 @@ package auth_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/vo"
 	"caltrack/handler/auth"
+	"caltrack/handler/auth/dto"
 	"caltrack/handler/common"
 	"caltrack/usecase"
 )
@@ -25,14 +27,25 @@ func init() {
 
 // MockAuthUsecase はAuthUsecaseのモック実装
 type MockAuthUsecase struct {
-	LoginFunc           func(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error)
-	LogoutFunc          func(ctx context.Context, sessionID vo.SessionID) error
-	ValidateSessionFunc func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error)
+	LoginFunc               func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error)
+	LoginWithTOTPFunc       func(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error)
+	LogoutFunc              func(ctx context.Context, sessionID vo.SessionID) error
+	ValidateSessionFunc     func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error)
+	ListSessionsFunc        func(ctx context.Context, userID vo.UserID) ([]*entity.Session, error)
+	RevokeSessionFunc       func(ctx context.Context, userID vo.UserID, publicID string) error
+	RevokeOtherSessionsFunc func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error
 }
 
-func (m *MockAuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error) {
+func (m *MockAuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
 	if m.LoginFunc != nil {
-		return m.LoginFunc(ctx, email, password)
+		return m.LoginFunc(ctx, email, password, client)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthUsecase) LoginWithTOTP(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error) {
+	if m.LoginWithTOTPFunc != nil {
+		return m.LoginWithTOTPFunc(ctx, token, code, client)
 	}
 	return nil, nil
 }
@@ -51,6 +64,27 @@ func (m *MockAuthUsecase) ValidateSession(ctx context.Context, sessionID vo.Sess
 	return nil, nil
 }
 
+func (m *MockAuthUsecase) ListSessions(ctx context.Context, userID vo.UserID) ([]*entity.Session, error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthUsecase) RevokeSession(ctx context.Context, userID vo.UserID, publicID string) error {
+	if m.RevokeSessionFunc != nil {
+		return m.RevokeSessionFunc(ctx, userID, publicID)
+	}
+	return nil
+}
+
+func (m *MockAuthUsecase) RevokeOtherSessions(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error {
+	if m.RevokeOtherSessionsFunc != nil {
+		return m.RevokeOtherSessionsFunc(ctx, userID, currentSessionID)
+	}
+	return nil
+}
+
 // createTestUser はテスト用ユーザーを作成するヘルパー関数
 func createTestUser(t *testing.T, email, password string) *entity.User {
 	t.Helper()
@@ -78,7 +112,7 @@ func TestAuthHandler_Login(t *testing.T) {
 		testUser := createTestUser(t, testEmail, testPassword)
 
 		mockUC := &MockAuthUsecase{
-			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error) {
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
 				// テスト用のセッションを作成
 				session, err := entity.NewSessionWithUserID(testUser.ID())
 				if err != nil {
@@ -142,9 +176,57 @@ func TestAuthHandler_Login(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_2段階認証が有効な場合はトークンを返しセッションを開始しない", func(t *testing.T) {
+		testUser := createTestUser(t, "test@example.com", "password123")
+		token, _ := vo.NewOneTimeToken()
+		expiresAt := time.Now().Add(entity.TwoFactorChallengeTTL)
+
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return &usecase.LoginOutput{
+					User:               testUser,
+					TwoFactorRequired:  true,
+					TwoFactorToken:     token,
+					TwoFactorExpiresAt: expiresAt,
+				}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		reqBody := `{"email": "test@example.com", "password": "password123"}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusAccepted, w.Body.String())
+		}
+
+		var resp dto.TwoFactorChallengeResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !resp.TwoFactorRequired {
+			t.Error("twoFactorRequired should be true")
+		}
+		if resp.TwoFactorToken != token.String() {
+			t.Errorf("twoFactorToken = %s, want %s", resp.TwoFactorToken, token.String())
+		}
+
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				t.Error("session_id cookie should not be set before two-factor authentication")
+			}
+		}
+	})
+
 	t.Run("異常系_認証情報不正", func(t *testing.T) {
 		mockUC := &MockAuthUsecase{
-			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error) {
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
 				return nil, domainErrors.ErrInvalidCredentials
 			},
 		}
@@ -177,7 +259,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 	t.Run("異常系_ユーザー未登録", func(t *testing.T) {
 		mockUC := &MockAuthUsecase{
-			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password) (*usecase.LoginOutput, error) {
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
 				return nil, domainErrors.ErrInvalidCredentials
 			},
 		}
@@ -206,6 +288,71 @@ func TestAuthHandler_Login(t *testing.T) {
 		}
 	})
 
+	t.Run("異常系_停止されたアカウントは403を返す", func(t *testing.T) {
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrAccountDisabled
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		reqBody := `{"email": "test@example.com", "password": "password123"}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeAccountDisabled {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeAccountDisabled)
+		}
+	})
+
+	t.Run("異常系_試行回数超過で429とRetry-Afterを返す", func(t *testing.T) {
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, &domainErrors.LoginThrottledError{RetryAfter: 1500 * time.Millisecond}
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		reqBody := `{"email": "test@example.com", "password": "password123"}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.Login(c)
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+		}
+		// 秒未満は切り上げる
+		if got := w.Header().Get("Retry-After"); got != "2" {
+			t.Errorf("Retry-After = %q, want %q", got, "2")
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Code != common.CodeTooManyLoginAttempts {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeTooManyLoginAttempts)
+		}
+	})
+
 	t.Run("異常系_メールアドレス形式不正", func(t *testing.T) {
 		mockUC := &MockAuthUsecase{}
 		handler := auth.NewAuthHandler(mockUC)
@@ -261,6 +408,265 @@ func TestAuthHandler_Login(t *testing.T) {
 	})
 }
 
+func TestAuthHandler_LoginTOTP(t *testing.T) {
+	validToken, _ := vo.NewOneTimeToken()
+
+	// newLoginTOTPContext は2段階認証ログインのリクエストを持つテスト用コンテキストを生成する
+	newLoginTOTPContext := func(reqBody string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/totp", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c, w
+	}
+
+	t.Run("正常系_コード確認でセッションを開始する", func(t *testing.T) {
+		testUser := createTestUser(t, "test@example.com", "password123")
+
+		var gotCode string
+		mockUC := &MockAuthUsecase{
+			LoginWithTOTPFunc: func(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				if token.String() != validToken.String() {
+					t.Errorf("token = %s, want %s", token.String(), validToken.String())
+				}
+				gotCode = code
+				session, err := entity.NewSessionWithUserID(testUser.ID())
+				if err != nil {
+					return nil, err
+				}
+				return &usecase.LoginOutput{User: testUser, Session: session}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTOTPContext(`{"token": "` + validToken.String() + `", "code": "123456"}`)
+		handler.LoginTOTP(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotCode != "123456" {
+			t.Errorf("code = %s, want %s", gotCode, "123456")
+		}
+
+		var sessionCookie *http.Cookie
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				sessionCookie = cookie
+			}
+		}
+		if sessionCookie == nil || sessionCookie.Value == "" {
+			t.Error("session_id cookie should be set")
+		}
+	})
+
+	t.Run("異常系_トークン形式不正", func(t *testing.T) {
+		handler := auth.NewAuthHandler(&MockAuthUsecase{})
+
+		c, w := newLoginTOTPContext(`{"token": "invalid", "code": "123456"}`)
+		handler.LoginTOTP(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeInvalidToken {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeInvalidToken)
+		}
+	})
+
+	t.Run("異常系_コード不一致", func(t *testing.T) {
+		mockUC := &MockAuthUsecase{
+			LoginWithTOTPFunc: func(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrInvalidTOTPCode
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTOTPContext(`{"token": "` + validToken.String() + `", "code": "000000"}`)
+		handler.LoginTOTP(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeInvalidTOTPCode {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeInvalidTOTPCode)
+		}
+	})
+
+	t.Run("異常系_トークン期限切れ", func(t *testing.T) {
+		mockUC := &MockAuthUsecase{
+			LoginWithTOTPFunc: func(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrTwoFactorTokenInvalid
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTOTPContext(`{"token": "` + validToken.String() + `", "code": "123456"}`)
+		handler.LoginTOTP(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestAuthHandler_LoginToken(t *testing.T) {
+	// newLoginTokenContext はトークンログインのリクエストを持つテスト用コンテキストを生成する
+	newLoginTokenContext := func(reqBody string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c, w
+	}
+
+	t.Run("正常系_トークンをボディで返しCookieを設定しない", func(t *testing.T) {
+		testUser := createTestUser(t, "test@example.com", "password123")
+		session, err := entity.NewSessionWithUserID(testUser.ID())
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return &usecase.LoginOutput{User: testUser, Session: session}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTokenContext(`{"email": "test@example.com", "password": "password123"}`)
+		handler.LoginToken(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var resp dto.TokenLoginResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Token != session.ID().String() {
+			t.Errorf("token = %s, want %s", resp.Token, session.ID().String())
+		}
+		if resp.TokenType != "Bearer" {
+			t.Errorf("tokenType = %s, want %s", resp.TokenType, "Bearer")
+		}
+		if !resp.ExpiresAt.Equal(session.ExpiresAt().Time()) {
+			t.Errorf("expiresAt = %v, want %v", resp.ExpiresAt, session.ExpiresAt().Time())
+		}
+		if resp.Email != "test@example.com" {
+			t.Errorf("email = %s, want %s", resp.Email, "test@example.com")
+		}
+
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				t.Error("session_id cookie should not be set")
+			}
+		}
+	})
+
+	t.Run("正常系_2段階認証が有効な場合はトークンを返さない", func(t *testing.T) {
+		testUser := createTestUser(t, "test@example.com", "password123")
+		challengeToken, _ := vo.NewOneTimeToken()
+
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return &usecase.LoginOutput{
+					User:               testUser,
+					TwoFactorRequired:  true,
+					TwoFactorToken:     challengeToken,
+					TwoFactorExpiresAt: time.Now().Add(5 * time.Minute),
+				}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTokenContext(`{"email": "test@example.com", "password": "password123"}`)
+		handler.LoginToken(c)
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusAccepted, w.Body.String())
+		}
+
+		var resp dto.TwoFactorChallengeResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.TwoFactorToken != challengeToken.String() {
+			t.Errorf("twoFactorToken = %s, want %s", resp.TwoFactorToken, challengeToken.String())
+		}
+	})
+
+	t.Run("異常系_認証失敗", func(t *testing.T) {
+		mockUC := &MockAuthUsecase{
+			LoginFunc: func(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrInvalidCredentials
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		c, w := newLoginTokenContext(`{"email": "test@example.com", "password": "wrongpassword"}`)
+		handler.LoginToken(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestAuthHandler_LoginTOTPToken(t *testing.T) {
+	t.Run("正常系_コード確認でトークンをボディで返す", func(t *testing.T) {
+		testUser := createTestUser(t, "test@example.com", "password123")
+		session, err := entity.NewSessionWithUserID(testUser.ID())
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		validToken, _ := vo.NewOneTimeToken()
+
+		mockUC := &MockAuthUsecase{
+			LoginWithTOTPFunc: func(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return &usecase.LoginOutput{User: testUser, Session: session}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/token/totp", strings.NewReader(`{"token": "`+validToken.String()+`", "code": "123456"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.LoginTOTPToken(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var resp dto.TokenLoginResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Token != session.ID().String() {
+			t.Errorf("token = %s, want %s", resp.Token, session.ID().String())
+		}
+
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				t.Error("session_id cookie should not be set")
+			}
+		}
+	})
+}
+
 func TestAuthHandler_Logout(t *testing.T) {
 	t.Run("正常系_ログアウト成功", func(t *testing.T) {
 		mockUC := &MockAuthUsecase{
@@ -316,6 +722,54 @@ func TestAuthHandler_Logout(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_Bearerトークンでログアウト", func(t *testing.T) {
+		validSessionID := "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+		var gotSessionID string
+		mockUC := &MockAuthUsecase{
+			LogoutFunc: func(ctx context.Context, sessionID vo.SessionID) error {
+				gotSessionID = sessionID.String()
+				return nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+validSessionID)
+
+		handler.Logout(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotSessionID != validSessionID {
+			t.Errorf("sessionID = %s, want %s", gotSessionID, validSessionID)
+		}
+
+		// Bearerトークンの場合はCookieを操作しない
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				t.Error("session_id cookie should not be touched for bearer logout")
+			}
+		}
+	})
+
+	t.Run("異常系_AuthorizationヘッダーがBearer形式でない", func(t *testing.T) {
+		handler := auth.NewAuthHandler(&MockAuthUsecase{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil)
+		c.Request.Header.Set("Authorization", "Token abc")
+
+		handler.Logout(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
 	t.Run("異常系_セッションID不正", func(t *testing.T) {
 		mockUC := &MockAuthUsecase{
 			LogoutFunc: func(ctx context.Context, sessionID vo.SessionID) error {
@@ -376,3 +830,181 @@ func TestAuthHandler_Logout(t *testing.T) {
 		}
 	})
 }
+
+// setAuthContext はAuthMiddlewareが設定する認証情報をコンテキストに設定する
+func setAuthContext(c *gin.Context, session *entity.Session) {
+	c.Set("userID", session.UserID().String())
+	c.Set("sessionID", session.ID().String())
+}
+
+func TestAuthHandler_ListSessions(t *testing.T) {
+	t.Run("正常系_セッション一覧取得", func(t *testing.T) {
+		userID := vo.NewUserID()
+		current, _ := entity.NewSessionWithUserID(userID)
+		other, _ := entity.NewSessionWithUserID(userID)
+		other.AttachClient(entity.SessionClient{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.1"})
+
+		mockUC := &MockAuthUsecase{
+			ListSessionsFunc: func(ctx context.Context, id vo.UserID) ([]*entity.Session, error) {
+				return []*entity.Session{other, current}, nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+		setAuthContext(c, current)
+
+		handler.ListSessions(c)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var resp struct {
+			Sessions []struct {
+				ID        string `json:"id"`
+				UserAgent string `json:"userAgent"`
+				IPAddress string `json:"ipAddress"`
+				Current   bool   `json:"current"`
+			} `json:"sessions"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.Sessions) != 2 {
+			t.Fatalf("len(sessions) = %d, want 2", len(resp.Sessions))
+		}
+		if resp.Sessions[0].ID != other.ID().PublicID() {
+			t.Errorf("sessions[0].id = %s, want %s", resp.Sessions[0].ID, other.ID().PublicID())
+		}
+		if resp.Sessions[0].UserAgent != "Mozilla/5.0" || resp.Sessions[0].IPAddress != "203.0.113.1" {
+			t.Errorf("sessions[0] client = (%s, %s)", resp.Sessions[0].UserAgent, resp.Sessions[0].IPAddress)
+		}
+		if resp.Sessions[0].Current || !resp.Sessions[1].Current {
+			t.Errorf("current flags = (%v, %v), want (false, true)", resp.Sessions[0].Current, resp.Sessions[1].Current)
+		}
+		if strings.Contains(w.Body.String(), current.ID().String()) {
+			t.Error("response should not contain raw session id")
+		}
+	})
+
+	t.Run("異常系_未認証", func(t *testing.T) {
+		handler := auth.NewAuthHandler(&MockAuthUsecase{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+
+		handler.ListSessions(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	t.Run("正常系_セッション削除", func(t *testing.T) {
+		current, _ := entity.NewSessionWithUserID(vo.NewUserID())
+		var gotPublicID string
+
+		mockUC := &MockAuthUsecase{
+			RevokeSessionFunc: func(ctx context.Context, userID vo.UserID, publicID string) error {
+				gotPublicID = publicID
+				return nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/abc123", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc123"}}
+		setAuthContext(c, current)
+
+		handler.RevokeSession(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotPublicID != "abc123" {
+			t.Errorf("publicID = %s, want abc123", gotPublicID)
+		}
+	})
+
+	t.Run("異常系_セッションが見つからない", func(t *testing.T) {
+		current, _ := entity.NewSessionWithUserID(vo.NewUserID())
+
+		mockUC := &MockAuthUsecase{
+			RevokeSessionFunc: func(ctx context.Context, userID vo.UserID, publicID string) error {
+				return domainErrors.ErrSessionNotFound
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/unknown", nil)
+		c.Params = gin.Params{{Key: "id", Value: "unknown"}}
+		setAuthContext(c, current)
+
+		handler.RevokeSession(c)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestAuthHandler_RevokeOtherSessions(t *testing.T) {
+	t.Run("正常系_現在のセッション以外を削除", func(t *testing.T) {
+		current, _ := entity.NewSessionWithUserID(vo.NewUserID())
+		var gotSessionID vo.SessionID
+
+		mockUC := &MockAuthUsecase{
+			RevokeOtherSessionsFunc: func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error {
+				gotSessionID = currentSessionID
+				return nil
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil)
+		setAuthContext(c, current)
+
+		handler.RevokeOtherSessions(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if !gotSessionID.Equals(current.ID()) {
+			t.Errorf("currentSessionID = %s, want %s", gotSessionID.String(), current.ID().String())
+		}
+	})
+
+	t.Run("異常系_内部エラー", func(t *testing.T) {
+		current, _ := entity.NewSessionWithUserID(vo.NewUserID())
+
+		mockUC := &MockAuthUsecase{
+			RevokeOtherSessionsFunc: func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error {
+				return errors.New("db error")
+			},
+		}
+		handler := auth.NewAuthHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil)
+		setAuthContext(c, current)
+
+		handler.RevokeOtherSessions(c)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
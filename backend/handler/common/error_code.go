@@ -8,9 +8,41 @@ const (
 	CodeNotFound           = "NOT_FOUND"
 
 	// 認証関連エラーコード
-	CodeInvalidCredentials = "INVALID_CREDENTIALS"
-	CodeUnauthorized       = "UNAUTHORIZED"
-	CodeSessionExpired     = "SESSION_EXPIRED"
+	CodeInvalidCredentials   = "INVALID_CREDENTIALS"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeSessionExpired       = "SESSION_EXPIRED"
+	CodeInvalidToken         = "INVALID_TOKEN"
+	CodeTooManyLoginAttempts = "TOO_MANY_LOGIN_ATTEMPTS"
+	CodeInsufficientScope    = "INSUFFICIENT_SCOPE"
+
+	// 2段階認証関連エラーコード
+	CodeInvalidTOTPCode    = "INVALID_TOTP_CODE"
+	CodeTOTPAlreadyEnabled = "TOTP_ALREADY_ENABLED"
+	CodeTOTPNotEnabled     = "TOTP_NOT_ENABLED"
+
+	// OpenID Connect関連エラーコード
+	CodeOIDCProviderNotFound = "OIDC_PROVIDER_NOT_FOUND"
+	CodeOIDCStateInvalid     = "OIDC_STATE_INVALID"
+	CodeOIDCLoginFailed      = "OIDC_LOGIN_FAILED"
+	CodeOIDCEmailNotVerified = "OIDC_EMAIL_NOT_VERIFIED"
+	CodePasswordAlreadySet   = "PASSWORD_ALREADY_SET"
+
+	// 管理者・アカウント停止関連エラーコード
+	CodeAccountDisabled        = "ACCOUNT_DISABLED"
+	CodeAdminRequired          = "ADMIN_REQUIRED"
+	CodeAccountAlreadyDisabled = "ACCOUNT_ALREADY_DISABLED"
+	CodeAccountNotDisabled     = "ACCOUNT_NOT_DISABLED"
+	CodeCannotDisableSelf      = "CANNOT_DISABLE_SELF"
+
+	// 退会関連エラーコード
+	CodeAccountDeletionAlreadyScheduled = "ACCOUNT_DELETION_ALREADY_SCHEDULED"
+
+	// メールアドレス確認関連エラーコード
+	CodeEmailNotVerified     = "EMAIL_NOT_VERIFIED"
+	CodeEmailAlreadyVerified = "EMAIL_ALREADY_VERIFIED"
+
+	// プライバシー設定関連エラーコード
+	CodeAIProcessingDisabled = "AI_PROCESSING_DISABLED"
 
 	// 画像解析関連エラーコード
 	CodeNoFoodDetected      = "NO_FOOD_DETECTED"
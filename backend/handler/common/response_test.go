@@ -0,0 +1,64 @@
+package common_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/handler/common"
+	"caltrack/pkg/logger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRespondError(t *testing.T) {
+	t.Run("正常系_5xxのログにエラーコードがマスクされずに出力される", func(t *testing.T) {
+		var buf bytes.Buffer
+		restore := logger.Replace(slog.NewJSONHandler(&buf, nil))
+		defer restore()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/records/today", nil)
+
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", errors.New("db error"))
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("failed to parse log: %v (%s)", err, buf.String())
+		}
+		if entry["code"] != common.CodeInternalError {
+			t.Errorf("code = %v, want %s", entry["code"], common.CodeInternalError)
+		}
+		if entry["error"] != "db error" || entry["path"] != "/records/today" {
+			t.Errorf("entry = %v", entry)
+		}
+	})
+
+	t.Run("正常系_4xxはログを出力しない", func(t *testing.T) {
+		var buf bytes.Buffer
+		restore := logger.Replace(slog.NewJSONHandler(&buf, nil))
+		defer restore()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/records/today", nil)
+
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "Not found", errors.New("not found"))
+
+		if buf.Len() != 0 {
+			t.Errorf("log = %s, want empty", buf.String())
+		}
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
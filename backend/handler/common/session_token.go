@@ -0,0 +1,49 @@
+package common
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionCookieName はセッションIDを保持するCookie名
+const SessionCookieName = "session_id"
+
+// bearerScheme はAuthorizationヘッダーのBearerスキーム名
+const bearerScheme = "Bearer"
+
+// SessionTokenSource はセッショントークンの取得元
+type SessionTokenSource int
+
+const (
+	// SessionTokenNone はセッショントークンが送られていないことを表す
+	SessionTokenNone SessionTokenSource = iota
+	// SessionTokenCookie はCookieから取得したことを表す
+	SessionTokenCookie
+	// SessionTokenBearer はAuthorizationヘッダーのBearerトークンから取得したことを表す
+	SessionTokenBearer
+)
+
+// ErrInvalidAuthorizationHeader はAuthorizationヘッダーがBearer形式でない場合のエラー
+var ErrInvalidAuthorizationHeader = errors.New("invalid authorization header")
+
+// SessionTokenFromRequest はリクエストからセッショントークンを取得する
+// Authorizationヘッダーがあればそれを優先し、無ければCookieを参照する
+// ヘッダーが「Bearer <token>」の形式でない場合は ErrInvalidAuthorizationHeader を返す
+func SessionTokenFromRequest(c *gin.Context) (string, SessionTokenSource, error) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+		token = strings.TrimSpace(token)
+		if !found || !strings.EqualFold(scheme, bearerScheme) || token == "" {
+			return "", SessionTokenNone, ErrInvalidAuthorizationHeader
+		}
+		return token, SessionTokenBearer, nil
+	}
+
+	if token, err := c.Cookie(SessionCookieName); err == nil && token != "" {
+		return token, SessionTokenCookie, nil
+	}
+
+	return "", SessionTokenNone, nil
+}
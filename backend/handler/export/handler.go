@@ -0,0 +1,103 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+)
+
+// ExportUsecaseInterface はExportUsecaseのインターフェース
+type ExportUsecaseInterface interface {
+	Export(ctx context.Context, userID vo.UserID, w io.Writer) error
+}
+
+// ExportHandler は個人データのエクスポートに関するHTTPハンドラ
+type ExportHandler struct {
+	usecase ExportUsecaseInterface
+}
+
+// NewExportHandler は ExportHandler のインスタンスを生成する
+func NewExportHandler(uc ExportUsecaseInterface) *ExportHandler {
+	return &ExportHandler{usecase: uc}
+}
+
+// Export は個人データをZIPアーカイブでダウンロードさせる
+// @Summary 個人データのエクスポート
+// @Description プロフィール・全ての記録（品目・PFC）・アドバイス履歴をJSONとCSVでまとめたZIPを返す。スキーマバージョンを含むmanifest.jsonを同梱する
+// @Tags users
+// @Produce application/zip
+// @Success 200 {file} binary "ZIPアーカイブ"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが存在しない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/me/export [get]
+func (h *ExportHandler) Export(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	filename := fmt.Sprintf("caltrack-export-%s.zip", time.Now().Format("20060102"))
+	w := &attachmentWriter{c: c, filename: filename}
+	if err := h.usecase.Export(c.Request.Context(), userID, w); err != nil {
+		if !w.started {
+			h.handleError(c, err)
+			return
+		}
+		// 書き出し開始後はステータスを変更できないため、ログに残して接続を打ち切る
+		common.LogError("Export", err, "user_id", userID.String())
+		c.Abort()
+		return
+	}
+	if !w.started {
+		// 何も書き出されなかった場合もヘッダーは返す
+		w.writeHeader()
+	}
+}
+
+// attachmentWriter は最初の書き込み時にダウンロード用のヘッダーを送るWriter
+// エクスポートの書き出し前に発生したエラーはJSONのエラーレスポンスで返せるようにする
+type attachmentWriter struct {
+	c        *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *attachmentWriter) writeHeader() {
+	w.started = true
+	w.c.Header("Content-Type", "application/zip")
+	w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.c.Header("Cache-Control", "no-store")
+	w.c.Status(http.StatusOK)
+	w.c.Writer.WriteHeaderNow()
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.writeHeader()
+	}
+	return w.c.Writer.Write(p)
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *ExportHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrUserNotFound) {
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+		return
+	}
+
+	// 500エラー
+	common.LogError("handleError", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", nil)
+}
@@ -0,0 +1,142 @@
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/export"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockExportUsecase はExportUsecaseのモック実装
+type MockExportUsecase struct {
+	ExportFunc func(ctx context.Context, userID vo.UserID, w io.Writer) error
+}
+
+func (m *MockExportUsecase) Export(ctx context.Context, userID vo.UserID, w io.Writer) error {
+	if m.ExportFunc != nil {
+		return m.ExportFunc(ctx, userID, w)
+	}
+	return nil
+}
+
+// performExport は認証済みユーザーとしてエクスポートリクエストを実行する
+func performExport(uc export.ExportUsecaseInterface) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/me/export", nil)
+	c.Set("userID", testUserID)
+	export.NewExportHandler(uc).Export(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+func TestExportHandler_Export(t *testing.T) {
+	t.Run("正常系_ZIPを添付ファイルとして返す", func(t *testing.T) {
+		var gotUserID vo.UserID
+		mockUC := &MockExportUsecase{
+			ExportFunc: func(ctx context.Context, userID vo.UserID, w io.Writer) error {
+				gotUserID = userID
+				_, err := w.Write([]byte("PK"))
+				return err
+			},
+		}
+
+		w := performExport(mockUC)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID.String() != testUserID {
+			t.Errorf("userID = %s, want %s", gotUserID.String(), testUserID)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/zip" {
+			t.Errorf("Content-Type = %s, want application/zip", got)
+		}
+		if got := w.Header().Get("Content-Disposition"); !strings.HasPrefix(got, `attachment; filename="caltrack-export-`) {
+			t.Errorf("Content-Disposition = %s", got)
+		}
+		if w.Body.String() != "PK" {
+			t.Errorf("body = %q, want %q", w.Body.String(), "PK")
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない場合は404", func(t *testing.T) {
+		mockUC := &MockExportUsecase{
+			ExportFunc: func(ctx context.Context, userID vo.UserID, w io.Writer) error {
+				return domainErrors.ErrUserNotFound
+			},
+		}
+
+		w := performExport(mockUC)
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+
+	t.Run("異常系_書き出し前のエラーは500", func(t *testing.T) {
+		mockUC := &MockExportUsecase{
+			ExportFunc: func(ctx context.Context, userID vo.UserID, w io.Writer) error {
+				return errors.New("db error")
+			},
+		}
+
+		w := performExport(mockUC)
+
+		assertErrorCode(t, w, http.StatusInternalServerError, common.CodeInternalError)
+	})
+
+	t.Run("異常系_書き出し開始後のエラーはJSONを混ぜない", func(t *testing.T) {
+		mockUC := &MockExportUsecase{
+			ExportFunc: func(ctx context.Context, userID vo.UserID, w io.Writer) error {
+				_, _ = w.Write([]byte("PK"))
+				return errors.New("db error")
+			},
+		}
+
+		w := performExport(mockUC)
+
+		if w.Body.String() != "PK" {
+			t.Errorf("body = %q, want only archive bytes", w.Body.String())
+		}
+	})
+
+	t.Run("異常系_未認証の場合は401", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/me/export", nil)
+
+		export.NewExportHandler(&MockExportUsecase{}).Export(c)
+
+		assertErrorCode(t, w, http.StatusUnauthorized, common.CodeUnauthorized)
+	})
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+)
+
+// AdminChecker は管理者権限の確認用のインターフェース
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID vo.UserID) (bool, error)
+}
+
+// RequireAdmin は管理者以外のユーザーを拒否するミドルウェアを生成する
+// AuthMiddlewareの後に適用すること
+func RequireAdmin(checker AdminChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("userID")
+		if !exists {
+			common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Authentication required", nil)
+			c.Abort()
+			return
+		}
+
+		isAdmin, err := checker.IsAdmin(c.Request.Context(), vo.ReconstructUserID(userIDStr.(string)))
+		if err != nil && !errors.Is(err, domainErrors.ErrUserNotFound) {
+			common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+			c.Abort()
+			return
+		}
+		if !isAdmin {
+			common.RespondError(c, http.StatusForbidden, common.CodeAdminRequired, "Admin role is required", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,99 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/middleware"
+)
+
+// MockAdminChecker はAdminCheckerのモック実装
+type MockAdminChecker struct {
+	IsAdminFunc func(ctx context.Context, userID vo.UserID) (bool, error)
+}
+
+func (m *MockAdminChecker) IsAdmin(ctx context.Context, userID vo.UserID) (bool, error) {
+	if m.IsAdminFunc != nil {
+		return m.IsAdminFunc(ctx, userID)
+	}
+	return false, nil
+}
+
+// newAdminRouter はuserIDを設定した上でRequireAdminを適用したルーターを生成する
+func newAdminRouter(checker middleware.AdminChecker) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+		c.Next()
+	})
+	r.Use(middleware.RequireAdmin(checker))
+	r.GET("/admin", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return r
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Run("正常系_管理者は通過する", func(t *testing.T) {
+		checker := &MockAdminChecker{
+			IsAdminFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				if userID.String() != "550e8400-e29b-41d4-a716-446655440000" {
+					t.Errorf("userID = %s", userID.String())
+				}
+				return true, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newAdminRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("異常系_一般ユーザーは403", func(t *testing.T) {
+		checker := &MockAdminChecker{
+			IsAdminFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				return false, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newAdminRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeAdminRequired {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeAdminRequired)
+		}
+	})
+
+	t.Run("異常系_権限の取得に失敗", func(t *testing.T) {
+		checker := &MockAdminChecker{
+			IsAdminFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				return false, errors.New("db error")
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newAdminRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
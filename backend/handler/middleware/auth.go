@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -18,38 +19,123 @@ type AuthSessionValidator interface {
 	ValidateSession(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error)
 }
 
+// AccessTokenAuthenticator はパーソナルアクセストークン検証用のインターフェース
+type AccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error)
+}
+
 // AuthMiddleware は認証ミドルウェアを生成する
-func AuthMiddleware(authUsecase AuthSessionValidator) gin.HandlerFunc {
+// セッション（CookieまたはBearerトークン）で認証した場合は全てのルートにアクセスできる
+// パーソナルアクセストークンで認証した場合は、requiredScopes を全て持つときのみアクセスを許可する
+// requiredScopes を指定しないルートにはパーソナルアクセストークンではアクセスできない
+func AuthMiddleware(authUsecase AuthSessionValidator, tokenAuthenticator AccessTokenAuthenticator, requiredScopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// CookieからセッションIDを取得
-		sessionIDStr, err := c.Cookie("session_id")
+		// AuthorizationヘッダーまたはCookieからトークンを取得
+		token, source, err := common.SessionTokenFromRequest(c)
 		if err != nil {
+			common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid authorization header", nil)
+			c.Abort()
+			return
+		}
+		if source == common.SessionTokenNone {
 			common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Authentication required", nil)
 			c.Abort()
 			return
 		}
 
-		// セッションIDをVOに変換
-		sessionID, err := vo.ParseSessionID(sessionIDStr)
-		if err != nil {
-			common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid session", nil)
-			c.Abort()
+		// パーソナルアクセストークンはBearerトークンとしてのみ受け付ける
+		if source == common.SessionTokenBearer && vo.IsAccessToken(token) {
+			authenticateAccessToken(c, tokenAuthenticator, token, requiredScopes)
 			return
 		}
 
-		// セッションの有効性を検証
-		session, err := authUsecase.ValidateSession(c.Request.Context(), sessionID)
-		if err != nil {
-			handleAuthError(c, err)
+		authenticateSession(c, authUsecase, token, source)
+	}
+}
+
+// authenticateSession はセッションIDで認証し、コンテキストにユーザー情報を設定する
+func authenticateSession(c *gin.Context, authUsecase AuthSessionValidator, token string, source common.SessionTokenSource) {
+	// セッションIDをVOに変換
+	sessionID, err := vo.ParseSessionID(token)
+	if err != nil {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid session", nil)
+		c.Abort()
+		return
+	}
+
+	// セッションの有効性を検証
+	session, err := authUsecase.ValidateSession(c.Request.Context(), sessionID)
+	if err != nil {
+		handleAuthError(c, err)
+		c.Abort()
+		return
+	}
+
+	// セッションの有効期限に合わせてCookieのMaxAgeを更新
+	// Bearerトークンで認証したクライアントにはCookieを発行しない
+	if source == common.SessionTokenCookie {
+		refreshSessionCookie(c, session)
+	}
+
+	// コンテキストにユーザー情報を設定
+	c.Set("userID", session.UserID().String())
+	c.Set("sessionID", session.ID().String())
+	c.Next()
+}
+
+// authenticateAccessToken はパーソナルアクセストークンで認証し、スコープを確認してコンテキストにユーザー情報を設定する
+func authenticateAccessToken(c *gin.Context, tokenAuthenticator AccessTokenAuthenticator, token string, requiredScopes []string) {
+	accessToken, err := vo.ParseAccessToken(token)
+	if err != nil {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid access token", nil)
+		c.Abort()
+		return
+	}
+
+	pat, err := tokenAuthenticator.Authenticate(c.Request.Context(), accessToken)
+	if err != nil {
+		handleAuthError(c, err)
+		c.Abort()
+		return
+	}
+
+	// スコープ指定のないルートはセッション専用
+	if len(requiredScopes) == 0 {
+		common.RespondError(c, http.StatusForbidden, common.CodeInsufficientScope, "This endpoint cannot be accessed with a personal access token", nil)
+		c.Abort()
+		return
+	}
+	for _, scope := range requiredScopes {
+		if !pat.HasScope(vo.ReconstructTokenScope(scope)) {
+			common.RespondError(c, http.StatusForbidden, common.CodeInsufficientScope, "Access token does not have the required scope: "+scope, nil)
 			c.Abort()
 			return
 		}
+	}
+
+	// コンテキストにユーザー情報を設定（セッションIDは設定しない）
+	c.Set("userID", pat.UserID().String())
+	c.Set("personalAccessTokenID", pat.ID().String())
+	c.Next()
+}
 
-		// コンテキストにユーザー情報を設定
-		c.Set("userID", session.UserID().String())
-		c.Set("sessionID", session.ID().String())
-		c.Next()
+// refreshSessionCookie はセッションCookieのMaxAgeを残り有効期間で再設定する
+// スライド延長された有効期限をブラウザ側のCookieにも反映するため、認証のたびに設定する
+func refreshSessionCookie(c *gin.Context, session *entity.Session) {
+	maxAge := int(time.Until(session.ExpiresAt().Time()) / time.Second)
+	if maxAge <= 0 {
+		return
 	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(
+		common.SessionCookieName,
+		session.ID().String(),
+		maxAge,
+		"/",
+		"",   // domain: 空文字でリクエストドメインを使用
+		true, // secure: HTTPS必須
+		true, // httpOnly: JavaScriptからアクセス不可
+	)
 }
 
 // handleAuthError は認証エラーをHTTPレスポンスに変換する
@@ -60,12 +146,24 @@ func handleAuthError(c *gin.Context, err error) {
 		return
 	}
 
+	// アクセストークンが存在しない・有効期限切れ
+	if errors.Is(err, domainErrors.ErrInvalidAccessToken) {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid access token", nil)
+		return
+	}
+
 	// セッション期限切れ
 	if errors.Is(err, domainErrors.ErrSessionExpired) {
 		common.RespondError(c, http.StatusUnauthorized, common.CodeSessionExpired, "Session has expired", nil)
 		return
 	}
 
+	// 管理者に停止されたアカウント
+	if errors.Is(err, domainErrors.ErrAccountDisabled) {
+		common.RespondError(c, http.StatusForbidden, common.CodeAccountDisabled, "Account has been disabled", nil)
+		return
+	}
+
 	// その他のエラー
 	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
 }
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -32,6 +34,18 @@ func (m *MockAuthSessionValidator) ValidateSession(ctx context.Context, sessionI
 	return nil, nil
 }
 
+// MockAccessTokenAuthenticator はAccessTokenAuthenticatorのモック実装
+type MockAccessTokenAuthenticator struct {
+	AuthenticateFunc func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error)
+}
+
+func (m *MockAccessTokenAuthenticator) Authenticate(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+	if m.AuthenticateFunc != nil {
+		return m.AuthenticateFunc(ctx, accessToken)
+	}
+	return nil, domainErrors.ErrInvalidAccessToken
+}
+
 // createTestSession はテスト用のセッションを作成する
 func createTestSession(t *testing.T, userIDStr string) *entity.Session {
 	t.Helper()
@@ -56,7 +70,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 		// ミドルウェアを設定したルーターを作成
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			userID, _ := c.Get("userID")
 			c.JSON(http.StatusOK, gin.H{"userID": userID})
@@ -86,11 +100,220 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_CookieのMaxAgeがセッションの残り有効期間で更新される", func(t *testing.T) {
+		testSession := createTestSession(t, "550e8400-e29b-41d4-a716-446655440000")
+
+		mockValidator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				return testSession, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.AddCookie(&http.Cookie{
+			Name:  "session_id",
+			Value: testSession.ID().String(),
+		})
+
+		r.ServeHTTP(w, req)
+
+		var sessionCookie *http.Cookie
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				sessionCookie = cookie
+			}
+		}
+		if sessionCookie == nil {
+			t.Fatal("session_id cookie should be set")
+		}
+		if sessionCookie.Value != url.QueryEscape(testSession.ID().String()) {
+			t.Errorf("cookie value = %s, want %s", sessionCookie.Value, testSession.ID().String())
+		}
+		// 新規セッションの残り有効期間（約7日）
+		minMaxAge := int((time.Duration(vo.SessionDurationDays)*24*time.Hour - time.Minute) / time.Second)
+		if sessionCookie.MaxAge < minMaxAge {
+			t.Errorf("MaxAge = %d, want >= %d", sessionCookie.MaxAge, minMaxAge)
+		}
+	})
+
+	t.Run("正常系_Bearerトークンで認証成功", func(t *testing.T) {
+		testUserID := "550e8400-e29b-41d4-a716-446655440000"
+		testSession := createTestSession(t, testUserID)
+
+		var receivedSessionID vo.SessionID
+		mockValidator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				receivedSessionID = sessionID
+				return testSession, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			userID, _ := c.Get("userID")
+			c.JSON(http.StatusOK, gin.H{"userID": userID})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+testSession.ID().String())
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if !receivedSessionID.Equals(testSession.ID()) {
+			t.Errorf("sessionID = %s, want %s", receivedSessionID.String(), testSession.ID().String())
+		}
+
+		var resp map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp["userID"] != testUserID {
+			t.Errorf("userID = %s, want %s", resp["userID"], testUserID)
+		}
+
+		// Bearerトークンで認証した場合はCookieを発行しない
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "session_id" {
+				t.Error("session_id cookie should not be set for bearer authentication")
+			}
+		}
+	})
+
+	t.Run("正常系_Bearerスキームは大文字小文字を区別しない", func(t *testing.T) {
+		testSession := createTestSession(t, "550e8400-e29b-41d4-a716-446655440000")
+
+		mockValidator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				return testSession, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "bearer "+testSession.ID().String())
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+	})
+
+	t.Run("正常系_AuthorizationヘッダーがCookieより優先される", func(t *testing.T) {
+		testSession := createTestSession(t, "550e8400-e29b-41d4-a716-446655440000")
+
+		var receivedSessionID vo.SessionID
+		mockValidator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				receivedSessionID = sessionID
+				return testSession, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+testSession.ID().String())
+		req.AddCookie(&http.Cookie{
+			Name:  "session_id",
+			Value: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+		})
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if !receivedSessionID.Equals(testSession.ID()) {
+			t.Errorf("sessionID = %s, want %s", receivedSessionID.String(), testSession.ID().String())
+		}
+	})
+
+	t.Run("異常系_AuthorizationヘッダーがBearer形式でない", func(t *testing.T) {
+		called := false
+		mockValidator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				called = true
+				return nil, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+		if called {
+			t.Error("ValidateSession should not be called")
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeUnauthorized {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeUnauthorized)
+		}
+	})
+
+	t.Run("異常系_Bearerトークンが無効なセッションID", func(t *testing.T) {
+		mockValidator := &MockAuthSessionValidator{}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer invalid-session-id")
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
 	t.Run("異常系_Cookieなし", func(t *testing.T) {
 		mockValidator := &MockAuthSessionValidator{}
 
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -123,7 +346,7 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -159,7 +382,7 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -188,7 +411,7 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -225,7 +448,7 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 
 		r := gin.New()
-		r.Use(middleware.AuthMiddleware(mockValidator))
+		r.Use(middleware.AuthMiddleware(mockValidator, &MockAccessTokenAuthenticator{}))
 		r.GET("/protected", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -254,3 +477,183 @@ func TestAuthMiddleware(t *testing.T) {
 		}
 	})
 }
+
+// createTestAccessToken はテスト用のパーソナルアクセストークンとその平文を作成する
+func createTestAccessToken(t *testing.T, userIDStr string, scopes ...string) (*entity.PersonalAccessToken, vo.AccessToken) {
+	t.Helper()
+	tokenScopes, err := vo.NewTokenScopes(scopes)
+	if err != nil {
+		t.Fatalf("failed to create test scopes: %v", err)
+	}
+	token, raw, err := entity.NewPersonalAccessToken(vo.ReconstructUserID(userIDStr), "script", tokenScopes, nil)
+	if err != nil {
+		t.Fatalf("failed to create test access token: %v", err)
+	}
+	return token, raw
+}
+
+func TestAuthMiddleware_PersonalAccessToken(t *testing.T) {
+	testUserID := "550e8400-e29b-41d4-a716-446655440000"
+
+	// newRouter はスコープを指定した認証ミドルウェア付きのルーターを生成する
+	newRouter := func(authenticator *MockAccessTokenAuthenticator, scopes ...string) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(&MockAuthSessionValidator{}, authenticator, scopes...))
+		r.GET("/protected", func(c *gin.Context) {
+			userID, _ := c.Get("userID")
+			_, hasSession := c.Get("sessionID")
+			c.JSON(http.StatusOK, gin.H{"userID": userID, "hasSession": hasSession})
+		})
+		return r
+	}
+
+	t.Run("正常系_必要なスコープを持つトークンで認証成功", func(t *testing.T) {
+		token, raw := createTestAccessToken(t, testUserID, vo.TokenScopeRecordsRead)
+		authenticator := &MockAccessTokenAuthenticator{
+			AuthenticateFunc: func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+				if accessToken.String() != raw.String() {
+					t.Errorf("token = %s, want %s", accessToken.String(), raw.String())
+				}
+				return token, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+raw.String())
+		newRouter(authenticator, vo.TokenScopeRecordsRead).ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var resp struct {
+			UserID     string `json:"userID"`
+			HasSession bool   `json:"hasSession"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.UserID != testUserID {
+			t.Errorf("userID = %s, want %s", resp.UserID, testUserID)
+		}
+		if resp.HasSession {
+			t.Error("sessionID should not be set for access token authentication")
+		}
+	})
+
+	t.Run("異常系_スコープ不足", func(t *testing.T) {
+		token, raw := createTestAccessToken(t, testUserID, vo.TokenScopeRecordsRead)
+		authenticator := &MockAccessTokenAuthenticator{
+			AuthenticateFunc: func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+				return token, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+raw.String())
+		newRouter(authenticator, vo.TokenScopeRecordsWrite).ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeInsufficientScope {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeInsufficientScope)
+		}
+	})
+
+	t.Run("異常系_スコープ指定のないルートはセッション専用", func(t *testing.T) {
+		token, raw := createTestAccessToken(t, testUserID, vo.TokenScopeRecordsRead, vo.TokenScopeRecordsWrite, vo.TokenScopeProfileRead)
+		authenticator := &MockAccessTokenAuthenticator{
+			AuthenticateFunc: func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+				return token, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+raw.String())
+		newRouter(authenticator).ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("異常系_無効・期限切れのトークン", func(t *testing.T) {
+		_, raw := createTestAccessToken(t, testUserID, vo.TokenScopeRecordsRead)
+		authenticator := &MockAccessTokenAuthenticator{
+			AuthenticateFunc: func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+				return nil, domainErrors.ErrInvalidAccessToken
+			},
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+raw.String())
+		newRouter(authenticator, vo.TokenScopeRecordsRead).ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("異常系_停止されたアカウントのトークンは403", func(t *testing.T) {
+		_, raw := createTestAccessToken(t, testUserID, vo.TokenScopeRecordsRead)
+		authenticator := &MockAccessTokenAuthenticator{
+			AuthenticateFunc: func(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+				return nil, domainErrors.ErrAccountDisabled
+			},
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+raw.String())
+		newRouter(authenticator, vo.TokenScopeRecordsRead).ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("異常系_トークン形式不正", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+vo.AccessTokenPrefix+"short")
+		newRouter(&MockAccessTokenAuthenticator{}, vo.TokenScopeRecordsRead).ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("正常系_スコープ指定のあるルートでもセッションは全て許可される", func(t *testing.T) {
+		testSession := createTestSession(t, testUserID)
+		validator := &MockAuthSessionValidator{
+			ValidateSessionFunc: func(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
+				return testSession, nil
+			},
+		}
+
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(validator, &MockAccessTokenAuthenticator{}, vo.TokenScopeRecordsWrite))
+		r.GET("/protected", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+testSession.ID().String())
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+)
+
+// EmailVerificationChecker はメールアドレス確認状態の取得用のインターフェース
+type EmailVerificationChecker interface {
+	IsEmailVerified(ctx context.Context, userID vo.UserID) (bool, error)
+}
+
+// RequireVerifiedEmail はメールアドレス未確認のユーザーを拒否するミドルウェアを生成する
+// AuthMiddlewareの後に適用すること
+func RequireVerifiedEmail(checker EmailVerificationChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("userID")
+		if !exists {
+			common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Authentication required", nil)
+			c.Abort()
+			return
+		}
+
+		verified, err := checker.IsEmailVerified(c.Request.Context(), vo.ReconstructUserID(userIDStr.(string)))
+		if err != nil {
+			common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+			c.Abort()
+			return
+		}
+		if !verified {
+			common.RespondError(c, http.StatusForbidden, common.CodeEmailNotVerified, "Email address must be verified to use this feature", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/middleware"
+)
+
+// MockEmailVerificationChecker はEmailVerificationCheckerのモック実装
+type MockEmailVerificationChecker struct {
+	IsEmailVerifiedFunc func(ctx context.Context, userID vo.UserID) (bool, error)
+}
+
+func (m *MockEmailVerificationChecker) IsEmailVerified(ctx context.Context, userID vo.UserID) (bool, error) {
+	if m.IsEmailVerifiedFunc != nil {
+		return m.IsEmailVerifiedFunc(ctx, userID)
+	}
+	return false, nil
+}
+
+// newVerifiedEmailRouter はuserIDを設定した上でRequireVerifiedEmailを適用したルーターを生成する
+func newVerifiedEmailRouter(checker middleware.EmailVerificationChecker) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+		c.Next()
+	})
+	r.Use(middleware.RequireVerifiedEmail(checker))
+	r.GET("/ai", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return r
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	t.Run("正常系_確認済みのユーザーは通過する", func(t *testing.T) {
+		checker := &MockEmailVerificationChecker{
+			IsEmailVerifiedFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				return true, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newVerifiedEmailRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ai", nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("異常系_未確認のユーザーは403", func(t *testing.T) {
+		checker := &MockEmailVerificationChecker{
+			IsEmailVerifiedFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				return false, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newVerifiedEmailRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ai", nil))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeEmailNotVerified {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeEmailNotVerified)
+		}
+	})
+
+	t.Run("異常系_確認状態の取得に失敗", func(t *testing.T) {
+		checker := &MockEmailVerificationChecker{
+			IsEmailVerifiedFunc: func(ctx context.Context, userID vo.UserID) (bool, error) {
+				return false, errors.New("db error")
+			},
+		}
+
+		w := httptest.NewRecorder()
+		newVerifiedEmailRouter(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ai", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	"caltrack/usecase"
+)
+
+// RequestClientMiddleware はリクエスト元のIPアドレスとUser-Agentをコンテキストに設定するミドルウェアを生成する
+// Usecaseは監査イベントの記録時にこの情報を参照する
+func RequestClientMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := entity.SessionClient{
+			UserAgent: c.Request.UserAgent(),
+			IPAddress: c.ClientIP(),
+		}
+		c.Request = c.Request.WithContext(usecase.WithRequestClient(c.Request.Context(), client))
+		c.Next()
+	}
+}
@@ -50,6 +50,10 @@ func (h *NutritionHandler) GetAdvice(c *gin.Context) {
 			common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
 			return
 		}
+		if errors.Is(err, domainErrors.ErrAIProcessingDisabled) {
+			common.RespondError(c, http.StatusForbidden, common.CodeAIProcessingDisabled, "AI processing is disabled in your privacy settings", nil)
+			return
+		}
 		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
 		return
 	}
@@ -136,6 +136,38 @@ func TestNutritionHandler_GetAdvice(t *testing.T) {
 		}
 	})
 
+	t.Run("異常系_AI機能をオフにしている", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+		mockUsecase := &MockNutritionUsecase{
+			GetAdviceFunc: func(ctx context.Context, userID vo.UserID) (*service.NutritionAdviceOutput, error) {
+				return nil, domainErrors.ErrAIProcessingDisabled
+			},
+		}
+
+		handler := nutrition.NewNutritionHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/nutrition/advice", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetAdvice(c)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Code != common.CodeAIProcessingDisabled {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeAIProcessingDisabled)
+		}
+	})
+
 	t.Run("異常系_Usecaseエラー", func(t *testing.T) {
 		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
 
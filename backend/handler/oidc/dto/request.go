@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/usecase"
+)
+
+// CompleteSignupRequest はOpenID Connectでの新規登録を完了するリクエストDTO
+type CompleteSignupRequest struct {
+	Token         string  `json:"token" example:"q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // コールバックのリダイレクトで受け取った登録トークン
+	Nickname      string  `json:"nickname" example:"John"`
+	Weight        float64 `json:"weight" example:"70.5"`
+	Height        float64 `json:"height" example:"175.0"`
+	BirthDate     string  `json:"birthDate" example:"1990-01-15"` // "2006-01-02"形式
+	Gender        string  `json:"gender" example:"male"`
+	ActivityLevel string  `json:"activityLevel" example:"moderate"`
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// トークンの形式が不正な場合は ErrOIDCSignupTokenInvalid、生年月日の形式が不正な場合はパースエラーを返す
+// プロフィールのバリデーションエラーはまとめて返す
+func (r CompleteSignupRequest) ToDomain() (vo.OneTimeToken, usecase.OIDCSignupProfile, error, []error) {
+	token, err := vo.ParseOneTimeToken(r.Token)
+	if err != nil {
+		return vo.OneTimeToken{}, usecase.OIDCSignupProfile{}, domainErrors.ErrOIDCSignupTokenInvalid, nil
+	}
+
+	birthDateVal, err := time.Parse("2006-01-02", r.BirthDate)
+	if err != nil {
+		return vo.OneTimeToken{}, usecase.OIDCSignupProfile{}, err, nil
+	}
+
+	var errs []error
+
+	nickname, err := vo.NewNickname(r.Nickname)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	weight, err := vo.NewWeight(r.Weight)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	height, err := vo.NewHeight(r.Height)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	birthDate, err := vo.NewBirthDate(birthDateVal)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	gender, err := vo.NewGender(r.Gender)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	activityLevel, err := vo.NewActivityLevel(r.ActivityLevel)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return vo.OneTimeToken{}, usecase.OIDCSignupProfile{}, nil, errs
+	}
+
+	return token, usecase.OIDCSignupProfile{
+		Nickname:      nickname,
+		Weight:        weight,
+		Height:        height,
+		BirthDate:     birthDate,
+		Gender:        gender,
+		ActivityLevel: activityLevel,
+	}, nil, nil
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"caltrack/usecase"
+)
+
+// SignupResponse はOpenID Connectでの新規登録レスポンスDTO
+type SignupResponse struct {
+	UserID   string `json:"userId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email    string `json:"email" example:"user@example.com"`
+	Nickname string `json:"nickname" example:"John"`
+}
+
+// NewSignupResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewSignupResponse(output *usecase.LoginOutput) SignupResponse {
+	return SignupResponse{
+		UserID:   output.User.ID().String(),
+		Email:    output.User.Email().String(),
+		Nickname: output.User.Nickname().String(),
+	}
+}
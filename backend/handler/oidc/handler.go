@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/oidc/dto"
+	"caltrack/usecase"
+)
+
+// Cookie設定定数
+const (
+	// stateCookieName はコールバックで照合するstateを保持するCookie名
+	stateCookieName = "oidc_state"
+	stateCookiePath = "/api/v1/auth/oidc"
+	stateCookieAge  = int(entity.OIDCAuthRequestTTL / time.Second)
+
+	sessionCookiePath = "/"
+	// 有効期限は7日間（秒単位）
+	sessionCookieMaxAge = int(vo.SessionDurationDays * 24 * time.Hour / time.Second)
+)
+
+// OIDCUsecaseInterface はOIDCUsecaseのインターフェース
+type OIDCUsecaseInterface interface {
+	StartLogin(ctx context.Context, providerName string) (*usecase.OIDCStartOutput, error)
+	HandleCallback(ctx context.Context, providerName string, state vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error)
+	CompleteSignup(ctx context.Context, token vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error)
+}
+
+// OIDCHandler はOpenID Connectによるログイン関連のHTTPハンドラ
+type OIDCHandler struct {
+	usecase    OIDCUsecaseInterface
+	appBaseURL string
+}
+
+// NewOIDCHandler は OIDCHandler のインスタンスを生成する
+// appBaseURLはログイン完了後などにリダイレクトするフロントエンドのURL
+func NewOIDCHandler(uc OIDCUsecaseInterface, appBaseURL string) *OIDCHandler {
+	return &OIDCHandler{usecase: uc, appBaseURL: strings.TrimRight(appBaseURL, "/")}
+}
+
+// Start はOpenID Connectプロバイダーでのログインを開始する
+// @Summary 外部IDでログイン開始
+// @Description プロバイダー（google など）の認可画面へリダイレクトする。stateをCookieに保存し、コールバックで照合する
+// @Tags auth
+// @Param provider path string true "プロバイダー名" example(google)
+// @Success 302 "プロバイダーの認可画面へリダイレクト"
+// @Failure 302 "エラー時はフロントエンドのログイン画面へリダイレクト（errorクエリにエラーコード）"
+// @Router /auth/oidc/{provider}/start [get]
+func (h *OIDCHandler) Start(c *gin.Context) {
+	output, err := h.usecase.StartLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		h.redirectWithError(c, err)
+		return
+	}
+
+	h.setStateCookie(c, output.State.String(), stateCookieAge)
+	c.Redirect(http.StatusFound, output.AuthURL)
+}
+
+// Callback はOpenID Connectプロバイダーからのリダイレクトを受け取り、ログインを完了する
+// @Summary 外部IDでログインのコールバック
+// @Description 認可コードをIDトークンに交換して検証し、セッションCookieを設定してフロントエンドへリダイレクトする。2段階認証が必要な場合は /login?twoFactorToken=...、未登録の場合は /register?oidcToken=... へリダイレクトする
+// @Tags auth
+// @Param provider path string true "プロバイダー名" example(google)
+// @Param state query string true "state"
+// @Param code query string true "認可コード"
+// @Success 302 "フロントエンドへリダイレクト"
+// @Failure 302 "エラー時はフロントエンドのログイン画面へリダイレクト（errorクエリにエラーコード）"
+// @Router /auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	stateParam := c.Query("state")
+	cookieState, _ := c.Cookie(stateCookieName)
+	h.setStateCookie(c, "", -1)
+
+	// ユーザーが認可を拒否した場合など、プロバイダーがエラーを返した場合
+	if c.Query("error") != "" {
+		h.redirect(c, "/login", url.Values{"error": {common.CodeOIDCLoginFailed}})
+		return
+	}
+
+	// ログインを開始したブラウザからのリダイレクトであることを確認する
+	if stateParam == "" || subtle.ConstantTimeCompare([]byte(stateParam), []byte(cookieState)) != 1 {
+		h.redirectWithError(c, domainErrors.ErrOIDCStateInvalid)
+		return
+	}
+	state, err := vo.ParseOneTimeToken(stateParam)
+	if err != nil {
+		h.redirectWithError(c, domainErrors.ErrOIDCStateInvalid)
+		return
+	}
+
+	output, err := h.usecase.HandleCallback(c.Request.Context(), c.Param("provider"), state, c.Query("code"), sessionClient(c))
+	if err != nil {
+		h.redirectWithError(c, err)
+		return
+	}
+
+	if output.SignupRequired {
+		h.redirect(c, "/register", url.Values{
+			"oidcToken": {output.SignupToken.String()},
+			"email":     {output.SignupEmail},
+		})
+		return
+	}
+
+	if output.Login.TwoFactorRequired {
+		h.redirect(c, "/login", url.Values{"twoFactorToken": {output.Login.TwoFactorToken.String()}})
+		return
+	}
+
+	h.setSessionCookie(c, output.Login.Session.ID().String())
+	h.redirect(c, "/dashboard", nil)
+}
+
+// CompleteSignup はOpenID Connectで本人確認したユーザーの新規登録を完了する
+// @Summary 外部IDで新規登録
+// @Description コールバックで受け取った登録トークンとプロフィールでユーザーを作成し、セッションを開始する。パスワードは /users/password/set で後から設定できる
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.CompleteSignupRequest true "新規登録リクエスト"
+// @Success 201 {object} dto.SignupResponse "登録成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー・トークン不正"
+// @Failure 409 {object} common.ErrorResponse "メールアドレス重複"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/oidc/signup [post]
+func (h *OIDCHandler) CompleteSignup(c *gin.Context) {
+	var req dto.CompleteSignupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	token, profile, parseErr, validationErrs := req.ToDomain()
+	if errors.Is(parseErr, domainErrors.ErrOIDCSignupTokenInvalid) {
+		h.handleError(c, parseErr)
+		return
+	}
+	if parseErr != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeValidationError, "Invalid birth date format. Use YYYY-MM-DD", nil)
+		return
+	}
+	if validationErrs != nil {
+		common.RespondValidationError(c, common.ExtractErrorMessages(validationErrs))
+		return
+	}
+
+	output, err := h.usecase.CompleteSignup(c.Request.Context(), token, profile, sessionClient(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.setSessionCookie(c, output.Session.ID().String())
+	c.JSON(http.StatusCreated, dto.NewSignupResponse(output))
+}
+
+// sessionClient はセッション一覧で端末を識別するためのリクエスト元情報を取得する
+func sessionClient(c *gin.Context) entity.SessionClient {
+	return entity.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// setStateCookie はstateのCookieを設定する（maxAgeが負の値の場合は削除する）
+// プロバイダーからのトップレベルのリダイレクトで送信されるようSameSite=Laxにする
+func (h *OIDCHandler) setStateCookie(c *gin.Context, state string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookieName, state, maxAge, stateCookiePath, "", true, true)
+}
+
+// setSessionCookie はセッションCookieを設定する
+func (h *OIDCHandler) setSessionCookie(c *gin.Context, sessionID string) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(common.SessionCookieName, sessionID, sessionCookieMaxAge, sessionCookiePath, "", true, true)
+}
+
+// redirect はフロントエンドの指定パスへリダイレクトする
+func (h *OIDCHandler) redirect(c *gin.Context, path string, query url.Values) {
+	target := h.appBaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	c.Redirect(http.StatusFound, target)
+}
+
+// redirectWithError はドメインエラーをエラーコードに変換し、フロントエンドのログイン画面へリダイレクトする
+// ブラウザの画面遷移で呼ばれるエンドポイントのため、JSONではなくリダイレクトで返す
+func (h *OIDCHandler) redirectWithError(c *gin.Context, err error) {
+	code := common.CodeOIDCLoginFailed
+	switch {
+	case errors.Is(err, domainErrors.ErrOIDCProviderNotFound):
+		code = common.CodeOIDCProviderNotFound
+	case errors.Is(err, domainErrors.ErrOIDCStateInvalid):
+		code = common.CodeOIDCStateInvalid
+	case errors.Is(err, domainErrors.ErrOIDCEmailNotVerified):
+		code = common.CodeOIDCEmailNotVerified
+	case errors.Is(err, domainErrors.ErrEmailAlreadyExists):
+		code = common.CodeEmailAlreadyExists
+	case errors.Is(err, domainErrors.ErrAccountDisabled):
+		code = common.CodeAccountDisabled
+	case errors.Is(err, domainErrors.ErrOIDCTokenInvalid), errors.Is(err, domainErrors.ErrOIDCExchangeFailed):
+		// プロバイダー側の失敗はログにのみ残す
+	default:
+		common.LogError("redirectWithError", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	}
+	h.redirect(c, "/login", url.Values{"error": {code}})
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *OIDCHandler) handleError(c *gin.Context, err error) {
+	// 登録トークンが不正・期限切れ
+	if errors.Is(err, domainErrors.ErrOIDCSignupTokenInvalid) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidToken, "Signup token is invalid or expired", nil)
+		return
+	}
+
+	// 登録待ちの間に同じメールアドレスで登録された
+	if errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
+		common.RespondError(c, http.StatusConflict, common.CodeEmailAlreadyExists, "Email already exists", nil)
+		return
+	}
+
+	// 500エラー
+	common.LogError("handleError", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", nil)
+}
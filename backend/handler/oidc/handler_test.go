@@ -0,0 +1,373 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/oidc"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testAppBaseURL = "http://localhost:5173"
+
+// MockOIDCUsecase はOIDCUsecaseのモック実装
+type MockOIDCUsecase struct {
+	StartLoginFunc     func(ctx context.Context, providerName string) (*usecase.OIDCStartOutput, error)
+	HandleCallbackFunc func(ctx context.Context, providerName string, state vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error)
+	CompleteSignupFunc func(ctx context.Context, token vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error)
+}
+
+func (m *MockOIDCUsecase) StartLogin(ctx context.Context, providerName string) (*usecase.OIDCStartOutput, error) {
+	if m.StartLoginFunc != nil {
+		return m.StartLoginFunc(ctx, providerName)
+	}
+	return nil, nil
+}
+
+func (m *MockOIDCUsecase) HandleCallback(ctx context.Context, providerName string, state vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+	if m.HandleCallbackFunc != nil {
+		return m.HandleCallbackFunc(ctx, providerName, state, code, client)
+	}
+	return nil, nil
+}
+
+func (m *MockOIDCUsecase) CompleteSignup(ctx context.Context, token vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error) {
+	if m.CompleteSignupFunc != nil {
+		return m.CompleteSignupFunc(ctx, token, profile, client)
+	}
+	return nil, nil
+}
+
+// performRequest はハンドラにリクエストを渡して結果を返す
+func performRequest(handlerFunc gin.HandlerFunc, method, target, body string, cookies []*http.Cookie, params ...gin.Param) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	for _, cookie := range cookies {
+		c.Request.AddCookie(cookie)
+	}
+	c.Params = params
+	handlerFunc(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+// assertRedirect はリダイレクト先のパスとクエリを検証する
+func assertRedirect(t *testing.T, w *httptest.ResponseRecorder, wantPath string, wantQuery map[string]string) {
+	t.Helper()
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid location: %v", err)
+	}
+	if got := location.Scheme + "://" + location.Host + location.Path; got != testAppBaseURL+wantPath {
+		t.Errorf("redirect = %s, want %s", got, testAppBaseURL+wantPath)
+	}
+	for key, want := range wantQuery {
+		if got := location.Query().Get(key); got != want {
+			t.Errorf("query %s = %s, want %s", key, got, want)
+		}
+	}
+}
+
+// findCookie はレスポンスから指定名のCookieを探す（値はURLデコードして返す）
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			if value, err := url.QueryUnescape(cookie.Value); err == nil {
+				cookie.Value = value
+			}
+			return cookie
+		}
+	}
+	return nil
+}
+
+func newOneTimeToken(t *testing.T) vo.OneTimeToken {
+	t.Helper()
+	token, err := vo.NewOneTimeToken()
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	return token
+}
+
+func validLoginOutput(t *testing.T) *usecase.LoginOutput {
+	t.Helper()
+	user, errs := entity.NewUser("test@example.com", "password123", "testuser", 70.5, 175.0, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "male", "moderate")
+	if errs != nil {
+		t.Fatalf("failed to create user: %v", errs)
+	}
+	session, err := entity.NewSessionWithUserID(user.ID())
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	return &usecase.LoginOutput{Session: session, User: user}
+}
+
+func TestOIDCHandler_Start(t *testing.T) {
+	t.Run("正常系_stateをCookieに保存してプロバイダーへリダイレクトする", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		var gotProvider string
+		mockUC := &MockOIDCUsecase{
+			StartLoginFunc: func(ctx context.Context, providerName string) (*usecase.OIDCStartOutput, error) {
+				gotProvider = providerName
+				return &usecase.OIDCStartOutput{AuthURL: "https://accounts.example.com/auth?state=" + state.String(), State: state}, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Start, http.MethodGet, "/api/v1/auth/oidc/google/start", "", nil, gin.Param{Key: "provider", Value: "google"})
+
+		if w.Code != http.StatusFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+		}
+		if gotProvider != "google" {
+			t.Errorf("provider = %s, want google", gotProvider)
+		}
+		if !strings.HasPrefix(w.Header().Get("Location"), "https://accounts.example.com/auth") {
+			t.Errorf("Location = %s", w.Header().Get("Location"))
+		}
+		cookie := findCookie(w, "oidc_state")
+		if cookie == nil || cookie.Value != state.String() {
+			t.Fatal("state cookie should be set")
+		}
+		if !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+			t.Error("state cookie should be HttpOnly, Secure and SameSite=Lax")
+		}
+	})
+
+	t.Run("異常系_未設定のプロバイダーはログイン画面へエラー付きでリダイレクトする", func(t *testing.T) {
+		mockUC := &MockOIDCUsecase{
+			StartLoginFunc: func(ctx context.Context, providerName string) (*usecase.OIDCStartOutput, error) {
+				return nil, domainErrors.ErrOIDCProviderNotFound
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Start, http.MethodGet, "/api/v1/auth/oidc/unknown/start", "", nil, gin.Param{Key: "provider", Value: "unknown"})
+
+		assertRedirect(t, w, "/login", map[string]string{"error": common.CodeOIDCProviderNotFound})
+	})
+}
+
+func TestOIDCHandler_Callback(t *testing.T) {
+	callbackTarget := func(state vo.OneTimeToken) string {
+		return "/api/v1/auth/oidc/google/callback?state=" + state.String() + "&code=code-1"
+	}
+	stateCookie := func(state vo.OneTimeToken) []*http.Cookie {
+		return []*http.Cookie{{Name: "oidc_state", Value: state.String()}}
+	}
+	providerParam := gin.Param{Key: "provider", Value: "google"}
+
+	t.Run("正常系_セッションCookieを設定してダッシュボードへリダイレクトする", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		login := validLoginOutput(t)
+		var gotState vo.OneTimeToken
+		var gotCode string
+		mockUC := &MockOIDCUsecase{
+			HandleCallbackFunc: func(ctx context.Context, providerName string, s vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+				gotState, gotCode = s, code
+				return &usecase.OIDCCallbackOutput{Login: login}, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, callbackTarget(state), "", stateCookie(state), providerParam)
+
+		assertRedirect(t, w, "/dashboard", nil)
+		if gotState.String() != state.String() || gotCode != "code-1" {
+			t.Error("state and code should be passed to the usecase")
+		}
+		cookie := findCookie(w, common.SessionCookieName)
+		if cookie == nil || cookie.Value != login.Session.ID().String() {
+			t.Error("session cookie should be set")
+		}
+		if stateCookie := findCookie(w, "oidc_state"); stateCookie == nil || stateCookie.MaxAge >= 0 {
+			t.Error("state cookie should be cleared")
+		}
+	})
+
+	t.Run("正常系_2段階認証が必要な場合はトークン付きでログイン画面へリダイレクトする", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		twoFactorToken := newOneTimeToken(t)
+		mockUC := &MockOIDCUsecase{
+			HandleCallbackFunc: func(ctx context.Context, providerName string, s vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+				return &usecase.OIDCCallbackOutput{Login: &usecase.LoginOutput{TwoFactorRequired: true, TwoFactorToken: twoFactorToken}}, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, callbackTarget(state), "", stateCookie(state), providerParam)
+
+		assertRedirect(t, w, "/login", map[string]string{"twoFactorToken": twoFactorToken.String()})
+		if findCookie(w, common.SessionCookieName) != nil {
+			t.Error("session cookie should not be set")
+		}
+	})
+
+	t.Run("正常系_未登録の場合は登録トークン付きで登録画面へリダイレクトする", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		signupToken := newOneTimeToken(t)
+		mockUC := &MockOIDCUsecase{
+			HandleCallbackFunc: func(ctx context.Context, providerName string, s vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+				return &usecase.OIDCCallbackOutput{SignupRequired: true, SignupToken: signupToken, SignupEmail: "new@example.com"}, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, callbackTarget(state), "", stateCookie(state), providerParam)
+
+		assertRedirect(t, w, "/register", map[string]string{"oidcToken": signupToken.String(), "email": "new@example.com"})
+	})
+
+	t.Run("異常系_Cookieのstateと一致しない場合はUsecaseを呼ばない", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		called := false
+		mockUC := &MockOIDCUsecase{
+			HandleCallbackFunc: func(ctx context.Context, providerName string, s vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+				called = true
+				return nil, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, callbackTarget(state), "", stateCookie(newOneTimeToken(t)), providerParam)
+
+		assertRedirect(t, w, "/login", map[string]string{"error": common.CodeOIDCStateInvalid})
+		if called {
+			t.Error("usecase should not be called")
+		}
+	})
+
+	t.Run("異常系_プロバイダーがエラーを返した場合", func(t *testing.T) {
+		h := oidc.NewOIDCHandler(&MockOIDCUsecase{}, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, "/api/v1/auth/oidc/google/callback?error=access_denied", "", nil, providerParam)
+
+		assertRedirect(t, w, "/login", map[string]string{"error": common.CodeOIDCLoginFailed})
+	})
+
+	t.Run("異常系_メールアドレスが未確認", func(t *testing.T) {
+		state := newOneTimeToken(t)
+		mockUC := &MockOIDCUsecase{
+			HandleCallbackFunc: func(ctx context.Context, providerName string, s vo.OneTimeToken, code string, client entity.SessionClient) (*usecase.OIDCCallbackOutput, error) {
+				return nil, domainErrors.ErrOIDCEmailNotVerified
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.Callback, http.MethodGet, callbackTarget(state), "", stateCookie(state), providerParam)
+
+		assertRedirect(t, w, "/login", map[string]string{"error": common.CodeOIDCEmailNotVerified})
+	})
+}
+
+func TestOIDCHandler_CompleteSignup(t *testing.T) {
+	validBody := func(token string) string {
+		return `{"token":"` + token + `","nickname":"oidcuser","weight":60,"height":165,"birthDate":"1995-05-05","gender":"female","activityLevel":"moderate"}`
+	}
+
+	t.Run("正常系_登録してセッションCookieを設定する", func(t *testing.T) {
+		token := newOneTimeToken(t)
+		login := validLoginOutput(t)
+		var gotToken vo.OneTimeToken
+		var gotProfile usecase.OIDCSignupProfile
+		mockUC := &MockOIDCUsecase{
+			CompleteSignupFunc: func(ctx context.Context, tk vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				gotToken, gotProfile = tk, profile
+				return login, nil
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.CompleteSignup, http.MethodPost, "/api/v1/auth/oidc/signup", validBody(token.String()), nil)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusCreated, w.Body.String())
+		}
+		if gotToken.String() != token.String() || gotProfile.Nickname.String() != "oidcuser" {
+			t.Error("token and profile should be passed to the usecase")
+		}
+		if cookie := findCookie(w, common.SessionCookieName); cookie == nil || cookie.Value != login.Session.ID().String() {
+			t.Error("session cookie should be set")
+		}
+	})
+
+	t.Run("異常系_トークンの形式が不正", func(t *testing.T) {
+		h := oidc.NewOIDCHandler(&MockOIDCUsecase{}, testAppBaseURL)
+
+		w := performRequest(h.CompleteSignup, http.MethodPost, "/api/v1/auth/oidc/signup", validBody("invalid"), nil)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidToken)
+	})
+
+	t.Run("異常系_プロフィールのバリデーションエラー", func(t *testing.T) {
+		token := newOneTimeToken(t)
+		h := oidc.NewOIDCHandler(&MockOIDCUsecase{}, testAppBaseURL)
+		body := `{"token":"` + token.String() + `","nickname":"","weight":-1,"height":165,"birthDate":"1995-05-05","gender":"female","activityLevel":"moderate"}`
+
+		w := performRequest(h.CompleteSignup, http.MethodPost, "/api/v1/auth/oidc/signup", body, nil)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_トークンが期限切れ", func(t *testing.T) {
+		mockUC := &MockOIDCUsecase{
+			CompleteSignupFunc: func(ctx context.Context, tk vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrOIDCSignupTokenInvalid
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.CompleteSignup, http.MethodPost, "/api/v1/auth/oidc/signup", validBody(newOneTimeToken(t).String()), nil)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidToken)
+	})
+
+	t.Run("異常系_メールアドレス重複", func(t *testing.T) {
+		mockUC := &MockOIDCUsecase{
+			CompleteSignupFunc: func(ctx context.Context, tk vo.OneTimeToken, profile usecase.OIDCSignupProfile, client entity.SessionClient) (*usecase.LoginOutput, error) {
+				return nil, domainErrors.ErrEmailAlreadyExists
+			},
+		}
+		h := oidc.NewOIDCHandler(mockUC, testAppBaseURL)
+
+		w := performRequest(h.CompleteSignup, http.MethodPost, "/api/v1/auth/oidc/signup", validBody(newOneTimeToken(t).String()), nil)
+
+		assertErrorCode(t, w, http.StatusConflict, common.CodeEmailAlreadyExists)
+	})
+}
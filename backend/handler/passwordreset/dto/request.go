@@ -0,0 +1,38 @@
+package dto
+
+import (
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// RequestPasswordResetRequest はパスワードリセット要求のリクエストDTO
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" example:"user@example.com"`
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+func (r RequestPasswordResetRequest) ToDomain() (vo.Email, error) {
+	return vo.NewEmail(r.Email)
+}
+
+// ConfirmPasswordResetRequest はパスワードリセット確定のリクエストDTO
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token" example:"q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // メールのリンクに含まれるトークン
+	NewPassword string `json:"newPassword" example:"newpassword456"`
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// トークンの形式が不正な場合は ErrPasswordResetTokenInvalid を返す
+func (r ConfirmPasswordResetRequest) ToDomain() (vo.OneTimeToken, vo.Password, error) {
+	token, err := vo.ParseOneTimeToken(r.Token)
+	if err != nil {
+		return vo.OneTimeToken{}, vo.Password{}, domainErrors.ErrPasswordResetTokenInvalid
+	}
+
+	newPassword, err := vo.NewPassword(r.NewPassword)
+	if err != nil {
+		return vo.OneTimeToken{}, vo.Password{}, err
+	}
+
+	return token, newPassword, nil
+}
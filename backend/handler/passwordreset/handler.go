@@ -0,0 +1,109 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/passwordreset/dto"
+)
+
+// PasswordResetUsecaseInterface はPasswordResetUsecaseのインターフェース
+type PasswordResetUsecaseInterface interface {
+	RequestReset(ctx context.Context, email vo.Email) error
+	ConfirmReset(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error
+}
+
+// PasswordResetHandler はパスワードリセット関連のHTTPハンドラ
+type PasswordResetHandler struct {
+	usecase PasswordResetUsecaseInterface
+}
+
+// NewPasswordResetHandler は PasswordResetHandler のインスタンスを生成する
+func NewPasswordResetHandler(uc PasswordResetUsecaseInterface) *PasswordResetHandler {
+	return &PasswordResetHandler{usecase: uc}
+}
+
+// Request はパスワードリセットを要求する
+// @Summary パスワードリセット要求
+// @Description 登録済みのメールアドレスにパスワード再設定用のリンクを送信する。登録有無にかかわらず同じレスポンスを返す
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RequestPasswordResetRequest true "パスワードリセット要求"
+// @Success 200 {object} map[string]string "受付完了"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/password-reset/request [post]
+func (h *PasswordResetHandler) Request(c *gin.Context) {
+	var req dto.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	email, err := req.ToDomain()
+	if err != nil {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	if err := h.usecase.RequestReset(c.Request.Context(), email); err != nil {
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+// Confirm はリセットトークンを使って新しいパスワードを設定する
+// @Summary パスワードリセット確定
+// @Description メールで受け取ったトークンを検証して新しいパスワードを設定する。全端末のセッションは無効化される
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ConfirmPasswordResetRequest true "パスワードリセット確定"
+// @Success 200 {object} map[string]string "再設定成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー・トークン不正"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/password-reset/confirm [post]
+func (h *PasswordResetHandler) Confirm(c *gin.Context) {
+	var req dto.ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	token, newPassword, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.ConfirmReset(c.Request.Context(), token, newPassword); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset successfully"})
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *PasswordResetHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrPasswordResetTokenInvalid) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidToken, "Password reset token is invalid or expired", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrPasswordRequired) || errors.Is(err, domainErrors.ErrPasswordTooShort) {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+}
@@ -0,0 +1,152 @@
+package passwordreset_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/passwordreset"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// MockPasswordResetUsecase はPasswordResetUsecaseのモック実装
+type MockPasswordResetUsecase struct {
+	RequestResetFunc func(ctx context.Context, email vo.Email) error
+	ConfirmResetFunc func(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error
+}
+
+func (m *MockPasswordResetUsecase) RequestReset(ctx context.Context, email vo.Email) error {
+	if m.RequestResetFunc != nil {
+		return m.RequestResetFunc(ctx, email)
+	}
+	return nil
+}
+
+func (m *MockPasswordResetUsecase) ConfirmReset(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error {
+	if m.ConfirmResetFunc != nil {
+		return m.ConfirmResetFunc(ctx, token, newPassword)
+	}
+	return nil
+}
+
+// performRequest はハンドラにJSONリクエストを渡して結果を返す
+func performRequest(handlerFunc gin.HandlerFunc, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handlerFunc(c)
+	return w
+}
+
+func TestPasswordResetHandler_Request(t *testing.T) {
+	t.Run("正常系_リセット要求を受け付ける", func(t *testing.T) {
+		var requestedEmail string
+		mockUC := &MockPasswordResetUsecase{
+			RequestResetFunc: func(ctx context.Context, email vo.Email) error {
+				requestedEmail = email.String()
+				return nil
+			},
+		}
+		handler := passwordreset.NewPasswordResetHandler(mockUC)
+
+		w := performRequest(handler.Request, "/api/v1/auth/password-reset/request", `{"email": "test@example.com"}`)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if requestedEmail != "test@example.com" {
+			t.Errorf("email = %s, want test@example.com", requestedEmail)
+		}
+	})
+
+	t.Run("異常系_メールアドレス形式不正", func(t *testing.T) {
+		handler := passwordreset.NewPasswordResetHandler(&MockPasswordResetUsecase{})
+
+		w := performRequest(handler.Request, "/api/v1/auth/password-reset/request", `{"email": "invalid"}`)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestPasswordResetHandler_Confirm(t *testing.T) {
+	validToken, _ := vo.NewOneTimeToken()
+
+	t.Run("正常系_パスワードが再設定される", func(t *testing.T) {
+		called := false
+		mockUC := &MockPasswordResetUsecase{
+			ConfirmResetFunc: func(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error {
+				called = true
+				if token.Hash() != validToken.Hash() {
+					t.Error("token should be passed to usecase")
+				}
+				return nil
+			},
+		}
+		handler := passwordreset.NewPasswordResetHandler(mockUC)
+
+		body := `{"token": "` + validToken.String() + `", "newPassword": "newpassword456"}`
+		w := performRequest(handler.Confirm, "/api/v1/auth/password-reset/confirm", body)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if !called {
+			t.Error("ConfirmReset should be called")
+		}
+	})
+
+	t.Run("異常系_トークン無効", func(t *testing.T) {
+		mockUC := &MockPasswordResetUsecase{
+			ConfirmResetFunc: func(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error {
+				return domainErrors.ErrPasswordResetTokenInvalid
+			},
+		}
+		handler := passwordreset.NewPasswordResetHandler(mockUC)
+
+		body := `{"token": "` + validToken.String() + `", "newPassword": "newpassword456"}`
+		w := performRequest(handler.Confirm, "/api/v1/auth/password-reset/confirm", body)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeInvalidToken {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeInvalidToken)
+		}
+	})
+
+	t.Run("異常系_新しいパスワードが短すぎる", func(t *testing.T) {
+		handler := passwordreset.NewPasswordResetHandler(&MockPasswordResetUsecase{})
+
+		body := `{"token": "` + validToken.String() + `", "newPassword": "short"}`
+		w := performRequest(handler.Confirm, "/api/v1/auth/password-reset/confirm", body)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Code != common.CodeValidationError {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeValidationError)
+		}
+	})
+}
@@ -60,3 +60,13 @@ type GetStatisticsRequest struct {
 func (r GetStatisticsRequest) ToDomain() (vo.StatisticsPeriod, error) {
 	return vo.NewStatisticsPeriod(r.Period)
 }
+
+// GetCalendarRequest はカレンダーデータ取得リクエストDTO
+type GetCalendarRequest struct {
+	Month string `form:"month"` // クエリパラメータ: YYYY-MM
+}
+
+// ToDomain はリクエストを CalendarMonth VOに変換する
+func (r GetCalendarRequest) ToDomain() (vo.CalendarMonth, error) {
+	return vo.NewCalendarMonth(r.Month)
+}
@@ -9,8 +9,9 @@ import (
 
 // DailyStatisticsResponse は日別統計データレスポンスDTO
 type DailyStatisticsResponse struct {
-	Date          string `json:"date"`          // YYYY-MM-DD
-	TotalCalories int    `json:"totalCalories"` // その日の合計カロリー
+	Date           string `json:"date"`           // YYYY-MM-DD
+	TotalCalories  int    `json:"totalCalories"`  // その日の合計カロリー
+	TargetCalories int    `json:"targetCalories"` // その日に有効だった目標カロリー
 }
 
 // StatisticsResponse は統計データレスポンスDTO
@@ -29,8 +30,9 @@ func NewStatisticsResponse(output *usecase.StatisticsOutput) StatisticsResponse
 	dailyStats := make([]DailyStatisticsResponse, len(output.DailyStatistics))
 	for i, daily := range output.DailyStatistics {
 		dailyStats[i] = DailyStatisticsResponse{
-			Date:          daily.Date.Time().Format("2006-01-02"),
-			TotalCalories: daily.TotalCalories.Value(),
+			Date:           daily.Date.Time().Format("2006-01-02"),
+			TotalCalories:  daily.TotalCalories.Value(),
+			TargetCalories: daily.TargetCalories.Value(),
 		}
 	}
 
@@ -45,6 +47,44 @@ func NewStatisticsResponse(output *usecase.StatisticsOutput) StatisticsResponse
 	}
 }
 
+// CalendarDayResponse はカレンダーの1日分のレスポンスDTO
+type CalendarDayResponse struct {
+	Date           string `json:"date"`           // YYYY-MM-DD
+	TotalCalories  int    `json:"totalCalories"`  // その日の合計カロリー
+	TargetCalories int    `json:"targetCalories"` // その日に有効だった目標カロリー
+	IsAchieved     bool   `json:"isAchieved"`     // 達成フラグ（80%〜100%）
+	IsOver         bool   `json:"isOver"`         // 超過フラグ（100%超）
+	RecordCount    int    `json:"recordCount"`    // 記録件数
+	HasAdvice      bool   `json:"hasAdvice"`      // AIアドバイスの有無
+}
+
+// CalendarResponse は月間カレンダーのレスポンスDTO
+type CalendarResponse struct {
+	Month string                `json:"month"` // YYYY-MM
+	Days  []CalendarDayResponse `json:"days"`  // 月の全日分のデータ
+}
+
+// NewCalendarResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewCalendarResponse(output *usecase.CalendarOutput) CalendarResponse {
+	days := make([]CalendarDayResponse, len(output.Days))
+	for i, day := range output.Days {
+		days[i] = CalendarDayResponse{
+			Date:           day.Date.Format("2006-01-02"),
+			TotalCalories:  day.TotalCalories.Value(),
+			TargetCalories: day.TargetCalories.Value(),
+			IsAchieved:     day.IsAchieved,
+			IsOver:         day.IsOver,
+			RecordCount:    day.RecordCount,
+			HasAdvice:      day.HasAdvice,
+		}
+	}
+
+	return CalendarResponse{
+		Month: output.Month.String(),
+		Days:  days,
+	}
+}
+
 // CreateRecordResponse はカロリー記録作成レスポンスDTO
 type CreateRecordResponse struct {
 	RecordID      string               `json:"recordId"`
@@ -81,11 +121,14 @@ func NewCreateRecordResponse(record *entity.Record) CreateRecordResponse {
 
 // TodayCaloriesResponse は今日の摂取カロリーレスポンスDTO
 type TodayCaloriesResponse struct {
-	Date           string           `json:"date"`
-	TotalCalories  int              `json:"totalCalories"`
-	TargetCalories int              `json:"targetCalories"`
-	Difference     int              `json:"difference"`
-	Records        []RecordResponse `json:"records"`
+	Date                 string           `json:"date"`
+	TotalCalories        int              `json:"totalCalories"`
+	TargetCalories       int              `json:"targetCalories"`
+	Difference           int              `json:"difference"`
+	Records              []RecordResponse `json:"records"`
+	BudgetMode           string           `json:"budgetMode"`
+	WeeklyTargetCalories int              `json:"weeklyTargetCalories,omitempty"`
+	WeeklyTotalCalories  int              `json:"weeklyTotalCalories,omitempty"`
 }
 
 // RecordResponse は記録レスポンスDTO
@@ -115,10 +158,13 @@ func NewTodayCaloriesResponse(output *usecase.TodayCaloriesOutput) TodayCalories
 	}
 
 	return TodayCaloriesResponse{
-		Date:           output.Date.Format("2006-01-02"),
-		TotalCalories:  output.TotalCalories,
-		TargetCalories: output.TargetCalories,
-		Difference:     output.Difference,
-		Records:        records,
+		Date:                 output.Date.Format("2006-01-02"),
+		TotalCalories:        output.TotalCalories,
+		TargetCalories:       output.TargetCalories,
+		Difference:           output.Difference,
+		Records:              records,
+		BudgetMode:           output.BudgetMode.String(),
+		WeeklyTargetCalories: output.WeeklyTargetCalories,
+		WeeklyTotalCalories:  output.WeeklyTotalCalories,
 	}
 }
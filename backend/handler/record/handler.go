@@ -20,6 +20,7 @@ type RecordUsecaseInterface interface {
 	Create(ctx context.Context, record *entity.Record) error
 	GetTodayCalories(ctx context.Context, userID vo.UserID) (*usecase.TodayCaloriesOutput, error)
 	GetStatistics(ctx context.Context, userID vo.UserID, period vo.StatisticsPeriod) (*usecase.StatisticsOutput, error)
+	GetCalendar(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error)
 }
 
 // RecordHandler はカロリー記録関連のHTTPハンドラ
@@ -79,6 +80,10 @@ func (h *RecordHandler) Create(c *gin.Context) {
 
 	// Usecase実行
 	if err := h.usecase.Create(c.Request.Context(), record); err != nil {
+		if errors.Is(err, domainErrors.ErrEatenAtBeforeRetention) {
+			common.RespondValidationError(c, []string{err.Error()})
+			return
+		}
 		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
 		return
 	}
@@ -179,3 +184,55 @@ func (h *RecordHandler) GetStatistics(c *gin.Context) {
 	// 成功レスポンス
 	c.JSON(http.StatusOK, dto.NewStatisticsResponse(output))
 }
+
+// GetCalendar は月間カレンダーデータを取得する
+// @Summary 月間カレンダー取得
+// @Description 指定月の全日分の摂取カロリー・目標・達成状況・記録件数・アドバイス有無を取得する
+// @Tags records
+// @Produce json
+// @Param month query string false "対象月（YYYY-MM）。未指定の場合は今月"
+// @Success 200 {object} dto.CalendarResponse "取得成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが見つからない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /calendar [get]
+func (h *RecordHandler) GetCalendar(c *gin.Context) {
+	// コンテキストからユーザーIDを取得
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	// クエリパラメータのバインド
+	var req dto.GetCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid query parameters", nil)
+		return
+	}
+
+	// リクエストをVOに変換
+	month, err := req.ToDomain()
+	if err != nil {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	// UserID VOに変換
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	// Usecase実行
+	output, err := h.usecase.GetCalendar(c.Request.Context(), userID, month)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+			return
+		}
+		common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+		return
+	}
+
+	// 成功レスポンス
+	c.JSON(http.StatusOK, dto.NewCalendarResponse(output))
+}
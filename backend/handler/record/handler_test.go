@@ -30,6 +30,7 @@ type MockRecordUsecase struct {
 	CreateFunc           func(ctx context.Context, record *entity.Record) error
 	GetTodayCaloriesFunc func(ctx context.Context, userID vo.UserID) (*usecase.TodayCaloriesOutput, error)
 	GetStatisticsFunc    func(ctx context.Context, userID vo.UserID, period vo.StatisticsPeriod) (*usecase.StatisticsOutput, error)
+	GetCalendarFunc      func(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error)
 }
 
 func (m *MockRecordUsecase) Create(ctx context.Context, rec *entity.Record) error {
@@ -53,6 +54,13 @@ func (m *MockRecordUsecase) GetStatistics(ctx context.Context, userID vo.UserID,
 	return nil, nil
 }
 
+func (m *MockRecordUsecase) GetCalendar(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error) {
+	if m.GetCalendarFunc != nil {
+		return m.GetCalendarFunc(ctx, userID, month)
+	}
+	return nil, nil
+}
+
 func TestRecordHandler_Create(t *testing.T) {
 	t.Run("正常系_記録が作成される", func(t *testing.T) {
 		mockUsecase := &MockRecordUsecase{
@@ -322,6 +330,42 @@ func TestRecordHandler_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("異常系_保存期間より前の日時", func(t *testing.T) {
+		mockUsecase := &MockRecordUsecase{
+			CreateFunc: func(ctx context.Context, rec *entity.Record) error {
+				return domainErrors.ErrEatenAtBeforeRetention
+			},
+		}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		eatenAt := time.Now().AddDate(-3, 0, 0).Format(time.RFC3339)
+		reqBody := `{
+		"eatenAt": "` + eatenAt + `",
+		"items": [{"name": "ご飯", "calories": 250}]
+	}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/records", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+
+		handler.Create(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Code != common.CodeValidationError {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeValidationError)
+		}
+	})
+
 	t.Run("異常系_DB保存失敗", func(t *testing.T) {
 		mockUsecase := &MockRecordUsecase{
 			CreateFunc: func(ctx context.Context, rec *entity.Record) error {
@@ -956,3 +1000,156 @@ func TestRecordHandler_GetStatistics(t *testing.T) {
 
 // domainErrorsのダミー参照（importエラー回避）
 var _ = domainErrors.ErrUserNotFound
+
+func TestRecordHandler_GetCalendar(t *testing.T) {
+	t.Run("正常系_月間カレンダーが取得できる", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+		var gotMonth vo.CalendarMonth
+		mockUsecase := &MockRecordUsecase{
+			GetCalendarFunc: func(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error) {
+				gotMonth = month
+				return &usecase.CalendarOutput{
+					Month: month,
+					Days: []usecase.CalendarDay{
+						{
+							Date:           month.Start(),
+							TotalCalories:  vo.ReconstructCalories(1800),
+							TargetCalories: vo.ReconstructCalories(2000),
+							IsAchieved:     true,
+							RecordCount:    3,
+							HasAdvice:      true,
+						},
+						{
+							Date:           month.Start().AddDate(0, 0, 1),
+							TotalCalories:  vo.ZeroCalories(),
+							TargetCalories: vo.ReconstructCalories(2000),
+						},
+					},
+				}, nil
+			},
+		}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/calendar?month=2025-02", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetCalendar(c)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotMonth.String() != "2025-02" {
+			t.Errorf("month = %s, want 2025-02", gotMonth.String())
+		}
+
+		var resp dto.CalendarResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Month != "2025-02" {
+			t.Errorf("month = %s, want 2025-02", resp.Month)
+		}
+		if len(resp.Days) != 2 {
+			t.Fatalf("len(days) = %d, want 2", len(resp.Days))
+		}
+		if resp.Days[0].Date != "2025-02-01" {
+			t.Errorf("days[0].date = %s, want 2025-02-01", resp.Days[0].Date)
+		}
+		if !resp.Days[0].IsAchieved || resp.Days[0].RecordCount != 3 || !resp.Days[0].HasAdvice {
+			t.Errorf("days[0] = %+v, want achieved with 3 records and advice", resp.Days[0])
+		}
+		if resp.Days[1].TotalCalories != 0 || resp.Days[1].RecordCount != 0 {
+			t.Errorf("days[1] = %+v, want empty day", resp.Days[1])
+		}
+	})
+
+	t.Run("異常系_認証なし", func(t *testing.T) {
+		mockUsecase := &MockRecordUsecase{}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/calendar?month=2025-02", nil)
+
+		handler.GetCalendar(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("異常系_無効な月パラメータ", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+		mockUsecase := &MockRecordUsecase{}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/calendar?month=2025-13", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetCalendar(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+
+		var resp common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Code != common.CodeValidationError {
+			t.Errorf("code = %s, want %s", resp.Code, common.CodeValidationError)
+		}
+	})
+
+	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+		mockUsecase := &MockRecordUsecase{
+			GetCalendarFunc: func(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error) {
+				return nil, domainErrors.ErrUserNotFound
+			},
+		}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/calendar?month=2025-02", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetCalendar(c)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("異常系_Usecaseエラー", func(t *testing.T) {
+		userIDStr := "550e8400-e29b-41d4-a716-446655440000"
+
+		mockUsecase := &MockRecordUsecase{
+			GetCalendarFunc: func(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*usecase.CalendarOutput, error) {
+				return nil, errors.New("database connection error")
+			},
+		}
+		handler := record.NewRecordHandler(mockUsecase)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/calendar?month=2025-02", nil)
+		c.Set("userID", userIDStr)
+
+		handler.GetCalendar(c)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"caltrack/usecase"
+)
+
+// ImportRecordsRequest は食事履歴インポートリクエストDTO（multipart/form-data）
+// presetを指定した場合はその列の対応を使い、個別に指定した列名で上書きする
+type ImportRecordsRequest struct {
+	Preset         string `form:"preset"`
+	DateColumn     string `form:"dateColumn"`
+	TimeColumn     string `form:"timeColumn"`
+	MealColumn     string `form:"mealColumn"`
+	NameColumn     string `form:"nameColumn"`
+	CaloriesColumn string `form:"caloriesColumn"`
+	DryRun         bool   `form:"dryRun"`
+}
+
+// ToMapping はリクエストを列の対応に変換する
+func (r ImportRecordsRequest) ToMapping() (usecase.RecordImportMapping, error) {
+	var mapping usecase.RecordImportMapping
+	if r.Preset != "" {
+		preset, err := usecase.RecordImportPreset(r.Preset)
+		if err != nil {
+			return usecase.RecordImportMapping{}, err
+		}
+		mapping = preset
+	}
+
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&mapping.Date, r.DateColumn)
+	override(&mapping.Time, r.TimeColumn)
+	override(&mapping.Meal, r.MealColumn)
+	override(&mapping.Name, r.NameColumn)
+	override(&mapping.Calories, r.CaloriesColumn)
+	return mapping, nil
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"caltrack/usecase"
+)
+
+// ImportRowErrorResponse は取り込めなかった行のレスポンスDTO
+type ImportRowErrorResponse struct {
+	Line    int    `json:"line"`    // CSV上の行番号（ヘッダーが1行目）
+	Message string `json:"message"` // 取り込めなかった理由
+}
+
+// ImportRecordsResponse は食事履歴インポートのレスポンスDTO
+type ImportRecordsResponse struct {
+	DryRun           bool                     `json:"dryRun"`           // trueの場合は保存していない
+	TotalRows        int                      `json:"totalRows"`        // ヘッダーを除くデータ行数
+	ImportedRecords  int                      `json:"importedRecords"`  // 取り込んだ記録数
+	ImportedItems    int                      `json:"importedItems"`    // 取り込んだ食品数
+	DuplicateRecords int                      `json:"duplicateRecords"` // 取り込み済みのためスキップした記録数
+	Errors           []ImportRowErrorResponse `json:"errors"`           // 取り込めなかった行
+}
+
+// NewImportRecordsResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewImportRecordsResponse(output *usecase.RecordImportOutput) ImportRecordsResponse {
+	errors := make([]ImportRowErrorResponse, len(output.Errors))
+	for i, e := range output.Errors {
+		errors[i] = ImportRowErrorResponse{Line: e.Line, Message: e.Message}
+	}
+
+	return ImportRecordsResponse{
+		DryRun:           output.DryRun,
+		TotalRows:        output.TotalRows,
+		ImportedRecords:  len(output.Records),
+		ImportedItems:    output.ImportedItems(),
+		DuplicateRecords: output.DuplicateRecords,
+		Errors:           errors,
+	}
+}
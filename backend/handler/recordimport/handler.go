@@ -0,0 +1,119 @@
+package recordimport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/recordimport/dto"
+	"caltrack/usecase"
+)
+
+// maxImportFileSize はアップロードできるCSVのサイズ上限（5MB）
+const maxImportFileSize = 5 << 20
+
+// RecordImportUsecaseInterface はRecordImportUsecaseのインターフェース
+type RecordImportUsecaseInterface interface {
+	Import(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error)
+}
+
+// RecordImportHandler は食事履歴のインポートに関するHTTPハンドラ
+type RecordImportHandler struct {
+	usecase RecordImportUsecaseInterface
+}
+
+// NewRecordImportHandler は RecordImportHandler のインスタンスを生成する
+func NewRecordImportHandler(uc RecordImportUsecaseInterface) *RecordImportHandler {
+	return &RecordImportHandler{usecase: uc}
+}
+
+// Import は他の記録アプリのCSVから食事履歴を取り込む
+// @Summary 食事履歴のインポート
+// @Description CSVから食事記録を取り込む。列の対応はプリセット（caltrack, myfitnesspal, cronometer, loseit）または列名で指定する。日時と食事区分が同じ行を1件の記録にまとめ、不正な行はスキップして行番号とともに返す。同じ日時・同じ食品の記録が既にある場合は重複としてスキップする。dryRunを指定すると保存せずに結果のみ返す
+// @Tags records
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSVファイル（5MBまで）"
+// @Param preset formData string false "プリセット名"
+// @Param dateColumn formData string false "日付（日時）の列名"
+// @Param timeColumn formData string false "時刻の列名"
+// @Param mealColumn formData string false "食事区分の列名"
+// @Param nameColumn formData string false "食品名の列名"
+// @Param caloriesColumn formData string false "カロリーの列名"
+// @Param dryRun formData bool false "trueの場合は保存しない"
+// @Success 200 {object} dto.ImportRecordsResponse "取り込み結果"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /records/import [post]
+func (h *RecordImportHandler) Import(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	// フォームの他の項目の分を加えてリクエスト全体のサイズを制限する
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize+1<<20)
+
+	var req dto.ImportRecordsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "CSV file is required", nil)
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "CSV file must be 5MB or less", nil)
+		return
+	}
+
+	mapping, err := req.ToMapping()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	output, err := h.usecase.Import(c.Request.Context(), userID, file, mapping, req.DryRun)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewImportRecordsResponse(output))
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *RecordImportHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrImportPresetNotFound) ||
+		errors.Is(err, domainErrors.ErrImportColumnMappingRequired) ||
+		errors.Is(err, domainErrors.ErrImportColumnNotFound) ||
+		errors.Is(err, domainErrors.ErrImportFileEmpty) ||
+		errors.Is(err, domainErrors.ErrImportTooManyRows) ||
+		errors.Is(err, domainErrors.ErrInvalidImportCSV) {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	// 500エラー
+	common.LogError("handleError", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", nil)
+}
@@ -0,0 +1,170 @@
+package recordimport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/recordimport"
+	"caltrack/handler/recordimport/dto"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockRecordImportUsecase はRecordImportUsecaseのモック実装
+type MockRecordImportUsecase struct {
+	ImportFunc func(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error)
+}
+
+func (m *MockRecordImportUsecase) Import(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error) {
+	if m.ImportFunc != nil {
+		return m.ImportFunc(ctx, userID, r, mapping, dryRun)
+	}
+	return &usecase.RecordImportOutput{}, nil
+}
+
+// performImport は認証済みユーザーとしてCSVとフォーム項目を送信する
+// csvが空の場合はファイルを添付しない
+func performImport(t *testing.T, uc recordimport.RecordImportUsecaseInterface, csv string, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		_ = mw.WriteField(k, v)
+	}
+	if csv != "" {
+		fw, err := mw.CreateFormFile("file", "meals.csv")
+		if err != nil {
+			t.Fatalf("failed to create form file: %v", err)
+		}
+		_, _ = fw.Write([]byte(csv))
+	}
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/records/import", &body)
+	c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+	c.Set("userID", testUserID)
+	recordimport.NewRecordImportHandler(uc).Import(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+func TestRecordImportHandler_Import(t *testing.T) {
+	csv := "Date,Food,kcal\n2024-05-01,Toast,180\n"
+
+	t.Run("正常系_プリセットを列名で上書きして取り込む", func(t *testing.T) {
+		var gotMapping usecase.RecordImportMapping
+		var gotDryRun bool
+		var gotCSV string
+		mockUC := &MockRecordImportUsecase{
+			ImportFunc: func(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error) {
+				b, _ := io.ReadAll(r)
+				gotCSV, gotMapping, gotDryRun = string(b), mapping, dryRun
+				record, _ := entity.NewRecord(userID, time.Now().Add(-time.Hour))
+				_ = record.AddItem("Toast", 180)
+				return &usecase.RecordImportOutput{
+					DryRun:           dryRun,
+					TotalRows:        2,
+					Records:          []*entity.Record{record},
+					DuplicateRecords: 0,
+					Errors:           []usecase.RecordImportRowError{{Line: 3, Message: "calories must be a number"}},
+				}, nil
+			},
+		}
+
+		w := performImport(t, mockUC, csv, map[string]string{
+			"preset":         "loseit",
+			"nameColumn":     "Food",
+			"caloriesColumn": "kcal",
+			"dryRun":         "true",
+		})
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body = %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		want := usecase.RecordImportMapping{Date: "Date", Meal: "Type", Name: "Food", Calories: "kcal"}
+		if gotMapping != want {
+			t.Errorf("mapping = %+v, want %+v", gotMapping, want)
+		}
+		if !gotDryRun || gotCSV != csv {
+			t.Errorf("dryRun = %v, csv = %q", gotDryRun, gotCSV)
+		}
+		var resp dto.ImportRecordsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !resp.DryRun || resp.ImportedRecords != 1 || resp.ImportedItems != 1 || len(resp.Errors) != 1 || resp.Errors[0].Line != 3 {
+			t.Errorf("response = %+v", resp)
+		}
+	})
+
+	t.Run("異常系_ファイルがない場合は400", func(t *testing.T) {
+		w := performImport(t, &MockRecordImportUsecase{}, "", map[string]string{"preset": "loseit"})
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidRequest)
+	})
+
+	t.Run("異常系_存在しないプリセットは400", func(t *testing.T) {
+		w := performImport(t, &MockRecordImportUsecase{}, csv, map[string]string{"preset": "unknown"})
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_列がCSVにない場合は400", func(t *testing.T) {
+		mockUC := &MockRecordImportUsecase{
+			ImportFunc: func(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error) {
+				return nil, domainErrors.ErrImportColumnNotFound
+			},
+		}
+
+		w := performImport(t, mockUC, csv, map[string]string{"preset": "cronometer"})
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeValidationError)
+	})
+
+	t.Run("異常系_保存に失敗した場合は500", func(t *testing.T) {
+		mockUC := &MockRecordImportUsecase{
+			ImportFunc: func(ctx context.Context, userID vo.UserID, r io.Reader, mapping usecase.RecordImportMapping, dryRun bool) (*usecase.RecordImportOutput, error) {
+				return nil, errors.New("db error")
+			},
+		}
+
+		w := performImport(t, mockUC, csv, map[string]string{"preset": "loseit"})
+
+		assertErrorCode(t, w, http.StatusInternalServerError, common.CodeInternalError)
+	})
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+)
+
+// ConfirmTOTPRequest はTOTP登録確認のリクエストDTO
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" example:"123456"` // 認証アプリに表示されたコード
+}
+
+// DisableTOTPRequest は2段階認証無効化のリクエストDTO
+type DisableTOTPRequest struct {
+	Password string `json:"password" example:"password123"` // 本人確認のための現在のパスワード
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// パスワードが形式を満たさない場合は、照合失敗として ErrCurrentPasswordIncorrect を返す
+func (r DisableTOTPRequest) ToDomain() (vo.Password, error) {
+	password, err := vo.NewPassword(r.Password)
+	if err != nil {
+		return vo.Password{}, domainErrors.ErrCurrentPasswordIncorrect
+	}
+	return password, nil
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/usecase"
+)
+
+// TOTPStatusResponse は2段階認証の設定状況のレスポンスDTO
+type TOTPStatusResponse struct {
+	Enabled   bool       `json:"enabled" example:"true"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty"`
+}
+
+// NewTOTPStatusResponse はTOTP設定からレスポンスDTOを生成する（未登録の場合はnil）
+func NewTOTPStatusResponse(totp *entity.UserTOTP) TOTPStatusResponse {
+	if totp == nil || !totp.IsEnabled() {
+		return TOTPStatusResponse{Enabled: false}
+	}
+	return TOTPStatusResponse{
+		Enabled:   true,
+		EnabledAt: totp.EnabledAt(),
+	}
+}
+
+// TOTPEnrollmentResponse はTOTP登録開始のレスポンスDTO
+type TOTPEnrollmentResponse struct {
+	Secret        string   `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`                                                     // 認証アプリに手入力する場合のシークレット
+	OtpauthURI    string   `json:"otpauthUri" example:"otpauth://totp/CalTrack:user@example.com?issuer=CalTrack&secret=JBSWY3DPEHPK3PXP"` // 認証アプリ登録用URI
+	QRPayload     string   `json:"qrPayload" example:"otpauth://totp/CalTrack:user@example.com?issuer=CalTrack&secret=JBSWY3DPEHPK3PXP"`  // QRコードに埋め込む文字列
+	RecoveryCodes []string `json:"recoveryCodes" example:"abcd-efgh-ijkl-mnop"`                                                           // 一度だけ表示するリカバリーコード
+}
+
+// NewTOTPEnrollmentResponse はUsecaseの出力からレスポンスDTOを生成する
+func NewTOTPEnrollmentResponse(output *usecase.TOTPEnrollmentOutput) TOTPEnrollmentResponse {
+	codes := make([]string, len(output.RecoveryCodes))
+	for i, code := range output.RecoveryCodes {
+		codes[i] = code.String()
+	}
+	return TOTPEnrollmentResponse{
+		Secret:        output.Secret.String(),
+		OtpauthURI:    output.ProvisioningURI,
+		QRPayload:     output.ProvisioningURI,
+		RecoveryCodes: codes,
+	}
+}
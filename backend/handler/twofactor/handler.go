@@ -0,0 +1,202 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/twofactor/dto"
+	"caltrack/usecase"
+)
+
+// TwoFactorUsecaseInterface はTwoFactorUsecaseのインターフェース
+type TwoFactorUsecaseInterface interface {
+	GetTOTPStatus(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error)
+	BeginTOTPEnrollment(ctx context.Context, userID vo.UserID) (*usecase.TOTPEnrollmentOutput, error)
+	ConfirmTOTPEnrollment(ctx context.Context, userID vo.UserID, code string) error
+	DisableTOTP(ctx context.Context, userID vo.UserID, password vo.Password) error
+}
+
+// TwoFactorHandler は2段階認証の設定に関するHTTPハンドラ
+type TwoFactorHandler struct {
+	usecase TwoFactorUsecaseInterface
+}
+
+// NewTwoFactorHandler は TwoFactorHandler のインスタンスを生成する
+func NewTwoFactorHandler(uc TwoFactorUsecaseInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{usecase: uc}
+}
+
+// GetStatus は2段階認証の設定状況を取得する
+// @Summary 2段階認証の設定状況取得
+// @Description 認証ユーザーのTOTPによる2段階認証が有効かどうかを取得する
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.TOTPStatusResponse "取得成功"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/2fa/totp [get]
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	totp, err := h.usecase.GetTOTPStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTOTPStatusResponse(totp))
+}
+
+// Enroll はTOTPの登録を開始する
+// @Summary TOTP登録開始
+// @Description 新しいシークレットとリカバリーコードを発行し、認証アプリ登録用のotpauth URIとQRコード用の文字列を返す。/auth/2fa/totp/confirm でコードを確認するまで有効にならない
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.TOTPEnrollmentResponse "登録開始"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 409 {object} common.ErrorResponse "既に有効"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/2fa/totp/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.usecase.BeginTOTPEnrollment(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTOTPEnrollmentResponse(output))
+}
+
+// Confirm は認証アプリのコードを確認してTOTPを有効にする
+// @Summary TOTP登録確認
+// @Description 認証アプリに表示されたコードを確認して2段階認証を有効にする
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ConfirmTOTPRequest true "TOTP登録確認"
+// @Success 200 {object} map[string]string "有効化成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正・コード不一致"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 404 {object} common.ErrorResponse "登録が開始されていない"
+// @Failure 409 {object} common.ErrorResponse "既に有効"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/2fa/totp/confirm [post]
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.usecase.ConfirmTOTPEnrollment(c.Request.Context(), userID, req.Code); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication enabled"})
+}
+
+// Disable はパスワードを確認して2段階認証を無効にする
+// @Summary 2段階認証無効化
+// @Description 現在のパスワードを確認し、TOTP設定とリカバリーコードを削除する
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.DisableTOTPRequest true "2段階認証無効化"
+// @Success 200 {object} map[string]string "無効化成功"
+// @Failure 400 {object} common.ErrorResponse "リクエスト不正・パスワード不一致"
+// @Failure 401 {object} common.ErrorResponse "認証失敗"
+// @Failure 409 {object} common.ErrorResponse "有効になっていない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /auth/2fa/totp/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DisableTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	password, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.DisableTOTP(c.Request.Context(), userID, password); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// currentUserID はAuthMiddlewareが設定したユーザーIDを取得する
+// 取得できない場合は401を返してfalseを返す
+func currentUserID(c *gin.Context) (vo.UserID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return vo.UserID{}, false
+	}
+	return vo.ReconstructUserID(userIDStr.(string)), true
+}
+
+// handleError はドメインエラーをHTTPレスポンスに変換する
+func (h *TwoFactorHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidTOTPCode, "Invalid authentication code", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidCredentials, "Current password is incorrect", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrTOTPEnrollmentNotFound) {
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "Two-factor enrollment not found", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrUserNotFound) {
+		common.RespondError(c, http.StatusNotFound, common.CodeNotFound, "User not found", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrTOTPAlreadyEnabled) {
+		common.RespondError(c, http.StatusConflict, common.CodeTOTPAlreadyEnabled, "Two-factor authentication is already enabled", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrTOTPNotEnabled) {
+		common.RespondError(c, http.StatusConflict, common.CodeTOTPNotEnabled, "Two-factor authentication is not enabled", nil)
+		return
+	}
+
+	common.RespondError(c, http.StatusInternalServerError, common.CodeInternalError, "Internal server error", err)
+}
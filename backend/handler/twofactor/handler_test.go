@@ -0,0 +1,284 @@
+package twofactor_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/handler/common"
+	"caltrack/handler/twofactor"
+	"caltrack/handler/twofactor/dto"
+	"caltrack/usecase"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// MockTwoFactorUsecase はTwoFactorUsecaseのモック実装
+type MockTwoFactorUsecase struct {
+	GetTOTPStatusFunc         func(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error)
+	BeginTOTPEnrollmentFunc   func(ctx context.Context, userID vo.UserID) (*usecase.TOTPEnrollmentOutput, error)
+	ConfirmTOTPEnrollmentFunc func(ctx context.Context, userID vo.UserID, code string) error
+	DisableTOTPFunc           func(ctx context.Context, userID vo.UserID, password vo.Password) error
+}
+
+func (m *MockTwoFactorUsecase) GetTOTPStatus(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error) {
+	if m.GetTOTPStatusFunc != nil {
+		return m.GetTOTPStatusFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTwoFactorUsecase) BeginTOTPEnrollment(ctx context.Context, userID vo.UserID) (*usecase.TOTPEnrollmentOutput, error) {
+	if m.BeginTOTPEnrollmentFunc != nil {
+		return m.BeginTOTPEnrollmentFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTwoFactorUsecase) ConfirmTOTPEnrollment(ctx context.Context, userID vo.UserID, code string) error {
+	if m.ConfirmTOTPEnrollmentFunc != nil {
+		return m.ConfirmTOTPEnrollmentFunc(ctx, userID, code)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorUsecase) DisableTOTP(ctx context.Context, userID vo.UserID, password vo.Password) error {
+	if m.DisableTOTPFunc != nil {
+		return m.DisableTOTPFunc(ctx, userID, password)
+	}
+	return nil
+}
+
+// performRequest は認証済みユーザーとしてハンドラにリクエストを渡して結果を返す
+func performRequest(handlerFunc gin.HandlerFunc, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", testUserID)
+	handlerFunc(c)
+	return w
+}
+
+// assertErrorCode はエラーレスポンスのステータスとコードを検証する
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d, body = %s", w.Code, wantStatus, w.Body.String())
+	}
+	var resp common.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Code != wantCode {
+		t.Errorf("code = %s, want %s", resp.Code, wantCode)
+	}
+}
+
+func TestTwoFactorHandler_GetStatus(t *testing.T) {
+	t.Run("正常系_有効な場合は有効化日時を返す", func(t *testing.T) {
+		secret, _ := vo.NewTOTPSecret()
+		enabledAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockUC := &MockTwoFactorUsecase{
+			GetTOTPStatusFunc: func(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error) {
+				return entity.ReconstructUserTOTP(userID.String(), secret.String(), &enabledAt, 0, enabledAt, enabledAt), nil
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.GetStatus, http.MethodGet, "/api/v1/auth/2fa/totp", "")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var resp dto.TOTPStatusResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !resp.Enabled || resp.EnabledAt == nil || !resp.EnabledAt.Equal(enabledAt) {
+			t.Errorf("resp = %+v, want enabled at %v", resp, enabledAt)
+		}
+	})
+
+	t.Run("正常系_未登録の場合は無効を返す", func(t *testing.T) {
+		handler := twofactor.NewTwoFactorHandler(&MockTwoFactorUsecase{})
+
+		w := performRequest(handler.GetStatus, http.MethodGet, "/api/v1/auth/2fa/totp", "")
+
+		var resp dto.TOTPStatusResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Enabled {
+			t.Error("enabled should be false")
+		}
+	})
+
+	t.Run("異常系_未認証", func(t *testing.T) {
+		handler := twofactor.NewTwoFactorHandler(&MockTwoFactorUsecase{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/auth/2fa/totp", nil)
+		handler.GetStatus(c)
+
+		assertErrorCode(t, w, http.StatusUnauthorized, common.CodeUnauthorized)
+	})
+}
+
+func TestTwoFactorHandler_Enroll(t *testing.T) {
+	t.Run("正常系_otpauth URIとリカバリーコードを返す", func(t *testing.T) {
+		secret, _ := vo.NewTOTPSecret()
+		code, _ := vo.NewRecoveryCode()
+		uri := secret.ProvisioningURI(entity.TOTPIssuer, "test@example.com")
+		mockUC := &MockTwoFactorUsecase{
+			BeginTOTPEnrollmentFunc: func(ctx context.Context, userID vo.UserID) (*usecase.TOTPEnrollmentOutput, error) {
+				if userID.String() != testUserID {
+					t.Errorf("userID = %s, want %s", userID.String(), testUserID)
+				}
+				return &usecase.TOTPEnrollmentOutput{
+					Secret:          secret,
+					ProvisioningURI: uri,
+					RecoveryCodes:   []vo.RecoveryCode{code},
+				}, nil
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Enroll, http.MethodPost, "/api/v1/auth/2fa/totp/enroll", "")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var resp dto.TOTPEnrollmentResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if resp.Secret != secret.String() {
+			t.Errorf("secret = %s, want %s", resp.Secret, secret.String())
+		}
+		if resp.OtpauthURI != uri || resp.QRPayload != uri {
+			t.Errorf("otpauthUri/qrPayload = %s/%s, want %s", resp.OtpauthURI, resp.QRPayload, uri)
+		}
+		if len(resp.RecoveryCodes) != 1 || resp.RecoveryCodes[0] != code.String() {
+			t.Errorf("recoveryCodes = %v, want [%s]", resp.RecoveryCodes, code.String())
+		}
+	})
+
+	t.Run("異常系_既に有効", func(t *testing.T) {
+		mockUC := &MockTwoFactorUsecase{
+			BeginTOTPEnrollmentFunc: func(ctx context.Context, userID vo.UserID) (*usecase.TOTPEnrollmentOutput, error) {
+				return nil, domainErrors.ErrTOTPAlreadyEnabled
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Enroll, http.MethodPost, "/api/v1/auth/2fa/totp/enroll", "")
+
+		assertErrorCode(t, w, http.StatusConflict, common.CodeTOTPAlreadyEnabled)
+	})
+}
+
+func TestTwoFactorHandler_Confirm(t *testing.T) {
+	t.Run("正常系_コードを渡して有効化する", func(t *testing.T) {
+		var gotCode string
+		mockUC := &MockTwoFactorUsecase{
+			ConfirmTOTPEnrollmentFunc: func(ctx context.Context, userID vo.UserID, code string) error {
+				gotCode = code
+				return nil
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Confirm, http.MethodPost, "/api/v1/auth/2fa/totp/confirm", `{"code": "123456"}`)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotCode != "123456" {
+			t.Errorf("code = %s, want 123456", gotCode)
+		}
+	})
+
+	t.Run("異常系_コード不一致", func(t *testing.T) {
+		mockUC := &MockTwoFactorUsecase{
+			ConfirmTOTPEnrollmentFunc: func(ctx context.Context, userID vo.UserID, code string) error {
+				return domainErrors.ErrInvalidTOTPCode
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Confirm, http.MethodPost, "/api/v1/auth/2fa/totp/confirm", `{"code": "000000"}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidTOTPCode)
+	})
+
+	t.Run("異常系_登録が開始されていない", func(t *testing.T) {
+		mockUC := &MockTwoFactorUsecase{
+			ConfirmTOTPEnrollmentFunc: func(ctx context.Context, userID vo.UserID, code string) error {
+				return domainErrors.ErrTOTPEnrollmentNotFound
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Confirm, http.MethodPost, "/api/v1/auth/2fa/totp/confirm", `{"code": "123456"}`)
+
+		assertErrorCode(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+}
+
+func TestTwoFactorHandler_Disable(t *testing.T) {
+	t.Run("正常系_パスワードを確認して無効化する", func(t *testing.T) {
+		called := false
+		mockUC := &MockTwoFactorUsecase{
+			DisableTOTPFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) error {
+				called = true
+				return nil
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Disable, http.MethodPost, "/api/v1/auth/2fa/totp/disable", `{"password": "password123"}`)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if !called {
+			t.Error("DisableTOTP should be called")
+		}
+	})
+
+	t.Run("異常系_パスワード形式不正は照合失敗として扱う", func(t *testing.T) {
+		handler := twofactor.NewTwoFactorHandler(&MockTwoFactorUsecase{})
+
+		w := performRequest(handler.Disable, http.MethodPost, "/api/v1/auth/2fa/totp/disable", `{"password": "short"}`)
+
+		assertErrorCode(t, w, http.StatusBadRequest, common.CodeInvalidCredentials)
+	})
+
+	t.Run("異常系_有効になっていない", func(t *testing.T) {
+		mockUC := &MockTwoFactorUsecase{
+			DisableTOTPFunc: func(ctx context.Context, userID vo.UserID, password vo.Password) error {
+				return domainErrors.ErrTOTPNotEnabled
+			},
+		}
+		handler := twofactor.NewTwoFactorHandler(mockUC)
+
+		w := performRequest(handler.Disable, http.MethodPost, "/api/v1/auth/2fa/totp/disable", `{"password": "password123"}`)
+
+		assertErrorCode(t, w, http.StatusConflict, common.CodeTOTPNotEnabled)
+	})
+}
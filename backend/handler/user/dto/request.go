@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/vo"
 )
 
@@ -39,11 +40,14 @@ func (r RegisterUserRequest) ToDomain() (*entity.User, error, []error) {
 }
 
 // UpdateProfileRequest はプロフィール更新リクエストDTO
+// Gender・BirthDateは省略した場合は変更しない
 type UpdateProfileRequest struct {
 	Nickname      string  `json:"nickname" example:"NewNickname"`
 	Height        float64 `json:"height" example:"175.0"`
 	Weight        float64 `json:"weight" example:"70.5"`
 	ActivityLevel string  `json:"activityLevel" example:"moderate"`
+	Gender        *string `json:"gender,omitempty" example:"female"`
+	BirthDate     *string `json:"birthDate,omitempty" example:"1990-01-15"` // "2006-01-02"形式
 }
 
 // ToDomain はリクエストをドメインのVOに変換する
@@ -76,3 +80,132 @@ func (r UpdateProfileRequest) ToDomain() (vo.Nickname, vo.Height, vo.Weight, vo.
 
 	return nickname, height, weight, activityLevel, nil
 }
+
+// UpdateBudgetModeRequest は予算モード変更リクエストDTO
+type UpdateBudgetModeRequest struct {
+	BudgetMode string `json:"budgetMode" example:"weekly"` // "daily" または "weekly"
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+func (r UpdateBudgetModeRequest) ToDomain() (vo.BudgetMode, error) {
+	return vo.NewBudgetMode(r.BudgetMode)
+}
+
+// UpdatePrivacySettingsRequest はプライバシー設定変更リクエストDTO
+// 全ての項目を送信し、表示したプライバシーポリシーのバージョンへの同意として記録する
+type UpdatePrivacySettingsRequest struct {
+	AIProcessing   bool   `json:"aiProcessing" example:"false"`     // 食事内容・写真を外部のAIプロバイダーに送信してよいか
+	Analytics      bool   `json:"analytics" example:"false"`        // 利用状況を分析に使ってよいか
+	RetentionYears int    `json:"retentionYears" example:"3"`       // 記録の保存期間（年）。0は無期限、最大10
+	ConsentVersion string `json:"consentVersion" example:"2026-10"` // 同意したプライバシーポリシーのバージョン
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+func (r UpdatePrivacySettingsRequest) ToDomain() (vo.PrivacySettings, error) {
+	return vo.NewPrivacySettings(r.AIProcessing, r.Analytics, r.RetentionYears, r.ConsentVersion)
+}
+
+// ChangePasswordRequest はパスワード変更リクエストDTO
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" example:"password123"`
+	NewPassword     string `json:"newPassword" example:"newpassword456"`
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// 現在のパスワードが形式を満たさない場合は、照合失敗として ErrCurrentPasswordIncorrect を返す
+func (r ChangePasswordRequest) ToDomain() (vo.Password, vo.Password, error) {
+	currentPassword, err := vo.NewPassword(r.CurrentPassword)
+	if err != nil {
+		return vo.Password{}, vo.Password{}, domainErrors.ErrCurrentPasswordIncorrect
+	}
+
+	newPassword, err := vo.NewPassword(r.NewPassword)
+	if err != nil {
+		return vo.Password{}, vo.Password{}, err
+	}
+
+	return currentPassword, newPassword, nil
+}
+
+// SetPasswordRequest はパスワード未設定のユーザーがパスワードを設定するリクエストDTO
+type SetPasswordRequest struct {
+	NewPassword string `json:"newPassword" example:"newpassword456"`
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+func (r SetPasswordRequest) ToDomain() (vo.Password, error) {
+	return vo.NewPassword(r.NewPassword)
+}
+
+// ChangeEmailRequest はメールアドレス変更リクエストDTO
+type ChangeEmailRequest struct {
+	NewEmail string `json:"newEmail" example:"new@example.com"`
+	Password string `json:"password" example:"password123"` // 本人確認のための現在のパスワード
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// パスワードが形式を満たさない場合は、照合失敗として ErrCurrentPasswordIncorrect を返す
+func (r ChangeEmailRequest) ToDomain() (vo.Email, vo.Password, error) {
+	newEmail, err := vo.NewEmail(r.NewEmail)
+	if err != nil {
+		return vo.Email{}, vo.Password{}, err
+	}
+
+	password, err := vo.NewPassword(r.Password)
+	if err != nil {
+		return vo.Email{}, vo.Password{}, domainErrors.ErrCurrentPasswordIncorrect
+	}
+
+	return newEmail, password, nil
+}
+
+// VerifyEmailRequest はメールアドレス確認リクエストDTO
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"q2mJv7Yc3pX0b1kR9sT4uV6wZ8aB5cD7eF9gH1iJ3kL"` // 確認メールのリンクに含まれるトークン
+}
+
+// ToDomain はリクエストをドメインのVOに変換する
+// トークンの形式が不正な場合は ErrEmailVerificationTokenInvalid を返す
+func (r VerifyEmailRequest) ToDomain() (vo.OneTimeToken, error) {
+	token, err := vo.ParseOneTimeToken(r.Token)
+	if err != nil {
+		return vo.OneTimeToken{}, domainErrors.ErrEmailVerificationTokenInvalid
+	}
+	return token, nil
+}
+
+// ToDemographics は性別・生年月日をドメインのVOに変換する（省略された項目はnil）
+func (r UpdateProfileRequest) ToDemographics() (*vo.Gender, *vo.BirthDate, []error) {
+	var errs []error
+	var gender *vo.Gender
+	var birthDate *vo.BirthDate
+
+	if r.Gender != nil {
+		g, err := vo.NewGender(*r.Gender)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			gender = &g
+		}
+	}
+
+	if r.BirthDate != nil {
+		date, err := time.Parse("2006-01-02", *r.BirthDate)
+		if err != nil {
+			errs = append(errs, domainErrors.ErrInvalidBirthDateFormat)
+		} else {
+			b, err := vo.NewBirthDate(date)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				birthDate = &b
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	return gender, birthDate, nil
+}
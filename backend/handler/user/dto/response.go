@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"caltrack/domain/entity"
 )
 
@@ -15,6 +17,8 @@ type UpdateProfileResponse struct {
 	Height        float64 `json:"height" example:"175.0"`
 	Weight        float64 `json:"weight" example:"70.5"`
 	ActivityLevel string  `json:"activityLevel" example:"moderate"`
+	Gender        string  `json:"gender" example:"male"`
+	BirthDate     string  `json:"birthDate" example:"1990-01-15"`
 }
 
 // NewUpdateProfileResponse はEntityからレスポンスDTOを生成する
@@ -25,29 +29,72 @@ func NewUpdateProfileResponse(user *entity.User) UpdateProfileResponse {
 		Height:        user.Height().Cm(),
 		Weight:        user.Weight().Kg(),
 		ActivityLevel: user.ActivityLevel().String(),
+		Gender:        user.Gender().String(),
+		BirthDate:     user.BirthDate().Time().Format("2006-01-02"),
 	}
 }
 
 // GetProfileResponse はプロフィール取得レスポンスDTO
 type GetProfileResponse struct {
-	Email         string  `json:"email" example:"user@example.com"`
-	Nickname      string  `json:"nickname" example:"John"`
-	Weight        float64 `json:"weight" example:"70.5"`
-	Height        float64 `json:"height" example:"175.0"`
-	BirthDate     string  `json:"birthDate" example:"1990-01-15"`
-	Gender        string  `json:"gender" example:"male"`
-	ActivityLevel string  `json:"activityLevel" example:"moderate"`
+	Email         string                  `json:"email" example:"user@example.com"`
+	EmailVerified bool                    `json:"emailVerified" example:"true"`
+	Nickname      string                  `json:"nickname" example:"John"`
+	Weight        float64                 `json:"weight" example:"70.5"`
+	Height        float64                 `json:"height" example:"175.0"`
+	BirthDate     string                  `json:"birthDate" example:"1990-01-15"`
+	Gender        string                  `json:"gender" example:"male"`
+	ActivityLevel string                  `json:"activityLevel" example:"moderate"`
+	BudgetMode    string                  `json:"budgetMode" example:"daily"`
+	Role          string                  `json:"role" example:"user"`
+	Privacy       PrivacySettingsResponse `json:"privacy"`
 }
 
 // NewGetProfileResponse はEntityからレスポンスDTOを生成する
 func NewGetProfileResponse(user *entity.User) GetProfileResponse {
 	return GetProfileResponse{
 		Email:         user.Email().String(),
+		EmailVerified: user.IsEmailVerified(),
 		Nickname:      user.Nickname().String(),
 		Weight:        user.Weight().Kg(),
 		Height:        user.Height().Cm(),
 		BirthDate:     user.BirthDate().Time().Format("2006-01-02"),
 		Gender:        user.Gender().String(),
 		ActivityLevel: user.ActivityLevel().String(),
+		BudgetMode:    user.BudgetMode().String(),
+		Role:          user.Role().String(),
+		Privacy:       NewPrivacySettingsResponse(user),
+	}
+}
+
+// PrivacySettingsResponse はプライバシー設定のレスポンスDTO
+type PrivacySettingsResponse struct {
+	AIProcessing   bool       `json:"aiProcessing" example:"true"`
+	Analytics      bool       `json:"analytics" example:"false"`
+	RetentionYears int        `json:"retentionYears" example:"0"`                 // 0は無期限
+	ConsentVersion string     `json:"consentVersion,omitempty" example:"2026-10"` // 設定を変更していない場合は省略
+	ConsentedAt    *time.Time `json:"consentedAt,omitempty"`
+}
+
+// NewPrivacySettingsResponse はEntityからレスポンスDTOを生成する
+func NewPrivacySettingsResponse(user *entity.User) PrivacySettingsResponse {
+	privacy := user.PrivacySettings()
+	return PrivacySettingsResponse{
+		AIProcessing:   privacy.AIProcessingEnabled(),
+		Analytics:      privacy.AnalyticsEnabled(),
+		RetentionYears: privacy.RetentionYears(),
+		ConsentVersion: privacy.ConsentVersion(),
+		ConsentedAt:    privacy.ConsentedAt(),
+	}
+}
+
+// UpdateBudgetModeResponse は予算モード変更レスポンスDTO
+type UpdateBudgetModeResponse struct {
+	BudgetMode string `json:"budgetMode" example:"weekly"`
+}
+
+// NewUpdateBudgetModeResponse はEntityからレスポンスDTOを生成する
+func NewUpdateBudgetModeResponse(user *entity.User) UpdateBudgetModeResponse {
+	return UpdateBudgetModeResponse{
+		BudgetMode: user.BudgetMode().String(),
 	}
 }
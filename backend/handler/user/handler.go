@@ -18,7 +18,14 @@ import (
 type UserUsecaseInterface interface {
 	Register(ctx context.Context, user *entity.User) (*entity.User, error)
 	GetProfile(ctx context.Context, userID vo.UserID) (*entity.User, error)
-	UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error)
+	UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error)
+	UpdateBudgetMode(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error)
+	UpdatePrivacySettings(ctx context.Context, userID vo.UserID, settings vo.PrivacySettings) (*entity.User, error)
+	ChangePassword(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error
+	SetPassword(ctx context.Context, userID vo.UserID, newPassword vo.Password) error
+	RequestEmailChange(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error
+	ResendEmailVerification(ctx context.Context, userID vo.UserID) error
+	VerifyEmail(ctx context.Context, token vo.OneTimeToken) error
 }
 
 type UserHandler struct {
@@ -107,13 +114,15 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	// DTOからVOに変換
 	nickname, height, weight, activityLevel, errs := req.ToDomain()
-	if errs != nil {
+	gender, birthDate, demographicsErrs := req.ToDemographics()
+	errs = append(errs, demographicsErrs...)
+	if len(errs) > 0 {
 		details := common.ExtractErrorMessages(errs)
 		common.RespondValidationError(c, details)
 		return
 	}
 
-	updatedUser, err := h.usecase.UpdateProfile(c.Request.Context(), userID, nickname, height, weight, activityLevel)
+	updatedUser, err := h.usecase.UpdateProfile(c.Request.Context(), userID, nickname, height, weight, activityLevel, gender, birthDate)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -122,6 +131,286 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewUpdateProfileResponse(updatedUser))
 }
 
+// UpdateBudgetMode は認証ユーザーのカロリー予算モードを変更する
+// @Summary カロリー予算モード変更
+// @Description 目標カロリーを日単位で管理するか週単位で管理するかを切り替える
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateBudgetModeRequest true "予算モード変更リクエスト"
+// @Success 200 {object} dto.UpdateBudgetModeResponse "変更成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが存在しない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/budget-mode [patch]
+func (h *UserHandler) UpdateBudgetMode(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.UpdateBudgetModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	budgetMode, err := req.ToDomain()
+	if err != nil {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	updatedUser, err := h.usecase.UpdateBudgetMode(c.Request.Context(), userID, budgetMode)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUpdateBudgetModeResponse(updatedUser))
+}
+
+// UpdatePrivacySettings は認証ユーザーのプライバシー設定を変更する
+// @Summary プライバシー設定変更
+// @Description AI機能（食事内容・写真の外部AIプロバイダーへの送信）、利用状況の分析、記録の保存期間を設定する。同意日時と同意したポリシーのバージョンを記録する
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdatePrivacySettingsRequest true "プライバシー設定変更リクエスト"
+// @Success 200 {object} dto.PrivacySettingsResponse "変更成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが存在しない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/privacy [patch]
+func (h *UserHandler) UpdatePrivacySettings(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.UpdatePrivacySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	settings, err := req.ToDomain()
+	if err != nil {
+		common.RespondValidationError(c, []string{err.Error()})
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	updatedUser, err := h.usecase.UpdatePrivacySettings(c.Request.Context(), userID, settings)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPrivacySettingsResponse(updatedUser))
+}
+
+// ChangePassword はパスワードを変更する
+// @Summary パスワード変更
+// @Description 現在のパスワードを確認して新しいパスワードに変更し、現在のセッション以外をログアウトさせる
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangePasswordRequest true "パスワード変更リクエスト"
+// @Success 200 {object} map[string]string "変更成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー・現在のパスワードが不正"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが存在しない"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/password [post]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userIDStr, userExists := c.Get("userID")
+	sessionIDStr, sessionExists := c.Get("sessionID")
+	if !userExists || !sessionExists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	sessionID, err := vo.ParseSessionID(sessionIDStr.(string))
+	if err != nil {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "Invalid session", nil)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	currentPassword, newPassword, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	if err := h.usecase.ChangePassword(c.Request.Context(), userID, sessionID, currentPassword, newPassword); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// SetPassword はパスワード未設定のユーザーにパスワードを設定する
+// @Summary パスワード設定
+// @Description 外部ID（Google など）で登録したパスワード未設定のユーザーにパスワードを設定する。設定後はメールアドレスとパスワードでもログインできる
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.SetPasswordRequest true "パスワード設定リクエスト"
+// @Success 200 {object} map[string]string "設定成功"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 404 {object} common.ErrorResponse "ユーザーが存在しない"
+// @Failure 409 {object} common.ErrorResponse "パスワード設定済み"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/password/set [post]
+func (h *UserHandler) SetPassword(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.SetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	newPassword, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	if err := h.usecase.SetPassword(c.Request.Context(), userID, newPassword); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password set successfully"})
+}
+
+// ChangeEmail はメールアドレスの変更を申請する
+// @Summary メールアドレス変更
+// @Description パスワードを確認して新しいメールアドレス宛てに確認メールを送信する。確認リンクが開かれるまでメールアドレスは変更されない
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangeEmailRequest true "メールアドレス変更リクエスト"
+// @Success 202 {object} map[string]string "確認メール送信"
+// @Failure 400 {object} common.ErrorResponse "バリデーションエラー・パスワードが不正"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 409 {object} common.ErrorResponse "メールアドレス重複"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/email [patch]
+func (h *UserHandler) ChangeEmail(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	newEmail, password, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	if err := h.usecase.RequestEmailChange(c.Request.Context(), userID, password, newEmail); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "A confirmation email has been sent to the new address"})
+}
+
+// ResendEmailVerification は確認メールを再送する
+// @Summary 確認メール再送
+// @Description 現在のメールアドレス宛てに確認メールを再送する
+// @Tags users
+// @Produce json
+// @Success 202 {object} map[string]string "確認メール送信"
+// @Failure 401 {object} common.ErrorResponse "認証エラー"
+// @Failure 409 {object} common.ErrorResponse "確認済み"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/email/verification [post]
+func (h *UserHandler) ResendEmailVerification(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		common.RespondError(c, http.StatusUnauthorized, common.CodeUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	userID := vo.ReconstructUserID(userIDStr.(string))
+
+	if err := h.usecase.ResendEmailVerification(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "A confirmation email has been sent"})
+}
+
+// VerifyEmail は確認メールのトークンでメールアドレスを確認する
+// @Summary メールアドレス確認
+// @Description 確認メールのトークンを検証してメールアドレスを確認済みにする。変更申請中の場合は新しいアドレスに切り替わる
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "メールアドレス確認リクエスト"
+// @Success 200 {object} map[string]string "確認成功"
+// @Failure 400 {object} common.ErrorResponse "トークン不正"
+// @Failure 409 {object} common.ErrorResponse "メールアドレス重複"
+// @Failure 500 {object} common.ErrorResponse "サーバーエラー"
+// @Router /users/email/verify [post]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidRequest, "Invalid request body", nil)
+		return
+	}
+
+	token, err := req.ToDomain()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.VerifyEmail(c.Request.Context(), token); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email address has been verified"})
+}
+
 func (h *UserHandler) handleError(c *gin.Context, err error) {
 	if errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
 		common.RespondError(c, http.StatusConflict, common.CodeEmailAlreadyExists, err.Error(), nil)
@@ -133,6 +422,26 @@ func (h *UserHandler) handleError(c *gin.Context, err error) {
 		return
 	}
 
+	if errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidCredentials, "Current password is incorrect", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrPasswordAlreadySet) {
+		common.RespondError(c, http.StatusConflict, common.CodePasswordAlreadySet, "Password is already set", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrEmailVerificationTokenInvalid) {
+		common.RespondError(c, http.StatusBadRequest, common.CodeInvalidToken, "Email verification token is invalid or expired", nil)
+		return
+	}
+
+	if errors.Is(err, domainErrors.ErrEmailAlreadyVerified) {
+		common.RespondError(c, http.StatusConflict, common.CodeEmailAlreadyVerified, "Email address is already verified", nil)
+		return
+	}
+
 	if isValidationError(err) {
 		common.RespondValidationError(c, []string{err.Error()})
 		return
@@ -152,6 +461,13 @@ func isValidationError(err error) bool {
 		domainErrors.ErrHeightMustBePositive,
 		domainErrors.ErrHeightTooTall,
 		domainErrors.ErrInvalidActivityLevel,
+		domainErrors.ErrInvalidBudgetMode,
+		domainErrors.ErrPasswordRequired,
+		domainErrors.ErrPasswordTooShort,
+		domainErrors.ErrEmailRequired,
+		domainErrors.ErrInvalidEmailFormat,
+		domainErrors.ErrEmailTooLong,
+		domainErrors.ErrEmailUnchanged,
 	}
 	for _, ve := range validationErrors {
 		if errors.Is(err, ve) {
@@ -15,6 +15,7 @@ import (
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/vo"
+	"caltrack/handler/common"
 	"caltrack/handler/user"
 )
 
@@ -24,9 +25,16 @@ func init() {
 
 // MockUserUsecase はUserUsecaseのモック実装
 type MockUserUsecase struct {
-	RegisterFunc      func(ctx context.Context, user *entity.User) (*entity.User, error)
-	GetProfileFunc    func(ctx context.Context, userID vo.UserID) (*entity.User, error)
-	UpdateProfileFunc func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error)
+	RegisterFunc                func(ctx context.Context, user *entity.User) (*entity.User, error)
+	GetProfileFunc              func(ctx context.Context, userID vo.UserID) (*entity.User, error)
+	UpdateProfileFunc           func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error)
+	UpdateBudgetModeFunc        func(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error)
+	UpdatePrivacySettingsFunc   func(ctx context.Context, userID vo.UserID, settings vo.PrivacySettings) (*entity.User, error)
+	ChangePasswordFunc          func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error
+	SetPasswordFunc             func(ctx context.Context, userID vo.UserID, newPassword vo.Password) error
+	RequestEmailChangeFunc      func(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error
+	ResendEmailVerificationFunc func(ctx context.Context, userID vo.UserID) error
+	VerifyEmailFunc             func(ctx context.Context, token vo.OneTimeToken) error
 }
 
 func (m *MockUserUsecase) Register(ctx context.Context, user *entity.User) (*entity.User, error) {
@@ -43,13 +51,62 @@ func (m *MockUserUsecase) GetProfile(ctx context.Context, userID vo.UserID) (*en
 	return nil, nil
 }
 
-func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error) {
+func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
 	if m.UpdateProfileFunc != nil {
-		return m.UpdateProfileFunc(ctx, userID, nickname, height, weight, activityLevel)
+		return m.UpdateProfileFunc(ctx, userID, nickname, height, weight, activityLevel, gender, birthDate)
 	}
 	return nil, nil
 }
 
+func (m *MockUserUsecase) UpdateBudgetMode(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error) {
+	if m.UpdateBudgetModeFunc != nil {
+		return m.UpdateBudgetModeFunc(ctx, userID, budgetMode)
+	}
+	return nil, nil
+}
+
+func (m *MockUserUsecase) UpdatePrivacySettings(ctx context.Context, userID vo.UserID, settings vo.PrivacySettings) (*entity.User, error) {
+	if m.UpdatePrivacySettingsFunc != nil {
+		return m.UpdatePrivacySettingsFunc(ctx, userID, settings)
+	}
+	return nil, nil
+}
+
+func (m *MockUserUsecase) ChangePassword(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error {
+	if m.ChangePasswordFunc != nil {
+		return m.ChangePasswordFunc(ctx, userID, currentSessionID, currentPassword, newPassword)
+	}
+	return nil
+}
+
+func (m *MockUserUsecase) SetPassword(ctx context.Context, userID vo.UserID, newPassword vo.Password) error {
+	if m.SetPasswordFunc != nil {
+		return m.SetPasswordFunc(ctx, userID, newPassword)
+	}
+	return nil
+}
+
+func (m *MockUserUsecase) RequestEmailChange(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error {
+	if m.RequestEmailChangeFunc != nil {
+		return m.RequestEmailChangeFunc(ctx, userID, password, newEmail)
+	}
+	return nil
+}
+
+func (m *MockUserUsecase) ResendEmailVerification(ctx context.Context, userID vo.UserID) error {
+	if m.ResendEmailVerificationFunc != nil {
+		return m.ResendEmailVerificationFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockUserUsecase) VerifyEmail(ctx context.Context, token vo.OneTimeToken) error {
+	if m.VerifyEmailFunc != nil {
+		return m.VerifyEmailFunc(ctx, token)
+	}
+	return nil
+}
+
 func TestUserHandler_Register(t *testing.T) {
 	t.Run("正常系_登録成功", func(t *testing.T) {
 		testUser := createTestUser()
@@ -181,6 +238,11 @@ func createTestUser() *entity.User {
 		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 		"male",
 		"moderate",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Now(),
 		time.Now(),
 	)
@@ -194,7 +256,7 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 	t.Run("正常系_プロフィール更新成功", func(t *testing.T) {
 		testUser := createTestUser()
 		mockUC := &MockUserUsecase{
-			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error) {
+			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
 				// 更新後のユーザーを返す
 				testUser.UpdateProfile("UpdatedNickname", 175.0, 72.5, "active")
 				return testUser, nil
@@ -243,6 +305,108 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_性別と生年月日を指定した場合はVOに変換して渡す", func(t *testing.T) {
+		testUser := createTestUser()
+		var gotGender *vo.Gender
+		var gotBirthDate *vo.BirthDate
+		mockUC := &MockUserUsecase{
+			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
+				gotGender, gotBirthDate = gender, birthDate
+				return testUser, nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		reqBody := `{
+			"nickname": "UpdatedNickname",
+			"height": 175.0,
+			"weight": 72.5,
+			"activityLevel": "active",
+			"gender": "female",
+			"birthDate": "1985-06-15"
+		}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/profile", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateProfile(c)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		if gotGender == nil || gotGender.String() != "female" {
+			t.Errorf("gender = %v, want female", gotGender)
+		}
+		if gotBirthDate == nil || gotBirthDate.Time().Format("2006-01-02") != "1985-06-15" {
+			t.Errorf("birthDate = %v, want 1985-06-15", gotBirthDate)
+		}
+	})
+
+	t.Run("正常系_性別と生年月日を省略した場合はnilを渡す", func(t *testing.T) {
+		testUser := createTestUser()
+		called := false
+		mockUC := &MockUserUsecase{
+			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
+				called = true
+				if gender != nil || birthDate != nil {
+					t.Errorf("gender = %v, birthDate = %v, want nil", gender, birthDate)
+				}
+				return testUser, nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		reqBody := `{"nickname": "UpdatedNickname", "height": 175.0, "weight": 72.5, "activityLevel": "active"}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/profile", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateProfile(c)
+
+		if w.Code != http.StatusOK || !called {
+			t.Errorf("status = %d, called = %v", w.Code, called)
+		}
+	})
+
+	t.Run("異常系_不正な性別と生年月日はバリデーションエラー", func(t *testing.T) {
+		testUser := createTestUser()
+		handler := user.NewUserHandler(&MockUserUsecase{})
+
+		reqBody := `{
+			"nickname": "UpdatedNickname",
+			"height": 175.0,
+			"weight": 72.5,
+			"activityLevel": "active",
+			"gender": "unknown",
+			"birthDate": "1985/06/15"
+		}`
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/profile", strings.NewReader(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateProfile(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var response common.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Details) != 2 {
+			t.Errorf("details = %v, want 2 errors", response.Details)
+		}
+	})
+
 	t.Run("異常系_認証なし", func(t *testing.T) {
 		mockUC := &MockUserUsecase{}
 		handler := user.NewUserHandler(mockUC)
@@ -290,7 +454,7 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
 		testUser := createTestUser()
 		mockUC := &MockUserUsecase{
-			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error) {
+			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
 				return nil, domainErrors.ErrUserNotFound
 			},
 		}
@@ -369,7 +533,7 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 	t.Run("異常系_DB更新失敗", func(t *testing.T) {
 		testUser := createTestUser()
 		mockUC := &MockUserUsecase{
-			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error) {
+			UpdateProfileFunc: func(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
 				return nil, errors.New("database error")
 			},
 		}
@@ -422,7 +586,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 			t.Fatalf("Failed to parse response: %v", err)
 		}
 
-		// 全7フィールド検証
+		// 全8フィールド検証
 		if response["email"] != testUser.Email().String() {
 			t.Errorf("email = %v, want %v", response["email"], testUser.Email().String())
 		}
@@ -444,6 +608,9 @@ func TestUserHandler_GetProfile(t *testing.T) {
 		if response["activityLevel"] != "moderate" {
 			t.Errorf("activityLevel = %v, want moderate", response["activityLevel"])
 		}
+		if response["budgetMode"] != "daily" {
+			t.Errorf("budgetMode = %v, want daily", response["budgetMode"])
+		}
 
 		// userIdが含まれないこと確認
 		if _, exists := response["userId"]; exists {
@@ -517,3 +684,439 @@ func TestUserHandler_GetProfile(t *testing.T) {
 		}
 	})
 }
+
+func TestUserHandler_UpdateBudgetMode(t *testing.T) {
+	t.Run("正常系_予算モード変更成功", func(t *testing.T) {
+		testUser := createTestUser()
+		mockUC := &MockUserUsecase{
+			UpdateBudgetModeFunc: func(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error) {
+				testUser.ChangeBudgetMode(budgetMode)
+				return testUser, nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/budget-mode", strings.NewReader(`{"budgetMode": "weekly"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateBudgetMode(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["budgetMode"] != "weekly" {
+			t.Errorf("budgetMode = %v, want weekly", response["budgetMode"])
+		}
+	})
+
+	t.Run("異常系_認証なし", func(t *testing.T) {
+		mockUC := &MockUserUsecase{}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/budget-mode", strings.NewReader(`{"budgetMode": "weekly"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.UpdateBudgetMode(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("異常系_バリデーションエラー_不正な予算モード", func(t *testing.T) {
+		testUser := createTestUser()
+		mockUC := &MockUserUsecase{}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/budget-mode", strings.NewReader(`{"budgetMode": "monthly"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateBudgetMode(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
+		testUser := createTestUser()
+		mockUC := &MockUserUsecase{
+			UpdateBudgetModeFunc: func(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error) {
+				return nil, domainErrors.ErrUserNotFound
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/budget-mode", strings.NewReader(`{"budgetMode": "weekly"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdateBudgetMode(c)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestUserHandler_UpdatePrivacySettings(t *testing.T) {
+	t.Run("正常系_プライバシー設定変更成功", func(t *testing.T) {
+		testUser := createTestUser()
+		mockUC := &MockUserUsecase{
+			UpdatePrivacySettingsFunc: func(ctx context.Context, userID vo.UserID, settings vo.PrivacySettings) (*entity.User, error) {
+				testUser.ChangePrivacySettings(settings)
+				return testUser, nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/privacy", strings.NewReader(`{"aiProcessing": false, "analytics": true, "retentionYears": 3, "consentVersion": "2026-10"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", testUser.ID().String())
+
+		handler.UpdatePrivacySettings(c)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["aiProcessing"] != false || response["analytics"] != true || response["retentionYears"] != float64(3) {
+			t.Errorf("response = %v, want aiProcessing=false, analytics=true, retentionYears=3", response)
+		}
+		if response["consentVersion"] != "2026-10" || response["consentedAt"] == nil {
+			t.Errorf("response = %v, want consentVersion and consentedAt", response)
+		}
+	})
+
+	t.Run("異常系_保存期間が範囲外", func(t *testing.T) {
+		mockUC := &MockUserUsecase{}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/privacy", strings.NewReader(`{"aiProcessing": true, "retentionYears": 11, "consentVersion": "2026-10"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+
+		handler.UpdatePrivacySettings(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("異常系_同意したバージョンがない", func(t *testing.T) {
+		mockUC := &MockUserUsecase{}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/privacy", strings.NewReader(`{"aiProcessing": false}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+
+		handler.UpdatePrivacySettings(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	// newChangePasswordContext は認証済みのパスワード変更リクエストのコンテキストを生成する
+	newChangePasswordContext := func(t *testing.T, body string) (*gin.Context, *httptest.ResponseRecorder) {
+		t.Helper()
+		sessionID, err := vo.NewSessionID()
+		if err != nil {
+			t.Fatalf("failed to create session id: %v", err)
+		}
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/password", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+		c.Set("sessionID", sessionID.String())
+		return c, w
+	}
+
+	t.Run("正常系_パスワード変更成功", func(t *testing.T) {
+		var gotCurrent, gotNew vo.Password
+		mockUC := &MockUserUsecase{
+			ChangePasswordFunc: func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error {
+				gotCurrent = currentPassword
+				gotNew = newPassword
+				return nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newChangePasswordContext(t, `{"currentPassword": "password123", "newPassword": "newpassword456"}`)
+		handler.ChangePassword(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		wantCurrent, _ := vo.NewPassword("password123")
+		wantNew, _ := vo.NewPassword("newpassword456")
+		if gotCurrent != wantCurrent || gotNew != wantNew {
+			t.Error("passwords should be passed to usecase")
+		}
+	})
+
+	t.Run("異常系_現在のパスワードが一致しない", func(t *testing.T) {
+		mockUC := &MockUserUsecase{
+			ChangePasswordFunc: func(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error {
+				return domainErrors.ErrCurrentPasswordIncorrect
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newChangePasswordContext(t, `{"currentPassword": "wrongpassword", "newPassword": "newpassword456"}`)
+		handler.ChangePassword(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["code"] != "INVALID_CREDENTIALS" {
+			t.Errorf("code = %v, want INVALID_CREDENTIALS", response["code"])
+		}
+	})
+
+	t.Run("異常系_新しいパスワードが短すぎる", func(t *testing.T) {
+		handler := user.NewUserHandler(&MockUserUsecase{})
+
+		c, w := newChangePasswordContext(t, `{"currentPassword": "password123", "newPassword": "short"}`)
+		handler.ChangePassword(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["code"] != "VALIDATION_ERROR" {
+			t.Errorf("code = %v, want VALIDATION_ERROR", response["code"])
+		}
+	})
+
+	t.Run("異常系_認証なし", func(t *testing.T) {
+		handler := user.NewUserHandler(&MockUserUsecase{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/password", strings.NewReader(`{}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.ChangePassword(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestUserHandler_SetPassword(t *testing.T) {
+	// newSetPasswordContext は認証済みのパスワード設定リクエストのコンテキストを生成する
+	newSetPasswordContext := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/password/set", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+		return c, w
+	}
+
+	t.Run("正常系_パスワード設定成功", func(t *testing.T) {
+		var gotNew vo.Password
+		mockUC := &MockUserUsecase{
+			SetPasswordFunc: func(ctx context.Context, userID vo.UserID, newPassword vo.Password) error {
+				gotNew = newPassword
+				return nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newSetPasswordContext(`{"newPassword": "newpassword456"}`)
+		handler.SetPassword(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		wantNew, _ := vo.NewPassword("newpassword456")
+		if gotNew != wantNew {
+			t.Error("password should be passed to usecase")
+		}
+	})
+
+	t.Run("異常系_パスワード設定済み", func(t *testing.T) {
+		mockUC := &MockUserUsecase{
+			SetPasswordFunc: func(ctx context.Context, userID vo.UserID, newPassword vo.Password) error {
+				return domainErrors.ErrPasswordAlreadySet
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newSetPasswordContext(`{"newPassword": "newpassword456"}`)
+		handler.SetPassword(c)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["code"] != "PASSWORD_ALREADY_SET" {
+			t.Errorf("code = %v, want PASSWORD_ALREADY_SET", response["code"])
+		}
+	})
+
+	t.Run("異常系_パスワードが短すぎる", func(t *testing.T) {
+		handler := user.NewUserHandler(&MockUserUsecase{})
+
+		c, w := newSetPasswordContext(`{"newPassword": "short"}`)
+		handler.SetPassword(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestUserHandler_ChangeEmail(t *testing.T) {
+	// newChangeEmailContext は認証済みのメールアドレス変更リクエストのコンテキストを生成する
+	newChangeEmailContext := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/email", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+		return c, w
+	}
+
+	t.Run("正常系_確認メール送信を受け付ける", func(t *testing.T) {
+		var gotEmail vo.Email
+		mockUC := &MockUserUsecase{
+			RequestEmailChangeFunc: func(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error {
+				gotEmail = newEmail
+				return nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newChangeEmailContext(`{"newEmail": "new@example.com", "password": "password123"}`)
+		handler.ChangeEmail(c)
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf("status = %d, want %d, body: %s", w.Code, http.StatusAccepted, w.Body.String())
+		}
+		if gotEmail.String() != "new@example.com" {
+			t.Errorf("newEmail = %s, want new@example.com", gotEmail.String())
+		}
+	})
+
+	t.Run("異常系_メールアドレス形式不正", func(t *testing.T) {
+		handler := user.NewUserHandler(&MockUserUsecase{})
+
+		c, w := newChangeEmailContext(`{"newEmail": "invalid", "password": "password123"}`)
+		handler.ChangeEmail(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("異常系_メールアドレス重複", func(t *testing.T) {
+		mockUC := &MockUserUsecase{
+			RequestEmailChangeFunc: func(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error {
+				return domainErrors.ErrEmailAlreadyExists
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		c, w := newChangeEmailContext(`{"newEmail": "new@example.com", "password": "password123"}`)
+		handler.ChangeEmail(c)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+	})
+}
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+	validToken, _ := vo.NewOneTimeToken()
+
+	t.Run("正常系_確認成功", func(t *testing.T) {
+		mockUC := &MockUserUsecase{
+			VerifyEmailFunc: func(ctx context.Context, token vo.OneTimeToken) error {
+				return nil
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/email/verify", strings.NewReader(`{"token": "`+validToken.String()+`"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.VerifyEmail(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+	})
+
+	t.Run("異常系_トークン無効", func(t *testing.T) {
+		mockUC := &MockUserUsecase{
+			VerifyEmailFunc: func(ctx context.Context, token vo.OneTimeToken) error {
+				return domainErrors.ErrEmailVerificationTokenInvalid
+			},
+		}
+		handler := user.NewUserHandler(mockUC)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/email/verify", strings.NewReader(`{"token": "`+validToken.String()+`"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.VerifyEmail(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["code"] != "INVALID_TOKEN" {
+			t.Errorf("code = %v, want INVALID_TOKEN", response["code"])
+		}
+	})
+}
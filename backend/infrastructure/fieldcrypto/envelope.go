@@ -0,0 +1,148 @@
+package fieldcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// dataKeySize はレコードごとのデータ鍵（DEK）のバイト数（AES-256）
+const dataKeySize = 32
+
+// ErrInvalidCiphertext は暗号文の形式が不正、または改ざんされている場合のエラー
+var ErrInvalidCiphertext = errors.New("invalid field ciphertext")
+
+// EnvelopeEncryptor はエンベロープ暗号化でフィールドを暗号化する
+// レコードごとにデータ鍵（DEK）を生成してフィールドを暗号化し、DEKはKeyProviderの鍵（KEK）で暗号化して保存する
+// 鍵のローテーションはDEKの再暗号化（Rewrap）のみで済み、フィールドを暗号化し直す必要はない
+type EnvelopeEncryptor struct {
+	keys KeyProvider
+}
+
+// NewEnvelopeEncryptor は EnvelopeEncryptor のインスタンスを生成する
+func NewEnvelopeEncryptor(keys KeyProvider) *EnvelopeEncryptor {
+	return &EnvelopeEncryptor{keys: keys}
+}
+
+// DataKey はレコード1件分の平文のデータ鍵
+type DataKey struct {
+	key []byte
+}
+
+// NewDataKey は新しいデータ鍵を生成し、現在のKEKで暗号化した文字列（"鍵ID:base64"形式）と合わせて返す
+func (e *EnvelopeEncryptor) NewDataKey() (*DataKey, string, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", err
+	}
+	wrapped, err := e.wrap(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return &DataKey{key: key}, wrapped, nil
+}
+
+// UnwrapDataKey は暗号化されたデータ鍵を復号する
+func (e *EnvelopeEncryptor) UnwrapDataKey(wrapped string) (*DataKey, error) {
+	keyID, ciphertext, ok := strings.Cut(wrapped, ":")
+	if !ok {
+		return nil, ErrInvalidCiphertext
+	}
+	kek, err := e.keys.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := open(kek, ciphertext, "data-key:"+keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{key: key}, nil
+}
+
+// NeedsRewrap は暗号化されたデータ鍵が現在のKEK以外で暗号化されているかを判定する
+func (e *EnvelopeEncryptor) NeedsRewrap(wrapped string) bool {
+	keyID, _, _ := strings.Cut(wrapped, ":")
+	return keyID != e.keys.CurrentKeyID()
+}
+
+// Rewrap は暗号化されたデータ鍵を現在のKEKで暗号化し直す（鍵のローテーション用）
+func (e *EnvelopeEncryptor) Rewrap(wrapped string) (string, error) {
+	dataKey, err := e.UnwrapDataKey(wrapped)
+	if err != nil {
+		return "", err
+	}
+	return e.wrap(dataKey.key)
+}
+
+// wrap はデータ鍵を現在のKEKで暗号化する
+func (e *EnvelopeEncryptor) wrap(key []byte) (string, error) {
+	keyID := e.keys.CurrentKeyID()
+	kek, err := e.keys.Key(keyID)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(kek, key, "data-key:"+keyID)
+	if err != nil {
+		return "", err
+	}
+	return keyID + ":" + ciphertext, nil
+}
+
+// Encrypt はフィールドの値を暗号化する
+// aadには別のレコード・別のフィールドへの暗号文の付け替えを防ぐため、レコードIDとフィールド名を渡す
+func (k *DataKey) Encrypt(plaintext, aad string) (string, error) {
+	return seal(k.key, []byte(plaintext), aad)
+}
+
+// Decrypt はフィールドの値を復号する
+func (k *DataKey) Decrypt(ciphertext, aad string) (string, error) {
+	plaintext, err := open(k.key, ciphertext, aad)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// seal はAES-GCMで暗号化し、nonceと暗号文を連結してbase64で返す
+func seal(key, plaintext []byte, aad string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(aad))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open はsealで暗号化した値を復号する
+func open(key []byte, encoded, aad string) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
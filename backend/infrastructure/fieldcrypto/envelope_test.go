@@ -0,0 +1,155 @@
+package fieldcrypto_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"caltrack/infrastructure/fieldcrypto"
+)
+
+func encodedKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func newEncryptor(t *testing.T, spec, currentKeyID string) *fieldcrypto.EnvelopeEncryptor {
+	t.Helper()
+	provider, err := fieldcrypto.NewEnvKeyProvider(spec, currentKeyID)
+	if err != nil {
+		t.Fatalf("NewEnvKeyProvider() error = %v", err)
+	}
+	return fieldcrypto.NewEnvelopeEncryptor(provider)
+}
+
+func TestEnvelopeEncryptor(t *testing.T) {
+	t.Run("正常系_暗号化した値をデータ鍵で復号できる", func(t *testing.T) {
+		enc := newEncryptor(t, "k1:"+encodedKey(1), "")
+
+		dataKey, wrapped, err := enc.NewDataKey()
+		if err != nil {
+			t.Fatalf("NewDataKey() error = %v", err)
+		}
+		if !strings.HasPrefix(wrapped, "k1:") {
+			t.Errorf("wrapped = %q, want k1 prefix", wrapped)
+		}
+		ciphertext, err := dataKey.Encrypt("1990-01-01", "user-1:birth_date")
+		if err != nil {
+			t.Fatalf("Encrypt() error = %v", err)
+		}
+
+		unwrapped, err := enc.UnwrapDataKey(wrapped)
+		if err != nil {
+			t.Fatalf("UnwrapDataKey() error = %v", err)
+		}
+		plaintext, err := unwrapped.Decrypt(ciphertext, "user-1:birth_date")
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if plaintext != "1990-01-01" {
+			t.Errorf("plaintext = %q, want 1990-01-01", plaintext)
+		}
+	})
+
+	t.Run("異常系_別のフィールドの暗号文は復号できない", func(t *testing.T) {
+		enc := newEncryptor(t, "k1:"+encodedKey(1), "")
+		dataKey, _, _ := enc.NewDataKey()
+		ciphertext, _ := dataKey.Encrypt("male", "user-1:gender")
+
+		_, err := dataKey.Decrypt(ciphertext, "user-2:gender")
+
+		if !errors.Is(err, fieldcrypto.ErrInvalidCiphertext) {
+			t.Errorf("got %v, want ErrInvalidCiphertext", err)
+		}
+	})
+
+	t.Run("正常系_鍵のローテーション後も古い鍵のデータ鍵を再暗号化して使える", func(t *testing.T) {
+		spec := "k1:" + encodedKey(1) + ",k2:" + encodedKey(2)
+		oldEnc := newEncryptor(t, spec, "k1")
+		dataKey, wrapped, _ := oldEnc.NewDataKey()
+		ciphertext, _ := dataKey.Encrypt("70.5", "user-1:weight")
+
+		newEnc := newEncryptor(t, spec, "k2")
+		if !newEnc.NeedsRewrap(wrapped) {
+			t.Fatal("NeedsRewrap() = false, want true")
+		}
+		rewrapped, err := newEnc.Rewrap(wrapped)
+		if err != nil {
+			t.Fatalf("Rewrap() error = %v", err)
+		}
+		if newEnc.NeedsRewrap(rewrapped) {
+			t.Error("NeedsRewrap() after rewrap = true, want false")
+		}
+
+		unwrapped, err := newEnc.UnwrapDataKey(rewrapped)
+		if err != nil {
+			t.Fatalf("UnwrapDataKey() error = %v", err)
+		}
+		plaintext, err := unwrapped.Decrypt(ciphertext, "user-1:weight")
+		if err != nil || plaintext != "70.5" {
+			t.Errorf("Decrypt() = %q, %v, want 70.5", plaintext, err)
+		}
+	})
+
+	t.Run("異常系_存在しない鍵IDのデータ鍵は復号できない", func(t *testing.T) {
+		enc := newEncryptor(t, "k1:"+encodedKey(1), "")
+		_, wrapped, _ := enc.NewDataKey()
+
+		other := newEncryptor(t, "k2:"+encodedKey(2), "")
+		_, err := other.UnwrapDataKey(wrapped)
+
+		if !errors.Is(err, fieldcrypto.ErrKeyNotFound) {
+			t.Errorf("got %v, want ErrKeyNotFound", err)
+		}
+	})
+}
+
+func TestKeyProvider(t *testing.T) {
+	t.Run("正常系_鍵ファイルから読み込める", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		content := `{"current": "k2", "keys": {"k1": "` + encodedKey(1) + `", "k2": "` + encodedKey(2) + `"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		provider, err := fieldcrypto.NewFileKeyProvider(path)
+		if err != nil {
+			t.Fatalf("NewFileKeyProvider() error = %v", err)
+		}
+		if provider.CurrentKeyID() != "k2" {
+			t.Errorf("CurrentKeyID() = %q, want k2", provider.CurrentKeyID())
+		}
+		if _, err := provider.Key("k1"); err != nil {
+			t.Errorf("Key(k1) error = %v", err)
+		}
+	})
+
+	t.Run("正常系_設定がない場合は暗号化しない", func(t *testing.T) {
+		enc, err := fieldcrypto.NewEncryptorFromSettings("", "", "")
+		if err != nil || enc != nil {
+			t.Errorf("NewEncryptorFromSettings() = %v, %v, want nil, nil", enc, err)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		spec         string
+		currentKeyID string
+		wantErr      error
+	}{
+		{"異常系_鍵が32バイトでない", "k1:" + base64.StdEncoding.EncodeToString([]byte("short")), "", fieldcrypto.ErrInvalidKey},
+		{"異常系_鍵がbase64でない", "k1:not-base64!", "", fieldcrypto.ErrInvalidKey},
+		{"異常系_現在の鍵IDが存在しない", "k1:" + encodedKey(1), "k9", fieldcrypto.ErrKeyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fieldcrypto.NewEnvKeyProvider(tt.spec, tt.currentKeyID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package fieldcrypto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// keySize は鍵暗号化鍵（KEK）のバイト数（AES-256）
+const keySize = 32
+
+var (
+	// ErrKeyNotFound は指定された鍵IDの鍵が存在しない場合のエラー
+	ErrKeyNotFound = errors.New("field encryption key not found")
+	// ErrInvalidKey は鍵の形式が不正な場合のエラー
+	ErrInvalidKey = errors.New("field encryption key must be 32 bytes encoded in base64")
+)
+
+// KeyProvider はデータ鍵を暗号化する鍵（KEK）を提供するインターフェース
+// 鍵のローテーションでは新しい鍵を現在の鍵にし、古い鍵も復号のために残しておく
+type KeyProvider interface {
+	// CurrentKeyID は新しく暗号化する際に使う鍵のIDを返す
+	CurrentKeyID() string
+	// Key は指定IDの鍵を返す（存在しない場合は ErrKeyNotFound）
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeyProvider は起動時に読み込んだ鍵を保持するKeyProvider
+type StaticKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+// NewStaticKeyProvider は鍵IDとbase64の鍵の組からStaticKeyProviderを生成する
+// currentKeyIDの鍵が存在しない場合や、鍵が32バイトでない場合はエラーを返す
+func NewStaticKeyProvider(currentKeyID string, encodedKeys map[string]string) (*StaticKeyProvider, error) {
+	keys := make(map[string][]byte, len(encodedKeys))
+	for id, encoded := range encodedKeys {
+		if id == "" || strings.ContainsAny(id, ":,") {
+			return nil, fmt.Errorf("invalid field encryption key id %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKey, id)
+		}
+		keys[id] = key
+	}
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("%w: current key %q", ErrKeyNotFound, currentKeyID)
+	}
+	return &StaticKeyProvider{currentKeyID: currentKeyID, keys: keys}, nil
+}
+
+// CurrentKeyID は新しく暗号化する際に使う鍵のIDを返す
+func (p *StaticKeyProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+// Key は指定IDの鍵を返す
+func (p *StaticKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// NewEnvKeyProvider は "鍵ID:base64鍵" をカンマ区切りで並べた文字列から鍵を読み込む
+// currentKeyIDが空の場合は先頭の鍵を現在の鍵にする
+func NewEnvKeyProvider(spec, currentKeyID string) (*StaticKeyProvider, error) {
+	encodedKeys := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("field encryption key must be in keyID:base64 format")
+		}
+		if currentKeyID == "" {
+			currentKeyID = id
+		}
+		encodedKeys[id] = encoded
+	}
+	return NewStaticKeyProvider(currentKeyID, encodedKeys)
+}
+
+// keyFile は鍵ファイルのJSON形式
+//
+//	{"current": "2025-01", "keys": {"2024-01": "base64...", "2025-01": "base64..."}}
+type keyFile struct {
+	Current string            `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// NewFileKeyProvider はJSONの鍵ファイルから鍵を読み込む
+// Kubernetes SecretやDocker secretをファイルとしてマウントする運用を想定している
+func NewFileKeyProvider(path string) (*StaticKeyProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f keyFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse field encryption key file: %w", err)
+	}
+	return NewStaticKeyProvider(f.Current, f.Keys)
+}
+
+// NewEncryptorFromSettings は鍵の設定からEnvelopeEncryptorを生成する
+// keyFileが指定されていれば鍵ファイルを、なければkeysの文字列を使う
+// どちらも未設定の場合はnilを返す（暗号化しない）
+func NewEncryptorFromSettings(keyFile, keys, currentKeyID string) (*EnvelopeEncryptor, error) {
+	var (
+		provider *StaticKeyProvider
+		err      error
+	)
+	switch {
+	case keyFile != "":
+		provider, err = NewFileKeyProvider(keyFile)
+	case keys != "":
+		provider, err = NewEnvKeyProvider(keys, currentKeyID)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewEnvelopeEncryptor(provider), nil
+}
@@ -0,0 +1,143 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormAccountDeletionRepository はAccountDeletionRepositoryのGORM実装
+type GormAccountDeletionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAccountDeletionRepository は新しいGormAccountDeletionRepositoryを生成する
+func NewGormAccountDeletionRepository(db *gorm.DB) *GormAccountDeletionRepository {
+	return &GormAccountDeletionRepository{db: db}
+}
+
+// Save は新しい削除の申請を保存する
+func (r *GormAccountDeletionRepository) Save(ctx context.Context, deletion *entity.AccountDeletion) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toAccountDeletionModel(deletion)
+	if err != nil {
+		logError("Save", err, "account_deletion_id", deletion.ID().String())
+		return err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "account_deletion_id", deletion.ID().String())
+		return err
+	}
+	return nil
+}
+
+// Update は削除の申請の取り消し・削除完了を保存する
+func (r *GormAccountDeletionRepository) Update(ctx context.Context, deletion *entity.AccountDeletion) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toAccountDeletionModel(deletion)
+	if err != nil {
+		logError("Update", err, "account_deletion_id", deletion.ID().String())
+		return err
+	}
+	err = tx.Model(&model.AccountDeletion{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]any{
+			"cancelled_at": m.CancelledAt,
+			"purged_at":    m.PurgedAt,
+			"purged_rows":  m.PurgedRows,
+		}).Error
+	if err != nil {
+		logError("Update", err, "account_deletion_id", deletion.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindPendingByUserID はユーザーの削除待ちの申請を取得する
+func (r *GormAccountDeletionRepository) FindPendingByUserID(ctx context.Context, userID vo.UserID) (*entity.AccountDeletion, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.AccountDeletion
+	err := tx.Where("user_id = ? AND cancelled_at IS NULL AND purged_at IS NULL", userID.String()).
+		Order("requested_at DESC").
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindPendingByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+	return toAccountDeletionEntity(&m)
+}
+
+// FindDue は猶予期間が終わった削除待ちの申請を予定日時の古い順に取得する
+func (r *GormAccountDeletionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.AccountDeletion, error) {
+	tx := GetTx(ctx, r.db)
+	var models []model.AccountDeletion
+	err := tx.Where("scheduled_at <= ? AND cancelled_at IS NULL AND purged_at IS NULL", now).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		logError("FindDue", err)
+		return nil, err
+	}
+
+	deletions := make([]*entity.AccountDeletion, 0, len(models))
+	for i := range models {
+		deletion, err := toAccountDeletionEntity(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+	return deletions, nil
+}
+
+// toAccountDeletionModel はエンティティをGORMモデルに変換する
+func toAccountDeletionModel(deletion *entity.AccountDeletion) (model.AccountDeletion, error) {
+	m := model.AccountDeletion{
+		ID:          deletion.ID().String(),
+		UserID:      deletion.UserID().String(),
+		RequestedAt: deletion.RequestedAt(),
+		ScheduledAt: deletion.ScheduledAt(),
+		CancelledAt: deletion.CancelledAt(),
+		PurgedAt:    deletion.PurgedAt(),
+	}
+	if deletion.PurgedRows() != nil {
+		b, err := json.Marshal(deletion.PurgedRows())
+		if err != nil {
+			return model.AccountDeletion{}, err
+		}
+		purgedRows := string(b)
+		m.PurgedRows = &purgedRows
+	}
+	return m, nil
+}
+
+// toAccountDeletionEntity はGORMモデルをエンティティに変換する
+func toAccountDeletionEntity(m *model.AccountDeletion) (*entity.AccountDeletion, error) {
+	var purgedRows map[string]int64
+	if m.PurgedRows != nil {
+		if err := json.Unmarshal([]byte(*m.PurgedRows), &purgedRows); err != nil {
+			logError("toAccountDeletionEntity", err, "account_deletion_id", m.ID)
+			return nil, err
+		}
+	}
+	return entity.ReconstructAccountDeletion(
+		m.ID,
+		m.UserID,
+		m.RequestedAt,
+		m.ScheduledAt,
+		m.CancelledAt,
+		m.PurgedAt,
+		purgedRows,
+	), nil
+}
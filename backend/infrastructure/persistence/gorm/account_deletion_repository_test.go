@@ -0,0 +1,143 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormAccountDeletionRepository_Save(t *testing.T) {
+	t.Run("正常系_削除の申請が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), 14*24*time.Hour)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `account_deletions`")).
+			WithArgs(
+				deletion.ID().String(),
+				deletion.UserID().String(),
+				deletion.RequestedAt(),
+				deletion.ScheduledAt(),
+				nil, // cancelled_at
+				nil, // purged_at
+				nil, // purged_rows
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), deletion); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `account_deletions`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), entity.NewAccountDeletion(vo.NewUserID(), 0)); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormAccountDeletionRepository_Update(t *testing.T) {
+	t.Run("正常系_削除件数がJSONで保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		deletion := entity.NewAccountDeletion(vo.NewUserID(), 0)
+		_ = deletion.MarkPurged(map[string]int64{"records": 2, "users": 1})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `account_deletions` SET `cancelled_at`=?,`purged_at`=?,`purged_rows`=? WHERE id = ?")).
+			WithArgs(nil, deletion.PurgedAt(), `{"records":2,"users":1}`, deletion.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Update(context.Background(), deletion); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	})
+}
+
+func TestGormAccountDeletionRepository_FindPendingByUserID(t *testing.T) {
+	t.Run("正常系_削除待ちの申請が見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		userID := vo.NewUserID()
+		now := time.Now()
+		rows := sqlmock.NewRows(accountDeletionColumns()).
+			AddRow(vo.NewAccountDeletionID().String(), userID.String(), now, now.Add(time.Hour), nil, nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `account_deletions` WHERE user_id = ? AND cancelled_at IS NULL AND purged_at IS NULL ORDER BY requested_at DESC")).
+			WithArgs(userID.String(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindPendingByUserID(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("FindPendingByUserID() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found deletion should not be nil")
+		}
+		if !found.IsPending() {
+			t.Error("found deletion should be pending")
+		}
+	})
+
+	t.Run("正常系_削除待ちがない場合はnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		userID := vo.NewUserID()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `account_deletions`")).
+			WithArgs(userID.String(), 1).
+			WillReturnRows(sqlmock.NewRows(accountDeletionColumns()))
+
+		found, err := repo.FindPendingByUserID(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("FindPendingByUserID() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found deletion should be nil")
+		}
+	})
+}
+
+func TestGormAccountDeletionRepository_FindDue(t *testing.T) {
+	t.Run("正常系_猶予期間が終わった申請を取得する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountDeletionRepository(db)
+
+		now := time.Now()
+		rows := sqlmock.NewRows(accountDeletionColumns()).
+			AddRow(vo.NewAccountDeletionID().String(), vo.NewUserID().String(), now.Add(-48*time.Hour), now.Add(-time.Hour), nil, nil, nil).
+			AddRow(vo.NewAccountDeletionID().String(), vo.NewUserID().String(), now.Add(-24*time.Hour), now.Add(-time.Minute), nil, nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `account_deletions` WHERE scheduled_at <= ? AND cancelled_at IS NULL AND purged_at IS NULL ORDER BY scheduled_at ASC LIMIT ?")).
+			WithArgs(now, 100).
+			WillReturnRows(rows)
+
+		deletions, err := repo.FindDue(context.Background(), now, 100)
+		if err != nil {
+			t.Fatalf("FindDue() error = %v", err)
+		}
+		if len(deletions) != 2 {
+			t.Errorf("len(deletions) = %d, want 2", len(deletions))
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// recordIDsOfUser は指定ユーザーの記録IDを返すサブクエリ
+const recordIDsOfUser = "record_id IN (SELECT id FROM records WHERE user_id = ?)"
+
+// userAuditEvents は指定ユーザー本人の監査イベントを表す条件
+// 管理者による操作の記録は対象ユーザーの削除後も管理者の行動記録として残す
+const userAuditEvents = "user_id = ? AND action NOT LIKE 'admin.%'"
+
+// GormAccountPurgeRepository はAccountPurgeRepositoryのGORM実装
+type GormAccountPurgeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAccountPurgeRepository は新しいGormAccountPurgeRepositoryを生成する
+func NewGormAccountPurgeRepository(db *gorm.DB) *GormAccountPurgeRepository {
+	return &GormAccountPurgeRepository{db: db}
+}
+
+// purgeStep は1テーブル分の削除を表す
+type purgeStep struct {
+	table string
+	model any
+	where string
+}
+
+// PurgeByUserID はユーザーと関連データを全て削除する
+// 外部キーのCASCADEに頼らず、子テーブルから順に明示的に削除して件数を記録する
+// トランザクション内で呼び出すこと
+func (r *GormAccountPurgeRepository) PurgeByUserID(ctx context.Context, userID vo.UserID) (map[string]int64, error) {
+	tx := GetTx(ctx, r.db)
+
+	var user model.User
+	err := tx.Where("id = ?", userID.String()).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logError("PurgeByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	steps := []purgeStep{
+		{"record_pfcs", &model.RecordPfc{}, recordIDsOfUser},
+		{"record_items", &model.RecordItem{}, recordIDsOfUser},
+		{"records", &model.Record{}, "user_id = ?"},
+		{"daily_summaries", &model.DailySummary{}, "user_id = ?"},
+		{"advice_caches", &model.AdviceCache{}, "user_id = ?"},
+		{"ai_usages", &model.AIUsage{}, "user_id = ?"},
+		{"target_snapshots", &model.TargetSnapshot{}, "user_id = ?"},
+		{"user_badges", &model.UserBadge{}, "user_id = ?"},
+		{"sessions", &model.Session{}, "user_id = ?"},
+		{"personal_access_tokens", &model.PersonalAccessToken{}, "user_id = ?"},
+		{"password_reset_tokens", &model.PasswordResetToken{}, "user_id = ?"},
+		{"email_verification_tokens", &model.EmailVerificationToken{}, "user_id = ?"},
+		{"two_factor_challenges", &model.TwoFactorChallenge{}, "user_id = ?"},
+		{"recovery_codes", &model.RecoveryCode{}, "user_id = ?"},
+		{"user_totps", &model.UserTOTP{}, "user_id = ?"},
+		{"user_identities", &model.UserIdentity{}, "user_id = ?"},
+		{"profile_changes", &model.ProfileChange{}, "user_id = ?"},
+		{"audit_events", &model.AuditEvent{}, userAuditEvents},
+		{"users", &model.User{}, "id = ?"},
+	}
+
+	purged := make(map[string]int64, len(steps)+1)
+	for _, step := range steps {
+		result := tx.Where(step.where, userID.String()).Delete(step.model)
+		if result.Error != nil {
+			logError("PurgeByUserID", result.Error, "user_id", userID.String(), "table", step.table)
+			return nil, result.Error
+		}
+		purged[step.table] = result.RowsAffected
+	}
+
+	// ログイン失敗回数はメールアドレスをキーに保持しているため、ユーザーが存在した場合のみ削除する
+	if user.ID != "" {
+		if email, err := vo.NewEmail(user.Email); err == nil {
+			result := tx.Where("attempt_key = ?", entity.EmailLoginAttemptKey(email)).Delete(&model.LoginAttempt{})
+			if result.Error != nil {
+				logError("PurgeByUserID", result.Error, "user_id", userID.String(), "table", "login_attempts")
+				return nil, result.Error
+			}
+			purged["login_attempts"] = result.RowsAffected
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,81 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormAccountPurgeRepository_PurgeByUserID(t *testing.T) {
+	// expectDelete は1テーブル分の削除を期待する
+	expectDelete := func(mock sqlmock.Sqlmock, query string, arg string, rows int64) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(arg).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+		mock.ExpectCommit()
+	}
+
+	t.Run("正常系_関連テーブルを順に削除して件数を返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountPurgeRepository(db)
+
+		user := testUser(t)
+		userID := user.ID().String()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ?")).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID, user.Email().String()))
+
+		expectDelete(mock, "DELETE FROM `record_pfcs` WHERE record_id IN (SELECT id FROM records WHERE user_id = ?)", userID, 2)
+		expectDelete(mock, "DELETE FROM `record_items` WHERE record_id IN (SELECT id FROM records WHERE user_id = ?)", userID, 5)
+		expectDelete(mock, "DELETE FROM `records` WHERE user_id = ?", userID, 3)
+		for _, table := range []string{
+			"daily_summaries", "advice_caches", "ai_usages", "target_snapshots", "user_badges", "sessions",
+			"personal_access_tokens", "password_reset_tokens", "email_verification_tokens",
+			"two_factor_challenges", "recovery_codes", "user_totps", "user_identities",
+			"profile_changes",
+		} {
+			expectDelete(mock, "DELETE FROM `"+table+"` WHERE user_id = ?", userID, 1)
+		}
+		// 管理者による操作の監査イベントは削除しない
+		expectDelete(mock, "DELETE FROM `audit_events` WHERE user_id = ? AND action NOT LIKE 'admin.%'", userID, 1)
+		expectDelete(mock, "DELETE FROM `users` WHERE id = ?", userID, 1)
+		expectDelete(mock, "DELETE FROM `login_attempts` WHERE attempt_key = ?", "email:"+user.Email().String(), 0)
+
+		purged, err := repo.PurgeByUserID(context.Background(), user.ID())
+		if err != nil {
+			t.Fatalf("PurgeByUserID() error = %v", err)
+		}
+		if purged["records"] != 3 || purged["record_items"] != 5 || purged["users"] != 1 {
+			t.Errorf("purged = %v, want records=3 record_items=5 users=1", purged)
+		}
+		if _, ok := purged["login_attempts"]; !ok {
+			t.Error("login_attempts should be recorded")
+		}
+	})
+
+	t.Run("異常系_削除に失敗した場合はエラーを返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAccountPurgeRepository(db)
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ?")).
+			WithArgs(user.ID().String(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(user.ID().String(), user.Email().String()))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `record_pfcs`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if _, err := repo.PurgeByUserID(context.Background(), user.ID()); err == nil {
+			t.Error("PurgeByUserID() should fail with db error")
+		}
+	})
+}
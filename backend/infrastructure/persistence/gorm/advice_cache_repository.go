@@ -57,6 +57,72 @@ func (r *GormAdviceCacheRepository) DeleteByUserIDAndDate(ctx context.Context, u
 	return nil
 }
 
+// FindCacheDatesByUserIDAndDateRange はstartDate以上、endDate未満でキャッシュが存在する日付を取得する
+func (r *GormAdviceCacheRepository) FindCacheDatesByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]time.Time, error) {
+	tx := GetTx(ctx, r.db)
+
+	var dates []time.Time
+	err := tx.Model(&model.AdviceCache{}).
+		Where("user_id = ? AND cache_date >= ? AND cache_date < ?", userID.String(), startDate, endDate).
+		Order("cache_date ASC").
+		Pluck("cache_date", &dates).Error
+	if err != nil {
+		logError("FindCacheDatesByUserIDAndDateRange", err, "user_id", userID.String())
+		return nil, err
+	}
+	return dates, nil
+}
+
+// ForEachBatchByUserID は指定ユーザーの全アドバイスを日付の昇順でbatchSize件ずつ取得し、fnに渡す
+// OFFSETを使わず、直前のバッチの最後の (cache_date, id) より後を取得する
+func (r *GormAdviceCacheRepository) ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func(caches []*entity.AdviceCache) error) error {
+	tx := GetTx(ctx, r.db)
+
+	var last *model.AdviceCache
+	for {
+		query := tx.Where("user_id = ?", userID.String())
+		if last != nil {
+			query = query.Where("cache_date > ? OR (cache_date = ? AND id > ?)", last.CacheDate, last.CacheDate, last.ID)
+		}
+
+		var models []model.AdviceCache
+		err := query.Order("cache_date ASC, id ASC").
+			Limit(batchSize).
+			Find(&models).Error
+		if err != nil {
+			logError("ForEachBatchByUserID", err, "user_id", userID.String())
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		caches := make([]*entity.AdviceCache, len(models))
+		for i := range models {
+			caches[i] = toAdviceCacheEntity(&models[i])
+		}
+		if err := fn(caches); err != nil {
+			return err
+		}
+
+		if len(models) < batchSize {
+			return nil
+		}
+		last = &models[len(models)-1]
+	}
+}
+
+// DeleteCreatedBefore は作成日時がbeforeより前の全ユーザーのアドバイスを削除する
+func (r *GormAdviceCacheRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := GetTx(ctx, r.db)
+	result := tx.Where("created_at < ?", before).Delete(&model.AdviceCache{})
+	if result.Error != nil {
+		logError("DeleteCreatedBefore", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 func toAdviceCacheModel(cache *entity.AdviceCache) model.AdviceCache {
 	return model.AdviceCache{
 		ID:        cache.ID().String(),
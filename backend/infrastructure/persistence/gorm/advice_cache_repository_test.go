@@ -216,3 +216,101 @@ func TestGormAdviceCacheRepository_DeleteByUserIDAndDate(t *testing.T) {
 		}
 	})
 }
+
+// ============================================================================
+// FindCacheDatesByUserIDAndDateRange テスト
+// ============================================================================
+
+func TestGormAdviceCacheRepository_FindCacheDatesByUserIDAndDateRange(t *testing.T) {
+	t.Run("正常系_キャッシュが存在する日付が取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAdviceCacheRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows([]string{"cache_date"}).
+			AddRow(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)).
+			AddRow(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `cache_date` FROM `advice_caches` WHERE user_id = ? AND cache_date >= ? AND cache_date < ? ORDER BY cache_date ASC")).
+			WithArgs(user.ID().String(), startDate, endDate).
+			WillReturnRows(rows)
+
+		dates, err := repo.FindCacheDatesByUserIDAndDateRange(ctx, user.ID(), startDate, endDate)
+		if err != nil {
+			t.Fatalf("FindCacheDatesByUserIDAndDateRange() error = %v", err)
+		}
+		if len(dates) != 2 {
+			t.Fatalf("len(dates) = %d, want 2", len(dates))
+		}
+		if dates[1].Day() != 10 {
+			t.Errorf("dates[1].Day() = %d, want 10", dates[1].Day())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAdviceCacheRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `cache_date` FROM `advice_caches`")).
+			WithArgs(user.ID().String(), startDate, endDate).
+			WillReturnError(errors.New("db error"))
+
+		dates, err := repo.FindCacheDatesByUserIDAndDateRange(ctx, user.ID(), startDate, endDate)
+		if err == nil {
+			t.Error("FindCacheDatesByUserIDAndDateRange() should fail with db error")
+		}
+		if dates != nil {
+			t.Error("dates should be nil on error")
+		}
+	})
+}
+
+// ============================================================================
+// DeleteCreatedBefore テスト
+// ============================================================================
+
+func TestGormAdviceCacheRepository_DeleteCreatedBefore(t *testing.T) {
+	t.Run("正常系_作成日時が古いキャッシュが削除され件数を返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAdviceCacheRepository(db)
+
+		before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `advice_caches` WHERE created_at < ?")).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteCreatedBefore(context.Background(), before)
+		if err != nil {
+			t.Fatalf("DeleteCreatedBefore() error = %v", err)
+		}
+		if deleted != 4 {
+			t.Errorf("DeleteCreatedBefore() = %d, want 4", deleted)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAdviceCacheRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `advice_caches` WHERE created_at < ?")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if _, err := repo.DeleteCreatedBefore(context.Background(), time.Now()); err == nil {
+			t.Error("DeleteCreatedBefore() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormAIUsageRepository はAIUsageRepositoryのGORM実装
+type GormAIUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAIUsageRepository は新しいGormAIUsageRepositoryを生成する
+func NewGormAIUsageRepository(db *gorm.DB) *GormAIUsageRepository {
+	return &GormAIUsageRepository{db: db}
+}
+
+// Save はAI使用履歴を保存する
+func (r *GormAIUsageRepository) Save(ctx context.Context, usage *entity.AIUsage) error {
+	tx := GetTx(ctx, r.db)
+	m := model.AIUsage{
+		ID:           usage.ID().String(),
+		UserID:       usage.UserID().String(),
+		Feature:      string(usage.Feature()),
+		Model:        usage.Model(),
+		PromptTokens: usage.PromptTokens(),
+		OutputTokens: usage.OutputTokens(),
+		TotalTokens:  usage.TotalTokens(),
+		OccurredAt:   usage.OccurredAt(),
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "ai_usage_id", m.ID)
+		return err
+	}
+	return nil
+}
+
+// SummarizeByUserID は指定ユーザーのsince以降の使用量をAI機能ごとに集計する
+func (r *GormAIUsageRepository) SummarizeByUserID(ctx context.Context, userID vo.UserID, since time.Time) ([]repository.AIUsageSummary, error) {
+	tx := GetTx(ctx, r.db)
+
+	type featureSummary struct {
+		Feature      string
+		Requests     int64
+		PromptTokens int64
+		OutputTokens int64
+		TotalTokens  int64
+	}
+	var results []featureSummary
+
+	err := tx.Model(&model.AIUsage{}).
+		Select("feature, COUNT(*) as requests, COALESCE(SUM(prompt_tokens), 0) as prompt_tokens, COALESCE(SUM(output_tokens), 0) as output_tokens, COALESCE(SUM(total_tokens), 0) as total_tokens").
+		Where("user_id = ? AND occurred_at >= ?", userID.String(), since).
+		Group("feature").
+		Order("feature ASC").
+		Find(&results).Error
+	if err != nil {
+		logError("SummarizeByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	summaries := make([]repository.AIUsageSummary, len(results))
+	for i, r := range results {
+		summaries[i] = repository.AIUsageSummary{
+			Feature:      entity.AIFeature(r.Feature),
+			Requests:     r.Requests,
+			PromptTokens: r.PromptTokens,
+			OutputTokens: r.OutputTokens,
+			TotalTokens:  r.TotalTokens,
+		}
+	}
+	return summaries, nil
+}
@@ -0,0 +1,95 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormAIUsageRepository_Save(t *testing.T) {
+	t.Run("正常系_AI使用履歴が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAIUsageRepository(db)
+
+		userID := vo.NewUserID()
+		usage := entity.NewAIUsage(userID, entity.AIFeatureImageAnalysis, "gemini-2.0-flash", 1200, 80, 1280, time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `ai_usages`")).
+			WithArgs(usage.ID().String(), userID.String(), "image_analysis", "gemini-2.0-flash", 1200, 80, 1280, usage.OccurredAt()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), usage); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAIUsageRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `ai_usages`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		usage := entity.NewAIUsage(vo.NewUserID(), entity.AIFeaturePfcEstimation, "gemini-2.0-flash", 10, 5, 15, time.Now())
+		if err := repo.Save(context.Background(), usage); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormAIUsageRepository_SummarizeByUserID(t *testing.T) {
+	query := "SELECT feature, COUNT(*) as requests, COALESCE(SUM(prompt_tokens), 0) as prompt_tokens, COALESCE(SUM(output_tokens), 0) as output_tokens, COALESCE(SUM(total_tokens), 0) as total_tokens FROM `ai_usages` WHERE user_id = ? AND occurred_at >= ? GROUP BY `feature` ORDER BY feature ASC"
+
+	t.Run("正常系_AI機能ごとに集計される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAIUsageRepository(db)
+
+		userID := vo.NewUserID()
+		since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows([]string{"feature", "requests", "prompt_tokens", "output_tokens", "total_tokens"}).
+			AddRow("image_analysis", 3, 3600, 240, 3840).
+			AddRow("nutrition_advice", 1, 500, 200, 700)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID.String(), since).
+			WillReturnRows(rows)
+
+		summaries, err := repo.SummarizeByUserID(context.Background(), userID, since)
+		if err != nil {
+			t.Fatalf("SummarizeByUserID() error = %v", err)
+		}
+		if len(summaries) != 2 {
+			t.Fatalf("len(summaries) = %d, want 2", len(summaries))
+		}
+		if summaries[0].Feature != entity.AIFeatureImageAnalysis || summaries[0].Requests != 3 || summaries[0].TotalTokens != 3840 {
+			t.Errorf("summaries[0] = %+v", summaries[0])
+		}
+		if summaries[1].Feature != entity.AIFeatureNutritionAdvice || summaries[1].PromptTokens != 500 || summaries[1].OutputTokens != 200 {
+			t.Errorf("summaries[1] = %+v", summaries[1])
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAIUsageRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.SummarizeByUserID(context.Background(), vo.NewUserID(), time.Now()); err == nil {
+			t.Error("SummarizeByUserID() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormAuditEventRepository はAuditEventRepositoryのGORM実装
+type GormAuditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditEventRepository は新しいGormAuditEventRepositoryを生成する
+func NewGormAuditEventRepository(db *gorm.DB) *GormAuditEventRepository {
+	return &GormAuditEventRepository{db: db}
+}
+
+// Save は監査イベントを保存する
+func (r *GormAuditEventRepository) Save(ctx context.Context, event *entity.AuditEvent) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toAuditEventModel(event)
+	if err != nil {
+		logError("Save", err, "audit_event_id", event.ID().String())
+		return err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "audit_event_id", event.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByUserID は指定ユーザーのアカウントの監査イベントを発生日時の新しい順にlimit件取得する
+func (r *GormAuditEventRepository) FindByUserID(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+	tx := GetTx(ctx, r.db)
+	query := tx.Where("user_id = ?", userID.String())
+	if before != nil {
+		query = query.Where("occurred_at < ?", *before)
+	}
+
+	var models []model.AuditEvent
+	err := query.Order("occurred_at DESC").Limit(limit).Find(&models).Error
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	events := make([]*entity.AuditEvent, 0, len(models))
+	for i := range models {
+		event, err := toAuditEventEntity(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// toAuditEventModel はエンティティをGORMモデルに変換する
+func toAuditEventModel(event *entity.AuditEvent) (model.AuditEvent, error) {
+	m := model.AuditEvent{
+		ID:         event.ID().String(),
+		Action:     string(event.Action()),
+		UserID:     optionalUserIDString(event.UserID()),
+		ActorID:    optionalUserIDString(event.ActorID()),
+		TargetType: event.TargetType(),
+		TargetID:   event.TargetID(),
+		IPAddress:  event.IPAddress(),
+		UserAgent:  event.UserAgent(),
+		OccurredAt: event.OccurredAt(),
+	}
+	if event.Diff() != nil {
+		b, err := json.Marshal(event.Diff())
+		if err != nil {
+			return model.AuditEvent{}, err
+		}
+		diff := string(b)
+		m.Diff = &diff
+	}
+	return m, nil
+}
+
+// toAuditEventEntity はGORMモデルをエンティティに変換する
+func toAuditEventEntity(m *model.AuditEvent) (*entity.AuditEvent, error) {
+	var diff map[string]any
+	if m.Diff != nil {
+		if err := json.Unmarshal([]byte(*m.Diff), &diff); err != nil {
+			logError("toAuditEventEntity", err, "audit_event_id", m.ID)
+			return nil, err
+		}
+	}
+	return entity.ReconstructAuditEvent(
+		m.ID,
+		m.Action,
+		m.UserID,
+		m.ActorID,
+		m.TargetType,
+		m.TargetID,
+		m.IPAddress,
+		m.UserAgent,
+		diff,
+		m.OccurredAt,
+	), nil
+}
+
+// optionalUserIDString はnilを許容するユーザーIDを文字列のポインタに変換する
+func optionalUserIDString(id *vo.UserID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
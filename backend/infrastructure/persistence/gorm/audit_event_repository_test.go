@@ -0,0 +1,128 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormAuditEventRepository_Save(t *testing.T) {
+	t.Run("正常系_監査イベントが差分のJSONとともに保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAuditEventRepository(db)
+
+		userID := vo.NewUserID()
+		event := entity.NewUserAuditEvent(entity.AuditActionProfileUpdate, userID, entity.AuditTargetUser, userID.String(),
+			map[string]any{"nickname": map[string]any{"old": "a", "new": "b"}})
+		event.AttachClient(entity.SessionClient{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_events`")).
+			WithArgs(
+				event.ID().String(),
+				"profile.update",
+				userID.String(),
+				userID.String(),
+				"user",
+				userID.String(),
+				"192.0.2.1",
+				"Mozilla/5.0",
+				`{"nickname":{"new":"b","old":"a"}}`,
+				event.OccurredAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("正常系_ユーザーが特定できないイベントはuser_idとactor_idがNULLになる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAuditEventRepository(db)
+
+		event := entity.NewAuditEvent(entity.AuditActionLoginFailed, nil, nil, "", "", nil)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_events`")).
+			WithArgs(event.ID().String(), "auth.login_failed", nil, nil, "", "", "", "", nil, event.OccurredAt()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAuditEventRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_events`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		event := entity.NewUserAuditEvent(entity.AuditActionLogout, vo.NewUserID(), "", "", nil)
+		if err := repo.Save(context.Background(), event); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormAuditEventRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_beforeより前のイベントを新しい順に取得する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAuditEventRepository(db)
+
+		userID := vo.NewUserID()
+		before := time.Now()
+		occurredAt := before.Add(-time.Hour)
+
+		rows := sqlmock.NewRows(auditEventColumns()).
+			AddRow("e-1", "auth.login", userID.String(), userID.String(), "session", "", "192.0.2.1", "curl", nil, occurredAt).
+			AddRow("e-2", "data.export", userID.String(), nil, "user", userID.String(), "", "", `{"files":6}`, occurredAt.Add(-time.Hour))
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_events` WHERE user_id = ? AND occurred_at < ? ORDER BY occurred_at DESC LIMIT ?")).
+			WithArgs(userID.String(), before, 20).
+			WillReturnRows(rows)
+
+		events, err := repo.FindByUserID(context.Background(), userID, &before, 20)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("len(events) = %d, want 2", len(events))
+		}
+		if events[0].Action() != entity.AuditActionLogin || events[0].IPAddress() != "192.0.2.1" {
+			t.Errorf("events[0] = %+v", events[0])
+		}
+		if events[1].ActorID() != nil {
+			t.Errorf("events[1].ActorID() = %v, want nil", events[1].ActorID())
+		}
+		if events[1].Diff()["files"] != float64(6) {
+			t.Errorf("events[1].Diff() = %v, want files", events[1].Diff())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormAuditEventRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_events`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindByUserID(context.Background(), vo.NewUserID(), nil, 20); err == nil {
+			t.Error("FindByUserID() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package gorm
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/helper"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// upsertDailySummaryQuery は日別サマリーを作成し、既にあれば集計値を更新する
+const upsertDailySummaryQuery = `
+INSERT INTO daily_summaries (user_id, summary_date, calories, protein, fat, carbs, record_count, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	calories = VALUES(calories),
+	protein = VALUES(protein),
+	fat = VALUES(fat),
+	carbs = VALUES(carbs),
+	record_count = VALUES(record_count),
+	updated_at = VALUES(updated_at)`
+
+// GormDailySummaryRepository はDailySummaryRepositoryのGORM実装
+type GormDailySummaryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDailySummaryRepository は新しいGormDailySummaryRepositoryを生成する
+func NewGormDailySummaryRepository(db *gorm.DB) *GormDailySummaryRepository {
+	return &GormDailySummaryRepository{db: db}
+}
+
+// Refresh は指定ユーザーの指定日の記録を再集計してサマリーを更新する
+// 記録が1件もない日はサマリーを削除する
+func (r *GormDailySummaryRepository) Refresh(ctx context.Context, userID vo.UserID, date time.Time) error {
+	tx := GetTx(ctx, r.db)
+	endDate := date.AddDate(0, 0, 1)
+
+	// カロリーと記録件数（JOINで重複するためRecord件数はDISTINCTで数える）
+	type calorieSum struct {
+		TotalCalories int
+		RecordCount   int
+	}
+	var calories calorieSum
+	err := tx.Table("records").
+		Select("COALESCE(SUM(record_items.calories), 0) as total_calories, COUNT(DISTINCT records.id) as record_count").
+		Joins("LEFT JOIN record_items ON records.id = record_items.record_id").
+		Where("records.user_id = ? AND records.eaten_at >= ? AND records.eaten_at < ?", userID.String(), date, endDate).
+		Scan(&calories).Error
+	if err != nil {
+		logError("Refresh", err, "user_id", userID.String())
+		return err
+	}
+
+	if calories.RecordCount == 0 {
+		err := tx.Where("user_id = ? AND summary_date = ?", userID.String(), toDateColumn(date)).Delete(&model.DailySummary{}).Error
+		if err != nil {
+			logError("Refresh", err, "user_id", userID.String())
+			return err
+		}
+		return nil
+	}
+
+	// PFC合計
+	type pfcSum struct {
+		TotalProtein float64
+		TotalFat     float64
+		TotalCarbs   float64
+	}
+	var pfc pfcSum
+	err = tx.Table("records").
+		Select("COALESCE(SUM(record_pfcs.protein), 0) as total_protein, COALESCE(SUM(record_pfcs.fat), 0) as total_fat, COALESCE(SUM(record_pfcs.carbs), 0) as total_carbs").
+		Joins("INNER JOIN record_pfcs ON records.id = record_pfcs.record_id").
+		Where("records.user_id = ? AND records.eaten_at >= ? AND records.eaten_at < ?", userID.String(), date, endDate).
+		Scan(&pfc).Error
+	if err != nil {
+		logError("Refresh", err, "user_id", userID.String())
+		return err
+	}
+
+	err = tx.Exec(upsertDailySummaryQuery,
+		userID.String(), toDateColumn(date),
+		calories.TotalCalories, pfc.TotalProtein, pfc.TotalFat, pfc.TotalCarbs, calories.RecordCount,
+		time.Now(),
+	).Error
+	if err != nil {
+		logError("Refresh", err, "user_id", userID.String())
+		return err
+	}
+
+	return nil
+}
+
+// RebuildByUserID は指定ユーザーのサマリーを記録から作り直す
+// 記録がある日（JST）ごとに Refresh と同じ集計を行い、日付の区切りを記録の作成時の更新と揃える
+// 記録がある最初の日より前のサマリーは、保存期間を過ぎて記録を削除した期間の集計として残す
+func (r *GormDailySummaryRepository) RebuildByUserID(ctx context.Context, userID vo.UserID) error {
+	if err := r.rebuildFromRecords(ctx, userID); err != nil {
+		logError("RebuildByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// RebuildAll は記録があるユーザーごとに RebuildByUserID と同じくサマリーを作り直す
+// 記録が1件もないユーザーのサマリーは、保存期間を過ぎて記録を削除した期間の集計として残す
+func (r *GormDailySummaryRepository) RebuildAll(ctx context.Context) error {
+	tx := GetTx(ctx, r.db)
+
+	var userIDs []string
+	if err := tx.Model(&model.Record{}).Distinct().Order("user_id").Pluck("user_id", &userIDs).Error; err != nil {
+		logError("RebuildAll", err)
+		return err
+	}
+
+	for _, id := range userIDs {
+		if err := r.rebuildFromRecords(ctx, vo.ReconstructUserID(id)); err != nil {
+			logError("RebuildAll", err, "user_id", id)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildFromRecords は指定ユーザーの記録がある最初の日以降のサマリーを削除し、記録がある全ての日を Refresh で集計する
+func (r *GormDailySummaryRepository) rebuildFromRecords(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+
+	dates, err := r.findRecordDates(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("user_id = ? AND summary_date >= ?", userID.String(), toDateColumn(dates[0])).Delete(&model.DailySummary{}).Error; err != nil {
+		return err
+	}
+
+	for _, date := range dates {
+		if err := r.Refresh(ctx, userID, date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findRecordDates は指定ユーザーの記録がある日（JSTの0時）を昇順で取得する
+// 日付はDBのタイムゾーン（DATE関数）ではなく、アプリケーションと同じJSTで区切る
+func (r *GormDailySummaryRepository) findRecordDates(ctx context.Context, userID vo.UserID) ([]time.Time, error) {
+	tx := GetTx(ctx, r.db)
+
+	var eatenAts []time.Time
+	if err := tx.Model(&model.Record{}).Where("user_id = ?", userID.String()).Pluck("eaten_at", &eatenAts).Error; err != nil {
+		return nil, err
+	}
+
+	seen := make(map[time.Time]struct{})
+	dates := make([]time.Time, 0, len(eatenAts))
+	for _, eatenAt := range eatenAts {
+		jst := eatenAt.In(helper.JST())
+		date := time.Date(jst.Year(), jst.Month(), jst.Day(), 0, 0, 0, 0, helper.JST())
+		if _, ok := seen[date]; ok {
+			continue
+		}
+		seen[date] = struct{}{}
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates, nil
+}
+
+// FindByUserID は指定ユーザーの全期間のサマリーを日付の昇順で取得する
+func (r *GormDailySummaryRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.DailySummary, error) {
+	tx := GetTx(ctx, r.db)
+
+	var models []model.DailySummary
+	err := tx.Where("user_id = ?", userID.String()).
+		Order("summary_date ASC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	summaries := make([]*entity.DailySummary, len(models))
+	for i := range models {
+		summaries[i] = toDailySummaryEntity(&models[i])
+	}
+	return summaries, nil
+}
+
+// FindByUserIDAndDateRange は指定期間（startDate以上、endDate未満）のサマリーを日付の昇順で取得する
+func (r *GormDailySummaryRepository) FindByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.DailySummary, error) {
+	tx := GetTx(ctx, r.db)
+
+	var models []model.DailySummary
+	err := tx.Where("user_id = ? AND summary_date >= ? AND summary_date < ?", userID.String(), toDateColumn(startDate), toDateColumn(endDate)).
+		Order("summary_date ASC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindByUserIDAndDateRange", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	summaries := make([]*entity.DailySummary, len(models))
+	for i := range models {
+		summaries[i] = toDailySummaryEntity(&models[i])
+	}
+	return summaries, nil
+}
+
+// toDailySummaryEntity はGORMモデルをエンティティに変換する
+func toDailySummaryEntity(m *model.DailySummary) *entity.DailySummary {
+	return entity.ReconstructDailySummary(
+		m.UserID,
+		fromDateColumn(m.SummaryDate),
+		m.Calories,
+		m.Protein,
+		m.Fat,
+		m.Carbs,
+		m.RecordCount,
+		m.UpdatedAt,
+	)
+}
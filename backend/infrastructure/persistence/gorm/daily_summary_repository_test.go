@@ -0,0 +1,419 @@
+package gorm_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/helper"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// ============================================================================
+// Refresh テスト
+// ============================================================================
+
+func TestGormDailySummaryRepository_Refresh(t *testing.T) {
+	t.Run("正常系_再集計した値でUPSERTされる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		date := time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST())
+		nextDate := date.AddDate(0, 0, 1)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_items.calories), 0) as total_calories, COUNT(DISTINCT records.id) as record_count FROM `records` LEFT JOIN record_items ON records.id = record_items.record_id WHERE")).
+			WithArgs(user.ID().String(), date, nextDate).
+			WillReturnRows(sqlmock.NewRows([]string{"total_calories", "record_count"}).AddRow(1200, 2))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_pfcs.protein), 0) as total_protein, COALESCE(SUM(record_pfcs.fat), 0) as total_fat, COALESCE(SUM(record_pfcs.carbs), 0) as total_carbs FROM `records` INNER JOIN record_pfcs ON records.id = record_pfcs.record_id WHERE")).
+			WithArgs(user.ID().String(), date, nextDate).
+			WillReturnRows(sqlmock.NewRows([]string{"total_protein", "total_fat", "total_carbs"}).AddRow(60.0, 40.0, 150.0))
+		// summary_dateは接続のタイムゾーンに左右されないようJSTの日付の文字列で渡す
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO daily_summaries")+"(?s).*"+regexp.QuoteMeta("ON DUPLICATE KEY UPDATE")).
+			WithArgs(
+				user.ID().String(),
+				"2025-03-10",
+				1200,
+				60.0,
+				40.0,
+				150.0,
+				2,
+				sqlmock.AnyArg(), // updated_at
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		if err := repo.Refresh(ctx, user.ID(), date); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("正常系_記録がない日はサマリーが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		date := time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST())
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_items.calories), 0) as total_calories")).
+			WithArgs(user.ID().String(), date, date.AddDate(0, 0, 1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total_calories", "record_count"}).AddRow(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `daily_summaries` WHERE user_id = ? AND summary_date = ?")).
+			WithArgs(user.ID().String(), "2025-03-10").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Refresh(ctx, user.ID(), date); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_集計時にDBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_items.calories), 0) as total_calories")).
+			WillReturnError(errors.New("db error"))
+
+		if err := repo.Refresh(ctx, user.ID(), time.Now()); err == nil {
+			t.Error("Refresh() should fail with db error")
+		}
+	})
+
+	t.Run("異常系_保存時にDBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_items.calories), 0) as total_calories")).
+			WillReturnRows(sqlmock.NewRows([]string{"total_calories", "record_count"}).AddRow(500, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_pfcs.protein), 0) as total_protein")).
+			WillReturnRows(sqlmock.NewRows([]string{"total_protein", "total_fat", "total_carbs"}).AddRow(0.0, 0.0, 0.0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO daily_summaries")).
+			WillReturnError(errors.New("db error"))
+
+		if err := repo.Refresh(ctx, user.ID(), time.Now()); err == nil {
+			t.Error("Refresh() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// RebuildByUserID テスト
+// ============================================================================
+
+// expectRefresh は Refresh が指定日に発行するクエリを登録し、保存されたsummary_dateをsummaryDateに記録する
+func expectRefresh(mock sqlmock.Sqlmock, userID string, date time.Time, summaryDate *driver.Value) {
+	nextDate := date.AddDate(0, 0, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_items.calories), 0) as total_calories")).
+		WithArgs(userID, date, nextDate).
+		WillReturnRows(sqlmock.NewRows([]string{"total_calories", "record_count"}).AddRow(500, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(record_pfcs.protein), 0) as total_protein")).
+		WithArgs(userID, date, nextDate).
+		WillReturnRows(sqlmock.NewRows([]string{"total_protein", "total_fat", "total_carbs"}).AddRow(20.0, 10.0, 60.0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO daily_summaries")).
+		WithArgs(userID, captureArg{summaryDate}, 500, 20.0, 10.0, 60.0, 1, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestGormDailySummaryRepository_RebuildByUserID(t *testing.T) {
+	t.Run("正常系_記録がある最初の日以降のサマリーを削除し記録がある日ごとに再集計する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		day1 := time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST())
+		day2 := day1.AddDate(0, 0, 1)
+
+		// 同じ日の記録は1回だけ集計し、日付の昇順で処理する
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `eaten_at` FROM `records` WHERE user_id = ?")).
+			WithArgs(user.ID().String()).
+			WillReturnRows(sqlmock.NewRows([]string{"eaten_at"}).
+				AddRow(day2.Add(12 * time.Hour)).
+				AddRow(day1.Add(8 * time.Hour)).
+				AddRow(day1.Add(19 * time.Hour)))
+		// 最初の日より前のサマリー（保存期間を過ぎて記録を削除した期間の集計）は残す
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `daily_summaries` WHERE user_id = ? AND summary_date >= ?")).
+			WithArgs(user.ID().String(), "2025-03-10").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+		var summaryDate1, summaryDate2 driver.Value
+		expectRefresh(mock, user.ID().String(), day1, &summaryDate1)
+		expectRefresh(mock, user.ID().String(), day2, &summaryDate2)
+
+		if err := repo.RebuildByUserID(ctx, user.ID()); err != nil {
+			t.Fatalf("RebuildByUserID() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("正常系_記録がない場合はサマリーを削除しない", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `eaten_at` FROM `records` WHERE user_id = ?")).
+			WithArgs(user.ID().String()).
+			WillReturnRows(sqlmock.NewRows([]string{"eaten_at"}))
+
+		if err := repo.RebuildByUserID(ctx, user.ID()); err != nil {
+			t.Fatalf("RebuildByUserID() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("正常系_日付の境界付近の記録は記録時の更新と同じ日に集計される", func(t *testing.T) {
+		user := testUser(t)
+		// JSTの23:30と翌0:15の食事（UTCではどちらも同じ日）
+		lateNight := time.Date(2025, 3, 10, 23, 30, 0, 0, helper.JST())
+		afterMidnight := time.Date(2025, 3, 11, 0, 15, 0, 0, helper.JST())
+		day1 := time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST())
+		day2 := day1.AddDate(0, 0, 1)
+
+		// 記録作成時の更新（RecordUsecaseは食事日時のJSTの0時を渡す）
+		refreshDB, refreshMock := setupMockDB(t)
+		refreshRepo := gormPkg.NewGormDailySummaryRepository(refreshDB)
+		var refreshed1, refreshed2 driver.Value
+		expectRefresh(refreshMock, user.ID().String(), day1, &refreshed1)
+		expectRefresh(refreshMock, user.ID().String(), day2, &refreshed2)
+		if err := refreshRepo.Refresh(context.Background(), user.ID(), day1); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+		if err := refreshRepo.Refresh(context.Background(), user.ID(), day2); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+
+		// 再構築
+		rebuildDB, rebuildMock := setupMockDB(t)
+		rebuildRepo := gormPkg.NewGormDailySummaryRepository(rebuildDB)
+		rebuildMock.ExpectQuery(regexp.QuoteMeta("SELECT `eaten_at` FROM `records`")).
+			WillReturnRows(sqlmock.NewRows([]string{"eaten_at"}).
+				AddRow(lateNight.UTC()).
+				AddRow(afterMidnight.UTC()))
+		rebuildMock.ExpectBegin()
+		rebuildMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `daily_summaries`")).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		rebuildMock.ExpectCommit()
+		var rebuilt1, rebuilt2 driver.Value
+		expectRefresh(rebuildMock, user.ID().String(), day1, &rebuilt1)
+		expectRefresh(rebuildMock, user.ID().String(), day2, &rebuilt2)
+		if err := rebuildRepo.RebuildByUserID(context.Background(), user.ID()); err != nil {
+			t.Fatalf("RebuildByUserID() error = %v", err)
+		}
+
+		if err := rebuildMock.ExpectationsWereMet(); err != nil {
+			t.Fatal(err)
+		}
+		if refreshed1 != rebuilt1 || refreshed2 != rebuilt2 {
+			t.Errorf("summary_date rebuilt = %v, %v, want %v, %v", rebuilt1, rebuilt2, refreshed1, refreshed2)
+		}
+		if rebuilt1 != "2025-03-10" || rebuilt2 != "2025-03-11" {
+			t.Errorf("summary_date = %v, %v, want 2025-03-10, 2025-03-11", rebuilt1, rebuilt2)
+		}
+	})
+
+	t.Run("異常系_削除時にDBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `eaten_at` FROM `records`")).
+			WillReturnRows(sqlmock.NewRows([]string{"eaten_at"}).AddRow(time.Now()))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `daily_summaries`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.RebuildByUserID(ctx, user.ID()); err == nil {
+			t.Error("RebuildByUserID() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// RebuildAll テスト
+// ============================================================================
+
+func TestGormDailySummaryRepository_RebuildAll(t *testing.T) {
+	t.Run("正常系_記録があるユーザーごとに最初の日以降のサマリーを作り直す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		day := time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST())
+		userID1 := testUser(t).ID().String()
+		userID2 := testUser(t).ID().String()
+
+		// 記録がないユーザーのサマリーは削除しない（全件削除は行わない）
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `user_id` FROM `records` ORDER BY user_id")).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID1).AddRow(userID2))
+		for _, userID := range []string{userID1, userID2} {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT `eaten_at` FROM `records` WHERE user_id = ?")).
+				WithArgs(userID).
+				WillReturnRows(sqlmock.NewRows([]string{"eaten_at"}).AddRow(day.Add(12 * time.Hour)))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `daily_summaries` WHERE user_id = ? AND summary_date >= ?")).
+				WithArgs(userID, "2025-03-10").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			var summaryDate driver.Value
+			expectRefresh(mock, userID, day, &summaryDate)
+		}
+
+		if err := repo.RebuildAll(ctx); err != nil {
+			t.Fatalf("RebuildAll() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("異常系_ユーザー取得時にDBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `user_id` FROM `records`")).
+			WillReturnError(errors.New("db error"))
+
+		if err := repo.RebuildAll(ctx); err == nil {
+			t.Error("RebuildAll() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// FindByUserID テスト
+// ============================================================================
+
+func TestGormDailySummaryRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_全期間のサマリーがJSTの日付で昇順に取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		now := time.Now()
+
+		rows := sqlmock.NewRows(dailySummaryColumns()).
+			AddRow(user.ID().String(), time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), 1800, 70.0, 50.0, 220.0, 3, now).
+			AddRow(user.ID().String(), time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), 900, 30.0, 20.0, 100.0, 1, now)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `daily_summaries` WHERE user_id = ? ORDER BY summary_date ASC")).
+			WithArgs(user.ID().String()).
+			WillReturnRows(rows)
+
+		summaries, err := repo.FindByUserID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(summaries) != 2 {
+			t.Fatalf("len(summaries) = %d, want 2", len(summaries))
+		}
+		wantDate := time.Date(2025, 3, 4, 0, 0, 0, 0, helper.JST())
+		if !summaries[1].Date().Equal(wantDate) {
+			t.Errorf("Date = %v, want %v", summaries[1].Date(), wantDate)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `daily_summaries`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindByUserID(context.Background(), user.ID()); err == nil {
+			t.Error("FindByUserID() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// FindByUserIDAndDateRange テスト
+// ============================================================================
+
+func TestGormDailySummaryRepository_FindByUserIDAndDateRange(t *testing.T) {
+	t.Run("正常系_期間内のサマリーが日付の昇順で取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, helper.JST())
+		endDate := time.Date(2025, 4, 1, 0, 0, 0, 0, helper.JST())
+		now := time.Now()
+
+		rows := sqlmock.NewRows(dailySummaryColumns()).
+			AddRow(user.ID().String(), time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), 1800, 70.0, 50.0, 220.0, 3, now).
+			AddRow(user.ID().String(), time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), 900, 30.0, 20.0, 100.0, 1, now)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `daily_summaries` WHERE user_id = ? AND summary_date >= ? AND summary_date < ? ORDER BY summary_date ASC")).
+			WithArgs(user.ID().String(), "2025-03-01", "2025-04-01").
+			WillReturnRows(rows)
+
+		summaries, err := repo.FindByUserIDAndDateRange(ctx, user.ID(), startDate, endDate)
+		if err != nil {
+			t.Fatalf("FindByUserIDAndDateRange() error = %v", err)
+		}
+		if len(summaries) != 2 {
+			t.Fatalf("len(summaries) = %d, want 2", len(summaries))
+		}
+		// DATE型は接続のタイムゾーン（UTC）の0時として読まれるため、JSTの同じ日付に揃える
+		wantDate := time.Date(2025, 3, 3, 0, 0, 0, 0, helper.JST())
+		if !summaries[0].Date().Equal(wantDate) {
+			t.Errorf("Date = %v, want %v", summaries[0].Date(), wantDate)
+		}
+		if summaries[0].Calories().Value() != 1800 {
+			t.Errorf("Calories = %d, want 1800", summaries[0].Calories().Value())
+		}
+		if summaries[0].Pfc().Protein() != 70.0 {
+			t.Errorf("Protein = %v, want 70.0", summaries[0].Pfc().Protein())
+		}
+		if summaries[0].RecordCount() != 3 {
+			t.Errorf("RecordCount = %d, want 3", summaries[0].RecordCount())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormDailySummaryRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `daily_summaries`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindByUserIDAndDateRange(ctx, user.ID(), time.Now(), time.Now()); err == nil {
+			t.Error("FindByUserIDAndDateRange() should fail with db error")
+		}
+	})
+}
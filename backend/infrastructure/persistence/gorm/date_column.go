@@ -0,0 +1,23 @@
+package gorm
+
+import (
+	"time"
+
+	"caltrack/domain/helper"
+)
+
+// dateColumnLayout はDATE型の列に渡す日付の書式
+const dateColumnLayout = "2006-01-02"
+
+// toDateColumn はJSTの日付をDATE型の列に渡す文字列に変換する
+// time.Timeのまま渡すとドライバーが接続のタイムゾーン（loc）に変換し、日付がずれるため文字列で渡す
+func toDateColumn(date time.Time) string {
+	return date.In(helper.JST()).Format(dateColumnLayout)
+}
+
+// fromDateColumn はDATE型の列から読み込んだ値を同じ年月日のJSTの0時に揃える
+// DATE型は接続のタイムゾーンの0時として読み込まれるため、タイムゾーンの変換はせずに年月日だけを使う
+func fromDateColumn(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, helper.JST())
+}
@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormEmailVerificationTokenRepository はEmailVerificationTokenRepositoryのGORM実装
+type GormEmailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEmailVerificationTokenRepository は新しいGormEmailVerificationTokenRepositoryを生成する
+func NewGormEmailVerificationTokenRepository(db *gorm.DB) *GormEmailVerificationTokenRepository {
+	return &GormEmailVerificationTokenRepository{db: db}
+}
+
+// Save はトークンを保存する
+func (r *GormEmailVerificationTokenRepository) Save(ctx context.Context, token *entity.EmailVerificationToken) error {
+	tx := GetTx(ctx, r.db)
+	m := toEmailVerificationTokenModel(token)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "email_verification_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash はトークンハッシュでトークンを取得する
+func (r *GormEmailVerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.EmailVerificationToken
+	err := tx.Where("token_hash = ?", tokenHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByTokenHash", err)
+		return nil, err
+	}
+	return toEmailVerificationTokenEntity(&m)
+}
+
+// MarkUsed はトークンの使用日時を保存する
+func (r *GormEmailVerificationTokenRepository) MarkUsed(ctx context.Context, token *entity.EmailVerificationToken) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.EmailVerificationToken{}).
+		Where("id = ?", token.ID().String()).
+		Update("used_at", token.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "email_verification_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID はユーザーの全トークンを削除する
+func (r *GormEmailVerificationTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.EmailVerificationToken{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toEmailVerificationTokenModel はエンティティをGORMモデルに変換する
+func toEmailVerificationTokenModel(token *entity.EmailVerificationToken) model.EmailVerificationToken {
+	return model.EmailVerificationToken{
+		ID:        token.ID().String(),
+		UserID:    token.UserID().String(),
+		Email:     token.Email().String(),
+		TokenHash: token.TokenHash(),
+		ExpiresAt: token.ExpiresAt(),
+		UsedAt:    token.UsedAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+}
+
+// toEmailVerificationTokenEntity はGORMモデルをエンティティに変換する
+func toEmailVerificationTokenEntity(m *model.EmailVerificationToken) (*entity.EmailVerificationToken, error) {
+	return entity.ReconstructEmailVerificationToken(
+		m.ID,
+		m.UserID,
+		m.Email,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
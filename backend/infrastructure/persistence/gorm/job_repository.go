@@ -0,0 +1,173 @@
+package gorm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// acquireJobLockQuery はロックが無いか期限切れの場合のみ、ロックの持ち主を自分に書き換える
+// （MySQLは代入を左から評価するため、locked_untilの判定には更新前の値が使われる）
+const acquireJobLockQuery = `INSERT INTO job_locks (job_name, owner, locked_until) VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	owner = IF(locked_until <= ?, VALUES(owner), owner),
+	locked_until = IF(locked_until <= ?, VALUES(locked_until), locked_until)`
+
+// GormJobRunRepository はJobRunRepositoryのGORM実装
+type GormJobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewGormJobRunRepository は新しいGormJobRunRepositoryを生成する
+func NewGormJobRunRepository(db *gorm.DB) *GormJobRunRepository {
+	return &GormJobRunRepository{db: db}
+}
+
+// Save は実行を開始したジョブの実行履歴を保存する
+func (r *GormJobRunRepository) Save(ctx context.Context, run *entity.JobRun) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toJobRunModel(run)
+	if err != nil {
+		logError("Save", err, "job_run_id", run.ID().String())
+		return err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "job_run_id", run.ID().String())
+		return err
+	}
+	return nil
+}
+
+// Update はジョブの実行結果を保存する
+func (r *GormJobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toJobRunModel(run)
+	if err != nil {
+		logError("Update", err, "job_run_id", run.ID().String())
+		return err
+	}
+	err = tx.Model(&model.JobRun{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]any{
+			"status":        m.Status,
+			"finished_at":   m.FinishedAt,
+			"result":        m.Result,
+			"error_message": m.ErrorMessage,
+		}).Error
+	if err != nil {
+		logError("Update", err, "job_run_id", run.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindLatestByJobName は指定ジョブの最後に開始した実行履歴を取得する
+func (r *GormJobRunRepository) FindLatestByJobName(ctx context.Context, jobName string) (*entity.JobRun, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.JobRun
+	err := tx.Where("job_name = ?", jobName).
+		Order("started_at DESC").
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindLatestByJobName", err, "job_name", jobName)
+		return nil, err
+	}
+	return toJobRunEntity(&m)
+}
+
+// GormJobLockRepository はJobLockRepositoryのGORM実装
+// job_locksテーブルの行をロックとして使い、複数台構成でもジョブの重複実行を防ぐ
+type GormJobLockRepository struct {
+	db *gorm.DB
+}
+
+// NewGormJobLockRepository は新しいGormJobLockRepositoryを生成する
+func NewGormJobLockRepository(db *gorm.DB) *GormJobLockRepository {
+	return &GormJobLockRepository{db: db}
+}
+
+// TryAcquire は指定ジョブのロックの取得を試みる
+func (r *GormJobLockRepository) TryAcquire(ctx context.Context, jobName, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Exec(acquireJobLockQuery, jobName, owner, now.Add(ttl), now, now).Error; err != nil {
+		logError("TryAcquire", err, "job_name", jobName)
+		return false, err
+	}
+
+	var m model.JobLock
+	if err := tx.Where("job_name = ?", jobName).First(&m).Error; err != nil {
+		logError("TryAcquire", err, "job_name", jobName)
+		return false, err
+	}
+	return m.Owner == owner, nil
+}
+
+// Release は自分が持っている指定ジョブのロックを解放する
+// 期限切れで他のプロセスに取得されていた場合は何もしない
+func (r *GormJobLockRepository) Release(ctx context.Context, jobName, owner string) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("job_name = ? AND owner = ?", jobName, owner).Delete(&model.JobLock{}).Error; err != nil {
+		logError("Release", err, "job_name", jobName)
+		return err
+	}
+	return nil
+}
+
+// toJobRunModel はエンティティをGORMモデルに変換する
+func toJobRunModel(run *entity.JobRun) (model.JobRun, error) {
+	m := model.JobRun{
+		ID:         run.ID().String(),
+		JobName:    run.JobName(),
+		Owner:      run.Owner(),
+		Status:     string(run.Status()),
+		StartedAt:  run.StartedAt(),
+		FinishedAt: run.FinishedAt(),
+	}
+	if run.Result() != nil {
+		b, err := json.Marshal(run.Result())
+		if err != nil {
+			return model.JobRun{}, err
+		}
+		result := string(b)
+		m.Result = &result
+	}
+	if run.ErrorMessage() != "" {
+		errorMessage := run.ErrorMessage()
+		m.ErrorMessage = &errorMessage
+	}
+	return m, nil
+}
+
+// toJobRunEntity はGORMモデルをエンティティに変換する
+func toJobRunEntity(m *model.JobRun) (*entity.JobRun, error) {
+	var result map[string]int64
+	if m.Result != nil {
+		if err := json.Unmarshal([]byte(*m.Result), &result); err != nil {
+			logError("toJobRunEntity", err, "job_run_id", m.ID)
+			return nil, err
+		}
+	}
+	var errorMessage string
+	if m.ErrorMessage != nil {
+		errorMessage = *m.ErrorMessage
+	}
+	return entity.ReconstructJobRun(
+		m.ID,
+		m.JobName,
+		m.Owner,
+		m.Status,
+		m.StartedAt,
+		m.FinishedAt,
+		result,
+		errorMessage,
+	), nil
+}
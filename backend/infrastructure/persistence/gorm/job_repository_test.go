@@ -0,0 +1,197 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormJobRunRepository_Save(t *testing.T) {
+	t.Run("正常系_実行中の履歴が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobRunRepository(db)
+
+		run := entity.NewJobRun("retention", "host:1", time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `job_runs`")).
+			WithArgs(
+				run.ID().String(),
+				"retention",
+				"host:1",
+				"running",
+				run.StartedAt(),
+				nil, // finished_at
+				nil, // result
+				nil, // error_message
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), run); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobRunRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `job_runs`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), entity.NewJobRun("retention", "host:1", time.Now())); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormJobRunRepository_Update(t *testing.T) {
+	t.Run("正常系_失敗した実行結果が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobRunRepository(db)
+
+		run := entity.NewJobRun("retention", "host:1", time.Now())
+		run.Fail(map[string]int64{"records": 2}, errors.New("db error"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `job_runs` SET `error_message`=?,`finished_at`=?,`result`=?,`status`=? WHERE id = ?")).
+			WithArgs("db error", run.FinishedAt(), `{"records":2}`, "failed", run.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Update(context.Background(), run); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	})
+}
+
+func TestGormJobRunRepository_FindLatestByJobName(t *testing.T) {
+	t.Run("正常系_最後に開始した実行履歴が見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobRunRepository(db)
+
+		startedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		finishedAt := startedAt.Add(time.Minute)
+		rows := sqlmock.NewRows(jobRunColumns()).
+			AddRow("run-1", "retention", "host:1", "succeeded", startedAt, finishedAt, `{"records":3}`, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs` WHERE job_name = ? ORDER BY started_at DESC")).
+			WithArgs("retention", 1).
+			WillReturnRows(rows)
+
+		run, err := repo.FindLatestByJobName(context.Background(), "retention")
+		if err != nil {
+			t.Fatalf("FindLatestByJobName() error = %v", err)
+		}
+		if run.Status() != entity.JobRunStatusSucceeded {
+			t.Errorf("Status() = %v, want %v", run.Status(), entity.JobRunStatusSucceeded)
+		}
+		if !run.StartedAt().Equal(startedAt) {
+			t.Errorf("StartedAt() = %v, want %v", run.StartedAt(), startedAt)
+		}
+		if run.Result()["records"] != 3 {
+			t.Errorf("Result()[records] = %d, want 3", run.Result()["records"])
+		}
+	})
+
+	t.Run("正常系_実行履歴がなければnil", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobRunRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs` WHERE job_name = ?")).
+			WithArgs("retention", 1).
+			WillReturnRows(sqlmock.NewRows(jobRunColumns()))
+
+		run, err := repo.FindLatestByJobName(context.Background(), "retention")
+		if err != nil {
+			t.Fatalf("FindLatestByJobName() error = %v", err)
+		}
+		if run != nil {
+			t.Errorf("FindLatestByJobName() = %+v, want nil", run)
+		}
+	})
+}
+
+func TestGormJobLockRepository_TryAcquire(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	ttl := time.Hour
+
+	t.Run("正常系_ロックの持ち主が自分になれば取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobLockRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO job_locks")).
+			WithArgs("retention", "host:1", now.Add(ttl), now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_locks` WHERE job_name = ?")).
+			WithArgs("retention", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"job_name", "owner", "locked_until"}).AddRow("retention", "host:1", now.Add(ttl)))
+
+		acquired, err := repo.TryAcquire(context.Background(), "retention", "host:1", now, ttl)
+		if err != nil {
+			t.Fatalf("TryAcquire() error = %v", err)
+		}
+		if !acquired {
+			t.Error("TryAcquire() = false, want true")
+		}
+	})
+
+	t.Run("正常系_他のプロセスが有効なロックを持っていれば取得できない", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobLockRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO job_locks")).
+			WithArgs("retention", "host:1", now.Add(ttl), now, now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_locks` WHERE job_name = ?")).
+			WithArgs("retention", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"job_name", "owner", "locked_until"}).AddRow("retention", "host:2", now.Add(time.Minute)))
+
+		acquired, err := repo.TryAcquire(context.Background(), "retention", "host:1", now, ttl)
+		if err != nil {
+			t.Fatalf("TryAcquire() error = %v", err)
+		}
+		if acquired {
+			t.Error("TryAcquire() = true, want false")
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobLockRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO job_locks")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.TryAcquire(context.Background(), "retention", "host:1", now, ttl); err == nil {
+			t.Error("TryAcquire() should fail with db error")
+		}
+	})
+}
+
+func TestGormJobLockRepository_Release(t *testing.T) {
+	t.Run("正常系_自分のロックが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormJobLockRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `job_locks` WHERE job_name = ? AND owner = ?")).
+			WithArgs("retention", "host:1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Release(context.Background(), "retention", "host:1"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+	})
+}
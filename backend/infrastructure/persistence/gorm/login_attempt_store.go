@@ -0,0 +1,77 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// recordLoginFailureQuery は失敗回数を原子的に加算する
+// 最後の失敗がリセット期間より前の場合は1回目として記録し直す
+// （MySQLは代入を左から評価するため、failuresの判定には更新前のlast_failed_atが使われる）
+const recordLoginFailureQuery = `INSERT INTO login_attempts (attempt_key, failures, last_failed_at) VALUES (?, 1, ?)
+ON DUPLICATE KEY UPDATE
+	failures = IF(last_failed_at <= ?, 1, failures + 1),
+	last_failed_at = VALUES(last_failed_at)`
+
+// GormLoginAttemptStore はLoginAttemptStoreのGORM実装
+// 複数台構成でも失敗回数を共有できる
+type GormLoginAttemptStore struct {
+	db *gorm.DB
+}
+
+// NewGormLoginAttemptStore は新しいGormLoginAttemptStoreを生成する
+func NewGormLoginAttemptStore(db *gorm.DB) *GormLoginAttemptStore {
+	return &GormLoginAttemptStore{db: db}
+}
+
+// Find は指定キーの失敗状況を取得する（記録がない場合はnil）
+func (s *GormLoginAttemptStore) Find(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	tx := GetTx(ctx, s.db)
+	var m model.LoginAttempt
+	err := tx.Where("attempt_key = ?", key).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("Find", err, "attempt_key", key)
+		return nil, err
+	}
+	return toLoginAttemptEntity(&m), nil
+}
+
+// RecordFailure は失敗を1回加算し、加算後の状況を返す
+func (s *GormLoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (*entity.LoginAttempt, error) {
+	tx := GetTx(ctx, s.db)
+	if err := tx.Exec(recordLoginFailureQuery, key, now, now.Add(-resetAfter)).Error; err != nil {
+		logError("RecordFailure", err, "attempt_key", key)
+		return nil, err
+	}
+
+	var m model.LoginAttempt
+	if err := tx.Where("attempt_key = ?", key).First(&m).Error; err != nil {
+		logError("RecordFailure", err, "attempt_key", key)
+		return nil, err
+	}
+	return toLoginAttemptEntity(&m), nil
+}
+
+// Reset は指定キーの失敗状況を削除する
+func (s *GormLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	tx := GetTx(ctx, s.db)
+	if err := tx.Where("attempt_key = ?", key).Delete(&model.LoginAttempt{}).Error; err != nil {
+		logError("Reset", err, "attempt_key", key)
+		return err
+	}
+	return nil
+}
+
+// toLoginAttemptEntity はGORMモデルをエンティティに変換する
+func toLoginAttemptEntity(m *model.LoginAttempt) *entity.LoginAttempt {
+	return entity.ReconstructLoginAttempt(m.AttemptKey, m.Failures, m.LastFailedAt)
+}
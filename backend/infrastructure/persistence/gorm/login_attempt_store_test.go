@@ -0,0 +1,141 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+const testLoginAttemptKey = "email:test@example.com"
+
+func TestGormLoginAttemptStore_Find(t *testing.T) {
+	t.Run("正常系_失敗状況が見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		lastFailedAt := time.Now().Truncate(time.Millisecond)
+		rows := sqlmock.NewRows(loginAttemptColumns()).
+			AddRow(testLoginAttemptKey, 4, lastFailedAt)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE attempt_key = ?")).
+			WithArgs(testLoginAttemptKey, 1).
+			WillReturnRows(rows)
+
+		attempt, err := store.Find(context.Background(), testLoginAttemptKey)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if attempt.Key() != testLoginAttemptKey {
+			t.Errorf("Key() = %s, want %s", attempt.Key(), testLoginAttemptKey)
+		}
+		if attempt.Failures() != 4 {
+			t.Errorf("Failures() = %d, want 4", attempt.Failures())
+		}
+		if !attempt.LastFailedAt().Equal(lastFailedAt) {
+			t.Errorf("LastFailedAt() = %v, want %v", attempt.LastFailedAt(), lastFailedAt)
+		}
+	})
+
+	t.Run("正常系_記録がなければnil", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE attempt_key = ?")).
+			WithArgs(testLoginAttemptKey, 1).
+			WillReturnRows(sqlmock.NewRows(loginAttemptColumns()))
+
+		attempt, err := store.Find(context.Background(), testLoginAttemptKey)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if attempt != nil {
+			t.Errorf("Find() = %+v, want nil", attempt)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := store.Find(context.Background(), testLoginAttemptKey); err == nil {
+			t.Error("Find() should fail with db error")
+		}
+	})
+}
+
+func TestGormLoginAttemptStore_RecordFailure(t *testing.T) {
+	t.Run("正常系_失敗回数を加算して加算後の状況を返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		now := time.Now().Truncate(time.Millisecond)
+		resetAfter := time.Hour
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_attempts")).
+			WithArgs(testLoginAttemptKey, now, now.Add(-resetAfter)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE attempt_key = ?")).
+			WithArgs(testLoginAttemptKey, 1).
+			WillReturnRows(sqlmock.NewRows(loginAttemptColumns()).AddRow(testLoginAttemptKey, 2, now))
+
+		attempt, err := store.RecordFailure(context.Background(), testLoginAttemptKey, now, resetAfter)
+		if err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+		if attempt.Failures() != 2 {
+			t.Errorf("Failures() = %d, want 2", attempt.Failures())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_attempts")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := store.RecordFailure(context.Background(), testLoginAttemptKey, time.Now(), time.Hour); err == nil {
+			t.Error("RecordFailure() should fail with db error")
+		}
+	})
+}
+
+func TestGormLoginAttemptStore_Reset(t *testing.T) {
+	t.Run("正常系_失敗状況が削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts` WHERE attempt_key = ?")).
+			WithArgs(testLoginAttemptKey).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := store.Reset(context.Background(), testLoginAttemptKey); err != nil {
+			t.Fatalf("Reset() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		store := gormPkg.NewGormLoginAttemptStore(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_attempts`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := store.Reset(context.Background(), testLoginAttemptKey); err == nil {
+			t.Error("Reset() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// AccountDeletion はアカウント削除の申請を保持するGORMモデル
+type AccountDeletion struct {
+	ID          string    `gorm:"primaryKey;size:36"`
+	UserID      string    `gorm:"size:36;not null;index"`
+	RequestedAt time.Time `gorm:"not null"`
+	ScheduledAt time.Time `gorm:"not null;index"`
+	CancelledAt *time.Time
+	PurgedAt    *time.Time
+	// PurgedRows はテーブル名ごとの削除件数のJSON
+	PurgedRows *string `gorm:"type:json"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (AccountDeletion) TableName() string {
+	return "account_deletions"
+}
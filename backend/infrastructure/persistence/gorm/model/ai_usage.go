@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// AIUsage はAI機能のトークン使用量を保持するGORMモデル
+type AIUsage struct {
+	ID           string    `gorm:"primaryKey;size:36"`
+	UserID       string    `gorm:"size:36;not null;index"`
+	Feature      string    `gorm:"size:30;not null"`
+	Model        string    `gorm:"size:100;not null"`
+	PromptTokens int       `gorm:"not null"`
+	OutputTokens int       `gorm:"not null"`
+	TotalTokens  int       `gorm:"not null"`
+	OccurredAt   time.Time `gorm:"not null"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (AIUsage) TableName() string {
+	return "ai_usages"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// AuditEvent は監査イベントを保持するGORMモデル
+type AuditEvent struct {
+	ID         string  `gorm:"primaryKey;size:36"`
+	Action     string  `gorm:"size:50;not null"`
+	UserID     *string `gorm:"size:36;index:idx_audit_events_user_id_occurred_at"`
+	ActorID    *string `gorm:"size:36;index"`
+	TargetType string  `gorm:"size:20;not null;default:''"`
+	TargetID   string  `gorm:"size:64;not null;default:''"`
+	IPAddress  string  `gorm:"size:45;not null;default:''"`
+	UserAgent  string  `gorm:"size:512;not null;default:''"`
+	// Diff は変更内容のJSON
+	Diff       *string   `gorm:"type:json"`
+	OccurredAt time.Time `gorm:"not null;index:idx_audit_events_user_id_occurred_at"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// DailySummary は日別の記録集計を保持するGORMモデル
+type DailySummary struct {
+	UserID      string    `gorm:"primaryKey;size:36"`
+	SummaryDate time.Time `gorm:"primaryKey;type:date"`
+	Calories    int       `gorm:"not null"`
+	Protein     float64   `gorm:"not null"`
+	Fat         float64   `gorm:"not null"`
+	Carbs       float64   `gorm:"not null"`
+	RecordCount int       `gorm:"not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (DailySummary) TableName() string {
+	return "daily_summaries"
+}
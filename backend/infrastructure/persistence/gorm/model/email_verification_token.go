@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// EmailVerificationToken はメールアドレス確認トークンを保持するGORMモデル
+type EmailVerificationToken struct {
+	ID        string    `gorm:"primaryKey;size:36"`
+	UserID    string    `gorm:"size:36;not null;index"`
+	Email     string    `gorm:"size:254;not null"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
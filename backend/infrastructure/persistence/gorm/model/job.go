@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// JobRun はジョブの実行履歴を保持するGORMモデル
+type JobRun struct {
+	ID         string    `gorm:"primaryKey;size:36"`
+	JobName    string    `gorm:"size:50;not null"`
+	Owner      string    `gorm:"size:100;not null"`
+	Status     string    `gorm:"size:20;not null"`
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt *time.Time
+	// Result は処理件数のJSON
+	Result       *string `gorm:"type:json"`
+	ErrorMessage *string `gorm:"type:text"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// JobLock はジョブのロックを保持するGORMモデル
+type JobLock struct {
+	JobName     string    `gorm:"primaryKey;size:50"`
+	Owner       string    `gorm:"size:100;not null"`
+	LockedUntil time.Time `gorm:"not null"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (JobLock) TableName() string {
+	return "job_locks"
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// LoginAttempt はログイン失敗の状況を保持するGORMモデル
+type LoginAttempt struct {
+	AttemptKey   string    `gorm:"primaryKey;size:300"`
+	Failures     int       `gorm:"not null"`
+	LastFailedAt time.Time `gorm:"not null;index"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
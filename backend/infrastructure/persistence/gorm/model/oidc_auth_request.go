@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// OIDCAuthRequest はOpenID Connectの認可リクエストを保持するGORMモデル
+type OIDCAuthRequest struct {
+	ID           string    `gorm:"primaryKey;size:36"`
+	Provider     string    `gorm:"size:50;not null"`
+	StateHash    string    `gorm:"size:64;not null;uniqueIndex"`
+	Nonce        string    `gorm:"size:64;not null"`
+	CodeVerifier string    `gorm:"size:64;not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	UsedAt       *time.Time
+	CreatedAt    time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (OIDCAuthRequest) TableName() string {
+	return "oidc_auth_requests"
+}
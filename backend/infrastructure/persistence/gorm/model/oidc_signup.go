@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// OIDCSignup はOpenID Connectでの登録待ちを保持するGORMモデル
+type OIDCSignup struct {
+	ID        string    `gorm:"primaryKey;size:36"`
+	Provider  string    `gorm:"size:50;not null"`
+	Subject   string    `gorm:"size:255;not null"`
+	Email     string    `gorm:"size:255;not null"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (OIDCSignup) TableName() string {
+	return "oidc_signups"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// PasswordResetToken はパスワードリセットトークンを保持するGORMモデル
+type PasswordResetToken struct {
+	ID        string    `gorm:"primaryKey;size:36"`
+	UserID    string    `gorm:"size:36;not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// PersonalAccessToken はパーソナルアクセストークンを保持するGORMモデル
+type PersonalAccessToken struct {
+	ID         string `gorm:"primaryKey;size:36"`
+	UserID     string `gorm:"size:36;not null;index"`
+	Name       string `gorm:"size:100;not null"`
+	TokenHash  string `gorm:"size:64;not null;uniqueIndex"`
+	Scopes     string `gorm:"size:255;not null"` // スペース区切りのスコープ一覧
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
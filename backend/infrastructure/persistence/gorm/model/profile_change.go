@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// ProfileChange はプロフィールの変更履歴を保持するGORMモデル
+// 暗号化が有効な場合、OldValue・NewValueには暗号文を保存し、EncryptedDataKeyに暗号化したデータ鍵を保存する
+type ProfileChange struct {
+	ID               string    `gorm:"primaryKey;size:36"`
+	UserID           string    `gorm:"size:36;not null;index:idx_profile_changes_user_id_changed_at"`
+	Field            string    `gorm:"size:50;not null"`
+	OldValue         string    `gorm:"size:255;not null"`
+	NewValue         string    `gorm:"size:255;not null"`
+	EncryptedDataKey *string   `gorm:"size:255"`
+	ChangedAt        time.Time `gorm:"not null;index:idx_profile_changes_user_id_changed_at"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (ProfileChange) TableName() string {
+	return "profile_changes"
+}
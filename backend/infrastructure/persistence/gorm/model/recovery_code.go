@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// RecoveryCode は2段階認証のリカバリーコードを保持するGORMモデル
+type RecoveryCode struct {
+	ID        string `gorm:"primaryKey;size:36"`
+	UserID    string `gorm:"size:36;not null;uniqueIndex:uk_recovery_codes_user_id_code_hash"`
+	CodeHash  string `gorm:"size:64;not null;uniqueIndex:uk_recovery_codes_user_id_code_hash"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
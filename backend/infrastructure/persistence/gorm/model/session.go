@@ -4,8 +4,11 @@ import "time"
 
 // Session はセッション情報を保持するGORMモデル
 type Session struct {
-	ID        string    `gorm:"primaryKey;size:44"` // Base64エンコードされたSessionID（44文字）
-	UserID    string    `gorm:"index;size:36;not null"`
-	ExpiresAt time.Time `gorm:"index;not null"`
-	CreatedAt time.Time
+	ID         string    `gorm:"primaryKey;size:44"` // Base64エンコードされたSessionID（44文字）
+	UserID     string    `gorm:"index;size:36;not null"`
+	ExpiresAt  time.Time `gorm:"index;not null"`
+	UserAgent  string    `gorm:"size:512;not null"`
+	IPAddress  string    `gorm:"column:ip_address;size:45;not null"`
+	LastSeenAt time.Time `gorm:"not null"`
+	CreatedAt  time.Time
 }
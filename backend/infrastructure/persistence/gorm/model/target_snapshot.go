@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// TargetSnapshot は目標値スナップショットを保持するGORMモデル
+type TargetSnapshot struct {
+	ID             string    `gorm:"primaryKey;size:36"`
+	UserID         string    `gorm:"size:36;not null;index:uk_target_snapshots_user_date,unique"`
+	EffectiveDate  time.Time `gorm:"type:date;not null;index:uk_target_snapshots_user_date,unique"`
+	TargetCalories int       `gorm:"not null"`
+	Protein        float64   `gorm:"not null"`
+	Fat            float64   `gorm:"not null"`
+	Carbs          float64   `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (TargetSnapshot) TableName() string {
+	return "target_snapshots"
+}
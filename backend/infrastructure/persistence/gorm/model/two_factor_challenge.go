@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// TwoFactorChallenge は2段階認証チャレンジを保持するGORMモデル
+type TwoFactorChallenge struct {
+	ID        string    `gorm:"primaryKey;size:36"`
+	UserID    string    `gorm:"size:36;not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (TwoFactorChallenge) TableName() string {
+	return "two_factor_challenges"
+}
@@ -2,17 +2,33 @@ package model
 
 import "time"
 
+// User はユーザー情報を保持するGORMモデル
+// 暗号化が有効な場合、健康データ（体重・身長・生年月日・性別）は *Encrypted 列に保存し、平文の列はNULLにする
 type User struct {
-	ID             string    `gorm:"primaryKey;size:36"`
-	Email          string    `gorm:"uniqueIndex;size:254;not null"`
-	HashedPassword string    `gorm:"size:60;not null"`
-	Nickname       string    `gorm:"size:50;not null"`
-	Weight         float64   `gorm:"not null"`
-	Height         float64   `gorm:"not null"`
-	BirthDate      time.Time `gorm:"not null"`
-	Gender         string    `gorm:"size:10;not null"`
-	ActivityLevel  string    `gorm:"size:20;not null"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Records        []Record `gorm:"foreignKey:UserID"`
+	ID                    string `gorm:"primaryKey;size:36"`
+	Email                 string `gorm:"uniqueIndex;size:254;not null"`
+	HashedPassword        string `gorm:"size:60;not null"`
+	Nickname              string `gorm:"size:50;not null"`
+	Weight                *float64
+	Height                *float64
+	BirthDate             *time.Time
+	Gender                *string `gorm:"size:10"`
+	EncryptedDataKey      *string `gorm:"size:255"`
+	WeightEncrypted       *string `gorm:"size:255"`
+	HeightEncrypted       *string `gorm:"size:255"`
+	BirthDateEncrypted    *string `gorm:"size:255"`
+	GenderEncrypted       *string `gorm:"size:255"`
+	ActivityLevel         string  `gorm:"size:20;not null"`
+	BudgetMode            string  `gorm:"size:10;not null;default:daily"`
+	AIProcessingEnabled   bool    `gorm:"column:ai_processing_enabled;not null;default:true"`
+	AnalyticsEnabled      bool    `gorm:"not null;default:false"`
+	DataRetentionYears    int     `gorm:"not null;default:0"`
+	PrivacyConsentVersion *string `gorm:"size:20"`
+	PrivacyConsentedAt    *time.Time
+	Role                  string `gorm:"size:20;not null;default:user"`
+	DisabledAt            *time.Time
+	EmailVerifiedAt       *time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Records               []Record `gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// UserBadge は獲得バッジを保持するGORMモデル
+type UserBadge struct {
+	ID         string    `gorm:"primaryKey;size:36"`
+	UserID     string    `gorm:"size:36;not null;index:uk_user_badges_user_type,unique"`
+	BadgeType  string    `gorm:"size:50;not null;index:uk_user_badges_user_type,unique"`
+	UnlockedAt time.Time `gorm:"not null"`
+}
+
+// TableName はテーブル名を明示的に指定する
+func (UserBadge) TableName() string {
+	return "user_badges"
+}
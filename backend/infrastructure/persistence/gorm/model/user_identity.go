@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// UserIdentity は外部IDプロバイダーとの連携を保持するGORMモデル
+type UserIdentity struct {
+	ID        string `gorm:"primaryKey;size:36"`
+	UserID    string `gorm:"size:36;not null;index"`
+	Provider  string `gorm:"size:50;not null;uniqueIndex:uk_user_identities_provider_subject"`
+	Subject   string `gorm:"size:255;not null;uniqueIndex:uk_user_identities_provider_subject"`
+	Email     string `gorm:"size:255;not null"`
+	CreatedAt time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
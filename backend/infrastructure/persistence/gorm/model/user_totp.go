@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// UserTOTP はユーザーのTOTP設定を保持するGORMモデル
+type UserTOTP struct {
+	UserID       string `gorm:"primaryKey;size:36"`
+	Secret       string `gorm:"size:64;not null"`
+	EnabledAt    *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TableName はテーブル名を明示的に指定する
+func (UserTOTP) TableName() string {
+	return "user_totps"
+}
@@ -0,0 +1,88 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormOIDCAuthRequestRepository はOIDCAuthRequestRepositoryのGORM実装
+type GormOIDCAuthRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOIDCAuthRequestRepository は新しいGormOIDCAuthRequestRepositoryを生成する
+func NewGormOIDCAuthRequestRepository(db *gorm.DB) *GormOIDCAuthRequestRepository {
+	return &GormOIDCAuthRequestRepository{db: db}
+}
+
+// Save は認可リクエストを保存する
+func (r *GormOIDCAuthRequestRepository) Save(ctx context.Context, req *entity.OIDCAuthRequest) error {
+	tx := GetTx(ctx, r.db)
+	m := toOIDCAuthRequestModel(req)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "oidc_auth_request_id", req.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByStateHash はstateのハッシュで認可リクエストを取得する
+func (r *GormOIDCAuthRequestRepository) FindByStateHash(ctx context.Context, stateHash string) (*entity.OIDCAuthRequest, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.OIDCAuthRequest
+	err := tx.Where("state_hash = ?", stateHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByStateHash", err)
+		return nil, err
+	}
+	return toOIDCAuthRequestEntity(&m), nil
+}
+
+// MarkUsed は認可リクエストの使用日時を保存する
+func (r *GormOIDCAuthRequestRepository) MarkUsed(ctx context.Context, req *entity.OIDCAuthRequest) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.OIDCAuthRequest{}).
+		Where("id = ?", req.ID().String()).
+		Update("used_at", req.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "oidc_auth_request_id", req.ID().String())
+		return err
+	}
+	return nil
+}
+
+// toOIDCAuthRequestModel はエンティティをGORMモデルに変換する
+func toOIDCAuthRequestModel(req *entity.OIDCAuthRequest) model.OIDCAuthRequest {
+	return model.OIDCAuthRequest{
+		ID:           req.ID().String(),
+		Provider:     req.Provider(),
+		StateHash:    req.StateHash(),
+		Nonce:        req.Nonce(),
+		CodeVerifier: req.CodeVerifier(),
+		ExpiresAt:    req.ExpiresAt(),
+		UsedAt:       req.UsedAt(),
+		CreatedAt:    req.CreatedAt(),
+	}
+}
+
+// toOIDCAuthRequestEntity はGORMモデルをエンティティに変換する
+func toOIDCAuthRequestEntity(m *model.OIDCAuthRequest) *entity.OIDCAuthRequest {
+	return entity.ReconstructOIDCAuthRequest(
+		m.ID,
+		m.Provider,
+		m.StateHash,
+		m.Nonce,
+		m.CodeVerifier,
+		m.ExpiresAt,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
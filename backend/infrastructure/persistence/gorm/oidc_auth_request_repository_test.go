@@ -0,0 +1,142 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// testOIDCAuthRequest はテスト用OIDCAuthRequestとその平文stateを生成する
+func testOIDCAuthRequest(t *testing.T) (*entity.OIDCAuthRequest, vo.OneTimeToken) {
+	t.Helper()
+	req, state, err := entity.NewOIDCAuthRequest("google")
+	if err != nil {
+		t.Fatalf("failed to create test oidc auth request: %v", err)
+	}
+	return req, state
+}
+
+func TestGormOIDCAuthRequestRepository_Save(t *testing.T) {
+	t.Run("正常系_認可リクエストが保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCAuthRequestRepository(db)
+
+		req, _ := testOIDCAuthRequest(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `oidc_auth_requests`")).
+			WithArgs(
+				req.ID().String(),
+				"google",
+				req.StateHash(),
+				req.Nonce(),
+				req.CodeVerifier(),
+				req.ExpiresAt(),
+				nil, // used_at
+				req.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), req); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCAuthRequestRepository(db)
+
+		req, _ := testOIDCAuthRequest(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `oidc_auth_requests`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), req); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormOIDCAuthRequestRepository_FindByStateHash(t *testing.T) {
+	t.Run("正常系_stateのハッシュで認可リクエストが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCAuthRequestRepository(db)
+
+		req, state := testOIDCAuthRequest(t)
+
+		rows := sqlmock.NewRows(oidcAuthRequestColumns()).
+			AddRow(
+				req.ID().String(),
+				"google",
+				req.StateHash(),
+				req.Nonce(),
+				req.CodeVerifier(),
+				req.ExpiresAt(),
+				nil,
+				req.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `oidc_auth_requests` WHERE state_hash = ?")).
+			WithArgs(state.Hash(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByStateHash(context.Background(), state.Hash())
+		if err != nil {
+			t.Fatalf("FindByStateHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found request should not be nil")
+		}
+		if found.CodeVerifier() != req.CodeVerifier() || found.Nonce() != req.Nonce() {
+			t.Error("nonce and code verifier should be restored")
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCAuthRequestRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `oidc_auth_requests` WHERE state_hash = ?")).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows(oidcAuthRequestColumns()))
+
+		found, err := repo.FindByStateHash(context.Background(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByStateHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found request should be nil")
+		}
+	})
+}
+
+func TestGormOIDCAuthRequestRepository_MarkUsed(t *testing.T) {
+	t.Run("正常系_使用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCAuthRequestRepository(db)
+
+		req, _ := testOIDCAuthRequest(t)
+		if err := req.Use("google"); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `oidc_auth_requests` SET `used_at`=? WHERE id = ?")).
+			WithArgs(*req.UsedAt(), req.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.MarkUsed(context.Background(), req); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+	})
+}
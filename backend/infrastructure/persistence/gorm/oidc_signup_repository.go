@@ -0,0 +1,88 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormOIDCSignupRepository はOIDCSignupRepositoryのGORM実装
+type GormOIDCSignupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOIDCSignupRepository は新しいGormOIDCSignupRepositoryを生成する
+func NewGormOIDCSignupRepository(db *gorm.DB) *GormOIDCSignupRepository {
+	return &GormOIDCSignupRepository{db: db}
+}
+
+// Save は登録待ちを保存する
+func (r *GormOIDCSignupRepository) Save(ctx context.Context, signup *entity.OIDCSignup) error {
+	tx := GetTx(ctx, r.db)
+	m := toOIDCSignupModel(signup)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "oidc_signup_id", signup.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash はトークンハッシュで登録待ちを取得する
+func (r *GormOIDCSignupRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.OIDCSignup, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.OIDCSignup
+	err := tx.Where("token_hash = ?", tokenHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByTokenHash", err)
+		return nil, err
+	}
+	return toOIDCSignupEntity(&m), nil
+}
+
+// MarkUsed は登録待ちの使用日時を保存する
+func (r *GormOIDCSignupRepository) MarkUsed(ctx context.Context, signup *entity.OIDCSignup) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.OIDCSignup{}).
+		Where("id = ?", signup.ID().String()).
+		Update("used_at", signup.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "oidc_signup_id", signup.ID().String())
+		return err
+	}
+	return nil
+}
+
+// toOIDCSignupModel はエンティティをGORMモデルに変換する
+func toOIDCSignupModel(signup *entity.OIDCSignup) model.OIDCSignup {
+	return model.OIDCSignup{
+		ID:        signup.ID().String(),
+		Provider:  signup.Provider(),
+		Subject:   signup.Subject(),
+		Email:     signup.Email(),
+		TokenHash: signup.TokenHash(),
+		ExpiresAt: signup.ExpiresAt(),
+		UsedAt:    signup.UsedAt(),
+		CreatedAt: signup.CreatedAt(),
+	}
+}
+
+// toOIDCSignupEntity はGORMモデルをエンティティに変換する
+func toOIDCSignupEntity(m *model.OIDCSignup) *entity.OIDCSignup {
+	return entity.ReconstructOIDCSignup(
+		m.ID,
+		m.Provider,
+		m.Subject,
+		m.Email,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
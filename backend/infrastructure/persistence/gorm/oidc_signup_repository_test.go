@@ -0,0 +1,143 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// testOIDCSignup はテスト用OIDCSignupとその平文トークンを生成する
+func testOIDCSignup(t *testing.T) (*entity.OIDCSignup, vo.OneTimeToken) {
+	t.Helper()
+	email, _ := vo.NewEmail("oidc@example.com")
+	signup, token, err := entity.NewOIDCSignup("google", "sub-123", email)
+	if err != nil {
+		t.Fatalf("failed to create test oidc signup: %v", err)
+	}
+	return signup, token
+}
+
+func TestGormOIDCSignupRepository_Save(t *testing.T) {
+	t.Run("正常系_登録待ちが保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCSignupRepository(db)
+
+		signup, _ := testOIDCSignup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `oidc_signups`")).
+			WithArgs(
+				signup.ID().String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				signup.TokenHash(),
+				signup.ExpiresAt(),
+				nil, // used_at
+				signup.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), signup); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCSignupRepository(db)
+
+		signup, _ := testOIDCSignup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `oidc_signups`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), signup); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormOIDCSignupRepository_FindByTokenHash(t *testing.T) {
+	t.Run("正常系_ハッシュで登録待ちが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCSignupRepository(db)
+
+		signup, token := testOIDCSignup(t)
+
+		rows := sqlmock.NewRows(oidcSignupColumns()).
+			AddRow(
+				signup.ID().String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				signup.TokenHash(),
+				signup.ExpiresAt(),
+				nil,
+				signup.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `oidc_signups` WHERE token_hash = ?")).
+			WithArgs(token.Hash(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByTokenHash(context.Background(), token.Hash())
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found signup should not be nil")
+		}
+		if found.Subject() != "sub-123" {
+			t.Errorf("Subject = %v, want sub-123", found.Subject())
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCSignupRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `oidc_signups` WHERE token_hash = ?")).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows(oidcSignupColumns()))
+
+		found, err := repo.FindByTokenHash(context.Background(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found signup should be nil")
+		}
+	})
+}
+
+func TestGormOIDCSignupRepository_MarkUsed(t *testing.T) {
+	t.Run("正常系_使用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormOIDCSignupRepository(db)
+
+		signup, _ := testOIDCSignup(t)
+		if err := signup.Use(); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `oidc_signups` SET `used_at`=? WHERE id = ?")).
+			WithArgs(*signup.UsedAt(), signup.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.MarkUsed(context.Background(), signup); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+	})
+}
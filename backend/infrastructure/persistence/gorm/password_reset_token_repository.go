@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormPasswordResetTokenRepository はPasswordResetTokenRepositoryのGORM実装
+type GormPasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPasswordResetTokenRepository は新しいGormPasswordResetTokenRepositoryを生成する
+func NewGormPasswordResetTokenRepository(db *gorm.DB) *GormPasswordResetTokenRepository {
+	return &GormPasswordResetTokenRepository{db: db}
+}
+
+// Save はトークンを保存する
+func (r *GormPasswordResetTokenRepository) Save(ctx context.Context, token *entity.PasswordResetToken) error {
+	tx := GetTx(ctx, r.db)
+	m := toPasswordResetTokenModel(token)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "password_reset_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash はトークンハッシュでトークンを取得する
+func (r *GormPasswordResetTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.PasswordResetToken
+	err := tx.Where("token_hash = ?", tokenHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByTokenHash", err)
+		return nil, err
+	}
+	return toPasswordResetTokenEntity(&m), nil
+}
+
+// MarkUsed はトークンの使用日時を保存する
+func (r *GormPasswordResetTokenRepository) MarkUsed(ctx context.Context, token *entity.PasswordResetToken) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.PasswordResetToken{}).
+		Where("id = ?", token.ID().String()).
+		Update("used_at", token.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "password_reset_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID はユーザーの全トークンを削除する
+func (r *GormPasswordResetTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.PasswordResetToken{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toPasswordResetTokenModel はエンティティをGORMモデルに変換する
+func toPasswordResetTokenModel(token *entity.PasswordResetToken) model.PasswordResetToken {
+	return model.PasswordResetToken{
+		ID:        token.ID().String(),
+		UserID:    token.UserID().String(),
+		TokenHash: token.TokenHash(),
+		ExpiresAt: token.ExpiresAt(),
+		UsedAt:    token.UsedAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+}
+
+// toPasswordResetTokenEntity はGORMモデルをエンティティに変換する
+func toPasswordResetTokenEntity(m *model.PasswordResetToken) *entity.PasswordResetToken {
+	return entity.ReconstructPasswordResetToken(
+		m.ID,
+		m.UserID,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
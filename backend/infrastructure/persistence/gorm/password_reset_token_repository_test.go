@@ -0,0 +1,174 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// testPasswordResetToken はテスト用PasswordResetTokenとその平文トークンを生成する
+func testPasswordResetToken(t *testing.T, userID vo.UserID) (*entity.PasswordResetToken, vo.OneTimeToken) {
+	t.Helper()
+	token, raw, err := entity.NewPasswordResetToken(userID)
+	if err != nil {
+		t.Fatalf("failed to create test password reset token: %v", err)
+	}
+	return token, raw
+}
+
+func TestGormPasswordResetTokenRepository_Save(t *testing.T) {
+	t.Run("正常系_トークンが保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		token, _ := testPasswordResetToken(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `password_reset_tokens`")).
+			WithArgs(
+				token.ID().String(),
+				token.UserID().String(),
+				token.TokenHash(),
+				token.ExpiresAt(),
+				nil, // used_at
+				token.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		token, _ := testPasswordResetToken(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `password_reset_tokens`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), token); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormPasswordResetTokenRepository_FindByTokenHash(t *testing.T) {
+	t.Run("正常系_ハッシュでトークンが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		token, raw := testPasswordResetToken(t, vo.NewUserID())
+
+		rows := sqlmock.NewRows(passwordResetTokenColumns()).
+			AddRow(
+				token.ID().String(),
+				token.UserID().String(),
+				token.TokenHash(),
+				token.ExpiresAt(),
+				nil,
+				token.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `password_reset_tokens` WHERE token_hash = ?")).
+			WithArgs(raw.Hash(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByTokenHash(context.Background(), raw.Hash())
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found token should not be nil")
+		}
+		if !found.ID().Equals(token.ID()) {
+			t.Errorf("ID = %v, want %v", found.ID(), token.ID())
+		}
+		if found.UsedAt() != nil {
+			t.Error("UsedAt should be nil")
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `password_reset_tokens` WHERE token_hash = ?")).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows(passwordResetTokenColumns()))
+
+		found, err := repo.FindByTokenHash(context.Background(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found token should be nil")
+		}
+	})
+}
+
+func TestGormPasswordResetTokenRepository_MarkUsed(t *testing.T) {
+	t.Run("正常系_使用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		token, _ := testPasswordResetToken(t, vo.NewUserID())
+		if err := token.Use(); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `used_at`=? WHERE id = ?")).
+			WithArgs(*token.UsedAt(), token.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.MarkUsed(context.Background(), token); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+	})
+}
+
+func TestGormPasswordResetTokenRepository_DeleteByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの全トークンが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		userID := vo.NewUserID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `password_reset_tokens` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPasswordResetTokenRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `password_reset_tokens` WHERE user_id = ?")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.DeleteByUserID(context.Background(), vo.NewUserID()); err == nil {
+			t.Error("DeleteByUserID() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormPersonalAccessTokenRepository はPersonalAccessTokenRepositoryのGORM実装
+type GormPersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPersonalAccessTokenRepository は新しいGormPersonalAccessTokenRepositoryを生成する
+func NewGormPersonalAccessTokenRepository(db *gorm.DB) *GormPersonalAccessTokenRepository {
+	return &GormPersonalAccessTokenRepository{db: db}
+}
+
+// Save はトークンを保存する
+func (r *GormPersonalAccessTokenRepository) Save(ctx context.Context, token *entity.PersonalAccessToken) error {
+	tx := GetTx(ctx, r.db)
+	m := toPersonalAccessTokenModel(token)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "personal_access_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash はトークンハッシュでトークンを取得する
+func (r *GormPersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.PersonalAccessToken
+	err := tx.Where("token_hash = ?", tokenHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByTokenHash", err)
+		return nil, err
+	}
+	return toPersonalAccessTokenEntity(&m), nil
+}
+
+// FindByUserID はユーザーの全トークンを作成日時の降順で取得する
+func (r *GormPersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error) {
+	tx := GetTx(ctx, r.db)
+	var models []model.PersonalAccessToken
+	err := tx.Where("user_id = ?", userID.String()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	tokens := make([]*entity.PersonalAccessToken, len(models))
+	for i := range models {
+		tokens[i] = toPersonalAccessTokenEntity(&models[i])
+	}
+	return tokens, nil
+}
+
+// UpdateLastUsedAt はトークンの最終利用日時を保存する
+func (r *GormPersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, token *entity.PersonalAccessToken) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.PersonalAccessToken{}).
+		Where("id = ?", token.ID().String()).
+		Update("last_used_at", token.LastUsedAt()).Error
+	if err != nil {
+		logError("UpdateLastUsedAt", err, "personal_access_token_id", token.ID().String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByID はトークンを削除する
+func (r *GormPersonalAccessTokenRepository) DeleteByID(ctx context.Context, id vo.PersonalAccessTokenID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("id = ?", id.String()).Delete(&model.PersonalAccessToken{}).Error; err != nil {
+		logError("DeleteByID", err, "personal_access_token_id", id.String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID はユーザーの全トークンを削除する
+func (r *GormPersonalAccessTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.PersonalAccessToken{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toPersonalAccessTokenModel はエンティティをGORMモデルに変換する
+func toPersonalAccessTokenModel(token *entity.PersonalAccessToken) model.PersonalAccessToken {
+	scopes := make([]string, len(token.Scopes()))
+	for i, scope := range token.Scopes() {
+		scopes[i] = scope.String()
+	}
+
+	return model.PersonalAccessToken{
+		ID:         token.ID().String(),
+		UserID:     token.UserID().String(),
+		Name:       token.Name(),
+		TokenHash:  token.TokenHash(),
+		Scopes:     strings.Join(scopes, " "),
+		ExpiresAt:  token.ExpiresAt(),
+		LastUsedAt: token.LastUsedAt(),
+		CreatedAt:  token.CreatedAt(),
+	}
+}
+
+// toPersonalAccessTokenEntity はGORMモデルをエンティティに変換する
+func toPersonalAccessTokenEntity(m *model.PersonalAccessToken) *entity.PersonalAccessToken {
+	return entity.ReconstructPersonalAccessToken(
+		m.ID,
+		m.UserID,
+		m.Name,
+		m.TokenHash,
+		strings.Fields(m.Scopes),
+		m.ExpiresAt,
+		m.LastUsedAt,
+		m.CreatedAt,
+	)
+}
@@ -0,0 +1,247 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// testPersonalAccessToken はテスト用PersonalAccessTokenとその平文トークンを生成する
+func testPersonalAccessToken(t *testing.T, userID vo.UserID) (*entity.PersonalAccessToken, vo.AccessToken) {
+	t.Helper()
+	scopes, err := vo.NewTokenScopes([]string{vo.TokenScopeRecordsRead, vo.TokenScopeProfileRead})
+	if err != nil {
+		t.Fatalf("failed to create test scopes: %v", err)
+	}
+	token, raw, err := entity.NewPersonalAccessToken(userID, "spreadsheet", scopes, nil)
+	if err != nil {
+		t.Fatalf("failed to create test personal access token: %v", err)
+	}
+	return token, raw
+}
+
+func TestGormPersonalAccessTokenRepository_Save(t *testing.T) {
+	t.Run("正常系_トークンが保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		token, _ := testPersonalAccessToken(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `personal_access_tokens`")).
+			WithArgs(
+				token.ID().String(),
+				token.UserID().String(),
+				"spreadsheet",
+				token.TokenHash(),
+				"records:read profile:read",
+				nil, // expires_at
+				nil, // last_used_at
+				token.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		token, _ := testPersonalAccessToken(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `personal_access_tokens`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), token); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormPersonalAccessTokenRepository_FindByTokenHash(t *testing.T) {
+	t.Run("正常系_ハッシュでトークンが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		token, raw := testPersonalAccessToken(t, vo.NewUserID())
+		expiresAt := time.Now().Add(24 * time.Hour)
+
+		rows := sqlmock.NewRows(personalAccessTokenColumns()).
+			AddRow(
+				token.ID().String(),
+				token.UserID().String(),
+				"spreadsheet",
+				token.TokenHash(),
+				"records:read profile:read",
+				expiresAt,
+				nil,
+				token.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE token_hash = ?")).
+			WithArgs(raw.Hash(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByTokenHash(context.Background(), raw.Hash())
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found token should not be nil")
+		}
+		if !found.ID().Equals(token.ID()) {
+			t.Errorf("ID = %v, want %v", found.ID(), token.ID())
+		}
+		if len(found.Scopes()) != 2 {
+			t.Errorf("len(Scopes) = %d, want 2", len(found.Scopes()))
+		}
+		if found.ExpiresAt() == nil || !found.ExpiresAt().Equal(expiresAt) {
+			t.Errorf("ExpiresAt = %v, want %v", found.ExpiresAt(), expiresAt)
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE token_hash = ?")).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows(personalAccessTokenColumns()))
+
+		found, err := repo.FindByTokenHash(context.Background(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found token should be nil")
+		}
+	})
+}
+
+func TestGormPersonalAccessTokenRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーのトークン一覧を取得する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		userID := vo.NewUserID()
+		token1, _ := testPersonalAccessToken(t, userID)
+		token2, _ := testPersonalAccessToken(t, userID)
+
+		rows := sqlmock.NewRows(personalAccessTokenColumns()).
+			AddRow(token2.ID().String(), userID.String(), "spreadsheet", token2.TokenHash(), "records:read", nil, nil, token2.CreatedAt()).
+			AddRow(token1.ID().String(), userID.String(), "spreadsheet", token1.TokenHash(), "records:read", nil, nil, token1.CreatedAt())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE user_id = ? ORDER BY created_at DESC")).
+			WithArgs(userID.String()).
+			WillReturnRows(rows)
+
+		tokens, err := repo.FindByUserID(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(tokens) != 2 {
+			t.Fatalf("len(tokens) = %d, want 2", len(tokens))
+		}
+		if !tokens[0].ID().Equals(token2.ID()) {
+			t.Errorf("tokens[0].ID = %v, want %v", tokens[0].ID(), token2.ID())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE user_id = ?")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindByUserID(context.Background(), vo.NewUserID()); err == nil {
+			t.Error("FindByUserID() should fail with db error")
+		}
+	})
+}
+
+func TestGormPersonalAccessTokenRepository_UpdateLastUsedAt(t *testing.T) {
+	t.Run("正常系_最終利用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		token, _ := testPersonalAccessToken(t, vo.NewUserID())
+		if err := token.Use(); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `last_used_at`=? WHERE id = ?")).
+			WithArgs(*token.LastUsedAt(), token.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.UpdateLastUsedAt(context.Background(), token); err != nil {
+			t.Fatalf("UpdateLastUsedAt() error = %v", err)
+		}
+	})
+}
+
+func TestGormPersonalAccessTokenRepository_DeleteByID(t *testing.T) {
+	t.Run("正常系_トークンが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		id := vo.NewPersonalAccessTokenID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `personal_access_tokens` WHERE id = ?")).
+			WithArgs(id.String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByID(context.Background(), id); err != nil {
+			t.Fatalf("DeleteByID() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `personal_access_tokens` WHERE id = ?")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.DeleteByID(context.Background(), vo.NewPersonalAccessTokenID()); err == nil {
+			t.Error("DeleteByID() should fail with db error")
+		}
+	})
+}
+
+func TestGormPersonalAccessTokenRepository_DeleteByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの全トークンが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormPersonalAccessTokenRepository(db)
+
+		userID := vo.NewUserID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `personal_access_tokens` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+	})
+}
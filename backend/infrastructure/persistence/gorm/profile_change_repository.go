@@ -0,0 +1,191 @@
+package gorm
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/fieldcrypto"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// 暗号化する変更履歴の列の名前（AADに含める）
+const (
+	columnOldValue = "old_value"
+	columnNewValue = "new_value"
+)
+
+// GormProfileChangeRepository はProfileChangeRepositoryのGORM実装
+type GormProfileChangeRepository struct {
+	db        *gorm.DB
+	encryptor *fieldcrypto.EnvelopeEncryptor
+}
+
+// NewGormProfileChangeRepository は新しいGormProfileChangeRepositoryを生成する
+// encryptorを渡すと変更前後の値（性別・生年月日）を暗号化して保存する
+func NewGormProfileChangeRepository(db *gorm.DB, encryptor *fieldcrypto.EnvelopeEncryptor) *GormProfileChangeRepository {
+	return &GormProfileChangeRepository{db: db, encryptor: encryptor}
+}
+
+// Save は変更履歴を保存する
+func (r *GormProfileChangeRepository) Save(ctx context.Context, change *entity.ProfileChange) error {
+	tx := GetTx(ctx, r.db)
+	m, err := toProfileChangeModel(change, r.encryptor)
+	if err != nil {
+		logError("Save", err, "profile_change_id", change.ID().String())
+		return err
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "profile_change_id", change.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByUserID は指定ユーザーの変更履歴を変更日時の新しい順に取得する
+func (r *GormProfileChangeRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.ProfileChange, error) {
+	tx := GetTx(ctx, r.db)
+	var models []model.ProfileChange
+	err := tx.Where("user_id = ?", userID.String()).
+		Order("changed_at DESC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	changes := make([]*entity.ProfileChange, len(models))
+	for i := range models {
+		change, err := toProfileChangeEntity(&models[i], r.encryptor)
+		if err != nil {
+			logError("FindByUserID", err, "profile_change_id", models[i].ID)
+			return nil, err
+		}
+		changes[i] = change
+	}
+	return changes, nil
+}
+
+// EncryptExistingRows は平文で保存されている既存の変更履歴を暗号化する
+// 暗号化済みの行のうち、現在の鍵以外で暗号化されたデータ鍵は現在の鍵で暗号化し直す（鍵のローテーション）
+// batchSize件ずつID順に処理し、暗号化した件数と再暗号化した件数を返す
+func (r *GormProfileChangeRepository) EncryptExistingRows(ctx context.Context, batchSize int) (encrypted int, rewrapped int, err error) {
+	if r.encryptor == nil {
+		return 0, 0, errFieldEncryptionDisabled
+	}
+	tx := GetTx(ctx, r.db)
+
+	lastID := ""
+	for {
+		var models []model.ProfileChange
+		if err := tx.Where("id > ?", lastID).Order("id").Limit(batchSize).Find(&models).Error; err != nil {
+			logError("EncryptExistingRows", err, "last_id", lastID)
+			return encrypted, rewrapped, err
+		}
+
+		for i := range models {
+			m := &models[i]
+			switch {
+			case m.EncryptedDataKey == nil:
+				encryptedModel, err := toProfileChangeModel(toPlainProfileChangeEntity(m), r.encryptor)
+				if err != nil {
+					logError("EncryptExistingRows", err, "profile_change_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				if err := tx.Model(&model.ProfileChange{}).Where("id = ?", m.ID).UpdateColumns(map[string]any{
+					"old_value":          encryptedModel.OldValue,
+					"new_value":          encryptedModel.NewValue,
+					"encrypted_data_key": encryptedModel.EncryptedDataKey,
+				}).Error; err != nil {
+					logError("EncryptExistingRows", err, "profile_change_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				encrypted++
+			case r.encryptor.NeedsRewrap(*m.EncryptedDataKey):
+				wrapped, err := r.encryptor.Rewrap(*m.EncryptedDataKey)
+				if err != nil {
+					logError("EncryptExistingRows", err, "profile_change_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				if err := tx.Model(&model.ProfileChange{}).Where("id = ?", m.ID).UpdateColumn("encrypted_data_key", wrapped).Error; err != nil {
+					logError("EncryptExistingRows", err, "profile_change_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				rewrapped++
+			}
+		}
+
+		if len(models) < batchSize {
+			return encrypted, rewrapped, nil
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// toProfileChangeModel はエンティティをGORMモデルに変換する
+// encryptorがある場合は行ごとに新しいデータ鍵を生成し、変更前後の値を暗号化する
+func toProfileChangeModel(change *entity.ProfileChange, encryptor *fieldcrypto.EnvelopeEncryptor) (model.ProfileChange, error) {
+	m := model.ProfileChange{
+		ID:        change.ID().String(),
+		UserID:    change.UserID().String(),
+		Field:     change.Field(),
+		OldValue:  change.OldValue(),
+		NewValue:  change.NewValue(),
+		ChangedAt: change.ChangedAt(),
+	}
+	if encryptor == nil {
+		return m, nil
+	}
+
+	dataKey, wrapped, err := encryptor.NewDataKey()
+	if err != nil {
+		return model.ProfileChange{}, err
+	}
+	if m.OldValue, err = dataKey.Encrypt(change.OldValue(), fieldAAD(m.ID, columnOldValue)); err != nil {
+		return model.ProfileChange{}, err
+	}
+	if m.NewValue, err = dataKey.Encrypt(change.NewValue(), fieldAAD(m.ID, columnNewValue)); err != nil {
+		return model.ProfileChange{}, err
+	}
+	m.EncryptedDataKey = &wrapped
+	return m, nil
+}
+
+// toProfileChangeEntity はGORMモデルをエンティティに変換する
+// 暗号化済みの行（encrypted_data_keyがある行）は変更前後の値を復号する
+func toProfileChangeEntity(m *model.ProfileChange, encryptor *fieldcrypto.EnvelopeEncryptor) (*entity.ProfileChange, error) {
+	if m.EncryptedDataKey == nil {
+		return toPlainProfileChangeEntity(m), nil
+	}
+	if encryptor == nil {
+		return nil, errFieldEncryptionDisabled
+	}
+
+	dataKey, err := encryptor.UnwrapDataKey(*m.EncryptedDataKey)
+	if err != nil {
+		return nil, err
+	}
+	oldValue, err := dataKey.Decrypt(m.OldValue, fieldAAD(m.ID, columnOldValue))
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := dataKey.Decrypt(m.NewValue, fieldAAD(m.ID, columnNewValue))
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructProfileChange(m.ID, m.UserID, m.Field, oldValue, newValue, m.ChangedAt), nil
+}
+
+// toPlainProfileChangeEntity は平文の行をエンティティに変換する
+func toPlainProfileChangeEntity(m *model.ProfileChange) *entity.ProfileChange {
+	return entity.ReconstructProfileChange(
+		m.ID,
+		m.UserID,
+		m.Field,
+		m.OldValue,
+		m.NewValue,
+		m.ChangedAt,
+	)
+}
@@ -0,0 +1,225 @@
+package gorm_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormProfileChangeRepository_Save(t *testing.T) {
+	t.Run("正常系_変更履歴が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, nil)
+
+		change := entity.NewProfileChange(vo.NewUserID(), entity.ProfileFieldGender, "male", "female")
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `profile_changes`")).
+			WithArgs(
+				change.ID().String(),
+				change.UserID().String(),
+				"gender",
+				"male",
+				"female",
+				nil, // encrypted_data_key（暗号化なし）
+				change.ChangedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), change); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, nil)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `profile_changes`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		change := entity.NewProfileChange(vo.NewUserID(), entity.ProfileFieldBirthDate, "1990-01-01", "1991-01-01")
+		if err := repo.Save(context.Background(), change); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormProfileChangeRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_変更履歴を新しい順に取得する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, nil)
+		userID := vo.NewUserID()
+		now := time.Now()
+
+		rows := sqlmock.NewRows(profileChangeColumns()).
+			AddRow("c2", userID.String(), "birth_date", "1990-01-01", "1991-01-01", nil, now).
+			AddRow("c1", userID.String(), "gender", "male", "female", nil, now.Add(-time.Hour))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `profile_changes` WHERE user_id = ? ORDER BY changed_at DESC")).
+			WithArgs(userID.String()).
+			WillReturnRows(rows)
+
+		changes, err := repo.FindByUserID(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("len(changes) = %d, want 2", len(changes))
+		}
+		if changes[0].Field() != entity.ProfileFieldBirthDate || changes[0].NewValue() != "1991-01-01" {
+			t.Errorf("changes[0] = %+v", changes[0])
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `profile_changes`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindByUserID(context.Background(), vo.NewUserID()); err == nil {
+			t.Error("FindByUserID() should fail with db error")
+		}
+	})
+}
+
+func TestGormProfileChangeRepository_Encryption(t *testing.T) {
+	t.Run("正常系_変更前後の値を暗号化して保存し復号して読み出せる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, testFieldEncryptor(t, "k1"))
+		ctx := context.Background()
+
+		change := entity.NewProfileChange(vo.NewUserID(), entity.ProfileFieldBirthDate, "1990-01-01", "1991-01-01")
+
+		var oldValue, newValue, dataKey driver.Value
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `profile_changes`")).
+			WithArgs(
+				change.ID().String(),
+				change.UserID().String(),
+				"birth_date",
+				captureArg{&oldValue},
+				captureArg{&newValue},
+				captureArg{&dataKey},
+				change.ChangedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(ctx, change); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if oldValue == "1990-01-01" || newValue == "1991-01-01" {
+			t.Errorf("values should be stored encrypted: old = %v, new = %v", oldValue, newValue)
+		}
+
+		// 保存した暗号文をそのまま読み出す
+		rows := sqlmock.NewRows(profileChangeColumns()).
+			AddRow(change.ID().String(), change.UserID().String(), "birth_date", oldValue, newValue, dataKey, change.ChangedAt())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `profile_changes` WHERE user_id = ?")).
+			WithArgs(change.UserID().String()).
+			WillReturnRows(rows)
+
+		changes, err := repo.FindByUserID(ctx, change.UserID())
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(changes) != 1 || changes[0].OldValue() != "1990-01-01" || changes[0].NewValue() != "1991-01-01" {
+			t.Errorf("changes = %+v", changes)
+		}
+	})
+
+	t.Run("異常系_別の行の暗号文は復号できない", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, testFieldEncryptor(t, "k1"))
+		ctx := context.Background()
+
+		change := entity.NewProfileChange(vo.NewUserID(), entity.ProfileFieldGender, "male", "female")
+
+		var oldValue, newValue, dataKey driver.Value
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `profile_changes`")).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), captureArg{&oldValue}, captureArg{&newValue}, captureArg{&dataKey}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		if err := repo.Save(ctx, change); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		// IDを付け替えるとAADが一致しない
+		rows := sqlmock.NewRows(profileChangeColumns()).
+			AddRow("other-id", change.UserID().String(), "gender", oldValue, newValue, dataKey, change.ChangedAt())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `profile_changes` WHERE user_id = ?")).
+			WillReturnRows(rows)
+
+		if _, err := repo.FindByUserID(ctx, change.UserID()); err == nil {
+			t.Error("FindByUserID() should fail for ciphertext moved to another row")
+		}
+	})
+}
+
+func TestGormProfileChangeRepository_EncryptExistingRows(t *testing.T) {
+	t.Run("正常系_平文の行を暗号化し古い鍵のデータ鍵を再暗号化する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		_, oldWrapped, err := testFieldEncryptor(t, "k1").NewDataKey()
+		if err != nil {
+			t.Fatalf("NewDataKey() error = %v", err)
+		}
+		// 現在の鍵をk2に切り替える（k1も復号用に残す）
+		repo := gormPkg.NewGormProfileChangeRepository(db, testFieldEncryptor(t, "k2"))
+		userID := vo.NewUserID().String()
+		now := time.Now()
+
+		rows := sqlmock.NewRows(profileChangeColumns()).
+			AddRow("id-1", userID, "gender", "male", "female", nil, now).
+			AddRow("id-2", userID, "birth_date", "o", "n", oldWrapped, now)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `profile_changes` WHERE id > ? ORDER BY id LIMIT ?")).
+			WithArgs("", 10).
+			WillReturnRows(rows)
+
+		// 平文の行: 変更前後の値を暗号文に置き換える
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `profile_changes` SET `encrypted_data_key`=?,`new_value`=?,`old_value`=? WHERE id = ?")).
+			WithArgs(keyIDArg{"k2"}, sqlmock.AnyArg(), sqlmock.AnyArg(), "id-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// 古い鍵の行: データ鍵だけを再暗号化する
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `profile_changes` SET `encrypted_data_key`=? WHERE id = ?")).
+			WithArgs(keyIDArg{"k2"}, "id-2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		encrypted, rewrapped, err := repo.EncryptExistingRows(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("EncryptExistingRows() error = %v", err)
+		}
+		if encrypted != 1 || rewrapped != 1 {
+			t.Errorf("encrypted/rewrapped = %d/%d, want 1/1", encrypted, rewrapped)
+		}
+	})
+
+	t.Run("異常系_鍵が設定されていない場合はエラー", func(t *testing.T) {
+		db, _ := setupMockDB(t)
+		repo := gormPkg.NewGormProfileChangeRepository(db, nil)
+
+		if _, _, err := repo.EncryptExistingRows(context.Background(), 10); err == nil {
+			t.Error("EncryptExistingRows() should fail without encryption keys")
+		}
+	})
+}
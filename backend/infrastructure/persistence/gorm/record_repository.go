@@ -7,7 +7,6 @@ import (
 	"gorm.io/gorm"
 
 	"caltrack/domain/entity"
-	"caltrack/domain/repository"
 	"caltrack/domain/vo"
 	"caltrack/infrastructure/persistence/gorm/model"
 )
@@ -57,47 +56,56 @@ func (r *GormRecordRepository) FindByUserIDAndDateRange(ctx context.Context, use
 	return records, nil
 }
 
-// GetDailyCalories は日別カロリーを取得（グラフ用）
-func (r *GormRecordRepository) GetDailyCalories(ctx context.Context, userID vo.UserID, period vo.StatisticsPeriod) ([]repository.DailyCalories, error) {
+// ForEachBatchByUserID は指定ユーザーの全Recordを食事日時の昇順でbatchSize件ずつ取得し、fnに渡す
+// OFFSETを使わず、直前のバッチの最後の (eaten_at, id) より後を取得する
+func (r *GormRecordRepository) ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func(records []*entity.Record) error) error {
 	tx := GetTx(ctx, r.db)
 
-	// 期間の計算（今日を含めて過去N日間）
-	now := time.Now()
-	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
-	startOfPeriod := endOfDay.AddDate(0, 0, -period.Days())
+	var last *model.Record
+	for {
+		query := tx.Where("user_id = ?", userID.String())
+		if last != nil {
+			query = query.Where("eaten_at > ? OR (eaten_at = ? AND id > ?)", last.EatenAt, last.EatenAt, last.ID)
+		}
 
-	// 日別カロリー集計クエリ
-	type dailySum struct {
-		Date          time.Time
-		TotalCalories int
-	}
-	var results []dailySum
-
-	// records と record_items を JOIN して日別集計
-	err := tx.Table("records").
-		Select("DATE(eaten_at) as date, COALESCE(SUM(record_items.calories), 0) as total_calories").
-		Joins("LEFT JOIN record_items ON records.id = record_items.record_id").
-		Where("records.user_id = ? AND records.eaten_at >= ? AND records.eaten_at < ?", userID.String(), startOfPeriod, endOfDay).
-		Group("DATE(eaten_at)").
-		Order("date ASC").
-		Find(&results).Error
-	if err != nil {
-		logError("GetDailyCalories", err, "user_id", userID.String())
-		return nil, err
-	}
+		var models []model.Record
+		err := query.Preload("Items").
+			Order("eaten_at ASC, id ASC").
+			Limit(batchSize).
+			Find(&models).Error
+		if err != nil {
+			logError("ForEachBatchByUserID", err, "user_id", userID.String())
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
 
-	// 結果をVO形式に変換
-	dailyCalories := make([]repository.DailyCalories, len(results))
-	for i, r := range results {
-		eatenAt := vo.ReconstructEatenAt(r.Date)
-		calories := vo.ReconstructCalories(r.TotalCalories)
-		dailyCalories[i] = repository.DailyCalories{
-			Date:     eatenAt,
-			Calories: calories,
+		records := make([]*entity.Record, len(models))
+		for i := range models {
+			records[i] = toRecordEntity(&models[i])
 		}
+		if err := fn(records); err != nil {
+			return err
+		}
+
+		if len(models) < batchSize {
+			return nil
+		}
+		last = &models[len(models)-1]
 	}
+}
 
-	return dailyCalories, nil
+// CountByUserID は指定ユーザーのRecordの件数を返す
+func (r *GormRecordRepository) CountByUserID(ctx context.Context, userID vo.UserID) (int64, error) {
+	tx := GetTx(ctx, r.db)
+	var count int64
+	err := tx.Model(&model.Record{}).Where("user_id = ?", userID.String()).Count(&count).Error
+	if err != nil {
+		logError("CountByUserID", err, "user_id", userID.String())
+		return 0, err
+	}
+	return count, nil
 }
 
 // toRecordModel はエンティティをGORMモデルに変換する
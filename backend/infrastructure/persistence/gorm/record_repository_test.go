@@ -2,12 +2,14 @@ package gorm_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"caltrack/domain/entity"
 	gormPkg "caltrack/infrastructure/persistence/gorm"
 )
 
@@ -191,99 +193,106 @@ func TestGormRecordRepository_FindByUserIDAndDateRange(t *testing.T) {
 }
 
 // ============================================================================
-// GetDailyCalories テスト
+// ForEachBatchByUserID テスト
 // ============================================================================
 
-func TestGormRecordRepository_GetDailyCalories(t *testing.T) {
-	t.Run("正常系_日別カロリー集計が取得できる", func(t *testing.T) {
+func TestGormRecordRepository_ForEachBatchByUserID(t *testing.T) {
+	t.Run("正常系_直前のバッチの続きから全件を順に取得する", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := gormPkg.NewGormRecordRepository(db)
-		ctx := context.Background()
 
 		user := testUser(t)
-		period := testStatisticsPeriod(t, "week") // 7日間
-
-		// 期間の計算（record_repository.goの実装と同じ）
-		now := time.Now()
-		endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
-		startOfPeriod := endOfDay.AddDate(0, 0, -period.Days())
-
-		// 集計結果: DATE(eaten_at), COALESCE(SUM(record_items.calories), 0)
-		rows := sqlmock.NewRows([]string{"date", "total_calories"}).
-			AddRow(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1500).
-			AddRow(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), 2000)
-
-		// JOIN + GROUP BY + SUM のクエリ
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT DATE(eaten_at) as date, COALESCE(SUM(record_items.calories), 0) as total_calories FROM `records` LEFT JOIN record_items ON records.id = record_items.record_id WHERE")).
-			WithArgs(user.ID().String(), startOfPeriod, endOfDay).
-			WillReturnRows(rows)
+		first := testRecordWithItem(t, user.ID(), time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), "朝食", 400)
+		second := testRecordWithItem(t, user.ID(), time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), "昼食", 600)
+
+		// expectBatch は1件分のバッチ取得を期待する
+		expectBatch := func(query string, args []driver.Value, record *entity.Record) {
+			mock.ExpectQuery(regexp.QuoteMeta(query)).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows(recordColumns()).
+					AddRow(record.ID().String(), record.UserID().String(), record.EatenAt().Time(), record.CreatedAt()))
+			item := record.Items()[0]
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `record_items` WHERE `record_items`.`record_id` = ?")).
+				WithArgs(record.ID().String()).
+				WillReturnRows(sqlmock.NewRows(recordItemColumns()).
+					AddRow(item.ID().String(), item.RecordID().String(), item.Name().String(), item.Calories().Value()))
+		}
 
-		// GetDailyCalories実行
-		result, err := repo.GetDailyCalories(ctx, user.ID(), period)
+		expectBatch("SELECT * FROM `records` WHERE user_id = ? ORDER BY eaten_at ASC, id ASC LIMIT ?",
+			[]driver.Value{user.ID().String(), 1}, first)
+		expectBatch("SELECT * FROM `records` WHERE user_id = ? AND (eaten_at > ? OR (eaten_at = ? AND id > ?)) ORDER BY eaten_at ASC, id ASC LIMIT ?",
+			[]driver.Value{user.ID().String(), first.EatenAt().Time(), first.EatenAt().Time(), first.ID().String(), 1}, second)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `records` WHERE user_id = ? AND (eaten_at > ? OR (eaten_at = ? AND id > ?))")).
+			WithArgs(user.ID().String(), second.EatenAt().Time(), second.EatenAt().Time(), second.ID().String(), 1).
+			WillReturnRows(sqlmock.NewRows(recordColumns()))
+
+		var got []string
+		err := repo.ForEachBatchByUserID(context.Background(), user.ID(), 1, func(records []*entity.Record) error {
+			for _, r := range records {
+				got = append(got, r.Items()[0].Name().String())
+			}
+			return nil
+		})
 		if err != nil {
-			t.Fatalf("GetDailyCalories() error = %v", err)
+			t.Fatalf("ForEachBatchByUserID() error = %v", err)
 		}
-		if len(result) != 2 {
-			t.Fatalf("expected 2 daily records, got %d", len(result))
-		}
-		if result[0].Calories.Value() != 1500 {
-			t.Errorf("expected calories 1500, got %d", result[0].Calories.Value())
-		}
-		if result[1].Calories.Value() != 2000 {
-			t.Errorf("expected calories 2000, got %d", result[1].Calories.Value())
+		if len(got) != 2 || got[0] != "朝食" || got[1] != "昼食" {
+			t.Errorf("got %v, want [朝食 昼食]", got)
 		}
 	})
 
-	t.Run("正常系_該当なしで空配列が返る", func(t *testing.T) {
+	t.Run("異常系_fnのエラーで中断する", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := gormPkg.NewGormRecordRepository(db)
-		ctx := context.Background()
 
 		user := testUser(t)
-		period := testStatisticsPeriod(t, "week")
-
-		now := time.Now()
-		endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
-		startOfPeriod := endOfDay.AddDate(0, 0, -period.Days())
+		record := testRecordWithItem(t, user.ID(), time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), "朝食", 400)
+		fnErr := errors.New("write error")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `records` WHERE user_id = ?")).
+			WithArgs(user.ID().String(), 1).
+			WillReturnRows(sqlmock.NewRows(recordColumns()).
+				AddRow(record.ID().String(), record.UserID().String(), record.EatenAt().Time(), record.CreatedAt()))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `record_items`")).
+			WillReturnRows(sqlmock.NewRows(recordItemColumns()))
+
+		err := repo.ForEachBatchByUserID(context.Background(), user.ID(), 1, func(records []*entity.Record) error {
+			return fnErr
+		})
+		if !errors.Is(err, fnErr) {
+			t.Errorf("ForEachBatchByUserID() error = %v, want %v", err, fnErr)
+		}
+	})
+}
 
-		// 空の集計結果
-		rows := sqlmock.NewRows([]string{"date", "total_calories"})
+func TestGormRecordRepository_CountByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの記録件数が返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecordRepository(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT DATE(eaten_at) as date, COALESCE(SUM(record_items.calories), 0) as total_calories FROM `records` LEFT JOIN record_items ON records.id = record_items.record_id WHERE")).
-			WithArgs(user.ID().String(), startOfPeriod, endOfDay).
-			WillReturnRows(rows)
+		userID := testUser(t).ID()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `records` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
 
-		result, err := repo.GetDailyCalories(ctx, user.ID(), period)
+		count, err := repo.CountByUserID(context.Background(), userID)
 		if err != nil {
-			t.Fatalf("GetDailyCalories() error = %v", err)
+			t.Fatalf("CountByUserID() error = %v", err)
 		}
-		if len(result) != 0 {
-			t.Errorf("expected empty array, got %d records", len(result))
+		if count != 42 {
+			t.Errorf("count = %d, want 42", count)
 		}
 	})
 
 	t.Run("異常系_DBエラー", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := gormPkg.NewGormRecordRepository(db)
-		ctx := context.Background()
 
-		user := testUser(t)
-		period := testStatisticsPeriod(t, "week")
-
-		now := time.Now()
-		endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
-		startOfPeriod := endOfDay.AddDate(0, 0, -period.Days())
-
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT DATE(eaten_at) as date, COALESCE(SUM(record_items.calories), 0) as total_calories FROM `records` LEFT JOIN record_items ON records.id = record_items.record_id WHERE")).
-			WithArgs(user.ID().String(), startOfPeriod, endOfDay).
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `records` WHERE user_id = ?")).
 			WillReturnError(errors.New("db error"))
 
-		result, err := repo.GetDailyCalories(ctx, user.ID(), period)
-		if err == nil {
-			t.Error("GetDailyCalories() should fail with db error")
-		}
-		if result != nil {
-			t.Error("result should be nil on error")
+		if _, err := repo.CountByUserID(context.Background(), testUser(t).ID()); err == nil {
+			t.Error("CountByUserID() should fail with db error")
 		}
 	})
 }
@@ -0,0 +1,100 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormRecoveryCodeRepository はRecoveryCodeRepositoryのGORM実装
+type GormRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRecoveryCodeRepository は新しいGormRecoveryCodeRepositoryを生成する
+func NewGormRecoveryCodeRepository(db *gorm.DB) *GormRecoveryCodeRepository {
+	return &GormRecoveryCodeRepository{db: db}
+}
+
+// SaveAll はリカバリーコードをまとめて保存する
+func (r *GormRecoveryCodeRepository) SaveAll(ctx context.Context, codes []*entity.RecoveryCode) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	tx := GetTx(ctx, r.db)
+	models := make([]model.RecoveryCode, len(codes))
+	for i, code := range codes {
+		models[i] = toRecoveryCodeModel(code)
+	}
+	if err := tx.Create(&models).Error; err != nil {
+		logError("SaveAll", err, "user_id", codes[0].UserID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByUserIDAndCodeHash はユーザーのリカバリーコードをハッシュで取得する
+func (r *GormRecoveryCodeRepository) FindByUserIDAndCodeHash(ctx context.Context, userID vo.UserID, codeHash string) (*entity.RecoveryCode, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.RecoveryCode
+	err := tx.Where("user_id = ? AND code_hash = ?", userID.String(), codeHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByUserIDAndCodeHash", err, "user_id", userID.String())
+		return nil, err
+	}
+	return toRecoveryCodeEntity(&m), nil
+}
+
+// MarkUsed はコードの使用日時を保存する
+func (r *GormRecoveryCodeRepository) MarkUsed(ctx context.Context, code *entity.RecoveryCode) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.RecoveryCode{}).
+		Where("id = ?", code.ID().String()).
+		Update("used_at", code.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "recovery_code_id", code.ID().String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID はユーザーの全リカバリーコードを削除する
+func (r *GormRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.RecoveryCode{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toRecoveryCodeModel はエンティティをGORMモデルに変換する
+func toRecoveryCodeModel(code *entity.RecoveryCode) model.RecoveryCode {
+	return model.RecoveryCode{
+		ID:        code.ID().String(),
+		UserID:    code.UserID().String(),
+		CodeHash:  code.CodeHash(),
+		UsedAt:    code.UsedAt(),
+		CreatedAt: code.CreatedAt(),
+	}
+}
+
+// toRecoveryCodeEntity はGORMモデルをエンティティに変換する
+func toRecoveryCodeEntity(m *model.RecoveryCode) *entity.RecoveryCode {
+	return entity.ReconstructRecoveryCode(
+		m.ID,
+		m.UserID,
+		m.CodeHash,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
@@ -0,0 +1,145 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormRecoveryCodeRepository_SaveAll(t *testing.T) {
+	t.Run("正常系_まとめて保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		codes, _, err := entity.NewRecoveryCodes(vo.NewUserID())
+		if err != nil {
+			t.Fatalf("failed to create test recovery codes: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `recovery_codes`")).
+			WillReturnResult(sqlmock.NewResult(1, int64(len(codes))))
+		mock.ExpectCommit()
+
+		if err := repo.SaveAll(context.Background(), codes); err != nil {
+			t.Fatalf("SaveAll() error = %v", err)
+		}
+	})
+
+	t.Run("正常系_空の場合はクエリを発行しない", func(t *testing.T) {
+		db, _ := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		if err := repo.SaveAll(context.Background(), nil); err != nil {
+			t.Fatalf("SaveAll() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		codes, _, _ := entity.NewRecoveryCodes(vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `recovery_codes`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.SaveAll(context.Background(), codes); err == nil {
+			t.Error("SaveAll() should fail with db error")
+		}
+	})
+}
+
+func TestGormRecoveryCodeRepository_FindByUserIDAndCodeHash(t *testing.T) {
+	t.Run("正常系_コードが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		userID := vo.NewUserID()
+		codeID := vo.NewRecoveryCodeID()
+
+		rows := sqlmock.NewRows(recoveryCodeColumns()).
+			AddRow(codeID.String(), userID.String(), "hash", nil, time.Now())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `recovery_codes` WHERE user_id = ? AND code_hash = ?")).
+			WithArgs(userID.String(), "hash", 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByUserIDAndCodeHash(context.Background(), userID, "hash")
+		if err != nil {
+			t.Fatalf("FindByUserIDAndCodeHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found code should not be nil")
+		}
+		if !found.ID().Equals(codeID) {
+			t.Errorf("ID = %v, want %v", found.ID(), codeID)
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `recovery_codes` WHERE user_id = ? AND code_hash = ?")).
+			WillReturnRows(sqlmock.NewRows(recoveryCodeColumns()))
+
+		found, err := repo.FindByUserIDAndCodeHash(context.Background(), vo.NewUserID(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByUserIDAndCodeHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found code should be nil")
+		}
+	})
+}
+
+func TestGormRecoveryCodeRepository_MarkUsed(t *testing.T) {
+	t.Run("正常系_使用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		code := entity.ReconstructRecoveryCode(vo.NewRecoveryCodeID().String(), vo.NewUserID().String(), "hash", nil, time.Now())
+		if err := code.Use(); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `recovery_codes` SET `used_at`=? WHERE id = ?")).
+			WithArgs(*code.UsedAt(), code.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.MarkUsed(context.Background(), code); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+	})
+}
+
+func TestGormRecoveryCodeRepository_DeleteByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの全コードが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRecoveryCodeRepository(db)
+
+		userID := vo.NewUserID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `recovery_codes` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+	})
+}
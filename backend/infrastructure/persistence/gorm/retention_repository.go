@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormRetentionRepository はRetentionRepositoryのGORM実装
+type GormRetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRetentionRepository は新しいGormRetentionRepositoryを生成する
+func NewGormRetentionRepository(db *gorm.DB) *GormRetentionRepository {
+	return &GormRetentionRepository{db: db}
+}
+
+// FindUserRetentions は保存期間（年数）を設定しているユーザーを取得する
+func (r *GormRetentionRepository) FindUserRetentions(ctx context.Context) ([]repository.UserRetention, error) {
+	tx := GetTx(ctx, r.db)
+	var rows []struct {
+		ID                 string
+		DataRetentionYears int
+	}
+	err := tx.Model(&model.User{}).
+		Select("id", "data_retention_years").
+		Where("data_retention_years > 0").
+		Find(&rows).Error
+	if err != nil {
+		logError("FindUserRetentions", err)
+		return nil, err
+	}
+
+	retentions := make([]repository.UserRetention, 0, len(rows))
+	for _, row := range rows {
+		retentions = append(retentions, repository.UserRetention{
+			UserID: vo.ReconstructUserID(row.ID),
+			Years:  row.DataRetentionYears,
+		})
+	}
+	return retentions, nil
+}
+
+// PurgeRecordsBefore は食事日時がbeforeより前の記録と、その明細・PFCを削除する
+// 子テーブルから順に削除し、日別集計は残す
+// トランザクション内で呼び出すこと
+func (r *GormRetentionRepository) PurgeRecordsBefore(ctx context.Context, userID *vo.UserID, before time.Time) (map[string]int64, error) {
+	tx := GetTx(ctx, r.db)
+
+	recordsWhere := "eaten_at < ?"
+	args := []any{before}
+	if userID != nil {
+		recordsWhere = "user_id = ? AND eaten_at < ?"
+		args = []any{userID.String(), before}
+	}
+	childWhere := "record_id IN (SELECT id FROM records WHERE " + recordsWhere + ")"
+
+	steps := []purgeStep{
+		{"record_pfcs", &model.RecordPfc{}, childWhere},
+		{"record_items", &model.RecordItem{}, childWhere},
+		{"records", &model.Record{}, recordsWhere},
+	}
+
+	purged := make(map[string]int64, len(steps))
+	for _, step := range steps {
+		result := tx.Where(step.where, args...).Delete(step.model)
+		if result.Error != nil {
+			logError("PurgeRecordsBefore", result.Error, "table", step.table)
+			return nil, result.Error
+		}
+		purged[step.table] = result.RowsAffected
+	}
+	return purged, nil
+}
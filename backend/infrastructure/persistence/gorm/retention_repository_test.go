@@ -0,0 +1,111 @@
+package gorm_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormRetentionRepository_FindUserRetentions(t *testing.T) {
+	t.Run("正常系_保存期間を設定したユーザーを返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRetentionRepository(db)
+
+		userID := vo.NewUserID()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`data_retention_years` FROM `users` WHERE data_retention_years > 0")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "data_retention_years"}).AddRow(userID.String(), 2))
+
+		retentions, err := repo.FindUserRetentions(context.Background())
+		if err != nil {
+			t.Fatalf("FindUserRetentions() error = %v", err)
+		}
+		if len(retentions) != 1 {
+			t.Fatalf("len(retentions) = %d, want 1", len(retentions))
+		}
+		if !retentions[0].UserID.Equals(userID) || retentions[0].Years != 2 {
+			t.Errorf("retentions[0] = %+v, want user %s, 2 years", retentions[0], userID)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRetentionRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`data_retention_years` FROM `users`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindUserRetentions(context.Background()); err == nil {
+			t.Error("FindUserRetentions() should fail with db error")
+		}
+	})
+}
+
+func TestGormRetentionRepository_PurgeRecordsBefore(t *testing.T) {
+	// expectDelete は1テーブル分の削除を期待する
+	expectDelete := func(mock sqlmock.Sqlmock, query string, rows int64, args ...driver.Value) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+		mock.ExpectCommit()
+	}
+
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系_全ユーザーの古い記録を子テーブルから削除する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRetentionRepository(db)
+
+		expectDelete(mock, "DELETE FROM `record_pfcs` WHERE record_id IN (SELECT id FROM records WHERE eaten_at < ?)", 2, before)
+		expectDelete(mock, "DELETE FROM `record_items` WHERE record_id IN (SELECT id FROM records WHERE eaten_at < ?)", 5, before)
+		expectDelete(mock, "DELETE FROM `records` WHERE eaten_at < ?", 3, before)
+
+		purged, err := repo.PurgeRecordsBefore(context.Background(), nil, before)
+		if err != nil {
+			t.Fatalf("PurgeRecordsBefore() error = %v", err)
+		}
+		if purged["record_pfcs"] != 2 || purged["record_items"] != 5 || purged["records"] != 3 {
+			t.Errorf("purged = %v, want record_pfcs=2 record_items=5 records=3", purged)
+		}
+	})
+
+	t.Run("正常系_指定ユーザーの古い記録のみ削除する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRetentionRepository(db)
+
+		userID := vo.NewUserID()
+		expectDelete(mock, "DELETE FROM `record_pfcs` WHERE record_id IN (SELECT id FROM records WHERE user_id = ? AND eaten_at < ?)", 0, userID.String(), before)
+		expectDelete(mock, "DELETE FROM `record_items` WHERE record_id IN (SELECT id FROM records WHERE user_id = ? AND eaten_at < ?)", 1, userID.String(), before)
+		expectDelete(mock, "DELETE FROM `records` WHERE user_id = ? AND eaten_at < ?", 1, userID.String(), before)
+
+		purged, err := repo.PurgeRecordsBefore(context.Background(), &userID, before)
+		if err != nil {
+			t.Fatalf("PurgeRecordsBefore() error = %v", err)
+		}
+		if purged["records"] != 1 {
+			t.Errorf("purged[records] = %d, want 1", purged["records"])
+		}
+	})
+
+	t.Run("異常系_DBエラーで中断する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormRetentionRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `record_pfcs`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if _, err := repo.PurgeRecordsBefore(context.Background(), nil, before); err == nil {
+			t.Error("PurgeRecordsBefore() should fail with db error")
+		}
+	})
+}
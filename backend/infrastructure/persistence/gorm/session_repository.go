@@ -3,6 +3,7 @@ package gorm
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -47,6 +48,47 @@ func (r *GormSessionRepository) FindByID(ctx context.Context, sessionID vo.Sessi
 	return toSessionEntity(&m)
 }
 
+// FindActiveByUserID はユーザーの有効期限内のセッションを最終利用日時の降順で取得する
+func (r *GormSessionRepository) FindActiveByUserID(ctx context.Context, userID vo.UserID) ([]*entity.Session, error) {
+	tx := GetTx(ctx, r.db)
+	var models []model.Session
+	err := tx.Where("user_id = ? AND expires_at > ?", userID.String(), time.Now()).
+		Order("last_seen_at DESC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindActiveByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(models))
+	for i := range models {
+		session, err := toSessionEntity(&models[i])
+		if err != nil {
+			logError("FindActiveByUserID", err, "session_id", models[i].ID)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// UpdateActivity はセッションの有効期限と最終利用日時を更新する
+// 保存済みの最終利用日時より後になる場合のみ更新する
+func (r *GormSessionRepository) UpdateActivity(ctx context.Context, session *entity.Session) error {
+	tx := GetTx(ctx, r.db)
+	result := tx.Model(&model.Session{}).
+		Where("id = ? AND last_seen_at < ?", session.ID().String(), session.LastSeenAt()).
+		Updates(map[string]interface{}{
+			"expires_at":   session.ExpiresAt().Time(),
+			"last_seen_at": session.LastSeenAt(),
+		})
+	if result.Error != nil {
+		logError("UpdateActivity", result.Error, "session_id", session.ID().String())
+		return result.Error
+	}
+	return nil
+}
+
 // DeleteByID はセッションを削除する
 func (r *GormSessionRepository) DeleteByID(ctx context.Context, sessionID vo.SessionID) error {
 	tx := GetTx(ctx, r.db)
@@ -69,13 +111,38 @@ func (r *GormSessionRepository) DeleteByUserID(ctx context.Context, userID vo.Us
 	return nil
 }
 
+// DeleteByUserIDExcept はユーザーの指定セッション以外の全セッションを削除する
+func (r *GormSessionRepository) DeleteByUserIDExcept(ctx context.Context, userID vo.UserID, keepSessionID vo.SessionID) error {
+	tx := GetTx(ctx, r.db)
+	result := tx.Where("user_id = ? AND id <> ?", userID.String(), keepSessionID.String()).Delete(&model.Session{})
+	if result.Error != nil {
+		logError("DeleteByUserIDExcept", result.Error, "user_id", userID.String())
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteExpired は有効期限が切れている全ユーザーのセッションを削除する
+func (r *GormSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tx := GetTx(ctx, r.db)
+	result := tx.Where("expires_at <= ?", now).Delete(&model.Session{})
+	if result.Error != nil {
+		logError("DeleteExpired", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 // toSessionModel はEntityからGORMモデルに変換する
 func toSessionModel(session *entity.Session) model.Session {
 	return model.Session{
-		ID:        session.ID().String(),
-		UserID:    session.UserID().String(),
-		ExpiresAt: session.ExpiresAt().Time(),
-		CreatedAt: session.CreatedAt(),
+		ID:         session.ID().String(),
+		UserID:     session.UserID().String(),
+		ExpiresAt:  session.ExpiresAt().Time(),
+		UserAgent:  session.UserAgent(),
+		IPAddress:  session.IPAddress(),
+		LastSeenAt: session.LastSeenAt(),
+		CreatedAt:  session.CreatedAt(),
 	}
 }
 
@@ -85,6 +152,9 @@ func toSessionEntity(m *model.Session) (*entity.Session, error) {
 		m.ID,
 		m.UserID,
 		m.ExpiresAt,
+		m.UserAgent,
+		m.IPAddress,
+		m.LastSeenAt,
 		m.CreatedAt,
 	)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	gormPkg "caltrack/infrastructure/persistence/gorm"
@@ -30,6 +31,9 @@ func TestGormSessionRepository_Save(t *testing.T) {
 				session.ID().String(),
 				session.UserID().String(),
 				session.ExpiresAt().Time(),
+				session.UserAgent(),
+				session.IPAddress(),
+				session.LastSeenAt(),
 				sqlmock.AnyArg(), // created_at
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -83,6 +87,9 @@ func TestGormSessionRepository_FindByID(t *testing.T) {
 				session.ID().String(),
 				session.UserID().String(),
 				session.ExpiresAt().Time(),
+				session.UserAgent(),
+				session.IPAddress(),
+				session.LastSeenAt(),
 				session.CreatedAt(),
 			)
 
@@ -154,6 +161,105 @@ func TestGormSessionRepository_FindByID(t *testing.T) {
 	})
 }
 
+// ============================================================================
+// FindActiveByUserID テスト
+// ============================================================================
+
+func TestGormSessionRepository_FindActiveByUserID(t *testing.T) {
+	t.Run("正常系_有効なセッションが取得される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		session := testSession(t, user.ID())
+
+		rows := sqlmock.NewRows(sessionColumns()).
+			AddRow(
+				session.ID().String(),
+				session.UserID().String(),
+				session.ExpiresAt().Time(),
+				"Mozilla/5.0",
+				"203.0.113.1",
+				session.LastSeenAt(),
+				session.CreatedAt(),
+			)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE user_id = ? AND expires_at > ? ORDER BY last_seen_at DESC")).
+			WithArgs(user.ID().String(), sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		sessions, err := repo.FindActiveByUserID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("FindActiveByUserID() error = %v", err)
+		}
+		if len(sessions) != 1 {
+			t.Fatalf("len(sessions) = %d, want 1", len(sessions))
+		}
+		if sessions[0].UserAgent() != "Mozilla/5.0" || sessions[0].IPAddress() != "203.0.113.1" {
+			t.Errorf("client = (%s, %s), want (Mozilla/5.0, 203.0.113.1)", sessions[0].UserAgent(), sessions[0].IPAddress())
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.FindActiveByUserID(ctx, user.ID()); err == nil {
+			t.Error("FindActiveByUserID() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// UpdateActivity テスト
+// ============================================================================
+
+func TestGormSessionRepository_UpdateActivity(t *testing.T) {
+	t.Run("正常系_有効期限と最終利用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		session := testSession(t, user.ID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET `expires_at`=?,`last_seen_at`=? WHERE id = ? AND last_seen_at < ?")).
+			WithArgs(session.ExpiresAt().Time(), session.LastSeenAt(), session.ID().String(), session.LastSeenAt()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.UpdateActivity(ctx, session); err != nil {
+			t.Fatalf("UpdateActivity() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		session := testSession(t, user.ID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `sessions` SET")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.UpdateActivity(ctx, session); err == nil {
+			t.Error("UpdateActivity() should fail with db error")
+		}
+	})
+}
+
 // ============================================================================
 // DeleteByID テスト
 // ============================================================================
@@ -249,3 +355,87 @@ func TestGormSessionRepository_DeleteByUserID(t *testing.T) {
 		}
 	})
 }
+
+// ============================================================================
+// DeleteByUserIDExcept テスト
+// ============================================================================
+
+func TestGormSessionRepository_DeleteByUserIDExcept(t *testing.T) {
+	t.Run("正常系_指定セッション以外が削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		session := testSession(t, user.ID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE user_id = ? AND id <> ?")).
+			WithArgs(user.ID().String(), session.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserIDExcept(ctx, user.ID(), session.ID()); err != nil {
+			t.Fatalf("DeleteByUserIDExcept() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		session := testSession(t, user.ID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.DeleteByUserIDExcept(ctx, user.ID(), session.ID()); err == nil {
+			t.Error("DeleteByUserIDExcept() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// DeleteExpired テスト
+// ============================================================================
+
+func TestGormSessionRepository_DeleteExpired(t *testing.T) {
+	t.Run("正常系_期限切れのセッションが削除され件数を返す", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE expires_at <= ?")).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteExpired(context.Background(), now)
+		if err != nil {
+			t.Fatalf("DeleteExpired() error = %v", err)
+		}
+		if deleted != 3 {
+			t.Errorf("DeleteExpired() = %d, want 3", deleted)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormSessionRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE expires_at <= ?")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if _, err := repo.DeleteExpired(context.Background(), time.Now()); err == nil {
+			t.Error("DeleteExpired() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// upsertTargetSnapshotQuery はスナップショットを作成し、同一ユーザー・同一適用日があれば目標値を上書きする
+const upsertTargetSnapshotQuery = `
+INSERT INTO target_snapshots (id, user_id, effective_date, target_calories, protein, fat, carbs, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	target_calories = VALUES(target_calories),
+	protein = VALUES(protein),
+	fat = VALUES(fat),
+	carbs = VALUES(carbs),
+	created_at = VALUES(created_at)`
+
+// GormTargetSnapshotRepository はTargetSnapshotRepositoryのGORM実装
+type GormTargetSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTargetSnapshotRepository は新しいGormTargetSnapshotRepositoryを生成する
+func NewGormTargetSnapshotRepository(db *gorm.DB) *GormTargetSnapshotRepository {
+	return &GormTargetSnapshotRepository{db: db}
+}
+
+// Save はスナップショットを保存する
+// 同一ユーザー・同一適用日のスナップショットが存在する場合は目標値を上書きする
+func (r *GormTargetSnapshotRepository) Save(ctx context.Context, snapshot *entity.TargetSnapshot) error {
+	tx := GetTx(ctx, r.db)
+
+	pfc := snapshot.TargetPfc()
+	err := tx.Exec(upsertTargetSnapshotQuery,
+		snapshot.ID().String(), snapshot.UserID().String(), toDateColumn(snapshot.EffectiveDate()),
+		snapshot.TargetCalories().Value(), pfc.Protein(), pfc.Fat(), pfc.Carbs(),
+		snapshot.CreatedAt(),
+	).Error
+	if err != nil {
+		logError("Save", err, "target_snapshot_id", snapshot.ID().String())
+		return err
+	}
+
+	return nil
+}
+
+// ExistsByUserID は指定ユーザーのスナップショットが1件以上存在するかを返す
+func (r *GormTargetSnapshotRepository) ExistsByUserID(ctx context.Context, userID vo.UserID) (bool, error) {
+	tx := GetTx(ctx, r.db)
+
+	var count int64
+	err := tx.Model(&model.TargetSnapshot{}).Where("user_id = ?", userID.String()).Count(&count).Error
+	if err != nil {
+		logError("ExistsByUserID", err, "user_id", userID.String())
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// FindEffectiveByUserIDAndDateRange は指定期間の判定に必要なスナップショットを適用日の昇順で取得する
+// startDate時点で有効な1件と、startDateより後・endDate未満に適用開始されたものを返す
+func (r *GormTargetSnapshotRepository) FindEffectiveByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.TargetSnapshot, error) {
+	tx := GetTx(ctx, r.db)
+
+	// 期間開始時点で有効なスナップショット（開始日以前で最新の1件）
+	var baseModels []model.TargetSnapshot
+	err := tx.Where("user_id = ? AND effective_date <= ?", userID.String(), toDateColumn(startDate)).
+		Order("effective_date DESC").
+		Limit(1).
+		Find(&baseModels).Error
+	if err != nil {
+		logError("FindEffectiveByUserIDAndDateRange", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// 期間中に適用開始されたスナップショット
+	var rangeModels []model.TargetSnapshot
+	err = tx.Where("user_id = ? AND effective_date > ? AND effective_date < ?", userID.String(), toDateColumn(startDate), toDateColumn(endDate)).
+		Order("effective_date ASC").
+		Find(&rangeModels).Error
+	if err != nil {
+		logError("FindEffectiveByUserIDAndDateRange", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	snapshots := make([]*entity.TargetSnapshot, 0, len(baseModels)+len(rangeModels))
+	for _, m := range append(baseModels, rangeModels...) {
+		snapshots = append(snapshots, toTargetSnapshotEntity(&m))
+	}
+	return snapshots, nil
+}
+
+// toTargetSnapshotEntity はGORMモデルをエンティティに変換する
+func toTargetSnapshotEntity(m *model.TargetSnapshot) *entity.TargetSnapshot {
+	return entity.ReconstructTargetSnapshot(
+		m.ID,
+		m.UserID,
+		fromDateColumn(m.EffectiveDate),
+		m.TargetCalories,
+		m.Protein,
+		m.Fat,
+		m.Carbs,
+		m.CreatedAt,
+	)
+}
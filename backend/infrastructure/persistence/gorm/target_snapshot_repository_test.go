@@ -0,0 +1,175 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/helper"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// ============================================================================
+// Save テスト
+// ============================================================================
+
+func TestGormTargetSnapshotRepository_Save(t *testing.T) {
+	t.Run("正常系_同一日付は上書きするUPSERTが発行される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		snapshot := entity.NewTargetSnapshot(user, time.Date(2025, 3, 10, 0, 0, 0, 0, helper.JST()))
+		pfc := snapshot.TargetPfc()
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO target_snapshots")+"(?s).*"+regexp.QuoteMeta("ON DUPLICATE KEY UPDATE")).
+			WithArgs(
+				snapshot.ID().String(),
+				user.ID().String(),
+				// effective_dateは接続のタイムゾーンに左右されないようJSTの日付の文字列で渡す
+				"2025-03-10",
+				snapshot.TargetCalories().Value(),
+				pfc.Protein(),
+				pfc.Fat(),
+				pfc.Carbs(),
+				sqlmock.AnyArg(), // created_at
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		if err := repo.Save(ctx, snapshot); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		snapshot := entity.NewTargetSnapshot(user, time.Now())
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO target_snapshots")).
+			WillReturnError(errors.New("db error"))
+
+		if err := repo.Save(ctx, snapshot); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// ExistsByUserID テスト
+// ============================================================================
+
+func TestGormTargetSnapshotRepository_ExistsByUserID(t *testing.T) {
+	t.Run("正常系_存在する場合trueが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `target_snapshots` WHERE user_id = ?")).
+			WithArgs(user.ID().String()).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+		exists, err := repo.ExistsByUserID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("ExistsByUserID() error = %v", err)
+		}
+		if !exists {
+			t.Error("ExistsByUserID() = false, want true")
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `target_snapshots` WHERE user_id = ?")).
+			WithArgs(user.ID().String()).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := repo.ExistsByUserID(ctx, user.ID()); err == nil {
+			t.Error("ExistsByUserID() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// FindEffectiveByUserIDAndDateRange テスト
+// ============================================================================
+
+func TestGormTargetSnapshotRepository_FindEffectiveByUserIDAndDateRange(t *testing.T) {
+	t.Run("正常系_開始時点で有効な1件と期間中の変更が昇順で返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, helper.JST())
+		endDate := time.Date(2025, 3, 8, 0, 0, 0, 0, helper.JST())
+
+		baseRows := sqlmock.NewRows(targetSnapshotColumns()).
+			AddRow("base-id", user.ID().String(), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 1800, 67.5, 50.0, 270.0, time.Now())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `target_snapshots` WHERE user_id = ? AND effective_date <= ? ORDER BY effective_date DESC LIMIT ?")).
+			WithArgs(user.ID().String(), "2025-03-01", 1).
+			WillReturnRows(baseRows)
+
+		rangeRows := sqlmock.NewRows(targetSnapshotColumns()).
+			AddRow("range-id", user.ID().String(), time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), 2200, 82.5, 61.1, 330.0, time.Now())
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `target_snapshots` WHERE user_id = ? AND effective_date > ? AND effective_date < ? ORDER BY effective_date ASC")).
+			WithArgs(user.ID().String(), "2025-03-01", "2025-03-08").
+			WillReturnRows(rangeRows)
+
+		snapshots, err := repo.FindEffectiveByUserIDAndDateRange(ctx, user.ID(), startDate, endDate)
+		if err != nil {
+			t.Fatalf("FindEffectiveByUserIDAndDateRange() error = %v", err)
+		}
+		if len(snapshots) != 2 {
+			t.Fatalf("len(snapshots) = %d, want 2", len(snapshots))
+		}
+		if snapshots[0].TargetCalories().Value() != 1800 {
+			t.Errorf("snapshots[0].TargetCalories = %d, want 1800", snapshots[0].TargetCalories().Value())
+		}
+		if snapshots[1].TargetCalories().Value() != 2200 {
+			t.Errorf("snapshots[1].TargetCalories = %d, want 2200", snapshots[1].TargetCalories().Value())
+		}
+		// DATE型の値は同じ年月日のJSTの0時として復元される
+		wantDate := time.Date(2025, 2, 1, 0, 0, 0, 0, helper.JST())
+		if !snapshots[0].EffectiveDate().Equal(wantDate) {
+			t.Errorf("snapshots[0].EffectiveDate = %v, want %v", snapshots[0].EffectiveDate(), wantDate)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTargetSnapshotRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `target_snapshots`")).
+			WillReturnError(errors.New("db error"))
+
+		snapshots, err := repo.FindEffectiveByUserIDAndDateRange(ctx, user.ID(), startDate, endDate)
+		if err == nil {
+			t.Error("FindEffectiveByUserIDAndDateRange() should fail with db error")
+		}
+		if snapshots != nil {
+			t.Error("snapshots should be nil on error")
+		}
+	})
+}
@@ -140,6 +140,46 @@ func userColumns() []string {
 		"birth_date",
 		"gender",
 		"activity_level",
+		"budget_mode",
+		"ai_processing_enabled",
+		"analytics_enabled",
+		"data_retention_years",
+		"privacy_consent_version",
+		"privacy_consented_at",
+		"role",
+		"disabled_at",
+		"email_verified_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+// encryptedUserColumns は暗号化用の列を含むUsersテーブルのカラム一覧を返す
+func encryptedUserColumns() []string {
+	return []string{
+		"id",
+		"email",
+		"hashed_password",
+		"nickname",
+		"weight",
+		"height",
+		"birth_date",
+		"gender",
+		"encrypted_data_key",
+		"weight_encrypted",
+		"height_encrypted",
+		"birth_date_encrypted",
+		"gender_encrypted",
+		"activity_level",
+		"budget_mode",
+		"ai_processing_enabled",
+		"analytics_enabled",
+		"data_retention_years",
+		"privacy_consent_version",
+		"privacy_consented_at",
+		"role",
+		"disabled_at",
+		"email_verified_at",
 		"created_at",
 		"updated_at",
 	}
@@ -151,6 +191,9 @@ func sessionColumns() []string {
 		"id",
 		"user_id",
 		"expires_at",
+		"user_agent",
+		"ip_address",
+		"last_seen_at",
 		"created_at",
 	}
 }
@@ -196,3 +239,210 @@ func adviceCacheColumns() []string {
 		"created_at",
 	}
 }
+
+// targetSnapshotColumns はTargetSnapshotsテーブルのカラム一覧を返す
+func targetSnapshotColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"effective_date",
+		"target_calories",
+		"protein",
+		"fat",
+		"carbs",
+		"created_at",
+	}
+}
+
+// dailySummaryColumns はDailySummariesテーブルのカラム一覧を返す
+func dailySummaryColumns() []string {
+	return []string{
+		"user_id",
+		"summary_date",
+		"calories",
+		"protein",
+		"fat",
+		"carbs",
+		"record_count",
+		"updated_at",
+	}
+}
+
+// passwordResetTokenColumns はPasswordResetTokensテーブルのカラム一覧を返す
+func passwordResetTokenColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"token_hash",
+		"expires_at",
+		"used_at",
+		"created_at",
+	}
+}
+
+// emailVerificationTokenColumns はEmailVerificationTokensテーブルのカラム一覧を返す
+func emailVerificationTokenColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"email",
+		"token_hash",
+		"expires_at",
+		"used_at",
+		"created_at",
+	}
+}
+
+// loginAttemptColumns はLoginAttemptsテーブルのカラム一覧を返す
+func loginAttemptColumns() []string {
+	return []string{
+		"attempt_key",
+		"failures",
+		"last_failed_at",
+	}
+}
+
+// twoFactorChallengeColumns はTwoFactorChallengesテーブルのカラム一覧を返す
+func twoFactorChallengeColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"token_hash",
+		"expires_at",
+		"used_at",
+		"created_at",
+	}
+}
+
+// userTOTPColumns はUserTOTPsテーブルのカラム一覧を返す
+func userTOTPColumns() []string {
+	return []string{
+		"user_id",
+		"secret",
+		"enabled_at",
+		"last_used_step",
+		"created_at",
+		"updated_at",
+	}
+}
+
+// recoveryCodeColumns はRecoveryCodesテーブルのカラム一覧を返す
+func recoveryCodeColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"code_hash",
+		"used_at",
+		"created_at",
+	}
+}
+
+// personalAccessTokenColumns はPersonalAccessTokensテーブルのカラム一覧を返す
+func personalAccessTokenColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"token_hash",
+		"scopes",
+		"expires_at",
+		"last_used_at",
+		"created_at",
+	}
+}
+
+// userIdentityColumns はuser_identitiesテーブルのカラム一覧を返す
+func userIdentityColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"provider",
+		"subject",
+		"email",
+		"created_at",
+	}
+}
+
+// oidcAuthRequestColumns はoidc_auth_requestsテーブルのカラム一覧を返す
+func oidcAuthRequestColumns() []string {
+	return []string{
+		"id",
+		"provider",
+		"state_hash",
+		"nonce",
+		"code_verifier",
+		"expires_at",
+		"used_at",
+		"created_at",
+	}
+}
+
+// oidcSignupColumns はoidc_signupsテーブルのカラム一覧を返す
+func oidcSignupColumns() []string {
+	return []string{
+		"id",
+		"provider",
+		"subject",
+		"email",
+		"token_hash",
+		"expires_at",
+		"used_at",
+		"created_at",
+	}
+}
+
+// accountDeletionColumns はaccount_deletionsテーブルのカラム一覧を返す
+func accountDeletionColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"requested_at",
+		"scheduled_at",
+		"cancelled_at",
+		"purged_at",
+		"purged_rows",
+	}
+}
+
+// profileChangeColumns はprofile_changesテーブルのカラム一覧を返す
+func profileChangeColumns() []string {
+	return []string{
+		"id",
+		"user_id",
+		"field",
+		"old_value",
+		"new_value",
+		"encrypted_data_key",
+		"changed_at",
+	}
+}
+
+// auditEventColumns はaudit_eventsテーブルのカラム一覧を返す
+func auditEventColumns() []string {
+	return []string{
+		"id",
+		"action",
+		"user_id",
+		"actor_id",
+		"target_type",
+		"target_id",
+		"ip_address",
+		"user_agent",
+		"diff",
+		"occurred_at",
+	}
+}
+
+// jobRunColumns はjob_runsテーブルのカラム一覧を返す
+func jobRunColumns() []string {
+	return []string{
+		"id",
+		"job_name",
+		"owner",
+		"status",
+		"started_at",
+		"finished_at",
+		"result",
+		"error_message",
+	}
+}
@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormTwoFactorChallengeRepository はTwoFactorChallengeRepositoryのGORM実装
+type GormTwoFactorChallengeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTwoFactorChallengeRepository は新しいGormTwoFactorChallengeRepositoryを生成する
+func NewGormTwoFactorChallengeRepository(db *gorm.DB) *GormTwoFactorChallengeRepository {
+	return &GormTwoFactorChallengeRepository{db: db}
+}
+
+// Save はチャレンジを保存する
+func (r *GormTwoFactorChallengeRepository) Save(ctx context.Context, challenge *entity.TwoFactorChallenge) error {
+	tx := GetTx(ctx, r.db)
+	m := toTwoFactorChallengeModel(challenge)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "two_factor_challenge_id", challenge.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash はトークンハッシュでチャレンジを取得する
+func (r *GormTwoFactorChallengeRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.TwoFactorChallenge, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.TwoFactorChallenge
+	err := tx.Where("token_hash = ?", tokenHash).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByTokenHash", err)
+		return nil, err
+	}
+	return toTwoFactorChallengeEntity(&m), nil
+}
+
+// MarkUsed はチャレンジの使用日時を保存する
+func (r *GormTwoFactorChallengeRepository) MarkUsed(ctx context.Context, challenge *entity.TwoFactorChallenge) error {
+	tx := GetTx(ctx, r.db)
+	err := tx.Model(&model.TwoFactorChallenge{}).
+		Where("id = ?", challenge.ID().String()).
+		Update("used_at", challenge.UsedAt()).Error
+	if err != nil {
+		logError("MarkUsed", err, "two_factor_challenge_id", challenge.ID().String())
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID はユーザーの全チャレンジを削除する
+func (r *GormTwoFactorChallengeRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.TwoFactorChallenge{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toTwoFactorChallengeModel はエンティティをGORMモデルに変換する
+func toTwoFactorChallengeModel(challenge *entity.TwoFactorChallenge) model.TwoFactorChallenge {
+	return model.TwoFactorChallenge{
+		ID:        challenge.ID().String(),
+		UserID:    challenge.UserID().String(),
+		TokenHash: challenge.TokenHash(),
+		ExpiresAt: challenge.ExpiresAt(),
+		UsedAt:    challenge.UsedAt(),
+		CreatedAt: challenge.CreatedAt(),
+	}
+}
+
+// toTwoFactorChallengeEntity はGORMモデルをエンティティに変換する
+func toTwoFactorChallengeEntity(m *model.TwoFactorChallenge) *entity.TwoFactorChallenge {
+	return entity.ReconstructTwoFactorChallenge(
+		m.ID,
+		m.UserID,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.UsedAt,
+		m.CreatedAt,
+	)
+}
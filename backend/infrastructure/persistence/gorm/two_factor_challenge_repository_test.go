@@ -0,0 +1,174 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// testTwoFactorChallenge はテスト用TwoFactorChallengeとその平文トークンを生成する
+func testTwoFactorChallenge(t *testing.T, userID vo.UserID) (*entity.TwoFactorChallenge, vo.OneTimeToken) {
+	t.Helper()
+	token, raw, err := entity.NewTwoFactorChallenge(userID)
+	if err != nil {
+		t.Fatalf("failed to create test two-factor challenge: %v", err)
+	}
+	return token, raw
+}
+
+func TestGormTwoFactorChallengeRepository_Save(t *testing.T) {
+	t.Run("正常系_チャレンジが保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		token, _ := testTwoFactorChallenge(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `two_factor_challenges`")).
+			WithArgs(
+				token.ID().String(),
+				token.UserID().String(),
+				token.TokenHash(),
+				token.ExpiresAt(),
+				nil, // used_at
+				token.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		token, _ := testTwoFactorChallenge(t, vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `two_factor_challenges`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), token); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormTwoFactorChallengeRepository_FindByTokenHash(t *testing.T) {
+	t.Run("正常系_ハッシュでチャレンジが見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		token, raw := testTwoFactorChallenge(t, vo.NewUserID())
+
+		rows := sqlmock.NewRows(twoFactorChallengeColumns()).
+			AddRow(
+				token.ID().String(),
+				token.UserID().String(),
+				token.TokenHash(),
+				token.ExpiresAt(),
+				nil,
+				token.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `two_factor_challenges` WHERE token_hash = ?")).
+			WithArgs(raw.Hash(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByTokenHash(context.Background(), raw.Hash())
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found challenge should not be nil")
+		}
+		if !found.ID().Equals(token.ID()) {
+			t.Errorf("ID = %v, want %v", found.ID(), token.ID())
+		}
+		if found.UsedAt() != nil {
+			t.Error("UsedAt should be nil")
+		}
+	})
+
+	t.Run("正常系_存在しないハッシュでnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `two_factor_challenges` WHERE token_hash = ?")).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows(twoFactorChallengeColumns()))
+
+		found, err := repo.FindByTokenHash(context.Background(), "unknown")
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found challenge should be nil")
+		}
+	})
+}
+
+func TestGormTwoFactorChallengeRepository_MarkUsed(t *testing.T) {
+	t.Run("正常系_使用日時が更新される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		token, _ := testTwoFactorChallenge(t, vo.NewUserID())
+		if err := token.Use(); err != nil {
+			t.Fatalf("Use() error = %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `two_factor_challenges` SET `used_at`=? WHERE id = ?")).
+			WithArgs(*token.UsedAt(), token.ID().String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.MarkUsed(context.Background(), token); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+	})
+}
+
+func TestGormTwoFactorChallengeRepository_DeleteByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの全チャレンジが削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		userID := vo.NewUserID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `two_factor_challenges` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormTwoFactorChallengeRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `two_factor_challenges` WHERE user_id = ?")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.DeleteByUserID(context.Background(), vo.NewUserID()); err == nil {
+			t.Error("DeleteByUserID() should fail with db error")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormUserBadgeRepository はUserBadgeRepositoryのGORM実装
+type GormUserBadgeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserBadgeRepository は新しいGormUserBadgeRepositoryを生成する
+func NewGormUserBadgeRepository(db *gorm.DB) *GormUserBadgeRepository {
+	return &GormUserBadgeRepository{db: db}
+}
+
+// Save はバッジを保存する
+// 同一ユーザー・同一種別のバッジが既に存在する場合は何もしない
+func (r *GormUserBadgeRepository) Save(ctx context.Context, badge *entity.UserBadge) error {
+	tx := GetTx(ctx, r.db)
+
+	m := toUserBadgeModel(badge)
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&m).Error; err != nil {
+		logError("Save", err, "user_badge_id", badge.ID().String())
+		return err
+	}
+
+	return nil
+}
+
+// FindByUserID は指定ユーザーの獲得バッジを獲得日時の昇順で取得する
+func (r *GormUserBadgeRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.UserBadge, error) {
+	tx := GetTx(ctx, r.db)
+
+	var models []model.UserBadge
+	err := tx.Where("user_id = ?", userID.String()).
+		Order("unlocked_at ASC").
+		Find(&models).Error
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	badges := make([]*entity.UserBadge, len(models))
+	for i, m := range models {
+		badges[i] = toUserBadgeEntity(&m)
+	}
+	return badges, nil
+}
+
+// toUserBadgeModel はエンティティをGORMモデルに変換する
+func toUserBadgeModel(badge *entity.UserBadge) model.UserBadge {
+	return model.UserBadge{
+		ID:         badge.ID().String(),
+		UserID:     badge.UserID().String(),
+		BadgeType:  badge.BadgeType().String(),
+		UnlockedAt: badge.UnlockedAt(),
+	}
+}
+
+// toUserBadgeEntity はGORMモデルをエンティティに変換する
+func toUserBadgeEntity(m *model.UserBadge) *entity.UserBadge {
+	return entity.ReconstructUserBadge(
+		m.ID,
+		m.UserID,
+		m.BadgeType,
+		m.UnlockedAt,
+	)
+}
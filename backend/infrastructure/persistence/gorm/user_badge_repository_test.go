@@ -0,0 +1,121 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+// ============================================================================
+// Save テスト
+// ============================================================================
+
+func TestGormUserBadgeRepository_Save(t *testing.T) {
+	t.Run("正常系_獲得済みの場合は無視するINSERTが発行される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserBadgeRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		unlockedAt := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+		badge := entity.NewUserBadge(user.ID(), vo.ReconstructBadgeType(vo.BadgeTypeFirstRecord), unlockedAt)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_badges`")+".*"+regexp.QuoteMeta("ON DUPLICATE KEY UPDATE `id`=`id`")).
+			WithArgs(
+				badge.ID().String(),
+				user.ID().String(),
+				vo.BadgeTypeFirstRecord,
+				unlockedAt,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(ctx, badge); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserBadgeRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		badge := entity.NewUserBadge(user.ID(), vo.ReconstructBadgeType(vo.BadgeTypeFirstRecord), time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_badges`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(ctx, badge); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// FindByUserID テスト
+// ============================================================================
+
+func TestGormUserBadgeRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_獲得バッジが取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserBadgeRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+		unlockedAt := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "badge_type", "unlocked_at"}).
+			AddRow("badge-id-1", user.ID().String(), vo.BadgeTypeFirstRecord, unlockedAt).
+			AddRow("badge-id-2", user.ID().String(), vo.BadgeTypeFirstPhotoAnalysis, unlockedAt.AddDate(0, 0, 1))
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_badges` WHERE user_id = ? ORDER BY unlocked_at ASC")).
+			WithArgs(user.ID().String()).
+			WillReturnRows(rows)
+
+		badges, err := repo.FindByUserID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(badges) != 2 {
+			t.Fatalf("len(badges) = %d, want 2", len(badges))
+		}
+		if badges[0].BadgeType().String() != vo.BadgeTypeFirstRecord {
+			t.Errorf("badges[0].BadgeType = %s, want %s", badges[0].BadgeType().String(), vo.BadgeTypeFirstRecord)
+		}
+		if !badges[0].UnlockedAt().Equal(unlockedAt) {
+			t.Errorf("badges[0].UnlockedAt = %v, want %v", badges[0].UnlockedAt(), unlockedAt)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserBadgeRepository(db)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_badges`")).
+			WithArgs(user.ID().String()).
+			WillReturnError(errors.New("db error"))
+
+		badges, err := repo.FindByUserID(ctx, user.ID())
+		if err == nil {
+			t.Error("FindByUserID() should fail with db error")
+		}
+		if badges != nil {
+			t.Error("badges should be nil on error")
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormUserIdentityRepository はUserIdentityRepositoryのGORM実装
+type GormUserIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserIdentityRepository は新しいGormUserIdentityRepositoryを生成する
+func NewGormUserIdentityRepository(db *gorm.DB) *GormUserIdentityRepository {
+	return &GormUserIdentityRepository{db: db}
+}
+
+// Save は連携を保存する
+func (r *GormUserIdentityRepository) Save(ctx context.Context, identity *entity.UserIdentity) error {
+	tx := GetTx(ctx, r.db)
+	m := toUserIdentityModel(identity)
+	if err := tx.Create(&m).Error; err != nil {
+		logError("Save", err, "user_identity_id", identity.ID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByProviderSubject はプロバイダー名とsubjectで連携を取得する
+func (r *GormUserIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.UserIdentity
+	err := tx.Where("provider = ? AND subject = ?", provider, subject).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByProviderSubject", err, "provider", provider)
+		return nil, err
+	}
+	return toUserIdentityEntity(&m), nil
+}
+
+// FindByUserID はユーザーの全連携を取得する
+func (r *GormUserIdentityRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.UserIdentity, error) {
+	tx := GetTx(ctx, r.db)
+	var models []model.UserIdentity
+	if err := tx.Where("user_id = ?", userID.String()).Order("created_at ASC").Find(&models).Error; err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	identities := make([]*entity.UserIdentity, 0, len(models))
+	for i := range models {
+		identities = append(identities, toUserIdentityEntity(&models[i]))
+	}
+	return identities, nil
+}
+
+// toUserIdentityModel はエンティティをGORMモデルに変換する
+func toUserIdentityModel(identity *entity.UserIdentity) model.UserIdentity {
+	return model.UserIdentity{
+		ID:        identity.ID().String(),
+		UserID:    identity.UserID().String(),
+		Provider:  identity.Provider(),
+		Subject:   identity.Subject(),
+		Email:     identity.Email(),
+		CreatedAt: identity.CreatedAt(),
+	}
+}
+
+// toUserIdentityEntity はGORMモデルをエンティティに変換する
+func toUserIdentityEntity(m *model.UserIdentity) *entity.UserIdentity {
+	return entity.ReconstructUserIdentity(
+		m.ID,
+		m.UserID,
+		m.Provider,
+		m.Subject,
+		m.Email,
+		m.CreatedAt,
+	)
+}
@@ -0,0 +1,140 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormUserIdentityRepository_Save(t *testing.T) {
+	t.Run("正常系_連携が保存される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserIdentityRepository(db)
+
+		identity := entity.NewUserIdentity(vo.NewUserID(), "google", "sub-123", "oidc@example.com")
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities`")).
+			WithArgs(
+				identity.ID().String(),
+				identity.UserID().String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				identity.CreatedAt(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), identity); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserIdentityRepository(db)
+
+		identity := entity.NewUserIdentity(vo.NewUserID(), "google", "sub-123", "oidc@example.com")
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), identity); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormUserIdentityRepository_FindByProviderSubject(t *testing.T) {
+	t.Run("正常系_プロバイダーとsubjectで連携が見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserIdentityRepository(db)
+
+		identity := entity.NewUserIdentity(vo.NewUserID(), "google", "sub-123", "oidc@example.com")
+
+		rows := sqlmock.NewRows(userIdentityColumns()).
+			AddRow(
+				identity.ID().String(),
+				identity.UserID().String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				identity.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE provider = ? AND subject = ?")).
+			WithArgs("google", "sub-123", 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByProviderSubject(context.Background(), "google", "sub-123")
+		if err != nil {
+			t.Fatalf("FindByProviderSubject() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found identity should not be nil")
+		}
+		if !found.UserID().Equals(identity.UserID()) {
+			t.Errorf("UserID = %v, want %v", found.UserID(), identity.UserID())
+		}
+	})
+
+	t.Run("正常系_存在しない場合nilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserIdentityRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE provider = ? AND subject = ?")).
+			WithArgs("google", "unknown", 1).
+			WillReturnRows(sqlmock.NewRows(userIdentityColumns()))
+
+		found, err := repo.FindByProviderSubject(context.Background(), "google", "unknown")
+		if err != nil {
+			t.Fatalf("FindByProviderSubject() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found identity should be nil")
+		}
+	})
+}
+
+func TestGormUserIdentityRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_ユーザーの連携一覧が取得できる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserIdentityRepository(db)
+
+		userID := vo.NewUserID()
+		identity := entity.NewUserIdentity(userID, "google", "sub-123", "oidc@example.com")
+
+		rows := sqlmock.NewRows(userIdentityColumns()).
+			AddRow(
+				identity.ID().String(),
+				userID.String(),
+				"google",
+				"sub-123",
+				"oidc@example.com",
+				identity.CreatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE user_id = ? ORDER BY created_at ASC")).
+			WithArgs(userID.String()).
+			WillReturnRows(rows)
+
+		identities, err := repo.FindByUserID(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(identities) != 1 {
+			t.Fatalf("len(identities) = %d, want 1", len(identities))
+		}
+		if identities[0].Provider() != "google" {
+			t.Errorf("Provider = %v, want google", identities[0].Provider())
+		}
+	})
+}
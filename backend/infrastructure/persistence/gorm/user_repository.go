@@ -3,25 +3,48 @@ package gorm
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
 	"caltrack/domain/entity"
 	"caltrack/domain/vo"
+	"caltrack/infrastructure/fieldcrypto"
 	"caltrack/infrastructure/persistence/gorm/model"
 )
 
+// 暗号化する列の名前（暗号文を別の列・別のユーザーに付け替えられないよう、AADに含める）
+const (
+	columnWeight    = "weight"
+	columnHeight    = "height"
+	columnBirthDate = "birth_date"
+	columnGender    = "gender"
+)
+
+// errFieldEncryptionDisabled は暗号化済みの行を鍵の設定なしで読もうとした場合のエラー
+var errFieldEncryptionDisabled = errors.New("fields are encrypted but field encryption is not configured")
+
 type GormUserRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	encryptor *fieldcrypto.EnvelopeEncryptor
 }
 
-func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
-	return &GormUserRepository{db: db}
+// NewGormUserRepository は GormUserRepository のインスタンスを生成する
+// encryptorを渡すと健康データ（体重・身長・生年月日・性別）を暗号化して保存する
+// nilの場合は平文で保存する（暗号化済みの行は読めない）
+func NewGormUserRepository(db *gorm.DB, encryptor *fieldcrypto.EnvelopeEncryptor) *GormUserRepository {
+	return &GormUserRepository{db: db, encryptor: encryptor}
 }
 
 func (r *GormUserRepository) Save(ctx context.Context, user *entity.User) error {
 	tx := GetTx(ctx, r.db)
-	m := toUserModel(user)
+	m, err := toUserModel(user, r.encryptor)
+	if err != nil {
+		logError("Save", err, "user_id", user.ID().String())
+		return err
+	}
 	if err := tx.Create(&m).Error; err != nil {
 		logError("Save", err, "user_id", user.ID().String())
 		return err
@@ -40,7 +63,7 @@ func (r *GormUserRepository) FindByEmail(ctx context.Context, email vo.Email) (*
 		logError("FindByEmail", err, "email", email.String())
 		return nil, err
 	}
-	return toUserEntity(&m)
+	return toUserEntity(&m, r.encryptor)
 }
 
 func (r *GormUserRepository) ExistsByEmail(ctx context.Context, email vo.Email) (bool, error) {
@@ -67,13 +90,17 @@ func (r *GormUserRepository) FindByID(ctx context.Context, id vo.UserID) (*entit
 		logError("FindByID", err, "user_id", id.String())
 		return nil, err
 	}
-	return toUserEntity(&m)
+	return toUserEntity(&m, r.encryptor)
 }
 
 // Update は既存ユーザーを更新する
 func (r *GormUserRepository) Update(ctx context.Context, user *entity.User) error {
 	tx := GetTx(ctx, r.db)
-	m := toUserModel(user)
+	m, err := toUserModel(user, r.encryptor)
+	if err != nil {
+		logError("Update", err, "user_id", user.ID().String())
+		return err
+	}
 	if err := tx.Save(&m).Error; err != nil {
 		logError("Update", err, "user_id", user.ID().String())
 		return err
@@ -81,34 +108,267 @@ func (r *GormUserRepository) Update(ctx context.Context, user *entity.User) erro
 	return nil
 }
 
-func toUserModel(user *entity.User) model.User {
-	return model.User{
-		ID:             user.ID().String(),
-		Email:          user.Email().String(),
-		HashedPassword: user.HashedPassword().String(),
-		Nickname:       user.Nickname().String(),
-		Weight:         user.Weight().Kg(),
-		Height:         user.Height().Cm(),
-		BirthDate:      user.BirthDate().Time(),
-		Gender:         user.Gender().String(),
-		ActivityLevel:  user.ActivityLevel().String(),
-		CreatedAt:      user.CreatedAt(),
-		UpdatedAt:      user.UpdatedAt(),
+// Search はメールアドレスかニックネームにqueryを含むユーザーを登録日時の新しい順にlimit件取得する
+// queryが空の場合は全ユーザーを対象とし、条件に一致する総件数も返す
+func (r *GormUserRepository) Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, int64, error) {
+	tx := GetTx(ctx, r.db)
+	cond := tx.Model(&model.User{})
+	if query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		cond = cond.Where("email LIKE ? OR nickname LIKE ?", pattern, pattern)
+	}
+	// 件数取得と一覧取得で同じ条件を使い回すため、新しいセッションにする
+	cond = cond.Session(&gorm.Session{})
+
+	var total int64
+	if err := cond.Count(&total).Error; err != nil {
+		logError("Search", err)
+		return nil, 0, err
+	}
+
+	var models []model.User
+	err := cond.Order("created_at DESC").Order("id ASC").Limit(limit).Offset(offset).Find(&models).Error
+	if err != nil {
+		logError("Search", err)
+		return nil, 0, err
+	}
+
+	users := make([]*entity.User, 0, len(models))
+	for i := range models {
+		user, err := toUserEntity(&models[i], r.encryptor)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	return users, total, nil
+}
+
+// escapeLike はLIKE検索のワイルドカード（%・_）とエスケープ文字を文字として扱うようにエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// EncryptExistingRows は平文で保存されている既存の行を暗号化する
+// 暗号化済みの行のうち、現在の鍵以外で暗号化されたデータ鍵は現在の鍵で暗号化し直す（鍵のローテーション）
+// batchSize件ずつID順に処理し、暗号化した件数と再暗号化した件数を返す
+func (r *GormUserRepository) EncryptExistingRows(ctx context.Context, batchSize int) (encrypted int, rewrapped int, err error) {
+	if r.encryptor == nil {
+		return 0, 0, errFieldEncryptionDisabled
+	}
+	tx := GetTx(ctx, r.db)
+
+	lastID := ""
+	for {
+		var models []model.User
+		if err := tx.Where("id > ?", lastID).Order("id").Limit(batchSize).Find(&models).Error; err != nil {
+			logError("EncryptExistingRows", err, "last_id", lastID)
+			return encrypted, rewrapped, err
+		}
+
+		for i := range models {
+			m := &models[i]
+			switch {
+			case m.EncryptedDataKey == nil:
+				if err := r.encryptRow(tx, m); err != nil {
+					logError("EncryptExistingRows", err, "user_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				encrypted++
+			case r.encryptor.NeedsRewrap(*m.EncryptedDataKey):
+				wrapped, err := r.encryptor.Rewrap(*m.EncryptedDataKey)
+				if err != nil {
+					logError("EncryptExistingRows", err, "user_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				if err := tx.Model(&model.User{}).Where("id = ?", m.ID).UpdateColumn("encrypted_data_key", wrapped).Error; err != nil {
+					logError("EncryptExistingRows", err, "user_id", m.ID)
+					return encrypted, rewrapped, err
+				}
+				rewrapped++
+			}
+		}
+
+		if len(models) < batchSize {
+			return encrypted, rewrapped, nil
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// encryptRow は平文の行を暗号化して保存する
+// updated_atはユーザーの操作ではないため更新しない
+func (r *GormUserRepository) encryptRow(tx *gorm.DB, m *model.User) error {
+	user, err := toUserEntity(m, nil)
+	if err != nil {
+		return err
+	}
+	encryptedModel, err := toUserModel(user, r.encryptor)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&model.User{}).Where("id = ?", m.ID).UpdateColumns(map[string]any{
+		"weight":               nil,
+		"height":               nil,
+		"birth_date":           nil,
+		"gender":               nil,
+		"encrypted_data_key":   encryptedModel.EncryptedDataKey,
+		"weight_encrypted":     encryptedModel.WeightEncrypted,
+		"height_encrypted":     encryptedModel.HeightEncrypted,
+		"birth_date_encrypted": encryptedModel.BirthDateEncrypted,
+		"gender_encrypted":     encryptedModel.GenderEncrypted,
+	}).Error
+}
+
+// toUserModel はEntityをモデルに変換する
+// encryptorがある場合は保存のたびに新しいデータ鍵を生成し、健康データを暗号化する
+func toUserModel(user *entity.User, encryptor *fieldcrypto.EnvelopeEncryptor) (model.User, error) {
+	privacy := user.PrivacySettings()
+	m := model.User{
+		ID:                  user.ID().String(),
+		Email:               user.Email().String(),
+		HashedPassword:      user.HashedPassword().String(),
+		Nickname:            user.Nickname().String(),
+		ActivityLevel:       user.ActivityLevel().String(),
+		BudgetMode:          user.BudgetMode().String(),
+		AIProcessingEnabled: privacy.AIProcessingEnabled(),
+		AnalyticsEnabled:    privacy.AnalyticsEnabled(),
+		DataRetentionYears:  privacy.RetentionYears(),
+		PrivacyConsentedAt:  privacy.ConsentedAt(),
+		Role:                user.Role().String(),
+		DisabledAt:          user.DisabledAt(),
+		EmailVerifiedAt:     user.EmailVerifiedAt(),
+		CreatedAt:           user.CreatedAt(),
+		UpdatedAt:           user.UpdatedAt(),
+	}
+	if version := privacy.ConsentVersion(); version != "" {
+		m.PrivacyConsentVersion = &version
+	}
+
+	weight := user.Weight().Kg()
+	height := user.Height().Cm()
+	birthDate := user.BirthDate().Time()
+	gender := user.Gender().String()
+
+	if encryptor == nil {
+		m.Weight = &weight
+		m.Height = &height
+		m.BirthDate = &birthDate
+		m.Gender = &gender
+		return m, nil
+	}
+
+	dataKey, wrapped, err := encryptor.NewDataKey()
+	if err != nil {
+		return model.User{}, err
+	}
+	plaintexts := []struct {
+		column string
+		value  string
+		dest   **string
+	}{
+		{columnWeight, strconv.FormatFloat(weight, 'f', -1, 64), &m.WeightEncrypted},
+		{columnHeight, strconv.FormatFloat(height, 'f', -1, 64), &m.HeightEncrypted},
+		{columnBirthDate, birthDate.Format(time.DateOnly), &m.BirthDateEncrypted},
+		{columnGender, gender, &m.GenderEncrypted},
+	}
+	for _, p := range plaintexts {
+		ciphertext, err := dataKey.Encrypt(p.value, fieldAAD(m.ID, p.column))
+		if err != nil {
+			return model.User{}, err
+		}
+		*p.dest = &ciphertext
 	}
+	m.EncryptedDataKey = &wrapped
+	return m, nil
 }
 
-func toUserEntity(m *model.User) (*entity.User, error) {
+// toUserEntity はモデルをEntityに変換する
+// 暗号化済みの行（encrypted_data_keyがある行）は復号し、それ以外は平文の列を読む
+func toUserEntity(m *model.User, encryptor *fieldcrypto.EnvelopeEncryptor) (*entity.User, error) {
+	var (
+		weight    float64
+		height    float64
+		birthDate time.Time
+		gender    string
+	)
+
+	if m.EncryptedDataKey == nil {
+		if m.Weight != nil {
+			weight = *m.Weight
+		}
+		if m.Height != nil {
+			height = *m.Height
+		}
+		if m.BirthDate != nil {
+			birthDate = *m.BirthDate
+		}
+		if m.Gender != nil {
+			gender = *m.Gender
+		}
+	} else {
+		if encryptor == nil {
+			return nil, errFieldEncryptionDisabled
+		}
+		dataKey, err := encryptor.UnwrapDataKey(*m.EncryptedDataKey)
+		if err != nil {
+			return nil, err
+		}
+		decrypt := func(ciphertext *string, column string) (string, error) {
+			if ciphertext == nil {
+				return "", fieldcrypto.ErrInvalidCiphertext
+			}
+			return dataKey.Decrypt(*ciphertext, fieldAAD(m.ID, column))
+		}
+
+		weightStr, err := decrypt(m.WeightEncrypted, columnWeight)
+		if err != nil {
+			return nil, err
+		}
+		if weight, err = strconv.ParseFloat(weightStr, 64); err != nil {
+			return nil, err
+		}
+		heightStr, err := decrypt(m.HeightEncrypted, columnHeight)
+		if err != nil {
+			return nil, err
+		}
+		if height, err = strconv.ParseFloat(heightStr, 64); err != nil {
+			return nil, err
+		}
+		birthDateStr, err := decrypt(m.BirthDateEncrypted, columnBirthDate)
+		if err != nil {
+			return nil, err
+		}
+		// DATE列の読み出し（loc=Local）と同じくローカルタイムゾーンで復元する
+		if birthDate, err = time.ParseInLocation(time.DateOnly, birthDateStr, time.Local); err != nil {
+			return nil, err
+		}
+		if gender, err = decrypt(m.GenderEncrypted, columnGender); err != nil {
+			return nil, err
+		}
+	}
+
 	return entity.ReconstructUser(
 		m.ID,
 		m.Email,
 		m.HashedPassword,
 		m.Nickname,
-		m.Weight,
-		m.Height,
-		m.BirthDate,
-		m.Gender,
+		weight,
+		height,
+		birthDate,
+		gender,
 		m.ActivityLevel,
+		m.BudgetMode,
+		vo.ReconstructPrivacySettings(m.AIProcessingEnabled, m.AnalyticsEnabled, m.DataRetentionYears, m.PrivacyConsentVersion, m.PrivacyConsentedAt),
+		m.Role,
+		m.DisabledAt,
+		m.EmailVerifiedAt,
 		m.CreatedAt,
 		m.UpdatedAt,
 	)
 }
+
+// fieldAAD は暗号化するフィールドの追加認証データ（行のIDと列名）を返す
+func fieldAAD(rowID, column string) string {
+	return rowID + ":" + column
+}
@@ -1,14 +1,18 @@
 package gorm_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
+	"encoding/base64"
 	"errors"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"caltrack/infrastructure/fieldcrypto"
 	gormPkg "caltrack/infrastructure/persistence/gorm"
 )
 
@@ -19,7 +23,7 @@ import (
 func TestGormUserRepository_Save(t *testing.T) {
 	t.Run("正常系_ユーザーが保存される", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUser(t)
@@ -36,7 +40,16 @@ func TestGormUserRepository_Save(t *testing.T) {
 				user.Height().Cm(),
 				user.BirthDate().Time(),
 				user.Gender().String(),
+				nil, // encrypted_data_key（暗号化なし）
+				nil, // weight_encrypted
+				nil, // height_encrypted
+				nil, // birth_date_encrypted
+				nil, // gender_encrypted
 				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
 				sqlmock.AnyArg(), // created_at
 				sqlmock.AnyArg(), // updated_at
 			).
@@ -52,7 +65,7 @@ func TestGormUserRepository_Save(t *testing.T) {
 
 	t.Run("異常系_DBエラーで保存失敗", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUser(t)
@@ -78,7 +91,7 @@ func TestGormUserRepository_Save(t *testing.T) {
 func TestGormUserRepository_FindByEmail(t *testing.T) {
 	t.Run("正常系_メールでユーザーが見つかる", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUserWithEmail(t, "find@example.com")
@@ -95,6 +108,10 @@ func TestGormUserRepository_FindByEmail(t *testing.T) {
 				user.BirthDate().Time(),
 				user.Gender().String(),
 				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
 				user.CreatedAt(),
 				user.UpdatedAt(),
 			)
@@ -121,7 +138,7 @@ func TestGormUserRepository_FindByEmail(t *testing.T) {
 
 	t.Run("正常系_存在しないメールでnilが返る", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		email := testUserWithEmail(t, "notfound@example.com").Email()
@@ -145,7 +162,7 @@ func TestGormUserRepository_FindByEmail(t *testing.T) {
 
 	t.Run("異常系_DBエラー", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		email := testUserWithEmail(t, "error@example.com").Email()
@@ -172,7 +189,7 @@ func TestGormUserRepository_FindByEmail(t *testing.T) {
 func TestGormUserRepository_ExistsByEmail(t *testing.T) {
 	t.Run("正常系_存在するメールでtrueが返る", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		email := testUserWithEmail(t, "exists@example.com").Email()
@@ -196,7 +213,7 @@ func TestGormUserRepository_ExistsByEmail(t *testing.T) {
 
 	t.Run("正常系_存在しないメールでfalseが返る", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		email := testUserWithEmail(t, "notexists@example.com").Email()
@@ -220,7 +237,7 @@ func TestGormUserRepository_ExistsByEmail(t *testing.T) {
 
 	t.Run("異常系_DBエラー", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		email := testUserWithEmail(t, "error@example.com").Email()
@@ -247,7 +264,7 @@ func TestGormUserRepository_ExistsByEmail(t *testing.T) {
 func TestGormUserRepository_FindByID(t *testing.T) {
 	t.Run("正常系_IDでユーザーが見つかる", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUser(t)
@@ -264,6 +281,10 @@ func TestGormUserRepository_FindByID(t *testing.T) {
 				user.BirthDate().Time(),
 				user.Gender().String(),
 				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
 				user.CreatedAt(),
 				user.UpdatedAt(),
 			)
@@ -285,9 +306,52 @@ func TestGormUserRepository_FindByID(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_プライバシー設定と同意日時が復元される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+		ctx := context.Background()
+
+		user := testUser(t)
+		consentedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows(userColumns()).
+			AddRow(
+				user.ID().String(),
+				user.Email().String(),
+				user.HashedPassword().String(),
+				user.Nickname().String(),
+				user.Weight().Kg(),
+				user.Height().Cm(),
+				user.BirthDate().Time(),
+				user.Gender().String(),
+				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				false, true, 3, "2026-10", consentedAt,
+				"user", nil,
+				user.EmailVerifiedAt(),
+				user.CreatedAt(),
+				user.UpdatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ?")).
+			WithArgs(user.ID().String(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		privacy := found.PrivacySettings()
+		if privacy.AIProcessingEnabled() || !privacy.AnalyticsEnabled() || privacy.RetentionYears() != 3 {
+			t.Errorf("PrivacySettings = %+v, want AI disabled, analytics enabled, 3 years", privacy)
+		}
+		if privacy.ConsentVersion() != "2026-10" || privacy.ConsentedAt() == nil || !privacy.ConsentedAt().Equal(consentedAt) {
+			t.Errorf("consent = %q %v, want 2026-10 %v", privacy.ConsentVersion(), privacy.ConsentedAt(), consentedAt)
+		}
+	})
+
 	t.Run("正常系_存在しないIDでnilが返る", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		nonExistentID := testUser(t).ID()
@@ -311,7 +375,7 @@ func TestGormUserRepository_FindByID(t *testing.T) {
 
 	t.Run("異常系_DBエラー", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		id := testUser(t).ID()
@@ -338,7 +402,7 @@ func TestGormUserRepository_FindByID(t *testing.T) {
 func TestGormUserRepository_Update(t *testing.T) {
 	t.Run("正常系_ユーザーが更新される", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUser(t)
@@ -354,7 +418,16 @@ func TestGormUserRepository_Update(t *testing.T) {
 				user.Height().Cm(),
 				user.BirthDate().Time(),
 				user.Gender().String(),
+				nil, // encrypted_data_key（暗号化なし）
+				nil, // weight_encrypted
+				nil, // height_encrypted
+				nil, // birth_date_encrypted
+				nil, // gender_encrypted
 				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
 				sqlmock.AnyArg(), // created_at
 				sqlmock.AnyArg(), // updated_at
 				user.ID().String(), // WHERE id = ?
@@ -371,7 +444,7 @@ func TestGormUserRepository_Update(t *testing.T) {
 
 	t.Run("異常系_DBエラー", func(t *testing.T) {
 		db, mock := setupMockDB(t)
-		repo := gormPkg.NewGormUserRepository(db)
+		repo := gormPkg.NewGormUserRepository(db, nil)
 		ctx := context.Background()
 
 		user := testUser(t)
@@ -390,6 +463,346 @@ func TestGormUserRepository_Update(t *testing.T) {
 	})
 }
 
+// ============================================================================
+// 暗号化 テスト
+// ============================================================================
+
+func TestGormUserRepository_Encryption(t *testing.T) {
+	t.Run("正常系_健康データを暗号化して保存し復号して読み出せる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		encryptor := testFieldEncryptor(t, "k1")
+		repo := gormPkg.NewGormUserRepository(db, encryptor)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		// 平文の列はNULLで、暗号文の列に値が入る
+		var dataKey, weight, height, birthDate, gender driver.Value
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).
+			WithArgs(
+				user.ID().String(),
+				user.Email().String(),
+				user.HashedPassword().String(),
+				user.Nickname().String(),
+				nil, // weight
+				nil, // height
+				nil, // birth_date
+				nil, // gender
+				captureArg{&dataKey},
+				captureArg{&weight},
+				captureArg{&height},
+				captureArg{&birthDate},
+				captureArg{&gender},
+				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
+				sqlmock.AnyArg(), // created_at
+				sqlmock.AnyArg(), // updated_at
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(ctx, user); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if gender == user.Gender().String() {
+			t.Error("gender should be stored encrypted")
+		}
+
+		// 保存した暗号文をそのまま読み出す
+		rows := sqlmock.NewRows(encryptedUserColumns()).
+			AddRow(
+				user.ID().String(),
+				user.Email().String(),
+				user.HashedPassword().String(),
+				user.Nickname().String(),
+				nil, nil, nil, nil,
+				dataKey, weight, height, birthDate, gender,
+				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
+				user.CreatedAt(),
+				user.UpdatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ?")).
+			WithArgs(user.ID().String(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByID(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if found.Weight().Kg() != user.Weight().Kg() || found.Height().Cm() != user.Height().Cm() {
+			t.Errorf("weight/height = %v/%v, want %v/%v", found.Weight().Kg(), found.Height().Cm(), user.Weight().Kg(), user.Height().Cm())
+		}
+		if !found.BirthDate().Time().Equal(user.BirthDate().Time()) {
+			t.Errorf("BirthDate = %v, want %v", found.BirthDate().Time(), user.BirthDate().Time())
+		}
+		if found.Gender().String() != user.Gender().String() {
+			t.Errorf("Gender = %v, want %v", found.Gender(), user.Gender())
+		}
+	})
+
+	t.Run("異常系_鍵が設定されていない場合は暗号化済みの行を読めない", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		rows := sqlmock.NewRows(encryptedUserColumns()).
+			AddRow(
+				user.ID().String(),
+				user.Email().String(),
+				user.HashedPassword().String(),
+				user.Nickname().String(),
+				nil, nil, nil, nil,
+				"k1:wrapped", "w", "h", "b", "g",
+				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
+				user.CreatedAt(),
+				user.UpdatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ?")).
+			WithArgs(user.ID().String(), 1).
+			WillReturnRows(rows)
+
+		if _, err := repo.FindByID(ctx, user.ID()); err == nil {
+			t.Error("FindByID() should fail without encryption keys")
+		}
+	})
+}
+
+// ============================================================================
+// Search テスト
+// ============================================================================
+
+func TestGormUserRepository_Search(t *testing.T) {
+	t.Run("正常系_メールアドレスかニックネームの部分一致で検索され総件数も返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+		ctx := context.Background()
+
+		user := testUser(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email LIKE ? OR nickname LIKE ?")).
+			WithArgs("%test%", "%test%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+		rows := sqlmock.NewRows(userColumns()).
+			AddRow(
+				user.ID().String(),
+				user.Email().String(),
+				user.HashedPassword().String(),
+				user.Nickname().String(),
+				user.Weight().Kg(),
+				user.Height().Cm(),
+				user.BirthDate().Time(),
+				user.Gender().String(),
+				user.ActivityLevel().String(),
+				user.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"admin", nil, // 権限・停止日時
+				user.EmailVerifiedAt(),
+				user.CreatedAt(),
+				user.UpdatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email LIKE ? OR nickname LIKE ? ORDER BY created_at DESC,id ASC LIMIT ? OFFSET ?")).
+			WithArgs("%test%", "%test%", 20, 20).
+			WillReturnRows(rows)
+
+		users, total, err := repo.Search(ctx, "test", 20, 20)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if total != 21 {
+			t.Errorf("total = %d, want 21", total)
+		}
+		if len(users) != 1 || !users[0].ID().Equals(user.ID()) {
+			t.Fatalf("users = %v, want [%s]", users, user.ID())
+		}
+		if !users[0].IsAdmin() {
+			t.Error("role should be restored as admin")
+		}
+	})
+
+	t.Run("正常系_ワイルドカードは文字としてエスケープされる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email LIKE ? OR nickname LIKE ?")).
+			WithArgs(`%50\%\_off\\%`, `%50\%\_off\\%`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email LIKE ? OR nickname LIKE ?")).
+			WillReturnRows(sqlmock.NewRows(userColumns()))
+
+		users, total, err := repo.Search(context.Background(), `50%_off\`, 20, 0)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if total != 0 || len(users) != 0 {
+			t.Errorf("total = %d, len(users) = %d, want 0, 0", total, len(users))
+		}
+	})
+
+	t.Run("正常系_queryが空の場合は全ユーザーが対象になる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users`")).
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` ORDER BY created_at DESC,id ASC LIMIT ?")).
+			WithArgs(20).
+			WillReturnRows(sqlmock.NewRows(userColumns()))
+
+		if _, _, err := repo.Search(context.Background(), "", 20, 0); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users`")).
+			WillReturnError(errors.New("db error"))
+
+		if _, _, err := repo.Search(context.Background(), "test", 20, 0); err == nil {
+			t.Error("Search() should fail with db error")
+		}
+	})
+}
+
+// ============================================================================
+// EncryptExistingRows テスト
+// ============================================================================
+
+func TestGormUserRepository_EncryptExistingRows(t *testing.T) {
+	t.Run("正常系_平文の行を暗号化し古い鍵のデータ鍵を再暗号化する", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		oldEncryptor := testFieldEncryptor(t, "k1")
+		_, oldWrapped, err := oldEncryptor.NewDataKey()
+		if err != nil {
+			t.Fatalf("NewDataKey() error = %v", err)
+		}
+		// 現在の鍵をk2に切り替える（k1も復号用に残す）
+		repo := gormPkg.NewGormUserRepository(db, testFieldEncryptor(t, "k2"))
+		ctx := context.Background()
+
+		plainUser := testUserWithEmail(t, "plain@example.com")
+		encryptedUser := testUserWithEmail(t, "encrypted@example.com")
+
+		rows := sqlmock.NewRows(encryptedUserColumns()).
+			AddRow(
+				"id-1",
+				plainUser.Email().String(),
+				plainUser.HashedPassword().String(),
+				plainUser.Nickname().String(),
+				plainUser.Weight().Kg(),
+				plainUser.Height().Cm(),
+				plainUser.BirthDate().Time(),
+				plainUser.Gender().String(),
+				nil, nil, nil, nil, nil,
+				plainUser.ActivityLevel().String(),
+				plainUser.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				plainUser.EmailVerifiedAt(),
+				plainUser.CreatedAt(),
+				plainUser.UpdatedAt(),
+			).
+			AddRow(
+				"id-2",
+				encryptedUser.Email().String(),
+				encryptedUser.HashedPassword().String(),
+				encryptedUser.Nickname().String(),
+				nil, nil, nil, nil,
+				oldWrapped, "w", "h", "b", "g",
+				encryptedUser.ActivityLevel().String(),
+				encryptedUser.BudgetMode().String(),
+				true, false, 0, nil, nil, // プライバシー設定（既定値）
+				"user", nil, // 権限・停止日時
+				encryptedUser.EmailVerifiedAt(),
+				encryptedUser.CreatedAt(),
+				encryptedUser.UpdatedAt(),
+			)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id > ? ORDER BY id LIMIT ?")).
+			WithArgs("", 10).
+			WillReturnRows(rows)
+
+		// 平文の行: 平文の列をNULLにして暗号文を書き込む（updated_atは更新しない）
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `birth_date`=?,`birth_date_encrypted`=?,`encrypted_data_key`=?,`gender`=?,`gender_encrypted`=?,`height`=?,`height_encrypted`=?,`weight`=?,`weight_encrypted`=? WHERE id = ?")).
+			WithArgs(nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), "id-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// 古い鍵の行: データ鍵だけを再暗号化する
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `encrypted_data_key`=? WHERE id = ?")).
+			WithArgs(keyIDArg{"k2"}, "id-2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		encrypted, rewrapped, err := repo.EncryptExistingRows(ctx, 10)
+		if err != nil {
+			t.Fatalf("EncryptExistingRows() error = %v", err)
+		}
+		if encrypted != 1 || rewrapped != 1 {
+			t.Errorf("encrypted/rewrapped = %d/%d, want 1/1", encrypted, rewrapped)
+		}
+	})
+
+	t.Run("異常系_鍵が設定されていない場合はエラー", func(t *testing.T) {
+		db, _ := setupMockDB(t)
+		repo := gormPkg.NewGormUserRepository(db, nil)
+
+		if _, _, err := repo.EncryptExistingRows(context.Background(), 10); err == nil {
+			t.Error("EncryptExistingRows() should fail without encryption keys")
+		}
+	})
+}
+
+// testFieldEncryptor はk1・k2の2つの鍵を持ち、currentKeyIDの鍵で暗号化するEnvelopeEncryptorを返す
+func testFieldEncryptor(t *testing.T, currentKeyID string) *fieldcrypto.EnvelopeEncryptor {
+	t.Helper()
+	k1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	k2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+	provider, err := fieldcrypto.NewEnvKeyProvider("k1:"+k1+",k2:"+k2, currentKeyID)
+	if err != nil {
+		t.Fatalf("NewEnvKeyProvider() error = %v", err)
+	}
+	return fieldcrypto.NewEnvelopeEncryptor(provider)
+}
+
+// captureArg は任意の値にマッチし、その値を記録する
+type captureArg struct {
+	value *driver.Value
+}
+
+func (a captureArg) Match(v driver.Value) bool {
+	*a.value = v
+	return true
+}
+
+// keyIDArg は指定の鍵IDで暗号化されたデータ鍵にマッチする
+type keyIDArg struct {
+	keyID string
+}
+
+func (a keyIDArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, a.keyID+":")
+}
+
 // ============================================================================
 // ヘルパー: driver.Valuer実装（時刻をtime.Timeとして扱う）
 // ============================================================================
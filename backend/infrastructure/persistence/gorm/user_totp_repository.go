@@ -0,0 +1,88 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/gorm/model"
+)
+
+// GormUserTOTPRepository はUserTOTPRepositoryのGORM実装
+type GormUserTOTPRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserTOTPRepository は新しいGormUserTOTPRepositoryを生成する
+func NewGormUserTOTPRepository(db *gorm.DB) *GormUserTOTPRepository {
+	return &GormUserTOTPRepository{db: db}
+}
+
+// Save はTOTP設定を保存する
+// 登録のやり直しや有効化・コード使用の記録では既存の設定を上書きする
+func (r *GormUserTOTPRepository) Save(ctx context.Context, totp *entity.UserTOTP) error {
+	tx := GetTx(ctx, r.db)
+	m := toUserTOTPModel(totp)
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "created_at", "updated_at"}),
+	}).Create(&m).Error
+	if err != nil {
+		logError("Save", err, "user_id", totp.UserID().String())
+		return err
+	}
+	return nil
+}
+
+// FindByUserID はユーザーのTOTP設定を取得する
+func (r *GormUserTOTPRepository) FindByUserID(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error) {
+	tx := GetTx(ctx, r.db)
+	var m model.UserTOTP
+	err := tx.Where("user_id = ?", userID.String()).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logError("FindByUserID", err, "user_id", userID.String())
+		return nil, err
+	}
+	return toUserTOTPEntity(&m), nil
+}
+
+// DeleteByUserID はユーザーのTOTP設定を削除する
+func (r *GormUserTOTPRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	tx := GetTx(ctx, r.db)
+	if err := tx.Where("user_id = ?", userID.String()).Delete(&model.UserTOTP{}).Error; err != nil {
+		logError("DeleteByUserID", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// toUserTOTPModel はエンティティをGORMモデルに変換する
+func toUserTOTPModel(totp *entity.UserTOTP) model.UserTOTP {
+	return model.UserTOTP{
+		UserID:       totp.UserID().String(),
+		Secret:       totp.Secret().String(),
+		EnabledAt:    totp.EnabledAt(),
+		LastUsedStep: totp.LastUsedStep(),
+		CreatedAt:    totp.CreatedAt(),
+		UpdatedAt:    totp.UpdatedAt(),
+	}
+}
+
+// toUserTOTPEntity はGORMモデルをエンティティに変換する
+func toUserTOTPEntity(m *model.UserTOTP) *entity.UserTOTP {
+	return entity.ReconstructUserTOTP(
+		m.UserID,
+		m.Secret,
+		m.EnabledAt,
+		m.LastUsedStep,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
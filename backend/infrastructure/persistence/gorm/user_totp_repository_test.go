@@ -0,0 +1,129 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	gormPkg "caltrack/infrastructure/persistence/gorm"
+)
+
+func TestGormUserTOTPRepository_Save(t *testing.T) {
+	t.Run("正常系_既存の設定を上書きするUPSERTが発行される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserTOTPRepository(db)
+
+		totp, err := entity.NewUserTOTP(vo.NewUserID())
+		if err != nil {
+			t.Fatalf("failed to create test totp: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_totps`")+".*"+regexp.QuoteMeta("ON DUPLICATE KEY UPDATE")).
+			WithArgs(
+				totp.UserID().String(),
+				totp.Secret().String(),
+				nil, // enabled_at
+				int64(0),
+				sqlmock.AnyArg(), // created_at
+				sqlmock.AnyArg(), // updated_at
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Save(context.Background(), totp); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserTOTPRepository(db)
+
+		totp, _ := entity.NewUserTOTP(vo.NewUserID())
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_totps`")).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := repo.Save(context.Background(), totp); err == nil {
+			t.Error("Save() should fail with db error")
+		}
+	})
+}
+
+func TestGormUserTOTPRepository_FindByUserID(t *testing.T) {
+	t.Run("正常系_設定が見つかる", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserTOTPRepository(db)
+
+		userID := vo.NewUserID()
+		secret, _ := vo.NewTOTPSecret()
+		enabledAt := time.Now().Add(-time.Hour)
+
+		rows := sqlmock.NewRows(userTOTPColumns()).
+			AddRow(userID.String(), secret.String(), enabledAt, int64(12345), enabledAt, enabledAt)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_totps` WHERE user_id = ?")).
+			WithArgs(userID.String(), 1).
+			WillReturnRows(rows)
+
+		found, err := repo.FindByUserID(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if found == nil {
+			t.Fatal("found totp should not be nil")
+		}
+		if !found.IsEnabled() {
+			t.Error("found totp should be enabled")
+		}
+		if found.Secret().String() != secret.String() {
+			t.Errorf("Secret = %s, want %s", found.Secret().String(), secret.String())
+		}
+		if found.LastUsedStep() != 12345 {
+			t.Errorf("LastUsedStep = %d, want 12345", found.LastUsedStep())
+		}
+	})
+
+	t.Run("正常系_未登録でnilが返る", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserTOTPRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_totps` WHERE user_id = ?")).
+			WillReturnRows(sqlmock.NewRows(userTOTPColumns()))
+
+		found, err := repo.FindByUserID(context.Background(), vo.NewUserID())
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if found != nil {
+			t.Error("found totp should be nil")
+		}
+	})
+}
+
+func TestGormUserTOTPRepository_DeleteByUserID(t *testing.T) {
+	t.Run("正常系_設定が削除される", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := gormPkg.NewGormUserTOTPRepository(db)
+
+		userID := vo.NewUserID()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_totps` WHERE user_id = ?")).
+			WithArgs(userID.String()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.DeleteByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"caltrack/domain/entity"
+)
+
+// pruneThreshold はこの件数を超えたら期限切れの記録を掃除する
+const pruneThreshold = 10000
+
+// loginAttemptRecord は1キー分の失敗状況
+type loginAttemptRecord struct {
+	failures     int
+	lastFailedAt time.Time
+	resetAfter   time.Duration
+}
+
+// LoginAttemptStore はLoginAttemptStoreのインメモリ実装
+// プロセス内でのみ共有されるため、テストや単一ノード構成で使用する
+type LoginAttemptStore struct {
+	mu      sync.Mutex
+	records map[string]loginAttemptRecord
+}
+
+// NewLoginAttemptStore は新しいLoginAttemptStoreを生成する
+func NewLoginAttemptStore() *LoginAttemptStore {
+	return &LoginAttemptStore{records: make(map[string]loginAttemptRecord)}
+}
+
+// Find は指定キーの失敗状況を取得する（記録がない場合はnil）
+func (s *LoginAttemptStore) Find(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return entity.ReconstructLoginAttempt(key, record.failures, record.lastFailedAt), nil
+}
+
+// RecordFailure は失敗を1回加算し、加算後の状況を返す
+func (s *LoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.records) >= pruneThreshold {
+		s.prune(now)
+	}
+
+	record, ok := s.records[key]
+	if !ok || now.Sub(record.lastFailedAt) >= resetAfter {
+		record = loginAttemptRecord{}
+	}
+	record.failures++
+	record.lastFailedAt = now
+	record.resetAfter = resetAfter
+	s.records[key] = record
+
+	return entity.ReconstructLoginAttempt(key, record.failures, record.lastFailedAt), nil
+}
+
+// Reset は指定キーの失敗状況を削除する
+func (s *LoginAttemptStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// prune はリセット期間を過ぎた記録を削除する（呼び出し側でロックを取得すること）
+func (s *LoginAttemptStore) prune(now time.Time) {
+	for key, record := range s.records {
+		if now.Sub(record.lastFailedAt) >= record.resetAfter {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"caltrack/infrastructure/persistence/memory"
+)
+
+func TestLoginAttemptStore(t *testing.T) {
+	ctx := context.Background()
+	key := "email:test@example.com"
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("正常系_記録がなければnil", func(t *testing.T) {
+		store := memory.NewLoginAttemptStore()
+
+		attempt, err := store.Find(ctx, key)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if attempt != nil {
+			t.Errorf("Find() = %+v, want nil", attempt)
+		}
+	})
+
+	t.Run("正常系_失敗回数が加算される", func(t *testing.T) {
+		store := memory.NewLoginAttemptStore()
+
+		store.RecordFailure(ctx, key, now, time.Hour)
+		attempt, err := store.RecordFailure(ctx, key, now.Add(time.Minute), time.Hour)
+		if err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+		if attempt.Failures() != 2 {
+			t.Errorf("Failures() = %d, want 2", attempt.Failures())
+		}
+		if !attempt.LastFailedAt().Equal(now.Add(time.Minute)) {
+			t.Errorf("LastFailedAt() = %v, want %v", attempt.LastFailedAt(), now.Add(time.Minute))
+		}
+
+		found, _ := store.Find(ctx, key)
+		if found == nil || found.Failures() != 2 {
+			t.Errorf("Find() = %+v, want 2 failures", found)
+		}
+	})
+
+	t.Run("正常系_リセット期間経過後は1回目から数え直す", func(t *testing.T) {
+		store := memory.NewLoginAttemptStore()
+
+		store.RecordFailure(ctx, key, now, time.Hour)
+		store.RecordFailure(ctx, key, now, time.Hour)
+		attempt, _ := store.RecordFailure(ctx, key, now.Add(time.Hour), time.Hour)
+
+		if attempt.Failures() != 1 {
+			t.Errorf("Failures() = %d, want 1", attempt.Failures())
+		}
+	})
+
+	t.Run("正常系_Resetで記録が削除される", func(t *testing.T) {
+		store := memory.NewLoginAttemptStore()
+
+		store.RecordFailure(ctx, key, now, time.Hour)
+		if err := store.Reset(ctx, key); err != nil {
+			t.Fatalf("Reset() error = %v", err)
+		}
+
+		attempt, _ := store.Find(ctx, key)
+		if attempt != nil {
+			t.Errorf("Find() = %+v, want nil", attempt)
+		}
+	})
+
+	t.Run("正常系_キーごとに独立して数える", func(t *testing.T) {
+		store := memory.NewLoginAttemptStore()
+
+		store.RecordFailure(ctx, key, now, time.Hour)
+		attempt, _ := store.RecordFailure(ctx, "ip:192.0.2.1", now, time.Hour)
+
+		if attempt.Failures() != 1 {
+			t.Errorf("Failures() = %d, want 1", attempt.Failures())
+		}
+	})
+}
@@ -33,9 +33,9 @@ type geminiResponse struct {
 	Calories int    `json:"calories"`
 }
 
-// Analyze は画像データを解析し、認識した食品のリストを返す
+// Analyze は画像データを解析し、認識した食品のリストとトークン使用量を返す
 // configからモデル名・プロンプトを受け取る（ビジネスロジックはUsecase層で管理）
-func (g *GeminiImageAnalyzer) Analyze(ctx context.Context, config usecaseService.ImageAnalyzerConfig, imageData string, mimeType string) ([]usecaseService.AnalyzedItem, error) {
+func (g *GeminiImageAnalyzer) Analyze(ctx context.Context, config usecaseService.ImageAnalyzerConfig, imageData string, mimeType string) (*usecaseService.ImageAnalysisOutput, error) {
 	if g.client == nil {
 		return nil, errors.New("Gemini client is not initialized")
 	}
@@ -80,16 +80,19 @@ func (g *GeminiImageAnalyzer) Analyze(ctx context.Context, config usecaseService
 	}
 
 	// レスポンスログ
-	logger.Debug("Gemini API Response", "text", responseText)
+	logger.Debug("Gemini API Response", "textLength", len(responseText), logger.Sensitive("text"))
 
 	// JSONをパース
 	items, err := parseGeminiResponse(responseText)
 	if err != nil {
-		logger.Error("Failed to parse Gemini response", "error", err, "response", responseText)
+		logger.Error("Failed to parse Gemini response", "error", err, logger.Sensitive("response"))
 		return nil, err
 	}
 
-	return items, nil
+	return &usecaseService.ImageAnalysisOutput{
+		Items: items,
+		Usage: extractTokenUsage(resp),
+	}, nil
 }
 
 // extractResponseText はGeminiのレスポンスからテキスト部分を抽出する
@@ -117,6 +120,19 @@ func extractResponseText(resp *genai.GenerateContentResponse) (string, error) {
 	return strings.Join(textParts, ""), nil
 }
 
+// extractTokenUsage はGeminiのレスポンスからトークン使用量を取り出す
+// UsageMetadataが含まれない場合はゼロ値を返す
+func extractTokenUsage(resp *genai.GenerateContentResponse) usecaseService.TokenUsage {
+	if resp == nil || resp.UsageMetadata == nil {
+		return usecaseService.TokenUsage{}
+	}
+	return usecaseService.TokenUsage{
+		PromptTokens: int(resp.UsageMetadata.PromptTokenCount),
+		OutputTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+		TotalTokens:  int(resp.UsageMetadata.TotalTokenCount),
+	}
+}
+
 // parseGeminiResponse はGeminiのレスポンステキストをパースしてAnalyzedItemのスライスに変換する
 func parseGeminiResponse(responseText string) ([]usecaseService.AnalyzedItem, error) {
 	// 前後の空白を除去
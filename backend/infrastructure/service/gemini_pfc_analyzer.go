@@ -61,5 +61,6 @@ func (g *GeminiPfcAnalyzer) Analyze(ctx context.Context, config usecaseService.P
 
 	return &usecaseService.NutritionAdviceOutput{
 		Advice: responseText,
+		Usage:  extractTokenUsage(resp),
 	}, nil
 }
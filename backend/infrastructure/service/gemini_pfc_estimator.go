@@ -46,7 +46,7 @@ func (g *GeminiPfcEstimator) Estimate(ctx context.Context, config usecaseService
 	model := g.client.GenerativeModel(config.ModelName)
 
 	// リクエストログ
-	logger.Debug("Gemini PFC Estimator Request", "model", config.ModelName, "foodItems", input.FoodItems)
+	logger.Debug("Gemini PFC Estimator Request", "model", config.ModelName, "foodItemCount", len(input.FoodItems), logger.Sensitive("foodItems"))
 
 	// configで指定されたプロンプトを使用してリクエストを送信
 	resp, err := model.GenerateContent(ctx, genai.Text(config.Prompt))
@@ -70,14 +70,15 @@ func (g *GeminiPfcEstimator) Estimate(ctx context.Context, config usecaseService
 	}
 
 	// レスポンスログ
-	logger.Debug("Gemini PFC Estimator Response", "raw", responseText)
+	logger.Debug("Gemini PFC Estimator Response", "rawLength", len(responseText), logger.Sensitive("raw"))
 
 	// JSONをパース
 	output, err := parsePfcResponse(responseText)
 	if err != nil {
-		logger.Error("Failed to parse PFC response", "error", err, "raw", responseText)
+		logger.Error("Failed to parse PFC response", "error", err, logger.Sensitive("raw"))
 		return nil, fmt.Errorf("failed to parse PFC response: %w", err)
 	}
+	output.Usage = extractTokenUsage(resp)
 
 	return output, nil
 }
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"caltrack/pkg/logger"
+	usecaseService "caltrack/usecase/service"
+)
+
+// LogMailer はメールを送信せずにファイルまたはログへ書き出すMailerの実装
+// SMTPサーバーを用意しないローカル開発やテストで、メール本文（リンク等）を確認するために使う
+type LogMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogMailer はLogMailerを生成する
+// pathが空の場合はアプリケーションログに出力する
+func NewLogMailer(path string) *LogMailer {
+	return &LogMailer{path: path}
+}
+
+// Send はメールをファイルに追記、またはログに出力する
+func (m *LogMailer) Send(ctx context.Context, msg usecaseService.Mail) error {
+	if m.path == "" {
+		logger.Info("Mail (not sent)", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write mail log file: %w", err)
+	}
+	return nil
+}
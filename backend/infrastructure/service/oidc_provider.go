@@ -0,0 +1,470 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	usecaseService "caltrack/usecase/service"
+)
+
+const (
+	oidcHTTPTimeout = 10 * time.Second
+	// oidcClockSkew はIDトークンの有効期限判定で許容する時計のずれ
+	oidcClockSkew = time.Minute
+	// oidcMaxResponseBytes はプロバイダーからのレスポンスとして読み込む最大サイズ
+	oidcMaxResponseBytes = 1 << 20
+)
+
+// OIDCProvider はディスカバリー（/.well-known/openid-configuration）に対応した
+// OpenID Connectプロバイダーの実装
+// 外部ライブラリを使わず、標準ライブラリでRS256/ES256のIDトークンを検証する
+type OIDCProvider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	httpClient   *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+}
+
+// oidcDiscovery はディスカバリードキュメントのうち利用する項目
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDCProvider はOIDCProviderを生成する
+// httpClientがnilの場合はタイムアウト付きのデフォルトクライアントを使用する
+// ディスカバリーは初回利用時に行い、結果をキャッシュする
+func NewOIDCProvider(name, issuer, clientID, clientSecret, redirectURL string, scopes []string, httpClient *http.Client) *OIDCProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: oidcHTTPTimeout}
+	}
+	return &OIDCProvider{
+		name:         name,
+		issuer:       strings.TrimRight(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		httpClient:   httpClient,
+	}
+}
+
+// Name はプロバイダー名を返す
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL は認可エンドポイントへのリダイレクトURLを組み立てる
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", strings.Join(p.scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange は認可コードをトークンに交換し、IDトークンを検証してクレームを返す
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*usecaseService.OIDCClaims, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCExchangeFailed, err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("client_id", p.clientID)
+	form.Set("client_secret", p.clientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCExchangeFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.doJSON(req, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCExchangeFailed, err)
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, fmt.Errorf("%w: id_token is missing", domainErrors.ErrOIDCTokenInvalid)
+	}
+
+	return p.verifyIDToken(ctx, tokenResponse.IDToken, nonce)
+}
+
+// idTokenClaims はIDトークンのペイロードのうち検証・利用する項目
+type idTokenClaims struct {
+	Issuer          string       `json:"iss"`
+	Subject         string       `json:"sub"`
+	Audience        audience     `json:"aud"`
+	AuthorizedParty string       `json:"azp"`
+	Expiry          float64      `json:"exp"`
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+}
+
+// audience は文字列または文字列配列のどちらでも表現される aud クレーム
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// flexibleBool は真偽値または "true"/"false" 文字列で表現される真偽値
+// 一部のプロバイダーは email_verified を文字列で返すため
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	*b = flexibleBool(strings.EqualFold(str, "true"))
+	return nil
+}
+
+// verifyIDToken はIDトークンの署名とクレームを検証する
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, rawToken, nonce string) (*usecaseService.OIDCClaims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed jwt", domainErrors.ErrOIDCTokenInvalid)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %v", domainErrors.ErrOIDCTokenInvalid, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature encoding", domainErrors.ErrOIDCTokenInvalid)
+	}
+
+	key, err := p.getKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCTokenInvalid, err)
+	}
+
+	var claims idTokenClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: invalid payload: %v", domainErrors.ErrOIDCTokenInvalid, err)
+	}
+
+	switch {
+	case strings.TrimRight(claims.Issuer, "/") != p.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", domainErrors.ErrOIDCTokenInvalid, claims.Issuer)
+	case !claims.Audience.contains(p.clientID):
+		return nil, fmt.Errorf("%w: audience mismatch", domainErrors.ErrOIDCTokenInvalid)
+	case claims.AuthorizedParty != "" && claims.AuthorizedParty != p.clientID:
+		return nil, fmt.Errorf("%w: authorized party mismatch", domainErrors.ErrOIDCTokenInvalid)
+	case time.Unix(int64(claims.Expiry), 0).Add(oidcClockSkew).Before(time.Now()):
+		return nil, fmt.Errorf("%w: token expired", domainErrors.ErrOIDCTokenInvalid)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", domainErrors.ErrOIDCTokenInvalid)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: subject is missing", domainErrors.ErrOIDCTokenInvalid)
+	}
+
+	return &usecaseService.OIDCClaims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// decodeJWTPart はJWTのヘッダー・ペイロード部をデコードする
+func decodeJWTPart(part string, v any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
+
+// verifySignature はJWSの署名を検証する
+// none や HS256 などを受け付けないよう、対応するアルゴリズムと鍵の種類を固定で照合する
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match RS256")
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match ES256")
+		}
+		if len(signature) != 64 {
+			return errors.New("invalid ES256 signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+// getDiscovery はディスカバリードキュメントを取得する（取得済みの場合はキャッシュを返す）
+func (p *OIDCProvider) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var discovery oidcDiscovery
+	if err := p.doJSON(req, &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if strings.TrimRight(discovery.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("oidc discovery issuer mismatch: %q", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is incomplete")
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// getKey はkidに対応する公開鍵を返す
+// キャッシュに無いkidの場合は鍵のローテーションとみなし、JWKSを取得し直す
+func (p *OIDCProvider) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCExchangeFailed, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrOIDCExchangeFailed, err)
+	}
+	p.keys = keys
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id %q", domainErrors.ErrOIDCTokenInvalid, kid)
+}
+
+// lookupKey はkidで公開鍵を探す
+// kidが指定されていない場合は、鍵が1つだけのときに限りその鍵を使う
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if kid != "" {
+		key, ok := keys[kid]
+		return key, ok
+	}
+	if len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// jsonWebKey はJWKのうち署名検証に利用する項目
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys はJWKSを取得し、署名用の公開鍵をkidごとに返す
+// 対応していない種類の鍵は読み飛ばす
+func (p *OIDCProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.doJSON(req, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// publicKey はJWKを公開鍵に変換する
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("rsa exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !key.Curve.IsOnCurve(x, y) {
+			return nil, errors.New("ec point is not on curve")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
+
+// doJSON はリクエストを送信し、200番台のJSONレスポンスをデコードする
+func (p *OIDCProvider) doJSON(req *http.Request, v any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Redacted())
+	}
+	return json.Unmarshal(body, v)
+}
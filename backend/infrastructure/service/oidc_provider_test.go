@@ -0,0 +1,360 @@
+package service_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "caltrack/domain/errors"
+	infraService "caltrack/infrastructure/service"
+)
+
+const (
+	testClientID     = "caltrack-client"
+	testClientSecret = "caltrack-secret"
+	testRedirectURL  = "http://localhost:8080/api/v1/auth/oidc/mock/callback"
+	testCode         = "auth-code"
+	testVerifier     = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testNonce        = "test-nonce"
+)
+
+// mockIssuer はテスト用のローカルOpenID Connectプロバイダー
+// ディスカバリー・JWKS・トークンエンドポイントを提供し、任意のクレームでIDトークンを発行する
+type mockIssuer struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	rsaKey     *rsa.PrivateKey
+	kid        string
+	ecKey      *ecdsa.PrivateKey
+	claims     map[string]any
+	signWith   string // "RS256" または "ES256"
+	tokenError bool
+	jwksHits   int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ec key: %v", err)
+	}
+
+	m := &mockIssuer{rsaKey: rsaKey, kid: "rsa-1", ecKey: ecKey, signWith: "RS256"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.jwksHits++
+		writeJSON(w, map[string]any{"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": m.kid,
+				"use": "sig",
+				"n":   b64(m.rsaKey.N.Bytes()),
+				"e":   b64(big.NewInt(int64(m.rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-1",
+				"use": "sig",
+				"crv": "P-256",
+				"x":   b64(padTo32(m.ecKey.X.Bytes())),
+				"y":   b64(padTo32(m.ecKey.Y.Bytes())),
+			},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if err := r.ParseForm(); err != nil || m.tokenError ||
+			r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("code") != testCode ||
+			r.PostForm.Get("code_verifier") != testVerifier ||
+			r.PostForm.Get("client_id") != testClientID ||
+			r.PostForm.Get("client_secret") != testClientSecret ||
+			r.PostForm.Get("redirect_uri") != testRedirectURL {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     m.signLocked(t),
+		})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	m.claims = m.defaultClaims()
+	return m
+}
+
+func (m *mockIssuer) defaultClaims() map[string]any {
+	return map[string]any{
+		"iss":            m.server.URL,
+		"sub":            "mock-user-1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          testNonce,
+		"email":          "oidc@example.com",
+		"email_verified": true,
+		"name":           "OIDC User",
+	}
+}
+
+func (m *mockIssuer) setClaim(key string, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claims[key] = value
+}
+
+// signLocked は現在のクレームでIDトークンを署名する（mu を保持した状態で呼ぶ）
+func (m *mockIssuer) signLocked(t *testing.T) string {
+	header := map[string]string{"alg": m.signWith, "typ": "JWT"}
+	if m.signWith == "ES256" {
+		header["kid"] = "ec-1"
+	} else {
+		header["kid"] = m.kid
+	}
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(m.claims)
+	signingInput := b64(headerJSON) + "." + b64(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	if m.signWith == "ES256" {
+		r, s, err := ecdsa.Sign(rand.Reader, m.ecKey, digest[:])
+		if err != nil {
+			t.Errorf("failed to sign: %v", err)
+		}
+		signature = append(padTo32(r.Bytes()), padTo32(s.Bytes())...)
+	} else {
+		sig, err := rsa.SignPKCS1v15(rand.Reader, m.rsaKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Errorf("failed to sign: %v", err)
+		}
+		signature = sig
+	}
+	return signingInput + "." + b64(signature)
+}
+
+// rotateKey は署名鍵を新しい鍵に入れ替える
+func (m *mockIssuer) rotateKey(t *testing.T) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rsaKey = key
+	m.kid = "rsa-2"
+}
+
+func (m *mockIssuer) provider() *infraService.OIDCProvider {
+	return infraService.NewOIDCProvider("mock", m.server.URL, testClientID, testClientSecret, testRedirectURL, []string{"openid", "email"}, m.server.Client())
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func padTo32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
+	}
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	t.Run("正常系_PKCEとstate・nonceを含む認可URLが組み立てられる", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		provider := issuer.provider()
+
+		authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+		if err != nil {
+			t.Fatalf("AuthCodeURL() error = %v", err)
+		}
+
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			t.Fatalf("invalid url: %v", err)
+		}
+		if !strings.HasPrefix(authURL, issuer.server.URL+"/authorize?") {
+			t.Errorf("auth url = %v, want authorization endpoint", authURL)
+		}
+		want := map[string]string{
+			"response_type":         "code",
+			"client_id":             testClientID,
+			"redirect_uri":          testRedirectURL,
+			"scope":                 "openid email",
+			"state":                 "state-1",
+			"nonce":                 "nonce-1",
+			"code_challenge":        "challenge-1",
+			"code_challenge_method": "S256",
+		}
+		for key, value := range want {
+			if got := parsed.Query().Get(key); got != value {
+				t.Errorf("%s = %v, want %v", key, got, value)
+			}
+		}
+	})
+
+	t.Run("異常系_ディスカバリーのissuerが一致しない場合はエラー", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		provider := infraService.NewOIDCProvider("mock", issuer.server.URL+"/other", testClientID, testClientSecret, testRedirectURL, nil, issuer.server.Client())
+
+		if _, err := provider.AuthCodeURL(context.Background(), "s", "n", "c"); err == nil {
+			t.Error("AuthCodeURL() should fail when discovery is unavailable")
+		}
+	})
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	t.Run("正常系_RS256のIDトークンが検証されクレームが返る", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+
+		claims, err := issuer.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+		if err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		if claims.Subject != "mock-user-1" || claims.Email != "oidc@example.com" || !claims.EmailVerified {
+			t.Errorf("claims = %+v", claims)
+		}
+		if claims.Name != "OIDC User" {
+			t.Errorf("Name = %v, want OIDC User", claims.Name)
+		}
+	})
+
+	t.Run("正常系_ES256のIDトークンが検証される", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		issuer.signWith = "ES256"
+
+		if _, err := issuer.provider().Exchange(context.Background(), testCode, testVerifier, testNonce); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+	})
+
+	t.Run("正常系_audが配列でemail_verifiedが文字列でも受け付ける", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		issuer.setClaim("aud", []string{testClientID, "other"})
+		issuer.setClaim("azp", testClientID)
+		issuer.setClaim("email_verified", "true")
+
+		claims, err := issuer.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+		if err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		if !claims.EmailVerified {
+			t.Error("EmailVerified should be true")
+		}
+	})
+
+	t.Run("正常系_未知のkidの場合はJWKSを取得し直す", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		provider := issuer.provider()
+
+		if _, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		issuer.rotateKey(t)
+		if _, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce); err != nil {
+			t.Fatalf("Exchange() after rotation error = %v", err)
+		}
+		if issuer.jwksHits != 2 {
+			t.Errorf("jwks fetched %d times, want 2", issuer.jwksHits)
+		}
+	})
+
+	t.Run("異常系_code_verifierが一致しない場合は交換に失敗する", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+
+		_, err := issuer.provider().Exchange(context.Background(), testCode, "wrong-verifier", testNonce)
+		if !errors.Is(err, domainErrors.ErrOIDCExchangeFailed) {
+			t.Errorf("Exchange() error = %v, want ErrOIDCExchangeFailed", err)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		key   string
+		value any
+	}{
+		{"異常系_nonceが一致しない", "nonce", "other-nonce"},
+		{"異常系_audが一致しない", "aud", "other-client"},
+		{"異常系_azpが一致しない", "azp", "other-client"},
+		{"異常系_issが一致しない", "iss", "https://evil.example.com"},
+		{"異常系_有効期限切れ", "exp", time.Now().Add(-time.Hour).Unix()},
+		{"異常系_subが空", "sub", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			issuer.setClaim(tt.key, tt.value)
+
+			_, err := issuer.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+			if !errors.Is(err, domainErrors.ErrOIDCTokenInvalid) {
+				t.Errorf("Exchange() error = %v, want ErrOIDCTokenInvalid", err)
+			}
+		})
+	}
+
+	t.Run("異常系_別の鍵で署名されたIDトークンは拒否される", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		provider := issuer.provider()
+		if _, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+
+		// 公開済みのkidのまま、別の秘密鍵で署名する
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("failed to generate rsa key: %v", err)
+		}
+		issuer.mu.Lock()
+		issuer.rsaKey = other
+		issuer.mu.Unlock()
+
+		_, err = provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+		if !errors.Is(err, domainErrors.ErrOIDCTokenInvalid) {
+			t.Errorf("Exchange() error = %v, want ErrOIDCTokenInvalid", err)
+		}
+	})
+}
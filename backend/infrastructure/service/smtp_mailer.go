@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	usecaseService "caltrack/usecase/service"
+)
+
+// SMTPMailer はSMTPサーバー経由でメールを送信するMailerの実装
+type SMTPMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer はSMTPMailerを生成する
+// usernameが空の場合はSMTP認証を行わない（開発用のSMTPサーバー向け）
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send はメールを送信する
+// サーバーがSTARTTLSに対応していれば暗号化して送信する
+func (m *SMTPMailer) Send(ctx context.Context, msg usecaseService.Mail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fromAddr, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	return smtp.SendMail(m.addr, auth, fromAddr.Address, []string{msg.To}, buildMessage(m.from, msg))
+}
+
+// buildMessage はRFC 5322形式のメッセージを組み立てる
+func buildMessage(from string, msg usecaseService.Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
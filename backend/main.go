@@ -1,23 +1,43 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 
 	"caltrack/config"
 	_ "caltrack/docs"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/handler/accesstoken"
+	"caltrack/handler/account"
+	"caltrack/handler/achievement"
+	"caltrack/handler/admin"
 	"caltrack/handler/analyze"
+	"caltrack/handler/audit"
 	"caltrack/handler/auth"
+	"caltrack/handler/export"
 	"caltrack/handler/middleware"
 	"caltrack/handler/nutrition"
+	"caltrack/handler/oidc"
+	"caltrack/handler/passwordreset"
 	"caltrack/handler/record"
+	"caltrack/handler/recordimport"
+	"caltrack/handler/twofactor"
 	"caltrack/handler/user"
+	"caltrack/infrastructure/fieldcrypto"
 	gormPersistence "caltrack/infrastructure/persistence/gorm"
+	memoryPersistence "caltrack/infrastructure/persistence/memory"
 	infraService "caltrack/infrastructure/service"
 	"caltrack/pkg/logger"
 	"caltrack/usecase"
+	usecaseService "caltrack/usecase/service"
 )
 
 // @title CalTrack API
@@ -51,25 +71,75 @@ func main() {
 	}
 	defer geminiConfig.Close()
 
+	// 健康データの暗号化鍵を読み込む
+	fieldEncryptor, err := newFieldEncryptor(config.NewFieldEncryptionConfig())
+	if err != nil {
+		logger.Error("暗号化鍵の読み込み失敗", "error", err.Error())
+		panic(err)
+	}
+
 	// DI - Repository
-	userRepo := gormPersistence.NewGormUserRepository(database.DB)
+	userRepo := gormPersistence.NewGormUserRepository(database.DB, fieldEncryptor)
 	sessionRepo := gormPersistence.NewGormSessionRepository(database.DB)
 	recordRepo := gormPersistence.NewGormRecordRepository(database.DB)
 	recordPfcRepo := gormPersistence.NewGormRecordPfcRepository(database.DB)
 	adviceCacheRepo := gormPersistence.NewGormAdviceCacheRepository(database.DB)
+	targetSnapshotRepo := gormPersistence.NewGormTargetSnapshotRepository(database.DB)
+	userBadgeRepo := gormPersistence.NewGormUserBadgeRepository(database.DB)
+	dailySummaryRepo := gormPersistence.NewGormDailySummaryRepository(database.DB)
+	passwordResetTokenRepo := gormPersistence.NewGormPasswordResetTokenRepository(database.DB)
+	emailVerificationTokenRepo := gormPersistence.NewGormEmailVerificationTokenRepository(database.DB)
+	userTOTPRepo := gormPersistence.NewGormUserTOTPRepository(database.DB)
+	recoveryCodeRepo := gormPersistence.NewGormRecoveryCodeRepository(database.DB)
+	twoFactorChallengeRepo := gormPersistence.NewGormTwoFactorChallengeRepository(database.DB)
+	personalAccessTokenRepo := gormPersistence.NewGormPersonalAccessTokenRepository(database.DB)
+	userIdentityRepo := gormPersistence.NewGormUserIdentityRepository(database.DB)
+	oidcAuthRequestRepo := gormPersistence.NewGormOIDCAuthRequestRepository(database.DB)
+	oidcSignupRepo := gormPersistence.NewGormOIDCSignupRepository(database.DB)
+	profileChangeRepo := gormPersistence.NewGormProfileChangeRepository(database.DB, fieldEncryptor)
+	accountDeletionRepo := gormPersistence.NewGormAccountDeletionRepository(database.DB)
+	accountPurgeRepo := gormPersistence.NewGormAccountPurgeRepository(database.DB)
+	auditEventRepo := gormPersistence.NewGormAuditEventRepository(database.DB)
+	retentionRepo := gormPersistence.NewGormRetentionRepository(database.DB)
+	jobRunRepo := gormPersistence.NewGormJobRunRepository(database.DB)
+	jobLockRepo := gormPersistence.NewGormJobLockRepository(database.DB)
+	aiUsageRepo := gormPersistence.NewGormAIUsageRepository(database.DB)
+	loginAttemptStore := newLoginAttemptStore(database.DB)
 	txManager := gormPersistence.NewGormTransactionManager(database.DB)
 
 	// DI - Service
 	imageAnalyzer := infraService.NewGeminiImageAnalyzer(geminiConfig.Client)
 	pfcAnalyzer := infraService.NewGeminiPfcAnalyzer(geminiConfig.Client)
 	pfcEstimator := infraService.NewGeminiPfcEstimator(geminiConfig.Client)
+	mailer := newMailer(config.NewMailConfig())
+	oidcProviders := newOIDCProviders(config.NewOIDCProviderConfigs())
 
 	// DI - Usecase
-	userUsecase := usecase.NewUserUsecase(userRepo, txManager)
-	authUsecase := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
-	recordUsecase := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, geminiConfig)
-	analyzeUsecase := usecase.NewAnalyzeUsecase(imageAnalyzer, geminiConfig)
-	nutritionUsecase := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, pfcAnalyzer, geminiConfig)
+	auditUsecase := usecase.NewAuditUsecase(auditEventRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, targetSnapshotRepo, sessionRepo, emailVerificationTokenRepo, profileChangeRepo, txManager, auditUsecase, mailer, config.GetAppBaseURL())
+	authUsecase := usecase.NewAuthUsecase(userRepo, sessionRepo, userTOTPRepo, recoveryCodeRepo, twoFactorChallengeRepo, loginAttemptStore, accountDeletionRepo, txManager, auditUsecase)
+	achievementUsecase := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+	recordUsecase := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, auditUsecase, achievementUsecase, aiUsageRepo, pfcEstimator, geminiConfig, config.GetRecordRetentionYears())
+	analyzeUsecase := usecase.NewAnalyzeUsecase(userRepo, userBadgeRepo, aiUsageRepo, imageAnalyzer, geminiConfig)
+	nutritionUsecase := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, dailySummaryRepo, aiUsageRepo, pfcAnalyzer, geminiConfig)
+	passwordResetUsecase := usecase.NewPasswordResetUsecase(userRepo, passwordResetTokenRepo, sessionRepo, txManager, auditUsecase, mailer, config.GetAppBaseURL())
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(userRepo, userTOTPRepo, recoveryCodeRepo, twoFactorChallengeRepo, txManager, auditUsecase)
+	personalAccessTokenUsecase := usecase.NewPersonalAccessTokenUsecase(personalAccessTokenRepo, userRepo, txManager)
+	recordImportUsecase := usecase.NewRecordImportUsecase(recordRepo, userRepo, adviceCacheRepo, dailySummaryRepo, txManager, auditUsecase, achievementUsecase, config.GetRecordRetentionYears())
+	exportUsecase := usecase.NewExportUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, auditUsecase)
+	accountDeletionUsecase := usecase.NewAccountDeletionUsecase(userRepo, sessionRepo, personalAccessTokenRepo, accountDeletionRepo, accountPurgeRepo, txManager, auditUsecase, config.GetAccountDeletionGracePeriod())
+	oidcUsecase := usecase.NewOIDCUsecase(oidcProviders, userRepo, userIdentityRepo, oidcAuthRequestRepo, oidcSignupRepo, sessionRepo, targetSnapshotRepo, userTOTPRepo, twoFactorChallengeRepo, accountDeletionRepo, txManager, auditUsecase)
+	adminUsecase := usecase.NewAdminUsecase(userRepo, sessionRepo, recordRepo, aiUsageRepo, txManager, auditUsecase)
+
+	// 定期実行ジョブ（保存期間を過ぎたデータ・期限切れセッションの削除）
+	if config.IsJobRunnerEnabled() {
+		jobRunner := usecase.NewJobRunner(jobRunRepo, jobLockRepo, newJobRunnerOwner())
+		jobRunner.Register(usecase.NewRetentionJob(retentionRepo, adviceCacheRepo, txManager, config.GetRecordRetentionYears(), config.GetAdviceCacheRetentionDays()), config.GetRetentionJobInterval())
+		jobRunner.Register(usecase.NewSessionCleanupJob(sessionRepo), config.GetSessionCleanupJobInterval())
+		go jobRunner.Start(context.Background())
+	} else {
+		logger.Info("JobRunner: disabled")
+	}
 
 	// DI - Handler
 	userHandler := user.NewUserHandler(userUsecase)
@@ -77,6 +147,16 @@ func main() {
 	recordHandler := record.NewRecordHandler(recordUsecase)
 	analyzeHandler := analyze.NewAnalyzeHandler(analyzeUsecase)
 	nutritionHandler := nutrition.NewNutritionHandler(nutritionUsecase)
+	achievementHandler := achievement.NewAchievementHandler(achievementUsecase)
+	passwordResetHandler := passwordreset.NewPasswordResetHandler(passwordResetUsecase)
+	twoFactorHandler := twofactor.NewTwoFactorHandler(twoFactorUsecase)
+	accessTokenHandler := accesstoken.NewAccessTokenHandler(personalAccessTokenUsecase)
+	accountHandler := account.NewAccountHandler(accountDeletionUsecase)
+	exportHandler := export.NewExportHandler(exportUsecase)
+	recordImportHandler := recordimport.NewRecordImportHandler(recordImportUsecase)
+	auditHandler := audit.NewAuditHandler(auditUsecase)
+	oidcHandler := oidc.NewOIDCHandler(oidcUsecase, config.GetAppBaseURL())
+	adminHandler := admin.NewAdminHandler(adminUsecase)
 
 	// Setup router
 	r := gin.Default()
@@ -84,6 +164,9 @@ func main() {
 	// ロガーミドルウェア追加
 	r.Use(logger.Middleware())
 
+	// 監査ログ用に接続元のIPアドレスとUser-Agentをコンテキストに載せる
+	r.Use(middleware.RequestClientMiddleware())
+
 	// CORS configuration
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     config.GetCORSAllowOrigins(),
@@ -103,27 +186,96 @@ func main() {
 	api := r.Group("/api/v1")
 	{
 		api.POST("/users", userHandler.Register)
+		api.POST("/users/email/verify", userHandler.VerifyEmail)
 	}
 
 	// Auth routes
 	authGroup := api.Group("/auth")
 	{
 		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/login/totp", authHandler.LoginTOTP)
+		authGroup.POST("/token", authHandler.LoginToken)
+		authGroup.POST("/token/totp", authHandler.LoginTOTPToken)
 		authGroup.POST("/logout", authHandler.Logout)
+		authGroup.POST("/password-reset/request", passwordResetHandler.Request)
+		authGroup.POST("/password-reset/confirm", passwordResetHandler.Confirm)
+		authGroup.GET("/oidc/:provider/start", oidcHandler.Start)
+		authGroup.GET("/oidc/:provider/callback", oidcHandler.Callback)
+		authGroup.POST("/oidc/signup", oidcHandler.CompleteSignup)
+	}
+
+	// AI機能はメールアドレス確認済みのユーザーのみ利用可能
+	requireVerifiedEmail := middleware.RequireVerifiedEmail(userUsecase)
+
+	// requireAuth は認証ミドルウェアを生成する
+	// scopes を指定したルートのみパーソナルアクセストークンでもアクセスできる
+	requireAuth := func(scopes ...string) gin.HandlerFunc {
+		return middleware.AuthMiddleware(authUsecase, personalAccessTokenUsecase, scopes...)
 	}
 
-	// 認証が必要なルート
+	// 認証が必要なルート（セッションのみ）
 	authenticated := api.Group("")
-	authenticated.Use(middleware.AuthMiddleware(authUsecase))
+	authenticated.Use(requireAuth())
 	{
-		authenticated.GET("/users/profile", userHandler.GetProfile)
 		authenticated.PATCH("/users/profile", userHandler.UpdateProfile)
-		authenticated.POST("/records", recordHandler.Create)
-		authenticated.GET("/records/today", recordHandler.GetToday)
-		authenticated.GET("/statistics", recordHandler.GetStatistics)
-		authenticated.POST("/analyze-image", analyzeHandler.AnalyzeImage)
-		authenticated.GET("/nutrition/advice", nutritionHandler.GetAdvice)
-		authenticated.GET("/nutrition/today-pfc", nutritionHandler.GetTodayPfc)
+		authenticated.PATCH("/users/budget-mode", userHandler.UpdateBudgetMode)
+		authenticated.PATCH("/users/privacy", userHandler.UpdatePrivacySettings)
+		authenticated.DELETE("/users/me", accountHandler.Delete)
+		authenticated.GET("/users/me/export", exportHandler.Export)
+		authenticated.GET("/users/me/audit", auditHandler.List)
+		authenticated.POST("/users/password", userHandler.ChangePassword)
+		authenticated.POST("/users/password/set", userHandler.SetPassword)
+		authenticated.PATCH("/users/email", userHandler.ChangeEmail)
+		authenticated.POST("/users/email/verification", userHandler.ResendEmailVerification)
+		authenticated.GET("/auth/sessions", authHandler.ListSessions)
+		authenticated.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
+		authenticated.POST("/auth/sessions/revoke-others", authHandler.RevokeOtherSessions)
+		authenticated.GET("/auth/2fa/totp", twoFactorHandler.GetStatus)
+		authenticated.POST("/auth/2fa/totp/enroll", twoFactorHandler.Enroll)
+		authenticated.POST("/auth/2fa/totp/confirm", twoFactorHandler.Confirm)
+		authenticated.POST("/auth/2fa/totp/disable", twoFactorHandler.Disable)
+		authenticated.GET("/auth/tokens", accessTokenHandler.List)
+		authenticated.POST("/auth/tokens", accessTokenHandler.Create)
+		authenticated.DELETE("/auth/tokens/:id", accessTokenHandler.Revoke)
+	}
+
+	// プロフィールの参照（profile:read）
+	profileRead := api.Group("")
+	profileRead.Use(requireAuth(vo.TokenScopeProfileRead))
+	{
+		profileRead.GET("/users/profile", userHandler.GetProfile)
+	}
+
+	// 記録の参照（records:read）
+	recordsRead := api.Group("")
+	recordsRead.Use(requireAuth(vo.TokenScopeRecordsRead))
+	{
+		recordsRead.GET("/records/today", recordHandler.GetToday)
+		recordsRead.GET("/statistics", recordHandler.GetStatistics)
+		recordsRead.GET("/calendar", recordHandler.GetCalendar)
+		recordsRead.GET("/nutrition/advice", requireVerifiedEmail, nutritionHandler.GetAdvice)
+		recordsRead.GET("/nutrition/today-pfc", nutritionHandler.GetTodayPfc)
+		recordsRead.GET("/achievements", achievementHandler.GetAchievements)
+	}
+
+	// 記録の作成（records:write）
+	recordsWrite := api.Group("")
+	recordsWrite.Use(requireAuth(vo.TokenScopeRecordsWrite))
+	{
+		recordsWrite.POST("/records", recordHandler.Create)
+		recordsWrite.POST("/records/import", recordImportHandler.Import)
+		recordsWrite.POST("/analyze-image", requireVerifiedEmail, analyzeHandler.AnalyzeImage)
+	}
+
+	// 管理者用ルート（セッションのみ・管理者権限が必要）
+	adminGroup := api.Group("/admin")
+	adminGroup.Use(requireAuth(), middleware.RequireAdmin(adminUsecase))
+	{
+		adminGroup.GET("/users", adminHandler.SearchUsers)
+		adminGroup.GET("/users/:id/usage", adminHandler.GetUserUsage)
+		adminGroup.POST("/users/:id/disable", adminHandler.DisableUser)
+		adminGroup.POST("/users/:id/enable", adminHandler.EnableUser)
+		adminGroup.DELETE("/users/:id/sessions", adminHandler.ForceLogout)
 	}
 
 	// Start server
@@ -133,3 +285,59 @@ func main() {
 		panic(err)
 	}
 }
+
+// newMailer はメール設定に応じたMailerを生成する
+// SMTP_HOST が未設定の場合は送信せず、MAIL_LOG_FILE またはログに書き出す
+func newMailer(mailConfig config.MailConfig) usecaseService.Mailer {
+	if mailConfig.SMTPEnabled() {
+		logger.Info("Mailer: SMTP", "host", mailConfig.SMTPHost, "port", mailConfig.SMTPPort)
+		return infraService.NewSMTPMailer(mailConfig.SMTPHost, mailConfig.SMTPPort, mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.From)
+	}
+	logger.Info("Mailer: log", "file", mailConfig.LogFile)
+	return infraService.NewLogMailer(mailConfig.LogFile)
+}
+
+// newOIDCProviders は設定が有効なOpenID Connectプロバイダーを生成する
+func newOIDCProviders(configs []config.OIDCProviderConfig) []usecaseService.OIDCProvider {
+	providers := make([]usecaseService.OIDCProvider, 0, len(configs))
+	for _, c := range configs {
+		logger.Info("OIDCProvider", "name", c.Name, "issuer", c.Issuer)
+		providers = append(providers, infraService.NewOIDCProvider(c.Name, c.Issuer, c.ClientID, c.ClientSecret, c.RedirectURL, c.Scopes, nil))
+	}
+	return providers
+}
+
+// newLoginAttemptStore はログイン失敗回数のストアを生成する
+// LOGIN_ATTEMPT_STORE=memory の場合はプロセス内に保持する（単一ノード構成向け）
+func newLoginAttemptStore(db *gorm.DB) repository.LoginAttemptStore {
+	if config.GetLoginAttemptStoreType() == config.LoginAttemptStoreMemory {
+		logger.Info("LoginAttemptStore: memory")
+		return memoryPersistence.NewLoginAttemptStore()
+	}
+	logger.Info("LoginAttemptStore: database")
+	return gormPersistence.NewGormLoginAttemptStore(db)
+}
+
+// newJobRunnerOwner は定期実行ジョブのロックと実行履歴に記録するプロセスの識別子（ホスト名:PID）を生成する
+func newJobRunnerOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// newFieldEncryptor は設定に応じて健康データの暗号化に使うEnvelopeEncryptorを生成する
+// 鍵が設定されていない場合はnil（平文で保存する）
+func newFieldEncryptor(c config.FieldEncryptionConfig) (*fieldcrypto.EnvelopeEncryptor, error) {
+	encryptor, err := fieldcrypto.NewEncryptorFromSettings(c.KeyFile, c.Keys, c.CurrentKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if encryptor == nil {
+		logger.Warn("FieldEncryption: disabled (health data is stored in plaintext)")
+		return nil, nil
+	}
+	logger.Info("FieldEncryption: enabled")
+	return encryptor, nil
+}
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE target_snapshots (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    effective_date DATE NOT NULL,
+    target_calories INT NOT NULL,
+    protein DOUBLE NOT NULL,
+    fat DOUBLE NOT NULL,
+    carbs DOUBLE NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uk_target_snapshots_user_date (user_id, effective_date),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE target_snapshots;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE user_badges (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    badge_type VARCHAR(50) NOT NULL,
+    unlocked_at DATETIME NOT NULL,
+    UNIQUE KEY uk_user_badges_user_type (user_id, badge_type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE user_badges;
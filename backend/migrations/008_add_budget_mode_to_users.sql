@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE users ADD COLUMN budget_mode VARCHAR(10) NOT NULL DEFAULT 'daily' AFTER activity_level;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN budget_mode;
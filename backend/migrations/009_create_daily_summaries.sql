@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE daily_summaries (
+    user_id VARCHAR(36) NOT NULL,
+    summary_date DATE NOT NULL,
+    calories INT NOT NULL,
+    protein DOUBLE NOT NULL,
+    fat DOUBLE NOT NULL,
+    carbs DOUBLE NOT NULL,
+    record_count INT NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, summary_date),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE daily_summaries;
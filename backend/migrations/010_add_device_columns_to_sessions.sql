@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE sessions
+    ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '' AFTER expires_at,
+    ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '' AFTER user_agent,
+    ADD COLUMN last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER ip_address;
+UPDATE sessions SET last_seen_at = created_at;
+
+-- +migrate Down
+ALTER TABLE sessions
+    DROP COLUMN last_seen_at,
+    DROP COLUMN ip_address,
+    DROP COLUMN user_agent;
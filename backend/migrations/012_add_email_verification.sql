@@ -0,0 +1,22 @@
+-- +migrate Up
+ALTER TABLE users ADD COLUMN email_verified_at DATETIME NULL AFTER budget_mode;
+
+-- 既存ユーザーは確認済みとして扱う（機能追加前に登録したユーザーのAI機能を止めないため）
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE email_verification_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    email VARCHAR(254) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uk_email_verification_tokens_token_hash (token_hash),
+    INDEX idx_email_verification_tokens_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE email_verification_tokens;
+ALTER TABLE users DROP COLUMN email_verified_at;
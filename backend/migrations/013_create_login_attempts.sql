@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE login_attempts (
+    attempt_key VARCHAR(300) PRIMARY KEY,
+    failures INT NOT NULL,
+    last_failed_at DATETIME(3) NOT NULL,
+    INDEX idx_login_attempts_last_failed_at (last_failed_at)
+);
+
+-- +migrate Down
+DROP TABLE login_attempts;
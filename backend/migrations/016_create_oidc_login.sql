@@ -0,0 +1,43 @@
+-- +migrate Up
+CREATE TABLE user_identities (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uk_user_identities_provider_subject (provider, subject),
+    INDEX idx_user_identities_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE oidc_auth_requests (
+    id VARCHAR(36) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    state_hash CHAR(64) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uk_oidc_auth_requests_state_hash (state_hash),
+    INDEX idx_oidc_auth_requests_expires_at (expires_at)
+);
+
+CREATE TABLE oidc_signups (
+    id VARCHAR(36) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uk_oidc_signups_token_hash (token_hash),
+    INDEX idx_oidc_signups_expires_at (expires_at)
+);
+
+-- +migrate Down
+DROP TABLE oidc_signups;
+DROP TABLE oidc_auth_requests;
+DROP TABLE user_identities;
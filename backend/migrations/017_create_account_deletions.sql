@@ -0,0 +1,16 @@
+-- +migrate Up
+-- ユーザー削除後も削除の記録として残すため、usersへの外部キーは張らない
+CREATE TABLE account_deletions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    requested_at DATETIME NOT NULL,
+    scheduled_at DATETIME NOT NULL,
+    cancelled_at DATETIME NULL,
+    purged_at DATETIME NULL,
+    purged_rows JSON NULL,
+    INDEX idx_account_deletions_user_id (user_id),
+    INDEX idx_account_deletions_scheduled_at (scheduled_at)
+);
+
+-- +migrate Down
+DROP TABLE account_deletions;
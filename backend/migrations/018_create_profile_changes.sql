@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE profile_changes (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    field VARCHAR(50) NOT NULL,
+    old_value VARCHAR(255) NOT NULL,
+    new_value VARCHAR(255) NOT NULL,
+    changed_at DATETIME NOT NULL,
+    INDEX idx_profile_changes_user_id_changed_at (user_id, changed_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE profile_changes;
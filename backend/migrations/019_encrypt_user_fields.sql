@@ -0,0 +1,26 @@
+-- +migrate Up
+-- 健康データは暗号化した列に保存し、平文の列はNULLにする
+-- 既存の行は go run ./cmd/encrypt-user-fields で暗号化する
+ALTER TABLE users
+    MODIFY COLUMN weight DOUBLE NULL,
+    MODIFY COLUMN height DOUBLE NULL,
+    MODIFY COLUMN birth_date DATE NULL,
+    MODIFY COLUMN gender VARCHAR(10) NULL,
+    ADD COLUMN encrypted_data_key VARCHAR(255) NULL AFTER gender,
+    ADD COLUMN weight_encrypted VARCHAR(255) NULL AFTER encrypted_data_key,
+    ADD COLUMN height_encrypted VARCHAR(255) NULL AFTER weight_encrypted,
+    ADD COLUMN birth_date_encrypted VARCHAR(255) NULL AFTER height_encrypted,
+    ADD COLUMN gender_encrypted VARCHAR(255) NULL AFTER birth_date_encrypted;
+
+-- +migrate Down
+-- 暗号化済みの行がある場合は、先に復号して平文の列に戻してから実行すること
+ALTER TABLE users
+    DROP COLUMN gender_encrypted,
+    DROP COLUMN birth_date_encrypted,
+    DROP COLUMN height_encrypted,
+    DROP COLUMN weight_encrypted,
+    DROP COLUMN encrypted_data_key,
+    MODIFY COLUMN weight DOUBLE NOT NULL,
+    MODIFY COLUMN height DOUBLE NOT NULL,
+    MODIFY COLUMN birth_date DATE NOT NULL,
+    MODIFY COLUMN gender VARCHAR(10) NOT NULL;
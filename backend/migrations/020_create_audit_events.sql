@@ -0,0 +1,19 @@
+-- +migrate Up
+-- 監査ログは追記のみ。アカウント削除後も削除の記録を残すため、usersへの外部キーは張らない
+CREATE TABLE audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    user_id VARCHAR(36) NULL,
+    actor_id VARCHAR(36) NULL,
+    target_type VARCHAR(20) NOT NULL DEFAULT '',
+    target_id VARCHAR(64) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    diff JSON NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    INDEX idx_audit_events_user_id_occurred_at (user_id, occurred_at),
+    INDEX idx_audit_events_actor_id (actor_id)
+);
+
+-- +migrate Down
+DROP TABLE audit_events;
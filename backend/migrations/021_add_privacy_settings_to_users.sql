@@ -0,0 +1,16 @@
+-- +migrate Up
+-- AI機能はオプトアウト方式のため既存ユーザーも有効、分析への利用は同意したユーザーのみ有効にする
+ALTER TABLE users
+    ADD COLUMN ai_processing_enabled BOOLEAN NOT NULL DEFAULT TRUE AFTER budget_mode,
+    ADD COLUMN analytics_enabled BOOLEAN NOT NULL DEFAULT FALSE AFTER ai_processing_enabled,
+    ADD COLUMN data_retention_years INT NOT NULL DEFAULT 0 AFTER analytics_enabled,
+    ADD COLUMN privacy_consent_version VARCHAR(20) NULL AFTER data_retention_years,
+    ADD COLUMN privacy_consented_at DATETIME NULL AFTER privacy_consent_version;
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN privacy_consented_at,
+    DROP COLUMN privacy_consent_version,
+    DROP COLUMN data_retention_years,
+    DROP COLUMN analytics_enabled,
+    DROP COLUMN ai_processing_enabled;
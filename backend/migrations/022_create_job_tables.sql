@@ -0,0 +1,29 @@
+-- +migrate Up
+-- 定期実行ジョブの実行履歴
+CREATE TABLE job_runs (
+    id VARCHAR(36) PRIMARY KEY,
+    job_name VARCHAR(50) NOT NULL,
+    owner VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    started_at DATETIME(3) NOT NULL,
+    finished_at DATETIME(3) NULL,
+    result JSON NULL,
+    error_message TEXT NULL,
+    INDEX idx_job_runs_job_name_started_at (job_name, started_at)
+);
+
+-- 複数のプロセスで同じジョブを同時に実行しないためのロック
+-- プロセスが異常終了した場合でも locked_until を過ぎれば他のプロセスが取得できる
+CREATE TABLE job_locks (
+    job_name VARCHAR(50) PRIMARY KEY,
+    owner VARCHAR(100) NOT NULL,
+    locked_until DATETIME(3) NOT NULL
+);
+
+-- 保存期間を過ぎたアドバイスキャッシュを作成日時で削除するためのインデックス
+CREATE INDEX idx_advice_caches_created_at ON advice_caches (created_at);
+
+-- +migrate Down
+DROP INDEX idx_advice_caches_created_at ON advice_caches;
+DROP TABLE job_locks;
+DROP TABLE job_runs;
@@ -0,0 +1,10 @@
+-- +migrate Up
+-- 管理者は go run ./cmd/set-user-role で付与する
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' AFTER privacy_consented_at,
+    ADD COLUMN disabled_at DATETIME NULL AFTER role;
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN disabled_at,
+    DROP COLUMN role;
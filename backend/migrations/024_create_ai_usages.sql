@@ -0,0 +1,17 @@
+-- +migrate Up
+-- AI機能の呼び出しごとのトークン使用量（管理者が利用状況を確認するため）
+CREATE TABLE ai_usages (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    feature VARCHAR(30) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    prompt_tokens INT NOT NULL,
+    output_tokens INT NOT NULL,
+    total_tokens INT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    INDEX idx_ai_usages_user_id_occurred_at (user_id, occurred_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE ai_usages;
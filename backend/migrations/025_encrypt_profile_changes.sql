@@ -0,0 +1,11 @@
+-- +migrate Up
+-- 変更前後の値（性別・生年月日）はユーザーの健康データと同じく暗号化して保存する
+-- 暗号化が有効な場合、old_value・new_valueには暗号文を保存する
+-- 既存の行は go run ./cmd/encrypt-user-fields で暗号化する
+ALTER TABLE profile_changes
+    ADD COLUMN encrypted_data_key VARCHAR(255) NULL AFTER new_value;
+
+-- +migrate Down
+-- 暗号化済みの行がある場合は、先に復号して平文に戻してから実行すること
+ALTER TABLE profile_changes
+    DROP COLUMN encrypted_data_key;
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/account_deletion_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/account_deletion_repository.go -destination=mock/mock_account_deletion_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountDeletionRepository is a mock of AccountDeletionRepository interface.
+type MockAccountDeletionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDeletionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountDeletionRepositoryMockRecorder is the mock recorder for MockAccountDeletionRepository.
+type MockAccountDeletionRepositoryMockRecorder struct {
+	mock *MockAccountDeletionRepository
+}
+
+// NewMockAccountDeletionRepository creates a new mock instance.
+func NewMockAccountDeletionRepository(ctrl *gomock.Controller) *MockAccountDeletionRepository {
+	mock := &MockAccountDeletionRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountDeletionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDeletionRepository) EXPECT() *MockAccountDeletionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindDue mocks base method.
+func (m *MockAccountDeletionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockAccountDeletionRepositoryMockRecorder) FindDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockAccountDeletionRepository)(nil).FindDue), ctx, now, limit)
+}
+
+// FindPendingByUserID mocks base method.
+func (m *MockAccountDeletionRepository) FindPendingByUserID(ctx context.Context, userID vo.UserID) (*entity.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByUserID indicates an expected call of FindPendingByUserID.
+func (mr *MockAccountDeletionRepositoryMockRecorder) FindPendingByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByUserID", reflect.TypeOf((*MockAccountDeletionRepository)(nil).FindPendingByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockAccountDeletionRepository) Save(ctx context.Context, deletion *entity.AccountDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAccountDeletionRepositoryMockRecorder) Save(ctx, deletion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAccountDeletionRepository)(nil).Save), ctx, deletion)
+}
+
+// Update mocks base method.
+func (m *MockAccountDeletionRepository) Update(ctx context.Context, deletion *entity.AccountDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAccountDeletionRepositoryMockRecorder) Update(ctx, deletion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccountDeletionRepository)(nil).Update), ctx, deletion)
+}
+
+// MockAccountPurgeRepository is a mock of AccountPurgeRepository interface.
+type MockAccountPurgeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountPurgeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountPurgeRepositoryMockRecorder is the mock recorder for MockAccountPurgeRepository.
+type MockAccountPurgeRepositoryMockRecorder struct {
+	mock *MockAccountPurgeRepository
+}
+
+// NewMockAccountPurgeRepository creates a new mock instance.
+func NewMockAccountPurgeRepository(ctrl *gomock.Controller) *MockAccountPurgeRepository {
+	mock := &MockAccountPurgeRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountPurgeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountPurgeRepository) EXPECT() *MockAccountPurgeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// PurgeByUserID mocks base method.
+func (m *MockAccountPurgeRepository) PurgeByUserID(ctx context.Context, userID vo.UserID) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeByUserID", ctx, userID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeByUserID indicates an expected call of PurgeByUserID.
+func (mr *MockAccountPurgeRepositoryMockRecorder) PurgeByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeByUserID", reflect.TypeOf((*MockAccountPurgeRepository)(nil).PurgeByUserID), ctx, userID)
+}
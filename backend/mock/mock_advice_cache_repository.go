@@ -57,6 +57,21 @@ func (mr *MockAdviceCacheRepositoryMockRecorder) DeleteByUserIDAndDate(ctx, user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndDate", reflect.TypeOf((*MockAdviceCacheRepository)(nil).DeleteByUserIDAndDate), ctx, userID, date)
 }
 
+// DeleteCreatedBefore mocks base method.
+func (m *MockAdviceCacheRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreatedBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCreatedBefore indicates an expected call of DeleteCreatedBefore.
+func (mr *MockAdviceCacheRepositoryMockRecorder) DeleteCreatedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreatedBefore", reflect.TypeOf((*MockAdviceCacheRepository)(nil).DeleteCreatedBefore), ctx, before)
+}
+
 // FindByUserIDAndDate mocks base method.
 func (m *MockAdviceCacheRepository) FindByUserIDAndDate(ctx context.Context, userID vo.UserID, date time.Time) (*entity.AdviceCache, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +87,35 @@ func (mr *MockAdviceCacheRepositoryMockRecorder) FindByUserIDAndDate(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndDate", reflect.TypeOf((*MockAdviceCacheRepository)(nil).FindByUserIDAndDate), ctx, userID, date)
 }
 
+// FindCacheDatesByUserIDAndDateRange mocks base method.
+func (m *MockAdviceCacheRepository) FindCacheDatesByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCacheDatesByUserIDAndDateRange", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCacheDatesByUserIDAndDateRange indicates an expected call of FindCacheDatesByUserIDAndDateRange.
+func (mr *MockAdviceCacheRepositoryMockRecorder) FindCacheDatesByUserIDAndDateRange(ctx, userID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCacheDatesByUserIDAndDateRange", reflect.TypeOf((*MockAdviceCacheRepository)(nil).FindCacheDatesByUserIDAndDateRange), ctx, userID, startDate, endDate)
+}
+
+// ForEachBatchByUserID mocks base method.
+func (m *MockAdviceCacheRepository) ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func([]*entity.AdviceCache) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachBatchByUserID", ctx, userID, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachBatchByUserID indicates an expected call of ForEachBatchByUserID.
+func (mr *MockAdviceCacheRepositoryMockRecorder) ForEachBatchByUserID(ctx, userID, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachBatchByUserID", reflect.TypeOf((*MockAdviceCacheRepository)(nil).ForEachBatchByUserID), ctx, userID, batchSize, fn)
+}
+
 // Save mocks base method.
 func (m *MockAdviceCacheRepository) Save(ctx context.Context, cache *entity.AdviceCache) error {
 	m.ctrl.T.Helper()
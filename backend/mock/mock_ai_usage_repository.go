@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/ai_usage_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/ai_usage_repository.go -destination=mock/mock_ai_usage_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	repository "caltrack/domain/repository"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAIUsageRepository is a mock of AIUsageRepository interface.
+type MockAIUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAIUsageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAIUsageRepositoryMockRecorder is the mock recorder for MockAIUsageRepository.
+type MockAIUsageRepositoryMockRecorder struct {
+	mock *MockAIUsageRepository
+}
+
+// NewMockAIUsageRepository creates a new mock instance.
+func NewMockAIUsageRepository(ctrl *gomock.Controller) *MockAIUsageRepository {
+	mock := &MockAIUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockAIUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAIUsageRepository) EXPECT() *MockAIUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockAIUsageRepository) Save(ctx context.Context, usage *entity.AIUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, usage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAIUsageRepositoryMockRecorder) Save(ctx, usage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAIUsageRepository)(nil).Save), ctx, usage)
+}
+
+// SummarizeByUserID mocks base method.
+func (m *MockAIUsageRepository) SummarizeByUserID(ctx context.Context, userID vo.UserID, since time.Time) ([]repository.AIUsageSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeByUserID", ctx, userID, since)
+	ret0, _ := ret[0].([]repository.AIUsageSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeByUserID indicates an expected call of SummarizeByUserID.
+func (mr *MockAIUsageRepositoryMockRecorder) SummarizeByUserID(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeByUserID", reflect.TypeOf((*MockAIUsageRepository)(nil).SummarizeByUserID), ctx, userID, since)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/audit_event_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/audit_event_repository.go -destination=mock/mock_audit_event_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditEventRepository is a mock of AuditEventRepository interface.
+type MockAuditEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditEventRepositoryMockRecorder is the mock recorder for MockAuditEventRepository.
+type MockAuditEventRepositoryMockRecorder struct {
+	mock *MockAuditEventRepository
+}
+
+// NewMockAuditEventRepository creates a new mock instance.
+func NewMockAuditEventRepository(ctrl *gomock.Controller) *MockAuditEventRepository {
+	mock := &MockAuditEventRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventRepository) EXPECT() *MockAuditEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockAuditEventRepository) FindByUserID(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID, before, limit)
+	ret0, _ := ret[0].([]*entity.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockAuditEventRepositoryMockRecorder) FindByUserID(ctx, userID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockAuditEventRepository)(nil).FindByUserID), ctx, userID, before, limit)
+}
+
+// Save mocks base method.
+func (m *MockAuditEventRepository) Save(ctx context.Context, event *entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAuditEventRepositoryMockRecorder) Save(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAuditEventRepository)(nil).Save), ctx, event)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/service/audit_recorder.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecase/service/audit_recorder.go -destination=mock/mock_audit_recorder.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditRecorder is a mock of AuditRecorder interface.
+type MockAuditRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditRecorderMockRecorder is the mock recorder for MockAuditRecorder.
+type MockAuditRecorderMockRecorder struct {
+	mock *MockAuditRecorder
+}
+
+// NewMockAuditRecorder creates a new mock instance.
+func NewMockAuditRecorder(ctrl *gomock.Controller) *MockAuditRecorder {
+	mock := &MockAuditRecorder{ctrl: ctrl}
+	mock.recorder = &MockAuditRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRecorder) EXPECT() *MockAuditRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditRecorder) Record(ctx context.Context, event *entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditRecorderMockRecorder) Record(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditRecorder)(nil).Record), ctx, event)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/service/badge_unlocker.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecase/service/badge_unlocker.go -destination=mock/mock_badge_unlocker.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBadgeUnlocker is a mock of BadgeUnlocker interface.
+type MockBadgeUnlocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBadgeUnlockerMockRecorder
+	isgomock struct{}
+}
+
+// MockBadgeUnlockerMockRecorder is the mock recorder for MockBadgeUnlocker.
+type MockBadgeUnlockerMockRecorder struct {
+	mock *MockBadgeUnlocker
+}
+
+// NewMockBadgeUnlocker creates a new mock instance.
+func NewMockBadgeUnlocker(ctrl *gomock.Controller) *MockBadgeUnlocker {
+	mock := &MockBadgeUnlocker{ctrl: ctrl}
+	mock.recorder = &MockBadgeUnlockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBadgeUnlocker) EXPECT() *MockBadgeUnlockerMockRecorder {
+	return m.recorder
+}
+
+// UnlockMilestoneBadges mocks base method.
+func (m *MockBadgeUnlocker) UnlockMilestoneBadges(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockMilestoneBadges", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockMilestoneBadges indicates an expected call of UnlockMilestoneBadges.
+func (mr *MockBadgeUnlockerMockRecorder) UnlockMilestoneBadges(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockMilestoneBadges", reflect.TypeOf((*MockBadgeUnlocker)(nil).UnlockMilestoneBadges), ctx, userID)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/daily_summary_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/daily_summary_repository.go -destination=mock/mock_daily_summary_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDailySummaryRepository is a mock of DailySummaryRepository interface.
+type MockDailySummaryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailySummaryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDailySummaryRepositoryMockRecorder is the mock recorder for MockDailySummaryRepository.
+type MockDailySummaryRepositoryMockRecorder struct {
+	mock *MockDailySummaryRepository
+}
+
+// NewMockDailySummaryRepository creates a new mock instance.
+func NewMockDailySummaryRepository(ctrl *gomock.Controller) *MockDailySummaryRepository {
+	mock := &MockDailySummaryRepository{ctrl: ctrl}
+	mock.recorder = &MockDailySummaryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailySummaryRepository) EXPECT() *MockDailySummaryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockDailySummaryRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.DailySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.DailySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockDailySummaryRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockDailySummaryRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// FindByUserIDAndDateRange mocks base method.
+func (m *MockDailySummaryRepository) FindByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.DailySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDAndDateRange", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*entity.DailySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDAndDateRange indicates an expected call of FindByUserIDAndDateRange.
+func (mr *MockDailySummaryRepositoryMockRecorder) FindByUserIDAndDateRange(ctx, userID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndDateRange", reflect.TypeOf((*MockDailySummaryRepository)(nil).FindByUserIDAndDateRange), ctx, userID, startDate, endDate)
+}
+
+// RebuildAll mocks base method.
+func (m *MockDailySummaryRepository) RebuildAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildAll indicates an expected call of RebuildAll.
+func (mr *MockDailySummaryRepositoryMockRecorder) RebuildAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildAll", reflect.TypeOf((*MockDailySummaryRepository)(nil).RebuildAll), ctx)
+}
+
+// RebuildByUserID mocks base method.
+func (m *MockDailySummaryRepository) RebuildByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildByUserID indicates an expected call of RebuildByUserID.
+func (mr *MockDailySummaryRepositoryMockRecorder) RebuildByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildByUserID", reflect.TypeOf((*MockDailySummaryRepository)(nil).RebuildByUserID), ctx, userID)
+}
+
+// Refresh mocks base method.
+func (m *MockDailySummaryRepository) Refresh(ctx context.Context, userID vo.UserID, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, userID, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockDailySummaryRepositoryMockRecorder) Refresh(ctx, userID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockDailySummaryRepository)(nil).Refresh), ctx, userID, date)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/email_verification_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/email_verification_token_repository.go -destination=mock/mock_email_verification_token_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailVerificationTokenRepository is a mock of EmailVerificationTokenRepository interface.
+type MockEmailVerificationTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationTokenRepositoryMockRecorder is the mock recorder for MockEmailVerificationTokenRepository.
+type MockEmailVerificationTokenRepositoryMockRecorder struct {
+	mock *MockEmailVerificationTokenRepository
+}
+
+// NewMockEmailVerificationTokenRepository creates a new mock instance.
+func NewMockEmailVerificationTokenRepository(ctrl *gomock.Controller) *MockEmailVerificationTokenRepository {
+	mock := &MockEmailVerificationTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationTokenRepository) EXPECT() *MockEmailVerificationTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockEmailVerificationTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockEmailVerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) FindByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).FindByTokenHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockEmailVerificationTokenRepository) MarkUsed(ctx context.Context, token *entity.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) MarkUsed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).MarkUsed), ctx, token)
+}
+
+// Save mocks base method.
+func (m *MockEmailVerificationTokenRepository) Save(ctx context.Context, token *entity.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) Save(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).Save), ctx, token)
+}
@@ -42,10 +42,10 @@ func (m *MockImageAnalyzer) EXPECT() *MockImageAnalyzerMockRecorder {
 }
 
 // Analyze mocks base method.
-func (m *MockImageAnalyzer) Analyze(ctx context.Context, config service.ImageAnalyzerConfig, imageData, mimeType string) ([]service.AnalyzedItem, error) {
+func (m *MockImageAnalyzer) Analyze(ctx context.Context, config service.ImageAnalyzerConfig, imageData, mimeType string) (*service.ImageAnalysisOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Analyze", ctx, config, imageData, mimeType)
-	ret0, _ := ret[0].([]service.AnalyzedItem)
+	ret0, _ := ret[0].(*service.ImageAnalysisOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
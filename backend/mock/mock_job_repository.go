@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/job_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/job_repository.go -destination=mock/mock_job_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRunRepository is a mock of JobRunRepository interface.
+type MockJobRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobRunRepositoryMockRecorder is the mock recorder for MockJobRunRepository.
+type MockJobRunRepositoryMockRecorder struct {
+	mock *MockJobRunRepository
+}
+
+// NewMockJobRunRepository creates a new mock instance.
+func NewMockJobRunRepository(ctrl *gomock.Controller) *MockJobRunRepository {
+	mock := &MockJobRunRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRunRepository) EXPECT() *MockJobRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatestByJobName mocks base method.
+func (m *MockJobRunRepository) FindLatestByJobName(ctx context.Context, jobName string) (*entity.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByJobName", ctx, jobName)
+	ret0, _ := ret[0].(*entity.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByJobName indicates an expected call of FindLatestByJobName.
+func (mr *MockJobRunRepositoryMockRecorder) FindLatestByJobName(ctx, jobName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByJobName", reflect.TypeOf((*MockJobRunRepository)(nil).FindLatestByJobName), ctx, jobName)
+}
+
+// Save mocks base method.
+func (m *MockJobRunRepository) Save(ctx context.Context, run *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockJobRunRepositoryMockRecorder) Save(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockJobRunRepository)(nil).Save), ctx, run)
+}
+
+// Update mocks base method.
+func (m *MockJobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockJobRunRepositoryMockRecorder) Update(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockJobRunRepository)(nil).Update), ctx, run)
+}
+
+// MockJobLockRepository is a mock of JobLockRepository interface.
+type MockJobLockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobLockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobLockRepositoryMockRecorder is the mock recorder for MockJobLockRepository.
+type MockJobLockRepositoryMockRecorder struct {
+	mock *MockJobLockRepository
+}
+
+// NewMockJobLockRepository creates a new mock instance.
+func NewMockJobLockRepository(ctrl *gomock.Controller) *MockJobLockRepository {
+	mock := &MockJobLockRepository{ctrl: ctrl}
+	mock.recorder = &MockJobLockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobLockRepository) EXPECT() *MockJobLockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockJobLockRepository) Release(ctx context.Context, jobName, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, jobName, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockJobLockRepositoryMockRecorder) Release(ctx, jobName, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockJobLockRepository)(nil).Release), ctx, jobName, owner)
+}
+
+// TryAcquire mocks base method.
+func (m *MockJobLockRepository) TryAcquire(ctx context.Context, jobName, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAcquire", ctx, jobName, owner, now, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAcquire indicates an expected call of TryAcquire.
+func (mr *MockJobLockRepositoryMockRecorder) TryAcquire(ctx, jobName, owner, now, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAcquire", reflect.TypeOf((*MockJobLockRepository)(nil).TryAcquire), ctx, jobName, owner, now, ttl)
+}
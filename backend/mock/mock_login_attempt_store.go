@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/login_attempt_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/login_attempt_store.go -destination=mock/mock_login_attempt_store.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoginAttemptStore is a mock of LoginAttemptStore interface.
+type MockLoginAttemptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAttemptStoreMockRecorder is the mock recorder for MockLoginAttemptStore.
+type MockLoginAttemptStoreMockRecorder struct {
+	mock *MockLoginAttemptStore
+}
+
+// NewMockLoginAttemptStore creates a new mock instance.
+func NewMockLoginAttemptStore(ctrl *gomock.Controller) *MockLoginAttemptStore {
+	mock := &MockLoginAttemptStore{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptStore) EXPECT() *MockLoginAttemptStoreMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockLoginAttemptStore) Find(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, key)
+	ret0, _ := ret[0].(*entity.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockLoginAttemptStoreMockRecorder) Find(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockLoginAttemptStore)(nil).Find), ctx, key)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, resetAfter time.Duration) (*entity.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, key, now, resetAfter)
+	ret0, _ := ret[0].(*entity.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLoginAttemptStoreMockRecorder) RecordFailure(ctx, key, now, resetAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLoginAttemptStore)(nil).RecordFailure), ctx, key, now, resetAfter)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptStoreMockRecorder) Reset(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptStore)(nil).Reset), ctx, key)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/oidc_auth_request_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/oidc_auth_request_repository.go -destination=mock/mock_oidc_auth_request_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCAuthRequestRepository is a mock of OIDCAuthRequestRepository interface.
+type MockOIDCAuthRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCAuthRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCAuthRequestRepositoryMockRecorder is the mock recorder for MockOIDCAuthRequestRepository.
+type MockOIDCAuthRequestRepositoryMockRecorder struct {
+	mock *MockOIDCAuthRequestRepository
+}
+
+// NewMockOIDCAuthRequestRepository creates a new mock instance.
+func NewMockOIDCAuthRequestRepository(ctrl *gomock.Controller) *MockOIDCAuthRequestRepository {
+	mock := &MockOIDCAuthRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCAuthRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCAuthRequestRepository) EXPECT() *MockOIDCAuthRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByStateHash mocks base method.
+func (m *MockOIDCAuthRequestRepository) FindByStateHash(ctx context.Context, stateHash string) (*entity.OIDCAuthRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStateHash", ctx, stateHash)
+	ret0, _ := ret[0].(*entity.OIDCAuthRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStateHash indicates an expected call of FindByStateHash.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) FindByStateHash(ctx, stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStateHash", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).FindByStateHash), ctx, stateHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockOIDCAuthRequestRepository) MarkUsed(ctx context.Context, req *entity.OIDCAuthRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) MarkUsed(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).MarkUsed), ctx, req)
+}
+
+// Save mocks base method.
+func (m *MockOIDCAuthRequestRepository) Save(ctx context.Context, req *entity.OIDCAuthRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) Save(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).Save), ctx, req)
+}
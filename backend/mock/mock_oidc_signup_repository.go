@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/oidc_signup_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/oidc_signup_repository.go -destination=mock/mock_oidc_signup_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCSignupRepository is a mock of OIDCSignupRepository interface.
+type MockOIDCSignupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCSignupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCSignupRepositoryMockRecorder is the mock recorder for MockOIDCSignupRepository.
+type MockOIDCSignupRepositoryMockRecorder struct {
+	mock *MockOIDCSignupRepository
+}
+
+// NewMockOIDCSignupRepository creates a new mock instance.
+func NewMockOIDCSignupRepository(ctrl *gomock.Controller) *MockOIDCSignupRepository {
+	mock := &MockOIDCSignupRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCSignupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCSignupRepository) EXPECT() *MockOIDCSignupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockOIDCSignupRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.OIDCSignup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.OIDCSignup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockOIDCSignupRepositoryMockRecorder) FindByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockOIDCSignupRepository)(nil).FindByTokenHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockOIDCSignupRepository) MarkUsed(ctx context.Context, signup *entity.OIDCSignup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, signup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockOIDCSignupRepositoryMockRecorder) MarkUsed(ctx, signup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockOIDCSignupRepository)(nil).MarkUsed), ctx, signup)
+}
+
+// Save mocks base method.
+func (m *MockOIDCSignupRepository) Save(ctx context.Context, signup *entity.OIDCSignup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, signup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOIDCSignupRepositoryMockRecorder) Save(ctx, signup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOIDCSignupRepository)(nil).Save), ctx, signup)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/password_reset_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/password_reset_token_repository.go -destination=mock/mock_password_reset_token_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordResetTokenRepository is a mock of PasswordResetTokenRepository interface.
+type MockPasswordResetTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetTokenRepositoryMockRecorder is the mock recorder for MockPasswordResetTokenRepository.
+type MockPasswordResetTokenRepositoryMockRecorder struct {
+	mock *MockPasswordResetTokenRepository
+}
+
+// NewMockPasswordResetTokenRepository creates a new mock instance.
+func NewMockPasswordResetTokenRepository(ctrl *gomock.Controller) *MockPasswordResetTokenRepository {
+	mock := &MockPasswordResetTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetTokenRepository) EXPECT() *MockPasswordResetTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockPasswordResetTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockPasswordResetTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) FindByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).FindByTokenHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockPasswordResetTokenRepository) MarkUsed(ctx context.Context, token *entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) MarkUsed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).MarkUsed), ctx, token)
+}
+
+// Save mocks base method.
+func (m *MockPasswordResetTokenRepository) Save(ctx context.Context, token *entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) Save(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).Save), ctx, token)
+}
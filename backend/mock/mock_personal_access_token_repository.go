@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/personal_access_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/personal_access_token_repository.go -destination=mock/mock_personal_access_token_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPersonalAccessTokenRepository is a mock of PersonalAccessTokenRepository interface.
+type MockPersonalAccessTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPersonalAccessTokenRepositoryMockRecorder is the mock recorder for MockPersonalAccessTokenRepository.
+type MockPersonalAccessTokenRepositoryMockRecorder struct {
+	mock *MockPersonalAccessTokenRepository
+}
+
+// NewMockPersonalAccessTokenRepository creates a new mock instance.
+func NewMockPersonalAccessTokenRepository(ctrl *gomock.Controller) *MockPersonalAccessTokenRepository {
+	mock := &MockPersonalAccessTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessTokenRepository) EXPECT() *MockPersonalAccessTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByID mocks base method.
+func (m *MockPersonalAccessTokenRepository) DeleteByID(ctx context.Context, id vo.PersonalAccessTokenID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockPersonalAccessTokenRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockPersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) FindByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).FindByTokenHash), ctx, tokenHash)
+}
+
+// FindByUserID mocks base method.
+func (m *MockPersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockPersonalAccessTokenRepository) Save(ctx context.Context, token *entity.PersonalAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Save(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Save), ctx, token)
+}
+
+// UpdateLastUsedAt mocks base method.
+func (m *MockPersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, token *entity.PersonalAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedAt", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedAt indicates an expected call of UpdateLastUsedAt.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) UpdateLastUsedAt(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedAt", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).UpdateLastUsedAt), ctx, token)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/profile_change_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/profile_change_repository.go -destination=mock/mock_profile_change_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileChangeRepository is a mock of ProfileChangeRepository interface.
+type MockProfileChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileChangeRepositoryMockRecorder is the mock recorder for MockProfileChangeRepository.
+type MockProfileChangeRepositoryMockRecorder struct {
+	mock *MockProfileChangeRepository
+}
+
+// NewMockProfileChangeRepository creates a new mock instance.
+func NewMockProfileChangeRepository(ctrl *gomock.Controller) *MockProfileChangeRepository {
+	mock := &MockProfileChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockProfileChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileChangeRepository) EXPECT() *MockProfileChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockProfileChangeRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.ProfileChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.ProfileChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockProfileChangeRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockProfileChangeRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockProfileChangeRepository) Save(ctx context.Context, change *entity.ProfileChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProfileChangeRepositoryMockRecorder) Save(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProfileChangeRepository)(nil).Save), ctx, change)
+}
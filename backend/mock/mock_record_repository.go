@@ -11,7 +11,6 @@ package mock
 
 import (
 	entity "caltrack/domain/entity"
-	repository "caltrack/domain/repository"
 	vo "caltrack/domain/vo"
 	context "context"
 	reflect "reflect"
@@ -44,6 +43,21 @@ func (m *MockRecordRepository) EXPECT() *MockRecordRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByUserID mocks base method.
+func (m *MockRecordRepository) CountByUserID(ctx context.Context, userID vo.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserID", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockRecordRepositoryMockRecorder) CountByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockRecordRepository)(nil).CountByUserID), ctx, userID)
+}
+
 // FindByUserIDAndDateRange mocks base method.
 func (m *MockRecordRepository) FindByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startTime, endTime time.Time) ([]*entity.Record, error) {
 	m.ctrl.T.Helper()
@@ -59,19 +73,18 @@ func (mr *MockRecordRepositoryMockRecorder) FindByUserIDAndDateRange(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndDateRange", reflect.TypeOf((*MockRecordRepository)(nil).FindByUserIDAndDateRange), ctx, userID, startTime, endTime)
 }
 
-// GetDailyCalories mocks base method.
-func (m *MockRecordRepository) GetDailyCalories(ctx context.Context, userID vo.UserID, period vo.StatisticsPeriod) ([]repository.DailyCalories, error) {
+// ForEachBatchByUserID mocks base method.
+func (m *MockRecordRepository) ForEachBatchByUserID(ctx context.Context, userID vo.UserID, batchSize int, fn func([]*entity.Record) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDailyCalories", ctx, userID, period)
-	ret0, _ := ret[0].([]repository.DailyCalories)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ForEachBatchByUserID", ctx, userID, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetDailyCalories indicates an expected call of GetDailyCalories.
-func (mr *MockRecordRepositoryMockRecorder) GetDailyCalories(ctx, userID, period any) *gomock.Call {
+// ForEachBatchByUserID indicates an expected call of ForEachBatchByUserID.
+func (mr *MockRecordRepositoryMockRecorder) ForEachBatchByUserID(ctx, userID, batchSize, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyCalories", reflect.TypeOf((*MockRecordRepository)(nil).GetDailyCalories), ctx, userID, period)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachBatchByUserID", reflect.TypeOf((*MockRecordRepository)(nil).ForEachBatchByUserID), ctx, userID, batchSize, fn)
 }
 
 // Save mocks base method.
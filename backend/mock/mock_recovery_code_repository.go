@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/recovery_code_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/recovery_code_repository.go -destination=mock/mock_recovery_code_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecoveryCodeRepository is a mock of RecoveryCodeRepository interface.
+type MockRecoveryCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecoveryCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRecoveryCodeRepositoryMockRecorder is the mock recorder for MockRecoveryCodeRepository.
+type MockRecoveryCodeRepositoryMockRecorder struct {
+	mock *MockRecoveryCodeRepository
+}
+
+// NewMockRecoveryCodeRepository creates a new mock instance.
+func NewMockRecoveryCodeRepository(ctrl *gomock.Controller) *MockRecoveryCodeRepository {
+	mock := &MockRecoveryCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockRecoveryCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecoveryCodeRepository) EXPECT() *MockRecoveryCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockRecoveryCodeRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockRecoveryCodeRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByUserIDAndCodeHash mocks base method.
+func (m *MockRecoveryCodeRepository) FindByUserIDAndCodeHash(ctx context.Context, userID vo.UserID, codeHash string) (*entity.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDAndCodeHash", ctx, userID, codeHash)
+	ret0, _ := ret[0].(*entity.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDAndCodeHash indicates an expected call of FindByUserIDAndCodeHash.
+func (mr *MockRecoveryCodeRepositoryMockRecorder) FindByUserIDAndCodeHash(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDAndCodeHash", reflect.TypeOf((*MockRecoveryCodeRepository)(nil).FindByUserIDAndCodeHash), ctx, userID, codeHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRecoveryCodeRepository) MarkUsed(ctx context.Context, code *entity.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRecoveryCodeRepositoryMockRecorder) MarkUsed(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRecoveryCodeRepository)(nil).MarkUsed), ctx, code)
+}
+
+// SaveAll mocks base method.
+func (m *MockRecoveryCodeRepository) SaveAll(ctx context.Context, codes []*entity.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockRecoveryCodeRepositoryMockRecorder) SaveAll(ctx, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockRecoveryCodeRepository)(nil).SaveAll), ctx, codes)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/retention_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/retention_repository.go -destination=mock/mock_retention_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	repository "caltrack/domain/repository"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRetentionRepository is a mock of RetentionRepository interface.
+type MockRetentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionRepositoryMockRecorder is the mock recorder for MockRetentionRepository.
+type MockRetentionRepositoryMockRecorder struct {
+	mock *MockRetentionRepository
+}
+
+// NewMockRetentionRepository creates a new mock instance.
+func NewMockRetentionRepository(ctrl *gomock.Controller) *MockRetentionRepository {
+	mock := &MockRetentionRepository{ctrl: ctrl}
+	mock.recorder = &MockRetentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionRepository) EXPECT() *MockRetentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindUserRetentions mocks base method.
+func (m *MockRetentionRepository) FindUserRetentions(ctx context.Context) ([]repository.UserRetention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserRetentions", ctx)
+	ret0, _ := ret[0].([]repository.UserRetention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserRetentions indicates an expected call of FindUserRetentions.
+func (mr *MockRetentionRepositoryMockRecorder) FindUserRetentions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserRetentions", reflect.TypeOf((*MockRetentionRepository)(nil).FindUserRetentions), ctx)
+}
+
+// PurgeRecordsBefore mocks base method.
+func (m *MockRetentionRepository) PurgeRecordsBefore(ctx context.Context, userID *vo.UserID, before time.Time) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRecordsBefore", ctx, userID, before)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRecordsBefore indicates an expected call of PurgeRecordsBefore.
+func (mr *MockRetentionRepositoryMockRecorder) PurgeRecordsBefore(ctx, userID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRecordsBefore", reflect.TypeOf((*MockRetentionRepository)(nil).PurgeRecordsBefore), ctx, userID, before)
+}
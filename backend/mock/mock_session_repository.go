@@ -14,6 +14,7 @@ import (
 	vo "caltrack/domain/vo"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -70,6 +71,50 @@ func (mr *MockSessionRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByUserID), ctx, userID)
 }
 
+// DeleteByUserIDExcept mocks base method.
+func (m *MockSessionRepository) DeleteByUserIDExcept(ctx context.Context, userID vo.UserID, keepSessionID vo.SessionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIDExcept", ctx, userID, keepSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserIDExcept indicates an expected call of DeleteByUserIDExcept.
+func (mr *MockSessionRepositoryMockRecorder) DeleteByUserIDExcept(ctx, userID, keepSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDExcept", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByUserIDExcept), ctx, userID, keepSessionID)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSessionRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSessionRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// FindActiveByUserID mocks base method.
+func (m *MockSessionRepository) FindActiveByUserID(ctx context.Context, userID vo.UserID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUserID indicates an expected call of FindActiveByUserID.
+func (mr *MockSessionRepositoryMockRecorder) FindActiveByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUserID", reflect.TypeOf((*MockSessionRepository)(nil).FindActiveByUserID), ctx, userID)
+}
+
 // FindByID mocks base method.
 func (m *MockSessionRepository) FindByID(ctx context.Context, sessionID vo.SessionID) (*entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +143,17 @@ func (mr *MockSessionRepositoryMockRecorder) Save(ctx, session any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSessionRepository)(nil).Save), ctx, session)
 }
+
+// UpdateActivity mocks base method.
+func (m *MockSessionRepository) UpdateActivity(ctx context.Context, session *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActivity", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActivity indicates an expected call of UpdateActivity.
+func (mr *MockSessionRepositoryMockRecorder) UpdateActivity(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivity", reflect.TypeOf((*MockSessionRepository)(nil).UpdateActivity), ctx, session)
+}
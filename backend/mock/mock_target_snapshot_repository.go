@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/target_snapshot_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/target_snapshot_repository.go -destination=mock/mock_target_snapshot_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTargetSnapshotRepository is a mock of TargetSnapshotRepository interface.
+type MockTargetSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTargetSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTargetSnapshotRepositoryMockRecorder is the mock recorder for MockTargetSnapshotRepository.
+type MockTargetSnapshotRepositoryMockRecorder struct {
+	mock *MockTargetSnapshotRepository
+}
+
+// NewMockTargetSnapshotRepository creates a new mock instance.
+func NewMockTargetSnapshotRepository(ctrl *gomock.Controller) *MockTargetSnapshotRepository {
+	mock := &MockTargetSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockTargetSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTargetSnapshotRepository) EXPECT() *MockTargetSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistsByUserID mocks base method.
+func (m *MockTargetSnapshotRepository) ExistsByUserID(ctx context.Context, userID vo.UserID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByUserID", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByUserID indicates an expected call of ExistsByUserID.
+func (mr *MockTargetSnapshotRepositoryMockRecorder) ExistsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByUserID", reflect.TypeOf((*MockTargetSnapshotRepository)(nil).ExistsByUserID), ctx, userID)
+}
+
+// FindEffectiveByUserIDAndDateRange mocks base method.
+func (m *MockTargetSnapshotRepository) FindEffectiveByUserIDAndDateRange(ctx context.Context, userID vo.UserID, startDate, endDate time.Time) ([]*entity.TargetSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffectiveByUserIDAndDateRange", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*entity.TargetSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEffectiveByUserIDAndDateRange indicates an expected call of FindEffectiveByUserIDAndDateRange.
+func (mr *MockTargetSnapshotRepositoryMockRecorder) FindEffectiveByUserIDAndDateRange(ctx, userID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffectiveByUserIDAndDateRange", reflect.TypeOf((*MockTargetSnapshotRepository)(nil).FindEffectiveByUserIDAndDateRange), ctx, userID, startDate, endDate)
+}
+
+// Save mocks base method.
+func (m *MockTargetSnapshotRepository) Save(ctx context.Context, snapshot *entity.TargetSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTargetSnapshotRepositoryMockRecorder) Save(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTargetSnapshotRepository)(nil).Save), ctx, snapshot)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/two_factor_challenge_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/two_factor_challenge_repository.go -destination=mock/mock_two_factor_challenge_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTwoFactorChallengeRepository is a mock of TwoFactorChallengeRepository interface.
+type MockTwoFactorChallengeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorChallengeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTwoFactorChallengeRepositoryMockRecorder is the mock recorder for MockTwoFactorChallengeRepository.
+type MockTwoFactorChallengeRepositoryMockRecorder struct {
+	mock *MockTwoFactorChallengeRepository
+}
+
+// NewMockTwoFactorChallengeRepository creates a new mock instance.
+func NewMockTwoFactorChallengeRepository(ctrl *gomock.Controller) *MockTwoFactorChallengeRepository {
+	mock := &MockTwoFactorChallengeRepository{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorChallengeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorChallengeRepository) EXPECT() *MockTwoFactorChallengeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockTwoFactorChallengeRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockTwoFactorChallengeRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) FindByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).FindByTokenHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockTwoFactorChallengeRepository) MarkUsed(ctx context.Context, challenge *entity.TwoFactorChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) MarkUsed(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).MarkUsed), ctx, challenge)
+}
+
+// Save mocks base method.
+func (m *MockTwoFactorChallengeRepository) Save(ctx context.Context, challenge *entity.TwoFactorChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) Save(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).Save), ctx, challenge)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/user_badge_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/user_badge_repository.go -destination=mock/mock_user_badge_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserBadgeRepository is a mock of UserBadgeRepository interface.
+type MockUserBadgeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserBadgeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserBadgeRepositoryMockRecorder is the mock recorder for MockUserBadgeRepository.
+type MockUserBadgeRepositoryMockRecorder struct {
+	mock *MockUserBadgeRepository
+}
+
+// NewMockUserBadgeRepository creates a new mock instance.
+func NewMockUserBadgeRepository(ctrl *gomock.Controller) *MockUserBadgeRepository {
+	mock := &MockUserBadgeRepository{ctrl: ctrl}
+	mock.recorder = &MockUserBadgeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserBadgeRepository) EXPECT() *MockUserBadgeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockUserBadgeRepository) FindByUserID(ctx context.Context, userID vo.UserID) ([]*entity.UserBadge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.UserBadge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockUserBadgeRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockUserBadgeRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockUserBadgeRepository) Save(ctx context.Context, badge *entity.UserBadge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, badge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUserBadgeRepositoryMockRecorder) Save(ctx, badge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserBadgeRepository)(nil).Save), ctx, badge)
+}
@@ -101,6 +101,22 @@ func (mr *MockUserRepositoryMockRecorder) Save(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), ctx, user)
 }
 
+// Search mocks base method.
+func (m *MockUserRepository) Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserRepositoryMockRecorder) Search(ctx, query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepository)(nil).Search), ctx, query, limit, offset)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, user *entity.User) error {
 	m.ctrl.T.Helper()
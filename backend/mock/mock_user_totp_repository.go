@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/user_totp_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/repository/user_totp_repository.go -destination=mock/mock_user_totp_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	entity "caltrack/domain/entity"
+	vo "caltrack/domain/vo"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserTOTPRepository is a mock of UserTOTPRepository interface.
+type MockUserTOTPRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTOTPRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserTOTPRepositoryMockRecorder is the mock recorder for MockUserTOTPRepository.
+type MockUserTOTPRepositoryMockRecorder struct {
+	mock *MockUserTOTPRepository
+}
+
+// NewMockUserTOTPRepository creates a new mock instance.
+func NewMockUserTOTPRepository(ctrl *gomock.Controller) *MockUserTOTPRepository {
+	mock := &MockUserTOTPRepository{ctrl: ctrl}
+	mock.recorder = &MockUserTOTPRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserTOTPRepository) EXPECT() *MockUserTOTPRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockUserTOTPRepository) DeleteByUserID(ctx context.Context, userID vo.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockUserTOTPRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockUserTOTPRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// FindByUserID mocks base method.
+func (m *MockUserTOTPRepository) FindByUserID(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockUserTOTPRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockUserTOTPRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockUserTOTPRepository) Save(ctx context.Context, totp *entity.UserTOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, totp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUserTOTPRepositoryMockRecorder) Save(ctx, totp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserTOTPRepository)(nil).Save), ctx, totp)
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +20,8 @@ var (
 // 環境変数ENVに応じて出力形式を切り替える
 // - production: JSON形式（本番向け）
 // - それ以外: カラー形式（開発向け）
+// どの形式でも RedactHandler を通し、メールアドレスやセッションIDなどの個人情報をマスクする
+// 環境変数LOG_REDACT_KEYS（カンマ区切り）で秘匿する属性キーを追加できる
 func Init() {
 	once.Do(func() {
 		initLogger()
@@ -51,10 +54,25 @@ func initLogger() {
 		})
 	}
 
-	defaultLogger = slog.New(handler)
+	var redactKeys []string
+	if keys := os.Getenv("LOG_REDACT_KEYS"); keys != "" {
+		redactKeys = strings.Split(keys, ",")
+	}
+
+	defaultLogger = slog.New(NewRedactHandler(handler, redactKeys...))
 	slog.SetDefault(defaultLogger)
 }
 
+// Replace はログの出力先をnextに差し替え、元に戻す関数を返す
+// テストで出力されたログの内容を検証するために使う（nextにも RedactHandler を通す）
+func Replace(next slog.Handler, keys ...string) (restore func()) {
+	prev := getLogger()
+	defaultLogger = slog.New(NewRedactHandler(next, keys...))
+	return func() {
+		defaultLogger = prev
+	}
+}
+
 // getLogger はデフォルトロガーを取得する
 // 初期化されていない場合は自動的に初期化する
 func getLogger() *slog.Logger {
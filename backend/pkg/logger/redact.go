@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// redactedValue は秘匿した値の代わりに出力する文字列
+const redactedValue = "[REDACTED]"
+
+// defaultRedactKeys は値を常に秘匿する属性キー（大文字小文字を区別しない）
+// "code" はAPIのエラーコードに使うため含めない
+var defaultRedactKeys = []string{
+	"password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"secret",
+	"client_secret",
+	"authorization",
+	"cookie",
+}
+
+// sessionIDKeys はセッションIDを値に持つ属性キー（先頭だけを残してマスクする）
+var sessionIDKeys = map[string]struct{}{
+	"session_id": {},
+	"sessionid":  {},
+}
+
+// emailPattern は文字列中のメールアドレスを検出する
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+\-])[A-Za-z0-9._%+\-]*@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// sensitive は呼び出し側で秘匿を指定した値
+// LogValuer を実装するため、RedactHandler を通さないロガーでも値は出力されない
+type sensitive struct{}
+
+func (sensitive) LogValue() slog.Value {
+	return slog.StringValue(redactedValue)
+}
+
+// Sensitive は値の代わりに [REDACTED] を出力する属性を返す
+// 食事内容やAIの生レスポンスなど、キー名では判別できない個人データがあったことだけをログに残す
+//
+//	logger.Debug("Gemini API Response", "textLength", len(responseText), logger.Sensitive("text"))
+func Sensitive(key string) slog.Attr {
+	return slog.Any(key, sensitive{})
+}
+
+// RedactHandler は出力前に属性の個人情報をマスクするslog.Handler
+// - 設定されたキーの値は [REDACTED] に置き換える
+// - セッションIDは先頭4文字だけを残す
+// - 文字列中のメールアドレスは先頭1文字とドメインだけを残す（t***@example.com）
+type RedactHandler struct {
+	next slog.Handler
+	keys map[string]struct{}
+}
+
+// NewRedactHandler は RedactHandler を生成する
+// keysには既定のキー（password, tokenなど）に加えて秘匿する属性キーを渡す
+func NewRedactHandler(next slog.Handler, keys ...string) *RedactHandler {
+	keySet := make(map[string]struct{}, len(defaultRedactKeys)+len(keys))
+	for _, k := range append(defaultRedactKeys, keys...) {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			keySet[k] = struct{}{}
+		}
+	}
+	return &RedactHandler{next: next, keys: keySet}
+}
+
+// Enabled は次のハンドラーに委譲する
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle は属性をマスクしたレコードを次のハンドラーに渡す
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs は属性をマスクしてから次のハンドラーに渡す
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redact(a)
+	}
+	return &RedactHandler{next: h.next.WithAttrs(redacted), keys: h.keys}
+}
+
+// WithGroup は次のハンドラーに委譲する
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{next: h.next.WithGroup(name), keys: h.keys}
+}
+
+// redact は属性1件をマスクする（グループは再帰的に処理する）
+func (h *RedactHandler) redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	key := strings.ToLower(a.Key)
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		redacted := make([]slog.Attr, len(attrs))
+		for i, ga := range attrs {
+			redacted[i] = h.redact(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	}
+	if _, ok := h.keys[key]; ok {
+		return slog.String(a.Key, redactedValue)
+	}
+	if _, ok := sessionIDKeys[key]; ok {
+		return slog.String(a.Key, maskSessionID(a.Value.String()))
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, MaskEmails(a.Value.String()))
+	case slog.KindAny:
+		// エラーメッセージなどに含まれるメールアドレスもマスクする
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, MaskEmails(err.Error()))
+		}
+	}
+	return a
+}
+
+// MaskEmails は文字列中のメールアドレスを先頭1文字とドメインだけ残してマスクする
+func MaskEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
+
+// maskSessionID はセッションIDの先頭4文字だけを残す（ログの突き合わせ用）
+func maskSessionID(id string) string {
+	if len(id) <= 4 {
+		return redactedValue
+	}
+	return id[:4] + "***"
+}
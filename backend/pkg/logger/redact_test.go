@@ -0,0 +1,108 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"caltrack/pkg/logger"
+)
+
+// newTestLogger はJSONで出力するRedactHandler付きのロガーを返す
+func newTestLogger(buf *bytes.Buffer, keys ...string) *slog.Logger {
+	next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(logger.NewRedactHandler(next, keys...))
+}
+
+// decodeLog は出力されたJSONログ1行を読み込む
+func decodeLog(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log: %v (%s)", err, buf.String())
+	}
+	return entry
+}
+
+func TestRedactHandler(t *testing.T) {
+	t.Run("正常系_メールアドレスはドメインを残してマスクされる", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf)
+
+		log.Warn("Login", "email", "taro.yamada@example.com", "error", errors.New("user bob@example.org not found"))
+
+		entry := decodeLog(t, &buf)
+		if entry["email"] != "t***@example.com" {
+			t.Errorf("email = %v, want t***@example.com", entry["email"])
+		}
+		if entry["error"] != "user b***@example.org not found" {
+			t.Errorf("error = %v, want masked email", entry["error"])
+		}
+	})
+
+	t.Run("正常系_セッションIDは先頭4文字だけ残る", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf)
+
+		log.Error("Save", "session_id", "abcdEFGHijklMNOP")
+
+		if entry := decodeLog(t, &buf); entry["session_id"] != "abcd***" {
+			t.Errorf("session_id = %v, want abcd***", entry["session_id"])
+		}
+	})
+
+	t.Run("正常系_既定と設定したキーの値は秘匿される", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf, "ip_address")
+
+		log.Info("request", "password", "secret123", "IP_ADDRESS", "192.0.2.1", "user_id", "u-1")
+
+		entry := decodeLog(t, &buf)
+		if entry["password"] != "[REDACTED]" || entry["IP_ADDRESS"] != "[REDACTED]" {
+			t.Errorf("entry = %v, want password and IP_ADDRESS redacted", entry)
+		}
+		if entry["user_id"] != "u-1" {
+			t.Errorf("user_id = %v, want u-1", entry["user_id"])
+		}
+	})
+
+	t.Run("正常系_APIのエラーコードは秘匿しない", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf)
+
+		log.Error("handler error", "code", "INTERNAL_ERROR")
+
+		if entry := decodeLog(t, &buf); entry["code"] != "INTERNAL_ERROR" {
+			t.Errorf("code = %v, want INTERNAL_ERROR", entry["code"])
+		}
+	})
+
+	t.Run("正常系_呼び出し側でSensitiveを指定した値は出力されない", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf)
+
+		log.Debug("Gemini Request", logger.Sensitive("foodItems"))
+
+		if entry := decodeLog(t, &buf); entry["foodItems"] != "[REDACTED]" {
+			t.Errorf("foodItems = %v, want [REDACTED]", entry["foodItems"])
+		}
+	})
+
+	t.Run("正常系_Withやグループの属性もマスクされる", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := newTestLogger(&buf).With("email", "hanako@example.jp")
+
+		log.Info("nested", slog.Group("auth", slog.String("token", "abc"), slog.String("email", "x@example.com")))
+
+		entry := decodeLog(t, &buf)
+		if entry["email"] != "h***@example.jp" {
+			t.Errorf("email = %v, want h***@example.jp", entry["email"])
+		}
+		auth, _ := entry["auth"].(map[string]any)
+		if auth["token"] != "[REDACTED]" || auth["email"] != "x***@example.com" {
+			t.Errorf("auth = %v, want token and email redacted", auth)
+		}
+	})
+}
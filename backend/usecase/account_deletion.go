@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// purgeBatchSize は PurgeDue で1回に処理する削除の申請の上限
+const purgeBatchSize = 100
+
+// AccountDeletionUsecase はアカウント削除（退会）に関するユースケースを提供する
+type AccountDeletionUsecase struct {
+	userRepo            repository.UserRepository
+	sessionRepo         repository.SessionRepository
+	accessTokenRepo     repository.PersonalAccessTokenRepository
+	accountDeletionRepo repository.AccountDeletionRepository
+	accountPurgeRepo    repository.AccountPurgeRepository
+	txManager           repository.TransactionManager
+	auditRecorder       service.AuditRecorder
+	gracePeriod         time.Duration
+}
+
+// NewAccountDeletionUsecase は AccountDeletionUsecase のインスタンスを生成する
+// gracePeriodが0の場合は申請と同時にデータを削除する
+func NewAccountDeletionUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	accessTokenRepo repository.PersonalAccessTokenRepository,
+	accountDeletionRepo repository.AccountDeletionRepository,
+	accountPurgeRepo repository.AccountPurgeRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+	gracePeriod time.Duration,
+) *AccountDeletionUsecase {
+	return &AccountDeletionUsecase{
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		accessTokenRepo:     accessTokenRepo,
+		accountDeletionRepo: accountDeletionRepo,
+		accountPurgeRepo:    accountPurgeRepo,
+		txManager:           txManager,
+		auditRecorder:       auditRecorder,
+		gracePeriod:         gracePeriod,
+	}
+}
+
+// RequestDeletion はパスワードを確認した上でアカウントの削除を申請する
+// 猶予期間がない場合はユーザーと全データを即時に削除する
+// 猶予期間がある場合は削除を予約して全セッションとパーソナルアクセストークンを削除する（猶予期間中にログインすると取り消される）
+// パスワード未設定のユーザーは、先にパスワードを設定する必要がある
+func (u *AccountDeletionUsecase) RequestDeletion(ctx context.Context, userID vo.UserID, password vo.Password) (*entity.AccountDeletion, error) {
+	var deletion *entity.AccountDeletion
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("RequestDeletion", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("RequestDeletion", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		if !user.HashedPassword().Compare(password) {
+			logWarn("RequestDeletion", "password incorrect", "user_id", userID.String())
+			return domainErrors.ErrCurrentPasswordIncorrect
+		}
+
+		pending, err := u.accountDeletionRepo.FindPendingByUserID(txCtx, userID)
+		if err != nil {
+			logError("RequestDeletion", err, "user_id", userID.String())
+			return err
+		}
+		if pending != nil {
+			logWarn("RequestDeletion", "account deletion already scheduled", "user_id", userID.String())
+			return domainErrors.ErrAccountDeletionAlreadyScheduled
+		}
+
+		deletion = entity.NewAccountDeletion(userID, u.gracePeriod)
+		if err := u.accountDeletionRepo.Save(txCtx, deletion); err != nil {
+			logError("RequestDeletion", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionAccountDeletionRequest, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"accountDeletionId": deletion.ID().String(),
+			"scheduledAt":       deletion.ScheduledAt(),
+		})
+		if err := u.auditRecorder.Record(txCtx, event); err != nil {
+			return err
+		}
+
+		if u.gracePeriod <= 0 {
+			return u.purge(txCtx, deletion, &userID)
+		}
+
+		// 猶予期間中は全端末をログアウトさせ、アクセストークンでも記録を読み書きできないようにする
+		if err := u.sessionRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("RequestDeletion", err, "user_id", userID.String())
+			return err
+		}
+		if err := u.accessTokenRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("RequestDeletion", err, "user_id", userID.String())
+			return err
+		}
+		logInfo("RequestDeletion", "account deletion scheduled",
+			"user_id", userID.String(), "account_deletion_id", deletion.ID().String(), "scheduled_at", deletion.ScheduledAt())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// PurgeDue は猶予期間が終わった削除の申請を処理し、ユーザーと全データを削除する
+// 申請ごとにトランザクションを分け、失敗した申請があっても残りの処理を続ける
+// 戻り値は削除したユーザー数
+func (u *AccountDeletionUsecase) PurgeDue(ctx context.Context, now time.Time) (int, error) {
+	deletions, err := u.accountDeletionRepo.FindDue(ctx, now, purgeBatchSize)
+	if err != nil {
+		logError("PurgeDue", err)
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, deletion := range deletions {
+		err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+			return u.purge(txCtx, deletion, nil)
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// purge はユーザーと全データを削除し、削除件数を申請に記録する
+// 監査ログもユーザーのデータとして削除し、削除したことだけを新しい監査ログに残す（管理者の操作の記録は残す）
+// actorIDは即時削除の場合はユーザー本人、猶予期間後の削除ではnil（システムによる削除）
+// トランザクション内で呼び出すこと
+func (u *AccountDeletionUsecase) purge(ctx context.Context, deletion *entity.AccountDeletion, actorID *vo.UserID) error {
+	userID := deletion.UserID()
+	purgedRows, err := u.accountPurgeRepo.PurgeByUserID(ctx, userID)
+	if err != nil {
+		logError("purge", err, "user_id", userID.String())
+		return err
+	}
+
+	if err := deletion.MarkPurged(purgedRows); err != nil {
+		logWarn("purge", err.Error(), "user_id", userID.String())
+		return err
+	}
+	if err := u.accountDeletionRepo.Update(ctx, deletion); err != nil {
+		logError("purge", err, "user_id", userID.String())
+		return err
+	}
+
+	event := entity.NewAuditEvent(entity.AuditActionAccountDeletionComplete, &userID, actorID, entity.AuditTargetUser, userID.String(), map[string]any{
+		"accountDeletionId": deletion.ID().String(),
+		"purgedRows":        purgedRows,
+	})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		return err
+	}
+
+	logInfo("purge", "account purged",
+		"user_id", userID.String(), "account_deletion_id", deletion.ID().String(), "purged_rows", purgedRows)
+	return nil
+}
+
+// cancelAccountDeletion はユーザーの削除待ちの申請があれば取り消す
+// ログインでセッションを作成する際に呼び出し、猶予期間中の退会を取り消す
+func cancelAccountDeletion(ctx context.Context, accountDeletionRepo repository.AccountDeletionRepository, userID vo.UserID) error {
+	pending, err := accountDeletionRepo.FindPendingByUserID(ctx, userID)
+	if err != nil {
+		logError("cancelAccountDeletion", err, "user_id", userID.String())
+		return err
+	}
+	if pending == nil {
+		return nil
+	}
+
+	if err := pending.Cancel(); err != nil {
+		logWarn("cancelAccountDeletion", err.Error(), "user_id", userID.String())
+		return err
+	}
+	if err := accountDeletionRepo.Update(ctx, pending); err != nil {
+		logError("cancelAccountDeletion", err, "user_id", userID.String())
+		return err
+	}
+
+	logInfo("cancelAccountDeletion", "account deletion cancelled by login",
+		"user_id", userID.String(), "account_deletion_id", pending.ID().String())
+	return nil
+}
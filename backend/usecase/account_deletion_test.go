@@ -0,0 +1,239 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// accountDeletionMocks はAccountDeletion Usecaseのテスト用モックをまとめる
+type accountDeletionMocks struct {
+	userRepo            *mock.MockUserRepository
+	sessionRepo         *mock.MockSessionRepository
+	accessTokenRepo     *mock.MockPersonalAccessTokenRepository
+	accountDeletionRepo *mock.MockAccountDeletionRepository
+	accountPurgeRepo    *mock.MockAccountPurgeRepository
+	txManager           *mock.MockTransactionManager
+	auditEvents         *auditEvents
+}
+
+// setupAccountDeletionMocks はモックと指定した猶予期間のAccountDeletionUsecaseを初期化する
+func setupAccountDeletionMocks(t *testing.T, gracePeriod time.Duration) (*accountDeletionMocks, *usecase.AccountDeletionUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	m := &accountDeletionMocks{
+		userRepo:            mock.NewMockUserRepository(ctrl),
+		sessionRepo:         mock.NewMockSessionRepository(ctrl),
+		accessTokenRepo:     mock.NewMockPersonalAccessTokenRepository(ctrl),
+		accountDeletionRepo: mock.NewMockAccountDeletionRepository(ctrl),
+		accountPurgeRepo:    mock.NewMockAccountPurgeRepository(ctrl),
+		txManager:           mock.NewMockTransactionManager(ctrl),
+		auditEvents:         auditEvents,
+	}
+	uc := usecase.NewAccountDeletionUsecase(m.userRepo, m.sessionRepo, m.accessTokenRepo, m.accountDeletionRepo, m.accountPurgeRepo, m.txManager, auditRecorder, gracePeriod)
+	return m, uc
+}
+
+func TestAccountDeletionUsecase_RequestDeletion(t *testing.T) {
+	password, _ := vo.NewPassword("password123")
+
+	t.Run("正常系_猶予期間なしの場合は即時に全データを削除する", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 0)
+		user := validUser(t)
+		purgedRows := map[string]int64{"users": 1, "records": 3}
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.accountDeletionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.accountPurgeRepo.EXPECT().PurgeByUserID(gomock.Any(), user.ID()).Return(purgedRows, nil)
+		m.accountDeletionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		deletion, err := uc.RequestDeletion(context.Background(), user.ID(), password)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if deletion.PurgedAt() == nil {
+			t.Error("PurgedAt should be set")
+		}
+		if deletion.PurgedRows()["records"] != 3 {
+			t.Errorf("PurgedRows[records] = %v, want 3", deletion.PurgedRows()["records"])
+		}
+		// 削除後も退会の事実は監査ログに残す
+		wantActions := []entity.AuditAction{entity.AuditActionAccountDeletionRequest, entity.AuditActionAccountDeletionComplete}
+		if !reflect.DeepEqual(m.auditEvents.actions(), wantActions) {
+			t.Errorf("audit events = %v, want %v", m.auditEvents.actions(), wantActions)
+		}
+	})
+
+	t.Run("正常系_猶予期間ありの場合は削除を予約して全セッションとアクセストークンを削除する", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 14*24*time.Hour)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.accountDeletionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.accessTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+
+		deletion, err := uc.RequestDeletion(context.Background(), user.ID(), password)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !deletion.IsPending() {
+			t.Error("deletion should be pending")
+		}
+		if got := deletion.ScheduledAt().Sub(deletion.RequestedAt()); got != 14*24*time.Hour {
+			t.Errorf("grace period = %v, want %v", got, 14*24*time.Hour)
+		}
+	})
+
+	t.Run("異常系_アクセストークンの削除に失敗した場合はエラーを返す", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 14*24*time.Hour)
+		user := validUser(t)
+		dbErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.accountDeletionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.accessTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(dbErr)
+
+		_, err := uc.RequestDeletion(context.Background(), user.ID(), password)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("error = %v, want %v", err, dbErr)
+		}
+	})
+
+	t.Run("異常系_パスワードが誤っている場合は削除しない", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 0)
+		user := validUser(t)
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		_, err := uc.RequestDeletion(context.Background(), user.ID(), wrongPassword)
+
+		if !errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+			t.Errorf("got %v, want ErrCurrentPasswordIncorrect", err)
+		}
+	})
+
+	t.Run("異常系_既に削除を予約済み", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 14*24*time.Hour)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).
+			Return(entity.NewAccountDeletion(user.ID(), 14*24*time.Hour), nil)
+
+		_, err := uc.RequestDeletion(context.Background(), user.ID(), password)
+
+		if !errors.Is(err, domainErrors.ErrAccountDeletionAlreadyScheduled) {
+			t.Errorf("got %v, want ErrAccountDeletionAlreadyScheduled", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 0)
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := uc.RequestDeletion(context.Background(), userID, password)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+
+	t.Run("異常系_データ削除に失敗した場合はエラーを返す", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 0)
+		user := validUser(t)
+		purgeErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.accountDeletionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.accountPurgeRepo.EXPECT().PurgeByUserID(gomock.Any(), user.ID()).Return(nil, purgeErr)
+
+		_, err := uc.RequestDeletion(context.Background(), user.ID(), password)
+
+		if !errors.Is(err, purgeErr) {
+			t.Errorf("got %v, want purgeErr", err)
+		}
+	})
+}
+
+func TestAccountDeletionUsecase_PurgeDue(t *testing.T) {
+	t.Run("正常系_猶予期間が終わった申請を削除し、失敗した申請があっても続ける", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 14*24*time.Hour)
+		now := time.Now()
+		failing := entity.NewAccountDeletion(vo.NewUserID(), 0)
+		succeeding := entity.NewAccountDeletion(vo.NewUserID(), 0)
+		purgeErr := errors.New("db error")
+
+		m.accountDeletionRepo.EXPECT().FindDue(gomock.Any(), now, gomock.Any()).
+			Return([]*entity.AccountDeletion{failing, succeeding}, nil)
+		m.txManager.EXPECT().
+			Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(2)
+		m.accountPurgeRepo.EXPECT().PurgeByUserID(gomock.Any(), failing.UserID()).Return(nil, purgeErr)
+		m.accountPurgeRepo.EXPECT().PurgeByUserID(gomock.Any(), succeeding.UserID()).Return(map[string]int64{"users": 1}, nil)
+		m.accountDeletionRepo.EXPECT().Update(gomock.Any(), succeeding).Return(nil)
+
+		purged, err := uc.PurgeDue(context.Background(), now)
+
+		if purged != 1 {
+			t.Errorf("purged = %d, want 1", purged)
+		}
+		if !errors.Is(err, purgeErr) {
+			t.Errorf("got %v, want purgeErr", err)
+		}
+		if succeeding.PurgedAt() == nil {
+			t.Error("succeeding deletion should be marked as purged")
+		}
+		// バッチ処理による削除は操作したユーザーを持たない
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].ActorID() != nil {
+			t.Errorf("audit events = %v, want one event without actor", m.auditEvents.actions())
+		}
+	})
+
+	t.Run("正常系_対象がない場合は何もしない", func(t *testing.T) {
+		m, uc := setupAccountDeletionMocks(t, 14*24*time.Hour)
+
+		m.accountDeletionRepo.EXPECT().FindDue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		purged, err := uc.PurgeDue(context.Background(), time.Now())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if purged != 0 {
+			t.Errorf("purged = %d, want 0", purged)
+		}
+	})
+}
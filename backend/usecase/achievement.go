@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+)
+
+// AchievementsOutput は実績（ストリーク・バッジ）の出力構造体
+type AchievementsOutput struct {
+	Streaks entity.Streaks      // 連続記録・連続達成の集計結果
+	Badges  []*entity.UserBadge // 獲得済みバッジ（獲得日時の昇順）
+}
+
+// AchievementUsecase は実績（ストリーク・バッジ）に関するユースケースを提供する
+type AchievementUsecase struct {
+	userRepo           repository.UserRepository
+	dailySummaryRepo   repository.DailySummaryRepository
+	targetSnapshotRepo repository.TargetSnapshotRepository
+	userBadgeRepo      repository.UserBadgeRepository
+}
+
+// NewAchievementUsecase は AchievementUsecase のインスタンスを生成する
+func NewAchievementUsecase(
+	userRepo repository.UserRepository,
+	dailySummaryRepo repository.DailySummaryRepository,
+	targetSnapshotRepo repository.TargetSnapshotRepository,
+	userBadgeRepo repository.UserBadgeRepository,
+) *AchievementUsecase {
+	return &AchievementUsecase{
+		userRepo:           userRepo,
+		dailySummaryRepo:   dailySummaryRepo,
+		targetSnapshotRepo: targetSnapshotRepo,
+		userBadgeRepo:      userBadgeRepo,
+	}
+}
+
+// GetAchievements は認証ユーザーのストリークと獲得バッジを取得する
+// バッジの獲得判定は記録の作成・取り込み時に行うため、ここでは保存済みのバッジを返すだけとする
+func (u *AchievementUsecase) GetAchievements(ctx context.Context, userID vo.UserID) (*AchievementsOutput, error) {
+	user, err := u.findUser(ctx, "GetAchievements", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 日別の達成判定（当日もJSTで判定する）
+	now := time.Now().In(helper.JST())
+	days, err := u.dailyAchievements(ctx, user, now)
+	if err != nil {
+		logError("GetAchievements", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// 獲得済みバッジ取得
+	badges, err := u.userBadgeRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logError("GetAchievements", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	return &AchievementsOutput{
+		Streaks: entity.CalculateStreaks(days, now),
+		Badges:  badges,
+	}, nil
+}
+
+// UnlockMilestoneBadges は獲得条件を新たに満たしたマイルストーンバッジを保存する
+// 記録の作成・取り込みで日別サマリーを更新した後、同じトランザクション内で呼び出す
+func (u *AchievementUsecase) UnlockMilestoneBadges(ctx context.Context, userID vo.UserID) error {
+	user, err := u.findUser(ctx, "UnlockMilestoneBadges", userID)
+	if err != nil {
+		return err
+	}
+
+	days, err := u.dailyAchievements(ctx, user, time.Now().In(helper.JST()))
+	if err != nil {
+		logError("UnlockMilestoneBadges", err, "user_id", userID.String())
+		return err
+	}
+	unlocks := entity.EvaluateMilestoneBadges(days)
+	if len(unlocks) == 0 {
+		return nil
+	}
+
+	badges, err := u.userBadgeRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logError("UnlockMilestoneBadges", err, "user_id", userID.String())
+		return err
+	}
+	unlocked := make(map[string]bool, len(badges))
+	for _, badge := range badges {
+		unlocked[badge.BadgeType().String()] = true
+	}
+
+	for _, unlock := range unlocks {
+		if unlocked[unlock.BadgeType.String()] {
+			continue
+		}
+		badge := entity.NewUserBadge(userID, unlock.BadgeType, unlock.UnlockedAt)
+		if err := u.userBadgeRepo.Save(ctx, badge); err != nil {
+			logError("UnlockMilestoneBadges", err, "user_id", userID.String(), "badge_type", unlock.BadgeType.String())
+			return err
+		}
+	}
+	return nil
+}
+
+// findUser はユーザーを取得し、存在しない場合は ErrUserNotFound を返す
+func (u *AchievementUsecase) findUser(ctx context.Context, operation string, userID vo.UserID) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError(operation, err, "user_id", userID.String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn(operation, "user not found", "user_id", userID.String())
+		return nil, domainErrors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// dailyAchievements は全期間の日別サマリー（日付はJSTで区切られている）から日別の達成状況を返す
+func (u *AchievementUsecase) dailyAchievements(ctx context.Context, user *entity.User, now time.Time) ([]entity.DailyAchievement, error) {
+	summaries, err := u.dailySummaryRepo.FindByUserID(ctx, user.ID())
+	if err != nil {
+		return nil, err
+	}
+	return u.judgeDailyAchievements(ctx, user, summaries, now)
+}
+
+// judgeDailyAchievements は日別サマリーをその日に有効だった目標値で判定する
+func (u *AchievementUsecase) judgeDailyAchievements(ctx context.Context, user *entity.User, summaries []*entity.DailySummary, now time.Time) ([]entity.DailyAchievement, error) {
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+
+	snapshots, err := u.targetSnapshotRepo.FindEffectiveByUserIDAndDateRange(ctx, user.ID(), summaries[0].Date(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	// スナップショットがない日は現在の目標値で判定する
+	targetCalories := vo.ReconstructCalories(user.CalculateTargetCalories())
+
+	days := make([]entity.DailyAchievement, len(summaries))
+	for i, summary := range summaries {
+		dailyTarget := targetCalories
+		if snapshot := entity.FindEffectiveTargetSnapshot(snapshots, summary.Date()); snapshot != nil {
+			dailyTarget = snapshot.TargetCalories()
+		}
+		days[i] = entity.DailyAchievement{
+			Date:       summary.Date(),
+			IsAchieved: summary.Calories().IsAchieved(dailyTarget),
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,263 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// setupAchievementMocks はテスト用のモックを初期化する
+func setupAchievementMocks(t *testing.T) (
+	*mock.MockUserRepository,
+	*mock.MockDailySummaryRepository,
+	*mock.MockTargetSnapshotRepository,
+	*mock.MockUserBadgeRepository,
+	*gomock.Controller,
+) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	return mock.NewMockUserRepository(ctrl),
+		mock.NewMockDailySummaryRepository(ctrl),
+		mock.NewMockTargetSnapshotRepository(ctrl),
+		mock.NewMockUserBadgeRepository(ctrl),
+		ctrl
+}
+
+// allowBadgeUnlock はバッジの獲得判定を常に成功させるモックを返す
+func allowBadgeUnlock(ctrl *gomock.Controller) *mock.MockBadgeUnlocker {
+	badgeUnlocker := mock.NewMockBadgeUnlocker(ctrl)
+	badgeUnlocker.EXPECT().UnlockMilestoneBadges(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return badgeUnlocker
+}
+
+// dailySummariesUntilToday は今日（JST）から遡った連続N日分の日別サマリーを日付の昇順で生成する
+func dailySummariesUntilToday(userID vo.UserID, days int, calories int) []*entity.DailySummary {
+	now := time.Now().In(helper.JST())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, helper.JST())
+
+	list := make([]*entity.DailySummary, days)
+	for i := 0; i < days; i++ {
+		list[i] = dailySummary(userID, today.AddDate(0, 0, i-days+1), calories)
+	}
+	return list
+}
+
+func TestAchievementUsecase_GetAchievements(t *testing.T) {
+	t.Run("正常系_ストリークと獲得済みバッジが返りバッジは保存しない", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		// 目標の90%を摂取（達成）
+		achievedCalories := user.CalculateTargetCalories() * 9 / 10
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(dailySummariesUntilToday(userID, 7, achievedCalories), nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{}, nil)
+		// 初めての記録バッジは獲得済み
+		firstRecord := entity.NewUserBadge(userID, vo.ReconstructBadgeType(vo.BadgeTypeFirstRecord), time.Now().AddDate(0, 0, -6))
+		// 条件を満たしていても取得時には保存しない（Saveを呼ぶとモックがエラーにする）
+		userBadgeRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.UserBadge{firstRecord}, nil)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		output, err := uc.GetAchievements(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := entity.Streaks{
+			LoggedDays:               7,
+			CurrentLoggingStreak:     7,
+			LongestLoggingStreak:     7,
+			CurrentAchievementStreak: 7,
+			LongestAchievementStreak: 7,
+		}
+		if output.Streaks != want {
+			t.Errorf("Streaks = %+v, want %+v", output.Streaks, want)
+		}
+		if len(output.Badges) != 1 {
+			t.Errorf("len(Badges) = %d, want 1", len(output.Badges))
+		}
+	})
+
+	t.Run("正常系_過去日はその日に有効だった目標値で判定される", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		summaries := dailySummariesUntilToday(userID, 3, 1000)
+
+		// 1000kcalで達成となる目標値（1100kcal）のスナップショット
+		snapshot := entity.ReconstructTargetSnapshot(
+			vo.NewTargetSnapshotID().String(),
+			userID.String(),
+			summaries[0].Date(),
+			1100,
+			80, 50, 200,
+			time.Now(),
+		)
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(summaries, nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{snapshot}, nil)
+		userBadgeRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.UserBadge{}, nil)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		output, err := uc.GetAchievements(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Streaks.CurrentAchievementStreak != 3 {
+			t.Errorf("CurrentAchievementStreak = %d, want 3", output.Streaks.CurrentAchievementStreak)
+		}
+	})
+
+	t.Run("正常系_記録がない場合はストリーク0", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.DailySummary{}, nil)
+		userBadgeRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.UserBadge{}, nil)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		output, err := uc.GetAchievements(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Streaks != (entity.Streaks{}) {
+			t.Errorf("Streaks = %+v, want zero", output.Streaks)
+		}
+		if len(output.Badges) != 0 {
+			t.Errorf("len(Badges) = %d, want 0", len(output.Badges))
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		_, err := uc.GetAchievements(context.Background(), userID)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("異常系_日別サマリー取得時にエラー", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		dbErr := errors.New("db error")
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(nil, dbErr)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		_, err := uc.GetAchievements(context.Background(), userID)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("expected db error, got %v", err)
+		}
+	})
+}
+
+func TestAchievementUsecase_UnlockMilestoneBadges(t *testing.T) {
+	t.Run("正常系_新たに条件を満たしたバッジのみ保存される", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		// 目標の90%を摂取（達成）
+		achievedCalories := user.CalculateTargetCalories() * 9 / 10
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(dailySummariesUntilToday(userID, 7, achievedCalories), nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{}, nil)
+		// 初めての記録バッジは獲得済み
+		firstRecord := entity.NewUserBadge(userID, vo.ReconstructBadgeType(vo.BadgeTypeFirstRecord), time.Now().AddDate(0, 0, -6))
+		userBadgeRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.UserBadge{firstRecord}, nil)
+		// 累計7日記録・7日連続達成の2件のみ保存される
+		var saved []vo.BadgeType
+		userBadgeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, badge *entity.UserBadge) error {
+				saved = append(saved, badge.BadgeType())
+				return nil
+			}).
+			Times(2)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		err := uc.UnlockMilestoneBadges(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, badgeType := range saved {
+			if badgeType.String() == vo.BadgeTypeFirstRecord {
+				t.Error("already unlocked badge should not be saved again")
+			}
+		}
+	})
+
+	t.Run("正常系_記録がない場合は何も保存しない", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.DailySummary{}, nil)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		if err := uc.UnlockMilestoneBadges(context.Background(), userID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_バッジ保存時にエラー", func(t *testing.T) {
+		userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo, ctrl := setupAchievementMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		dbErr := errors.New("db error")
+
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(dailySummariesUntilToday(userID, 1, 1000), nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{}, nil)
+		userBadgeRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.UserBadge{}, nil)
+		userBadgeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		uc := usecase.NewAchievementUsecase(userRepo, dailySummaryRepo, targetSnapshotRepo, userBadgeRepo)
+		err := uc.UnlockMilestoneBadges(context.Background(), userID)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("expected db error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// 管理者のユーザー検索で1回に返す件数
+const (
+	defaultAdminUserSearchLimit = 20
+	maxAdminUserSearchLimit     = 100
+)
+
+// AI使用量を集計する期間（日数）
+const (
+	defaultAIUsageDays = 30
+	maxAIUsageDays     = 365
+)
+
+// AdminUserSearchOutput はユーザー検索の出力を表す
+type AdminUserSearchOutput struct {
+	Users []*entity.User
+	Total int64 // 条件に一致するユーザーの総数（ページング用）
+}
+
+// AdminUserUsageOutput はユーザーの利用状況を表す
+type AdminUserUsageOutput struct {
+	User           *entity.User
+	RecordCount    int64                       // 記録の件数
+	ActiveSessions int                         // 有効期限内のセッション数
+	LastSeenAt     *time.Time                  // 最後にセッションを利用した日時（有効なセッションがない場合はnil）
+	AIUsageSince   time.Time                   // AI使用量の集計開始日時
+	AIUsage        []repository.AIUsageSummary // AI機能ごとのトークン使用量
+}
+
+// AdminUsecase は管理者によるユーザー管理に関するユースケースを提供する
+// 管理者の操作は全て監査ログに記録し、記録に失敗した場合は操作も失敗させる
+// 監査イベントのuserIDは操作対象のユーザー、actorIDは操作した管理者にする
+// 対象ユーザーのアカウントを完全削除しても、管理者の操作（admin.*）の監査イベントは削除しない
+type AdminUsecase struct {
+	userRepo      repository.UserRepository
+	sessionRepo   repository.SessionRepository
+	recordRepo    repository.RecordRepository
+	aiUsageRepo   repository.AIUsageRepository
+	txManager     repository.TransactionManager
+	auditRecorder service.AuditRecorder
+}
+
+// NewAdminUsecase は AdminUsecase のインスタンスを生成する
+func NewAdminUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	recordRepo repository.RecordRepository,
+	aiUsageRepo repository.AIUsageRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+) *AdminUsecase {
+	return &AdminUsecase{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		recordRepo:    recordRepo,
+		aiUsageRepo:   aiUsageRepo,
+		txManager:     txManager,
+		auditRecorder: auditRecorder,
+	}
+}
+
+// IsAdmin は指定ユーザーが管理者かどうかを返す（管理者用ルートのミドルウェアで使う）
+// 停止中のアカウントは管理者として扱わない
+func (u *AdminUsecase) IsAdmin(ctx context.Context, userID vo.UserID) (bool, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError("IsAdmin", err, "user_id", userID.String())
+		return false, err
+	}
+	if user == nil {
+		logWarn("IsAdmin", "user not found", "user_id", userID.String())
+		return false, domainErrors.ErrUserNotFound
+	}
+	return user.IsAdmin() && !user.IsDisabled(), nil
+}
+
+// SearchUsers はメールアドレスかニックネームにqueryを含むユーザーを登録日時の新しい順に取得する
+// limitが0以下の場合は既定の件数、上限を超える場合は上限の件数にする
+func (u *AdminUsecase) SearchUsers(ctx context.Context, actorID vo.UserID, query string, limit, offset int) (*AdminUserSearchOutput, error) {
+	query = strings.TrimSpace(query)
+	if limit <= 0 {
+		limit = defaultAdminUserSearchLimit
+	}
+	if limit > maxAdminUserSearchLimit {
+		limit = maxAdminUserSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, total, err := u.userRepo.Search(ctx, query, limit, offset)
+	if err != nil {
+		logError("SearchUsers", err, "actor_id", actorID.String())
+		return nil, err
+	}
+
+	// 特定のユーザーに対する操作ではないため、userIDはnilにする
+	event := entity.NewAuditEvent(entity.AuditActionAdminUserSearch, nil, &actorID, "", "", map[string]any{
+		"query":   query,
+		"limit":   limit,
+		"offset":  offset,
+		"results": len(users),
+	})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return &AdminUserSearchOutput{Users: users, Total: total}, nil
+}
+
+// GetUserUsage はユーザーの記録件数・セッション・直近days日間のAI使用量を取得する
+// daysが0以下の場合は既定の日数、上限を超える場合は上限の日数にする
+func (u *AdminUsecase) GetUserUsage(ctx context.Context, actorID, userID vo.UserID, days int) (*AdminUserUsageOutput, error) {
+	if days <= 0 {
+		days = defaultAIUsageDays
+	}
+	if days > maxAIUsageDays {
+		days = maxAIUsageDays
+	}
+
+	user, err := u.findUser(ctx, "GetUserUsage", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordCount, err := u.recordRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		logError("GetUserUsage", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		logError("GetUserUsage", err, "user_id", userID.String())
+		return nil, err
+	}
+	var lastSeenAt *time.Time
+	if len(sessions) > 0 {
+		// 最終利用日時の降順で返るため先頭が最新
+		t := sessions[0].LastSeenAt()
+		lastSeenAt = &t
+	}
+
+	since := startOfDay(time.Now().AddDate(0, 0, -days))
+	aiUsage, err := u.aiUsageRepo.SummarizeByUserID(ctx, userID, since)
+	if err != nil {
+		logError("GetUserUsage", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	event := entity.NewAuditEvent(entity.AuditActionAdminUsageView, &userID, &actorID, entity.AuditTargetUser, userID.String(), map[string]any{
+		"days": days,
+	})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return &AdminUserUsageOutput{
+		User:           user,
+		RecordCount:    recordCount,
+		ActiveSessions: len(sessions),
+		LastSeenAt:     lastSeenAt,
+		AIUsageSince:   since,
+		AIUsage:        aiUsage,
+	}, nil
+}
+
+// DisableUser はユーザーのアカウントを停止し、全てのセッションを削除してログアウトさせる
+// 管理者が自分自身を停止することはできない（ErrCannotDisableSelf）
+func (u *AdminUsecase) DisableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+	if actorID.Equals(userID) {
+		logWarn("DisableUser", "admin tried to disable own account", "user_id", userID.String())
+		return nil, domainErrors.ErrCannotDisableSelf
+	}
+
+	var user *entity.User
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = u.findUser(txCtx, "DisableUser", userID)
+		if err != nil {
+			return err
+		}
+		if err := user.Disable(); err != nil {
+			logWarn("DisableUser", err.Error(), "user_id", userID.String())
+			return err
+		}
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("DisableUser", err, "user_id", userID.String())
+			return err
+		}
+
+		// 停止したアカウントの端末は全てログアウトさせる
+		if err := u.sessionRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("DisableUser", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewAuditEvent(entity.AuditActionAdminUserDisable, &userID, &actorID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"disabledAt": user.DisabledAt(),
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo("DisableUser", "account disabled", "user_id", userID.String(), "actor_id", actorID.String())
+	return user, nil
+}
+
+// EnableUser は停止したユーザーのアカウントを再開する
+func (u *AdminUsecase) EnableUser(ctx context.Context, actorID, userID vo.UserID) (*entity.User, error) {
+	var user *entity.User
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = u.findUser(txCtx, "EnableUser", userID)
+		if err != nil {
+			return err
+		}
+		disabledAt := user.DisabledAt()
+		if err := user.Enable(); err != nil {
+			logWarn("EnableUser", err.Error(), "user_id", userID.String())
+			return err
+		}
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("EnableUser", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewAuditEvent(entity.AuditActionAdminUserEnable, &userID, &actorID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"disabledAt": disabledAt,
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo("EnableUser", "account enabled", "user_id", userID.String(), "actor_id", actorID.String())
+	return user, nil
+}
+
+// ForceLogout はユーザーの全てのセッションを削除して全端末からログアウトさせる
+// パーソナルアクセストークンは削除しない
+func (u *AdminUsecase) ForceLogout(ctx context.Context, actorID, userID vo.UserID) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if _, err := u.findUser(txCtx, "ForceLogout", userID); err != nil {
+			return err
+		}
+		if err := u.sessionRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("ForceLogout", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewAuditEvent(entity.AuditActionAdminSessionsRevoke, &userID, &actorID, entity.AuditTargetUser, userID.String(), nil)
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// ChangeRole はメールアドレスで指定したユーザーの権限を変更する
+// 最初の管理者の登録など、サーバー上のコマンドから実行するため操作者（actorID）は記録しない
+// 権限が変わらない場合は何もしない
+func (u *AdminUsecase) ChangeRole(ctx context.Context, email vo.Email, role vo.UserRole) (*entity.User, error) {
+	var user *entity.User
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = u.userRepo.FindByEmail(txCtx, email)
+		if err != nil {
+			logError("ChangeRole", err, "email", email.String())
+			return err
+		}
+		if user == nil {
+			logWarn("ChangeRole", "user not found", "email", email.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		oldRole := user.Role()
+		if oldRole == role {
+			return nil
+		}
+		user.ChangeRole(role)
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("ChangeRole", err, "user_id", user.ID().String())
+			return err
+		}
+
+		userID := user.ID()
+		event := entity.NewAuditEvent(entity.AuditActionAdminRoleChange, &userID, nil, entity.AuditTargetUser, userID.String(), map[string]any{
+			"role": map[string]any{"old": oldRole.String(), "new": role.String()},
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// findUser は操作対象のユーザーを取得する
+// 存在しない場合は ErrUserNotFound を返す
+func (u *AdminUsecase) findUser(ctx context.Context, operation string, userID vo.UserID) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError(operation, err, "user_id", userID.String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn(operation, "user not found", "user_id", userID.String())
+		return nil, domainErrors.ErrUserNotFound
+	}
+	return user, nil
+}
@@ -0,0 +1,476 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// adminMocks はAdmin Usecaseのテスト用モックをまとめる
+type adminMocks struct {
+	userRepo    *mock.MockUserRepository
+	sessionRepo *mock.MockSessionRepository
+	recordRepo  *mock.MockRecordRepository
+	aiUsageRepo *mock.MockAIUsageRepository
+	txManager   *mock.MockTransactionManager
+	auditEvents *auditEvents
+}
+
+// setupAdminMocks はモックとAdminUsecaseを初期化する
+func setupAdminMocks(t *testing.T) (*adminMocks, *usecase.AdminUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	m := &adminMocks{
+		userRepo:    mock.NewMockUserRepository(ctrl),
+		sessionRepo: mock.NewMockSessionRepository(ctrl),
+		recordRepo:  mock.NewMockRecordRepository(ctrl),
+		aiUsageRepo: mock.NewMockAIUsageRepository(ctrl),
+		txManager:   mock.NewMockTransactionManager(ctrl),
+		auditEvents: auditEvents,
+	}
+	uc := usecase.NewAdminUsecase(m.userRepo, m.sessionRepo, m.recordRepo, m.aiUsageRepo, m.txManager, auditRecorder)
+	return m, uc
+}
+
+// adminUser はテスト用の管理者ユーザーを生成する
+func adminUser(t *testing.T) *entity.User {
+	t.Helper()
+	user := validUser(t)
+	role, _ := vo.NewUserRole(vo.UserRoleAdmin)
+	user.ChangeRole(role)
+	return user
+}
+
+// disabledUser はテスト用の停止中のユーザーを生成する
+func disabledUser(t *testing.T) *entity.User {
+	t.Helper()
+	user := validUser(t)
+	if err := user.Disable(); err != nil {
+		t.Fatalf("failed to disable user: %v", err)
+	}
+	return user
+}
+
+func TestAdminUsecase_IsAdmin(t *testing.T) {
+	t.Run("正常系_管理者はtrue", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		admin := adminUser(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), admin.ID()).Return(admin, nil)
+
+		got, err := uc.IsAdmin(context.Background(), admin.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got {
+			t.Error("IsAdmin() = false, want true")
+		}
+	})
+
+	t.Run("正常系_一般ユーザーはfalse", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		got, err := uc.IsAdmin(context.Background(), user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got {
+			t.Error("IsAdmin() = true, want false")
+		}
+	})
+
+	t.Run("正常系_停止中の管理者はfalse", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		admin := adminUser(t)
+		_ = admin.Disable()
+		m.userRepo.EXPECT().FindByID(gomock.Any(), admin.ID()).Return(admin, nil)
+
+		got, err := uc.IsAdmin(context.Background(), admin.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got {
+			t.Error("IsAdmin() = true, want false for disabled admin")
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		userID := vo.NewUserID()
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := uc.IsAdmin(context.Background(), userID)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrUserNotFound)
+		}
+	})
+}
+
+func TestAdminUsecase_SearchUsers(t *testing.T) {
+	t.Run("正常系_検索結果を返し検索条件を監査ログに記録する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		actorID := vo.NewUserID()
+		user := validUser(t)
+		m.userRepo.EXPECT().Search(gomock.Any(), "test", 20, 40).Return([]*entity.User{user}, int64(41), nil)
+
+		output, err := uc.SearchUsers(context.Background(), actorID, "  test ", 20, 40)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Total != 41 || len(output.Users) != 1 {
+			t.Errorf("Total = %d, len(Users) = %d, want 41, 1", output.Total, len(output.Users))
+		}
+		if len(m.auditEvents.events) != 1 {
+			t.Fatalf("audit events = %v, want 1 event", m.auditEvents.actions())
+		}
+		event := m.auditEvents.events[0]
+		if event.Action() != entity.AuditActionAdminUserSearch {
+			t.Errorf("action = %s, want %s", event.Action(), entity.AuditActionAdminUserSearch)
+		}
+		if event.UserID() != nil {
+			t.Errorf("userID = %v, want nil", event.UserID())
+		}
+		if event.ActorID() == nil || !event.ActorID().Equals(actorID) {
+			t.Errorf("actorID = %v, want %s", event.ActorID(), actorID)
+		}
+		if event.Diff()["query"] != "test" || event.Diff()["results"] != 1 {
+			t.Errorf("diff = %v, want query=test results=1", event.Diff())
+		}
+	})
+
+	t.Run("正常系_件数の指定を既定値と上限に丸める", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		m.userRepo.EXPECT().Search(gomock.Any(), "", 20, 0).Return(nil, int64(0), nil)
+		m.userRepo.EXPECT().Search(gomock.Any(), "", 100, 0).Return(nil, int64(0), nil)
+
+		if _, err := uc.SearchUsers(context.Background(), vo.NewUserID(), "", 0, -5); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := uc.SearchUsers(context.Background(), vo.NewUserID(), "", 1000, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_監査ログの記録に失敗した場合は結果を返さない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepo := mock.NewMockUserRepository(ctrl)
+		auditRecorder := mock.NewMockAuditRecorder(ctrl)
+		uc := usecase.NewAdminUsecase(userRepo, nil, nil, nil, nil, auditRecorder)
+		auditErr := errors.New("db error")
+
+		userRepo.EXPECT().Search(gomock.Any(), "test", 20, 0).Return([]*entity.User{validUser(t)}, int64(1), nil)
+		auditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(auditErr)
+
+		output, err := uc.SearchUsers(context.Background(), vo.NewUserID(), "test", 20, 0)
+
+		if !errors.Is(err, auditErr) {
+			t.Errorf("error = %v, want %v", err, auditErr)
+		}
+		if output != nil {
+			t.Error("output should be nil")
+		}
+	})
+}
+
+func TestAdminUsecase_GetUserUsage(t *testing.T) {
+	t.Run("正常系_記録件数・セッション・AI使用量を返し監査ログに記録する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		actorID := vo.NewUserID()
+		user := validUser(t)
+		sessions := []*entity.Session{validSession(t, user.ID()), validSession(t, user.ID())}
+		summaries := []repository.AIUsageSummary{
+			{Feature: entity.AIFeatureImageAnalysis, Requests: 3, PromptTokens: 3600, OutputTokens: 240, TotalTokens: 3840},
+		}
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.recordRepo.EXPECT().CountByUserID(gomock.Any(), user.ID()).Return(int64(42), nil)
+		m.sessionRepo.EXPECT().FindActiveByUserID(gomock.Any(), user.ID()).Return(sessions, nil)
+		m.aiUsageRepo.EXPECT().
+			SummarizeByUserID(gomock.Any(), user.ID(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID vo.UserID, since time.Time) ([]repository.AIUsageSummary, error) {
+				// 7日前の0時から集計する
+				want := time.Now().AddDate(0, 0, -7)
+				if since.Year() != want.Year() || since.YearDay() != want.YearDay() || since.Hour() != 0 {
+					t.Errorf("since = %v, want start of %v", since, want.Format("2006-01-02"))
+				}
+				return summaries, nil
+			})
+
+		output, err := uc.GetUserUsage(context.Background(), actorID, user.ID(), 7)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.RecordCount != 42 || output.ActiveSessions != 2 {
+			t.Errorf("RecordCount = %d, ActiveSessions = %d, want 42, 2", output.RecordCount, output.ActiveSessions)
+		}
+		if output.LastSeenAt == nil || !output.LastSeenAt.Equal(sessions[0].LastSeenAt()) {
+			t.Errorf("LastSeenAt = %v, want %v", output.LastSeenAt, sessions[0].LastSeenAt())
+		}
+		if len(output.AIUsage) != 1 || output.AIUsage[0].TotalTokens != 3840 {
+			t.Errorf("AIUsage = %+v", output.AIUsage)
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionAdminUsageView {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionAdminUsageView)
+		}
+		event := m.auditEvents.events[0]
+		if event.UserID() == nil || !event.UserID().Equals(user.ID()) {
+			t.Errorf("userID = %v, want %s", event.UserID(), user.ID())
+		}
+		if event.ActorID() == nil || !event.ActorID().Equals(actorID) {
+			t.Errorf("actorID = %v, want %s", event.ActorID(), actorID)
+		}
+	})
+
+	t.Run("正常系_有効なセッションがない場合は最終利用日時がnil", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.recordRepo.EXPECT().CountByUserID(gomock.Any(), user.ID()).Return(int64(0), nil)
+		m.sessionRepo.EXPECT().FindActiveByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.aiUsageRepo.EXPECT().SummarizeByUserID(gomock.Any(), user.ID(), gomock.Any()).Return(nil, nil)
+
+		output, err := uc.GetUserUsage(context.Background(), vo.NewUserID(), user.ID(), 0)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.LastSeenAt != nil {
+			t.Errorf("LastSeenAt = %v, want nil", output.LastSeenAt)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		userID := vo.NewUserID()
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := uc.GetUserUsage(context.Background(), vo.NewUserID(), userID, 30)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrUserNotFound)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+}
+
+func TestAdminUsecase_DisableUser(t *testing.T) {
+	t.Run("正常系_アカウントを停止して全セッションを削除する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		actorID := vo.NewUserID()
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+
+		got, err := uc.DisableUser(context.Background(), actorID, user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.IsDisabled() {
+			t.Error("user should be disabled")
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionAdminUserDisable {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionAdminUserDisable)
+		}
+		if !m.auditEvents.events[0].ActorID().Equals(actorID) {
+			t.Errorf("actorID = %v, want %s", m.auditEvents.events[0].ActorID(), actorID)
+		}
+	})
+
+	t.Run("異常系_自分自身は停止できない", func(t *testing.T) {
+		_, uc := setupAdminMocks(t)
+		actorID := vo.NewUserID()
+
+		_, err := uc.DisableUser(context.Background(), actorID, actorID)
+
+		if !errors.Is(err, domainErrors.ErrCannotDisableSelf) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrCannotDisableSelf)
+		}
+	})
+
+	t.Run("異常系_停止中のアカウント", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := disabledUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		// 更新・セッション削除は行われない（EXPECT未設定）
+
+		_, err := uc.DisableUser(context.Background(), vo.NewUserID(), user.ID())
+
+		if !errors.Is(err, domainErrors.ErrAccountAlreadyDisabled) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrAccountAlreadyDisabled)
+		}
+	})
+
+	t.Run("異常系_セッション削除エラー", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+		dbErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(dbErr)
+
+		_, err := uc.DisableUser(context.Background(), vo.NewUserID(), user.ID())
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("error = %v, want %v", err, dbErr)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+}
+
+func TestAdminUsecase_EnableUser(t *testing.T) {
+	t.Run("正常系_停止中のアカウントを再開する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := disabledUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+
+		got, err := uc.EnableUser(context.Background(), vo.NewUserID(), user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.IsDisabled() {
+			t.Error("user should be enabled")
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionAdminUserEnable {
+			t.Errorf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionAdminUserEnable)
+		}
+	})
+
+	t.Run("異常系_停止されていないアカウント", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		_, err := uc.EnableUser(context.Background(), vo.NewUserID(), user.ID())
+
+		if !errors.Is(err, domainErrors.ErrAccountNotDisabled) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrAccountNotDisabled)
+		}
+	})
+}
+
+func TestAdminUsecase_ForceLogout(t *testing.T) {
+	t.Run("正常系_全セッションを削除して監査ログに記録する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+
+		if err := uc.ForceLogout(context.Background(), vo.NewUserID(), user.ID()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionAdminSessionsRevoke {
+			t.Errorf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionAdminSessionsRevoke)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		err := uc.ForceLogout(context.Background(), vo.NewUserID(), userID)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrUserNotFound)
+		}
+	})
+}
+
+func TestAdminUsecase_ChangeRole(t *testing.T) {
+	adminRole, _ := vo.NewUserRole(vo.UserRoleAdmin)
+
+	t.Run("正常系_権限を変更して操作者なしで監査ログに記録する", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), user.Email()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+
+		got, err := uc.ChangeRole(context.Background(), user.Email(), adminRole)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.IsAdmin() {
+			t.Error("user should be an admin")
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionAdminRoleChange {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionAdminRoleChange)
+		}
+		if m.auditEvents.events[0].ActorID() != nil {
+			t.Errorf("actorID = %v, want nil", m.auditEvents.events[0].ActorID())
+		}
+	})
+
+	t.Run("正常系_同じ権限の場合は何もしない", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		user := adminUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), user.Email()).Return(user, nil)
+		// 更新は行われない（EXPECT未設定）
+
+		if _, err := uc.ChangeRole(context.Background(), user.Email(), adminRole); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupAdminMocks(t)
+		email, _ := vo.NewEmail("notfound@example.com")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(nil, nil)
+
+		_, err := uc.ChangeRole(context.Background(), email, adminRole)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrUserNotFound)
+		}
+	})
+}
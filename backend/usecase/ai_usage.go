@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// recordAIUsage はAI機能1回の呼び出しで消費したトークン数を記録する
+// 記録に失敗してもAI機能の結果は返すため、ログのみ残す
+func recordAIUsage(ctx context.Context, aiUsageRepo repository.AIUsageRepository, userID vo.UserID, feature entity.AIFeature, model string, usage service.TokenUsage) {
+	aiUsage := entity.NewAIUsage(userID, feature, model, usage.PromptTokens, usage.OutputTokens, usage.TotalTokens, time.Now())
+	if err := aiUsageRepo.Save(ctx, aiUsage); err != nil {
+		logError("recordAIUsage", err, "user_id", userID.String(), "feature", string(feature))
+	}
+}
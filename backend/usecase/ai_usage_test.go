@@ -0,0 +1,14 @@
+package usecase_test
+
+import (
+	"caltrack/mock"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// allowAIUsageSave はAI使用履歴の保存を全て受け付けるAIUsageRepositoryのモックを返す
+func allowAIUsageSave(ctrl *gomock.Controller) *mock.MockAIUsageRepository {
+	aiUsageRepo := mock.NewMockAIUsageRepository(ctrl)
+	aiUsageRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return aiUsageRepo
+}
@@ -2,8 +2,11 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
 	"caltrack/domain/vo"
 	"caltrack/usecase/service"
 )
@@ -39,20 +42,28 @@ type AnalyzedItemOutput struct {
 
 // AnalyzeUsecase は画像解析に関するユースケースを提供する
 type AnalyzeUsecase struct {
+	userRepo      repository.UserRepository
+	userBadgeRepo repository.UserBadgeRepository
+	aiUsageRepo   repository.AIUsageRepository
 	imageAnalyzer service.ImageAnalyzer
 	aiConfig      AIConfig
 }
 
 // NewAnalyzeUsecase は AnalyzeUsecase のインスタンスを生成する
-func NewAnalyzeUsecase(imageAnalyzer service.ImageAnalyzer, aiConfig AIConfig) *AnalyzeUsecase {
+func NewAnalyzeUsecase(userRepo repository.UserRepository, userBadgeRepo repository.UserBadgeRepository, aiUsageRepo repository.AIUsageRepository, imageAnalyzer service.ImageAnalyzer, aiConfig AIConfig) *AnalyzeUsecase {
 	return &AnalyzeUsecase{
+		userRepo:      userRepo,
+		userBadgeRepo: userBadgeRepo,
+		aiUsageRepo:   aiUsageRepo,
 		imageAnalyzer: imageAnalyzer,
 		aiConfig:      aiConfig,
 	}
 }
 
 // AnalyzeImage は画像から食品を解析し、カロリー情報を返す
-func (u *AnalyzeUsecase) AnalyzeImage(ctx context.Context, imageData string, mimeType string) (*AnalyzeOutput, error) {
+// 解析に成功した場合は「初めての写真解析」バッジを獲得する
+// プライバシー設定でAI機能をオフにしている場合は画像を送信せず ErrAIProcessingDisabled を返す
+func (u *AnalyzeUsecase) AnalyzeImage(ctx context.Context, userID vo.UserID, imageData string, mimeType string) (*AnalyzeOutput, error) {
 	// 入力バリデーション
 	if imageData == "" {
 		return nil, domainErrors.ErrImageDataRequired
@@ -61,6 +72,19 @@ func (u *AnalyzeUsecase) AnalyzeImage(ctx context.Context, imageData string, mim
 		return nil, domainErrors.ErrMimeTypeRequired
 	}
 
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError("AnalyzeImage", err, "user_id", userID.String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn("AnalyzeImage", "user not found", "user_id", userID.String())
+		return nil, domainErrors.ErrUserNotFound
+	}
+	if !user.AllowsAIProcessing() {
+		return nil, domainErrors.ErrAIProcessingDisabled
+	}
+
 	// 解析設定を構築（ビジネスロジックとしてUsecase層で管理）
 	config := service.ImageAnalyzerConfig{
 		ModelName: u.aiConfig.GeminiModelName(),
@@ -68,17 +92,26 @@ func (u *AnalyzeUsecase) AnalyzeImage(ctx context.Context, imageData string, mim
 	}
 
 	// 画像解析サービスを呼び出し
-	analyzedItems, err := u.imageAnalyzer.Analyze(ctx, config, imageData, mimeType)
+	analysis, err := u.imageAnalyzer.Analyze(ctx, config, imageData, mimeType)
 	if err != nil {
 		logError("AnalyzeImage", err, "mimeType", mimeType)
 		return nil, err
 	}
+	recordAIUsage(ctx, u.aiUsageRepo, userID, entity.AIFeatureImageAnalysis, config.ModelName, analysis.Usage)
+	analyzedItems := analysis.Items
 
 	// 結果が空の場合
 	if len(analyzedItems) == 0 {
 		return nil, domainErrors.ErrNoFoodDetected
 	}
 
+	// 初めての写真解析バッジを獲得（獲得済みの場合は何もしない）
+	badge := entity.NewUserBadge(userID, vo.ReconstructBadgeType(vo.BadgeTypeFirstPhotoAnalysis), time.Now())
+	if err := u.userBadgeRepo.Save(ctx, badge); err != nil {
+		// バッジ保存失敗はログのみ（解析結果は返す）
+		logError("AnalyzeImage", err, "user_id", userID.String(), "badge_save_failed", true)
+	}
+
 	// 出力に変換
 	outputItems := make([]AnalyzedItemOutput, len(analyzedItems))
 	for i, item := range analyzedItems {
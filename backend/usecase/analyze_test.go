@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"caltrack/mock"
+	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/vo"
 	"caltrack/usecase"
@@ -15,14 +16,35 @@ import (
 )
 
 // setupAnalyzeMocks はテスト用のモックをセットアップするヘルパー関数
-func setupAnalyzeMocks(t *testing.T) (*mock.MockImageAnalyzer, *mock.MockAIConfig, *gomock.Controller) {
+func setupAnalyzeMocks(t *testing.T) (*mock.MockUserBadgeRepository, *mock.MockImageAnalyzer, *mock.MockAIConfig, *gomock.Controller) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
+	userBadgeRepo := mock.NewMockUserBadgeRepository(ctrl)
 	imageAnalyzer := mock.NewMockImageAnalyzer(ctrl)
 	aiConfig := mock.NewMockAIConfig(ctrl)
 	// デフォルトでモデル名を返すように設定
 	aiConfig.EXPECT().GeminiModelName().Return("test-model").AnyTimes()
-	return imageAnalyzer, aiConfig, ctrl
+	return userBadgeRepo, imageAnalyzer, aiConfig, ctrl
+}
+
+// aiEnabledUserRepo はAI機能をオフにしていないユーザーを返すUserRepositoryのモックを生成する
+func aiEnabledUserRepo(t *testing.T, ctrl *gomock.Controller) *mock.MockUserRepository {
+	t.Helper()
+	userRepo := mock.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(validUser(t), nil).AnyTimes()
+	return userRepo
+}
+
+// aiDisabledUser はプライバシー設定でAI機能をオフにしたユーザーを生成する
+func aiDisabledUser(t *testing.T) *entity.User {
+	t.Helper()
+	user := validUser(t)
+	settings, err := vo.NewPrivacySettings(false, false, 0, "2026-10")
+	if err != nil {
+		t.Fatalf("failed to create privacy settings: %v", err)
+	}
+	user.ChangePrivacySettings(settings)
+	return user
 }
 
 // TestAnalyzeUsecase_AnalyzeImage は画像解析機能のテスト
@@ -46,7 +68,7 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 			t.Fatalf("failed to create Calories: %v", err)
 		}
 
-		mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
 		defer ctrl.Finish()
 
 		// Analyze メソッドの期待値を設定
@@ -57,13 +79,25 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 				gomock.Eq("base64encodedimage"),      // imageData
 				gomock.Eq("image/jpeg"),              // mimeType
 			).
-			Return([]service.AnalyzedItem{
-				{Name: itemName1, Calories: calories1},
-				{Name: itemName2, Calories: calories2},
+			Return(&service.ImageAnalysisOutput{
+				Items: []service.AnalyzedItem{
+					{Name: itemName1, Calories: calories1},
+					{Name: itemName2, Calories: calories2},
+				},
 			}, nil)
 
-		uc := usecase.NewAnalyzeUsecase(mockAnalyzer, aiConfig)
-		result, err := uc.AnalyzeImage(context.Background(), "base64encodedimage", "image/jpeg")
+		// 初めての写真解析バッジが保存される
+		userBadgeRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, badge *entity.UserBadge) error {
+				if badge.BadgeType().String() != vo.BadgeTypeFirstPhotoAnalysis {
+					t.Errorf("got %s, want %s", badge.BadgeType().String(), vo.BadgeTypeFirstPhotoAnalysis)
+				}
+				return nil
+			})
+
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		result, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "base64encodedimage", "image/jpeg")
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -89,13 +123,13 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 	})
 
 	t.Run("異常系_画像データが空の場合、ErrImageDataRequiredを返す", func(t *testing.T) {
-		mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
 		defer ctrl.Finish()
 
 		// バリデーションエラーのため、Analyzeは呼ばれないことを検証（EXPECT未設定）
 
-		uc := usecase.NewAnalyzeUsecase(mockAnalyzer, aiConfig)
-		_, err := uc.AnalyzeImage(context.Background(), "", "image/jpeg")
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		_, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "", "image/jpeg")
 
 		if err != domainErrors.ErrImageDataRequired {
 			t.Errorf("got %v, want ErrImageDataRequired", err)
@@ -103,13 +137,13 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 	})
 
 	t.Run("異常系_MIMEタイプが空の場合、ErrMimeTypeRequiredを返す", func(t *testing.T) {
-		mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
 		defer ctrl.Finish()
 
 		// バリデーションエラーのため、Analyzeは呼ばれないことを検証（EXPECT未設定）
 
-		uc := usecase.NewAnalyzeUsecase(mockAnalyzer, aiConfig)
-		_, err := uc.AnalyzeImage(context.Background(), "base64encodedimage", "")
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		_, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "base64encodedimage", "")
 
 		if err != domainErrors.ErrMimeTypeRequired {
 			t.Errorf("got %v, want ErrMimeTypeRequired", err)
@@ -117,7 +151,7 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 	})
 
 	t.Run("異常系_解析結果が空の場合、ErrNoFoodDetectedを返す", func(t *testing.T) {
-		mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
 		defer ctrl.Finish()
 
 		// Analyze メソッドの期待値を設定（空の配列を返す）
@@ -128,10 +162,10 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 				gomock.Eq("base64encodedimage"),      // imageData
 				gomock.Eq("image/jpeg"),              // mimeType
 			).
-			Return([]service.AnalyzedItem{}, nil)
+			Return(&service.ImageAnalysisOutput{}, nil)
 
-		uc := usecase.NewAnalyzeUsecase(mockAnalyzer, aiConfig)
-		_, err := uc.AnalyzeImage(context.Background(), "base64encodedimage", "image/jpeg")
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		_, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "base64encodedimage", "image/jpeg")
 
 		if err != domainErrors.ErrNoFoodDetected {
 			t.Errorf("got %v, want ErrNoFoodDetected", err)
@@ -140,7 +174,7 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 
 	t.Run("異常系_画像解析サービスがエラーを返す場合", func(t *testing.T) {
 		analyzeErr := errors.New("analysis service error")
-		mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
 		defer ctrl.Finish()
 
 		// Analyze メソッドの期待値を設定（エラーを返す）
@@ -153,11 +187,108 @@ func TestAnalyzeUsecase_AnalyzeImage(t *testing.T) {
 			).
 			Return(nil, analyzeErr)
 
-		uc := usecase.NewAnalyzeUsecase(mockAnalyzer, aiConfig)
-		_, err := uc.AnalyzeImage(context.Background(), "base64encodedimage", "image/jpeg")
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		_, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "base64encodedimage", "image/jpeg")
 
 		if err != analyzeErr {
 			t.Errorf("got %v, want analyzeErr", err)
 		}
 	})
+
+	t.Run("正常系_バッジ保存に失敗しても解析結果は返る", func(t *testing.T) {
+		calories, err := vo.NewCalories(500)
+		if err != nil {
+			t.Fatalf("failed to create Calories: %v", err)
+		}
+		itemName, err := vo.NewItemName("ハンバーガー")
+		if err != nil {
+			t.Fatalf("failed to create ItemName: %v", err)
+		}
+
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		defer ctrl.Finish()
+
+		mockAnalyzer.EXPECT().
+			Analyze(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&service.ImageAnalysisOutput{Items: []service.AnalyzedItem{{Name: itemName, Calories: calories}}}, nil)
+		userBadgeRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(errors.New("db error"))
+
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		result, err := uc.AnalyzeImage(context.Background(), vo.NewUserID(), "base64encodedimage", "image/jpeg")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Items) != 1 {
+			t.Errorf("got %d items, want 1", len(result.Items))
+		}
+	})
+
+	t.Run("正常系_消費したトークン数がAI使用履歴として記録される", func(t *testing.T) {
+		calories, err := vo.NewCalories(500)
+		if err != nil {
+			t.Fatalf("failed to create Calories: %v", err)
+		}
+		itemName, err := vo.NewItemName("ハンバーガー")
+		if err != nil {
+			t.Fatalf("failed to create ItemName: %v", err)
+		}
+
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		mockAnalyzer.EXPECT().
+			Analyze(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&service.ImageAnalysisOutput{
+				Items: []service.AnalyzedItem{{Name: itemName, Calories: calories}},
+				Usage: service.TokenUsage{PromptTokens: 1200, OutputTokens: 80, TotalTokens: 1280},
+			}, nil)
+		userBadgeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		aiUsageRepo := mock.NewMockAIUsageRepository(ctrl)
+		aiUsageRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, usage *entity.AIUsage) error {
+				if !usage.UserID().Equals(userID) {
+					t.Errorf("UserID = %s, want %s", usage.UserID(), userID)
+				}
+				if usage.Feature() != entity.AIFeatureImageAnalysis || usage.Model() != "test-model" {
+					t.Errorf("Feature = %s, Model = %s, want image_analysis, test-model", usage.Feature(), usage.Model())
+				}
+				if usage.PromptTokens() != 1200 || usage.OutputTokens() != 80 || usage.TotalTokens() != 1280 {
+					t.Errorf("tokens = %d/%d/%d, want 1200/80/1280", usage.PromptTokens(), usage.OutputTokens(), usage.TotalTokens())
+				}
+				return errors.New("db error") // 保存に失敗しても解析結果は返る
+			})
+
+		uc := usecase.NewAnalyzeUsecase(aiEnabledUserRepo(t, ctrl), userBadgeRepo, aiUsageRepo, mockAnalyzer, aiConfig)
+		result, err := uc.AnalyzeImage(context.Background(), userID, "base64encodedimage", "image/jpeg")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Items) != 1 {
+			t.Errorf("got %d items, want 1", len(result.Items))
+		}
+	})
+
+	t.Run("異常系_AI機能をオフにしている場合、画像を送信せずErrAIProcessingDisabledを返す", func(t *testing.T) {
+		userBadgeRepo, mockAnalyzer, aiConfig, ctrl := setupAnalyzeMocks(t)
+		defer ctrl.Finish()
+
+		user := aiDisabledUser(t)
+		userRepo := mock.NewMockUserRepository(ctrl)
+		userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		// Analyzeは呼ばれないことを検証（EXPECT未設定）
+
+		uc := usecase.NewAnalyzeUsecase(userRepo, userBadgeRepo, allowAIUsageSave(ctrl), mockAnalyzer, aiConfig)
+		_, err := uc.AnalyzeImage(context.Background(), user.ID(), "base64encodedimage", "image/jpeg")
+
+		if !errors.Is(err, domainErrors.ErrAIProcessingDisabled) {
+			t.Errorf("got %v, want ErrAIProcessingDisabled", err)
+		}
+	})
 }
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+)
+
+// 監査ログの一覧で1回に返す件数
+const (
+	defaultAuditEventLimit = 50
+	maxAuditEventLimit     = 200
+)
+
+// requestClientKey はリクエスト元のクライアント情報をコンテキストに保持するためのキー
+type requestClientKey struct{}
+
+// WithRequestClient はリクエスト元のクライアント情報（IPアドレス・User-Agent）をコンテキストに設定する
+// 監査ログに記録するため、HTTPリクエストごとにミドルウェアで設定する
+func WithRequestClient(ctx context.Context, client entity.SessionClient) context.Context {
+	return context.WithValue(ctx, requestClientKey{}, client)
+}
+
+// requestClientFromContext はコンテキストからリクエスト元のクライアント情報を取得する
+// バッチ処理などHTTPリクエスト以外では空になる
+func requestClientFromContext(ctx context.Context) entity.SessionClient {
+	client, _ := ctx.Value(requestClientKey{}).(entity.SessionClient)
+	return client
+}
+
+// AuditUsecase は監査ログの記録と参照に関するユースケースを提供する
+// 他のユースケースには service.AuditRecorder として渡す
+type AuditUsecase struct {
+	auditEventRepo repository.AuditEventRepository
+}
+
+// NewAuditUsecase は AuditUsecase のインスタンスを生成する
+func NewAuditUsecase(auditEventRepo repository.AuditEventRepository) *AuditUsecase {
+	return &AuditUsecase{auditEventRepo: auditEventRepo}
+}
+
+// Record はリクエスト元のクライアント情報を付けて監査イベントを保存する
+func (u *AuditUsecase) Record(ctx context.Context, event *entity.AuditEvent) error {
+	event.AttachClient(requestClientFromContext(ctx))
+	if err := u.auditEventRepo.Save(ctx, event); err != nil {
+		logError("Record", err, "action", string(event.Action()))
+		return err
+	}
+	return nil
+}
+
+// ListEvents はユーザーのアカウントの監査イベントを新しい順に取得する
+// limitが0以下の場合は既定の件数、上限を超える場合は上限の件数にする
+func (u *AuditUsecase) ListEvents(ctx context.Context, userID vo.UserID, before *time.Time, limit int) ([]*entity.AuditEvent, error) {
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	}
+	if limit > maxAuditEventLimit {
+		limit = maxAuditEventLimit
+	}
+
+	events, err := u.auditEventRepo.FindByUserID(ctx, userID, before, limit)
+	if err != nil {
+		logError("ListEvents", err, "user_id", userID.String())
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// auditEvents は記録された監査イベントを保持する
+type auditEvents struct {
+	events []*entity.AuditEvent
+}
+
+// actions は記録された監査イベントの種類を記録順に返す
+func (a *auditEvents) actions() []entity.AuditAction {
+	actions := make([]entity.AuditAction, len(a.events))
+	for i, e := range a.events {
+		actions[i] = e.Action()
+	}
+	return actions
+}
+
+// captureAuditEvents は監査イベントの記録を全て受け付け、記録された内容を保持するAuditRecorderのモックを返す
+func captureAuditEvents(ctrl *gomock.Controller) (*mock.MockAuditRecorder, *auditEvents) {
+	captured := &auditEvents{}
+	recorder := mock.NewMockAuditRecorder(ctrl)
+	recorder.EXPECT().Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event *entity.AuditEvent) error {
+			captured.events = append(captured.events, event)
+			return nil
+		}).
+		AnyTimes()
+	return recorder, captured
+}
+
+// allowAuditRecord は監査イベントの記録を全て受け付けるAuditRecorderのモックを返す
+func allowAuditRecord(ctrl *gomock.Controller) *mock.MockAuditRecorder {
+	recorder, _ := captureAuditEvents(ctrl)
+	return recorder
+}
+
+func TestAuditUsecase_Record(t *testing.T) {
+	t.Run("正常系_コンテキストのクライアント情報を付けて保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		auditEventRepo := mock.NewMockAuditEventRepository(ctrl)
+		uc := usecase.NewAuditUsecase(auditEventRepo)
+
+		userID := vo.NewUserID()
+		event := entity.NewUserAuditEvent(entity.AuditActionDataExport, userID, entity.AuditTargetUser, userID.String(), nil)
+		ctx := usecase.WithRequestClient(context.Background(), entity.SessionClient{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"})
+
+		auditEventRepo.EXPECT().Save(gomock.Any(), event).Return(nil)
+
+		if err := uc.Record(ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if event.IPAddress() != "192.0.2.1" || event.UserAgent() != "Mozilla/5.0" {
+			t.Errorf("client = %s / %s, want request client", event.IPAddress(), event.UserAgent())
+		}
+	})
+
+	t.Run("異常系_保存に失敗した場合はエラーを返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		auditEventRepo := mock.NewMockAuditEventRepository(ctrl)
+		uc := usecase.NewAuditUsecase(auditEventRepo)
+		dbErr := errors.New("db error")
+
+		auditEventRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		event := entity.NewUserAuditEvent(entity.AuditActionLogout, vo.NewUserID(), "", "", nil)
+		if err := uc.Record(context.Background(), event); !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want dbErr", err)
+		}
+	})
+}
+
+func TestAuditUsecase_ListEvents(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{"正常系_未指定の場合は既定の件数", 0, 50},
+		{"正常系_指定した件数", 10, 10},
+		{"正常系_上限を超える場合は上限の件数", 1000, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			auditEventRepo := mock.NewMockAuditEventRepository(ctrl)
+			uc := usecase.NewAuditUsecase(auditEventRepo)
+
+			userID := vo.NewUserID()
+			before := time.Now()
+			event := entity.NewUserAuditEvent(entity.AuditActionLogin, userID, entity.AuditTargetSession, "abc", nil)
+			auditEventRepo.EXPECT().FindByUserID(gomock.Any(), userID, &before, tt.wantLimit).Return([]*entity.AuditEvent{event}, nil)
+
+			events, err := uc.ListEvents(context.Background(), userID, &before, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != 1 {
+				t.Errorf("len(events) = %d, want 1", len(events))
+			}
+		})
+	}
+}
@@ -2,42 +2,92 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/repository"
 	"caltrack/domain/vo"
+	"caltrack/usecase/service"
 )
 
 // AuthUsecase は認証に関するユースケースを提供する
 type AuthUsecase struct {
-	userRepo    repository.UserRepository
-	sessionRepo repository.SessionRepository
-	txManager   repository.TransactionManager
+	userRepo               repository.UserRepository
+	sessionRepo            repository.SessionRepository
+	userTOTPRepo           repository.UserTOTPRepository
+	recoveryCodeRepo       repository.RecoveryCodeRepository
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository
+	loginAttemptStore      repository.LoginAttemptStore
+	accountDeletionRepo    repository.AccountDeletionRepository
+	txManager              repository.TransactionManager
+	auditRecorder          service.AuditRecorder
 }
 
 // NewAuthUsecase は AuthUsecase のインスタンスを生成する
 func NewAuthUsecase(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
+	userTOTPRepo repository.UserTOTPRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository,
+	loginAttemptStore repository.LoginAttemptStore,
+	accountDeletionRepo repository.AccountDeletionRepository,
 	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		txManager:   txManager,
+		userRepo:               userRepo,
+		sessionRepo:            sessionRepo,
+		userTOTPRepo:           userTOTPRepo,
+		recoveryCodeRepo:       recoveryCodeRepo,
+		twoFactorChallengeRepo: twoFactorChallengeRepo,
+		loginAttemptStore:      loginAttemptStore,
+		accountDeletionRepo:    accountDeletionRepo,
+		txManager:              txManager,
+		auditRecorder:          auditRecorder,
 	}
 }
 
+// ログイン方法（監査ログに記録する）
+const (
+	loginMethodPassword = "password"
+	loginMethodTOTP     = "totp"
+	loginMethodOIDC     = "oidc"
+)
+
+// loginAttemptTarget はログイン試行を制限する単位（キーと適用するポリシー）
+type loginAttemptTarget struct {
+	key    string
+	policy entity.LoginThrottlePolicy
+}
+
 // LoginOutput はログイン処理の出力を表す
+// TwoFactorRequired が true の場合はセッションを作成しておらず（Sessionはnil）、
+// TwoFactorToken を使って LoginWithTOTP でログインを完了する
 type LoginOutput struct {
-	Session *entity.Session
-	User    *entity.User
+	Session            *entity.Session
+	User               *entity.User
+	TwoFactorRequired  bool
+	TwoFactorToken     vo.OneTimeToken
+	TwoFactorExpiresAt time.Time
 }
 
 // Login はメールアドレスとパスワードでユーザーを認証し、セッションを作成する
-func (u *AuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Password) (*LoginOutput, error) {
+// clientはセッション一覧で端末を識別するために保存する
+// メールアドレス・IPアドレス単位で失敗が続いている場合は、認証せずに LoginThrottledError を返す
+// 2段階認証が有効なユーザーの場合は、セッションの代わりに短時間有効な2段階認証トークンを発行する
+// 退会の猶予期間中のユーザーの場合は、セッションの作成時に削除の申請を取り消す
+func (u *AuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Password, client entity.SessionClient) (*LoginOutput, error) {
+	targets := loginAttemptTargets(email, client)
+	if err := u.checkLoginThrottle(ctx, targets); err != nil {
+		return nil, err
+	}
+
 	var output *LoginOutput
+	// パスワードを誤ったユーザー（ログイン失敗の監査ログ用）
+	var failedUserID *vo.UserID
 
 	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
 		// ユーザーをメールアドレスで検索
@@ -54,19 +104,47 @@ func (u *AuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Pas
 		// パスワードの照合
 		if !user.HashedPassword().Compare(password) {
 			logWarn("Login", "password mismatch", "email", email.String())
+			userID := user.ID()
+			failedUserID = &userID
 			return domainErrors.ErrInvalidCredentials
 		}
 
-		// セッションの作成
-		session, err := entity.NewSessionWithUserID(user.ID())
+		// 管理者に停止されたアカウントはパスワードが正しくてもログインできない
+		if user.IsDisabled() {
+			logWarn("Login", "account disabled", "user_id", user.ID().String())
+			userID := user.ID()
+			failedUserID = &userID
+			return domainErrors.ErrAccountDisabled
+		}
+
+		// 2段階認証が有効な場合はコード入力待ちのチャレンジを発行する
+		totp, err := u.userTOTPRepo.FindByUserID(txCtx, user.ID())
 		if err != nil {
 			logError("Login", err, "user_id", user.ID().String())
 			return err
 		}
+		if totp != nil && totp.IsEnabled() {
+			challenge, token, err := entity.NewTwoFactorChallenge(user.ID())
+			if err != nil {
+				logError("Login", err, "user_id", user.ID().String())
+				return err
+			}
+			if err := u.twoFactorChallengeRepo.Save(txCtx, challenge); err != nil {
+				logError("Login", err, "user_id", user.ID().String())
+				return err
+			}
+
+			output = &LoginOutput{
+				User:               user,
+				TwoFactorRequired:  true,
+				TwoFactorToken:     token,
+				TwoFactorExpiresAt: challenge.ExpiresAt(),
+			}
+			return nil
+		}
 
-		// セッションの保存
-		if err := u.sessionRepo.Save(txCtx, session); err != nil {
-			logError("Login", err, "session_id", session.ID().String())
+		session, err := u.createSession(txCtx, user, client, loginMethodPassword)
+		if err != nil {
 			return err
 		}
 
@@ -77,22 +155,276 @@ func (u *AuthUsecase) Login(ctx context.Context, email vo.Email, password vo.Pas
 		return nil
 	})
 
+	// 失敗回数と監査ログはトランザクションのロールバックに巻き込まれないよう確定後に記録する
+	if errors.Is(err, domainErrors.ErrInvalidCredentials) {
+		u.recordLoginFailure(ctx, targets)
+		reason := "invalid_password"
+		if failedUserID == nil {
+			reason = "unknown_email"
+		}
+		u.recordLoginFailedEvent(ctx, failedUserID, reason)
+		return nil, err
+	}
+	if errors.Is(err, domainErrors.ErrAccountDisabled) {
+		u.recordLoginFailedEvent(ctx, failedUserID, "account_disabled")
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// 2段階認証の完了までは失敗回数をリセットしない
+	if !output.TwoFactorRequired {
+		u.resetLoginAttempts(ctx, email)
+	}
+
 	return output, nil
 }
 
+// LoginWithTOTP は Login で発行した2段階認証トークンと認証アプリのコードでログインを完了し、セッションを作成する
+// codeにはTOTPコードの代わりにリカバリーコードも指定できる（使用したリカバリーコードは無効になる）
+// コードの誤りはパスワードの誤りと同じくメールアドレス・IPアドレス単位の失敗回数に数える
+func (u *AuthUsecase) LoginWithTOTP(ctx context.Context, token vo.OneTimeToken, code string, client entity.SessionClient) (*LoginOutput, error) {
+	challenge, err := u.twoFactorChallengeRepo.FindByTokenHash(ctx, token.Hash())
+	if err != nil {
+		logError("LoginWithTOTP", err)
+		return nil, err
+	}
+	if challenge == nil {
+		logWarn("LoginWithTOTP", "challenge not found")
+		return nil, domainErrors.ErrTwoFactorTokenInvalid
+	}
+	if err := challenge.Validate(); err != nil {
+		logWarn("LoginWithTOTP", "challenge expired or already used", "user_id", challenge.UserID().String())
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, challenge.UserID())
+	if err != nil {
+		logError("LoginWithTOTP", err, "user_id", challenge.UserID().String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn("LoginWithTOTP", "user not found", "user_id", challenge.UserID().String())
+		return nil, domainErrors.ErrTwoFactorTokenInvalid
+	}
+	// チャレンジ発行後にアカウントが停止された場合
+	if user.IsDisabled() {
+		logWarn("LoginWithTOTP", "account disabled", "user_id", user.ID().String())
+		return nil, domainErrors.ErrAccountDisabled
+	}
+
+	targets := loginAttemptTargets(user.Email(), client)
+	if err := u.checkLoginThrottle(ctx, targets); err != nil {
+		return nil, err
+	}
+
+	var output *LoginOutput
+	err = u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := u.verifySecondFactor(txCtx, user.ID(), code); err != nil {
+			return err
+		}
+
+		if err := challenge.Use(); err != nil {
+			logWarn("LoginWithTOTP", "challenge expired or already used", "user_id", user.ID().String())
+			return err
+		}
+		if err := u.twoFactorChallengeRepo.MarkUsed(txCtx, challenge); err != nil {
+			logError("LoginWithTOTP", err, "user_id", user.ID().String())
+			return err
+		}
+
+		session, err := u.createSession(txCtx, user, client, loginMethodTOTP)
+		if err != nil {
+			return err
+		}
+
+		output = &LoginOutput{
+			Session: session,
+			User:    user,
+		}
+		return nil
+	})
+
+	if errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+		u.recordLoginFailure(ctx, targets)
+		userID := user.ID()
+		u.recordLoginFailedEvent(ctx, &userID, "invalid_second_factor")
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u.resetLoginAttempts(ctx, user.Email())
+	return output, nil
+}
+
+// verifySecondFactor はTOTPコードまたはリカバリーコードを検証する
+// リカバリーコードの形式で入力された場合はリカバリーコードとして照合し、使用済みにする
+// どちらも一致しない場合は ErrInvalidTOTPCode を返す
+func (u *AuthUsecase) verifySecondFactor(ctx context.Context, userID vo.UserID, code string) error {
+	if recoveryCode, err := vo.ParseRecoveryCode(code); err == nil {
+		stored, err := u.recoveryCodeRepo.FindByUserIDAndCodeHash(ctx, userID, recoveryCode.Hash())
+		if err != nil {
+			logError("verifySecondFactor", err, "user_id", userID.String())
+			return err
+		}
+		if stored == nil || stored.Use() != nil {
+			logWarn("verifySecondFactor", "recovery code mismatch", "user_id", userID.String())
+			return domainErrors.ErrInvalidTOTPCode
+		}
+		if err := u.recoveryCodeRepo.MarkUsed(ctx, stored); err != nil {
+			logError("verifySecondFactor", err, "user_id", userID.String())
+			return err
+		}
+		logWarn("verifySecondFactor", "recovery code used", "user_id", userID.String())
+		return nil
+	}
+
+	totp, err := u.userTOTPRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logError("verifySecondFactor", err, "user_id", userID.String())
+		return err
+	}
+	if totp == nil || !totp.IsEnabled() {
+		// チャレンジ発行後に2段階認証が無効化された場合
+		logWarn("verifySecondFactor", "totp not enabled", "user_id", userID.String())
+		return domainErrors.ErrTwoFactorTokenInvalid
+	}
+
+	if err := totp.VerifyCode(code); err != nil {
+		logWarn("verifySecondFactor", "totp code mismatch", "user_id", userID.String())
+		return err
+	}
+	// 使用したタイムステップを記録して同じコードの再利用を防ぐ
+	if err := u.userTOTPRepo.Save(ctx, totp); err != nil {
+		logError("verifySecondFactor", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// createSession はユーザーのセッションを作成して保存し、ログインの監査ログを記録する
+// methodはログイン方法（監査ログ用）
+func (u *AuthUsecase) createSession(ctx context.Context, user *entity.User, client entity.SessionClient, method string) (*entity.Session, error) {
+	// 退会の猶予期間中であればログインで取り消す
+	if err := cancelAccountDeletion(ctx, u.accountDeletionRepo, user.ID()); err != nil {
+		return nil, err
+	}
+
+	session, err := entity.NewSessionWithUserID(user.ID())
+	if err != nil {
+		logError("createSession", err, "user_id", user.ID().String())
+		return nil, err
+	}
+	session.AttachClient(client)
+
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		logError("createSession", err, "session_id", session.ID().String())
+		return nil, err
+	}
+
+	event := entity.NewUserAuditEvent(entity.AuditActionLogin, user.ID(), entity.AuditTargetSession, session.ID().PublicID(), map[string]any{"method": method})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// recordLoginFailedEvent はログイン失敗の監査ログを記録する
+// 存在しないメールアドレスの場合はuserIDをnilにする（メールアドレスは記録しない）
+// ログインの失敗を妨げないよう、記録に失敗してもエラーは返さない
+func (u *AuthUsecase) recordLoginFailedEvent(ctx context.Context, userID *vo.UserID, reason string) {
+	targetID := ""
+	if userID != nil {
+		targetID = userID.String()
+	}
+	event := entity.NewAuditEvent(entity.AuditActionLoginFailed, userID, nil, entity.AuditTargetUser, targetID, map[string]any{"reason": reason})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		logError("recordLoginFailedEvent", err)
+	}
+}
+
+// resetLoginAttempts はログイン成功時にメールアドレス単位の失敗回数をリセットする
+// IP単位は他アカウントへの試行を含むため、1件の成功では戻さない
+func (u *AuthUsecase) resetLoginAttempts(ctx context.Context, email vo.Email) {
+	if err := u.loginAttemptStore.Reset(ctx, entity.EmailLoginAttemptKey(email)); err != nil {
+		logError("resetLoginAttempts", err, "email", email.String())
+	}
+}
+
+// loginAttemptTargets はログイン試行を制限する単位を返す
+// IPアドレスが取得できない場合はメールアドレス単位のみで制限する
+func loginAttemptTargets(email vo.Email, client entity.SessionClient) []loginAttemptTarget {
+	targets := []loginAttemptTarget{
+		{key: entity.EmailLoginAttemptKey(email), policy: entity.DefaultEmailLoginThrottlePolicy},
+	}
+	if client.IPAddress != "" {
+		targets = append(targets, loginAttemptTarget{key: entity.IPLoginAttemptKey(client.IPAddress), policy: entity.DefaultIPLoginThrottlePolicy})
+	}
+	return targets
+}
+
+// checkLoginThrottle は制限中の単位があれば、最も長い待ち時間で LoginThrottledError を返す
+func (u *AuthUsecase) checkLoginThrottle(ctx context.Context, targets []loginAttemptTarget) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, target := range targets {
+		attempt, err := u.loginAttemptStore.Find(ctx, target.key)
+		if err != nil {
+			logError("checkLoginThrottle", err, "attempt_key", target.key)
+			return err
+		}
+		if wait := target.policy.RetryAfter(attempt, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		logWarn("Login", "login throttled", "retry_after", retryAfter.String())
+		return &domainErrors.LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// recordLoginFailure は各単位の失敗回数を加算する
+// 記録に失敗しても認証失敗のレスポンスは変えないため、ログ出力のみとする
+func (u *AuthUsecase) recordLoginFailure(ctx context.Context, targets []loginAttemptTarget) {
+	now := time.Now()
+	for _, target := range targets {
+		attempt, err := u.loginAttemptStore.RecordFailure(ctx, target.key, now, target.policy.ResetAfter)
+		if err != nil {
+			logError("recordLoginFailure", err, "attempt_key", target.key)
+			continue
+		}
+		if attempt.Failures() >= target.policy.LockoutThreshold {
+			logWarn("Login", "login locked out", "attempt_key", target.key, "failures", attempt.Failures())
+		}
+	}
+}
+
 // Logout はセッションを削除してログアウトする
+// セッションが存在した場合のみ監査ログを記録する
 func (u *AuthUsecase) Logout(ctx context.Context, sessionID vo.SessionID) error {
 	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		session, err := u.sessionRepo.FindByID(txCtx, sessionID)
+		if err != nil {
+			logError("Logout", err, "session_id", sessionID.String())
+			return err
+		}
+
 		// セッションの削除
 		if err := u.sessionRepo.DeleteByID(txCtx, sessionID); err != nil {
 			logError("Logout", err, "session_id", sessionID.String())
 			return err
 		}
-		return nil
+
+		if session == nil {
+			return nil
+		}
+		event := entity.NewUserAuditEvent(entity.AuditActionLogout, session.UserID(), entity.AuditTargetSession, sessionID.PublicID(), nil)
+		return u.auditRecorder.Record(txCtx, event)
 	})
 
 	return err
@@ -116,5 +448,60 @@ func (u *AuthUsecase) ValidateSession(ctx context.Context, sessionID vo.SessionI
 		return nil, err
 	}
 
+	// 最終利用日時の記録と、有効期間の半分を過ぎていれば有効期限の延長を行う
+	if session.Touch() {
+		if err := u.sessionRepo.UpdateActivity(ctx, session); err != nil {
+			logError("ValidateSession", err, "session_id", sessionID.String())
+			return nil, err
+		}
+	}
+
 	return session, nil
 }
+
+// ListSessions はユーザーの有効なセッション一覧を取得する
+func (u *AuthUsecase) ListSessions(ctx context.Context, userID vo.UserID) ([]*entity.Session, error) {
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		logError("ListSessions", err, "user_id", userID.String())
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession はユーザーのセッションを公開ID（SessionID.PublicID）で指定して削除する
+// 他のユーザーのセッションは削除できず、該当がない場合は ErrSessionNotFound を返す
+func (u *AuthUsecase) RevokeSession(ctx context.Context, userID vo.UserID, publicID string) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		sessions, err := u.sessionRepo.FindActiveByUserID(txCtx, userID)
+		if err != nil {
+			logError("RevokeSession", err, "user_id", userID.String())
+			return err
+		}
+
+		for _, session := range sessions {
+			if session.ID().PublicID() != publicID {
+				continue
+			}
+			if err := u.sessionRepo.DeleteByID(txCtx, session.ID()); err != nil {
+				logError("RevokeSession", err, "user_id", userID.String())
+				return err
+			}
+			return nil
+		}
+
+		logWarn("RevokeSession", "session not found", "user_id", userID.String())
+		return domainErrors.ErrSessionNotFound
+	})
+}
+
+// RevokeOtherSessions は現在のセッション以外のユーザーの全セッションを削除する
+func (u *AuthUsecase) RevokeOtherSessions(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := u.sessionRepo.DeleteByUserIDExcept(txCtx, userID, currentSessionID); err != nil {
+			logError("RevokeOtherSessions", err, "user_id", userID.String())
+			return err
+		}
+		return nil
+	})
+}
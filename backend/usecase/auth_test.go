@@ -8,7 +8,9 @@ import (
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
 	"caltrack/domain/vo"
+	"caltrack/infrastructure/persistence/memory"
 	"caltrack/mock"
 	"caltrack/usecase"
 
@@ -25,6 +27,50 @@ func setupAuthMocks(t *testing.T) (*mock.MockUserRepository, *mock.MockSessionRe
 	return userRepo, sessionRepo, txManager, ctrl
 }
 
+// newAuthUsecase は2段階認証を設定していないユーザー向けのAuthUsecaseを生成する
+func newAuthUsecase(
+	ctrl *gomock.Controller,
+	userRepo *mock.MockUserRepository,
+	sessionRepo *mock.MockSessionRepository,
+	loginAttemptStore repository.LoginAttemptStore,
+	txManager *mock.MockTransactionManager,
+) *usecase.AuthUsecase {
+	uc, _ := newAuditedAuthUsecase(ctrl, userRepo, sessionRepo, loginAttemptStore, txManager)
+	return uc
+}
+
+// newAuditedAuthUsecase は記録された監査イベントを参照できるAuthUsecaseを生成する
+func newAuditedAuthUsecase(
+	ctrl *gomock.Controller,
+	userRepo *mock.MockUserRepository,
+	sessionRepo *mock.MockSessionRepository,
+	loginAttemptStore repository.LoginAttemptStore,
+	txManager *mock.MockTransactionManager,
+) (*usecase.AuthUsecase, *auditEvents) {
+	userTOTPRepo := mock.NewMockUserTOTPRepository(ctrl)
+	userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	auditRecorder, audited := captureAuditEvents(ctrl)
+	uc := usecase.NewAuthUsecase(
+		userRepo,
+		sessionRepo,
+		userTOTPRepo,
+		mock.NewMockRecoveryCodeRepository(ctrl),
+		mock.NewMockTwoFactorChallengeRepository(ctrl),
+		loginAttemptStore,
+		noPendingAccountDeletion(ctrl),
+		txManager,
+		auditRecorder,
+	)
+	return uc, audited
+}
+
+// noPendingAccountDeletion は削除待ちの申請がないAccountDeletionRepositoryのモックを生成する
+func noPendingAccountDeletion(ctrl *gomock.Controller) *mock.MockAccountDeletionRepository {
+	accountDeletionRepo := mock.NewMockAccountDeletionRepository(ctrl)
+	accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	return accountDeletionRepo
+}
+
 // setupTxManagerExecute はTransactionManager.ExecuteのDoAndReturnを設定する
 func setupTxManagerExecute(txManager *mock.MockTransactionManager) {
 	txManager.EXPECT().
@@ -73,6 +119,9 @@ func validSession(t *testing.T, userID vo.UserID) *entity.Session {
 	return session
 }
 
+// testSessionClient はテスト用のクライアント情報
+var testSessionClient = entity.SessionClient{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.1"}
+
 // =============================================================================
 // Login テスト
 // =============================================================================
@@ -96,10 +145,10 @@ func TestAuthUsecase_Login(t *testing.T) {
 			Save(gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		testEmail, _ := vo.NewEmail("test@example.com")
 		testPassword, _ := vo.NewPassword("password123")
-		output, err := uc.Login(context.Background(), testEmail, testPassword)
+		output, err := uc.Login(context.Background(), testEmail, testPassword, testSessionClient)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -116,6 +165,9 @@ func TestAuthUsecase_Login(t *testing.T) {
 		if output.Session.UserID().String() != user.ID().String() {
 			t.Errorf("session user id mismatch: got %v, want %v", output.Session.UserID().String(), user.ID().String())
 		}
+		if output.Session.UserAgent() != testSessionClient.UserAgent || output.Session.IPAddress() != testSessionClient.IPAddress {
+			t.Errorf("session client = (%s, %s), want (%s, %s)", output.Session.UserAgent(), output.Session.IPAddress(), testSessionClient.UserAgent, testSessionClient.IPAddress)
+		}
 	})
 
 	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
@@ -129,10 +181,10 @@ func TestAuthUsecase_Login(t *testing.T) {
 			FindByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(nil, nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		testEmail, _ := vo.NewEmail("notfound@example.com")
 		testPassword, _ := vo.NewPassword("password123")
-		_, err := uc.Login(context.Background(), testEmail, testPassword)
+		_, err := uc.Login(context.Background(), testEmail, testPassword, testSessionClient)
 
 		if !errors.Is(err, domainErrors.ErrInvalidCredentials) {
 			t.Errorf("got %v, want ErrInvalidCredentials", err)
@@ -151,10 +203,10 @@ func TestAuthUsecase_Login(t *testing.T) {
 			FindByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(user, nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		testEmail, _ := vo.NewEmail("test@example.com")
 		testPassword, _ := vo.NewPassword("wrongpassword")
-		_, err := uc.Login(context.Background(), testEmail, testPassword)
+		_, err := uc.Login(context.Background(), testEmail, testPassword, testSessionClient)
 
 		if !errors.Is(err, domainErrors.ErrInvalidCredentials) {
 			t.Errorf("got %v, want ErrInvalidCredentials", err)
@@ -173,10 +225,10 @@ func TestAuthUsecase_Login(t *testing.T) {
 			FindByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		testEmail, _ := vo.NewEmail("test@example.com")
 		testPassword, _ := vo.NewPassword("password123")
-		_, err := uc.Login(context.Background(), testEmail, testPassword)
+		_, err := uc.Login(context.Background(), testEmail, testPassword, testSessionClient)
 
 		if !errors.Is(err, repoErr) {
 			t.Errorf("got %v, want repoErr", err)
@@ -199,10 +251,10 @@ func TestAuthUsecase_Login(t *testing.T) {
 			Save(gomock.Any(), gomock.Any()).
 			Return(saveErr)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		testEmail, _ := vo.NewEmail("test@example.com")
 		testPassword, _ := vo.NewPassword("password123")
-		_, err := uc.Login(context.Background(), testEmail, testPassword)
+		_, err := uc.Login(context.Background(), testEmail, testPassword, testSessionClient)
 
 		if !errors.Is(err, saveErr) {
 			t.Errorf("got %v, want saveErr", err)
@@ -210,6 +262,141 @@ func TestAuthUsecase_Login(t *testing.T) {
 	})
 }
 
+// TestAuthUsecase_Login_CancelsAccountDeletion は退会の猶予期間中のログインのテスト
+func TestAuthUsecase_Login_CancelsAccountDeletion(t *testing.T) {
+	t.Run("正常系_ログインで削除待ちの申請が取り消される", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		email, _ := vo.NewEmail("test@example.com")
+		password, _ := vo.NewPassword("password123")
+		pending := entity.NewAccountDeletion(user.ID(), 14*24*time.Hour)
+
+		userTOTPRepo := mock.NewMockUserTOTPRepository(ctrl)
+		accountDeletionRepo := mock.NewMockAccountDeletionRepository(ctrl)
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		accountDeletionRepo.EXPECT().FindPendingByUserID(gomock.Any(), user.ID()).Return(pending, nil)
+		accountDeletionRepo.EXPECT().Update(gomock.Any(), pending).Return(nil)
+		sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := usecase.NewAuthUsecase(
+			userRepo,
+			sessionRepo,
+			userTOTPRepo,
+			mock.NewMockRecoveryCodeRepository(ctrl),
+			mock.NewMockTwoFactorChallengeRepository(ctrl),
+			memory.NewLoginAttemptStore(),
+			accountDeletionRepo,
+			txManager,
+			allowAuditRecord(ctrl),
+		)
+		output, err := uc.Login(context.Background(), email, password, testSessionClient)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Session == nil {
+			t.Error("session should be created")
+		}
+		if pending.IsPending() {
+			t.Error("account deletion should be cancelled")
+		}
+	})
+}
+
+// TestAuthUsecase_LoginAudit はログインの監査ログ記録のテスト
+func TestAuthUsecase_LoginAudit(t *testing.T) {
+	t.Run("正常系_ログイン成功を記録する", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		email, _ := vo.NewEmail("test@example.com")
+		password, _ := vo.NewPassword("password123")
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc, audited := newAuditedAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		output, err := uc.Login(context.Background(), email, password, testSessionClient)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(audited.events) != 1 {
+			t.Fatalf("audit events = %v, want [%s]", audited.actions(), entity.AuditActionLogin)
+		}
+		event := audited.events[0]
+		if event.Action() != entity.AuditActionLogin || event.TargetID() != output.Session.ID().PublicID() {
+			t.Errorf("event = %s %s, want %s %s", event.Action(), event.TargetID(), entity.AuditActionLogin, output.Session.ID().PublicID())
+		}
+		if event.Diff()["method"] != "password" {
+			t.Errorf("diff[method] = %v, want password", event.Diff()["method"])
+		}
+	})
+
+	t.Run("正常系_パスワード誤りを失敗として記録する", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		email, _ := vo.NewEmail("test@example.com")
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+
+		uc, audited := newAuditedAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		if _, err := uc.Login(context.Background(), email, wrongPassword, testSessionClient); !errors.Is(err, domainErrors.ErrInvalidCredentials) {
+			t.Fatalf("got %v, want ErrInvalidCredentials", err)
+		}
+
+		if len(audited.events) != 1 || audited.events[0].Action() != entity.AuditActionLoginFailed {
+			t.Fatalf("audit events = %v, want [%s]", audited.actions(), entity.AuditActionLoginFailed)
+		}
+		event := audited.events[0]
+		if event.UserID() == nil || !event.UserID().Equals(user.ID()) {
+			t.Errorf("userID = %v, want %s", event.UserID(), user.ID())
+		}
+		if event.Diff()["reason"] != "invalid_password" {
+			t.Errorf("diff[reason] = %v, want invalid_password", event.Diff()["reason"])
+		}
+	})
+
+	t.Run("異常系_停止されたアカウントはセッションを作成せず失敗として記録する", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		if err := user.Disable(); err != nil {
+			t.Fatalf("failed to disable user: %v", err)
+		}
+		email, _ := vo.NewEmail("test@example.com")
+		password, _ := vo.NewPassword("password123")
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		// セッションは作成されない（EXPECT未設定）
+
+		uc, audited := newAuditedAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		if _, err := uc.Login(context.Background(), email, password, testSessionClient); !errors.Is(err, domainErrors.ErrAccountDisabled) {
+			t.Fatalf("got %v, want ErrAccountDisabled", err)
+		}
+
+		if len(audited.events) != 1 || audited.events[0].Action() != entity.AuditActionLoginFailed {
+			t.Fatalf("audit events = %v, want [%s]", audited.actions(), entity.AuditActionLoginFailed)
+		}
+		if audited.events[0].Diff()["reason"] != "account_disabled" {
+			t.Errorf("diff[reason] = %v, want account_disabled", audited.events[0].Diff()["reason"])
+		}
+	})
+}
+
 // =============================================================================
 // Logout テスト
 // =============================================================================
@@ -223,11 +410,14 @@ func TestAuthUsecase_Logout(t *testing.T) {
 		sid := validSessionID(t)
 
 		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(sid)).
+			Return(nil, nil)
 		sessionRepo.EXPECT().
 			DeleteByID(gomock.Any(), gomock.Eq(sid)).
 			Return(nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		err := uc.Logout(context.Background(), sid)
 
 		if err != nil {
@@ -235,6 +425,30 @@ func TestAuthUsecase_Logout(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_ログアウトを監査ログに記録する", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		session := validSession(t, user.ID())
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().FindByID(gomock.Any(), session.ID()).Return(session, nil)
+		sessionRepo.EXPECT().DeleteByID(gomock.Any(), session.ID()).Return(nil)
+
+		uc, audited := newAuditedAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		if err := uc.Logout(context.Background(), session.ID()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(audited.events) != 1 || audited.events[0].Action() != entity.AuditActionLogout {
+			t.Fatalf("audit events = %v, want [%s]", audited.actions(), entity.AuditActionLogout)
+		}
+		if got := audited.events[0].TargetID(); got != session.ID().PublicID() {
+			t.Errorf("targetID = %s, want %s", got, session.ID().PublicID())
+		}
+	})
+
 	t.Run("異常系_セッション削除エラー", func(t *testing.T) {
 		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
 		defer ctrl.Finish()
@@ -243,11 +457,14 @@ func TestAuthUsecase_Logout(t *testing.T) {
 		deleteErr := errors.New("delete error")
 
 		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(sid)).
+			Return(nil, nil)
 		sessionRepo.EXPECT().
 			DeleteByID(gomock.Any(), gomock.Eq(sid)).
 			Return(deleteErr)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		err := uc.Logout(context.Background(), sid)
 
 		if !errors.Is(err, deleteErr) {
@@ -273,7 +490,7 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(session.ID())).
 			Return(session, nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		result, err := uc.ValidateSession(context.Background(), session.ID())
 
 		if err != nil {
@@ -287,6 +504,77 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_有効期間の半分を過ぎたセッションは延長される", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		// 残り1日のセッション
+		session, err := entity.ReconstructSession(
+			validSessionID(t).String(),
+			userID.String(),
+			time.Now().AddDate(0, 0, 1),
+			"",
+			"",
+			time.Now().AddDate(0, 0, -6),
+			time.Now().AddDate(0, 0, -6),
+		)
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+
+		sessionRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(session.ID())).
+			Return(session, nil)
+		sessionRepo.EXPECT().
+			UpdateActivity(gomock.Any(), session).
+			Return(nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		result, err := uc.ValidateSession(context.Background(), session.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExpiresAt().Time().Before(time.Now().AddDate(0, 0, vo.SessionDurationDays-1)) {
+			t.Errorf("ExpiresAt = %v, want extended to about %d days later", result.ExpiresAt().Time(), vo.SessionDurationDays)
+		}
+	})
+
+	t.Run("異常系_有効期限の延長に失敗", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		session, err := entity.ReconstructSession(
+			validSessionID(t).String(),
+			userID.String(),
+			time.Now().AddDate(0, 0, 1),
+			"",
+			"",
+			time.Now().AddDate(0, 0, -6),
+			time.Now().AddDate(0, 0, -6),
+		)
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		repoErr := errors.New("db error")
+
+		sessionRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(session.ID())).
+			Return(session, nil)
+		sessionRepo.EXPECT().
+			UpdateActivity(gomock.Any(), session).
+			Return(repoErr)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		_, err = uc.ValidateSession(context.Background(), session.ID())
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+
 	t.Run("異常系_セッションが見つからない", func(t *testing.T) {
 		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
 		defer ctrl.Finish()
@@ -297,7 +585,7 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(sid)).
 			Return(nil, nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		_, err := uc.ValidateSession(context.Background(), sid)
 
 		if !errors.Is(err, domainErrors.ErrSessionNotFound) {
@@ -315,6 +603,9 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 			validSessionID(t).String(),
 			userID.String(),
 			time.Now().AddDate(0, 0, -1), // 1日前に期限切れ
+			"",
+			"",
+			time.Now().AddDate(0, 0, -8),
 			time.Now().AddDate(0, 0, -8), // 8日前に作成
 		)
 		if err != nil {
@@ -325,7 +616,7 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(expiredSession.ID())).
 			Return(expiredSession, nil)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		_, err = uc.ValidateSession(context.Background(), expiredSession.ID())
 
 		if !errors.Is(err, domainErrors.ErrSessionExpired) {
@@ -344,7 +635,7 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(sid)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewAuthUsecase(userRepo, sessionRepo, txManager)
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
 		_, err := uc.ValidateSession(context.Background(), sid)
 
 		if !errors.Is(err, repoErr) {
@@ -352,3 +643,600 @@ func TestAuthUsecase_ValidateSession(t *testing.T) {
 		}
 	})
 }
+
+// =============================================================================
+// ListSessions テスト
+// =============================================================================
+
+// TestAuthUsecase_ListSessions はセッション一覧取得のテスト
+func TestAuthUsecase_ListSessions(t *testing.T) {
+	t.Run("正常系_有効なセッション一覧を取得", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		sessions := []*entity.Session{validSession(t, userID), validSession(t, userID)}
+
+		sessionRepo.EXPECT().
+			FindActiveByUserID(gomock.Any(), userID).
+			Return(sessions, nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		result, err := uc.ListSessions(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 2 {
+			t.Errorf("len(result) = %d, want 2", len(result))
+		}
+	})
+
+	t.Run("異常系_リポジトリエラー", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		repoErr := errors.New("db error")
+
+		sessionRepo.EXPECT().
+			FindActiveByUserID(gomock.Any(), userID).
+			Return(nil, repoErr)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		_, err := uc.ListSessions(context.Background(), userID)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
+
+// =============================================================================
+// RevokeSession テスト
+// =============================================================================
+
+// TestAuthUsecase_RevokeSession はセッション削除のテスト
+func TestAuthUsecase_RevokeSession(t *testing.T) {
+	t.Run("正常系_公開IDが一致するセッションを削除", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		target := validSession(t, userID)
+		other := validSession(t, userID)
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			FindActiveByUserID(gomock.Any(), userID).
+			Return([]*entity.Session{other, target}, nil)
+		sessionRepo.EXPECT().
+			DeleteByID(gomock.Any(), target.ID()).
+			Return(nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		err := uc.RevokeSession(context.Background(), userID, target.ID().PublicID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーのセッションに該当がない", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		// 他ユーザーのセッションは一覧に含まれない
+		otherUsersSession := validSession(t, vo.NewUserID())
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			FindActiveByUserID(gomock.Any(), userID).
+			Return([]*entity.Session{validSession(t, userID)}, nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		err := uc.RevokeSession(context.Background(), userID, otherUsersSession.ID().PublicID())
+
+		if !errors.Is(err, domainErrors.ErrSessionNotFound) {
+			t.Errorf("got %v, want ErrSessionNotFound", err)
+		}
+	})
+
+	t.Run("異常系_削除時にリポジトリエラー", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		target := validSession(t, userID)
+		repoErr := errors.New("db error")
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			FindActiveByUserID(gomock.Any(), userID).
+			Return([]*entity.Session{target}, nil)
+		sessionRepo.EXPECT().
+			DeleteByID(gomock.Any(), target.ID()).
+			Return(repoErr)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		err := uc.RevokeSession(context.Background(), userID, target.ID().PublicID())
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
+
+// =============================================================================
+// RevokeOtherSessions テスト
+// =============================================================================
+
+// TestAuthUsecase_RevokeOtherSessions は他の端末からのログアウトのテスト
+func TestAuthUsecase_RevokeOtherSessions(t *testing.T) {
+	t.Run("正常系_現在のセッション以外を削除", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		current := validSessionID(t)
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			DeleteByUserIDExcept(gomock.Any(), userID, current).
+			Return(nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		if err := uc.RevokeOtherSessions(context.Background(), userID, current); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_リポジトリエラー", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		current := validSessionID(t)
+		repoErr := errors.New("db error")
+
+		setupTxManagerExecute(txManager)
+		sessionRepo.EXPECT().
+			DeleteByUserIDExcept(gomock.Any(), userID, current).
+			Return(repoErr)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		err := uc.RevokeOtherSessions(context.Background(), userID, current)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
+
+// =============================================================================
+// ログイン試行制限 テスト
+// =============================================================================
+
+// TestAuthUsecase_LoginThrottle はログイン失敗が続いた場合の試行制限のテスト
+func TestAuthUsecase_LoginThrottle(t *testing.T) {
+	email, _ := vo.NewEmail("test@example.com")
+	wrongPassword, _ := vo.NewPassword("wrongpassword")
+	correctPassword, _ := vo.NewPassword("password123")
+
+	// failLogin は誤ったパスワードでn回ログインに失敗させる
+	failLogin := func(t *testing.T, uc *usecase.AuthUsecase, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			if _, err := uc.Login(context.Background(), email, wrongPassword, testSessionClient); !errors.Is(err, domainErrors.ErrInvalidCredentials) {
+				t.Fatalf("attempt %d: got %v, want ErrInvalidCredentials", i+1, err)
+			}
+		}
+	}
+
+	t.Run("正常系_無料回数までは続けて試行できる", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		freeAttempts := entity.DefaultEmailLoginThrottlePolicy.FreeAttempts
+
+		txManager.EXPECT().
+			Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(freeAttempts + 1)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil).Times(freeAttempts + 1)
+		sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		failLogin(t, uc, freeAttempts)
+
+		if _, err := uc.Login(context.Background(), email, correctPassword, testSessionClient); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_無料回数を超えて失敗すると待ち時間付きで制限される", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		freeAttempts := entity.DefaultEmailLoginThrottlePolicy.FreeAttempts
+
+		txManager.EXPECT().
+			Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(freeAttempts + 1)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil).Times(freeAttempts + 1)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, memory.NewLoginAttemptStore(), txManager)
+		failLogin(t, uc, freeAttempts+1)
+
+		// 正しいパスワードでも認証せずに制限エラーを返す
+		_, err := uc.Login(context.Background(), email, correctPassword, testSessionClient)
+
+		var throttledErr *domainErrors.LoginThrottledError
+		if !errors.As(err, &throttledErr) {
+			t.Fatalf("got %v, want LoginThrottledError", err)
+		}
+		if !errors.Is(err, domainErrors.ErrTooManyLoginAttempts) {
+			t.Error("error should match ErrTooManyLoginAttempts")
+		}
+		if throttledErr.RetryAfter <= 0 || throttledErr.RetryAfter > entity.DefaultEmailLoginThrottlePolicy.BaseDelay {
+			t.Errorf("RetryAfter = %v, want (0, %v]", throttledErr.RetryAfter, entity.DefaultEmailLoginThrottlePolicy.BaseDelay)
+		}
+	})
+
+	t.Run("異常系_失敗回数がしきい値に達するとロックアウトされる", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		policy := entity.DefaultEmailLoginThrottlePolicy
+		store := memory.NewLoginAttemptStore()
+		// しきい値の1回手前まで失敗済みの状態を作る
+		key := entity.EmailLoginAttemptKey(email)
+		past := time.Now().Add(-policy.LockoutDuration)
+		for i := 0; i < policy.LockoutThreshold-1; i++ {
+			if _, err := store.RecordFailure(context.Background(), key, past, policy.ResetAfter); err != nil {
+				t.Fatalf("RecordFailure() error = %v", err)
+			}
+		}
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(validUserForAuth(t), nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, store, txManager)
+		failLogin(t, uc, 1)
+
+		_, err := uc.Login(context.Background(), email, correctPassword, testSessionClient)
+
+		var throttledErr *domainErrors.LoginThrottledError
+		if !errors.As(err, &throttledErr) {
+			t.Fatalf("got %v, want LoginThrottledError", err)
+		}
+		if throttledErr.RetryAfter < policy.LockoutDuration-time.Minute {
+			t.Errorf("RetryAfter = %v, want about %v", throttledErr.RetryAfter, policy.LockoutDuration)
+		}
+	})
+
+	t.Run("正常系_ログイン成功でメールアドレス単位の失敗回数がリセットされる", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		store := memory.NewLoginAttemptStore()
+		key := entity.EmailLoginAttemptKey(email)
+		if _, err := store.RecordFailure(context.Background(), key, time.Now(), time.Hour); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(validUserForAuth(t), nil)
+		sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, store, txManager)
+		if _, err := uc.Login(context.Background(), email, correctPassword, testSessionClient); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		attempt, _ := store.Find(context.Background(), key)
+		if attempt != nil {
+			t.Errorf("email attempts should be reset, got %d failures", attempt.Failures())
+		}
+	})
+
+	t.Run("異常系_ストアの取得エラー", func(t *testing.T) {
+		userRepo, sessionRepo, txManager, ctrl := setupAuthMocks(t)
+		defer ctrl.Finish()
+
+		storeErr := errors.New("store error")
+		store := mock.NewMockLoginAttemptStore(ctrl)
+		store.EXPECT().Find(gomock.Any(), entity.EmailLoginAttemptKey(email)).Return(nil, storeErr)
+
+		uc := newAuthUsecase(ctrl, userRepo, sessionRepo, store, txManager)
+		_, err := uc.Login(context.Background(), email, correctPassword, testSessionClient)
+
+		if !errors.Is(err, storeErr) {
+			t.Errorf("got %v, want store error", err)
+		}
+	})
+}
+
+// =============================================================================
+// 2段階認証ログイン テスト
+// =============================================================================
+
+// twoFactorAuthMocks は2段階認証ログインのテストで使うモック一式
+type twoFactorAuthMocks struct {
+	userRepo               *mock.MockUserRepository
+	sessionRepo            *mock.MockSessionRepository
+	userTOTPRepo           *mock.MockUserTOTPRepository
+	recoveryCodeRepo       *mock.MockRecoveryCodeRepository
+	twoFactorChallengeRepo *mock.MockTwoFactorChallengeRepository
+	txManager              *mock.MockTransactionManager
+	loginAttemptStore      *memory.LoginAttemptStore
+}
+
+// setupTwoFactorAuthMocks は2段階認証ログインのテスト用のモックとAuthUsecaseを初期化する
+func setupTwoFactorAuthMocks(t *testing.T) (*twoFactorAuthMocks, *usecase.AuthUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &twoFactorAuthMocks{
+		userRepo:               mock.NewMockUserRepository(ctrl),
+		sessionRepo:            mock.NewMockSessionRepository(ctrl),
+		userTOTPRepo:           mock.NewMockUserTOTPRepository(ctrl),
+		recoveryCodeRepo:       mock.NewMockRecoveryCodeRepository(ctrl),
+		twoFactorChallengeRepo: mock.NewMockTwoFactorChallengeRepository(ctrl),
+		txManager:              mock.NewMockTransactionManager(ctrl),
+		loginAttemptStore:      memory.NewLoginAttemptStore(),
+	}
+	uc := usecase.NewAuthUsecase(m.userRepo, m.sessionRepo, m.userTOTPRepo, m.recoveryCodeRepo, m.twoFactorChallengeRepo, m.loginAttemptStore, noPendingAccountDeletion(ctrl), m.txManager, allowAuditRecord(ctrl))
+	return m, uc
+}
+
+// enabledTOTP はユーザーの有効化済みTOTP設定を生成する
+func enabledTOTP(t *testing.T, userID vo.UserID) *entity.UserTOTP {
+	t.Helper()
+	secret, err := vo.NewTOTPSecret()
+	if err != nil {
+		t.Fatalf("failed to create totp secret: %v", err)
+	}
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	return entity.ReconstructUserTOTP(userID.String(), secret.String(), &enabledAt, 0, enabledAt, enabledAt)
+}
+
+func TestAuthUsecase_Login_TwoFactor(t *testing.T) {
+	t.Run("正常系_2段階認証が有効な場合はチャレンジを発行しセッションを作成しない", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+
+		user := validUserForAuth(t)
+		email, _ := vo.NewEmail("test@example.com")
+		password, _ := vo.NewPassword("password123")
+
+		var savedChallenge *entity.TwoFactorChallenge
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(enabledTOTP(t, user.ID()), nil)
+		m.twoFactorChallengeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, challenge *entity.TwoFactorChallenge) error {
+				savedChallenge = challenge
+				return nil
+			})
+
+		output, err := uc.Login(context.Background(), email, password, testSessionClient)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !output.TwoFactorRequired {
+			t.Error("TwoFactorRequired should be true")
+		}
+		if output.Session != nil {
+			t.Error("Session should not be created before two-factor authentication")
+		}
+		if output.TwoFactorToken.Hash() != savedChallenge.TokenHash() {
+			t.Error("TwoFactorToken should match the saved challenge")
+		}
+		if !output.TwoFactorExpiresAt.Equal(savedChallenge.ExpiresAt()) {
+			t.Errorf("TwoFactorExpiresAt = %v, want %v", output.TwoFactorExpiresAt, savedChallenge.ExpiresAt())
+		}
+	})
+
+	t.Run("正常系_登録確認前のTOTPは無視してセッションを作成する", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+
+		user := validUserForAuth(t)
+		email, _ := vo.NewEmail("test@example.com")
+		password, _ := vo.NewPassword("password123")
+		pending, _ := entity.NewUserTOTP(user.ID())
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(pending, nil)
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := uc.Login(context.Background(), email, password, testSessionClient)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.TwoFactorRequired || output.Session == nil {
+			t.Error("session should be created when totp is not confirmed")
+		}
+	})
+}
+
+func TestAuthUsecase_LoginWithTOTP(t *testing.T) {
+	// setupChallenge はユーザーと有効なチャレンジを用意し、取得のモックを設定する
+	setupChallenge := func(t *testing.T, m *twoFactorAuthMocks) (*entity.User, *entity.TwoFactorChallenge, vo.OneTimeToken) {
+		t.Helper()
+		user := validUserForAuth(t)
+		challenge, token, err := entity.NewTwoFactorChallenge(user.ID())
+		if err != nil {
+			t.Fatalf("failed to create challenge: %v", err)
+		}
+		m.twoFactorChallengeRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(challenge, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		return user, challenge, token
+	}
+
+	t.Run("正常系_TOTPコードでセッションを作成する", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, challenge, token := setupChallenge(t, m)
+		totp := enabledTOTP(t, user.ID())
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(totp, nil)
+		m.userTOTPRepo.EXPECT().Save(gomock.Any(), totp).Return(nil)
+		m.twoFactorChallengeRepo.EXPECT().MarkUsed(gomock.Any(), challenge).Return(nil)
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := uc.LoginWithTOTP(context.Background(), token, totp.Secret().Code(time.Now()), testSessionClient)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Session == nil {
+			t.Fatal("Session should be created")
+		}
+		if output.Session.IPAddress() != testSessionClient.IPAddress {
+			t.Errorf("IPAddress = %s, want %s", output.Session.IPAddress(), testSessionClient.IPAddress)
+		}
+		if challenge.UsedAt() == nil {
+			t.Error("challenge should be marked as used")
+		}
+		if totp.LastUsedStep() == 0 {
+			t.Error("used time step should be recorded")
+		}
+	})
+
+	t.Run("正常系_リカバリーコードでセッションを作成する", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, challenge, token := setupChallenge(t, m)
+
+		codes, raws, _ := entity.NewRecoveryCodes(user.ID())
+
+		setupTxManagerExecute(m.txManager)
+		m.recoveryCodeRepo.EXPECT().FindByUserIDAndCodeHash(gomock.Any(), user.ID(), raws[0].Hash()).Return(codes[0], nil)
+		m.recoveryCodeRepo.EXPECT().MarkUsed(gomock.Any(), codes[0]).Return(nil)
+		m.twoFactorChallengeRepo.EXPECT().MarkUsed(gomock.Any(), challenge).Return(nil)
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		output, err := uc.LoginWithTOTP(context.Background(), token, raws[0].String(), testSessionClient)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Session == nil {
+			t.Fatal("Session should be created")
+		}
+		if codes[0].UsedAt() == nil {
+			t.Error("recovery code should be marked as used")
+		}
+	})
+
+	t.Run("異常系_使用済みのリカバリーコード", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, _, token := setupChallenge(t, m)
+
+		codes, raws, _ := entity.NewRecoveryCodes(user.ID())
+		_ = codes[0].Use()
+
+		setupTxManagerExecute(m.txManager)
+		m.recoveryCodeRepo.EXPECT().FindByUserIDAndCodeHash(gomock.Any(), user.ID(), raws[0].Hash()).Return(codes[0], nil)
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, raws[0].String(), testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+			t.Errorf("got %v, want ErrInvalidTOTPCode", err)
+		}
+	})
+
+	t.Run("異常系_コード不一致は失敗回数に数える", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, challenge, token := setupChallenge(t, m)
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(enabledTOTP(t, user.ID()), nil)
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, "abcdef", testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+			t.Errorf("got %v, want ErrInvalidTOTPCode", err)
+		}
+		if challenge.UsedAt() != nil {
+			t.Error("challenge should remain usable after a wrong code")
+		}
+		attempt, _ := m.loginAttemptStore.Find(context.Background(), entity.EmailLoginAttemptKey(user.Email()))
+		if attempt == nil || attempt.Failures() != 1 {
+			t.Error("wrong code should be recorded as a login failure")
+		}
+	})
+
+	t.Run("異常系_試行制限中はコードを検証しない", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, _, token := setupChallenge(t, m)
+
+		policy := entity.DefaultEmailLoginThrottlePolicy
+		for i := 0; i < policy.LockoutThreshold; i++ {
+			m.loginAttemptStore.RecordFailure(context.Background(), entity.EmailLoginAttemptKey(user.Email()), time.Now(), policy.ResetAfter)
+		}
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, "123456", testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrTooManyLoginAttempts) {
+			t.Errorf("got %v, want ErrTooManyLoginAttempts", err)
+		}
+	})
+
+	t.Run("異常系_トークンが存在しない", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		token, _ := vo.NewOneTimeToken()
+
+		m.twoFactorChallengeRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(nil, nil)
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, "123456", testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrTwoFactorTokenInvalid) {
+			t.Errorf("got %v, want ErrTwoFactorTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_トークンの有効期限切れ", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		token, _ := vo.NewOneTimeToken()
+		expired := entity.ReconstructTwoFactorChallenge(
+			vo.NewTwoFactorChallengeID().String(),
+			vo.NewUserID().String(),
+			token.Hash(),
+			time.Now().Add(-time.Second),
+			nil,
+			time.Now().Add(-entity.TwoFactorChallengeTTL),
+		)
+
+		m.twoFactorChallengeRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(expired, nil)
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, "123456", testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrTwoFactorTokenInvalid) {
+			t.Errorf("got %v, want ErrTwoFactorTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_チャレンジ発行後に2段階認証が無効化された", func(t *testing.T) {
+		m, uc := setupTwoFactorAuthMocks(t)
+		user, _, token := setupChallenge(t, m)
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+
+		_, err := uc.LoginWithTOTP(context.Background(), token, "123456", testSessionClient)
+
+		if !errors.Is(err, domainErrors.ErrTwoFactorTokenInvalid) {
+			t.Errorf("got %v, want ErrTwoFactorTokenInvalid", err)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+)
+
+// DailySummaryUsecase は日別サマリーの保守に関するユースケースを提供する
+type DailySummaryUsecase struct {
+	dailySummaryRepo repository.DailySummaryRepository
+	txManager        repository.TransactionManager
+}
+
+// NewDailySummaryUsecase は DailySummaryUsecase のインスタンスを生成する
+func NewDailySummaryUsecase(
+	dailySummaryRepo repository.DailySummaryRepository,
+	txManager repository.TransactionManager,
+) *DailySummaryUsecase {
+	return &DailySummaryUsecase{
+		dailySummaryRepo: dailySummaryRepo,
+		txManager:        txManager,
+	}
+}
+
+// RebuildAll は全ユーザーの日別サマリーを記録から作り直す
+// サマリー導入前の記録のバックフィルや、集計のずれを修正する際に使用する
+func (u *DailySummaryUsecase) RebuildAll(ctx context.Context) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := u.dailySummaryRepo.RebuildAll(txCtx); err != nil {
+			logError("RebuildAll", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// RebuildByUserID は指定ユーザーの日別サマリーを記録から作り直す
+func (u *DailySummaryUsecase) RebuildByUserID(ctx context.Context, userID vo.UserID) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := u.dailySummaryRepo.RebuildByUserID(txCtx, userID); err != nil {
+			logError("RebuildByUserID", err, "user_id", userID.String())
+			return err
+		}
+		return nil
+	})
+}
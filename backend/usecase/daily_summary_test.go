@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// setupDailySummaryMocks はテスト用のモックを初期化する
+func setupDailySummaryMocks(t *testing.T) (*mock.MockDailySummaryRepository, *mock.MockTransactionManager, *gomock.Controller) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	return mock.NewMockDailySummaryRepository(ctrl), mock.NewMockTransactionManager(ctrl), ctrl
+}
+
+func TestDailySummaryUsecase_RebuildAll(t *testing.T) {
+	t.Run("正常系_トランザクション内で全ユーザーのサマリーが再構築される", func(t *testing.T) {
+		dailySummaryRepo, txManager, ctrl := setupDailySummaryMocks(t)
+		defer ctrl.Finish()
+
+		setupTxManagerExecute(txManager)
+		dailySummaryRepo.EXPECT().RebuildAll(gomock.Any()).Return(nil)
+
+		uc := usecase.NewDailySummaryUsecase(dailySummaryRepo, txManager)
+		if err := uc.RebuildAll(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_再構築時にエラー", func(t *testing.T) {
+		dailySummaryRepo, txManager, ctrl := setupDailySummaryMocks(t)
+		defer ctrl.Finish()
+
+		repoErr := errors.New("db error")
+		setupTxManagerExecute(txManager)
+		dailySummaryRepo.EXPECT().RebuildAll(gomock.Any()).Return(repoErr)
+
+		uc := usecase.NewDailySummaryUsecase(dailySummaryRepo, txManager)
+		if err := uc.RebuildAll(context.Background()); !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
+
+func TestDailySummaryUsecase_RebuildByUserID(t *testing.T) {
+	t.Run("正常系_指定ユーザーのサマリーが再構築される", func(t *testing.T) {
+		dailySummaryRepo, txManager, ctrl := setupDailySummaryMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		setupTxManagerExecute(txManager)
+		dailySummaryRepo.EXPECT().RebuildByUserID(gomock.Any(), gomock.Eq(userID)).Return(nil)
+
+		uc := usecase.NewDailySummaryUsecase(dailySummaryRepo, txManager)
+		if err := uc.RebuildByUserID(context.Background(), userID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_再構築時にエラー", func(t *testing.T) {
+		dailySummaryRepo, txManager, ctrl := setupDailySummaryMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		repoErr := errors.New("db error")
+		setupTxManagerExecute(txManager)
+		dailySummaryRepo.EXPECT().RebuildByUserID(gomock.Any(), gomock.Eq(userID)).Return(repoErr)
+
+		uc := usecase.NewDailySummaryUsecase(dailySummaryRepo, txManager)
+		if err := uc.RebuildByUserID(context.Background(), userID); !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// ExportSchemaVersion はエクスポートするアーカイブの形式のバージョン
+// ファイル構成や項目を変更した場合は上げる
+const ExportSchemaVersion = 1
+
+// exportBatchSize はエクスポート時に1回のクエリで取得する件数
+const exportBatchSize = 500
+
+// エクスポートするアーカイブ内のファイル名
+const (
+	exportManifestFile    = "manifest.json"
+	exportProfileJSONFile = "profile.json"
+	exportProfileCSVFile  = "profile.csv"
+	exportRecordsJSONFile = "records.json"
+	exportRecordsCSVFile  = "records.csv"
+	exportAdviceJSONFile  = "advice.json"
+	exportAdviceCSVFile   = "advice.csv"
+)
+
+// ExportUsecase は個人データのエクスポートに関するユースケースを提供する
+type ExportUsecase struct {
+	userRepo        repository.UserRepository
+	recordRepo      repository.RecordRepository
+	recordPfcRepo   repository.RecordPfcRepository
+	adviceCacheRepo repository.AdviceCacheRepository
+	auditRecorder   service.AuditRecorder
+}
+
+// NewExportUsecase は ExportUsecase のインスタンスを生成する
+func NewExportUsecase(
+	userRepo repository.UserRepository,
+	recordRepo repository.RecordRepository,
+	recordPfcRepo repository.RecordPfcRepository,
+	adviceCacheRepo repository.AdviceCacheRepository,
+	auditRecorder service.AuditRecorder,
+) *ExportUsecase {
+	return &ExportUsecase{
+		userRepo:        userRepo,
+		recordRepo:      recordRepo,
+		recordPfcRepo:   recordPfcRepo,
+		adviceCacheRepo: adviceCacheRepo,
+		auditRecorder:   auditRecorder,
+	}
+}
+
+// ExportManifest はアーカイブの内容を説明するマニフェスト
+type ExportManifest struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	ExportedAt    time.Time            `json:"exportedAt"`
+	UserID        string               `json:"userId"`
+	Files         []ExportManifestFile `json:"files"`
+}
+
+// ExportManifestFile はアーカイブ内の1ファイルの説明
+// EntriesはJSONの場合は要素数、CSVの場合はヘッダーを除く行数
+type ExportManifestFile struct {
+	Name    string `json:"name"`
+	Format  string `json:"format"`
+	Entries int    `json:"entries"`
+}
+
+// exportProfile はプロフィールのエクスポート形式
+type exportProfile struct {
+	UserID          string                `json:"userId"`
+	Email           string                `json:"email"`
+	EmailVerifiedAt *time.Time            `json:"emailVerifiedAt"`
+	Nickname        string                `json:"nickname"`
+	WeightKg        float64               `json:"weightKg"`
+	HeightCm        float64               `json:"heightCm"`
+	BirthDate       string                `json:"birthDate"`
+	Gender          string                `json:"gender"`
+	ActivityLevel   string                `json:"activityLevel"`
+	BudgetMode      string                `json:"budgetMode"`
+	Privacy         exportPrivacySettings `json:"privacy"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+// exportPrivacySettings はプライバシー設定と同意状況のエクスポート形式
+type exportPrivacySettings struct {
+	AIProcessing   bool       `json:"aiProcessing"`
+	Analytics      bool       `json:"analytics"`
+	RetentionYears int        `json:"retentionYears"`
+	ConsentVersion string     `json:"consentVersion"`
+	ConsentedAt    *time.Time `json:"consentedAt"`
+}
+
+// exportRecord は食事記録のエクスポート形式
+type exportRecord struct {
+	ID            string             `json:"id"`
+	EatenAt       time.Time          `json:"eatenAt"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	TotalCalories int                `json:"totalCalories"`
+	Items         []exportRecordItem `json:"items"`
+	Pfc           *exportPfc         `json:"pfc"`
+}
+
+// exportRecordItem は食品のエクスポート形式
+type exportRecordItem struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Calories int    `json:"calories"`
+}
+
+// exportPfc はPFC（推定値）のエクスポート形式
+type exportPfc struct {
+	Protein float64 `json:"protein"`
+	Fat     float64 `json:"fat"`
+	Carbs   float64 `json:"carbs"`
+}
+
+// exportAdvice はアドバイス履歴のエクスポート形式
+type exportAdvice struct {
+	Date      string    `json:"date"`
+	Advice    string    `json:"advice"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Export はユーザーの個人データをZIPアーカイブとしてwに書き出す
+// プロフィール（体重・身長を含む）、全ての食事記録（食品・PFC）、アドバイス履歴を、JSONとCSVの両方の形式で含める
+// 記録とアドバイスはリポジトリから一定件数ずつ読み出して書き出し、全件をメモリに載せない
+// ユーザーが存在しない場合は、wに何も書き出さずに ErrUserNotFound を返す
+func (u *ExportUsecase) Export(ctx context.Context, userID vo.UserID, w io.Writer) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError("Export", err, "user_id", userID.String())
+		return err
+	}
+	if user == nil {
+		logWarn("Export", "user not found", "user_id", userID.String())
+		return domainErrors.ErrUserNotFound
+	}
+
+	manifest := ExportManifest{
+		SchemaVersion: ExportSchemaVersion,
+		ExportedAt:    time.Now(),
+		UserID:        userID.String(),
+	}
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name   string
+		format string
+		write  func(w io.Writer) (int, error)
+	}{
+		{exportProfileJSONFile, "json", func(w io.Writer) (int, error) { return writeProfileJSON(w, user) }},
+		{exportProfileCSVFile, "csv", func(w io.Writer) (int, error) { return writeProfileCSV(w, user) }},
+		{exportRecordsJSONFile, "json", func(w io.Writer) (int, error) { return u.writeRecordsJSON(ctx, w, userID) }},
+		{exportRecordsCSVFile, "csv", func(w io.Writer) (int, error) { return u.writeRecordsCSV(ctx, w, userID) }},
+		{exportAdviceJSONFile, "json", func(w io.Writer) (int, error) { return u.writeAdviceJSON(ctx, w, userID) }},
+		{exportAdviceCSVFile, "csv", func(w io.Writer) (int, error) { return u.writeAdviceCSV(ctx, w, userID) }},
+	}
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			logError("Export", err, "user_id", userID.String(), "file", file.name)
+			return err
+		}
+		entries, err := file.write(fw)
+		if err != nil {
+			logError("Export", err, "user_id", userID.String(), "file", file.name)
+			return err
+		}
+		manifest.Files = append(manifest.Files, ExportManifestFile{Name: file.name, Format: file.format, Entries: entries})
+	}
+
+	// 各ファイルの件数を含めるため、マニフェストは最後に書き出す
+	fw, err := zw.Create(exportManifestFile)
+	if err != nil {
+		logError("Export", err, "user_id", userID.String(), "file", exportManifestFile)
+		return err
+	}
+	if err := writeIndentedJSON(fw, manifest); err != nil {
+		logError("Export", err, "user_id", userID.String(), "file", exportManifestFile)
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		logError("Export", err, "user_id", userID.String())
+		return err
+	}
+
+	entries := make(map[string]any, len(manifest.Files))
+	for _, f := range manifest.Files {
+		entries[f.Name] = f.Entries
+	}
+	event := entity.NewUserAuditEvent(entity.AuditActionDataExport, userID, entity.AuditTargetUser, userID.String(), map[string]any{"files": entries})
+	// 書き出し後のため、監査ログの記録に失敗してもエクスポートは成功として扱う（エラーはRecord内でログ出力する）
+	_ = u.auditRecorder.Record(ctx, event)
+	return nil
+}
+
+// forEachRecord は全ての食事記録を食事日時の昇順で、PFC（未推定の場合はnil）と合わせてfnに渡す
+func (u *ExportUsecase) forEachRecord(ctx context.Context, userID vo.UserID, fn func(record *entity.Record, pfc *entity.RecordPfc) error) error {
+	return u.recordRepo.ForEachBatchByUserID(ctx, userID, exportBatchSize, func(records []*entity.Record) error {
+		recordIDs := make([]vo.RecordID, len(records))
+		for i, record := range records {
+			recordIDs[i] = record.ID()
+		}
+		pfcs, err := u.recordPfcRepo.FindByRecordIDs(ctx, recordIDs)
+		if err != nil {
+			return err
+		}
+		pfcByRecordID := make(map[string]*entity.RecordPfc, len(pfcs))
+		for _, pfc := range pfcs {
+			pfcByRecordID[pfc.RecordID().String()] = pfc
+		}
+
+		for _, record := range records {
+			if err := fn(record, pfcByRecordID[record.ID().String()]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeRecordsJSON は食事記録をJSON配列として書き出す
+func (u *ExportUsecase) writeRecordsJSON(ctx context.Context, w io.Writer, userID vo.UserID) (int, error) {
+	array := newJSONArrayWriter(w)
+	err := u.forEachRecord(ctx, userID, func(record *entity.Record, pfc *entity.RecordPfc) error {
+		return array.Write(toExportRecord(record, pfc))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return array.Close()
+}
+
+// writeRecordsCSV は食事記録を食品ごとに1行のCSVとして書き出す
+func (u *ExportUsecase) writeRecordsCSV(ctx context.Context, w io.Writer, userID vo.UserID) (int, error) {
+	cw := csv.NewWriter(w)
+	header := []string{"record_id", "eaten_at", "item_id", "item_name", "item_calories", "record_total_calories", "protein", "fat", "carbs"}
+	if err := cw.Write(header); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err := u.forEachRecord(ctx, userID, func(record *entity.Record, pfc *entity.RecordPfc) error {
+		protein, fat, carbs := "", "", ""
+		if pfc != nil {
+			protein, fat, carbs = formatFloat(pfc.Protein()), formatFloat(pfc.Fat()), formatFloat(pfc.Carbs())
+		}
+		for _, item := range record.Items() {
+			row := []string{
+				record.ID().String(),
+				record.EatenAt().Time().Format(time.RFC3339),
+				item.ID().String(),
+				item.Name().String(),
+				strconv.Itoa(item.Calories().Value()),
+				strconv.Itoa(record.TotalCalories()),
+				protein,
+				fat,
+				carbs,
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	cw.Flush()
+	return rows, cw.Error()
+}
+
+// writeAdviceJSON はアドバイス履歴をJSON配列として書き出す
+func (u *ExportUsecase) writeAdviceJSON(ctx context.Context, w io.Writer, userID vo.UserID) (int, error) {
+	array := newJSONArrayWriter(w)
+	err := u.adviceCacheRepo.ForEachBatchByUserID(ctx, userID, exportBatchSize, func(caches []*entity.AdviceCache) error {
+		for _, cache := range caches {
+			if err := array.Write(toExportAdvice(cache)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return array.Close()
+}
+
+// writeAdviceCSV はアドバイス履歴をCSVとして書き出す
+func (u *ExportUsecase) writeAdviceCSV(ctx context.Context, w io.Writer, userID vo.UserID) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "advice", "created_at"}); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err := u.adviceCacheRepo.ForEachBatchByUserID(ctx, userID, exportBatchSize, func(caches []*entity.AdviceCache) error {
+		for _, cache := range caches {
+			advice := toExportAdvice(cache)
+			if err := cw.Write([]string{advice.Date, advice.Advice, advice.CreatedAt.Format(time.RFC3339)}); err != nil {
+				return err
+			}
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	cw.Flush()
+	return rows, cw.Error()
+}
+
+// writeProfileJSON はプロフィールをJSONとして書き出す
+func writeProfileJSON(w io.Writer, user *entity.User) (int, error) {
+	if err := writeIndentedJSON(w, toExportProfile(user)); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// writeProfileCSV はプロフィールを1行のCSVとして書き出す
+func writeProfileCSV(w io.Writer, user *entity.User) (int, error) {
+	p := toExportProfile(user)
+	emailVerifiedAt := ""
+	if p.EmailVerifiedAt != nil {
+		emailVerifiedAt = p.EmailVerifiedAt.Format(time.RFC3339)
+	}
+	consentedAt := ""
+	if p.Privacy.ConsentedAt != nil {
+		consentedAt = p.Privacy.ConsentedAt.Format(time.RFC3339)
+	}
+
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"user_id", "email", "email_verified_at", "nickname", "weight_kg", "height_cm", "birth_date", "gender", "activity_level", "budget_mode", "ai_processing", "analytics", "retention_years", "privacy_consent_version", "privacy_consented_at", "created_at", "updated_at"},
+		{
+			p.UserID,
+			p.Email,
+			emailVerifiedAt,
+			p.Nickname,
+			formatFloat(p.WeightKg),
+			formatFloat(p.HeightCm),
+			p.BirthDate,
+			p.Gender,
+			p.ActivityLevel,
+			p.BudgetMode,
+			strconv.FormatBool(p.Privacy.AIProcessing),
+			strconv.FormatBool(p.Privacy.Analytics),
+			strconv.Itoa(p.Privacy.RetentionYears),
+			p.Privacy.ConsentVersion,
+			consentedAt,
+			p.CreatedAt.Format(time.RFC3339),
+			p.UpdatedAt.Format(time.RFC3339),
+		},
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// toExportProfile はユーザーをエクスポート形式に変換する
+func toExportProfile(user *entity.User) exportProfile {
+	privacy := user.PrivacySettings()
+	return exportProfile{
+		UserID:          user.ID().String(),
+		Email:           user.Email().String(),
+		EmailVerifiedAt: user.EmailVerifiedAt(),
+		Nickname:        user.Nickname().String(),
+		WeightKg:        user.Weight().Kg(),
+		HeightCm:        user.Height().Cm(),
+		BirthDate:       user.BirthDate().Time().Format(dateLayout),
+		Gender:          user.Gender().String(),
+		ActivityLevel:   user.ActivityLevel().String(),
+		BudgetMode:      user.BudgetMode().String(),
+		Privacy: exportPrivacySettings{
+			AIProcessing:   privacy.AIProcessingEnabled(),
+			Analytics:      privacy.AnalyticsEnabled(),
+			RetentionYears: privacy.RetentionYears(),
+			ConsentVersion: privacy.ConsentVersion(),
+			ConsentedAt:    privacy.ConsentedAt(),
+		},
+		CreatedAt: user.CreatedAt(),
+		UpdatedAt: user.UpdatedAt(),
+	}
+}
+
+// toExportRecord は食事記録とPFCをエクスポート形式に変換する
+func toExportRecord(record *entity.Record, pfc *entity.RecordPfc) exportRecord {
+	items := make([]exportRecordItem, len(record.Items()))
+	for i, item := range record.Items() {
+		items[i] = exportRecordItem{
+			ID:       item.ID().String(),
+			Name:     item.Name().String(),
+			Calories: item.Calories().Value(),
+		}
+	}
+
+	r := exportRecord{
+		ID:            record.ID().String(),
+		EatenAt:       record.EatenAt().Time(),
+		CreatedAt:     record.CreatedAt(),
+		TotalCalories: record.TotalCalories(),
+		Items:         items,
+	}
+	if pfc != nil {
+		r.Pfc = &exportPfc{Protein: pfc.Protein(), Fat: pfc.Fat(), Carbs: pfc.Carbs()}
+	}
+	return r
+}
+
+// toExportAdvice はアドバイスをエクスポート形式に変換する
+func toExportAdvice(cache *entity.AdviceCache) exportAdvice {
+	return exportAdvice{
+		Date:      cache.CacheDate().Format(dateLayout),
+		Advice:    cache.Advice(),
+		CreatedAt: cache.CreatedAt(),
+	}
+}
+
+// formatFloat は数値をCSV用の文字列に変換する
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// writeIndentedJSON は値を整形したJSONとして書き出す
+func writeIndentedJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// jsonArrayWriter は要素を1件ずつJSON配列として書き出す
+type jsonArrayWriter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	count int
+}
+
+// newJSONArrayWriter は新しいjsonArrayWriterを生成する
+func newJSONArrayWriter(w io.Writer) *jsonArrayWriter {
+	return &jsonArrayWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// Write は配列の要素を1件書き出す
+func (a *jsonArrayWriter) Write(v any) error {
+	sep := ","
+	if a.count == 0 {
+		sep = "["
+	}
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		return err
+	}
+	if err := a.enc.Encode(v); err != nil {
+		return err
+	}
+	a.count++
+	return nil
+}
+
+// Close は配列を閉じ、書き出した要素数を返す
+func (a *jsonArrayWriter) Close() (int, error) {
+	end := "]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	if _, err := io.WriteString(a.w, end); err != nil {
+		return 0, err
+	}
+	return a.count, nil
+}
@@ -0,0 +1,239 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// exportMocks はExport Usecaseのテスト用モックをまとめる
+type exportMocks struct {
+	userRepo        *mock.MockUserRepository
+	recordRepo      *mock.MockRecordRepository
+	recordPfcRepo   *mock.MockRecordPfcRepository
+	adviceCacheRepo *mock.MockAdviceCacheRepository
+	auditEvents     *auditEvents
+}
+
+// setupExportMocks はモックとExportUsecaseを初期化する
+func setupExportMocks(t *testing.T) (*exportMocks, *usecase.ExportUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	m := &exportMocks{
+		userRepo:        mock.NewMockUserRepository(ctrl),
+		recordRepo:      mock.NewMockRecordRepository(ctrl),
+		recordPfcRepo:   mock.NewMockRecordPfcRepository(ctrl),
+		adviceCacheRepo: mock.NewMockAdviceCacheRepository(ctrl),
+		auditEvents:     auditEvents,
+	}
+	return m, usecase.NewExportUsecase(m.userRepo, m.recordRepo, m.recordPfcRepo, m.adviceCacheRepo, auditRecorder)
+}
+
+// readZipFile はアーカイブから指定ファイルの内容を読み出す
+func readZipFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	t.Helper()
+	f, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", name, err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return b
+}
+
+func TestExportUsecase_Export(t *testing.T) {
+	t.Run("正常系_プロフィール・記録・アドバイスをJSONとCSVで書き出す", func(t *testing.T) {
+		m, uc := setupExportMocks(t)
+		user := validUser(t)
+
+		record1, _ := entity.NewRecord(user.ID(), time.Now().Add(-2*time.Hour))
+		_ = record1.AddItem("ご飯", 250)
+		_ = record1.AddItem("味噌汁", 50)
+		record2, _ := entity.NewRecord(user.ID(), time.Now().Add(-1*time.Hour))
+		_ = record2.AddItem("りんご", 80)
+		pfc := entity.NewRecordPfc(record1.ID(), 10.5, 3, 60)
+		advice := entity.NewAdviceCache(user.ID(), time.Now(), "野菜を増やしましょう")
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		// 記録はバッチごとに2回に分けて返す（JSONとCSVで2回読み出す）
+		m.recordRepo.EXPECT().ForEachBatchByUserID(gomock.Any(), user.ID(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID vo.UserID, batchSize int, fn func([]*entity.Record) error) error {
+				if err := fn([]*entity.Record{record1}); err != nil {
+					return err
+				}
+				return fn([]*entity.Record{record2})
+			}).
+			Times(2)
+		m.recordPfcRepo.EXPECT().FindByRecordIDs(gomock.Any(), []vo.RecordID{record1.ID()}).Return([]*entity.RecordPfc{pfc}, nil).Times(2)
+		m.recordPfcRepo.EXPECT().FindByRecordIDs(gomock.Any(), []vo.RecordID{record2.ID()}).Return(nil, nil).Times(2)
+		m.adviceCacheRepo.EXPECT().ForEachBatchByUserID(gomock.Any(), user.ID(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID vo.UserID, batchSize int, fn func([]*entity.AdviceCache) error) error {
+				return fn([]*entity.AdviceCache{advice})
+			}).
+			Times(2)
+
+		var buf bytes.Buffer
+		if err := uc.Export(context.Background(), user.ID(), &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("failed to open zip: %v", err)
+		}
+
+		var manifest usecase.ExportManifest
+		if err := json.Unmarshal(readZipFile(t, archive, "manifest.json"), &manifest); err != nil {
+			t.Fatalf("failed to parse manifest: %v", err)
+		}
+		if manifest.SchemaVersion != usecase.ExportSchemaVersion {
+			t.Errorf("SchemaVersion = %d, want %d", manifest.SchemaVersion, usecase.ExportSchemaVersion)
+		}
+		entries := make(map[string]int)
+		for _, f := range manifest.Files {
+			entries[f.Name] = f.Entries
+		}
+		wantEntries := map[string]int{
+			"profile.json": 1, "profile.csv": 1,
+			"records.json": 2, "records.csv": 3,
+			"advice.json": 1, "advice.csv": 1,
+		}
+		for name, want := range wantEntries {
+			if entries[name] != want {
+				t.Errorf("manifest entries[%s] = %d, want %d", name, entries[name], want)
+			}
+		}
+
+		var profile map[string]any
+		if err := json.Unmarshal(readZipFile(t, archive, "profile.json"), &profile); err != nil {
+			t.Fatalf("failed to parse profile: %v", err)
+		}
+		if profile["email"] != "test@example.com" || profile["weightKg"] != 70.5 {
+			t.Errorf("profile = %v, want email and weight", profile)
+		}
+		if privacy, ok := profile["privacy"].(map[string]any); !ok || privacy["aiProcessing"] != true {
+			t.Errorf("profile.privacy = %v, want aiProcessing true", profile["privacy"])
+		}
+
+		var records []struct {
+			ID    string `json:"id"`
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			Pfc *struct {
+				Protein float64 `json:"protein"`
+			} `json:"pfc"`
+		}
+		if err := json.Unmarshal(readZipFile(t, archive, "records.json"), &records); err != nil {
+			t.Fatalf("failed to parse records: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("len(records) = %d, want 2", len(records))
+		}
+		if len(records[0].Items) != 2 || records[0].Pfc == nil || records[0].Pfc.Protein != 10.5 {
+			t.Errorf("records[0] = %+v, want 2 items with pfc", records[0])
+		}
+		if records[1].Pfc != nil {
+			t.Error("records[1].Pfc should be null")
+		}
+
+		rows, err := csv.NewReader(bytes.NewReader(readZipFile(t, archive, "records.csv"))).ReadAll()
+		if err != nil {
+			t.Fatalf("failed to parse records.csv: %v", err)
+		}
+		if len(rows) != 4 {
+			t.Fatalf("len(rows) = %d, want 4 (header + 3 items)", len(rows))
+		}
+		if rows[1][3] != "ご飯" || rows[1][6] != "10.5" || rows[3][6] != "" {
+			t.Errorf("rows = %v, want item names and pfc", rows)
+		}
+
+		var advices []map[string]any
+		if err := json.Unmarshal(readZipFile(t, archive, "advice.json"), &advices); err != nil {
+			t.Fatalf("failed to parse advice: %v", err)
+		}
+		if len(advices) != 1 || advices[0]["advice"] != "野菜を増やしましょう" {
+			t.Errorf("advices = %v", advices)
+		}
+
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionDataExport {
+			t.Errorf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionDataExport)
+		}
+	})
+
+	t.Run("正常系_記録がない場合は空の配列を書き出す", func(t *testing.T) {
+		m, uc := setupExportMocks(t)
+		user := validUser(t)
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.recordRepo.EXPECT().ForEachBatchByUserID(gomock.Any(), user.ID(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		m.adviceCacheRepo.EXPECT().ForEachBatchByUserID(gomock.Any(), user.ID(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		var buf bytes.Buffer
+		if err := uc.Export(context.Background(), user.ID(), &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("failed to open zip: %v", err)
+		}
+		var records []any
+		if err := json.Unmarshal(readZipFile(t, archive, "records.json"), &records); err != nil {
+			t.Fatalf("failed to parse records: %v", err)
+		}
+		if records == nil || len(records) != 0 {
+			t.Errorf("records = %v, want empty array", records)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない場合は何も書き出さない", func(t *testing.T) {
+		m, uc := setupExportMocks(t)
+		userID := vo.NewUserID()
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		var buf bytes.Buffer
+		err := uc.Export(context.Background(), userID, &buf)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("written %d bytes, want 0", buf.Len())
+		}
+	})
+
+	t.Run("異常系_記録の読み出しに失敗した場合はエラーを返す", func(t *testing.T) {
+		m, uc := setupExportMocks(t)
+		user := validUser(t)
+		dbErr := errors.New("db error")
+
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.recordRepo.EXPECT().ForEachBatchByUserID(gomock.Any(), user.ID(), gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := uc.Export(context.Background(), user.ID(), io.Discard)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want dbErr", err)
+		}
+	})
+}
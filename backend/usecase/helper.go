@@ -6,6 +6,9 @@ import (
 	"caltrack/domain/helper"
 )
 
+// dateLayout は日付単位の比較に使用するレイアウト
+const dateLayout = "2006-01-02"
+
 func startOfDay(t time.Time) time.Time {
 	jstTime := t.In(helper.JST())
 	return time.Date(jstTime.Year(), jstTime.Month(), jstTime.Day(), 0, 0, 0, 0, helper.JST())
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/domain/repository"
+)
+
+// jobLockTTL はジョブのロックの有効期限
+// 実行中のプロセスが異常終了した場合でも、この時間が過ぎれば他のプロセスが実行できる
+// ジョブの実行時間はこれより短くすること（超えると他のプロセスが重複して実行する可能性がある）
+const jobLockTTL = time.Hour
+
+// jobPollInterval は実行すべきジョブがあるかを確認する間隔
+const jobPollInterval = time.Minute
+
+// Job はAPIサーバーのプロセス内で定期的に実行するジョブ
+type Job interface {
+	// Name はジョブの名前を返す（実行履歴とロックのキーになる）
+	Name() string
+	// Run はジョブを実行し、テーブル名ごとの処理件数を返す
+	// エラーの場合も途中まで処理した件数を返す
+	Run(ctx context.Context, now time.Time) (map[string]int64, error)
+}
+
+// scheduledJob は登録されたジョブと実行間隔
+type scheduledJob struct {
+	job      Job
+	interval time.Duration
+}
+
+// JobRunner は登録されたジョブを実行間隔ごとに実行する
+// 複数台構成でも同じジョブを同時に実行しないよう、DBのロックを取得してから実行する
+// 実行のたびに実行履歴を記録し、前回の開始日時から実行間隔が過ぎているかの判定にも使う（再起動しても間隔を保つ）
+type JobRunner struct {
+	jobRunRepo  repository.JobRunRepository
+	jobLockRepo repository.JobLockRepository
+	owner       string
+	jobs        []scheduledJob
+}
+
+// NewJobRunner は JobRunner のインスタンスを生成する
+// ownerはロックの持ち主と実行履歴に記録するプロセスの識別子（ホスト名とPIDなど）
+func NewJobRunner(jobRunRepo repository.JobRunRepository, jobLockRepo repository.JobLockRepository, owner string) *JobRunner {
+	return &JobRunner{
+		jobRunRepo:  jobRunRepo,
+		jobLockRepo: jobLockRepo,
+		owner:       owner,
+	}
+}
+
+// Register はジョブを実行間隔とともに登録する
+func (r *JobRunner) Register(job Job, interval time.Duration) {
+	r.jobs = append(r.jobs, scheduledJob{job: job, interval: interval})
+}
+
+// Start はctxがキャンセルされるまで、実行すべきジョブを定期的に実行する
+// 起動直後にも1回確認する。goroutineで呼び出すこと
+func (r *JobRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_ = r.RunDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue は前回の開始から実行間隔が過ぎたジョブを登録順に実行する
+// 失敗したジョブがあっても残りのジョブは実行する
+func (r *JobRunner) RunDue(ctx context.Context, now time.Time) error {
+	var errs []error
+	for _, scheduled := range r.jobs {
+		if err := r.runIfDue(ctx, scheduled, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runIfDue はロックを取得できた場合に、実行間隔が過ぎていればジョブを実行する
+// 他のプロセスが実行した直後に重複して実行しないよう、ロックを取得してから実行履歴を確認する
+func (r *JobRunner) runIfDue(ctx context.Context, scheduled scheduledJob, now time.Time) error {
+	name := scheduled.job.Name()
+
+	acquired, err := r.jobLockRepo.TryAcquire(ctx, name, r.owner, now, jobLockTTL)
+	if err != nil {
+		logError("runIfDue", err, "job", name)
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer func() {
+		if err := r.jobLockRepo.Release(ctx, name, r.owner); err != nil {
+			logError("runIfDue", err, "job", name)
+		}
+	}()
+
+	latest, err := r.jobRunRepo.FindLatestByJobName(ctx, name)
+	if err != nil {
+		logError("runIfDue", err, "job", name)
+		return err
+	}
+	if latest != nil && now.Before(latest.StartedAt().Add(scheduled.interval)) {
+		return nil
+	}
+
+	return r.run(ctx, scheduled.job, now)
+}
+
+// run はジョブを実行し、実行履歴を記録する
+func (r *JobRunner) run(ctx context.Context, job Job, now time.Time) error {
+	run := entity.NewJobRun(job.Name(), r.owner, now)
+	if err := r.jobRunRepo.Save(ctx, run); err != nil {
+		logError("run", err, "job", job.Name())
+		return err
+	}
+	logInfo("run", "job started", "job", job.Name(), "job_run_id", run.ID().String())
+
+	result, jobErr := runJob(ctx, job, now)
+	if jobErr != nil {
+		logError("run", jobErr, "job", job.Name(), "job_run_id", run.ID().String(), "result", result)
+		run.Fail(result, jobErr)
+	} else {
+		logInfo("run", "job finished", "job", job.Name(), "job_run_id", run.ID().String(), "result", result)
+		run.Succeed(result)
+	}
+
+	if err := r.jobRunRepo.Update(ctx, run); err != nil {
+		logError("run", err, "job", job.Name(), "job_run_id", run.ID().String())
+		return errors.Join(jobErr, err)
+	}
+	return jobErr
+}
+
+// runJob はジョブを実行する
+// ジョブのpanicでAPIサーバーが停止しないよう、panicはエラーとして返す
+func runJob(ctx context.Context, job Job, now time.Time) (result map[string]int64, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return job.Run(ctx, now)
+}
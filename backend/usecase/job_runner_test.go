@@ -0,0 +1,184 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+const testJobOwner = "host:1"
+
+// fakeJob はテスト用のJob
+type fakeJob struct {
+	name   string
+	result map[string]int64
+	err    error
+	panics bool
+	calls  int
+}
+
+func (j *fakeJob) Name() string { return j.name }
+
+func (j *fakeJob) Run(ctx context.Context, now time.Time) (map[string]int64, error) {
+	j.calls++
+	if j.panics {
+		panic("boom")
+	}
+	return j.result, j.err
+}
+
+// setupJobRunner はモックとJobRunnerを初期化する
+func setupJobRunner(t *testing.T) (*mock.MockJobRunRepository, *mock.MockJobLockRepository, *usecase.JobRunner) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	jobRunRepo := mock.NewMockJobRunRepository(ctrl)
+	jobLockRepo := mock.NewMockJobLockRepository(ctrl)
+	return jobRunRepo, jobLockRepo, usecase.NewJobRunner(jobRunRepo, jobLockRepo, testJobOwner)
+}
+
+// expectLock はロックの取得を期待する。取得できた場合は解放も期待する
+func expectLock(jobLockRepo *mock.MockJobLockRepository, name string, acquired bool) {
+	jobLockRepo.EXPECT().TryAcquire(gomock.Any(), name, testJobOwner, gomock.Any(), gomock.Any()).Return(acquired, nil)
+	if acquired {
+		jobLockRepo.EXPECT().Release(gomock.Any(), name, testJobOwner).Return(nil)
+	}
+}
+
+func TestJobRunner_RunDue(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	t.Run("正常系_初回は実行して成功を記録する", func(t *testing.T) {
+		jobRunRepo, jobLockRepo, runner := setupJobRunner(t)
+		job := &fakeJob{name: "retention", result: map[string]int64{"records": 3}}
+		runner.Register(job, 24*time.Hour)
+
+		expectLock(jobLockRepo, "retention", true)
+		jobRunRepo.EXPECT().FindLatestByJobName(gomock.Any(), "retention").Return(nil, nil)
+		jobRunRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+			if run.Status() != entity.JobRunStatusRunning || run.Owner() != testJobOwner || !run.StartedAt().Equal(now) {
+				t.Errorf("saved run = %+v, want running by %s at %v", run, testJobOwner, now)
+			}
+			return nil
+		})
+		jobRunRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+			if run.Status() != entity.JobRunStatusSucceeded {
+				t.Errorf("Status() = %v, want %v", run.Status(), entity.JobRunStatusSucceeded)
+			}
+			if run.Result()["records"] != 3 {
+				t.Errorf("Result()[records] = %d, want 3", run.Result()["records"])
+			}
+			return nil
+		})
+
+		if err := runner.RunDue(context.Background(), now); err != nil {
+			t.Fatalf("RunDue() error = %v", err)
+		}
+		if job.calls != 1 {
+			t.Errorf("job.calls = %d, want 1", job.calls)
+		}
+	})
+
+	t.Run("正常系_前回の開始から実行間隔が過ぎていなければ実行しない", func(t *testing.T) {
+		jobRunRepo, jobLockRepo, runner := setupJobRunner(t)
+		job := &fakeJob{name: "retention"}
+		runner.Register(job, 24*time.Hour)
+
+		expectLock(jobLockRepo, "retention", true)
+		latest := entity.NewJobRun("retention", "host:2", now.Add(-23*time.Hour))
+		jobRunRepo.EXPECT().FindLatestByJobName(gomock.Any(), "retention").Return(latest, nil)
+
+		if err := runner.RunDue(context.Background(), now); err != nil {
+			t.Fatalf("RunDue() error = %v", err)
+		}
+		if job.calls != 0 {
+			t.Errorf("job.calls = %d, want 0", job.calls)
+		}
+	})
+
+	t.Run("正常系_他のプロセスがロックを持っていれば実行しない", func(t *testing.T) {
+		_, jobLockRepo, runner := setupJobRunner(t)
+		job := &fakeJob{name: "retention"}
+		runner.Register(job, 24*time.Hour)
+
+		expectLock(jobLockRepo, "retention", false)
+
+		if err := runner.RunDue(context.Background(), now); err != nil {
+			t.Fatalf("RunDue() error = %v", err)
+		}
+		if job.calls != 0 {
+			t.Errorf("job.calls = %d, want 0", job.calls)
+		}
+	})
+
+	t.Run("異常系_失敗したジョブを記録し残りのジョブは実行する", func(t *testing.T) {
+		jobRunRepo, jobLockRepo, runner := setupJobRunner(t)
+		failing := &fakeJob{name: "retention", result: map[string]int64{"records": 1}, err: errors.New("db error")}
+		next := &fakeJob{name: "session_cleanup"}
+		runner.Register(failing, 24*time.Hour)
+		runner.Register(next, time.Hour)
+
+		expectLock(jobLockRepo, "retention", true)
+		expectLock(jobLockRepo, "session_cleanup", true)
+		jobRunRepo.EXPECT().FindLatestByJobName(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		jobRunRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		var statuses []entity.JobRunStatus
+		jobRunRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+			statuses = append(statuses, run.Status())
+			if run.Status() == entity.JobRunStatusFailed && run.ErrorMessage() != "db error" {
+				t.Errorf("ErrorMessage() = %q, want %q", run.ErrorMessage(), "db error")
+			}
+			return nil
+		}).Times(2)
+
+		if err := runner.RunDue(context.Background(), now); err == nil {
+			t.Fatal("RunDue() should return the job error")
+		}
+		if next.calls != 1 {
+			t.Errorf("next.calls = %d, want 1", next.calls)
+		}
+		if len(statuses) != 2 || statuses[0] != entity.JobRunStatusFailed || statuses[1] != entity.JobRunStatusSucceeded {
+			t.Errorf("statuses = %v, want [failed succeeded]", statuses)
+		}
+	})
+
+	t.Run("異常系_ジョブのpanicは失敗として記録する", func(t *testing.T) {
+		jobRunRepo, jobLockRepo, runner := setupJobRunner(t)
+		runner.Register(&fakeJob{name: "retention", panics: true}, 24*time.Hour)
+
+		expectLock(jobLockRepo, "retention", true)
+		jobRunRepo.EXPECT().FindLatestByJobName(gomock.Any(), "retention").Return(nil, nil)
+		jobRunRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		jobRunRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+			if run.Status() != entity.JobRunStatusFailed {
+				t.Errorf("Status() = %v, want %v", run.Status(), entity.JobRunStatusFailed)
+			}
+			return nil
+		})
+
+		if err := runner.RunDue(context.Background(), now); err == nil {
+			t.Fatal("RunDue() should return an error when the job panics")
+		}
+	})
+
+	t.Run("異常系_ロックの取得に失敗した場合は実行しない", func(t *testing.T) {
+		_, jobLockRepo, runner := setupJobRunner(t)
+		job := &fakeJob{name: "retention"}
+		runner.Register(job, 24*time.Hour)
+
+		jobLockRepo.EXPECT().TryAcquire(gomock.Any(), "retention", testJobOwner, gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+
+		if err := runner.RunDue(context.Background(), now); err == nil {
+			t.Fatal("RunDue() should fail when the lock cannot be acquired")
+		}
+		if job.calls != 0 {
+			t.Errorf("job.calls = %d, want 0", job.calls)
+		}
+	})
+}
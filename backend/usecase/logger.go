@@ -13,3 +13,9 @@ func logWarn(operation string, message string, fields ...any) {
 	args := append([]any{"layer", "usecase", "operation", operation}, fields...)
 	logger.Warn(message, args...)
 }
+
+// logInfo はUsecase層の記録すべき操作をログ出力する
+func logInfo(operation string, message string, fields ...any) {
+	args := append([]any{"layer", "usecase", "operation", operation}, fields...)
+	logger.Info(message, args...)
+}
@@ -26,12 +26,14 @@ type TodayPfcOutput struct {
 
 // NutritionUsecase は栄養分析に関するユースケースを提供する
 type NutritionUsecase struct {
-	userRepo        repository.UserRepository
-	recordRepo      repository.RecordRepository
-	recordPfcRepo   repository.RecordPfcRepository
-	adviceCacheRepo repository.AdviceCacheRepository
-	pfcAnalyzer     service.PfcAnalyzer
-	aiConfig        AIConfig
+	userRepo         repository.UserRepository
+	recordRepo       repository.RecordRepository
+	recordPfcRepo    repository.RecordPfcRepository
+	adviceCacheRepo  repository.AdviceCacheRepository
+	dailySummaryRepo repository.DailySummaryRepository
+	aiUsageRepo      repository.AIUsageRepository
+	pfcAnalyzer      service.PfcAnalyzer
+	aiConfig         AIConfig
 }
 
 // NewNutritionUsecase は NutritionUsecase のインスタンスを生成する
@@ -40,20 +42,25 @@ func NewNutritionUsecase(
 	recordRepo repository.RecordRepository,
 	recordPfcRepo repository.RecordPfcRepository,
 	adviceCacheRepo repository.AdviceCacheRepository,
+	dailySummaryRepo repository.DailySummaryRepository,
+	aiUsageRepo repository.AIUsageRepository,
 	pfcAnalyzer service.PfcAnalyzer,
 	aiConfig AIConfig,
 ) *NutritionUsecase {
 	return &NutritionUsecase{
-		userRepo:        userRepo,
-		recordRepo:      recordRepo,
-		recordPfcRepo:   recordPfcRepo,
-		adviceCacheRepo: adviceCacheRepo,
-		pfcAnalyzer:     pfcAnalyzer,
-		aiConfig:        aiConfig,
+		userRepo:         userRepo,
+		recordRepo:       recordRepo,
+		recordPfcRepo:    recordPfcRepo,
+		adviceCacheRepo:  adviceCacheRepo,
+		dailySummaryRepo: dailySummaryRepo,
+		aiUsageRepo:      aiUsageRepo,
+		pfcAnalyzer:      pfcAnalyzer,
+		aiConfig:         aiConfig,
 	}
 }
 
 // GetAdvice はユーザーに対する栄養アドバイスを取得する
+// プライバシー設定でAI機能をオフにしている場合は ErrAIProcessingDisabled を返す
 func (u *NutritionUsecase) GetAdvice(ctx context.Context, userID vo.UserID) (*service.NutritionAdviceOutput, error) {
 	// ユーザー取得
 	user, err := u.userRepo.FindByID(ctx, userID)
@@ -65,6 +72,9 @@ func (u *NutritionUsecase) GetAdvice(ctx context.Context, userID vo.UserID) (*se
 		logWarn("GetAdvice", "user not found", "user_id", userID.String())
 		return nil, domainErrors.ErrUserNotFound
 	}
+	if !user.AllowsAIProcessing() {
+		return nil, domainErrors.ErrAIProcessingDisabled
+	}
 
 	// 今日の日付範囲を計算
 	now := time.Now()
@@ -171,6 +181,7 @@ func (u *NutritionUsecase) GetAdvice(ctx context.Context, userID vo.UserID) (*se
 		logError("GetAdvice", err, "user_id", userID.String())
 		return nil, err
 	}
+	recordAIUsage(ctx, u.aiUsageRepo, userID, entity.AIFeatureNutritionAdvice, analyzerConfig.ModelName, output.Usage)
 
 	// キャッシュを保存
 	cache := entity.NewAdviceCache(userID, now, output.Advice)
@@ -200,19 +211,23 @@ func (u *NutritionUsecase) GetTodayPfc(ctx context.Context, userID vo.UserID) (*
 	start := startOfDay(now)
 	end := endOfDay(now)
 
-	// SQL集計でPFC合計を取得
-	dailyPfc, err := u.recordPfcRepo.GetDailyPfc(ctx, userID, start, end)
+	// 日別サマリーからPFC合計を取得（記録がない日はサマリーが存在しない）
+	summaries, err := u.dailySummaryRepo.FindByUserIDAndDateRange(ctx, userID, start, end)
 	if err != nil {
 		logError("GetTodayPfc", err, "user_id", userID.String())
 		return nil, err
 	}
+	currentPfc := vo.NewPfc(0, 0, 0)
+	if len(summaries) > 0 {
+		currentPfc = summaries[0].Pfc()
+	}
 
 	// 目標PFC計算
 	targetPfc := user.CalculateTargetPfc()
 
 	return &TodayPfcOutput{
 		Date:       start,
-		CurrentPfc: dailyPfc.Pfc,
+		CurrentPfc: currentPfc,
 		TargetPfc:  targetPfc,
 	}, nil
 }
@@ -56,7 +56,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).
 			Return([]*entity.Record{}, nil)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		output, err := uc.GetAdvice(context.Background(), userID)
 
 		if err != nil {
@@ -68,6 +68,25 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 		}
 	})
 
+	t.Run("異常系_AI機能をオフにしている場合はErrAIProcessingDisabledを返す", func(t *testing.T) {
+		userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig, ctrl := setupNutritionMocks(t)
+		defer ctrl.Finish()
+
+		user := aiDisabledUser(t)
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), user.ID()).
+			Return(user, nil)
+		// 記録の取得とアドバイスの生成は行わない（EXPECT未設定）
+
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
+		_, err := uc.GetAdvice(context.Background(), user.ID())
+
+		if !errors.Is(err, domainErrors.ErrAIProcessingDisabled) {
+			t.Errorf("got %v, want ErrAIProcessingDisabled", err)
+		}
+	})
+
 	t.Run("正常系_キャッシュがある場合はキャッシュが返される", func(t *testing.T) {
 		userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
@@ -97,7 +116,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 
 		// analyzer.Analyzeは呼ばれないこと（EXPECTを設定しないことで検証）
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		output, err := uc.GetAdvice(context.Background(), userID)
 
 		if err != nil {
@@ -164,7 +183,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 				return nil
 			})
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		output, err := uc.GetAdvice(context.Background(), userID)
 
 		if err != nil {
@@ -190,7 +209,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(nil, nil)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		_, err := uc.GetAdvice(context.Background(), userID)
 
 		if !errors.Is(err, domainErrors.ErrUserNotFound) {
@@ -209,7 +228,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(nil, repoErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		_, err := uc.GetAdvice(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -233,7 +252,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).
 			Return(nil, repoErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		_, err := uc.GetAdvice(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -268,7 +287,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			FindByRecordIDs(gomock.Any(), gomock.Any()).
 			Return(nil, repoErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		_, err := uc.GetAdvice(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -307,7 +326,7 @@ func TestNutritionUsecase_GetAdvice(t *testing.T) {
 			Analyze(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil, analyzeErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, analyzer, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, recordRepo, recordPfcRepo, adviceCacheRepo, nil, allowAIUsageSave(ctrl), analyzer, aiConfig)
 		_, err := uc.GetAdvice(context.Background(), userID)
 
 		if !errors.Is(err, analyzeErr) {
@@ -320,21 +339,22 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 	t.Run("正常系_今日のPFC摂取量と目標を取得", func(t *testing.T) {
 		userRepo, _, recordPfcRepo, _, _, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
+		dailySummaryRepo := mock.NewMockDailySummaryRepository(ctrl)
 
 		userID := vo.NewUserID()
 		user := validUserForRecord(t, userID)
 
-		dailyPfc := vo.NewDailyPfc(35.0, 25.0, 100.0)
+		summary := entity.ReconstructDailySummary(userID.String(), time.Now(), 800, 35.0, 25.0, 100.0, 2, time.Now())
 
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), userID).
 			Return(user, nil)
 
-		recordPfcRepo.EXPECT().
-			GetDailyPfc(gomock.Any(), userID, gomock.Any(), gomock.Any()).
-			Return(dailyPfc, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{summary}, nil)
 
-		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, nil, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, dailySummaryRepo, nil, nil, aiConfig)
 		output, err := uc.GetTodayPfc(context.Background(), userID)
 
 		if err != nil {
@@ -366,21 +386,20 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 	t.Run("正常系_記録がない場合はゼロPFCが返される", func(t *testing.T) {
 		userRepo, _, recordPfcRepo, _, _, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
+		dailySummaryRepo := mock.NewMockDailySummaryRepository(ctrl)
 
 		userID := vo.NewUserID()
 		user := validUserForRecord(t, userID)
 
-		dailyPfc := vo.NewDailyPfc(0, 0, 0)
-
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), userID).
 			Return(user, nil)
 
-		recordPfcRepo.EXPECT().
-			GetDailyPfc(gomock.Any(), userID, gomock.Any(), gomock.Any()).
-			Return(dailyPfc, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{}, nil)
 
-		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, nil, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, dailySummaryRepo, nil, nil, aiConfig)
 		output, err := uc.GetTodayPfc(context.Background(), userID)
 
 		if err != nil {
@@ -401,6 +420,7 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
 		userRepo, _, recordPfcRepo, _, _, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
+		dailySummaryRepo := mock.NewMockDailySummaryRepository(ctrl)
 
 		userID := vo.NewUserID()
 
@@ -408,7 +428,7 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(nil, nil)
 
-		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, nil, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, dailySummaryRepo, nil, nil, aiConfig)
 		_, err := uc.GetTodayPfc(context.Background(), userID)
 
 		if !errors.Is(err, domainErrors.ErrUserNotFound) {
@@ -419,6 +439,7 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 	t.Run("異常系_ユーザー取得時にエラー", func(t *testing.T) {
 		userRepo, _, recordPfcRepo, _, _, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
+		dailySummaryRepo := mock.NewMockDailySummaryRepository(ctrl)
 
 		userID := vo.NewUserID()
 		repoErr := errors.New("db error")
@@ -427,7 +448,7 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(nil, repoErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, nil, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, dailySummaryRepo, nil, nil, aiConfig)
 		_, err := uc.GetTodayPfc(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -435,9 +456,10 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 		}
 	})
 
-	t.Run("異常系_DailyPfc取得時にエラー", func(t *testing.T) {
+	t.Run("異常系_日別サマリー取得時にエラー", func(t *testing.T) {
 		userRepo, _, recordPfcRepo, _, _, aiConfig, ctrl := setupNutritionMocks(t)
 		defer ctrl.Finish()
+		dailySummaryRepo := mock.NewMockDailySummaryRepository(ctrl)
 
 		userID := vo.NewUserID()
 		user := validUserForRecord(t, userID)
@@ -447,11 +469,11 @@ func TestNutritionUsecase_GetTodayPfc(t *testing.T) {
 			FindByID(gomock.Any(), userID).
 			Return(user, nil)
 
-		recordPfcRepo.EXPECT().
-			GetDailyPfc(gomock.Any(), userID, gomock.Any(), gomock.Any()).
-			Return(vo.DailyPfc{}, repoErr)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+			Return(nil, repoErr)
 
-		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, nil, aiConfig)
+		uc := usecase.NewNutritionUsecase(userRepo, nil, recordPfcRepo, nil, dailySummaryRepo, nil, nil, aiConfig)
 		_, err := uc.GetTodayPfc(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
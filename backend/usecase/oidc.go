@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// OIDCUsecase はOpenID Connectによるログイン・新規登録に関するユースケースを提供する
+type OIDCUsecase struct {
+	providers              map[string]service.OIDCProvider
+	userRepo               repository.UserRepository
+	userIdentityRepo       repository.UserIdentityRepository
+	authRequestRepo        repository.OIDCAuthRequestRepository
+	signupRepo             repository.OIDCSignupRepository
+	sessionRepo            repository.SessionRepository
+	targetSnapshotRepo     repository.TargetSnapshotRepository
+	userTOTPRepo           repository.UserTOTPRepository
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository
+	accountDeletionRepo    repository.AccountDeletionRepository
+	txManager              repository.TransactionManager
+	auditRecorder          service.AuditRecorder
+}
+
+// NewOIDCUsecase は OIDCUsecase のインスタンスを生成する
+// providersは有効なプロバイダーの一覧で、Name() をURLのプロバイダー名として使う
+func NewOIDCUsecase(
+	providers []service.OIDCProvider,
+	userRepo repository.UserRepository,
+	userIdentityRepo repository.UserIdentityRepository,
+	authRequestRepo repository.OIDCAuthRequestRepository,
+	signupRepo repository.OIDCSignupRepository,
+	sessionRepo repository.SessionRepository,
+	targetSnapshotRepo repository.TargetSnapshotRepository,
+	userTOTPRepo repository.UserTOTPRepository,
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository,
+	accountDeletionRepo repository.AccountDeletionRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+) *OIDCUsecase {
+	providerMap := make(map[string]service.OIDCProvider, len(providers))
+	for _, p := range providers {
+		providerMap[p.Name()] = p
+	}
+	return &OIDCUsecase{
+		providers:              providerMap,
+		userRepo:               userRepo,
+		userIdentityRepo:       userIdentityRepo,
+		authRequestRepo:        authRequestRepo,
+		signupRepo:             signupRepo,
+		sessionRepo:            sessionRepo,
+		targetSnapshotRepo:     targetSnapshotRepo,
+		userTOTPRepo:           userTOTPRepo,
+		twoFactorChallengeRepo: twoFactorChallengeRepo,
+		accountDeletionRepo:    accountDeletionRepo,
+		txManager:              txManager,
+		auditRecorder:          auditRecorder,
+	}
+}
+
+// OIDCStartOutput はログイン開始処理の出力を表す
+// State はコールバックでの照合のためブラウザ（Cookie）にも保持させる
+type OIDCStartOutput struct {
+	AuthURL string
+	State   vo.OneTimeToken
+}
+
+// OIDCCallbackOutput はコールバック処理の出力を表す
+// SignupRequired が true の場合はユーザーが存在せず（Loginはnil）、
+// SignupToken を使って CompleteSignup で登録を完了する
+type OIDCCallbackOutput struct {
+	Login           *LoginOutput
+	SignupRequired  bool
+	SignupToken     vo.OneTimeToken
+	SignupEmail     string
+	SignupExpiresAt time.Time
+}
+
+// OIDCSignupProfile はOpenID Connectでの新規登録時に入力するプロフィール
+type OIDCSignupProfile struct {
+	Nickname      vo.Nickname
+	Weight        vo.Weight
+	Height        vo.Height
+	BirthDate     vo.BirthDate
+	Gender        vo.Gender
+	ActivityLevel vo.ActivityLevel
+}
+
+// StartLogin は認可リクエストを作成し、プロバイダーの認可エンドポイントのURLを返す
+// state・nonce・PKCEの code_verifier はサーバー側に保存し、コールバックで検証する
+func (u *OIDCUsecase) StartLogin(ctx context.Context, providerName string) (*OIDCStartOutput, error) {
+	provider, err := u.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	req, state, err := entity.NewOIDCAuthRequest(providerName)
+	if err != nil {
+		logError("StartLogin", err, "provider", providerName)
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state.String(), req.Nonce(), req.CodeChallenge())
+	if err != nil {
+		logError("StartLogin", err, "provider", providerName)
+		return nil, err
+	}
+
+	if err := u.authRequestRepo.Save(ctx, req); err != nil {
+		logError("StartLogin", err, "provider", providerName)
+		return nil, err
+	}
+
+	return &OIDCStartOutput{AuthURL: authURL, State: state}, nil
+}
+
+// HandleCallback は認可コードをIDトークンに交換し、連携済みのユーザーでログインする
+// 連携が無い場合は、プロバイダーで確認済みのメールアドレスに一致するユーザーに連携してログインする
+// 一致するユーザーも無い場合は、新規登録用のトークンを発行する
+// 2段階認証が有効なユーザーの場合は、パスワードでのログインと同じく2段階認証トークンを発行する
+func (u *OIDCUsecase) HandleCallback(ctx context.Context, providerName string, state vo.OneTimeToken, code string, client entity.SessionClient) (*OIDCCallbackOutput, error) {
+	provider, err := u.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// state は1回限り有効とするため、トークン交換の成否に関わらず先に使用済みにする
+	var authRequest *entity.OIDCAuthRequest
+	err = u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		req, err := u.authRequestRepo.FindByStateHash(txCtx, state.Hash())
+		if err != nil {
+			logError("HandleCallback", err, "provider", providerName)
+			return err
+		}
+		if req == nil {
+			logWarn("HandleCallback", "auth request not found", "provider", providerName)
+			return domainErrors.ErrOIDCStateInvalid
+		}
+		if err := req.Use(providerName); err != nil {
+			logWarn("HandleCallback", err.Error(), "provider", providerName)
+			return err
+		}
+		if err := u.authRequestRepo.MarkUsed(txCtx, req); err != nil {
+			logError("HandleCallback", err, "provider", providerName)
+			return err
+		}
+		authRequest = req
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := provider.Exchange(ctx, code, authRequest.CodeVerifier(), authRequest.Nonce())
+	if err != nil {
+		logWarn("HandleCallback", err.Error(), "provider", providerName)
+		return nil, err
+	}
+
+	var output *OIDCCallbackOutput
+	err = u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.findOrLinkUser(txCtx, providerName, claims)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			email, err := vo.NewEmail(claims.Email)
+			if err != nil {
+				logWarn("HandleCallback", "invalid email from provider", "provider", providerName)
+				return domainErrors.ErrOIDCEmailNotVerified
+			}
+			signup, token, err := entity.NewOIDCSignup(providerName, claims.Subject, email)
+			if err != nil {
+				logError("HandleCallback", err, "provider", providerName)
+				return err
+			}
+			if err := u.signupRepo.Save(txCtx, signup); err != nil {
+				logError("HandleCallback", err, "provider", providerName)
+				return err
+			}
+			output = &OIDCCallbackOutput{
+				SignupRequired:  true,
+				SignupToken:     token,
+				SignupEmail:     signup.Email(),
+				SignupExpiresAt: signup.ExpiresAt(),
+			}
+			return nil
+		}
+
+		login, err := u.login(txCtx, user, client)
+		if err != nil {
+			return err
+		}
+		output = &OIDCCallbackOutput{Login: login}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// findOrLinkUser はIDトークンのクレームに対応するユーザーを返す
+// 連携済みであればそのユーザーを、未連携でメールアドレスが一致するユーザーがいれば連携して返す
+// どちらにも該当しない場合はnilを返す
+func (u *OIDCUsecase) findOrLinkUser(ctx context.Context, providerName string, claims *service.OIDCClaims) (*entity.User, error) {
+	identity, err := u.userIdentityRepo.FindByProviderSubject(ctx, providerName, claims.Subject)
+	if err != nil {
+		logError("findOrLinkUser", err, "provider", providerName)
+		return nil, err
+	}
+	if identity != nil {
+		user, err := u.userRepo.FindByID(ctx, identity.UserID())
+		if err != nil {
+			logError("findOrLinkUser", err, "user_id", identity.UserID().String())
+			return nil, err
+		}
+		if user == nil {
+			logWarn("findOrLinkUser", "linked user not found", "user_id", identity.UserID().String())
+			return nil, domainErrors.ErrUserNotFound
+		}
+		return user, nil
+	}
+
+	// 未確認のメールアドレスで既存アカウントに連携すると乗っ取りに使えるため、確認済みの場合のみ扱う
+	if !claims.EmailVerified {
+		logWarn("findOrLinkUser", "email not verified by provider", "provider", providerName)
+		return nil, domainErrors.ErrOIDCEmailNotVerified
+	}
+	email, err := vo.NewEmail(claims.Email)
+	if err != nil {
+		logWarn("findOrLinkUser", "invalid email from provider", "provider", providerName)
+		return nil, domainErrors.ErrOIDCEmailNotVerified
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		logError("findOrLinkUser", err, "email", email.String())
+		return nil, err
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	// 既存アカウント側のメールアドレスが未確認の場合、第三者が先に登録した可能性があるため連携しない
+	if !user.IsEmailVerified() {
+		logWarn("findOrLinkUser", "existing user email not verified", "user_id", user.ID().String())
+		return nil, domainErrors.ErrEmailAlreadyExists
+	}
+
+	identity = entity.NewUserIdentity(user.ID(), providerName, claims.Subject, email.String())
+	if err := u.userIdentityRepo.Save(ctx, identity); err != nil {
+		logError("findOrLinkUser", err, "user_id", user.ID().String())
+		return nil, err
+	}
+	return user, nil
+}
+
+// CompleteSignup は HandleCallback で発行した登録トークンとプロフィールでユーザーを作成し、ログインする
+// 作成したユーザーはパスワード未設定で、メールアドレスは確認済みとなる
+func (u *OIDCUsecase) CompleteSignup(ctx context.Context, token vo.OneTimeToken, profile OIDCSignupProfile, client entity.SessionClient) (*LoginOutput, error) {
+	var output *LoginOutput
+
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		signup, err := u.signupRepo.FindByTokenHash(txCtx, token.Hash())
+		if err != nil {
+			logError("CompleteSignup", err)
+			return err
+		}
+		if signup == nil {
+			logWarn("CompleteSignup", "signup not found")
+			return domainErrors.ErrOIDCSignupTokenInvalid
+		}
+		if err := signup.Use(); err != nil {
+			logWarn("CompleteSignup", err.Error(), "oidc_signup_id", signup.ID().String())
+			return err
+		}
+		if err := u.signupRepo.MarkUsed(txCtx, signup); err != nil {
+			logError("CompleteSignup", err, "oidc_signup_id", signup.ID().String())
+			return err
+		}
+
+		email, err := vo.NewEmail(signup.Email())
+		if err != nil {
+			logError("CompleteSignup", err, "oidc_signup_id", signup.ID().String())
+			return err
+		}
+
+		// 登録待ちの間に同じメールアドレスで登録された場合
+		exists, err := u.userRepo.ExistsByEmail(txCtx, email)
+		if err != nil {
+			logError("CompleteSignup", err, "email", email.String())
+			return err
+		}
+		if exists {
+			logWarn("CompleteSignup", "email already exists", "email", email.String())
+			return domainErrors.ErrEmailAlreadyExists
+		}
+
+		user := entity.NewUserWithoutPassword(
+			email,
+			profile.Nickname,
+			profile.Weight,
+			profile.Height,
+			profile.BirthDate,
+			profile.Gender,
+			profile.ActivityLevel,
+		)
+		if err := u.userRepo.Save(txCtx, user); err != nil {
+			logError("CompleteSignup", err, "user_id", user.ID().String())
+			return err
+		}
+
+		// 登録時点の目標値を記録
+		snapshot := entity.NewTargetSnapshot(user, startOfDay(user.CreatedAt()))
+		if err := u.targetSnapshotRepo.Save(txCtx, snapshot); err != nil {
+			logError("CompleteSignup", err, "user_id", user.ID().String())
+			return err
+		}
+
+		identity := entity.NewUserIdentity(user.ID(), signup.Provider(), signup.Subject(), signup.Email())
+		if err := u.userIdentityRepo.Save(txCtx, identity); err != nil {
+			logError("CompleteSignup", err, "user_id", user.ID().String())
+			return err
+		}
+
+		session, err := u.createSession(txCtx, user, client)
+		if err != nil {
+			return err
+		}
+		output = &LoginOutput{Session: session, User: user}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// login はユーザーのセッションを作成する
+// 2段階認証が有効な場合はセッションの代わりに2段階認証トークンを発行する
+// 管理者に停止されたアカウントは ErrAccountDisabled を返す
+func (u *OIDCUsecase) login(ctx context.Context, user *entity.User, client entity.SessionClient) (*LoginOutput, error) {
+	if user.IsDisabled() {
+		logWarn("login", "account disabled", "user_id", user.ID().String())
+		return nil, domainErrors.ErrAccountDisabled
+	}
+
+	totp, err := u.userTOTPRepo.FindByUserID(ctx, user.ID())
+	if err != nil {
+		logError("login", err, "user_id", user.ID().String())
+		return nil, err
+	}
+	if totp != nil && totp.IsEnabled() {
+		challenge, token, err := entity.NewTwoFactorChallenge(user.ID())
+		if err != nil {
+			logError("login", err, "user_id", user.ID().String())
+			return nil, err
+		}
+		if err := u.twoFactorChallengeRepo.Save(ctx, challenge); err != nil {
+			logError("login", err, "user_id", user.ID().String())
+			return nil, err
+		}
+		return &LoginOutput{
+			User:               user,
+			TwoFactorRequired:  true,
+			TwoFactorToken:     token,
+			TwoFactorExpiresAt: challenge.ExpiresAt(),
+		}, nil
+	}
+
+	session, err := u.createSession(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginOutput{Session: session, User: user}, nil
+}
+
+// createSession はユーザーのセッションを作成して保存し、ログインの監査ログを記録する
+func (u *OIDCUsecase) createSession(ctx context.Context, user *entity.User, client entity.SessionClient) (*entity.Session, error) {
+	// 退会の猶予期間中であればログインで取り消す
+	if err := cancelAccountDeletion(ctx, u.accountDeletionRepo, user.ID()); err != nil {
+		return nil, err
+	}
+
+	session, err := entity.NewSessionWithUserID(user.ID())
+	if err != nil {
+		logError("createSession", err, "user_id", user.ID().String())
+		return nil, err
+	}
+	session.AttachClient(client)
+
+	if err := u.sessionRepo.Save(ctx, session); err != nil {
+		logError("createSession", err, "session_id", session.ID().String())
+		return nil, err
+	}
+
+	event := entity.NewUserAuditEvent(entity.AuditActionLogin, user.ID(), entity.AuditTargetSession, session.ID().PublicID(), map[string]any{"method": loginMethodOIDC})
+	if err := u.auditRecorder.Record(ctx, event); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// provider はプロバイダー名に対応するプロバイダーを返す
+func (u *OIDCUsecase) provider(name string) (service.OIDCProvider, error) {
+	provider, ok := u.providers[name]
+	if !ok {
+		logWarn("provider", "oidc provider not found", "provider", name)
+		return nil, domainErrors.ErrOIDCProviderNotFound
+	}
+	return provider, nil
+}
@@ -0,0 +1,504 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+	"caltrack/usecase/service"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// oidcMocks はOIDC Usecaseのテスト用モックをまとめる
+type oidcMocks struct {
+	provider               *mock.MockOIDCProvider
+	userRepo               *mock.MockUserRepository
+	userIdentityRepo       *mock.MockUserIdentityRepository
+	authRequestRepo        *mock.MockOIDCAuthRequestRepository
+	signupRepo             *mock.MockOIDCSignupRepository
+	sessionRepo            *mock.MockSessionRepository
+	targetSnapshotRepo     *mock.MockTargetSnapshotRepository
+	userTOTPRepo           *mock.MockUserTOTPRepository
+	twoFactorChallengeRepo *mock.MockTwoFactorChallengeRepository
+	accountDeletionRepo    *mock.MockAccountDeletionRepository
+	txManager              *mock.MockTransactionManager
+	auditRecorder          *mock.MockAuditRecorder
+}
+
+// setupOIDCMocks はOIDC Usecase用のモックを初期化する
+func setupOIDCMocks(t *testing.T) (*oidcMocks, *gomock.Controller) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	provider := mock.NewMockOIDCProvider(ctrl)
+	provider.EXPECT().Name().Return("google").AnyTimes()
+	return &oidcMocks{
+		provider:               provider,
+		userRepo:               mock.NewMockUserRepository(ctrl),
+		userIdentityRepo:       mock.NewMockUserIdentityRepository(ctrl),
+		authRequestRepo:        mock.NewMockOIDCAuthRequestRepository(ctrl),
+		signupRepo:             mock.NewMockOIDCSignupRepository(ctrl),
+		sessionRepo:            mock.NewMockSessionRepository(ctrl),
+		targetSnapshotRepo:     mock.NewMockTargetSnapshotRepository(ctrl),
+		userTOTPRepo:           mock.NewMockUserTOTPRepository(ctrl),
+		twoFactorChallengeRepo: mock.NewMockTwoFactorChallengeRepository(ctrl),
+		accountDeletionRepo:    noPendingAccountDeletion(ctrl),
+		txManager:              mock.NewMockTransactionManager(ctrl),
+		auditRecorder:          allowAuditRecord(ctrl),
+	}, ctrl
+}
+
+// newOIDCUsecase はモックからOIDCUsecaseを生成する
+func newOIDCUsecase(m *oidcMocks) *usecase.OIDCUsecase {
+	return usecase.NewOIDCUsecase(
+		[]service.OIDCProvider{m.provider},
+		m.userRepo,
+		m.userIdentityRepo,
+		m.authRequestRepo,
+		m.signupRepo,
+		m.sessionRepo,
+		m.targetSnapshotRepo,
+		m.userTOTPRepo,
+		m.twoFactorChallengeRepo,
+		m.accountDeletionRepo,
+		m.txManager,
+		m.auditRecorder,
+	)
+}
+
+// validUserWithoutPassword はテスト用のパスワード未設定ユーザーを生成する
+func validUserWithoutPassword(t *testing.T) *entity.User {
+	t.Helper()
+	profile := validOIDCSignupProfile(t)
+	email, _ := vo.NewEmail("oidc@example.com")
+	return entity.NewUserWithoutPassword(email, profile.Nickname, profile.Weight, profile.Height, profile.BirthDate, profile.Gender, profile.ActivityLevel)
+}
+
+// validOIDCSignupProfile はテスト用の登録プロフィールを生成する
+func validOIDCSignupProfile(t *testing.T) usecase.OIDCSignupProfile {
+	t.Helper()
+	nickname, _ := vo.NewNickname("oidcuser")
+	weight, _ := vo.NewWeight(60.0)
+	height, _ := vo.NewHeight(165.0)
+	birthDate, _ := vo.NewBirthDate(time.Date(1995, 5, 5, 0, 0, 0, 0, time.UTC))
+	gender, _ := vo.NewGender("female")
+	activityLevel, _ := vo.NewActivityLevel("moderate")
+	return usecase.OIDCSignupProfile{
+		Nickname:      nickname,
+		Weight:        weight,
+		Height:        height,
+		BirthDate:     birthDate,
+		Gender:        gender,
+		ActivityLevel: activityLevel,
+	}
+}
+
+// verifiedEmailUser はメールアドレス確認済みのテスト用ユーザーを生成する
+func verifiedEmailUser(t *testing.T) *entity.User {
+	t.Helper()
+	user := validUserForAuth(t)
+	user.ConfirmEmail(user.Email())
+	return user
+}
+
+// pendingAuthRequest はコールバック待ちの認可リクエストとstateを生成する
+func pendingAuthRequest(t *testing.T) (*entity.OIDCAuthRequest, vo.OneTimeToken) {
+	t.Helper()
+	req, state, err := entity.NewOIDCAuthRequest("google")
+	if err != nil {
+		t.Fatalf("failed to create auth request: %v", err)
+	}
+	return req, state
+}
+
+// expectAuthRequestConsumed は認可リクエストが取得され使用済みになることを設定する
+func expectAuthRequestConsumed(m *oidcMocks, req *entity.OIDCAuthRequest, state vo.OneTimeToken) {
+	m.authRequestRepo.EXPECT().FindByStateHash(gomock.Any(), state.Hash()).Return(req, nil)
+	m.authRequestRepo.EXPECT().MarkUsed(gomock.Any(), req).Return(nil)
+}
+
+func verifiedClaims(email string) *service.OIDCClaims {
+	return &service.OIDCClaims{Subject: "google-sub-1", Email: email, EmailVerified: true, Name: "OIDC User"}
+}
+
+func TestOIDCUsecase_StartLogin(t *testing.T) {
+	t.Run("正常系_PKCEとnonceを保存して認可URLを返す", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		var saved *entity.OIDCAuthRequest
+		var gotState, gotNonce, gotChallenge string
+		m.provider.EXPECT().
+			AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, state, nonce, challenge string) (string, error) {
+				gotState, gotNonce, gotChallenge = state, nonce, challenge
+				return "https://accounts.example.com/auth?state=" + url.QueryEscape(state), nil
+			})
+		m.authRequestRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req *entity.OIDCAuthRequest) error {
+				saved = req
+				return nil
+			})
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.StartLogin(context.Background(), "google")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.State.String() != gotState {
+			t.Error("returned state should be passed to the provider")
+		}
+		if saved.StateHash() != output.State.Hash() {
+			t.Error("only the hash of the state should be saved")
+		}
+		if saved.Nonce() != gotNonce || saved.CodeChallenge() != gotChallenge {
+			t.Error("nonce and code challenge should match the saved request")
+		}
+		if output.AuthURL == "" {
+			t.Error("AuthURL should not be empty")
+		}
+	})
+
+	t.Run("異常系_未設定のプロバイダー", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.StartLogin(context.Background(), "unknown")
+
+		if !errors.Is(err, domainErrors.ErrOIDCProviderNotFound) {
+			t.Errorf("got %v, want ErrOIDCProviderNotFound", err)
+		}
+	})
+}
+
+func TestOIDCUsecase_HandleCallback(t *testing.T) {
+	client := entity.SessionClient{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.10"}
+
+	t.Run("正常系_連携済みのユーザーでログインする", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		user := verifiedEmailUser(t)
+		req, state := pendingAuthRequest(t)
+		identity := entity.NewUserIdentity(user.ID(), "google", "google-sub-1", "test@example.com")
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), "code-1", req.CodeVerifier(), req.Nonce()).Return(verifiedClaims("test@example.com"), nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(identity, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.SignupRequired || output.Login == nil || output.Login.Session == nil {
+			t.Fatalf("session should be created: %+v", output)
+		}
+		if !output.Login.Session.UserID().Equals(user.ID()) {
+			t.Error("session should belong to the linked user")
+		}
+	})
+
+	t.Run("正常系_確認済みメールアドレスが一致するユーザーに連携してログインする", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		user := verifiedEmailUser(t)
+		req, state := pendingAuthRequest(t)
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), "code-1", req.CodeVerifier(), req.Nonce()).Return(verifiedClaims("test@example.com"), nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(nil, nil)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), user.Email()).Return(user, nil)
+		var linked *entity.UserIdentity
+		m.userIdentityRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, identity *entity.UserIdentity) error {
+				linked = identity
+				return nil
+			})
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Login == nil || output.Login.Session == nil {
+			t.Fatal("session should be created")
+		}
+		if !linked.UserID().Equals(user.ID()) || linked.Subject() != "google-sub-1" {
+			t.Errorf("identity should be linked to the user: %+v", linked)
+		}
+	})
+
+	t.Run("正常系_2段階認証が有効な場合はチャレンジを発行する", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		user := verifiedEmailUser(t)
+		req, state := pendingAuthRequest(t)
+		identity := entity.NewUserIdentity(user.ID(), "google", "google-sub-1", "test@example.com")
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(verifiedClaims("test@example.com"), nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(identity, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(enabledTOTP(t, user.ID()), nil)
+		m.twoFactorChallengeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !output.Login.TwoFactorRequired || output.Login.Session != nil {
+			t.Error("two-factor challenge should be issued instead of a session")
+		}
+	})
+
+	t.Run("正常系_ユーザーが存在しない場合は登録トークンを発行する", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		req, state := pendingAuthRequest(t)
+		email, _ := vo.NewEmail("new@example.com")
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(verifiedClaims("new@example.com"), nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(nil, nil)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(nil, nil)
+		var saved *entity.OIDCSignup
+		m.signupRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, signup *entity.OIDCSignup) error {
+				saved = signup
+				return nil
+			})
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !output.SignupRequired || output.Login != nil {
+			t.Fatalf("signup should be required: %+v", output)
+		}
+		if saved.TokenHash() != output.SignupToken.Hash() {
+			t.Error("only the hash of the signup token should be saved")
+		}
+		if output.SignupEmail != "new@example.com" {
+			t.Errorf("SignupEmail = %v, want new@example.com", output.SignupEmail)
+		}
+	})
+
+	t.Run("異常系_stateが見つからない", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		_, state := pendingAuthRequest(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.authRequestRepo.EXPECT().FindByStateHash(gomock.Any(), state.Hash()).Return(nil, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if !errors.Is(err, domainErrors.ErrOIDCStateInvalid) {
+			t.Errorf("got %v, want ErrOIDCStateInvalid", err)
+		}
+	})
+
+	t.Run("異常系_別プロバイダーのstate", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		req, state, _ := entity.NewOIDCAuthRequest("other")
+
+		setupTxManagerExecute(m.txManager)
+		m.authRequestRepo.EXPECT().FindByStateHash(gomock.Any(), state.Hash()).Return(req, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if !errors.Is(err, domainErrors.ErrOIDCStateInvalid) {
+			t.Errorf("got %v, want ErrOIDCStateInvalid", err)
+		}
+	})
+
+	t.Run("異常系_IDトークンの検証に失敗", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		req, state := pendingAuthRequest(t)
+
+		setupTxManagerExecute(m.txManager)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domainErrors.ErrOIDCTokenInvalid)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if !errors.Is(err, domainErrors.ErrOIDCTokenInvalid) {
+			t.Errorf("got %v, want ErrOIDCTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_未連携でメールアドレスが未確認", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		req, state := pendingAuthRequest(t)
+		claims := verifiedClaims("test@example.com")
+		claims.EmailVerified = false
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(claims, nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(nil, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if !errors.Is(err, domainErrors.ErrOIDCEmailNotVerified) {
+			t.Errorf("got %v, want ErrOIDCEmailNotVerified", err)
+		}
+	})
+
+	t.Run("異常系_既存アカウントのメールアドレスが未確認の場合は連携しない", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserForAuth(t)
+		req, state := pendingAuthRequest(t)
+
+		m.txManager.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).Times(2)
+		expectAuthRequestConsumed(m, req, state)
+		m.provider.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(verifiedClaims("test@example.com"), nil)
+		m.userIdentityRepo.EXPECT().FindByProviderSubject(gomock.Any(), "google", "google-sub-1").Return(nil, nil)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), user.Email()).Return(user, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.HandleCallback(context.Background(), "google", state, "code-1", client)
+
+		if !errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
+			t.Errorf("got %v, want ErrEmailAlreadyExists", err)
+		}
+	})
+}
+
+func TestOIDCUsecase_CompleteSignup(t *testing.T) {
+	client := entity.SessionClient{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.10"}
+	email, _ := vo.NewEmail("new@example.com")
+
+	t.Run("正常系_パスワードなしのユーザーを作成して連携しログインする", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		signup, token, _ := entity.NewOIDCSignup("google", "google-sub-1", email)
+
+		setupTxManagerExecute(m.txManager)
+		m.signupRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(signup, nil)
+		m.signupRepo.EXPECT().MarkUsed(gomock.Any(), signup).Return(nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), email).Return(false, nil)
+		var savedUser *entity.User
+		m.userRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *entity.User) error {
+				savedUser = u
+				return nil
+			})
+		m.targetSnapshotRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		var linked *entity.UserIdentity
+		m.userIdentityRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, identity *entity.UserIdentity) error {
+				linked = identity
+				return nil
+			})
+		m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newOIDCUsecase(m)
+		output, err := uc.CompleteSignup(context.Background(), token, validOIDCSignupProfile(t), client)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if savedUser.HasPassword() || !savedUser.IsEmailVerified() {
+			t.Error("user should be created without password and with verified email")
+		}
+		if !savedUser.Email().Equals(email) {
+			t.Errorf("Email = %v, want %v", savedUser.Email(), email)
+		}
+		if !linked.UserID().Equals(savedUser.ID()) || linked.Provider() != "google" {
+			t.Errorf("identity should be linked to the new user: %+v", linked)
+		}
+		if output.Session == nil || !output.Session.UserID().Equals(savedUser.ID()) {
+			t.Error("session should be created for the new user")
+		}
+	})
+
+	t.Run("異常系_トークンが見つからない", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		_, token, _ := entity.NewOIDCSignup("google", "google-sub-1", email)
+
+		setupTxManagerExecute(m.txManager)
+		m.signupRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(nil, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.CompleteSignup(context.Background(), token, validOIDCSignupProfile(t), client)
+
+		if !errors.Is(err, domainErrors.ErrOIDCSignupTokenInvalid) {
+			t.Errorf("got %v, want ErrOIDCSignupTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_登録待ちの間に同じメールアドレスで登録された", func(t *testing.T) {
+		m, ctrl := setupOIDCMocks(t)
+		defer ctrl.Finish()
+
+		signup, token, _ := entity.NewOIDCSignup("google", "google-sub-1", email)
+
+		setupTxManagerExecute(m.txManager)
+		m.signupRepo.EXPECT().FindByTokenHash(gomock.Any(), token.Hash()).Return(signup, nil)
+		m.signupRepo.EXPECT().MarkUsed(gomock.Any(), signup).Return(nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), email).Return(true, nil)
+
+		uc := newOIDCUsecase(m)
+		_, err := uc.CompleteSignup(context.Background(), token, validOIDCSignupProfile(t), client)
+
+		if !errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
+			t.Errorf("got %v, want ErrEmailAlreadyExists", err)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// パスワードリセットメールの件名と本文
+const (
+	passwordResetMailSubject = "【CalTrack】パスワード再設定のご案内"
+	passwordResetMailBody    = `パスワード再設定のリクエストを受け付けました。
+以下のリンクから%d分以内に新しいパスワードを設定してください。
+
+%s
+
+このメールにお心当たりがない場合は、破棄していただいて問題ありません。
+`
+)
+
+// PasswordResetUsecase はパスワードリセットに関するユースケースを提供する
+type PasswordResetUsecase struct {
+	userRepo               repository.UserRepository
+	passwordResetTokenRepo repository.PasswordResetTokenRepository
+	sessionRepo            repository.SessionRepository
+	txManager              repository.TransactionManager
+	auditRecorder          service.AuditRecorder
+	mailer                 service.Mailer
+	appBaseURL             string
+}
+
+// NewPasswordResetUsecase は PasswordResetUsecase のインスタンスを生成する
+// appBaseURLはメール本文に記載するリセット画面のリンクに使う
+func NewPasswordResetUsecase(
+	userRepo repository.UserRepository,
+	passwordResetTokenRepo repository.PasswordResetTokenRepository,
+	sessionRepo repository.SessionRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+	mailer service.Mailer,
+	appBaseURL string,
+) *PasswordResetUsecase {
+	return &PasswordResetUsecase{
+		userRepo:               userRepo,
+		passwordResetTokenRepo: passwordResetTokenRepo,
+		sessionRepo:            sessionRepo,
+		txManager:              txManager,
+		auditRecorder:          auditRecorder,
+		mailer:                 mailer,
+		appBaseURL:             appBaseURL,
+	}
+}
+
+// RequestReset はパスワードリセットトークンを発行し、リセット用リンクをメールで送信する
+// メールアドレスの登録有無を推測されないよう、未登録の場合もエラーを返さない
+func (u *PasswordResetUsecase) RequestReset(ctx context.Context, email vo.Email) error {
+	var rawToken vo.OneTimeToken
+	var found bool
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByEmail(txCtx, email)
+		if err != nil {
+			logError("RequestReset", err, "email", email.String())
+			return err
+		}
+		if user == nil {
+			logWarn("RequestReset", "user not found", "email", email.String())
+			return nil
+		}
+
+		// 発行済みのトークンは無効化する
+		if err := u.passwordResetTokenRepo.DeleteByUserID(txCtx, user.ID()); err != nil {
+			logError("RequestReset", err, "user_id", user.ID().String())
+			return err
+		}
+
+		token, raw, err := entity.NewPasswordResetToken(user.ID())
+		if err != nil {
+			logError("RequestReset", err, "user_id", user.ID().String())
+			return err
+		}
+		if err := u.passwordResetTokenRepo.Save(txCtx, token); err != nil {
+			logError("RequestReset", err, "user_id", user.ID().String())
+			return err
+		}
+
+		rawToken = raw
+		found = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	// メール送信はトランザクション確定後にバックグラウンドで行う
+	// 登録済みの場合だけ送信を待つと応答時間の差で登録有無が推測できるため、完了を待たずに返す
+	// 同じ理由で送信失敗はレスポンスに反映せず、ログ出力のみとする
+	mail := service.Mail{
+		To:      email.String(),
+		Subject: passwordResetMailSubject,
+		Body:    fmt.Sprintf(passwordResetMailBody, int(entity.PasswordResetTokenTTL.Minutes()), u.resetURL(rawToken)),
+	}
+	go u.sendResetMail(context.WithoutCancel(ctx), mail)
+	return nil
+}
+
+// sendResetMail はパスワードリセットメールを送信する
+// リクエスト完了後に実行されるため、ctxはキャンセルされないものを渡すこと
+func (u *PasswordResetUsecase) sendResetMail(ctx context.Context, mail service.Mail) {
+	if err := u.mailer.Send(ctx, mail); err != nil {
+		logError("RequestReset", err, "email", mail.To)
+	}
+}
+
+// ConfirmReset はリセットトークンを検証して新しいパスワードを設定する
+// トークンは使用済みにし、ユーザーの全セッションを削除して監査ログに記録する
+func (u *PasswordResetUsecase) ConfirmReset(ctx context.Context, token vo.OneTimeToken, newPassword vo.Password) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		resetToken, err := u.passwordResetTokenRepo.FindByTokenHash(txCtx, token.Hash())
+		if err != nil {
+			logError("ConfirmReset", err)
+			return err
+		}
+		if resetToken == nil {
+			logWarn("ConfirmReset", "token not found")
+			return domainErrors.ErrPasswordResetTokenInvalid
+		}
+
+		if err := resetToken.Use(); err != nil {
+			logWarn("ConfirmReset", "token expired or already used", "user_id", resetToken.UserID().String())
+			return err
+		}
+
+		user, err := u.userRepo.FindByID(txCtx, resetToken.UserID())
+		if err != nil {
+			logError("ConfirmReset", err, "user_id", resetToken.UserID().String())
+			return err
+		}
+		if user == nil {
+			logWarn("ConfirmReset", "user not found", "user_id", resetToken.UserID().String())
+			return domainErrors.ErrPasswordResetTokenInvalid
+		}
+
+		if err := user.ResetPassword(newPassword); err != nil {
+			logError("ConfirmReset", err, "user_id", user.ID().String())
+			return err
+		}
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("ConfirmReset", err, "user_id", user.ID().String())
+			return err
+		}
+		if err := u.passwordResetTokenRepo.MarkUsed(txCtx, resetToken); err != nil {
+			logError("ConfirmReset", err, "user_id", user.ID().String())
+			return err
+		}
+
+		// 漏洩したパスワードでのセッションが残らないよう全セッションを削除
+		if err := u.sessionRepo.DeleteByUserID(txCtx, user.ID()); err != nil {
+			logError("ConfirmReset", err, "user_id", user.ID().String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionPasswordReset, user.ID(), entity.AuditTargetUser, user.ID().String(), nil)
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// resetURL はメールに記載するパスワード再設定画面のURLを返す
+func (u *PasswordResetUsecase) resetURL(token vo.OneTimeToken) string {
+	return u.appBaseURL + "/password-reset?token=" + token.String()
+}
@@ -0,0 +1,324 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+	"caltrack/usecase/service"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+const testAppBaseURL = "http://localhost:5173"
+
+// passwordResetMocks はPasswordResetUsecaseのテスト用モックをまとめる
+type passwordResetMocks struct {
+	userRepo               *mock.MockUserRepository
+	passwordResetTokenRepo *mock.MockPasswordResetTokenRepository
+	sessionRepo            *mock.MockSessionRepository
+	txManager              *mock.MockTransactionManager
+	auditRecorder          *mock.MockAuditRecorder
+	auditEvents            *auditEvents
+	mailer                 *mock.MockMailer
+}
+
+// setupPasswordResetMocks はテスト用のモックを初期化する
+func setupPasswordResetMocks(t *testing.T) (*passwordResetMocks, *gomock.Controller) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	return &passwordResetMocks{
+		userRepo:               mock.NewMockUserRepository(ctrl),
+		passwordResetTokenRepo: mock.NewMockPasswordResetTokenRepository(ctrl),
+		sessionRepo:            mock.NewMockSessionRepository(ctrl),
+		txManager:              mock.NewMockTransactionManager(ctrl),
+		auditRecorder:          auditRecorder,
+		auditEvents:            auditEvents,
+		mailer:                 mock.NewMockMailer(ctrl),
+	}, ctrl
+}
+
+// newPasswordResetUsecase はモックからPasswordResetUsecaseを生成する
+func newPasswordResetUsecase(m *passwordResetMocks) *usecase.PasswordResetUsecase {
+	return usecase.NewPasswordResetUsecase(m.userRepo, m.passwordResetTokenRepo, m.sessionRepo, m.txManager, m.auditRecorder, m.mailer, testAppBaseURL)
+}
+
+// waitForMail はバックグラウンドで送信されるメールを待つ
+func waitForMail(t *testing.T, sent <-chan service.Mail) service.Mail {
+	t.Helper()
+	select {
+	case mail := <-sent:
+		return mail
+	case <-time.After(time.Second):
+		t.Fatal("mail was not sent")
+		return service.Mail{}
+	}
+}
+
+// TestPasswordResetUsecase_RequestReset はパスワードリセット要求のテスト
+func TestPasswordResetUsecase_RequestReset(t *testing.T) {
+	email, _ := vo.NewEmail("test@example.com")
+
+	t.Run("正常系_トークンが保存されリセットリンクがメール送信される", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordResetTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		var savedToken *entity.PasswordResetToken
+		m.passwordResetTokenRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, token *entity.PasswordResetToken) error {
+				savedToken = token
+				return nil
+			})
+		sent := make(chan service.Mail, 1)
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, mail service.Mail) error {
+				sent <- mail
+				return nil
+			})
+
+		err := newPasswordResetUsecase(m).RequestReset(context.Background(), email)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sentMail := waitForMail(t, sent)
+		if sentMail.To != email.String() {
+			t.Errorf("mail to = %s, want %s", sentMail.To, email.String())
+		}
+		// メールのリンクに含まれるトークンが保存済みハッシュと一致する
+		_, rawToken, found := strings.Cut(sentMail.Body, testAppBaseURL+"/password-reset?token=")
+		if !found {
+			t.Fatalf("mail body should contain reset link: %s", sentMail.Body)
+		}
+		token, err := vo.ParseOneTimeToken(strings.Fields(rawToken)[0])
+		if err != nil {
+			t.Fatalf("failed to parse token in mail: %v", err)
+		}
+		if token.Hash() != savedToken.TokenHash() {
+			t.Error("token in mail should match the saved hash")
+		}
+	})
+
+	t.Run("正常系_未登録のメールアドレスはメールを送らずに成功する", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(nil, nil)
+
+		err := newPasswordResetUsecase(m).RequestReset(context.Background(), email)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("正常系_メール送信に失敗してもエラーを返さない", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordResetTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.passwordResetTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		sent := make(chan service.Mail, 1)
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, mail service.Mail) error {
+				sent <- mail
+				return errors.New("smtp error")
+			})
+
+		err := newPasswordResetUsecase(m).RequestReset(context.Background(), email)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitForMail(t, sent)
+	})
+
+	t.Run("正常系_メール送信の完了を待たずに返る", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordResetTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.passwordResetTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		// 送信はRequestResetが返るまでブロックする
+		release := make(chan struct{})
+		sent := make(chan service.Mail, 1)
+		var sendCtxErr error
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, mail service.Mail) error {
+				<-release
+				sendCtxErr = ctx.Err()
+				sent <- mail
+				return nil
+			})
+
+		// リクエストのコンテキストがキャンセルされても送信は続く
+		ctx, cancel := context.WithCancel(context.Background())
+		err := newPasswordResetUsecase(m).RequestReset(ctx, email)
+		cancel()
+		close(release)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitForMail(t, sent)
+		if sendCtxErr != nil {
+			t.Errorf("send context error = %v, want nil", sendCtxErr)
+		}
+	})
+
+	t.Run("異常系_トークン保存エラー", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		dbErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		m.passwordResetTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.passwordResetTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := newPasswordResetUsecase(m).RequestReset(context.Background(), email)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want db error", err)
+		}
+	})
+}
+
+// TestPasswordResetUsecase_ConfirmReset はパスワードリセット確定のテスト
+func TestPasswordResetUsecase_ConfirmReset(t *testing.T) {
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	// resetTokenFor は指定ユーザーの有効なリセットトークンとその平文を生成する
+	resetTokenFor := func(t *testing.T, userID vo.UserID) (*entity.PasswordResetToken, vo.OneTimeToken) {
+		t.Helper()
+		token, raw, err := entity.NewPasswordResetToken(userID)
+		if err != nil {
+			t.Fatalf("failed to create reset token: %v", err)
+		}
+		return token, raw
+	}
+
+	t.Run("正常系_パスワードが再設定され全セッションが削除される", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		token, raw := resetTokenFor(t, user.ID())
+
+		setupTxManagerExecute(m.txManager)
+		m.passwordResetTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.passwordResetTokenRepo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+
+		err := newPasswordResetUsecase(m).ConfirmReset(context.Background(), raw, newPassword)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !user.HashedPassword().Compare(newPassword) {
+			t.Error("password should be reset")
+		}
+		if token.UsedAt() == nil {
+			t.Error("token should be marked as used")
+		}
+		if got := m.auditEvents.actions(); !reflect.DeepEqual(got, []entity.AuditAction{entity.AuditActionPasswordReset}) {
+			t.Errorf("audit events = %v, want [%s]", got, entity.AuditActionPasswordReset)
+		}
+	})
+
+	t.Run("異常系_トークンが存在しない", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		raw, _ := vo.NewOneTimeToken()
+
+		setupTxManagerExecute(m.txManager)
+		m.passwordResetTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(nil, nil)
+
+		err := newPasswordResetUsecase(m).ConfirmReset(context.Background(), raw, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want ErrPasswordResetTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_トークンの有効期限切れ", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		raw, _ := vo.NewOneTimeToken()
+		expired := entity.ReconstructPasswordResetToken(
+			vo.NewPasswordResetTokenID().String(),
+			vo.NewUserID().String(),
+			raw.Hash(),
+			time.Now().Add(-1*time.Minute),
+			nil,
+			time.Now().Add(-31*time.Minute),
+		)
+
+		setupTxManagerExecute(m.txManager)
+		m.passwordResetTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(expired, nil)
+
+		err := newPasswordResetUsecase(m).ConfirmReset(context.Background(), raw, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want ErrPasswordResetTokenInvalid", err)
+		}
+	})
+
+	t.Run("異常系_セッション削除エラー", func(t *testing.T) {
+		m, ctrl := setupPasswordResetMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		token, raw := resetTokenFor(t, user.ID())
+		dbErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.passwordResetTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.passwordResetTokenRepo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(dbErr)
+
+		err := newPasswordResetUsecase(m).ConfirmReset(context.Background(), raw, newPassword)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want db error", err)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+)
+
+// PersonalAccessTokenUsecase はパーソナルアクセストークンの発行・管理・認証に関するユースケースを提供する
+type PersonalAccessTokenUsecase struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+	userRepo  repository.UserRepository
+	txManager repository.TransactionManager
+}
+
+// NewPersonalAccessTokenUsecase は PersonalAccessTokenUsecase のインスタンスを生成する
+func NewPersonalAccessTokenUsecase(
+	tokenRepo repository.PersonalAccessTokenRepository,
+	userRepo repository.UserRepository,
+	txManager repository.TransactionManager,
+) *PersonalAccessTokenUsecase {
+	return &PersonalAccessTokenUsecase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+	}
+}
+
+// CreatePersonalAccessTokenOutput はトークン発行の出力を表す
+// トークンの平文はこの時点でのみ取得できる
+type CreatePersonalAccessTokenOutput struct {
+	Token       *entity.PersonalAccessToken
+	AccessToken vo.AccessToken
+}
+
+// CreateToken は新しいパーソナルアクセストークンを発行する
+// expiresAt がnilの場合は無期限のトークンを発行する
+func (u *PersonalAccessTokenUsecase) CreateToken(
+	ctx context.Context,
+	userID vo.UserID,
+	name string,
+	scopes []vo.TokenScope,
+	expiresAt *time.Time,
+) (*CreatePersonalAccessTokenOutput, error) {
+	token, accessToken, err := entity.NewPersonalAccessToken(userID, name, scopes, expiresAt)
+	if err != nil {
+		logWarn("CreateToken", err.Error(), "user_id", userID.String())
+		return nil, err
+	}
+
+	if err := u.tokenRepo.Save(ctx, token); err != nil {
+		logError("CreateToken", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	return &CreatePersonalAccessTokenOutput{
+		Token:       token,
+		AccessToken: accessToken,
+	}, nil
+}
+
+// ListTokens はユーザーのパーソナルアクセストークンを作成日時の降順で取得する
+func (u *PersonalAccessTokenUsecase) ListTokens(ctx context.Context, userID vo.UserID) ([]*entity.PersonalAccessToken, error) {
+	tokens, err := u.tokenRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logError("ListTokens", err, "user_id", userID.String())
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeToken はユーザーのパーソナルアクセストークンを削除する
+// 他のユーザーのトークンは削除できず、該当がない場合は ErrPersonalAccessTokenNotFound を返す
+func (u *PersonalAccessTokenUsecase) RevokeToken(ctx context.Context, userID vo.UserID, tokenID vo.PersonalAccessTokenID) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		tokens, err := u.tokenRepo.FindByUserID(txCtx, userID)
+		if err != nil {
+			logError("RevokeToken", err, "user_id", userID.String())
+			return err
+		}
+
+		for _, token := range tokens {
+			if !token.ID().Equals(tokenID) {
+				continue
+			}
+			if err := u.tokenRepo.DeleteByID(txCtx, token.ID()); err != nil {
+				logError("RevokeToken", err, "user_id", userID.String())
+				return err
+			}
+			return nil
+		}
+
+		logWarn("RevokeToken", "token not found", "user_id", userID.String(), "personal_access_token_id", tokenID.String())
+		return domainErrors.ErrPersonalAccessTokenNotFound
+	})
+}
+
+// Authenticate はアクセストークンを検証し、最終利用日時を更新する
+// 存在しない・有効期限切れの場合は ErrInvalidAccessToken を返す
+// トークンの持ち主のアカウントが停止されている場合は ErrAccountDisabled を返す
+func (u *PersonalAccessTokenUsecase) Authenticate(ctx context.Context, accessToken vo.AccessToken) (*entity.PersonalAccessToken, error) {
+	token, err := u.tokenRepo.FindByTokenHash(ctx, accessToken.Hash())
+	if err != nil {
+		logError("Authenticate", err)
+		return nil, err
+	}
+	if token == nil {
+		logWarn("Authenticate", "token not found")
+		return nil, domainErrors.ErrInvalidAccessToken
+	}
+
+	if err := token.Use(); err != nil {
+		logWarn("Authenticate", "token expired", "personal_access_token_id", token.ID().String())
+		return nil, err
+	}
+
+	// 管理者に停止されたアカウントのトークンは使えない
+	user, err := u.userRepo.FindByID(ctx, token.UserID())
+	if err != nil {
+		logError("Authenticate", err, "user_id", token.UserID().String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn("Authenticate", "user not found", "user_id", token.UserID().String())
+		return nil, domainErrors.ErrInvalidAccessToken
+	}
+	if user.IsDisabled() {
+		logWarn("Authenticate", "account disabled", "user_id", token.UserID().String())
+		return nil, domainErrors.ErrAccountDisabled
+	}
+
+	if err := u.tokenRepo.UpdateLastUsedAt(ctx, token); err != nil {
+		logError("Authenticate", err, "personal_access_token_id", token.ID().String())
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,188 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// setupPersonalAccessTokenMocks はテスト用のモックとPersonalAccessTokenUsecaseを初期化する
+func setupPersonalAccessTokenMocks(t *testing.T) (*mock.MockPersonalAccessTokenRepository, *mock.MockUserRepository, *mock.MockTransactionManager, *usecase.PersonalAccessTokenUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	tokenRepo := mock.NewMockPersonalAccessTokenRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	txManager := mock.NewMockTransactionManager(ctrl)
+	return tokenRepo, userRepo, txManager, usecase.NewPersonalAccessTokenUsecase(tokenRepo, userRepo, txManager)
+}
+
+// testTokenScopes はテスト用のスコープ一覧を生成する
+func testTokenScopes(t *testing.T, values ...string) []vo.TokenScope {
+	t.Helper()
+	scopes, err := vo.NewTokenScopes(values)
+	if err != nil {
+		t.Fatalf("failed to create scopes: %v", err)
+	}
+	return scopes
+}
+
+func TestPersonalAccessTokenUsecase_CreateToken(t *testing.T) {
+	t.Run("正常系_トークンを発行して平文を返す", func(t *testing.T) {
+		tokenRepo, _, _, uc := setupPersonalAccessTokenMocks(t)
+		userID := vo.NewUserID()
+		expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+		var saved *entity.PersonalAccessToken
+		tokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, token *entity.PersonalAccessToken) error {
+				saved = token
+				return nil
+			})
+
+		output, err := uc.CreateToken(context.Background(), userID, "spreadsheet", testTokenScopes(t, vo.TokenScopeRecordsRead), &expiresAt)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if saved.TokenHash() != output.AccessToken.Hash() {
+			t.Error("saved token hash should match the returned access token")
+		}
+		if !saved.UserID().Equals(userID) {
+			t.Errorf("UserID = %v, want %v", saved.UserID(), userID)
+		}
+	})
+
+	t.Run("異常系_名前が空", func(t *testing.T) {
+		_, _, _, uc := setupPersonalAccessTokenMocks(t)
+
+		_, err := uc.CreateToken(context.Background(), vo.NewUserID(), "", testTokenScopes(t, vo.TokenScopeRecordsRead), nil)
+
+		if !errors.Is(err, domainErrors.ErrAccessTokenNameRequired) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrAccessTokenNameRequired)
+		}
+	})
+
+	t.Run("異常系_保存エラー", func(t *testing.T) {
+		tokenRepo, _, _, uc := setupPersonalAccessTokenMocks(t)
+		dbErr := errors.New("db error")
+		tokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		_, err := uc.CreateToken(context.Background(), vo.NewUserID(), "spreadsheet", testTokenScopes(t, vo.TokenScopeRecordsRead), nil)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("error = %v, want %v", err, dbErr)
+		}
+	})
+}
+
+func TestPersonalAccessTokenUsecase_RevokeToken(t *testing.T) {
+	t.Run("正常系_自分のトークンを削除する", func(t *testing.T) {
+		tokenRepo, _, txManager, uc := setupPersonalAccessTokenMocks(t)
+		userID := vo.NewUserID()
+		token, _, _ := entity.NewPersonalAccessToken(userID, "spreadsheet", testTokenScopes(t, vo.TokenScopeRecordsRead), nil)
+
+		setupTxManagerExecute(txManager)
+		tokenRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return([]*entity.PersonalAccessToken{token}, nil)
+		tokenRepo.EXPECT().DeleteByID(gomock.Any(), token.ID()).Return(nil)
+
+		if err := uc.RevokeToken(context.Background(), userID, token.ID()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_該当するトークンがない", func(t *testing.T) {
+		tokenRepo, _, txManager, uc := setupPersonalAccessTokenMocks(t)
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(txManager)
+		tokenRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(nil, nil)
+
+		err := uc.RevokeToken(context.Background(), userID, vo.NewPersonalAccessTokenID())
+
+		if !errors.Is(err, domainErrors.ErrPersonalAccessTokenNotFound) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrPersonalAccessTokenNotFound)
+		}
+	})
+}
+
+func TestPersonalAccessTokenUsecase_Authenticate(t *testing.T) {
+	t.Run("正常系_最終利用日時を更新してトークンを返す", func(t *testing.T) {
+		tokenRepo, userRepo, _, uc := setupPersonalAccessTokenMocks(t)
+		user := validUser(t)
+		token, raw, _ := entity.NewPersonalAccessToken(user.ID(), "spreadsheet", testTokenScopes(t, vo.TokenScopeRecordsRead), nil)
+
+		tokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		tokenRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), token).Return(nil)
+
+		got, err := uc.Authenticate(context.Background(), raw)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.LastUsedAt() == nil {
+			t.Error("LastUsedAt should be set")
+		}
+	})
+
+	t.Run("異常系_存在しないトークン", func(t *testing.T) {
+		tokenRepo, _, _, uc := setupPersonalAccessTokenMocks(t)
+		raw, _ := vo.NewAccessToken()
+
+		tokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(nil, nil)
+
+		_, err := uc.Authenticate(context.Background(), raw)
+
+		if !errors.Is(err, domainErrors.ErrInvalidAccessToken) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrInvalidAccessToken)
+		}
+	})
+
+	t.Run("異常系_有効期限切れ", func(t *testing.T) {
+		tokenRepo, _, _, uc := setupPersonalAccessTokenMocks(t)
+		raw, _ := vo.NewAccessToken()
+		expiredAt := time.Now().Add(-1 * time.Minute)
+		token := entity.ReconstructPersonalAccessToken(
+			vo.NewPersonalAccessTokenID().String(),
+			vo.NewUserID().String(),
+			"spreadsheet",
+			raw.Hash(),
+			[]string{vo.TokenScopeRecordsRead},
+			&expiredAt,
+			nil,
+			time.Now().Add(-24*time.Hour),
+		)
+
+		tokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+
+		_, err := uc.Authenticate(context.Background(), raw)
+
+		if !errors.Is(err, domainErrors.ErrInvalidAccessToken) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrInvalidAccessToken)
+		}
+	})
+	t.Run("異常系_停止されたアカウントのトークンは使えない", func(t *testing.T) {
+		tokenRepo, userRepo, _, uc := setupPersonalAccessTokenMocks(t)
+		user := disabledUser(t)
+		token, raw, _ := entity.NewPersonalAccessToken(user.ID(), "spreadsheet", testTokenScopes(t, vo.TokenScopeRecordsRead), nil)
+
+		tokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		// 最終利用日時は更新されない（EXPECT未設定）
+
+		_, err := uc.Authenticate(context.Background(), raw)
+
+		if !errors.Is(err, domainErrors.ErrAccountDisabled) {
+			t.Errorf("error = %v, want %v", err, domainErrors.ErrAccountDisabled)
+		}
+	})
+}
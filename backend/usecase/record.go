@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
 	"caltrack/domain/repository"
 	"caltrack/domain/vo"
 	"caltrack/usecase/service"
@@ -15,48 +17,87 @@ import (
 
 // TodayCaloriesOutput は今日の摂取カロリー情報を表す出力構造体
 type TodayCaloriesOutput struct {
-	Date           time.Time        // 対象日付
-	TotalCalories  int              // 今日の合計カロリー
-	TargetCalories int              // 目標カロリー
-	Difference     int              // 差分（目標 - 実績）：プラスは残り、マイナスは超過
-	Records        []*entity.Record // 今日のRecord一覧
+	Date                 time.Time        // 対象日付
+	TotalCalories        int              // 今日の合計カロリー
+	TargetCalories       int              // 目標カロリー（週単位モードでは今日使える配分）
+	Difference           int              // 差分（目標 - 実績）：プラスは残り、マイナスは超過
+	Records              []*entity.Record // 今日のRecord一覧
+	BudgetMode           vo.BudgetMode    // カロリー予算モード
+	WeeklyTargetCalories int              // 週の目標カロリー（週単位モードのみ）
+	WeeklyTotalCalories  int              // 今週の合計カロリー（今日を含む、週単位モードのみ）
 }
 
 // RecordUsecase はカロリー記録に関するユースケースを提供する
 type RecordUsecase struct {
-	recordRepo      repository.RecordRepository
-	recordPfcRepo   repository.RecordPfcRepository
-	userRepo        repository.UserRepository
-	adviceCacheRepo repository.AdviceCacheRepository
-	txManager       repository.TransactionManager
-	pfcEstimator    service.PfcEstimator
-	aiConfig        AIConfig
+	recordRepo         repository.RecordRepository
+	recordPfcRepo      repository.RecordPfcRepository
+	userRepo           repository.UserRepository
+	adviceCacheRepo    repository.AdviceCacheRepository
+	targetSnapshotRepo repository.TargetSnapshotRepository
+	dailySummaryRepo   repository.DailySummaryRepository
+	txManager          repository.TransactionManager
+	auditRecorder      service.AuditRecorder
+	badgeUnlocker      service.BadgeUnlocker
+	aiUsageRepo        repository.AIUsageRepository
+	pfcEstimator       service.PfcEstimator
+	aiConfig           AIConfig
+	retentionYears     int
 }
 
 // NewRecordUsecase は RecordUsecase のインスタンスを生成する
+// recordRetentionYearsはデプロイ全体の記録の保存年数（0の場合は無期限）
 func NewRecordUsecase(
 	recordRepo repository.RecordRepository,
 	recordPfcRepo repository.RecordPfcRepository,
 	userRepo repository.UserRepository,
 	adviceCacheRepo repository.AdviceCacheRepository,
+	targetSnapshotRepo repository.TargetSnapshotRepository,
+	dailySummaryRepo repository.DailySummaryRepository,
 	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+	badgeUnlocker service.BadgeUnlocker,
+	aiUsageRepo repository.AIUsageRepository,
 	pfcEstimator service.PfcEstimator,
 	aiConfig AIConfig,
+	recordRetentionYears int,
 ) *RecordUsecase {
 	return &RecordUsecase{
-		recordRepo:      recordRepo,
-		recordPfcRepo:   recordPfcRepo,
-		userRepo:        userRepo,
-		adviceCacheRepo: adviceCacheRepo,
-		txManager:       txManager,
-		pfcEstimator:    pfcEstimator,
-		aiConfig:        aiConfig,
+		recordRepo:         recordRepo,
+		recordPfcRepo:      recordPfcRepo,
+		userRepo:           userRepo,
+		adviceCacheRepo:    adviceCacheRepo,
+		targetSnapshotRepo: targetSnapshotRepo,
+		dailySummaryRepo:   dailySummaryRepo,
+		txManager:          txManager,
+		auditRecorder:      auditRecorder,
+		badgeUnlocker:      badgeUnlocker,
+		aiUsageRepo:        aiUsageRepo,
+		pfcEstimator:       pfcEstimator,
+		aiConfig:           aiConfig,
+		retentionYears:     recordRetentionYears,
 	}
 }
 
 // Create は新しいカロリー記録を作成する
+// 保存期間より前の日時の記録は ErrEatenAtBeforeRetention を返す
+// （記録を削除した期間の日別集計が、作成時の再集計で上書きされないようにするため）
 func (u *RecordUsecase) Create(ctx context.Context, record *entity.Record) error {
 	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, record.UserID())
+		if err != nil {
+			logError("Create", err, "user_id", record.UserID().String())
+			return err
+		}
+		if user == nil {
+			logWarn("Create", "user not found", "user_id", record.UserID().String())
+			return domainErrors.ErrUserNotFound
+		}
+		cutoff, ok := recordRetentionCutoff(time.Now(), u.retentionYears, user.PrivacySettings().RetentionYears())
+		if ok && record.EatenAt().Time().Before(cutoff) {
+			logWarn("Create", "eaten at is before the retention period", "user_id", record.UserID().String())
+			return domainErrors.ErrEatenAtBeforeRetention
+		}
+
 		// Record保存
 		if err := u.recordRepo.Save(txCtx, record); err != nil {
 			logError("Create", err, "record_id", record.ID().String())
@@ -64,8 +105,14 @@ func (u *RecordUsecase) Create(ctx context.Context, record *entity.Record) error
 		}
 
 		// AI-PFC推定実行
-		recordPfc, err := u.estimatePfc(txCtx, record)
-		if err != nil {
+		recordPfc, err := u.estimatePfc(txCtx, user, record)
+		if errors.Is(err, domainErrors.ErrAIProcessingDisabled) {
+			// AI機能をオフにしているユーザーはPFCを推定せずに記録する
+			logInfo("Create", "pfc estimation skipped", "record_id", record.ID().String(), "reason", "ai_processing_disabled")
+		} else if errors.Is(err, domainErrors.ErrEmailNotVerified) {
+			// メールアドレス未確認のユーザーはAI機能を使えないため、PFCを推定せずに記録する
+			logInfo("Create", "pfc estimation skipped", "record_id", record.ID().String(), "reason", "email_not_verified")
+		} else if err != nil {
 			// PFC推定失敗してもRecordは保存済みなのでログのみ
 			logError("Create", err, "record_id", record.ID().String(), "pfc_estimation_failed", true)
 		}
@@ -78,21 +125,48 @@ func (u *RecordUsecase) Create(ctx context.Context, record *entity.Record) error
 			}
 		}
 
-		// キャッシュ無効化（記録日のキャッシュを削除）
+		// 記録日の日別サマリーを再集計
 		recordDate := record.EatenAt().Time()
+		if err := u.dailySummaryRepo.Refresh(txCtx, record.UserID(), startOfDay(recordDate)); err != nil {
+			logError("Create", err, "record_id", record.ID().String())
+			return err
+		}
+
+		// 更新後のサマリーでマイルストーンバッジの獲得を判定
+		if err := u.badgeUnlocker.UnlockMilestoneBadges(txCtx, record.UserID()); err != nil {
+			// バッジ保存失敗はログのみ（記録作成は成功として扱う）
+			logError("Create", err, "user_id", record.UserID().String(), "badge_unlock_failed", true)
+		}
+
+		// キャッシュ無効化（記録日のキャッシュを削除）
 		if err := u.adviceCacheRepo.DeleteByUserIDAndDate(txCtx, record.UserID(), recordDate); err != nil {
 			// キャッシュ削除失敗はログのみ（記録作成は成功として扱う）
 			logError("Create", err, "user_id", record.UserID().String(), "cache_delete_failed", true)
 		}
 
-		return nil
+		// 食品名は記録せず、件数とカロリーのみを残す
+		event := entity.NewUserAuditEvent(entity.AuditActionRecordCreate, record.UserID(), entity.AuditTargetRecord, record.ID().String(), map[string]any{
+			"eatenAt":       record.EatenAt().Time(),
+			"items":         len(record.Items()),
+			"totalCalories": record.TotalCalories(),
+		})
+		return u.auditRecorder.Record(txCtx, event)
 	})
 
 	return err
 }
 
 // estimatePfc は食品名からPFC値を推定してRecordPfcを作成する
-func (u *RecordUsecase) estimatePfc(ctx context.Context, record *entity.Record) (*entity.RecordPfc, error) {
+// プライバシー設定でAI機能をオフにしている場合は食品名を送信せず ErrAIProcessingDisabled を返す
+// メールアドレスが未確認の場合は ErrEmailNotVerified を返す（画像解析・アドバイスと同じ制限）
+func (u *RecordUsecase) estimatePfc(ctx context.Context, user *entity.User, record *entity.Record) (*entity.RecordPfc, error) {
+	if !user.AllowsAIProcessing() {
+		return nil, domainErrors.ErrAIProcessingDisabled
+	}
+	if !user.IsEmailVerified() {
+		return nil, domainErrors.ErrEmailNotVerified
+	}
+
 	// 食品名リストを抽出
 	foodNames := record.ItemNames()
 
@@ -113,6 +187,7 @@ func (u *RecordUsecase) estimatePfc(ctx context.Context, record *entity.Record)
 	if err != nil {
 		return nil, err
 	}
+	recordAIUsage(ctx, u.aiUsageRepo, record.UserID(), entity.AIFeaturePfcEstimation, estimatorConfig.ModelName, output.Usage)
 
 	// RecordPfcを生成（エラーなし）
 	recordPfc := entity.NewRecordPfc(
@@ -182,20 +257,55 @@ func (u *RecordUsecase) GetTodayCalories(ctx context.Context, userID vo.UserID)
 	// 目標カロリー計算
 	targetCalories := user.CalculateTargetCalories()
 
-	return &TodayCaloriesOutput{
-		Date:           start,
-		TotalCalories:  totalCalories,
-		TargetCalories: targetCalories,
-		Difference:     targetCalories - totalCalories,
-		Records:        records,
-	}, nil
+	output := &TodayCaloriesOutput{
+		Date:       start,
+		Records:    records,
+		BudgetMode: user.BudgetMode(),
+	}
+
+	// 週単位モードでは週の残り予算を残り日数で割った値を今日の目標とする
+	if user.BudgetMode().IsWeekly() {
+		consumedBefore, err := u.sumCalories(ctx, userID, helper.StartOfWeek(now), start)
+		if err != nil {
+			logError("GetTodayCalories", err, "user_id", userID.String())
+			return nil, err
+		}
+
+		dailyTarget := vo.ReconstructCalories(targetCalories)
+		targetCalories = dailyTarget.WeeklyAllowance(consumedBefore, helper.DaysLeftInWeek(now)).Value()
+		output.WeeklyTargetCalories = dailyTarget.Value() * helper.DaysPerWeek
+		output.WeeklyTotalCalories = consumedBefore.Value() + totalCalories
+	}
+
+	output.TotalCalories = totalCalories
+	output.TargetCalories = targetCalories
+	output.Difference = targetCalories - totalCalories
+	return output, nil
+}
+
+// sumCalories は指定期間（startTime以上endTime未満）の合計カロリーを返す
+// 期間が空の場合はリポジトリを呼ばずに0を返す
+func (u *RecordUsecase) sumCalories(ctx context.Context, userID vo.UserID, startTime, endTime time.Time) (vo.Calories, error) {
+	total := vo.ZeroCalories()
+	if !startTime.Before(endTime) {
+		return total, nil
+	}
+
+	summaries, err := u.dailySummaryRepo.FindByUserIDAndDateRange(ctx, userID, startTime, endTime)
+	if err != nil {
+		return vo.ZeroCalories(), err
+	}
+	for _, summary := range summaries {
+		total = total.Add(summary.Calories())
+	}
+	return total, nil
 }
 
 // DailyStatistics は日別統計データ（グラフ表示用）
 type DailyStatistics struct {
 	Date           vo.EatenAt  // 対象日付
 	TotalCalories  vo.Calories // その日の合計カロリー
-	TargetCalories vo.Calories // 目標カロリー
+	TargetCalories vo.Calories // その日に有効だった目標カロリー（週単位モードではその日の配分）
 	IsAchieved     bool        // 達成フラグ（80%〜100%）
 	IsOver         bool        // 超過フラグ（100%超）
 }
@@ -203,7 +313,7 @@ type DailyStatistics struct {
 // StatisticsOutput は統計データ出力
 type StatisticsOutput struct {
 	Period          vo.StatisticsPeriod // 統計期間（week/month）
-	TargetCalories  vo.Calories         // 1日の目標カロリー
+	TargetCalories  vo.Calories         // 1日の目標カロリー（現在値）
 	AverageCalories vo.Calories         // 期間内の平均カロリー
 	TotalDays       int                 // 期間の日数
 	AchievedDays    int                 // 達成日数（80%〜100%）
@@ -227,25 +337,59 @@ func (u *RecordUsecase) GetStatistics(ctx context.Context, userID vo.UserID, per
 	// 目標カロリー取得
 	targetCalories := vo.ReconstructCalories(user.CalculateTargetCalories())
 
-	// 日別カロリーデータ取得
-	dailyCaloriesList, err := u.recordRepo.GetDailyCalories(ctx, userID, period)
+	// 期間の日別サマリー取得（今日を含む直近period.Days()日分）
+	now := time.Now()
+	periodStart := startOfDay(now).AddDate(0, 0, 1-period.Days())
+	summaries, err := u.dailySummaryRepo.FindByUserIDAndDateRange(ctx, userID, periodStart, endOfDay(now))
 	if err != nil {
 		logError("GetStatistics", err, "user_id", userID.String())
 		return nil, err
 	}
 
+	// 期間内の各日に有効な目標値スナップショットを取得
+	// 終了日は排他的なため、今日の変更も含めるよう翌日の0時を渡す
+	snapshots, err := u.targetSnapshotRepo.FindEffectiveByUserIDAndDateRange(ctx, userID, periodStart, startOfDay(now).AddDate(0, 0, 1))
+	if err != nil {
+		logError("GetStatistics", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// 週単位モードでは期間開始日を含む週の、期間開始前の摂取カロリーを取得
+	var weeklyConsumed weeklyConsumption
+	if user.BudgetMode().IsWeekly() {
+		weeklyConsumed, err = u.leadInWeeklyConsumption(ctx, userID, periodStart)
+		if err != nil {
+			logError("GetStatistics", err, "user_id", userID.String())
+			return nil, err
+		}
+	}
+
 	// 集計用変数の初期化
-	totalDays := len(dailyCaloriesList)
+	totalDays := len(summaries)
 	achievedDays := 0
 	overDays := 0
 	totalCaloriesSum := vo.ZeroCalories()
 	dailyStatistics := make([]DailyStatistics, 0, totalDays)
 
 	// 日別データをループして集計
-	for _, daily := range dailyCaloriesList {
+	for _, summary := range summaries {
+		date := summary.Date()
+		calories := summary.Calories()
+
+		// その日に有効だった目標値（スナップショットがなければ現在の目標値）
+		dailyTarget := targetCalories
+		if snapshot := entity.FindEffectiveTargetSnapshot(snapshots, date); snapshot != nil {
+			dailyTarget = snapshot.TargetCalories()
+		}
+
+		// 週単位モードでは週の残り予算をその日の残り日数で割った配分で判定する
+		if weeklyConsumed != nil {
+			dailyTarget = weeklyConsumed.allocate(dailyTarget, date, calories)
+		}
+
 		// 達成・超過判定（VOのメソッドで判定）
-		isAchieved := daily.Calories.IsAchieved(targetCalories)
-		isOver := daily.Calories.IsOver(targetCalories)
+		isAchieved := calories.IsAchieved(dailyTarget)
+		isOver := calories.IsOver(dailyTarget)
 
 		if isAchieved {
 			achievedDays++
@@ -254,12 +398,12 @@ func (u *RecordUsecase) GetStatistics(ctx context.Context, userID vo.UserID, per
 			overDays++
 		}
 
-		totalCaloriesSum = totalCaloriesSum.Add(daily.Calories)
+		totalCaloriesSum = totalCaloriesSum.Add(calories)
 
 		dailyStatistics = append(dailyStatistics, DailyStatistics{
-			Date:           daily.Date,
-			TotalCalories:  daily.Calories,
-			TargetCalories: targetCalories,
+			Date:           vo.ReconstructEatenAt(date),
+			TotalCalories:  calories,
+			TargetCalories: dailyTarget,
 			IsAchieved:     isAchieved,
 			IsOver:         isOver,
 		})
@@ -281,3 +425,143 @@ func (u *RecordUsecase) GetStatistics(ctx context.Context, userID vo.UserID, per
 		DailyStatistics: dailyStatistics,
 	}, nil
 }
+
+// weeklyConsumption は週単位モードの判定に使う、週の初日をキーにしたその週の摂取カロリー
+type weeklyConsumption map[string]vo.Calories
+
+// allocate は週の残り予算をその日の残り日数で割った配分を返し、その日の摂取カロリーを週の合計に加える
+// 日付の昇順に呼び出すこと
+func (w weeklyConsumption) allocate(dailyTarget vo.Calories, date time.Time, calories vo.Calories) vo.Calories {
+	weekKey := helper.StartOfWeek(date).Format(dateLayout)
+	allowance := dailyTarget.WeeklyAllowance(w[weekKey], helper.DaysLeftInWeek(date))
+	w[weekKey] = w[weekKey].Add(calories)
+	return allowance
+}
+
+// leadInWeeklyConsumption は期間開始日を含む週について、期間開始前に摂取したカロリーを週の初日をキーに返す
+func (u *RecordUsecase) leadInWeeklyConsumption(ctx context.Context, userID vo.UserID, periodStart time.Time) (weeklyConsumption, error) {
+	weekStart := helper.StartOfWeek(periodStart)
+	consumed, err := u.sumCalories(ctx, userID, weekStart, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	return weeklyConsumption{weekStart.Format(dateLayout): consumed}, nil
+}
+
+// CalendarDay はカレンダーの1日分のデータ
+type CalendarDay struct {
+	Date           time.Time   // 対象日付（JSTの0時）
+	TotalCalories  vo.Calories // その日の合計カロリー
+	TargetCalories vo.Calories // その日に有効だった目標カロリー（週単位モードではその日の配分）
+	IsAchieved     bool        // 達成フラグ（80%〜100%）
+	IsOver         bool        // 超過フラグ（100%超）
+	RecordCount    int         // その日の記録件数
+	HasAdvice      bool        // その日のAIアドバイスが存在するか
+}
+
+// CalendarOutput は月間カレンダーデータ出力
+type CalendarOutput struct {
+	Month vo.CalendarMonth // 対象月
+	Days  []CalendarDay    // 月の全日分のデータ（記録がない日を含む）
+}
+
+// GetCalendar は認証ユーザーの指定月のカレンダーデータを取得する
+func (u *RecordUsecase) GetCalendar(ctx context.Context, userID vo.UserID, month vo.CalendarMonth) (*CalendarOutput, error) {
+	// ユーザー取得（目標カロリー計算のため）
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError("GetCalendar", err, "user_id", userID.String())
+		return nil, err
+	}
+	if user == nil {
+		logWarn("GetCalendar", "user not found", "user_id", userID.String())
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	start := month.Start()
+	end := month.End()
+
+	// 日別のカロリー・記録件数取得
+	summaries, err := u.dailySummaryRepo.FindByUserIDAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		logError("GetCalendar", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// アドバイスが存在する日付取得
+	adviceDates, err := u.adviceCacheRepo.FindCacheDatesByUserIDAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		logError("GetCalendar", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// 月内の各日に有効な目標値スナップショットを取得
+	snapshots, err := u.targetSnapshotRepo.FindEffectiveByUserIDAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		logError("GetCalendar", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	// 週単位モードでは月初日を含む週の、月初日より前の摂取カロリーを取得
+	var weeklyConsumed weeklyConsumption
+	if user.BudgetMode().IsWeekly() {
+		weeklyConsumed, err = u.leadInWeeklyConsumption(ctx, userID, start)
+		if err != nil {
+			logError("GetCalendar", err, "user_id", userID.String())
+			return nil, err
+		}
+	}
+
+	// 日付をキーにした参照用マップ
+	summaryByDate := make(map[string]*entity.DailySummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByDate[summary.Date().Format(dateLayout)] = summary
+	}
+	hasAdviceByDate := make(map[string]bool, len(adviceDates))
+	for _, date := range adviceDates {
+		hasAdviceByDate[date.Format(dateLayout)] = true
+	}
+
+	targetCalories := vo.ReconstructCalories(user.CalculateTargetCalories())
+
+	// 記録がない日も含めて月の全日分を組み立てる
+	days := make([]CalendarDay, 0, month.Days())
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		key := date.Format(dateLayout)
+
+		// その日に有効だった目標値（スナップショットがなければ現在の目標値）
+		dailyTarget := targetCalories
+		if snapshot := entity.FindEffectiveTargetSnapshot(snapshots, date); snapshot != nil {
+			dailyTarget = snapshot.TargetCalories()
+		}
+
+		summary, hasSummary := summaryByDate[key]
+		calories := vo.ZeroCalories()
+		if hasSummary {
+			calories = summary.Calories()
+		}
+
+		// 週単位モードでは週の残り予算をその日の残り日数で割った配分で判定する
+		if weeklyConsumed != nil {
+			dailyTarget = weeklyConsumed.allocate(dailyTarget, date, calories)
+		}
+
+		day := CalendarDay{
+			Date:           date,
+			TotalCalories:  calories,
+			TargetCalories: dailyTarget,
+			HasAdvice:      hasAdviceByDate[key],
+		}
+		if hasSummary {
+			day.RecordCount = summary.RecordCount()
+			day.IsAchieved = calories.IsAchieved(dailyTarget)
+			day.IsOver = calories.IsOver(dailyTarget)
+		}
+		days = append(days, day)
+	}
+
+	return &CalendarOutput{
+		Month: month,
+		Days:  days,
+	}, nil
+}
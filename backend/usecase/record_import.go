@@ -0,0 +1,535 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// recordImportMaxRows は1回のインポートで受け付けるデータ行数の上限
+const recordImportMaxRows = 5000
+
+// 行単位のエラーメッセージ
+const (
+	importRowDateRequired    = "date is required"
+	importRowInvalidDate     = "date must be in a supported format (e.g. 2006-01-02 or 2006-01-02 15:04)"
+	importRowInvalidTime     = "time must be in a supported format (e.g. 15:04 or 3:04 PM)"
+	importRowInvalidCalorie  = "calories must be a number"
+	importRowBeforeRetention = "date must be within the data retention period"
+)
+
+// RecordImportMapping はCSVの列名と取り込み項目の対応
+// Date・Name・Caloriesは必須。Time・Mealは省略でき、CSVに列がない場合も無視する
+// Mealは記録をまとめる単位として使い、Timeがなく日付のみの場合は食事区分から時刻を補完する
+type RecordImportMapping struct {
+	Date     string
+	Time     string
+	Meal     string
+	Name     string
+	Calories string
+}
+
+// recordImportPresets は主要な記録アプリのエクスポート形式に対応する列の対応
+var recordImportPresets = map[string]RecordImportMapping{
+	// CalTrackのエクスポート（records.csv）。記録IDで元の記録単位にまとめる
+	"caltrack":     {Date: "eaten_at", Meal: "record_id", Name: "item_name", Calories: "item_calories"},
+	"myfitnesspal": {Date: "Date", Time: "Time", Meal: "Meal", Name: "Food Name", Calories: "Calories"},
+	"cronometer":   {Date: "Day", Time: "Time", Meal: "Group", Name: "Food Name", Calories: "Energy (kcal)"},
+	"loseit":       {Date: "Date", Meal: "Type", Name: "Name", Calories: "Calories"},
+}
+
+// RecordImportPreset はプリセット名に対応する列の対応を返す
+// 存在しない場合は ErrImportPresetNotFound を返す
+func RecordImportPreset(name string) (RecordImportMapping, error) {
+	mapping, ok := recordImportPresets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return RecordImportMapping{}, domainErrors.ErrImportPresetNotFound
+	}
+	return mapping, nil
+}
+
+// mealDefaultHours は時刻がない行に補完する食事区分ごとの時刻（JST）
+var mealDefaultHours = map[string]int{
+	"breakfast":  8,
+	"朝食":         8,
+	"lunch":      12,
+	"昼食":         12,
+	"snack":      15,
+	"snacks":     15,
+	"間食":         15,
+	"おやつ":        15,
+	"dinner":     19,
+	"夕食":         19,
+	"late night": 22,
+	"夜食":         22,
+}
+
+// defaultMealHour は食事区分が不明な場合に補完する時刻（JST）
+const defaultMealHour = 12
+
+// 日付列として受け付ける形式（時刻を含むかどうか）
+var importDateLayouts = []struct {
+	layout  string
+	hasTime bool
+}{
+	{time.RFC3339, true},
+	{"2006-01-02T15:04:05", true},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02 15:04", true},
+	{"2006/01/02 15:04:05", true},
+	{"2006/01/02 15:04", true},
+	{"2006-01-02", false},
+	{"2006/01/02", false},
+	{"1/2/2006", false},
+}
+
+// 時刻列として受け付ける形式
+var importTimeLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM", "3:04:05 PM"}
+
+// RecordImportRowError はインポートできなかった行とその理由
+// LineはヘッダーをLine 1とするCSV上の行番号
+type RecordImportRowError struct {
+	Line    int
+	Message string
+}
+
+// RecordImportOutput はインポート結果
+type RecordImportOutput struct {
+	DryRun           bool                   // trueの場合は保存していない
+	TotalRows        int                    // ヘッダーを除くデータ行数
+	Records          []*entity.Record       // 取り込んだ（DryRunの場合は取り込む予定の）記録
+	DuplicateRecords int                    // 取り込み済みのためスキップした記録数
+	Errors           []RecordImportRowError // 取り込めなかった行
+}
+
+// ImportedItems は取り込んだ食品の数を返す
+func (o *RecordImportOutput) ImportedItems() int {
+	count := 0
+	for _, record := range o.Records {
+		count += len(record.Items())
+	}
+	return count
+}
+
+// RecordImportUsecase は他の記録アプリからの食事履歴の取り込みに関するユースケースを提供する
+type RecordImportUsecase struct {
+	recordRepo       repository.RecordRepository
+	userRepo         repository.UserRepository
+	adviceCacheRepo  repository.AdviceCacheRepository
+	dailySummaryRepo repository.DailySummaryRepository
+	txManager        repository.TransactionManager
+	auditRecorder    service.AuditRecorder
+	badgeUnlocker    service.BadgeUnlocker
+	retentionYears   int
+}
+
+// NewRecordImportUsecase は RecordImportUsecase のインスタンスを生成する
+// recordRetentionYearsはデプロイ全体の記録の保存年数（0の場合は無期限）
+func NewRecordImportUsecase(
+	recordRepo repository.RecordRepository,
+	userRepo repository.UserRepository,
+	adviceCacheRepo repository.AdviceCacheRepository,
+	dailySummaryRepo repository.DailySummaryRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+	badgeUnlocker service.BadgeUnlocker,
+	recordRetentionYears int,
+) *RecordImportUsecase {
+	return &RecordImportUsecase{
+		recordRepo:       recordRepo,
+		userRepo:         userRepo,
+		adviceCacheRepo:  adviceCacheRepo,
+		dailySummaryRepo: dailySummaryRepo,
+		txManager:        txManager,
+		auditRecorder:    auditRecorder,
+		badgeUnlocker:    badgeUnlocker,
+		retentionYears:   recordRetentionYears,
+	}
+}
+
+// importRow はCSVの1行を取り込み用に解釈した結果
+type importRow struct {
+	line     int
+	eatenAt  time.Time
+	meal     string
+	name     string
+	calories int
+}
+
+// importGroup は1件の記録にまとめる行の集まり
+type importGroup struct {
+	eatenAt time.Time
+	rows    []importRow
+}
+
+// Import はCSVから食事記録を取り込む
+// 日時と食事区分が同じ行を1件の記録にまとめ、entity.NewRecord/AddItemで検証する
+// 不正な行と保存期間より前の日時の行はスキップしてErrorsに含め、同じ日時・同じ食品の記録が既にある場合は重複としてスキップする
+// dryRunがtrueの場合は検証と重複判定のみ行い保存しない
+// 取り込んだ記録のPFCは推定しない（大量のAI呼び出しを避けるため）
+func (u *RecordImportUsecase) Import(ctx context.Context, userID vo.UserID, r io.Reader, mapping RecordImportMapping, dryRun bool) (*RecordImportOutput, error) {
+	rows, rowErrors, err := parseImportCSV(r, mapping)
+	if err != nil {
+		logWarn("Import", "invalid csv", "user_id", userID.String(), "error", err.Error())
+		return nil, err
+	}
+
+	output := &RecordImportOutput{
+		DryRun:    dryRun,
+		TotalRows: len(rows) + len(rowErrors),
+		Errors:    rowErrors,
+	}
+
+	rows, retentionErrors, err := u.excludeBeforeRetention(ctx, userID, rows)
+	if err != nil {
+		logError("Import", err, "user_id", userID.String())
+		return nil, err
+	}
+	output.Errors = append(output.Errors, retentionErrors...)
+
+	records, buildErrors := buildImportRecords(userID, groupImportRows(rows))
+	output.Errors = append(output.Errors, buildErrors...)
+	sort.Slice(output.Errors, func(i, j int) bool { return output.Errors[i].Line < output.Errors[j].Line })
+
+	records, duplicates, err := u.excludeDuplicates(ctx, userID, records)
+	if err != nil {
+		logError("Import", err, "user_id", userID.String())
+		return nil, err
+	}
+	output.Records = records
+	output.DuplicateRecords = duplicates
+
+	if dryRun || len(records) == 0 {
+		return output, nil
+	}
+
+	err = u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		days := make(map[string]time.Time)
+		for _, record := range records {
+			if err := u.recordRepo.Save(txCtx, record); err != nil {
+				logError("Import", err, "record_id", record.ID().String())
+				return err
+			}
+			day := startOfDay(record.EatenAt().Time())
+			days[day.Format(dateLayout)] = day
+		}
+
+		// 取り込んだ日の日別サマリーを再集計し、アドバイスのキャッシュを無効化する
+		for _, day := range days {
+			if err := u.dailySummaryRepo.Refresh(txCtx, userID, day); err != nil {
+				logError("Import", err, "user_id", userID.String(), "date", day.Format(dateLayout))
+				return err
+			}
+			if err := u.adviceCacheRepo.DeleteByUserIDAndDate(txCtx, userID, day); err != nil {
+				// キャッシュ削除失敗はログのみ（取り込みは成功として扱う）
+				logError("Import", err, "user_id", userID.String(), "cache_delete_failed", true)
+			}
+		}
+
+		// 更新後のサマリーでマイルストーンバッジの獲得を判定
+		if err := u.badgeUnlocker.UnlockMilestoneBadges(txCtx, userID); err != nil {
+			// バッジ保存失敗はログのみ（取り込みは成功として扱う）
+			logError("Import", err, "user_id", userID.String(), "badge_unlock_failed", true)
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionRecordImport, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"records":    len(records),
+			"items":      output.ImportedItems(),
+			"duplicates": duplicates,
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo("Import", "records imported", "user_id", userID.String(), "records", len(records), "duplicates", duplicates, "errors", len(output.Errors))
+	return output, nil
+}
+
+// excludeBeforeRetention は保存期間より前の日時の行を除外し、行のエラーとして返す
+// 記録を削除した期間の日別集計が、取り込み時の再集計で上書きされないようにするため
+func (u *RecordImportUsecase) excludeBeforeRetention(ctx context.Context, userID vo.UserID, rows []importRow) ([]importRow, []RecordImportRowError, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, domainErrors.ErrUserNotFound
+	}
+	cutoff, ok := recordRetentionCutoff(time.Now(), u.retentionYears, user.PrivacySettings().RetentionYears())
+	if !ok {
+		return rows, nil, nil
+	}
+
+	kept := make([]importRow, 0, len(rows))
+	var rowErrors []RecordImportRowError
+	for _, row := range rows {
+		if row.eatenAt.Before(cutoff) {
+			rowErrors = append(rowErrors, RecordImportRowError{Line: row.line, Message: importRowBeforeRetention})
+			continue
+		}
+		kept = append(kept, row)
+	}
+	return kept, rowErrors, nil
+}
+
+// excludeDuplicates は既存の記録と日時・食品が一致する記録を除外する
+// 同じファイル内で一致する記録も1件目以外を除外する
+func (u *RecordImportUsecase) excludeDuplicates(ctx context.Context, userID vo.UserID, records []*entity.Record) ([]*entity.Record, int, error) {
+	if len(records) == 0 {
+		return records, 0, nil
+	}
+
+	// recordsは日時の昇順
+	start := records[0].EatenAt().Time().Truncate(time.Second)
+	end := records[len(records)-1].EatenAt().Time().Add(time.Second)
+	existing, err := u.recordRepo.FindByUserIDAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(records))
+	for _, record := range existing {
+		seen[recordFingerprint(record)] = true
+	}
+
+	unique := make([]*entity.Record, 0, len(records))
+	duplicates := 0
+	for _, record := range records {
+		key := recordFingerprint(record)
+		if seen[key] {
+			duplicates++
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, record)
+	}
+	return unique, duplicates, nil
+}
+
+// recordFingerprint は重複判定に使う記録の内容（秒単位の日時と、食品名・カロリーの組）を表す文字列を返す
+func recordFingerprint(record *entity.Record) string {
+	items := make([]string, len(record.Items()))
+	for i, item := range record.Items() {
+		items[i] = fmt.Sprintf("%s\x1f%d", item.Name().String(), item.Calories().Value())
+	}
+	sort.Strings(items)
+	return record.EatenAt().Time().UTC().Truncate(time.Second).Format(time.RFC3339) + "\x1e" + strings.Join(items, "\x1e")
+}
+
+// parseImportCSV はCSVを読み込み、取り込み可能な行と不正な行のエラーに分ける
+// ヘッダーや列の対応が不正な場合はエラーを返す
+func parseImportCSV(r io.Reader, mapping RecordImportMapping) ([]importRow, []RecordImportRowError, error) {
+	if mapping.Date == "" || mapping.Name == "" || mapping.Calories == "" {
+		return nil, nil, domainErrors.ErrImportColumnMappingRequired
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, domainErrors.ErrImportFileEmpty
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidImportCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(name string, required bool) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		if i, ok := columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			return i, nil
+		}
+		if required {
+			return -1, fmt.Errorf("%w: %s", domainErrors.ErrImportColumnNotFound, name)
+		}
+		return -1, nil
+	}
+
+	dateCol, err := column(mapping.Date, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	nameCol, err := column(mapping.Name, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	caloriesCol, err := column(mapping.Calories, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeCol, _ := column(mapping.Time, false)
+	mealCol, _ := column(mapping.Meal, false)
+
+	var rows []importRow
+	var rowErrors []RecordImportRowError
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidImportCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRow(fields) {
+			continue
+		}
+		if len(rows)+len(rowErrors) >= recordImportMaxRows {
+			return nil, nil, domainErrors.ErrImportTooManyRows
+		}
+
+		field := func(i int) string {
+			if i < 0 || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+
+		meal := field(mealCol)
+		eatenAt, message := parseImportEatenAt(field(dateCol), field(timeCol), meal)
+		if message == "" {
+			var calories int
+			calories, message = parseImportCalories(field(caloriesCol))
+			if message == "" {
+				rows = append(rows, importRow{line: line, eatenAt: eatenAt, meal: meal, name: field(nameCol), calories: calories})
+				continue
+			}
+		}
+		rowErrors = append(rowErrors, RecordImportRowError{Line: line, Message: message})
+	}
+
+	if len(rows)+len(rowErrors) == 0 {
+		return nil, nil, domainErrors.ErrImportFileEmpty
+	}
+	return rows, rowErrors, nil
+}
+
+// isBlankRow は全ての列が空の行かを判定する
+func isBlankRow(fields []string) bool {
+	for _, f := range fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportEatenAt は日付・時刻・食事区分の列から食事日時を求める（タイムゾーンの指定がない場合はJST）
+// 解釈できない場合は行のエラーメッセージを返す
+func parseImportEatenAt(dateStr, timeStr, meal string) (time.Time, string) {
+	if dateStr == "" {
+		return time.Time{}, importRowDateRequired
+	}
+
+	var date time.Time
+	hasTime := false
+	parsed := false
+	for _, l := range importDateLayouts {
+		t, err := time.ParseInLocation(l.layout, dateStr, helper.JST())
+		if err == nil {
+			date, hasTime, parsed = t, l.hasTime, true
+			break
+		}
+	}
+	if !parsed {
+		return time.Time{}, importRowInvalidDate
+	}
+	if hasTime {
+		return date, ""
+	}
+
+	if timeStr != "" {
+		for _, layout := range importTimeLayouts {
+			t, err := time.Parse(layout, strings.ToUpper(timeStr))
+			if err == nil {
+				return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, helper.JST()), ""
+			}
+		}
+		return time.Time{}, importRowInvalidTime
+	}
+
+	hour, ok := mealDefaultHours[strings.ToLower(meal)]
+	if !ok {
+		hour = defaultMealHour
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, helper.JST()), ""
+}
+
+// parseImportCalories はカロリー列を整数に変換する（小数は四捨五入、桁区切りのカンマは無視する）
+func parseImportCalories(s string) (int, string) {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, importRowInvalidCalorie
+	}
+	return int(math.Round(value)), ""
+}
+
+// groupImportRows は日時と食事区分が同じ行をまとめ、日時の昇順で返す
+func groupImportRows(rows []importRow) []*importGroup {
+	groups := make(map[string]*importGroup)
+	var ordered []*importGroup
+	for _, row := range rows {
+		key := fmt.Sprintf("%d\x1f%s", row.eatenAt.Unix(), strings.ToLower(row.meal))
+		group, ok := groups[key]
+		if !ok {
+			group = &importGroup{eatenAt: row.eatenAt}
+			groups[key] = group
+			ordered = append(ordered, group)
+		}
+		group.rows = append(group.rows, row)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].eatenAt.Before(ordered[j].eatenAt) })
+	return ordered
+}
+
+// buildImportRecords はまとめた行から記録を生成する
+// 記録として不正な場合はその記録の全ての行を、食品として不正な場合はその行のみをエラーとする
+func buildImportRecords(userID vo.UserID, groups []*importGroup) ([]*entity.Record, []RecordImportRowError) {
+	var records []*entity.Record
+	var rowErrors []RecordImportRowError
+	for _, group := range groups {
+		record, err := entity.NewRecord(userID, group.eatenAt)
+		if err != nil {
+			for _, row := range group.rows {
+				rowErrors = append(rowErrors, RecordImportRowError{Line: row.line, Message: err.Error()})
+			}
+			continue
+		}
+		for _, row := range group.rows {
+			if err := record.AddItem(row.name, row.calories); err != nil {
+				rowErrors = append(rowErrors, RecordImportRowError{Line: row.line, Message: err.Error()})
+			}
+		}
+		if len(record.Items()) > 0 {
+			records = append(records, record)
+		}
+	}
+	return records, rowErrors
+}
@@ -0,0 +1,317 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// recordImportMocks はRecordImport Usecaseのテスト用モックをまとめる
+type recordImportMocks struct {
+	recordRepo       *mock.MockRecordRepository
+	userRepo         *mock.MockUserRepository
+	adviceCacheRepo  *mock.MockAdviceCacheRepository
+	dailySummaryRepo *mock.MockDailySummaryRepository
+	txManager        *mock.MockTransactionManager
+	badgeUnlocker    *mock.MockBadgeUnlocker
+	auditEvents      *auditEvents
+}
+
+// setupRecordImportMocks はモックとRecordImportUsecaseを初期化する
+func setupRecordImportMocks(t *testing.T) (*recordImportMocks, *usecase.RecordImportUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	m := &recordImportMocks{
+		recordRepo:       mock.NewMockRecordRepository(ctrl),
+		userRepo:         mock.NewMockUserRepository(ctrl),
+		adviceCacheRepo:  mock.NewMockAdviceCacheRepository(ctrl),
+		dailySummaryRepo: mock.NewMockDailySummaryRepository(ctrl),
+		txManager:        mock.NewMockTransactionManager(ctrl),
+		badgeUnlocker:    mock.NewMockBadgeUnlocker(ctrl),
+		auditEvents:      auditEvents,
+	}
+	return m, usecase.NewRecordImportUsecase(m.recordRepo, m.userRepo, m.adviceCacheRepo, m.dailySummaryRepo, m.txManager, auditRecorder, m.badgeUnlocker, 0)
+}
+
+// cronometerMapping はテスト用のcronometerプリセット
+func cronometerMapping(t *testing.T) usecase.RecordImportMapping {
+	t.Helper()
+	mapping, err := usecase.RecordImportPreset("cronometer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return mapping
+}
+
+func TestRecordImportUsecase_Import(t *testing.T) {
+	userID := vo.NewUserID()
+
+	t.Run("正常系_日時と食事区分が同じ行を1件の記録にまとめて保存する", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n" +
+			"2024-05-01,7:30 AM,Breakfast,Toast,180.4\n" +
+			"2024-05-01,7:30 AM,Breakfast,Coffee,5\n" +
+			"2024-05-01,12:15,Lunch,Ramen,\"1,050\"\n" +
+			"2024-05-02,,Dinner,Curry,800\n"
+
+		var saved []*entity.Record
+		setupTxManagerExecute(m.txManager)
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+		m.recordRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, record *entity.Record) error {
+				saved = append(saved, record)
+				return nil
+			}).
+			Times(3)
+		m.dailySummaryRepo.EXPECT().Refresh(gomock.Any(), userID, gomock.Any()).Return(nil).Times(2)
+		m.adviceCacheRepo.EXPECT().DeleteByUserIDAndDate(gomock.Any(), userID, gomock.Any()).Return(nil).Times(2)
+		// 再集計後のサマリーでバッジの獲得を判定する
+		m.badgeUnlocker.EXPECT().UnlockMilestoneBadges(gomock.Any(), userID).Return(nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), false)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.TotalRows != 4 || len(output.Records) != 3 || output.ImportedItems() != 4 || len(output.Errors) != 0 {
+			t.Errorf("output = %+v, want 4 rows, 3 records, 4 items, no errors", output)
+		}
+		if len(saved) != 3 {
+			t.Fatalf("saved %d records, want 3", len(saved))
+		}
+		breakfast := saved[0]
+		if len(breakfast.Items()) != 2 || breakfast.TotalCalories() != 185 {
+			t.Errorf("breakfast = %v items, %d kcal, want 2 items, 185 kcal", len(breakfast.Items()), breakfast.TotalCalories())
+		}
+		wantBreakfast := time.Date(2024, 5, 1, 7, 30, 0, 0, helper.JST())
+		if !breakfast.EatenAt().Time().Equal(wantBreakfast) {
+			t.Errorf("breakfast eatenAt = %v, want %v", breakfast.EatenAt().Time(), wantBreakfast)
+		}
+		if saved[1].TotalCalories() != 1050 {
+			t.Errorf("lunch calories = %d, want 1050", saved[1].TotalCalories())
+		}
+		// 時刻がない場合は食事区分から補完する
+		wantDinner := time.Date(2024, 5, 2, 19, 0, 0, 0, helper.JST())
+		if !saved[2].EatenAt().Time().Equal(wantDinner) {
+			t.Errorf("dinner eatenAt = %v, want %v", saved[2].EatenAt().Time(), wantDinner)
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Diff()["records"] != 3 {
+			t.Errorf("audit events = %v, want one import event with 3 records", m.auditEvents.actions())
+		}
+	})
+
+	t.Run("正常系_不正な行はスキップして行番号とともに返す", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		future := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n" +
+			"2024-05-01,08:00,Breakfast,Toast,180\n" +
+			"2024-05-01,08:00,Breakfast,,50\n" +
+			"not-a-date,08:00,Breakfast,Egg,80\n" +
+			"2024-05-01,08:00,Breakfast,Water,0\n" +
+			"2024-05-01,08:00,Breakfast,Milk,abc\n" +
+			future + ",08:00,Breakfast,Bread,200\n"
+
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), true)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !output.DryRun || len(output.Records) != 1 || output.ImportedItems() != 1 {
+			t.Errorf("output = %+v, want 1 record with 1 item", output)
+		}
+		want := []struct {
+			line    int
+			message string
+		}{
+			{3, domainErrors.ErrItemNameRequired.Error()},
+			{4, "date must be in a supported format (e.g. 2006-01-02 or 2006-01-02 15:04)"},
+			{5, domainErrors.ErrCaloriesMustBePositive.Error()},
+			{6, "calories must be a number"},
+			{7, domainErrors.ErrEatenAtMustNotBeFuture.Error()},
+		}
+		if len(output.Errors) != len(want) {
+			t.Fatalf("errors = %+v, want %d errors", output.Errors, len(want))
+		}
+		for i, w := range want {
+			if output.Errors[i].Line != w.line || output.Errors[i].Message != w.message {
+				t.Errorf("errors[%d] = %+v, want line %d: %s", i, output.Errors[i], w.line, w.message)
+			}
+		}
+	})
+
+	t.Run("正常系_保存期間より前の日時の行はスキップして行番号とともに返す", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(retentionUserForRecord(t, userID, 1), nil)
+		expired := time.Now().AddDate(-2, 0, 0).Format("2006-01-02")
+		recent := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n" +
+			expired + ",08:00,Breakfast,Toast,180\n" +
+			recent + ",08:00,Breakfast,Toast,180\n"
+
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), true)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.TotalRows != 2 || len(output.Records) != 1 {
+			t.Errorf("output = %+v, want 2 rows, 1 record", output)
+		}
+		if len(output.Errors) != 1 || output.Errors[0].Line != 2 || output.Errors[0].Message != "date must be within the data retention period" {
+			t.Errorf("errors = %+v, want line 2: date must be within the data retention period", output.Errors)
+		}
+	})
+
+	t.Run("正常系_取り込み済みの記録は重複としてスキップする", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		eatenAt := time.Date(2024, 5, 1, 8, 0, 0, 0, helper.JST())
+		existing, _ := entity.NewRecord(userID, eatenAt)
+		_ = existing.AddItem("Coffee", 5)
+		_ = existing.AddItem("Toast", 180)
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n" +
+			"2024-05-01,08:00,Breakfast,Toast,180\n" +
+			"2024-05-01,08:00,Breakfast,Coffee,5\n" +
+			"2024-05-01,12:00,Lunch,Ramen,700\n"
+
+		setupTxManagerExecute(m.txManager)
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return([]*entity.Record{existing}, nil)
+		m.recordRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		m.dailySummaryRepo.EXPECT().Refresh(gomock.Any(), userID, gomock.Any()).Return(nil)
+		m.adviceCacheRepo.EXPECT().DeleteByUserIDAndDate(gomock.Any(), userID, gomock.Any()).Return(nil)
+		m.badgeUnlocker.EXPECT().UnlockMilestoneBadges(gomock.Any(), userID).Return(nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), false)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.DuplicateRecords != 1 || len(output.Records) != 1 {
+			t.Errorf("output = %+v, want 1 duplicate and 1 record", output)
+		}
+	})
+
+	t.Run("正常系_ドライランの場合は保存しない", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n2024-05-01,08:00,Breakfast,Toast,180\n"
+
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), true)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !output.DryRun || len(output.Records) != 1 {
+			t.Errorf("output = %+v, want dry run with 1 record", output)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+
+	t.Run("正常系_CalTrackのエクスポートは記録IDでまとめる", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		mapping, _ := usecase.RecordImportPreset("caltrack")
+		csv := "\ufeffrecord_id,eaten_at,item_id,item_name,item_calories\n" +
+			"r1,2024-05-01T08:00:00+09:00,i1,ご飯,250\n" +
+			"r1,2024-05-01T08:00:00+09:00,i2,味噌汁,50\n" +
+			"r2,2024-05-01T08:00:00+09:00,i3,りんご,80\n"
+
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		output, err := uc.Import(context.Background(), userID, strings.NewReader(csv), mapping, true)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(output.Records) != 2 || output.ImportedItems() != 3 {
+			t.Errorf("output = %+v, want 2 records with 3 items", output)
+		}
+	})
+
+	t.Run("異常系_必須の列がCSVにない場合はエラー", func(t *testing.T) {
+		_, uc := setupRecordImportMocks(t)
+		csv := "Day,Food Name\n2024-05-01,Toast\n"
+
+		_, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), true)
+
+		if !errors.Is(err, domainErrors.ErrImportColumnNotFound) {
+			t.Errorf("got %v, want ErrImportColumnNotFound", err)
+		}
+	})
+
+	t.Run("異常系_列の対応が指定されていない場合はエラー", func(t *testing.T) {
+		_, uc := setupRecordImportMocks(t)
+
+		_, err := uc.Import(context.Background(), userID, strings.NewReader("a,b\n1,2\n"), usecase.RecordImportMapping{Date: "a"}, true)
+
+		if !errors.Is(err, domainErrors.ErrImportColumnMappingRequired) {
+			t.Errorf("got %v, want ErrImportColumnMappingRequired", err)
+		}
+	})
+
+	t.Run("異常系_データ行がない場合はエラー", func(t *testing.T) {
+		_, uc := setupRecordImportMocks(t)
+
+		_, err := uc.Import(context.Background(), userID, strings.NewReader("Day,Time,Group,Food Name,Energy (kcal)\n"), cronometerMapping(t), true)
+
+		if !errors.Is(err, domainErrors.ErrImportFileEmpty) {
+			t.Errorf("got %v, want ErrImportFileEmpty", err)
+		}
+	})
+
+	t.Run("異常系_保存に失敗した場合はエラーを返す", func(t *testing.T) {
+		m, uc := setupRecordImportMocks(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(validUserForRecord(t, userID), nil)
+		dbErr := errors.New("db error")
+		csv := "Day,Time,Group,Food Name,Energy (kcal)\n2024-05-01,08:00,Breakfast,Toast,180\n"
+
+		setupTxManagerExecute(m.txManager)
+		m.recordRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+		m.recordRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		_, err := uc.Import(context.Background(), userID, strings.NewReader(csv), cronometerMapping(t), false)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want dbErr", err)
+		}
+	})
+}
+
+func TestRecordImportPreset(t *testing.T) {
+	t.Run("正常系_大文字小文字を区別しない", func(t *testing.T) {
+		mapping, err := usecase.RecordImportPreset("MyFitnessPal")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mapping.Date != "Date" {
+			t.Errorf("Date = %s, want Date", mapping.Date)
+		}
+	})
+
+	t.Run("異常系_存在しないプリセット", func(t *testing.T) {
+		if _, err := usecase.RecordImportPreset("unknown"); !errors.Is(err, domainErrors.ErrImportPresetNotFound) {
+			t.Errorf("got %v, want ErrImportPresetNotFound", err)
+		}
+	})
+}
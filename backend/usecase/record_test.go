@@ -8,7 +8,7 @@ import (
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
-	"caltrack/domain/repository"
+	"caltrack/domain/helper"
 	"caltrack/domain/vo"
 	"caltrack/mock"
 	"caltrack/usecase"
@@ -23,6 +23,8 @@ func setupRecordMocks(t *testing.T) (
 	*mock.MockRecordPfcRepository,
 	*mock.MockUserRepository,
 	*mock.MockAdviceCacheRepository,
+	*mock.MockTargetSnapshotRepository,
+	*mock.MockDailySummaryRepository,
 	*mock.MockTransactionManager,
 	*mock.MockPfcEstimator,
 	*mock.MockAIConfig,
@@ -37,12 +39,31 @@ func setupRecordMocks(t *testing.T) (
 		mock.NewMockRecordPfcRepository(ctrl),
 		mock.NewMockUserRepository(ctrl),
 		mock.NewMockAdviceCacheRepository(ctrl),
+		mock.NewMockTargetSnapshotRepository(ctrl),
+		mock.NewMockDailySummaryRepository(ctrl),
 		mock.NewMockTransactionManager(ctrl),
 		mock.NewMockPfcEstimator(ctrl),
 		aiConfig,
 		ctrl
 }
 
+// newRecordUsecase はsetupRecordMocksのモックからRecordUsecaseを生成する
+// 監査ログ・バッジの獲得判定・AI使用量の保存は同じコントローラーで常に成功するモックを使う
+func newRecordUsecase(
+	ctrl *gomock.Controller,
+	recordRepo *mock.MockRecordRepository,
+	recordPfcRepo *mock.MockRecordPfcRepository,
+	userRepo *mock.MockUserRepository,
+	adviceCacheRepo *mock.MockAdviceCacheRepository,
+	targetSnapshotRepo *mock.MockTargetSnapshotRepository,
+	dailySummaryRepo *mock.MockDailySummaryRepository,
+	txManager *mock.MockTransactionManager,
+	pfcEstimator *mock.MockPfcEstimator,
+	aiConfig *mock.MockAIConfig,
+) *usecase.RecordUsecase {
+	return usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, allowAuditRecord(ctrl), allowBadgeUnlock(ctrl), allowAIUsageSave(ctrl), pfcEstimator, aiConfig, 0)
+}
+
 // validRecord はテスト用の有効なRecordを生成する
 func validRecord(t *testing.T) *entity.Record {
 	t.Helper()
@@ -68,6 +89,11 @@ func validUserForRecord(t *testing.T, userID vo.UserID) *entity.User {
 		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 		"male",
 		"moderate",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Now(),
 		time.Now(),
 	)
@@ -77,14 +103,52 @@ func validUserForRecord(t *testing.T, userID vo.UserID) *entity.User {
 	return user
 }
 
+// dailySummary はテスト用の日別サマリーを生成する
+func dailySummary(userID vo.UserID, date time.Time, calories int) *entity.DailySummary {
+	return entity.ReconstructDailySummary(userID.String(), date, calories, 0, 0, 0, 1, time.Now())
+}
+
+// weeklyUserForRecord は週単位モードのテスト用ユーザーを生成する
+func weeklyUserForRecord(t *testing.T, userID vo.UserID) *entity.User {
+	t.Helper()
+	user := validUserForRecord(t, userID)
+	weekly, err := vo.NewBudgetMode("weekly")
+	if err != nil {
+		t.Fatalf("failed to create budget mode: %v", err)
+	}
+	user.ChangeBudgetMode(weekly)
+	return user
+}
+
+// retentionUserForRecord は記録の保存期間（年）を設定したRecord用テストのUserを生成する
+func retentionUserForRecord(t *testing.T, userID vo.UserID, years int) *entity.User {
+	t.Helper()
+	user := validUserForRecord(t, userID)
+	settings, err := vo.NewPrivacySettings(true, false, years, "2026-10")
+	if err != nil {
+		t.Fatalf("failed to create privacy settings: %v", err)
+	}
+	user.ChangePrivacySettings(settings)
+	return user
+}
+
+// verifiedUser はメールアドレスを確認済みのテスト用ユーザーを生成する
+func verifiedUser(t *testing.T) *entity.User {
+	t.Helper()
+	user := validUser(t)
+	user.ConfirmEmail(user.Email())
+	return user
+}
+
 func TestRecordUsecase_Create(t *testing.T) {
 	t.Run("正常系_記録が保存されキャッシュが無効化される", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		record := validRecord(t)
 		var savedRecord *entity.Record
 		var savedRecordPfc *entity.RecordPfc
+		summaryRefreshed := false
 		cacheDeleted := false
 
 		setupTxManagerExecute(txManager)
@@ -94,6 +158,9 @@ func TestRecordUsecase_Create(t *testing.T) {
 				savedRecord = r
 				return nil
 			})
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(verifiedUser(t), nil)
 		recordPfcRepo.EXPECT().
 			Save(gomock.Any(), gomock.Any()).
 			DoAndReturn(func(ctx context.Context, rp *entity.RecordPfc) error {
@@ -107,6 +174,12 @@ func TestRecordUsecase_Create(t *testing.T) {
 				Fat:     10.0,
 				Carbs:   30.0,
 			}, nil)
+		dailySummaryRepo.EXPECT().
+			Refresh(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID vo.UserID, date time.Time) error {
+				summaryRefreshed = true
+				return nil
+			})
 		adviceCacheRepo.EXPECT().
 			DeleteByUserIDAndDate(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
 			DoAndReturn(func(ctx context.Context, userID vo.UserID, date time.Time) error {
@@ -114,7 +187,7 @@ func TestRecordUsecase_Create(t *testing.T) {
 				return nil
 			})
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		err := uc.Create(context.Background(), record)
 
 		if err != nil {
@@ -129,24 +202,191 @@ func TestRecordUsecase_Create(t *testing.T) {
 		if savedRecordPfc == nil {
 			t.Error("recordPfc should be saved")
 		}
+		if !summaryRefreshed {
+			t.Error("daily summary should be refreshed")
+		}
 		if !cacheDeleted {
 			t.Error("cache should be deleted")
 		}
 	})
 
+	t.Run("異常系_日別サマリー更新時にエラーが発生", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		record := validRecord(t)
+		refreshErr := errors.New("refresh error")
+
+		setupTxManagerExecute(txManager)
+		recordRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(verifiedUser(t), nil)
+		pfcEstimator.EXPECT().
+			Estimate(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("estimate error"))
+		dailySummaryRepo.EXPECT().
+			Refresh(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(refreshErr)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		err := uc.Create(context.Background(), record)
+
+		if !errors.Is(err, refreshErr) {
+			t.Errorf("got %v, want refreshErr", err)
+		}
+	})
+
+	t.Run("正常系_サマリー更新後にバッジの獲得を判定し失敗しても記録は作成される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		record := validRecord(t)
+
+		setupTxManagerExecute(txManager)
+		recordRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(record.UserID())).Return(aiDisabledUser(t), nil)
+		refresh := dailySummaryRepo.EXPECT().
+			Refresh(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(nil)
+		badgeUnlocker := mock.NewMockBadgeUnlocker(ctrl)
+		badgeUnlocker.EXPECT().
+			UnlockMilestoneBadges(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(errors.New("db error")).
+			After(refresh)
+		adviceCacheRepo.EXPECT().DeleteByUserIDAndDate(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).Return(nil)
+
+		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, allowAuditRecord(ctrl), badgeUnlocker, allowAIUsageSave(ctrl), pfcEstimator, aiConfig, 0)
+		err := uc.Create(context.Background(), record)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("正常系_AI機能をオフにしている場合はPFCを推定せずに記録する", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		record := validRecord(t)
+
+		setupTxManagerExecute(txManager)
+		recordRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(aiDisabledUser(t), nil)
+		// 食品名は送信しないため、Estimate と RecordPfc の保存は呼ばれない（EXPECT未設定）
+		dailySummaryRepo.EXPECT().
+			Refresh(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(nil)
+		adviceCacheRepo.EXPECT().
+			DeleteByUserIDAndDate(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		err := uc.Create(context.Background(), record)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("正常系_メールアドレス未確認の場合はPFCを推定せずに記録する", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		record := validRecord(t)
+
+		setupTxManagerExecute(txManager)
+		recordRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(validUser(t), nil)
+		// 食品名は送信しないため、Estimate と RecordPfc の保存は呼ばれない（EXPECT未設定）
+		dailySummaryRepo.EXPECT().
+			Refresh(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(nil)
+		adviceCacheRepo.EXPECT().
+			DeleteByUserIDAndDate(gomock.Any(), gomock.Eq(record.UserID()), gomock.Any()).
+			Return(nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		err := uc.Create(context.Background(), record)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーの保存期間より前の日時は記録しない", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		record, err := entity.NewRecord(userID, time.Now().AddDate(-2, 0, 0))
+		if err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(retentionUserForRecord(t, userID, 1), nil)
+		// 削除済みの期間の日別集計を上書きしないよう、Save と Refresh は呼ばれない（EXPECT未設定）
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		err = uc.Create(context.Background(), record)
+
+		if !errors.Is(err, domainErrors.ErrEatenAtBeforeRetention) {
+			t.Errorf("got %v, want ErrEatenAtBeforeRetention", err)
+		}
+	})
+
+	t.Run("異常系_デプロイ全体の保存期間より前の日時は記録しない", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		record, err := entity.NewRecord(userID, time.Now().AddDate(-2, 0, 0))
+		if err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+
+		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(validUserForRecord(t, userID), nil)
+
+		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, allowAuditRecord(ctrl), allowBadgeUnlock(ctrl), allowAIUsageSave(ctrl), pfcEstimator, aiConfig, 1)
+		err = uc.Create(context.Background(), record)
+
+		if !errors.Is(err, domainErrors.ErrEatenAtBeforeRetention) {
+			t.Errorf("got %v, want ErrEatenAtBeforeRetention", err)
+		}
+	})
+
 	t.Run("異常系_保存時にエラーが発生", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		record := validRecord(t)
 		saveErr := errors.New("save error")
 
 		setupTxManagerExecute(txManager)
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(record.UserID())).
+			Return(verifiedUser(t), nil)
 		recordRepo.EXPECT().
 			Save(gomock.Any(), gomock.Any()).
 			Return(saveErr)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		err := uc.Create(context.Background(), record)
 
 		if !errors.Is(err, saveErr) {
@@ -157,7 +397,7 @@ func TestRecordUsecase_Create(t *testing.T) {
 
 func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 	t.Run("正常系_今日のカロリー情報を取得", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -180,7 +420,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return(records, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetTodayCalories(context.Background(), userID)
 
 		if err != nil {
@@ -211,7 +451,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 	})
 
 	t.Run("正常系_記録が0件の場合", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -224,7 +464,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return([]*entity.Record{}, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetTodayCalories(context.Background(), userID)
 
 		if err != nil {
@@ -242,8 +482,92 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_週単位モードでは週の残り予算を残り日数で割った値が目標になる", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := weeklyUserForRecord(t, userID)
+		dailyTarget := vo.ReconstructCalories(user.CalculateTargetCalories())
+		now := time.Now()
+
+		record, _ := entity.NewRecord(userID, now)
+		_ = record.AddItem("昼食", 900)
+
+		// 今週の今日より前に目標2日分を摂取済み（月曜は前日分がないため呼ばれない）
+		consumedBefore := vo.ZeroCalories()
+		if helper.DaysLeftInWeek(now) < helper.DaysPerWeek {
+			consumedBefore = vo.ReconstructCalories(dailyTarget.Value() * 2)
+		}
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		recordRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.Record{record}, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Eq(helper.StartOfWeek(now)), gomock.Any()).
+			Return([]*entity.DailySummary{dailySummary(userID, helper.StartOfWeek(now), consumedBefore.Value())}, nil).
+			AnyTimes()
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetTodayCalories(context.Background(), userID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		wantTarget := dailyTarget.WeeklyAllowance(consumedBefore, helper.DaysLeftInWeek(now)).Value()
+		if output.TargetCalories != wantTarget {
+			t.Errorf("TargetCalories = %d, want %d", output.TargetCalories, wantTarget)
+		}
+		if output.Difference != wantTarget-900 {
+			t.Errorf("Difference = %d, want %d", output.Difference, wantTarget-900)
+		}
+		if output.WeeklyTargetCalories != dailyTarget.Value()*7 {
+			t.Errorf("WeeklyTargetCalories = %d, want %d", output.WeeklyTargetCalories, dailyTarget.Value()*7)
+		}
+		if output.WeeklyTotalCalories != consumedBefore.Value()+900 {
+			t.Errorf("WeeklyTotalCalories = %d, want %d", output.WeeklyTotalCalories, consumedBefore.Value()+900)
+		}
+		if !output.BudgetMode.IsWeekly() {
+			t.Errorf("BudgetMode = %s, want weekly", output.BudgetMode.String())
+		}
+	})
+
+	t.Run("異常系_週単位モードで今週の集計取得時にエラー", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		if helper.DaysLeftInWeek(time.Now()) == helper.DaysPerWeek {
+			t.Skip("月曜は今週の前日分がないため集計を取得しない")
+		}
+
+		userID := vo.NewUserID()
+		user := weeklyUserForRecord(t, userID)
+		repoErr := errors.New("db error")
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		recordRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.Record{}, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return(nil, repoErr)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		_, err := uc.GetTodayCalories(context.Background(), userID)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+
 	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -252,7 +576,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetTodayCalories(context.Background(), userID)
 
 		if !errors.Is(err, domainErrors.ErrUserNotFound) {
@@ -261,7 +585,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 	})
 
 	t.Run("異常系_ユーザー取得時にエラー", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -271,7 +595,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetTodayCalories(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -280,7 +604,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 	})
 
 	t.Run("異常系_Record取得時にエラー", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -294,7 +618,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return(nil, repoErr)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetTodayCalories(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -305,7 +629,7 @@ func TestRecordUsecase_GetTodayCalories(t *testing.T) {
 
 func TestRecordUsecase_GetStatistics(t *testing.T) {
 	t.Run("正常系_週間統計データを取得", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -317,21 +641,21 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		// 超過条件: 100%超
 		// 未達成: 80%未満
 		now := time.Now()
-		dailyCalories := []repository.DailyCalories{
+		dailyCalories := []*entity.DailySummary{
 			// 達成（85%）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -6)), Calories: vo.ReconstructCalories(targetCalories * 85 / 100)},
+			dailySummary(userID, now.AddDate(0, 0, -6), targetCalories*85/100),
 			// 達成（90%）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -5)), Calories: vo.ReconstructCalories(targetCalories * 90 / 100)},
+			dailySummary(userID, now.AddDate(0, 0, -5), targetCalories*90/100),
 			// 達成（100%ちょうど）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -4)), Calories: vo.ReconstructCalories(targetCalories)},
+			dailySummary(userID, now.AddDate(0, 0, -4), targetCalories),
 			// 超過（120%）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -3)), Calories: vo.ReconstructCalories(targetCalories * 120 / 100)},
+			dailySummary(userID, now.AddDate(0, 0, -3), targetCalories*120/100),
 			// 超過（150%）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -2)), Calories: vo.ReconstructCalories(targetCalories * 150 / 100)},
+			dailySummary(userID, now.AddDate(0, 0, -2), targetCalories*150/100),
 			// 未達成（50%）
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -1)), Calories: vo.ReconstructCalories(targetCalories * 50 / 100)},
+			dailySummary(userID, now.AddDate(0, 0, -1), targetCalories*50/100),
 			// 未達成（30%）
-			{Date: vo.ReconstructEatenAt(now), Calories: vo.ReconstructCalories(targetCalories * 30 / 100)},
+			dailySummary(userID, now, targetCalories*30/100),
 		}
 
 		period, _ := vo.NewStatisticsPeriod("week")
@@ -339,11 +663,14 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(user, nil)
-		recordRepo.EXPECT().
-			GetDailyCalories(gomock.Any(), gomock.Eq(userID), gomock.Eq(period)).
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return(dailyCalories, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{}, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if err != nil {
@@ -382,7 +709,7 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 	})
 
 	t.Run("正常系_データがない場合", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -392,11 +719,14 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(user, nil)
-		recordRepo.EXPECT().
-			GetDailyCalories(gomock.Any(), gomock.Eq(userID), gomock.Eq(period)).
-			Return([]repository.DailyCalories{}, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{}, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{}, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if err != nil {
@@ -425,7 +755,7 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 	})
 
 	t.Run("正常系_月間統計データを取得", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -435,11 +765,14 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(user, nil)
-		recordRepo.EXPECT().
-			GetDailyCalories(gomock.Any(), gomock.Eq(userID), gomock.Eq(period)).
-			Return([]repository.DailyCalories{}, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{}, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{}, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if err != nil {
@@ -453,7 +786,7 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 	})
 
 	t.Run("正常系_平均カロリーの計算", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -462,20 +795,23 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 
 		// 3日分のデータ: 1000, 2000, 3000 -> 平均 2000
 		now := time.Now()
-		dailyCalories := []repository.DailyCalories{
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -2)), Calories: vo.ReconstructCalories(1000)},
-			{Date: vo.ReconstructEatenAt(now.AddDate(0, 0, -1)), Calories: vo.ReconstructCalories(2000)},
-			{Date: vo.ReconstructEatenAt(now), Calories: vo.ReconstructCalories(3000)},
+		dailyCalories := []*entity.DailySummary{
+			dailySummary(userID, now.AddDate(0, 0, -2), 1000),
+			dailySummary(userID, now.AddDate(0, 0, -1), 2000),
+			dailySummary(userID, now, 3000),
 		}
 
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(user, nil)
-		recordRepo.EXPECT().
-			GetDailyCalories(gomock.Any(), gomock.Eq(userID), gomock.Eq(period)).
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return(dailyCalories, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{}, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		output, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if err != nil {
@@ -489,8 +825,146 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_過去日はその日に有効だった目標値で判定される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		period, _ := vo.NewStatisticsPeriod("week")
+		now := time.Now()
+
+		// 3日前までは目標2000kcal、2日前以降は現在の目標値
+		oldSnapshot := entity.ReconstructTargetSnapshot(vo.NewTargetSnapshotID().String(), userID.String(), now.AddDate(0, 0, -10), 2000, 75, 55.6, 300, now)
+		newSnapshot := entity.NewTargetSnapshot(user, now.AddDate(0, 0, -2))
+
+		dailyCalories := []*entity.DailySummary{
+			// 旧目標2000kcalに対して90%（達成）
+			dailySummary(userID, now.AddDate(0, 0, -3), 1800),
+			// 現在の目標に対して120%（超過）
+			dailySummary(userID, now.AddDate(0, 0, -1), user.CalculateTargetCalories()*120/100),
+		}
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return(dailyCalories, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{oldSnapshot, newSnapshot}, nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetStatistics(context.Background(), userID, period)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.DailyStatistics[0].TargetCalories.Value() != 2000 {
+			t.Errorf("DailyStatistics[0].TargetCalories = %d, want 2000", output.DailyStatistics[0].TargetCalories.Value())
+		}
+		if !output.DailyStatistics[0].IsAchieved {
+			t.Error("DailyStatistics[0] should be achieved against the old target")
+		}
+		if output.DailyStatistics[1].TargetCalories.Value() != user.CalculateTargetCalories() {
+			t.Errorf("DailyStatistics[1].TargetCalories = %d, want %d", output.DailyStatistics[1].TargetCalories.Value(), user.CalculateTargetCalories())
+		}
+		if output.AchievedDays != 1 || output.OverDays != 1 {
+			t.Errorf("AchievedDays = %d, OverDays = %d, want 1, 1", output.AchievedDays, output.OverDays)
+		}
+	})
+
+	t.Run("正常系_週単位モードでは同じ週の残り予算を配分した値で判定される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := weeklyUserForRecord(t, userID)
+		dailyTarget := vo.ReconstructCalories(user.CalculateTargetCalories())
+		period, _ := vo.NewStatisticsPeriod("week")
+		today := time.Now().In(helper.JST())
+		weekStart := helper.StartOfWeek(today)
+
+		// 今週の初日（期間内）に目標の半分、今日に目標の120%を摂取
+		dailyCalories := []*entity.DailySummary{
+			dailySummary(userID, weekStart, dailyTarget.Value()/2),
+			dailySummary(userID, today, dailyTarget.Value()*120/100),
+		}
+		if weekStart.Format("2006-01-02") == today.Format("2006-01-02") {
+			dailyCalories = dailyCalories[1:]
+		}
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return(dailyCalories, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.TargetSnapshot{}, nil)
+		// 期間開始日を含む週の期間開始前の摂取はなし（期間分の取得後に呼ばれる）
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{}, nil).
+			AnyTimes()
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetStatistics(context.Background(), userID, period)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// 今日の配分は今週の残り予算を残り日数で割った値
+		consumedBefore := vo.ZeroCalories()
+		if len(dailyCalories) == 2 {
+			consumedBefore = dailyCalories[0].Calories()
+		}
+		wantAllowance := dailyTarget.WeeklyAllowance(consumedBefore, helper.DaysLeftInWeek(today))
+		todayStats := output.DailyStatistics[len(output.DailyStatistics)-1]
+		if todayStats.TargetCalories != wantAllowance {
+			t.Errorf("TargetCalories = %d, want %d", todayStats.TargetCalories.Value(), wantAllowance.Value())
+		}
+		if todayStats.IsOver != dailyCalories[len(dailyCalories)-1].Calories().IsOver(wantAllowance) {
+			t.Errorf("IsOver = %v, want judged against weekly allowance %d", todayStats.IsOver, wantAllowance.Value())
+		}
+		// 月曜以外は貯金により日単位の目標より配分が大きくなる
+		if len(dailyCalories) == 2 && todayStats.TargetCalories.Value() <= dailyTarget.Value() {
+			t.Errorf("TargetCalories = %d, want greater than daily target %d", todayStats.TargetCalories.Value(), dailyTarget.Value())
+		}
+	})
+
+	t.Run("異常系_スナップショット取得時にエラー", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		period, _ := vo.NewStatisticsPeriod("week")
+		repoErr := errors.New("db error")
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return([]*entity.DailySummary{}, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			Return(nil, repoErr)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		_, err := uc.GetStatistics(context.Background(), userID, period)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+
 	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -500,7 +974,7 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, nil)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if !errors.Is(err, domainErrors.ErrUserNotFound) {
@@ -508,8 +982,47 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系_スナップショットは日別サマリーと同じ期間開始日から取得される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		period, _ := vo.NewStatisticsPeriod("week")
+
+		var summaryStart, snapshotStart, snapshotEnd time.Time
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(userID)).Return(user, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ vo.UserID, start, _ time.Time) ([]*entity.DailySummary, error) {
+				summaryStart = start
+				return []*entity.DailySummary{}, nil
+			})
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ vo.UserID, start, end time.Time) ([]*entity.TargetSnapshot, error) {
+				snapshotStart, snapshotEnd = start, end
+				return []*entity.TargetSnapshot{}, nil
+			})
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		if _, err := uc.GetStatistics(context.Background(), userID, period); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !snapshotStart.Equal(summaryStart) {
+			t.Errorf("snapshot start = %v, want %v", snapshotStart, summaryStart)
+		}
+		// 今日の変更も含めるよう終了日は翌日のJSTの0時
+		now := time.Now().In(helper.JST())
+		wantEnd := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, helper.JST())
+		if !snapshotEnd.Equal(wantEnd) {
+			t.Errorf("snapshot end = %v, want %v", snapshotEnd, wantEnd)
+		}
+	})
+
 	t.Run("異常系_ユーザー取得時にエラー", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -520,7 +1033,7 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if !errors.Is(err, repoErr) {
@@ -528,8 +1041,8 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		}
 	})
 
-	t.Run("異常系_DailyCalories取得時にエラー", func(t *testing.T) {
-		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+	t.Run("異常系_日別サマリー取得時にエラー", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
@@ -540,11 +1053,11 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(user, nil)
-		recordRepo.EXPECT().
-			GetDailyCalories(gomock.Any(), gomock.Eq(userID), gomock.Eq(period)).
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
 			Return(nil, repoErr)
 
-		uc := usecase.NewRecordUsecase(recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, txManager, pfcEstimator, aiConfig)
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
 		_, err := uc.GetStatistics(context.Background(), userID, period)
 
 		if !errors.Is(err, repoErr) {
@@ -552,3 +1065,197 @@ func TestRecordUsecase_GetStatistics(t *testing.T) {
 		}
 	})
 }
+
+func TestRecordUsecase_GetCalendar(t *testing.T) {
+	t.Run("正常系_記録がない日を含む月の全日分が返る", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		month, _ := vo.NewCalendarMonth("2025-02")
+		target := user.CalculateTargetCalories()
+
+		summaries := []*entity.DailySummary{
+			// 2/3: 目標の90%（達成）
+			entity.ReconstructDailySummary(userID.String(), month.Start().AddDate(0, 0, 2), target*9/10, 0, 0, 0, 2, time.Now()),
+			// 2/10: 目標の120%（超過）
+			entity.ReconstructDailySummary(userID.String(), month.Start().AddDate(0, 0, 9), target*12/10, 0, 0, 0, 3, time.Now()),
+		}
+
+		userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(user, nil)
+		dailySummaryRepo.EXPECT().
+			FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), month.Start(), month.End()).
+			Return(summaries, nil)
+		adviceCacheRepo.EXPECT().
+			FindCacheDatesByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), month.Start(), month.End()).
+			Return([]time.Time{month.Start().AddDate(0, 0, 2)}, nil)
+		targetSnapshotRepo.EXPECT().
+			FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), month.Start(), month.End()).
+			Return([]*entity.TargetSnapshot{}, nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetCalendar(context.Background(), userID, month)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(output.Days) != 28 {
+			t.Fatalf("len(Days) = %d, want 28", len(output.Days))
+		}
+
+		achievedDay := output.Days[2]
+		if !achievedDay.IsAchieved || achievedDay.IsOver {
+			t.Errorf("Days[2] IsAchieved = %v, IsOver = %v, want true, false", achievedDay.IsAchieved, achievedDay.IsOver)
+		}
+		if achievedDay.RecordCount != 2 {
+			t.Errorf("Days[2].RecordCount = %d, want 2", achievedDay.RecordCount)
+		}
+		if !achievedDay.HasAdvice {
+			t.Error("Days[2].HasAdvice should be true")
+		}
+
+		overDay := output.Days[9]
+		if !overDay.IsOver || overDay.HasAdvice {
+			t.Errorf("Days[9] IsOver = %v, HasAdvice = %v, want true, false", overDay.IsOver, overDay.HasAdvice)
+		}
+
+		emptyDay := output.Days[0]
+		if emptyDay.TotalCalories.Value() != 0 || emptyDay.RecordCount != 0 || emptyDay.IsAchieved {
+			t.Errorf("Days[0] = %+v, want empty day", emptyDay)
+		}
+		if emptyDay.TargetCalories.Value() != target {
+			t.Errorf("Days[0].TargetCalories = %d, want %d", emptyDay.TargetCalories.Value(), target)
+		}
+	})
+
+	t.Run("正常系_各日はその日に有効だった目標値で判定される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		month, _ := vo.NewCalendarMonth("2025-02")
+
+		// 2/15から目標1000kcal
+		snapshot := entity.ReconstructTargetSnapshot(vo.NewTargetSnapshotID().String(), userID.String(), month.Start().AddDate(0, 0, 14), 1000, 40, 30, 140, time.Now())
+		summaries := []*entity.DailySummary{
+			entity.ReconstructDailySummary(userID.String(), month.Start().AddDate(0, 0, 19), 900, 0, 0, 0, 1, time.Now()),
+		}
+
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(userID)).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return(summaries, nil)
+		adviceCacheRepo.EXPECT().FindCacheDatesByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return(nil, nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{snapshot}, nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetCalendar(context.Background(), userID, month)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Days[13].TargetCalories.Value() != user.CalculateTargetCalories() {
+			t.Errorf("Days[13].TargetCalories = %d, want %d", output.Days[13].TargetCalories.Value(), user.CalculateTargetCalories())
+		}
+		if output.Days[19].TargetCalories.Value() != 1000 {
+			t.Errorf("Days[19].TargetCalories = %d, want 1000", output.Days[19].TargetCalories.Value())
+		}
+		if !output.Days[19].IsAchieved {
+			t.Error("Days[19] should be achieved against the snapshot target")
+		}
+	})
+
+	t.Run("正常系_週単位モードでは週の残り予算を配分した値で判定される", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := weeklyUserForRecord(t, userID)
+		month, _ := vo.NewCalendarMonth("2025-10") // 10/1は水曜
+		target := user.CalculateTargetCalories()
+		weekStart := helper.StartOfWeek(month.Start())
+
+		// 月初日より前の9/29〜9/30に目標の3日分を摂取
+		leadIn := []*entity.DailySummary{
+			dailySummary(userID, weekStart, target*3/2),
+			dailySummary(userID, weekStart.AddDate(0, 0, 1), target*3/2),
+		}
+		summaries := []*entity.DailySummary{
+			// 10/1: 目標の90%（日単位なら達成だが、週の残り予算の配分は超える）
+			dailySummary(userID, month.Start(), target*9/10),
+			// 10/6（翌週の月曜）: 目標の90%（新しい週の配分で達成）
+			dailySummary(userID, month.Start().AddDate(0, 0, 5), target*9/10),
+		}
+
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(userID)).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), month.Start(), month.End()).Return(summaries, nil)
+		dailySummaryRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), weekStart, month.Start()).Return(leadIn, nil)
+		adviceCacheRepo.EXPECT().FindCacheDatesByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return(nil, nil)
+		targetSnapshotRepo.EXPECT().FindEffectiveByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return([]*entity.TargetSnapshot{}, nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		output, err := uc.GetCalendar(context.Background(), userID, month)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		consumedBefore := leadIn[0].Calories().Add(leadIn[1].Calories())
+		wantAllowance := vo.ReconstructCalories(target).WeeklyAllowance(consumedBefore, helper.DaysLeftInWeek(month.Start()))
+		firstDay := output.Days[0]
+		if firstDay.TargetCalories != wantAllowance {
+			t.Errorf("Days[0].TargetCalories = %d, want %d", firstDay.TargetCalories.Value(), wantAllowance.Value())
+		}
+		if !firstDay.IsOver {
+			t.Errorf("Days[0].IsOver = false, want judged against weekly allowance %d", wantAllowance.Value())
+		}
+
+		nextMonday := output.Days[5]
+		if nextMonday.TargetCalories.Value() != target {
+			t.Errorf("Days[5].TargetCalories = %d, want %d", nextMonday.TargetCalories.Value(), target)
+		}
+		if !nextMonday.IsAchieved || nextMonday.IsOver {
+			t.Errorf("Days[5] IsAchieved = %v, IsOver = %v, want true, false", nextMonday.IsAchieved, nextMonday.IsOver)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		month, _ := vo.NewCalendarMonth("2025-02")
+
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(userID)).Return(nil, nil)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		_, err := uc.GetCalendar(context.Background(), userID, month)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("異常系_アドバイス日付取得時にエラー", func(t *testing.T) {
+		recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig, ctrl := setupRecordMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		user := validUserForRecord(t, userID)
+		month, _ := vo.NewCalendarMonth("2025-02")
+		repoErr := errors.New("db error")
+
+		userRepo.EXPECT().FindByID(gomock.Any(), gomock.Eq(userID)).Return(user, nil)
+		dailySummaryRepo.EXPECT().FindByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return(nil, nil)
+		adviceCacheRepo.EXPECT().FindCacheDatesByUserIDAndDateRange(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).Return(nil, repoErr)
+
+		uc := newRecordUsecase(ctrl, recordRepo, recordPfcRepo, userRepo, adviceCacheRepo, targetSnapshotRepo, dailySummaryRepo, txManager, pfcEstimator, aiConfig)
+		_, err := uc.GetCalendar(context.Background(), userID, month)
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("expected db error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+)
+
+// RetentionJobName はデータ保存期間ジョブの名前
+const RetentionJobName = "retention"
+
+// RetentionJob は保存期間を過ぎたデータを削除するジョブ
+//   - 記録: デプロイ全体の保存期間とユーザーが設定した保存期間のうち短い方を過ぎた記録・明細・PFCを削除する
+//     日別集計（daily_summaries）は残し、グラフなどの期間の集計には引き続き使う
+//     日の途中では区切らず、保存期間より前の日時の記録は作成・取り込みできないため、残した日別集計が再集計で上書きされることはない
+//   - アドバイスキャッシュ: 作成から保存日数を過ぎたものを削除する
+type RetentionJob struct {
+	retentionRepo   repository.RetentionRepository
+	adviceCacheRepo repository.AdviceCacheRepository
+	txManager       repository.TransactionManager
+	recordYears     int
+	adviceCacheDays int
+}
+
+// NewRetentionJob は RetentionJob のインスタンスを生成する
+// recordYearsはデプロイ全体の記録の保存年数、adviceCacheDaysはアドバイスキャッシュの保存日数
+// いずれも0の場合はその種類のデータを期間で削除しない（記録はユーザーが設定した保存期間のみ適用する）
+func NewRetentionJob(
+	retentionRepo repository.RetentionRepository,
+	adviceCacheRepo repository.AdviceCacheRepository,
+	txManager repository.TransactionManager,
+	recordYears int,
+	adviceCacheDays int,
+) *RetentionJob {
+	return &RetentionJob{
+		retentionRepo:   retentionRepo,
+		adviceCacheRepo: adviceCacheRepo,
+		txManager:       txManager,
+		recordYears:     recordYears,
+		adviceCacheDays: adviceCacheDays,
+	}
+}
+
+// Name はジョブの名前を返す
+func (j *RetentionJob) Name() string {
+	return RetentionJobName
+}
+
+// Run は保存期間を過ぎたデータを削除する
+// ユーザーごとにトランザクションを分け、失敗したユーザーがあっても残りの処理を続ける
+func (j *RetentionJob) Run(ctx context.Context, now time.Time) (map[string]int64, error) {
+	result := make(map[string]int64)
+	var errs []error
+
+	if j.recordYears > 0 {
+		if err := j.purgeRecords(ctx, nil, retentionCutoff(now, j.recordYears), result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	retentions, err := j.retentionRepo.FindUserRetentions(ctx)
+	if err != nil {
+		logError("RetentionJob.Run", err)
+		errs = append(errs, err)
+	}
+	for _, retention := range retentions {
+		// デプロイ全体の保存期間の方が短いユーザーは削除済み
+		if j.recordYears > 0 && retention.Years >= j.recordYears {
+			continue
+		}
+		userID := retention.UserID
+		if err := j.purgeRecords(ctx, &userID, retentionCutoff(now, retention.Years), result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if j.adviceCacheDays > 0 {
+		deleted, err := j.adviceCacheRepo.DeleteCreatedBefore(ctx, now.AddDate(0, 0, -j.adviceCacheDays))
+		if err != nil {
+			logError("RetentionJob.Run", err)
+			errs = append(errs, err)
+		} else {
+			result["advice_caches"] += deleted
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// purgeRecords はbeforeより前の記録を削除し、削除件数をresultに加算する
+// userIDがnilの場合は全ユーザーが対象
+func (j *RetentionJob) purgeRecords(ctx context.Context, userID *vo.UserID, before time.Time, result map[string]int64) error {
+	var purged map[string]int64
+	err := j.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		purged, err = j.retentionRepo.PurgeRecordsBefore(txCtx, userID, before)
+		return err
+	})
+	if err != nil {
+		if userID != nil {
+			logError("RetentionJob.purgeRecords", err, "user_id", userID.String())
+		} else {
+			logError("RetentionJob.purgeRecords", err)
+		}
+		return err
+	}
+	for table, count := range purged {
+		result[table] += count
+	}
+	return nil
+}
+
+// retentionCutoff は保存年数から記録を残す期間の開始（JSTの0時）を返す
+// 日別集計を残した日と記録が残る日が混在しないよう、日の途中では区切らない
+func retentionCutoff(now time.Time, years int) time.Time {
+	return startOfDay(now.AddDate(-years, 0, 0))
+}
+
+// recordRetentionCutoff はデプロイ全体とユーザーの保存年数のうち短い方から、記録を残す期間の開始を返す
+// どちらも0（無期限）の場合はfalseを返す
+func recordRetentionCutoff(now time.Time, deploymentYears, userYears int) (time.Time, bool) {
+	years := deploymentYears
+	if userYears > 0 && (years == 0 || userYears < years) {
+		years = userYears
+	}
+	if years == 0 {
+		return time.Time{}, false
+	}
+	return retentionCutoff(now, years), true
+}
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/domain/helper"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// retentionMocks はRetentionJobのテスト用モックをまとめる
+type retentionMocks struct {
+	retentionRepo   *mock.MockRetentionRepository
+	adviceCacheRepo *mock.MockAdviceCacheRepository
+	txManager       *mock.MockTransactionManager
+}
+
+// setupRetentionJob はモックと指定した保存期間のRetentionJobを初期化する
+func setupRetentionJob(t *testing.T, recordYears, adviceCacheDays int) (*retentionMocks, *usecase.RetentionJob) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &retentionMocks{
+		retentionRepo:   mock.NewMockRetentionRepository(ctrl),
+		adviceCacheRepo: mock.NewMockAdviceCacheRepository(ctrl),
+		txManager:       mock.NewMockTransactionManager(ctrl),
+	}
+	m.txManager.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return m, usecase.NewRetentionJob(m.retentionRepo, m.adviceCacheRepo, m.txManager, recordYears, adviceCacheDays)
+}
+
+// recordsPurged は記録の削除件数を返す
+func recordsPurged(records int64) map[string]int64 {
+	return map[string]int64{"record_pfcs": records, "record_items": records, "records": records}
+}
+
+func TestRetentionJob_Run(t *testing.T) {
+	now := time.Date(2026, 3, 15, 3, 0, 0, 0, time.UTC)
+	// 記録は日の途中で区切らず、保存期間を過ぎた日のJSTの0時より前を削除する
+
+	t.Run("正常系_デプロイ全体とユーザーの保存期間のうち短い方で削除する", func(t *testing.T) {
+		m, job := setupRetentionJob(t, 5, 30)
+
+		shorter := vo.NewUserID()
+		longer := vo.NewUserID()
+
+		m.retentionRepo.EXPECT().PurgeRecordsBefore(gomock.Any(), nil, time.Date(2021, 3, 15, 0, 0, 0, 0, helper.JST())).Return(recordsPurged(10), nil)
+		m.retentionRepo.EXPECT().FindUserRetentions(gomock.Any()).Return([]repository.UserRetention{
+			{UserID: shorter, Years: 1},
+			{UserID: longer, Years: 7}, // デプロイ全体の5年で削除済み
+		}, nil)
+		m.retentionRepo.EXPECT().PurgeRecordsBefore(gomock.Any(), gomock.Any(), time.Date(2025, 3, 15, 0, 0, 0, 0, helper.JST())).
+			DoAndReturn(func(_ context.Context, userID *vo.UserID, _ time.Time) (map[string]int64, error) {
+				if userID == nil || !userID.Equals(shorter) {
+					t.Errorf("PurgeRecordsBefore() userID = %v, want %s", userID, shorter)
+				}
+				return recordsPurged(2), nil
+			})
+		m.adviceCacheRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), time.Date(2026, 2, 13, 3, 0, 0, 0, time.UTC)).Return(int64(4), nil)
+
+		result, err := job.Run(context.Background(), now)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result["records"] != 12 || result["advice_caches"] != 4 {
+			t.Errorf("result = %v, want records=12 advice_caches=4", result)
+		}
+	})
+
+	t.Run("正常系_保存期間が0なら期間で削除せずユーザーの設定のみ適用する", func(t *testing.T) {
+		m, job := setupRetentionJob(t, 0, 0)
+
+		userID := vo.NewUserID()
+		m.retentionRepo.EXPECT().FindUserRetentions(gomock.Any()).Return([]repository.UserRetention{{UserID: userID, Years: 3}}, nil)
+		m.retentionRepo.EXPECT().PurgeRecordsBefore(gomock.Any(), gomock.Any(), time.Date(2023, 3, 15, 0, 0, 0, 0, helper.JST())).Return(recordsPurged(1), nil)
+
+		result, err := job.Run(context.Background(), now)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result["records"] != 1 {
+			t.Errorf("result[records] = %d, want 1", result["records"])
+		}
+		if _, ok := result["advice_caches"]; ok {
+			t.Errorf("advice caches should not be purged when retention days is 0: %v", result)
+		}
+	})
+
+	t.Run("異常系_失敗したユーザーがあっても残りの削除を続ける", func(t *testing.T) {
+		m, job := setupRetentionJob(t, 0, 30)
+
+		failing := vo.NewUserID()
+		m.retentionRepo.EXPECT().FindUserRetentions(gomock.Any()).Return([]repository.UserRetention{
+			{UserID: failing, Years: 1},
+			{UserID: vo.NewUserID(), Years: 2},
+		}, nil)
+		m.retentionRepo.EXPECT().PurgeRecordsBefore(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		m.retentionRepo.EXPECT().PurgeRecordsBefore(gomock.Any(), gomock.Any(), gomock.Any()).Return(recordsPurged(2), nil)
+		m.adviceCacheRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+		result, err := job.Run(context.Background(), now)
+		if err == nil {
+			t.Fatal("Run() should return the purge error")
+		}
+		if result["records"] != 2 || result["advice_caches"] != 1 {
+			t.Errorf("result = %v, want records=2 advice_caches=1", result)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+)
+
+// AuditRecorder は監査ログを記録するサービスインターフェース
+// トランザクション内で呼び出した場合は、操作と同じトランザクションで記録する
+type AuditRecorder interface {
+	Record(ctx context.Context, event *entity.AuditEvent) error
+}
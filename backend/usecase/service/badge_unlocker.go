@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"caltrack/domain/vo"
+)
+
+// BadgeUnlocker は獲得条件を満たしたマイルストーンバッジを保存するサービスインターフェース
+// 記録の作成や取り込みで日別サマリーを更新した後、同じトランザクション内で呼び出す
+type BadgeUnlocker interface {
+	UnlockMilestoneBadges(ctx context.Context, userID vo.UserID) error
+}
@@ -18,9 +18,15 @@ type ImageAnalyzerConfig struct {
 	Prompt    string // 解析に使用するプロンプト
 }
 
+// ImageAnalysisOutput は画像解析の結果を保持する
+type ImageAnalysisOutput struct {
+	Items []AnalyzedItem // 認識した食品のリスト
+	Usage TokenUsage     // 消費したトークン数
+}
+
 // ImageAnalyzer は画像から食品を解析するサービスのインターフェース
 // Infrastructure層で具体的な実装（Gemini API等）を提供する
 type ImageAnalyzer interface {
-	// Analyze は画像データを解析し、認識した食品のリストを返す
-	Analyze(ctx context.Context, config ImageAnalyzerConfig, imageData string, mimeType string) ([]AnalyzedItem, error)
+	// Analyze は画像データを解析し、認識した食品のリストとトークン使用量を返す
+	Analyze(ctx context.Context, config ImageAnalyzerConfig, imageData string, mimeType string) (*ImageAnalysisOutput, error)
 }
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+)
+
+// Mail は送信するメールの内容
+type Mail struct {
+	To      string
+	Subject string
+	Body    string // プレーンテキスト本文
+}
+
+// Mailer はメール送信を行うサービスインターフェース
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+)
+
+// OIDCClaims はIDトークンの検証後に取り出したユーザー情報
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OIDCProvider はOpenID Connectの認可コードフロー（PKCE）を行うサービスインターフェース
+type OIDCProvider interface {
+	// Name はプロバイダー名（URLのパスに使う識別子）を返す
+	Name() string
+	// AuthCodeURL は認可エンドポイントへのリダイレクトURLを組み立てる
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange は認可コードをトークンに交換し、IDトークンの署名・iss・aud・exp・nonceを検証する
+	// 交換に失敗した場合は ErrOIDCExchangeFailed、IDトークンが不正な場合は ErrOIDCTokenInvalid を返す
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OIDCClaims, error)
+}
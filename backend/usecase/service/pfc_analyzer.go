@@ -22,6 +22,7 @@ type PfcAnalyzerConfig struct {
 
 type NutritionAdviceOutput struct {
 	Advice string
+	Usage  TokenUsage
 }
 
 type PfcAnalyzer interface {
@@ -14,6 +14,7 @@ type PfcEstimateOutput struct {
 	Protein float64
 	Fat     float64
 	Carbs   float64
+	Usage   TokenUsage // 消費したトークン数
 }
 
 // PfcEstimatorConfig はPfcEstimatorの設定
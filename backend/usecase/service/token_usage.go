@@ -0,0 +1,9 @@
+package service
+
+// TokenUsage はAIサービス1回の呼び出しで消費したトークン数
+// プロバイダーが使用量を返さない場合はゼロ値になる
+type TokenUsage struct {
+	PromptTokens int // 入力（プロンプト）のトークン数
+	OutputTokens int // 出力（生成結果）のトークン数
+	TotalTokens  int // 合計トークン数
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"caltrack/domain/repository"
+)
+
+// SessionCleanupJobName はセッション削除ジョブの名前
+const SessionCleanupJobName = "session_cleanup"
+
+// SessionCleanupJob は有効期限が切れたセッションを削除するジョブ
+// 期限切れのセッションは認証で使われないが、ログアウトしないまま放置されたものが溜まり続けるため定期的に削除する
+type SessionCleanupJob struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewSessionCleanupJob は SessionCleanupJob のインスタンスを生成する
+func NewSessionCleanupJob(sessionRepo repository.SessionRepository) *SessionCleanupJob {
+	return &SessionCleanupJob{sessionRepo: sessionRepo}
+}
+
+// Name はジョブの名前を返す
+func (j *SessionCleanupJob) Name() string {
+	return SessionCleanupJobName
+}
+
+// Run は有効期限が切れたセッションを削除する
+func (j *SessionCleanupJob) Run(ctx context.Context, now time.Time) (map[string]int64, error) {
+	deleted, err := j.sessionRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		logError("SessionCleanupJob.Run", err)
+		return nil, err
+	}
+	return map[string]int64{"sessions": deleted}, nil
+}
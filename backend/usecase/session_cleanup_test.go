@@ -0,0 +1,41 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSessionCleanupJob_Run(t *testing.T) {
+	now := time.Now()
+
+	t.Run("正常系_期限切れのセッションを削除して件数を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mock.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().DeleteExpired(gomock.Any(), now).Return(int64(5), nil)
+
+		result, err := usecase.NewSessionCleanupJob(sessionRepo).Run(context.Background(), now)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result["sessions"] != 5 {
+			t.Errorf("result[sessions] = %d, want 5", result["sessions"])
+		}
+	})
+
+	t.Run("異常系_DBエラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mock.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().DeleteExpired(gomock.Any(), now).Return(int64(0), errors.New("db error"))
+
+		if _, err := usecase.NewSessionCleanupJob(sessionRepo).Run(context.Background(), now); err == nil {
+			t.Error("Run() should fail with db error")
+		}
+	})
+}
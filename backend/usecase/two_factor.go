@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/repository"
+	"caltrack/domain/vo"
+	"caltrack/usecase/service"
+)
+
+// TwoFactorUsecase はTOTPによる2段階認証の設定に関するユースケースを提供する
+type TwoFactorUsecase struct {
+	userRepo               repository.UserRepository
+	userTOTPRepo           repository.UserTOTPRepository
+	recoveryCodeRepo       repository.RecoveryCodeRepository
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository
+	txManager              repository.TransactionManager
+	auditRecorder          service.AuditRecorder
+}
+
+// NewTwoFactorUsecase は TwoFactorUsecase のインスタンスを生成する
+func NewTwoFactorUsecase(
+	userRepo repository.UserRepository,
+	userTOTPRepo repository.UserTOTPRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	twoFactorChallengeRepo repository.TwoFactorChallengeRepository,
+	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+) *TwoFactorUsecase {
+	return &TwoFactorUsecase{
+		userRepo:               userRepo,
+		userTOTPRepo:           userTOTPRepo,
+		recoveryCodeRepo:       recoveryCodeRepo,
+		twoFactorChallengeRepo: twoFactorChallengeRepo,
+		txManager:              txManager,
+		auditRecorder:          auditRecorder,
+	}
+}
+
+// TOTPEnrollmentOutput はTOTP登録開始の出力を表す
+// シークレットとリカバリーコードの平文はこの時点でのみ取得できる
+type TOTPEnrollmentOutput struct {
+	Secret          vo.TOTPSecret
+	ProvisioningURI string
+	RecoveryCodes   []vo.RecoveryCode
+}
+
+// GetTOTPStatus はユーザーのTOTP設定を取得する（未登録の場合はnil）
+func (u *TwoFactorUsecase) GetTOTPStatus(ctx context.Context, userID vo.UserID) (*entity.UserTOTP, error) {
+	totp, err := u.userTOTPRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logError("GetTOTPStatus", err, "user_id", userID.String())
+		return nil, err
+	}
+	return totp, nil
+}
+
+// BeginTOTPEnrollment はTOTPの登録を開始し、新しいシークレットとリカバリーコードを発行する
+// ConfirmTOTPEnrollment でコードを確認するまで2段階認証は有効にならない
+// 確認前に再度呼び出した場合は、シークレットとリカバリーコードを発行し直す
+func (u *TwoFactorUsecase) BeginTOTPEnrollment(ctx context.Context, userID vo.UserID) (*TOTPEnrollmentOutput, error) {
+	var output *TOTPEnrollmentOutput
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("BeginTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("BeginTOTPEnrollment", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		existing, err := u.userTOTPRepo.FindByUserID(txCtx, userID)
+		if err != nil {
+			logError("BeginTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+		if existing != nil && existing.IsEnabled() {
+			logWarn("BeginTOTPEnrollment", "totp already enabled", "user_id", userID.String())
+			return domainErrors.ErrTOTPAlreadyEnabled
+		}
+
+		totp, err := entity.NewUserTOTP(userID)
+		if err != nil {
+			logError("BeginTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+		if err := u.userTOTPRepo.Save(txCtx, totp); err != nil {
+			logError("BeginTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+
+		rawCodes, err := u.reissueRecoveryCodes(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		output = &TOTPEnrollmentOutput{
+			Secret:          totp.Secret(),
+			ProvisioningURI: totp.ProvisioningURI(user.Email()),
+			RecoveryCodes:   rawCodes,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// ConfirmTOTPEnrollment は認証アプリが生成したコードを確認してTOTPを有効にし、監査ログに記録する
+// 登録を開始していない場合は ErrTOTPEnrollmentNotFound、コードが一致しない場合は ErrInvalidTOTPCode を返す
+func (u *TwoFactorUsecase) ConfirmTOTPEnrollment(ctx context.Context, userID vo.UserID, code string) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		totp, err := u.userTOTPRepo.FindByUserID(txCtx, userID)
+		if err != nil {
+			logError("ConfirmTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+		if totp == nil {
+			logWarn("ConfirmTOTPEnrollment", "enrollment not found", "user_id", userID.String())
+			return domainErrors.ErrTOTPEnrollmentNotFound
+		}
+
+		if err := totp.Enable(code); err != nil {
+			logWarn("ConfirmTOTPEnrollment", err.Error(), "user_id", userID.String())
+			return err
+		}
+		if err := u.userTOTPRepo.Save(txCtx, totp); err != nil {
+			logError("ConfirmTOTPEnrollment", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionTwoFactorEnable, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"method": "totp",
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// DisableTOTP は現在のパスワードを確認して2段階認証を無効にする
+// TOTP設定・リカバリーコード・コード入力待ちのチャレンジを全て削除し、監査ログに記録する
+func (u *TwoFactorUsecase) DisableTOTP(ctx context.Context, userID vo.UserID, password vo.Password) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("DisableTOTP", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("DisableTOTP", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+		if !user.HashedPassword().Compare(password) {
+			logWarn("DisableTOTP", "password mismatch", "user_id", userID.String())
+			return domainErrors.ErrCurrentPasswordIncorrect
+		}
+
+		totp, err := u.userTOTPRepo.FindByUserID(txCtx, userID)
+		if err != nil {
+			logError("DisableTOTP", err, "user_id", userID.String())
+			return err
+		}
+		if totp == nil || !totp.IsEnabled() {
+			logWarn("DisableTOTP", "totp not enabled", "user_id", userID.String())
+			return domainErrors.ErrTOTPNotEnabled
+		}
+
+		if err := u.userTOTPRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("DisableTOTP", err, "user_id", userID.String())
+			return err
+		}
+		if err := u.recoveryCodeRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("DisableTOTP", err, "user_id", userID.String())
+			return err
+		}
+		if err := u.twoFactorChallengeRepo.DeleteByUserID(txCtx, userID); err != nil {
+			logError("DisableTOTP", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionTwoFactorDisable, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"method": "totp",
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// reissueRecoveryCodes は既存のリカバリーコードを破棄して新しいコードを発行し、表示用の平文を返す
+func (u *TwoFactorUsecase) reissueRecoveryCodes(ctx context.Context, userID vo.UserID) ([]vo.RecoveryCode, error) {
+	if err := u.recoveryCodeRepo.DeleteByUserID(ctx, userID); err != nil {
+		logError("reissueRecoveryCodes", err, "user_id", userID.String())
+		return nil, err
+	}
+
+	codes, rawCodes, err := entity.NewRecoveryCodes(userID)
+	if err != nil {
+		logError("reissueRecoveryCodes", err, "user_id", userID.String())
+		return nil, err
+	}
+	if err := u.recoveryCodeRepo.SaveAll(ctx, codes); err != nil {
+		logError("reissueRecoveryCodes", err, "user_id", userID.String())
+		return nil, err
+	}
+	return rawCodes, nil
+}
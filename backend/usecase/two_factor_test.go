@@ -0,0 +1,247 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"caltrack/domain/entity"
+	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/vo"
+	"caltrack/mock"
+	"caltrack/usecase"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// twoFactorMocks はTwoFactorUsecaseのテストで使うモック一式
+type twoFactorMocks struct {
+	userRepo               *mock.MockUserRepository
+	userTOTPRepo           *mock.MockUserTOTPRepository
+	recoveryCodeRepo       *mock.MockRecoveryCodeRepository
+	twoFactorChallengeRepo *mock.MockTwoFactorChallengeRepository
+	txManager              *mock.MockTransactionManager
+	auditEvents            *auditEvents
+}
+
+// setupTwoFactorMocks はテスト用のモックとTwoFactorUsecaseを初期化する
+func setupTwoFactorMocks(t *testing.T) (*twoFactorMocks, *usecase.TwoFactorUsecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	m := &twoFactorMocks{
+		userRepo:               mock.NewMockUserRepository(ctrl),
+		userTOTPRepo:           mock.NewMockUserTOTPRepository(ctrl),
+		recoveryCodeRepo:       mock.NewMockRecoveryCodeRepository(ctrl),
+		twoFactorChallengeRepo: mock.NewMockTwoFactorChallengeRepository(ctrl),
+		txManager:              mock.NewMockTransactionManager(ctrl),
+		auditEvents:            auditEvents,
+	}
+	uc := usecase.NewTwoFactorUsecase(m.userRepo, m.userTOTPRepo, m.recoveryCodeRepo, m.twoFactorChallengeRepo, m.txManager, auditRecorder)
+	return m, uc
+}
+
+func TestTwoFactorUsecase_BeginTOTPEnrollment(t *testing.T) {
+	t.Run("正常系_シークレットとリカバリーコードを発行する", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		user := validUser(t)
+
+		var savedTOTP *entity.UserTOTP
+		var savedCodes []*entity.RecoveryCode
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(nil, nil)
+		m.userTOTPRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, totp *entity.UserTOTP) error {
+				savedTOTP = totp
+				return nil
+			})
+		m.recoveryCodeRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.recoveryCodeRepo.EXPECT().SaveAll(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, codes []*entity.RecoveryCode) error {
+				savedCodes = codes
+				return nil
+			})
+
+		output, err := uc.BeginTOTPEnrollment(context.Background(), user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if savedTOTP.IsEnabled() {
+			t.Error("totp should not be enabled before confirmation")
+		}
+		if output.Secret.String() != savedTOTP.Secret().String() {
+			t.Error("returned secret should match the saved secret")
+		}
+		if !strings.HasPrefix(output.ProvisioningURI, "otpauth://totp/CalTrack:test@example.com?") {
+			t.Errorf("ProvisioningURI = %s", output.ProvisioningURI)
+		}
+		if len(output.RecoveryCodes) != entity.RecoveryCodeCount {
+			t.Fatalf("len(RecoveryCodes) = %d, want %d", len(output.RecoveryCodes), entity.RecoveryCodeCount)
+		}
+		for i, code := range output.RecoveryCodes {
+			if savedCodes[i].CodeHash() != code.Hash() {
+				t.Error("saved recovery codes should be hashes of the returned codes")
+			}
+		}
+	})
+
+	t.Run("異常系_既に有効", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(enabledTOTP(t, user.ID()), nil)
+
+		_, err := uc.BeginTOTPEnrollment(context.Background(), user.ID())
+
+		if !errors.Is(err, domainErrors.ErrTOTPAlreadyEnabled) {
+			t.Errorf("got %v, want ErrTOTPAlreadyEnabled", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := uc.BeginTOTPEnrollment(context.Background(), userID)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+}
+
+func TestTwoFactorUsecase_ConfirmTOTPEnrollment(t *testing.T) {
+	t.Run("正常系_正しいコードで有効になる", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		userID := vo.NewUserID()
+		totp, _ := entity.NewUserTOTP(userID)
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(totp, nil)
+		m.userTOTPRepo.EXPECT().Save(gomock.Any(), totp).Return(nil)
+
+		if err := uc.ConfirmTOTPEnrollment(context.Background(), userID, totp.Secret().Code(time.Now())); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !totp.IsEnabled() {
+			t.Error("totp should be enabled")
+		}
+		if got := m.auditEvents.actions(); len(got) != 1 || got[0] != entity.AuditActionTwoFactorEnable {
+			t.Errorf("audit events = %v, want [%s]", got, entity.AuditActionTwoFactorEnable)
+		}
+	})
+
+	t.Run("異常系_監査ログの記録に失敗した場合は有効にしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userTOTPRepo := mock.NewMockUserTOTPRepository(ctrl)
+		txManager := mock.NewMockTransactionManager(ctrl)
+		auditRecorder := mock.NewMockAuditRecorder(ctrl)
+		uc := usecase.NewTwoFactorUsecase(nil, userTOTPRepo, nil, nil, txManager, auditRecorder)
+		userID := vo.NewUserID()
+		totp, _ := entity.NewUserTOTP(userID)
+		auditErr := errors.New("audit error")
+
+		setupTxManagerExecute(txManager)
+		userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(totp, nil)
+		userTOTPRepo.EXPECT().Save(gomock.Any(), totp).Return(nil)
+		auditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(auditErr)
+
+		err := uc.ConfirmTOTPEnrollment(context.Background(), userID, totp.Secret().Code(time.Now()))
+
+		if !errors.Is(err, auditErr) {
+			t.Errorf("got %v, want %v", err, auditErr)
+		}
+	})
+
+	t.Run("異常系_コード不一致", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		userID := vo.NewUserID()
+		totp, _ := entity.NewUserTOTP(userID)
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(totp, nil)
+
+		err := uc.ConfirmTOTPEnrollment(context.Background(), userID, "abcdef")
+
+		if !errors.Is(err, domainErrors.ErrInvalidTOTPCode) {
+			t.Errorf("got %v, want ErrInvalidTOTPCode", err)
+		}
+	})
+
+	t.Run("異常系_登録が開始されていない", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), userID).Return(nil, nil)
+
+		err := uc.ConfirmTOTPEnrollment(context.Background(), userID, "123456")
+
+		if !errors.Is(err, domainErrors.ErrTOTPEnrollmentNotFound) {
+			t.Errorf("got %v, want ErrTOTPEnrollmentNotFound", err)
+		}
+	})
+}
+
+func TestTwoFactorUsecase_DisableTOTP(t *testing.T) {
+	password, _ := vo.NewPassword("password123")
+
+	t.Run("正常系_設定とリカバリーコードを削除する", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(enabledTOTP(t, user.ID()), nil)
+		m.userTOTPRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.recoveryCodeRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.twoFactorChallengeRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+
+		if err := uc.DisableTOTP(context.Background(), user.ID(), password); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := m.auditEvents.actions(); len(got) != 1 || got[0] != entity.AuditActionTwoFactorDisable {
+			t.Errorf("audit events = %v, want [%s]", got, entity.AuditActionTwoFactorDisable)
+		}
+	})
+
+	t.Run("異常系_パスワード不一致", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		user := validUser(t)
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		err := uc.DisableTOTP(context.Background(), user.ID(), wrongPassword)
+
+		if !errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+			t.Errorf("got %v, want ErrCurrentPasswordIncorrect", err)
+		}
+	})
+
+	t.Run("異常系_有効になっていない", func(t *testing.T) {
+		m, uc := setupTwoFactorMocks(t)
+		user := validUser(t)
+		pending, _ := entity.NewUserTOTP(user.ID())
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userTOTPRepo.EXPECT().FindByUserID(gomock.Any(), user.ID()).Return(pending, nil)
+
+		err := uc.DisableTOTP(context.Background(), user.ID(), password)
+
+		if !errors.Is(err, domainErrors.ErrTOTPNotEnabled) {
+			t.Errorf("got %v, want ErrTOTPNotEnabled", err)
+		}
+	})
+}
@@ -2,29 +2,71 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
 	"caltrack/domain/repository"
 	"caltrack/domain/vo"
+	"caltrack/pkg/logger"
+	"caltrack/usecase/service"
+)
+
+// メールアドレス確認メールの件名と本文
+const (
+	emailVerificationMailSubject = "【CalTrack】メールアドレスの確認"
+	emailVerificationMailBody    = `CalTrackをご利用いただきありがとうございます。
+以下のリンクから%d時間以内にメールアドレスの確認を完了してください。
+
+%s
+
+このメールにお心当たりがない場合は、破棄していただいて問題ありません。
+`
 )
 
 type UserUsecase struct {
-	userRepo  repository.UserRepository
-	txManager repository.TransactionManager
+	userRepo                   repository.UserRepository
+	targetSnapshotRepo         repository.TargetSnapshotRepository
+	sessionRepo                repository.SessionRepository
+	emailVerificationTokenRepo repository.EmailVerificationTokenRepository
+	profileChangeRepo          repository.ProfileChangeRepository
+	txManager                  repository.TransactionManager
+	auditRecorder              service.AuditRecorder
+	mailer                     service.Mailer
+	appBaseURL                 string
 }
 
+// NewUserUsecase は UserUsecase のインスタンスを生成する
+// appBaseURLは確認メール本文に記載するリンクに使う
 func NewUserUsecase(
 	userRepo repository.UserRepository,
+	targetSnapshotRepo repository.TargetSnapshotRepository,
+	sessionRepo repository.SessionRepository,
+	emailVerificationTokenRepo repository.EmailVerificationTokenRepository,
+	profileChangeRepo repository.ProfileChangeRepository,
 	txManager repository.TransactionManager,
+	auditRecorder service.AuditRecorder,
+	mailer service.Mailer,
+	appBaseURL string,
 ) *UserUsecase {
 	return &UserUsecase{
-		userRepo:  userRepo,
-		txManager: txManager,
+		userRepo:                   userRepo,
+		targetSnapshotRepo:         targetSnapshotRepo,
+		sessionRepo:                sessionRepo,
+		emailVerificationTokenRepo: emailVerificationTokenRepo,
+		profileChangeRepo:          profileChangeRepo,
+		txManager:                  txManager,
+		auditRecorder:              auditRecorder,
+		mailer:                     mailer,
+		appBaseURL:                 appBaseURL,
 	}
 }
 
+// Register はユーザーを登録し、登録したメールアドレスに確認メールを送信する
+// 確認メールの送信に失敗しても登録は成功として扱う（確認メールは再送できる）
 func (u *UserUsecase) Register(ctx context.Context, user *entity.User) (*entity.User, error) {
+	var rawToken vo.OneTimeToken
 	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
 		exists, err := u.userRepo.ExistsByEmail(txCtx, user.Email())
 		if err != nil {
@@ -40,6 +82,20 @@ func (u *UserUsecase) Register(ctx context.Context, user *entity.User) (*entity.
 			logError("Register", err, "user_id", user.ID().String())
 			return err
 		}
+
+		// 登録時点の目標値を記録
+		snapshot := entity.NewTargetSnapshot(user, startOfDay(user.CreatedAt()))
+		if err := u.targetSnapshotRepo.Save(txCtx, snapshot); err != nil {
+			logError("Register", err, "user_id", user.ID().String())
+			return err
+		}
+
+		token, err := u.issueEmailVerificationToken(txCtx, user.ID(), user.Email())
+		if err != nil {
+			logError("Register", err, "user_id", user.ID().String())
+			return err
+		}
+		rawToken = token
 		return nil
 	})
 
@@ -47,11 +103,16 @@ func (u *UserUsecase) Register(ctx context.Context, user *entity.User) (*entity.
 		return nil, err
 	}
 
+	if err := u.sendEmailVerification(ctx, user.Email(), rawToken); err != nil {
+		logError("Register", err, "user_id", user.ID().String())
+	}
+
 	return user, nil
 }
 
 // UpdateProfile は認証ユーザーのプロフィールを更新する
-func (u *UserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel) (*entity.User, error) {
+// gender・birthDateはnilの場合は変更せず、変更した場合は変更履歴を記録する
+func (u *UserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickname vo.Nickname, height vo.Height, weight vo.Weight, activityLevel vo.ActivityLevel, gender *vo.Gender, birthDate *vo.BirthDate) (*entity.User, error) {
 	var updatedUser *entity.User
 
 	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
@@ -65,13 +126,36 @@ func (u *UserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickn
 			return domainErrors.ErrUserNotFound
 		}
 
+		// 変更前の目標値を保持（スナップショット未作成ユーザーの基準値として使用）
+		previousSnapshot := entity.NewTargetSnapshot(user, startOfDay(user.CreatedAt()))
+		before := newAuditProfile(user)
+
 		user.ApplyProfile(nickname, height, weight, activityLevel)
+		changes := user.CorrectDemographics(gender, birthDate)
 
 		if err := u.userRepo.Update(txCtx, user); err != nil {
 			logError("UpdateProfile", err, "user_id", userID.String())
 			return err
 		}
 
+		for _, change := range changes {
+			if err := u.profileChangeRepo.Save(txCtx, change); err != nil {
+				logError("UpdateProfile", err, "user_id", userID.String(), "field", change.Field())
+				return err
+			}
+		}
+
+		if err := u.recordTargetSnapshot(txCtx, user, previousSnapshot); err != nil {
+			return err
+		}
+
+		if diff := before.diff(newAuditProfile(user)); len(diff) > 0 {
+			event := entity.NewUserAuditEvent(entity.AuditActionProfileUpdate, userID, entity.AuditTargetUser, userID.String(), diff)
+			if err := u.auditRecorder.Record(txCtx, event); err != nil {
+				return err
+			}
+		}
+
 		updatedUser = user
 		return nil
 	})
@@ -83,6 +167,79 @@ func (u *UserUsecase) UpdateProfile(ctx context.Context, userID vo.UserID, nickn
 	return updatedUser, nil
 }
 
+// auditProfile は監査ログの差分を求めるためのプロフィールのスナップショット
+type auditProfile struct {
+	nickname      string
+	activityLevel string
+	weight        float64
+	height        float64
+	gender        string
+	birthDate     string
+}
+
+// newAuditProfile はユーザーの現在のプロフィールからスナップショットを生成する
+func newAuditProfile(user *entity.User) auditProfile {
+	return auditProfile{
+		nickname:      user.Nickname().String(),
+		activityLevel: user.ActivityLevel().String(),
+		weight:        user.Weight().Kg(),
+		height:        user.Height().Cm(),
+		gender:        user.Gender().String(),
+		birthDate:     user.BirthDate().Time().Format(dateLayout),
+	}
+}
+
+// diff は変更された項目を監査ログの差分に変換する
+// 健康データ（体重・身長・性別・生年月日）は監査ログに値を残さないよう、変更されたことだけを記録する
+func (p auditProfile) diff(after auditProfile) map[string]any {
+	diff := make(map[string]any)
+	if p.nickname != after.nickname {
+		diff["nickname"] = map[string]any{"old": p.nickname, "new": after.nickname}
+	}
+	if p.activityLevel != after.activityLevel {
+		diff["activityLevel"] = map[string]any{"old": p.activityLevel, "new": after.activityLevel}
+	}
+	changed := map[string]bool{
+		"weight":    p.weight != after.weight,
+		"height":    p.height != after.height,
+		"gender":    p.gender != after.gender,
+		"birthDate": p.birthDate != after.birthDate,
+	}
+	for field, ok := range changed {
+		if ok {
+			diff[field] = map[string]any{"changed": true}
+		}
+	}
+	return diff
+}
+
+// recordTargetSnapshot は目標値が変わった場合に今日付けのスナップショットを記録する
+// スナップショットが1件もないユーザーは、変更前の目標値を登録日付で先に記録する
+func (u *UserUsecase) recordTargetSnapshot(ctx context.Context, user *entity.User, previous *entity.TargetSnapshot) error {
+	if user.CalculateTargetCalories() == previous.TargetCalories().Value() {
+		return nil
+	}
+
+	exists, err := u.targetSnapshotRepo.ExistsByUserID(ctx, user.ID())
+	if err != nil {
+		logError("recordTargetSnapshot", err, "user_id", user.ID().String())
+		return err
+	}
+	if !exists {
+		if err := u.targetSnapshotRepo.Save(ctx, previous); err != nil {
+			logError("recordTargetSnapshot", err, "user_id", user.ID().String())
+			return err
+		}
+	}
+
+	snapshot := entity.NewTargetSnapshot(user, startOfDay(time.Now()))
+	if err := u.targetSnapshotRepo.Save(ctx, snapshot); err != nil {
+		logError("recordTargetSnapshot", err, "user_id", user.ID().String())
+		return err
+	}
+	return nil
+}
+
 // GetProfile は認証ユーザーのプロフィールを取得する
 func (u *UserUsecase) GetProfile(ctx context.Context, userID vo.UserID) (*entity.User, error) {
 	user, err := u.userRepo.FindByID(ctx, userID)
@@ -97,3 +254,357 @@ func (u *UserUsecase) GetProfile(ctx context.Context, userID vo.UserID) (*entity
 
 	return user, nil
 }
+
+// UpdateBudgetMode は認証ユーザーのカロリー予算モード（日単位・週単位）を変更する
+// モードが変わった場合は変更前後の値を監査ログに記録する
+func (u *UserUsecase) UpdateBudgetMode(ctx context.Context, userID vo.UserID, budgetMode vo.BudgetMode) (*entity.User, error) {
+	var user *entity.User
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("UpdateBudgetMode", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("UpdateBudgetMode", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		before := user.BudgetMode()
+		user.ChangeBudgetMode(budgetMode)
+
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("UpdateBudgetMode", err, "user_id", userID.String())
+			return err
+		}
+
+		if before.String() == budgetMode.String() {
+			return nil
+		}
+		event := entity.NewUserAuditEvent(entity.AuditActionProfileUpdate, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"budgetMode": map[string]any{"old": before.String(), "new": budgetMode.String()},
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdatePrivacySettings は認証ユーザーのプライバシー設定を変更し、同意日時とポリシーのバージョンを記録する
+func (u *UserUsecase) UpdatePrivacySettings(ctx context.Context, userID vo.UserID, settings vo.PrivacySettings) (*entity.User, error) {
+	var user *entity.User
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("UpdatePrivacySettings", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("UpdatePrivacySettings", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		before := user.PrivacySettings()
+		user.ChangePrivacySettings(settings)
+
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("UpdatePrivacySettings", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionPrivacyUpdate, userID, entity.AuditTargetUser, userID.String(), privacySettingsDiff(before, settings))
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// privacySettingsDiff はプライバシー設定の変更を監査ログの差分に変換する
+// 同意したポリシーのバージョンは変更の有無にかかわらず記録する
+func privacySettingsDiff(before, after vo.PrivacySettings) map[string]any {
+	diff := map[string]any{"consentVersion": after.ConsentVersion()}
+	if before.AIProcessingEnabled() != after.AIProcessingEnabled() {
+		diff["aiProcessing"] = map[string]any{"old": before.AIProcessingEnabled(), "new": after.AIProcessingEnabled()}
+	}
+	if before.AnalyticsEnabled() != after.AnalyticsEnabled() {
+		diff["analytics"] = map[string]any{"old": before.AnalyticsEnabled(), "new": after.AnalyticsEnabled()}
+	}
+	if before.RetentionYears() != after.RetentionYears() {
+		diff["retentionYears"] = map[string]any{"old": before.RetentionYears(), "new": after.RetentionYears()}
+	}
+	return diff
+}
+
+// ChangePassword は現在のパスワードを照合して新しいパスワードに変更する
+// 同一トランザクション内で、現在のセッション以外の全セッションを削除して監査ログに記録する
+func (u *UserUsecase) ChangePassword(ctx context.Context, userID vo.UserID, currentSessionID vo.SessionID, currentPassword vo.Password, newPassword vo.Password) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("ChangePassword", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("ChangePassword", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		if err := user.ChangePassword(currentPassword, newPassword); err != nil {
+			logWarn("ChangePassword", err.Error(), "user_id", userID.String())
+			return err
+		}
+
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("ChangePassword", err, "user_id", userID.String())
+			return err
+		}
+
+		// 他の端末のセッションを無効化
+		if err := u.sessionRepo.DeleteByUserIDExcept(txCtx, userID, currentSessionID); err != nil {
+			logError("ChangePassword", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionPasswordChange, userID, entity.AuditTargetUser, userID.String(), nil)
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// SetPassword は外部IDプロバイダーで登録したパスワード未設定のユーザーにパスワードを設定する
+// 設定後はメールアドレスとパスワードでもログインできる
+// 既に設定済みの場合は ErrPasswordAlreadySet を返す（変更は ChangePassword を使う）
+func (u *UserUsecase) SetPassword(ctx context.Context, userID vo.UserID, newPassword vo.Password) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("SetPassword", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("SetPassword", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		if err := user.SetPassword(newPassword); err != nil {
+			logWarn("SetPassword", err.Error(), "user_id", userID.String())
+			return err
+		}
+
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("SetPassword", err, "user_id", userID.String())
+			return err
+		}
+
+		event := entity.NewUserAuditEvent(entity.AuditActionPasswordSet, userID, entity.AuditTargetUser, userID.String(), nil)
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// RequestEmailChange はパスワードを確認した上で、新しいメールアドレス宛てに確認メールを送信する
+// メールアドレスは確認リンクが開かれるまで変更しない（申請したことは監査ログに記録する）
+func (u *UserUsecase) RequestEmailChange(ctx context.Context, userID vo.UserID, password vo.Password, newEmail vo.Email) error {
+	var rawToken vo.OneTimeToken
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("RequestEmailChange", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("RequestEmailChange", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+
+		if !user.HashedPassword().Compare(password) {
+			logWarn("RequestEmailChange", "password incorrect", "user_id", userID.String())
+			return domainErrors.ErrCurrentPasswordIncorrect
+		}
+		if user.Email().Equals(newEmail) {
+			return domainErrors.ErrEmailUnchanged
+		}
+
+		exists, err := u.userRepo.ExistsByEmail(txCtx, newEmail)
+		if err != nil {
+			logError("RequestEmailChange", err, "user_id", userID.String())
+			return err
+		}
+		if exists {
+			logWarn("RequestEmailChange", "email already exists", "user_id", userID.String())
+			return domainErrors.ErrEmailAlreadyExists
+		}
+
+		token, err := u.issueEmailVerificationToken(txCtx, userID, newEmail)
+		if err != nil {
+			logError("RequestEmailChange", err, "user_id", userID.String())
+			return err
+		}
+		rawToken = token
+
+		event := entity.NewUserAuditEvent(entity.AuditActionEmailChangeRequest, userID, entity.AuditTargetUser, userID.String(), map[string]any{
+			"newEmail": logger.MaskEmails(newEmail.String()),
+		})
+		return u.auditRecorder.Record(txCtx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := u.sendEmailVerification(ctx, newEmail, rawToken); err != nil {
+		logError("RequestEmailChange", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// ResendEmailVerification は現在のメールアドレス宛てに確認メールを再送する
+// 発行済みのトークン（メールアドレス変更の申請を含む）は無効になる
+func (u *UserUsecase) ResendEmailVerification(ctx context.Context, userID vo.UserID) error {
+	var email vo.Email
+	var rawToken vo.OneTimeToken
+	err := u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := u.userRepo.FindByID(txCtx, userID)
+		if err != nil {
+			logError("ResendEmailVerification", err, "user_id", userID.String())
+			return err
+		}
+		if user == nil {
+			logWarn("ResendEmailVerification", "user not found", "user_id", userID.String())
+			return domainErrors.ErrUserNotFound
+		}
+		if user.IsEmailVerified() {
+			return domainErrors.ErrEmailAlreadyVerified
+		}
+
+		token, err := u.issueEmailVerificationToken(txCtx, userID, user.Email())
+		if err != nil {
+			logError("ResendEmailVerification", err, "user_id", userID.String())
+			return err
+		}
+		email = user.Email()
+		rawToken = token
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := u.sendEmailVerification(ctx, email, rawToken); err != nil {
+		logError("ResendEmailVerification", err, "user_id", userID.String())
+		return err
+	}
+	return nil
+}
+
+// VerifyEmail は確認トークンを検証し、トークンに紐づくメールアドレスを確認済みとして設定する
+// メールアドレス変更の場合は、この時点で新しいアドレスに切り替わる
+func (u *UserUsecase) VerifyEmail(ctx context.Context, token vo.OneTimeToken) error {
+	return u.txManager.Execute(ctx, func(txCtx context.Context) error {
+		verificationToken, err := u.emailVerificationTokenRepo.FindByTokenHash(txCtx, token.Hash())
+		if err != nil {
+			logError("VerifyEmail", err)
+			return err
+		}
+		if verificationToken == nil {
+			logWarn("VerifyEmail", "token not found")
+			return domainErrors.ErrEmailVerificationTokenInvalid
+		}
+
+		if err := verificationToken.Use(); err != nil {
+			logWarn("VerifyEmail", "token expired or already used", "user_id", verificationToken.UserID().String())
+			return err
+		}
+
+		user, err := u.userRepo.FindByID(txCtx, verificationToken.UserID())
+		if err != nil {
+			logError("VerifyEmail", err, "user_id", verificationToken.UserID().String())
+			return err
+		}
+		if user == nil {
+			logWarn("VerifyEmail", "user not found", "user_id", verificationToken.UserID().String())
+			return domainErrors.ErrEmailVerificationTokenInvalid
+		}
+
+		// 申請後に同じアドレスが別ユーザーに登録されていないか再確認する
+		if !user.Email().Equals(verificationToken.Email()) {
+			exists, err := u.userRepo.ExistsByEmail(txCtx, verificationToken.Email())
+			if err != nil {
+				logError("VerifyEmail", err, "user_id", user.ID().String())
+				return err
+			}
+			if exists {
+				logWarn("VerifyEmail", "email already exists", "user_id", user.ID().String())
+				return domainErrors.ErrEmailAlreadyExists
+			}
+		}
+
+		oldEmail := user.Email()
+		user.ConfirmEmail(verificationToken.Email())
+
+		if err := u.userRepo.Update(txCtx, user); err != nil {
+			logError("VerifyEmail", err, "user_id", user.ID().String())
+			return err
+		}
+		if err := u.emailVerificationTokenRepo.MarkUsed(txCtx, verificationToken); err != nil {
+			logError("VerifyEmail", err, "user_id", user.ID().String())
+			return err
+		}
+
+		// メールアドレスを変更した場合は変更前後のアドレスを記録する（先頭1文字とドメインだけ残す）
+		var diff map[string]any
+		if !oldEmail.Equals(user.Email()) {
+			diff = map[string]any{
+				"email": map[string]any{"old": logger.MaskEmails(oldEmail.String()), "new": logger.MaskEmails(user.Email().String())},
+			}
+		}
+		event := entity.NewUserAuditEvent(entity.AuditActionEmailVerify, user.ID(), entity.AuditTargetUser, user.ID().String(), diff)
+		return u.auditRecorder.Record(txCtx, event)
+	})
+}
+
+// IsEmailVerified は認証ユーザーのメールアドレスが確認済みかを返す
+func (u *UserUsecase) IsEmailVerified(ctx context.Context, userID vo.UserID) (bool, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logError("IsEmailVerified", err, "user_id", userID.String())
+		return false, err
+	}
+	if user == nil {
+		logWarn("IsEmailVerified", "user not found", "user_id", userID.String())
+		return false, domainErrors.ErrUserNotFound
+	}
+
+	return user.IsEmailVerified(), nil
+}
+
+// issueEmailVerificationToken は発行済みのトークンを無効化し、確認対象のアドレスに対する新しいトークンを保存する
+func (u *UserUsecase) issueEmailVerificationToken(ctx context.Context, userID vo.UserID, email vo.Email) (vo.OneTimeToken, error) {
+	if err := u.emailVerificationTokenRepo.DeleteByUserID(ctx, userID); err != nil {
+		return vo.OneTimeToken{}, err
+	}
+
+	token, rawToken, err := entity.NewEmailVerificationToken(userID, email)
+	if err != nil {
+		return vo.OneTimeToken{}, err
+	}
+	if err := u.emailVerificationTokenRepo.Save(ctx, token); err != nil {
+		return vo.OneTimeToken{}, err
+	}
+	return rawToken, nil
+}
+
+// sendEmailVerification は確認用リンクを記載したメールを送信する
+func (u *UserUsecase) sendEmailVerification(ctx context.Context, email vo.Email, token vo.OneTimeToken) error {
+	return u.mailer.Send(ctx, service.Mail{
+		To:      email.String(),
+		Subject: emailVerificationMailSubject,
+		Body:    fmt.Sprintf(emailVerificationMailBody, int(entity.EmailVerificationTokenTTL.Hours()), u.appBaseURL+"/verify-email?token="+token.String()),
+	})
+}
@@ -3,25 +3,64 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"caltrack/domain/entity"
 	domainErrors "caltrack/domain/errors"
+	"caltrack/domain/helper"
 	"caltrack/domain/vo"
 	"caltrack/mock"
 	"caltrack/usecase"
+	"caltrack/usecase/service"
 
 	gomock "go.uber.org/mock/gomock"
 )
 
+// userMocks はUser Usecaseのテスト用モックをまとめる
+type userMocks struct {
+	userRepo                   *mock.MockUserRepository
+	targetSnapshotRepo         *mock.MockTargetSnapshotRepository
+	sessionRepo                *mock.MockSessionRepository
+	emailVerificationTokenRepo *mock.MockEmailVerificationTokenRepository
+	profileChangeRepo          *mock.MockProfileChangeRepository
+	txManager                  *mock.MockTransactionManager
+	auditRecorder              *mock.MockAuditRecorder
+	auditEvents                *auditEvents
+	mailer                     *mock.MockMailer
+}
+
 // setupUserMocks はUser Usecase用のモックを初期化する
-func setupUserMocks(t *testing.T) (*mock.MockUserRepository, *mock.MockTransactionManager, *gomock.Controller) {
+func setupUserMocks(t *testing.T) (*userMocks, *gomock.Controller) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
-	userRepo := mock.NewMockUserRepository(ctrl)
-	txManager := mock.NewMockTransactionManager(ctrl)
-	return userRepo, txManager, ctrl
+	auditRecorder, auditEvents := captureAuditEvents(ctrl)
+	return &userMocks{
+		userRepo:                   mock.NewMockUserRepository(ctrl),
+		targetSnapshotRepo:         mock.NewMockTargetSnapshotRepository(ctrl),
+		sessionRepo:                mock.NewMockSessionRepository(ctrl),
+		emailVerificationTokenRepo: mock.NewMockEmailVerificationTokenRepository(ctrl),
+		profileChangeRepo:          mock.NewMockProfileChangeRepository(ctrl),
+		txManager:                  mock.NewMockTransactionManager(ctrl),
+		auditRecorder:              auditRecorder,
+		auditEvents:                auditEvents,
+		mailer:                     mock.NewMockMailer(ctrl),
+	}, ctrl
+}
+
+// newUserUsecase はモックからUserUsecaseを生成する
+func newUserUsecase(m *userMocks) *usecase.UserUsecase {
+	return usecase.NewUserUsecase(m.userRepo, m.targetSnapshotRepo, m.sessionRepo, m.emailVerificationTokenRepo, m.profileChangeRepo, m.txManager, m.auditRecorder, m.mailer, testAppBaseURL)
+}
+
+func mustNickname(t *testing.T, value string) vo.Nickname {
+	t.Helper()
+	nickname, err := vo.NewNickname(value)
+	if err != nil {
+		t.Fatalf("failed to create nickname: %v", err)
+	}
+	return nickname
 }
 
 func validUser(t *testing.T) *entity.User {
@@ -54,6 +93,11 @@ func reconstructedUser(t *testing.T) *entity.User {
 		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 		"male",
 		"sedentary",
+		"daily",
+		vo.DefaultPrivacySettings(),
+		vo.UserRoleUser,
+		nil,
+		nil,
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	)
@@ -66,44 +110,90 @@ func reconstructedUser(t *testing.T) *entity.User {
 // TestUserUsecase_Register はユーザー登録機能のテスト
 func TestUserUsecase_Register(t *testing.T) {
 	t.Run("正常系_登録成功", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := validUser(t)
 		email, _ := vo.NewEmail("test@example.com")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			ExistsByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(false, nil)
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			Save(gomock.Any(), gomock.Any()).
 			Return(nil)
-
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		var savedSnapshot *entity.TargetSnapshot
+		m.targetSnapshotRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, s *entity.TargetSnapshot) error {
+				savedSnapshot = s
+				return nil
+			})
+		m.emailVerificationTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		var sentMail service.Mail
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, mail service.Mail) error {
+				sentMail = mail
+				return nil
+			})
+
+		uc := newUserUsecase(m)
 		registeredUser, err := uc.Register(context.Background(), user)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
+		if sentMail.To != email.String() {
+			t.Errorf("verification mail to = %s, want %s", sentMail.To, email.String())
+		}
 		if registeredUser.ID().String() == "" {
 			t.Error("UserID should not be empty")
 		}
+		if savedSnapshot == nil {
+			t.Fatal("target snapshot should be saved")
+		}
+		if savedSnapshot.TargetCalories().Value() != user.CalculateTargetCalories() {
+			t.Errorf("snapshot target = %d, want %d", savedSnapshot.TargetCalories().Value(), user.CalculateTargetCalories())
+		}
+	})
+
+	t.Run("正常系_確認メールの送信に失敗しても登録は成功する", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), gomock.Any()).Return(false, nil)
+		m.userRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.targetSnapshotRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp error"))
+
+		_, err := newUserUsecase(m).Register(context.Background(), user)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 	})
 
 	t.Run("異常系_メールアドレスが既に存在する", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := validUser(t)
 		email, _ := vo.NewEmail("test@example.com")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			ExistsByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(true, nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		_, err := uc.Register(context.Background(), user)
 
 		if err != domainErrors.ErrEmailAlreadyExists {
@@ -112,19 +202,19 @@ func TestUserUsecase_Register(t *testing.T) {
 	})
 
 	t.Run("異常系_リポジトリエラー", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := validUser(t)
 		email, _ := vo.NewEmail("test@example.com")
 		repoErr := errors.New("db error")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			ExistsByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(false, repoErr)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		_, err := uc.Register(context.Background(), user)
 
 		if err != repoErr {
@@ -133,22 +223,22 @@ func TestUserUsecase_Register(t *testing.T) {
 	})
 
 	t.Run("異常系_保存エラー", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := validUser(t)
 		email, _ := vo.NewEmail("test@example.com")
 		saveErr := errors.New("save error")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			ExistsByEmail(gomock.Any(), gomock.Eq(email)).
 			Return(false, nil)
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			Save(gomock.Any(), gomock.Any()).
 			Return(saveErr)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		_, err := uc.Register(context.Background(), user)
 
 		if !errors.Is(err, saveErr) {
@@ -160,25 +250,31 @@ func TestUserUsecase_Register(t *testing.T) {
 // TestUserUsecase_UpdateProfile はプロフィール更新機能のテスト
 func TestUserUsecase_UpdateProfile(t *testing.T) {
 	t.Run("正常系_プロフィール更新成功", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := reconstructedUser(t)
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(user.ID())).
 			Return(user, nil)
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
+		m.targetSnapshotRepo.EXPECT().
+			ExistsByUserID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(true, nil)
+		m.targetSnapshotRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		nickname, _ := vo.NewNickname("newnick")
 		height, _ := vo.NewHeight(170.0)
 		weight, _ := vo.NewWeight(65.0)
 		activityLevel, _ := vo.NewActivityLevel("moderate")
-		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel)
+		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel, nil, nil)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -195,25 +291,202 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 		if updatedUser.ActivityLevel().String() != "moderate" {
 			t.Errorf("activity level got %v, want moderate", updatedUser.ActivityLevel().String())
 		}
+
+		// 監査ログには健康データの値を残さず、変更されたことだけを記録する
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionProfileUpdate {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionProfileUpdate)
+		}
+		diff := m.auditEvents.events[0].Diff()
+		wantNickname := map[string]any{"old": "oldnick", "new": "newnick"}
+		if !reflect.DeepEqual(diff["nickname"], wantNickname) {
+			t.Errorf("diff[nickname] = %v, want %v", diff["nickname"], wantNickname)
+		}
+		wantChanged := map[string]any{"changed": true}
+		if !reflect.DeepEqual(diff["weight"], wantChanged) || !reflect.DeepEqual(diff["height"], wantChanged) {
+			t.Errorf("diff[weight] = %v, diff[height] = %v, want %v", diff["weight"], diff["height"], wantChanged)
+		}
+		if _, ok := diff["gender"]; ok {
+			t.Errorf("diff[gender] = %v, want absent", diff["gender"])
+		}
+	})
+
+	t.Run("正常系_スナップショット未作成の場合は変更前の目標値も記録される", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		previousTarget := user.CalculateTargetCalories()
+		var savedSnapshots []*entity.TargetSnapshot
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+		m.targetSnapshotRepo.EXPECT().
+			ExistsByUserID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(false, nil)
+		m.targetSnapshotRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, s *entity.TargetSnapshot) error {
+				savedSnapshots = append(savedSnapshots, s)
+				return nil
+			}).
+			Times(2)
+
+		uc := newUserUsecase(m)
+		nickname, _ := vo.NewNickname("newnick")
+		height, _ := vo.NewHeight(170.0)
+		weight, _ := vo.NewWeight(65.0)
+		activityLevel, _ := vo.NewActivityLevel("moderate")
+		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel, nil, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(savedSnapshots) != 2 {
+			t.Fatalf("saved snapshots = %d, want 2", len(savedSnapshots))
+		}
+		// 1件目は登録日付で変更前の目標値
+		if savedSnapshots[0].TargetCalories().Value() != previousTarget {
+			t.Errorf("baseline target = %d, want %d", savedSnapshots[0].TargetCalories().Value(), previousTarget)
+		}
+		createdAt := user.CreatedAt().In(helper.JST())
+		wantBaselineDate := time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, helper.JST())
+		if !savedSnapshots[0].EffectiveDate().Equal(wantBaselineDate) {
+			t.Errorf("baseline effective date = %v, want %v", savedSnapshots[0].EffectiveDate(), wantBaselineDate)
+		}
+		// 2件目は変更後の目標値で、適用日は今日のJSTの0時
+		if savedSnapshots[1].TargetCalories().Value() != updatedUser.CalculateTargetCalories() {
+			t.Errorf("new target = %d, want %d", savedSnapshots[1].TargetCalories().Value(), updatedUser.CalculateTargetCalories())
+		}
+		now := time.Now().In(helper.JST())
+		wantDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, helper.JST())
+		if !savedSnapshots[1].EffectiveDate().Equal(wantDate) {
+			t.Errorf("new effective date = %v, want %v", savedSnapshots[1].EffectiveDate(), wantDate)
+		}
+	})
+
+	t.Run("正常系_目標値が変わらない場合はスナップショットを記録しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		uc := newUserUsecase(m)
+		// ニックネームのみ変更
+		_, err := uc.UpdateProfile(context.Background(), user.ID(), mustNickname(t, "newnick"), user.Height(), user.Weight(), user.ActivityLevel(), nil, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("正常系_性別と生年月日を訂正すると変更履歴を記録する", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		previousTarget := user.CalculateTargetCalories()
+		var savedChanges []*entity.ProfileChange
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+		m.profileChangeRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, change *entity.ProfileChange) error {
+				savedChanges = append(savedChanges, change)
+				return nil
+			}).
+			Times(2)
+		m.targetSnapshotRepo.EXPECT().
+			ExistsByUserID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(true, nil)
+		m.targetSnapshotRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		uc := newUserUsecase(m)
+		gender, _ := vo.NewGender("female")
+		birthDate, _ := vo.NewBirthDate(time.Date(1985, 6, 15, 0, 0, 0, 0, time.UTC))
+		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), user.Nickname(), user.Height(), user.Weight(), user.ActivityLevel(), &gender, &birthDate)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updatedUser.Gender().String() != "female" {
+			t.Errorf("gender got %v, want female", updatedUser.Gender().String())
+		}
+		if updatedUser.CalculateTargetCalories() == previousTarget {
+			t.Error("target calories should change after correcting gender and birth date")
+		}
+		if len(savedChanges) != 2 {
+			t.Fatalf("saved changes = %d, want 2", len(savedChanges))
+		}
+		if savedChanges[1].Field() != entity.ProfileFieldBirthDate || savedChanges[1].OldValue() != "1990-01-01" || savedChanges[1].NewValue() != "1985-06-15" {
+			t.Errorf("birth date change = %s: %s -> %s", savedChanges[1].Field(), savedChanges[1].OldValue(), savedChanges[1].NewValue())
+		}
+	})
+
+	t.Run("異常系_変更履歴の保存に失敗した場合はエラーを返す", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		saveErr := errors.New("save error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+		m.profileChangeRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(saveErr)
+
+		uc := newUserUsecase(m)
+		gender, _ := vo.NewGender("female")
+		_, err := uc.UpdateProfile(context.Background(), user.ID(), user.Nickname(), user.Height(), user.Weight(), user.ActivityLevel(), &gender, nil)
+
+		if !errors.Is(err, saveErr) {
+			t.Errorf("got %v, want saveErr", err)
+		}
 	})
 
 	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		nickname, _ := vo.NewNickname("newnick")
 		height, _ := vo.NewHeight(170.0)
 		weight, _ := vo.NewWeight(65.0)
 		activityLevel, _ := vo.NewActivityLevel("moderate")
-		_, err := uc.UpdateProfile(context.Background(), userID, nickname, height, weight, activityLevel)
+		_, err := uc.UpdateProfile(context.Background(), userID, nickname, height, weight, activityLevel, nil, nil)
 
 		if err != domainErrors.ErrUserNotFound {
 			t.Errorf("got %v, want ErrUserNotFound", err)
@@ -221,23 +494,23 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 	})
 
 	t.Run("異常系_FindByIDリポジトリエラー", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
 		repoErr := errors.New("db error")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		nickname, _ := vo.NewNickname("newnick")
 		height, _ := vo.NewHeight(170.0)
 		weight, _ := vo.NewWeight(65.0)
 		activityLevel, _ := vo.NewActivityLevel("moderate")
-		_, err := uc.UpdateProfile(context.Background(), userID, nickname, height, weight, activityLevel)
+		_, err := uc.UpdateProfile(context.Background(), userID, nickname, height, weight, activityLevel, nil, nil)
 
 		if !errors.Is(err, repoErr) {
 			t.Errorf("got %v, want repoErr", err)
@@ -245,26 +518,26 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 	})
 
 	t.Run("異常系_Updateリポジトリエラー", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := reconstructedUser(t)
 		updateErr := errors.New("update error")
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(user.ID())).
 			Return(user, nil)
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			Update(gomock.Any(), gomock.Any()).
 			Return(updateErr)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		nickname, _ := vo.NewNickname("newnick")
 		height, _ := vo.NewHeight(170.0)
 		weight, _ := vo.NewWeight(65.0)
 		activityLevel, _ := vo.NewActivityLevel("moderate")
-		_, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel)
+		_, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel, nil, nil)
 
 		if !errors.Is(err, updateErr) {
 			t.Errorf("got %v, want updateErr", err)
@@ -272,25 +545,31 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 	})
 
 	t.Run("正常系_更新後のEntityが返却される", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := reconstructedUser(t)
 
-		setupTxManagerExecute(txManager)
-		userRepo.EXPECT().
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(user.ID())).
 			Return(user, nil)
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			Update(gomock.Any(), gomock.Any()).
 			Return(nil)
+		m.targetSnapshotRepo.EXPECT().
+			ExistsByUserID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(true, nil)
+		m.targetSnapshotRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Return(nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		nickname, _ := vo.NewNickname("updatednick")
 		height, _ := vo.NewHeight(180.0)
 		weight, _ := vo.NewWeight(75.0)
 		activityLevel, _ := vo.NewActivityLevel("active")
-		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel)
+		updatedUser, err := uc.UpdateProfile(context.Background(), user.ID(), nickname, height, weight, activityLevel, nil, nil)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -310,17 +589,17 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 // TestUserUsecase_GetProfile はユーザー情報取得機能のテスト
 func TestUserUsecase_GetProfile(t *testing.T) {
 	t.Run("正常系_プロフィール取得成功", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		user := reconstructedUser(t)
 
 		// GetProfileはトランザクションを使用しないのでEXPECTは不要
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(user.ID())).
 			Return(user, nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		result, err := uc.GetProfile(context.Background(), user.ID())
 
 		if err != nil {
@@ -350,16 +629,16 @@ func TestUserUsecase_GetProfile(t *testing.T) {
 	})
 
 	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
 
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, nil)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		_, err := uc.GetProfile(context.Background(), userID)
 
 		if err != domainErrors.ErrUserNotFound {
@@ -368,17 +647,17 @@ func TestUserUsecase_GetProfile(t *testing.T) {
 	})
 
 	t.Run("異常系_リポジトリエラー", func(t *testing.T) {
-		userRepo, txManager, ctrl := setupUserMocks(t)
+		m, ctrl := setupUserMocks(t)
 		defer ctrl.Finish()
 
 		userID := vo.NewUserID()
 		repoErr := errors.New("db error")
 
-		userRepo.EXPECT().
+		m.userRepo.EXPECT().
 			FindByID(gomock.Any(), gomock.Eq(userID)).
 			Return(nil, repoErr)
 
-		uc := usecase.NewUserUsecase(userRepo, txManager)
+		uc := newUserUsecase(m)
 		_, err := uc.GetProfile(context.Background(), userID)
 
 		if !errors.Is(err, repoErr) {
@@ -386,3 +665,622 @@ func TestUserUsecase_GetProfile(t *testing.T) {
 		}
 	})
 }
+
+func TestUserUsecase_UpdateBudgetMode(t *testing.T) {
+	t.Run("正常系_週単位モードに変更される", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		weekly, _ := vo.NewBudgetMode("weekly")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		uc := newUserUsecase(m)
+		result, err := uc.UpdateBudgetMode(context.Background(), user.ID(), weekly)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.BudgetMode().String() != "weekly" {
+			t.Errorf("budget mode got %v, want weekly", result.BudgetMode().String())
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionProfileUpdate {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionProfileUpdate)
+		}
+		want := map[string]any{"budgetMode": map[string]any{"old": "daily", "new": "weekly"}}
+		if diff := m.auditEvents.events[0].Diff(); !reflect.DeepEqual(diff, want) {
+			t.Errorf("diff = %v, want %v", diff, want)
+		}
+	})
+
+	t.Run("正常系_モードが変わらない場合は監査ログを記録しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		uc := newUserUsecase(m)
+		_, err := uc.UpdateBudgetMode(context.Background(), user.ID(), vo.DefaultBudgetMode())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+
+	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(nil, nil)
+
+		uc := newUserUsecase(m)
+		_, err := uc.UpdateBudgetMode(context.Background(), userID, vo.DefaultBudgetMode())
+
+		if err != domainErrors.ErrUserNotFound {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+
+	t.Run("異常系_Updateリポジトリエラー", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		repoErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(repoErr)
+
+		uc := newUserUsecase(m)
+		_, err := uc.UpdateBudgetMode(context.Background(), user.ID(), vo.DefaultBudgetMode())
+
+		if !errors.Is(err, repoErr) {
+			t.Errorf("got %v, want repoErr", err)
+		}
+	})
+}
+
+func TestUserUsecase_UpdatePrivacySettings(t *testing.T) {
+	t.Run("正常系_AI機能をオフにして同意を記録する", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := reconstructedUser(t)
+		settings, _ := vo.NewPrivacySettings(false, false, 3, "2026-10")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(user.ID())).
+			Return(user, nil)
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		uc := newUserUsecase(m)
+		result, err := uc.UpdatePrivacySettings(context.Background(), user.ID(), settings)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.AllowsAIProcessing() {
+			t.Error("AI processing should be disabled")
+		}
+		if result.PrivacySettings().ConsentVersion() != "2026-10" || result.PrivacySettings().ConsentedAt() == nil {
+			t.Errorf("consent = %q %v, want 2026-10 with timestamp", result.PrivacySettings().ConsentVersion(), result.PrivacySettings().ConsentedAt())
+		}
+
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionPrivacyUpdate {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionPrivacyUpdate)
+		}
+		diff := m.auditEvents.events[0].Diff()
+		wantAI := map[string]any{"old": true, "new": false}
+		if !reflect.DeepEqual(diff["aiProcessing"], wantAI) || diff["consentVersion"] != "2026-10" {
+			t.Errorf("diff = %v, want aiProcessing %v and consentVersion 2026-10", diff, wantAI)
+		}
+		if _, ok := diff["analytics"]; ok {
+			t.Errorf("diff[analytics] = %v, want absent", diff["analytics"])
+		}
+	})
+
+	t.Run("異常系_ユーザーが見つからない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		settings, _ := vo.NewPrivacySettings(true, false, 0, "2026-10")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().
+			FindByID(gomock.Any(), gomock.Eq(userID)).
+			Return(nil, nil)
+
+		uc := newUserUsecase(m)
+		_, err := uc.UpdatePrivacySettings(context.Background(), userID, settings)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+		if len(m.auditEvents.events) != 0 {
+			t.Errorf("audit events = %v, want none", m.auditEvents.actions())
+		}
+	})
+}
+
+// TestUserUsecase_ChangePassword はパスワード変更機能のテスト
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	currentPassword, _ := vo.NewPassword("password123")
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	t.Run("正常系_パスワードが変更され他のセッションが削除される", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		currentSessionID := validSessionID(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		var updatedUser *entity.User
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *entity.User) error {
+				updatedUser = u
+				return nil
+			})
+		m.sessionRepo.EXPECT().DeleteByUserIDExcept(gomock.Any(), user.ID(), currentSessionID).Return(nil)
+
+		uc := newUserUsecase(m)
+		err := uc.ChangePassword(context.Background(), user.ID(), currentSessionID, currentPassword, newPassword)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !updatedUser.HashedPassword().Compare(newPassword) {
+			t.Error("new password should match the updated hash")
+		}
+		if updatedUser.HashedPassword().Compare(currentPassword) {
+			t.Error("old password should no longer match")
+		}
+		if got := m.auditEvents.actions(); !reflect.DeepEqual(got, []entity.AuditAction{entity.AuditActionPasswordChange}) {
+			t.Errorf("audit events = %v, want [%s]", got, entity.AuditActionPasswordChange)
+		}
+	})
+
+	t.Run("異常系_現在のパスワードが一致しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		uc := newUserUsecase(m)
+		err := uc.ChangePassword(context.Background(), user.ID(), validSessionID(t), wrongPassword, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+			t.Errorf("got %v, want ErrCurrentPasswordIncorrect", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		uc := newUserUsecase(m)
+		err := uc.ChangePassword(context.Background(), userID, validSessionID(t), currentPassword, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+
+	t.Run("異常系_セッション削除エラーでロールバック", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		currentSessionID := validSessionID(t)
+		dbErr := errors.New("db error")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		m.sessionRepo.EXPECT().DeleteByUserIDExcept(gomock.Any(), user.ID(), currentSessionID).Return(dbErr)
+
+		uc := newUserUsecase(m)
+		err := uc.ChangePassword(context.Background(), user.ID(), currentSessionID, currentPassword, newPassword)
+
+		if !errors.Is(err, dbErr) {
+			t.Errorf("got %v, want db error", err)
+		}
+	})
+}
+
+func TestUserUsecase_SetPassword(t *testing.T) {
+	newPassword, _ := vo.NewPassword("newpassword456")
+
+	t.Run("正常系_パスワード未設定のユーザーにパスワードが設定される", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUserWithoutPassword(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		var updatedUser *entity.User
+		m.userRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *entity.User) error {
+				updatedUser = u
+				return nil
+			})
+
+		uc := newUserUsecase(m)
+		err := uc.SetPassword(context.Background(), user.ID(), newPassword)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !updatedUser.HashedPassword().Compare(newPassword) {
+			t.Error("new password should match the updated hash")
+		}
+		if got := m.auditEvents.actions(); !reflect.DeepEqual(got, []entity.AuditAction{entity.AuditActionPasswordSet}) {
+			t.Errorf("audit events = %v, want [%s]", got, entity.AuditActionPasswordSet)
+		}
+	})
+
+	t.Run("異常系_パスワード設定済みのユーザー", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		uc := newUserUsecase(m)
+		err := uc.SetPassword(context.Background(), user.ID(), newPassword)
+
+		if !errors.Is(err, domainErrors.ErrPasswordAlreadySet) {
+			t.Errorf("got %v, want ErrPasswordAlreadySet", err)
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		uc := newUserUsecase(m)
+		err := uc.SetPassword(context.Background(), userID, newPassword)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+}
+
+// verificationTokenFor は指定ユーザー・メールアドレスの有効な確認トークンとその平文を生成する
+func verificationTokenFor(t *testing.T, userID vo.UserID, email string) (*entity.EmailVerificationToken, vo.OneTimeToken) {
+	t.Helper()
+	emailVO, err := vo.NewEmail(email)
+	if err != nil {
+		t.Fatalf("failed to create email: %v", err)
+	}
+	token, raw, err := entity.NewEmailVerificationToken(userID, emailVO)
+	if err != nil {
+		t.Fatalf("failed to create verification token: %v", err)
+	}
+	return token, raw
+}
+
+// TestUserUsecase_RequestEmailChange はメールアドレス変更申請のテスト
+func TestUserUsecase_RequestEmailChange(t *testing.T) {
+	password, _ := vo.NewPassword("password123")
+	newEmail, _ := vo.NewEmail("new@example.com")
+
+	t.Run("正常系_新しいアドレス宛てに確認メールが送信されメールアドレスは変わらない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), newEmail).Return(false, nil)
+		m.emailVerificationTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		var savedToken *entity.EmailVerificationToken
+		m.emailVerificationTokenRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, token *entity.EmailVerificationToken) error {
+				savedToken = token
+				return nil
+			})
+		var sentMail service.Mail
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, mail service.Mail) error {
+				sentMail = mail
+				return nil
+			})
+
+		err := newUserUsecase(m).RequestEmailChange(context.Background(), user.ID(), password, newEmail)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !savedToken.Email().Equals(newEmail) {
+			t.Errorf("token email = %s, want %s", savedToken.Email().String(), newEmail.String())
+		}
+		if sentMail.To != newEmail.String() {
+			t.Errorf("mail to = %s, want %s", sentMail.To, newEmail.String())
+		}
+		if user.Email().String() != "test@example.com" {
+			t.Error("email should not change until verified")
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionEmailChangeRequest {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionEmailChangeRequest)
+		}
+		// 監査ログにはマスクしたアドレスだけを残す
+		if got := m.auditEvents.events[0].Diff()["newEmail"]; got != "n***@example.com" {
+			t.Errorf("diff[newEmail] = %v, want n***@example.com", got)
+		}
+	})
+
+	t.Run("異常系_パスワードが一致しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		wrongPassword, _ := vo.NewPassword("wrongpassword")
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		err := newUserUsecase(m).RequestEmailChange(context.Background(), user.ID(), wrongPassword, newEmail)
+
+		if !errors.Is(err, domainErrors.ErrCurrentPasswordIncorrect) {
+			t.Errorf("got %v, want ErrCurrentPasswordIncorrect", err)
+		}
+	})
+
+	t.Run("異常系_現在と同じメールアドレス", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		err := newUserUsecase(m).RequestEmailChange(context.Background(), user.ID(), password, user.Email())
+
+		if !errors.Is(err, domainErrors.ErrEmailUnchanged) {
+			t.Errorf("got %v, want ErrEmailUnchanged", err)
+		}
+	})
+
+	t.Run("異常系_メールアドレスが既に使われている", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), newEmail).Return(true, nil)
+
+		err := newUserUsecase(m).RequestEmailChange(context.Background(), user.ID(), password, newEmail)
+
+		if !errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
+			t.Errorf("got %v, want ErrEmailAlreadyExists", err)
+		}
+	})
+}
+
+// TestUserUsecase_ResendEmailVerification は確認メール再送のテスト
+func TestUserUsecase_ResendEmailVerification(t *testing.T) {
+	t.Run("正常系_現在のアドレス宛てに再送される", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.emailVerificationTokenRepo.EXPECT().DeleteByUserID(gomock.Any(), user.ID()).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := newUserUsecase(m).ResendEmailVerification(context.Background(), user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("異常系_確認済み", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		user.ConfirmEmail(user.Email())
+
+		setupTxManagerExecute(m.txManager)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		err := newUserUsecase(m).ResendEmailVerification(context.Background(), user.ID())
+
+		if !errors.Is(err, domainErrors.ErrEmailAlreadyVerified) {
+			t.Errorf("got %v, want ErrEmailAlreadyVerified", err)
+		}
+	})
+}
+
+// TestUserUsecase_VerifyEmail はメールアドレス確認のテスト
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	t.Run("正常系_登録時のアドレスが確認済みになる", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		token, raw := verificationTokenFor(t, user.ID(), user.Email().String())
+
+		setupTxManagerExecute(m.txManager)
+		m.emailVerificationTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+
+		err := newUserUsecase(m).VerifyEmail(context.Background(), raw)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !user.IsEmailVerified() {
+			t.Error("email should be verified")
+		}
+	})
+
+	t.Run("正常系_変更先のアドレスに切り替わる", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		token, raw := verificationTokenFor(t, user.ID(), "new@example.com")
+
+		setupTxManagerExecute(m.txManager)
+		m.emailVerificationTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), token.Email()).Return(false, nil)
+		m.userRepo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		m.emailVerificationTokenRepo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+
+		err := newUserUsecase(m).VerifyEmail(context.Background(), raw)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Email().String() != "new@example.com" {
+			t.Errorf("email = %s, want new@example.com", user.Email().String())
+		}
+		if !user.IsEmailVerified() {
+			t.Error("email should be verified")
+		}
+		if len(m.auditEvents.events) != 1 || m.auditEvents.events[0].Action() != entity.AuditActionEmailVerify {
+			t.Fatalf("audit events = %v, want [%s]", m.auditEvents.actions(), entity.AuditActionEmailVerify)
+		}
+		wantDiff := map[string]any{"email": map[string]any{"old": "t***@example.com", "new": "n***@example.com"}}
+		if got := m.auditEvents.events[0].Diff(); !reflect.DeepEqual(got, wantDiff) {
+			t.Errorf("diff = %v, want %v", got, wantDiff)
+		}
+	})
+
+	t.Run("異常系_変更先のアドレスが確認前に他ユーザーに登録された", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		token, raw := verificationTokenFor(t, user.ID(), "new@example.com")
+
+		setupTxManagerExecute(m.txManager)
+		m.emailVerificationTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(token, nil)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+		m.userRepo.EXPECT().ExistsByEmail(gomock.Any(), token.Email()).Return(true, nil)
+
+		err := newUserUsecase(m).VerifyEmail(context.Background(), raw)
+
+		if !errors.Is(err, domainErrors.ErrEmailAlreadyExists) {
+			t.Errorf("got %v, want ErrEmailAlreadyExists", err)
+		}
+	})
+
+	t.Run("異常系_トークンが存在しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		raw, _ := vo.NewOneTimeToken()
+
+		setupTxManagerExecute(m.txManager)
+		m.emailVerificationTokenRepo.EXPECT().FindByTokenHash(gomock.Any(), raw.Hash()).Return(nil, nil)
+
+		err := newUserUsecase(m).VerifyEmail(context.Background(), raw)
+
+		if !errors.Is(err, domainErrors.ErrEmailVerificationTokenInvalid) {
+			t.Errorf("got %v, want ErrEmailVerificationTokenInvalid", err)
+		}
+	})
+}
+
+// TestUserUsecase_IsEmailVerified はメールアドレス確認状態取得のテスト
+func TestUserUsecase_IsEmailVerified(t *testing.T) {
+	t.Run("正常系_未確認のユーザー", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		user := validUser(t)
+		m.userRepo.EXPECT().FindByID(gomock.Any(), user.ID()).Return(user, nil)
+
+		verified, err := newUserUsecase(m).IsEmailVerified(context.Background(), user.ID())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verified {
+			t.Error("verified should be false")
+		}
+	})
+
+	t.Run("異常系_ユーザーが存在しない", func(t *testing.T) {
+		m, ctrl := setupUserMocks(t)
+		defer ctrl.Finish()
+
+		userID := vo.NewUserID()
+		m.userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := newUserUsecase(m).IsEmailVerified(context.Background(), userID)
+
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			t.Errorf("got %v, want ErrUserNotFound", err)
+		}
+	})
+}